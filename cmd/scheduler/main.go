@@ -16,8 +16,10 @@ import (
 	"time"
 
 	"github.com/example/enterprise-scheduler/internal/application"
+	"github.com/example/enterprise-scheduler/internal/caldav"
 	"github.com/example/enterprise-scheduler/internal/config"
 	httptransport "github.com/example/enterprise-scheduler/internal/http"
+	"github.com/example/enterprise-scheduler/internal/notification"
 	"github.com/example/enterprise-scheduler/internal/persistence"
 	"github.com/example/enterprise-scheduler/internal/persistence/sqlite"
 	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
@@ -57,35 +59,145 @@ func main() {
 	userRepo := newUserRepositoryAdapter(storage)
 	roomRepo := newRoomRepositoryAdapter(storage)
 	scheduleRepo := newScheduleRepositoryAdapter(storage)
+	scheduleRevisionRepo := newScheduleRevisionRepositoryAdapter(storage)
 	userDirectory := newUserDirectoryAdapter(storage)
 	roomCatalog := newRoomCatalogAdapter(storage)
 	recurrenceRepo := newRecurrenceRepositoryAdapter(storage, idGenerator)
 	sessionRepo := newSessionRepositoryAdapter(storage)
 	credentialStore := newCredentialStoreAdapter(storage)
-
-	scheduleService := application.NewScheduleServiceWithLogger(scheduleRepo, userDirectory, roomCatalog, recurrenceRepo, idGenerator, now, logger)
-	roomService := application.NewRoomServiceWithLogger(roomRepo, idGenerator, now, logger)
-	userService := application.NewUserServiceWithLogger(userRepo, idGenerator, now, logger)
+	availabilityRepo := newAvailabilityRepositoryAdapter(storage)
+	holidayRepo := newHolidayRepositoryAdapter(storage)
+	reminderRepo := newReminderRepositoryAdapter(storage)
+	webhookRepo := newWebhookRepositoryAdapter(storage)
+	appPasswordRepo := newAppPasswordRepositoryAdapter(storage)
+	calDAVRepo := newCalDAVObjectRepositoryAdapter(storage)
+	calDAVSyncRepo := newCalDAVSyncRepositoryAdapter(storage)
+	idempotencyStore := newIdempotencyStoreAdapter(storage)
+	occurrenceIndex := newOccurrenceIndexAdapter(storage)
+	colleagueGroupRepo := newColleagueGroupRepositoryAdapter(storage)
+	viewLinkRepo := newViewLinkRepositoryAdapter(storage)
+	calendarRepo := newCalendarRepositoryAdapter(storage)
+	userGroupRepo := newUserGroupRepositoryAdapter(storage)
+	departmentRepo := newDepartmentRepositoryAdapter(storage)
+
+	availabilityService := application.NewAvailabilityServiceWithLogger(availabilityRepo, userRepo, idGenerator, now, logger)
+	holidayService := application.NewHolidayServiceWithLogger(holidayRepo, now, logger).
+		WithOccurrenceIndex(occurrenceIndex)
+	webhookService := application.NewWebhookServiceWithLogger(webhookRepo, notification.NewSignedPoster(nil), idGenerator, tokenGenerator, now, logger)
+	colleagueGroupService := application.NewColleagueGroupServiceWithLogger(colleagueGroupRepo, userDirectory, idGenerator, now, logger)
+	calendarService := application.NewCalendarServiceWithLogger(calendarRepo, userDirectory, idGenerator, now, logger)
+	userGroupService := application.NewUserGroupServiceWithLogger(userGroupRepo, userDirectory, idGenerator, now, logger)
+	eventStreamService := application.NewEventStreamServiceWithLogger(webhookRepo, logger).
+		WithUserGroups(userGroupService)
+	orgService := application.NewOrgServiceWithLogger(departmentRepo, userRepo, idGenerator, now, logger)
+	scheduleService := application.NewScheduleServiceWithLogger(scheduleRepo, userDirectory, roomCatalog, recurrenceRepo, idGenerator, now, logger).
+		WithAvailability(availabilityService).
+		WithHolidays(holidayService).
+		WithEvents(webhookService).
+		WithChangeLog(scheduleRepo).
+		WithConflictCandidates(scheduleRepo).
+		WithTrash(scheduleRepo).
+		WithRevisions(scheduleRevisionRepo).
+		WithOccurrenceIndex(occurrenceIndex, cfg.OccurrenceHorizon).
+		WithColleagueGroups(colleagueGroupService).
+		WithCalendars(calendarService).
+		WithUserGroups(userGroupService).
+		WithOrg(orgService).
+		WithUnitOfWork(storage)
+	occurrenceIndexService := application.NewOccurrenceIndexServiceWithLogger(occurrenceIndex, scheduleRepo, recurrenceRepo, cfg.OccurrenceHorizon, now, logger).
+		WithHolidays(holidayService)
+	roomService := application.NewRoomServiceWithLogger(roomRepo, idGenerator, now, logger).
+		WithEvents(webhookService).
+		WithTrash(roomRepo).
+		WithUnitOfWork(storage)
+	trashService := application.NewTrashServiceWithLogger(scheduleRepo, roomRepo, cfg.TrashRetention, now, logger).
+		WithEvents(webhookService, scheduleRepo).
+		WithUnitOfWork(storage)
+	userService := application.NewUserServiceWithLogger(userRepo, idGenerator, now, logger).
+		WithEvents(webhookService).
+		WithDepartments(departmentRepo).
+		WithUnitOfWork(storage)
+	reminderService := application.NewReminderServiceWithLogger(reminderRepo, scheduleRepo, recurrenceRepo, userRepo, reminderChannels(cfg), idGenerator, now, logger).
+		WithHolidays(holidayService).
+		WithUserGroups(userGroupService)
 	authService := application.NewAuthServiceWithLogger(credentialStore, sessionRepo, nil, tokenGenerator, now, cfg.SessionTTL, logger)
+	appPasswordService := application.NewAppPasswordServiceWithLogger(appPasswordRepo, credentialStore, idGenerator, tokenGenerator, now, logger)
+	calendarViewService := application.NewCalendarViewServiceWithLogger(scheduleService, holidayService, availabilityService, now, logger).
+		WithUserGroups(userGroupService)
+	viewLinkService := application.NewViewLinkServiceWithLogger(viewLinkRepo, userDirectory, colleagueGroupService, func() string { return randomHex(4) }, now, logger)
+	calDAVService := application.NewCalDAVServiceWithLogger(scheduleService, calDAVRepo, recurrenceRepo, credentialStore, roomRepo, logger)
+	calDAVSyncService := application.NewCalDAVSyncServiceWithLogger(calDAVSyncRepo, caldav.NewClient(nil), calDAVService, credentialStore, idGenerator, now, logger)
+
+	if args := os.Args[1:]; len(args) > 0 {
+		if err := runCommand(ctx, args, occurrenceIndexService, logger); err != nil {
+			logger.Error("command failed", "command", args[0], "error", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	authHandler := httptransport.NewAuthHandler(authService, logger)
 	userHandler := httptransport.NewUserHandler(userService, logger)
 	roomHandler := httptransport.NewRoomHandler(roomService, logger)
 	scheduleHandler := httptransport.NewScheduleHandler(scheduleService, logger)
+	availabilityHandler := httptransport.NewAvailabilityHandler(availabilityService, logger)
+	holidayHandler := httptransport.NewHolidayHandler(holidayService, logger)
+	reminderHandler := httptransport.NewReminderHandler(reminderService, logger)
+	webhookHandler := httptransport.NewWebhookHandler(webhookService, logger)
+	eventStreamHandler := httptransport.NewEventStreamHandler(eventStreamService, logger)
+	appPasswordHandler := httptransport.NewAppPasswordHandler(appPasswordService, logger)
+	calDAVHandler := httptransport.NewCalDAVHandler(calDAVService, logger)
+	calDAVSyncHandler := httptransport.NewCalDAVSyncHandler(calDAVSyncService, logger)
+	trashHandler := httptransport.NewTrashHandler(trashService, logger)
+	calendarViewHandler := httptransport.NewCalendarViewHandler(calendarViewService, logger)
+	colleagueGroupHandler := httptransport.NewColleagueGroupHandler(colleagueGroupService, logger)
+	viewLinkHandler := httptransport.NewViewLinkHandler(viewLinkService, logger)
+	calendarHandler := httptransport.NewCalendarHandler(calendarService, logger)
+	userGroupHandler := httptransport.NewUserGroupHandler(userGroupService, logger)
+	departmentHandler := httptransport.NewDepartmentHandler(orgService, logger)
 
 	router := httptransport.NewRouter(httptransport.RouterConfig{
-		Auth:      authHandler,
-		Users:     userHandler,
-		Rooms:     roomHandler,
-		Schedules: scheduleHandler,
+		Auth:         authHandler,
+		Users:        userHandler,
+		Rooms:        roomHandler,
+		Schedules:    scheduleHandler,
+		Availability: availabilityHandler,
+		Holidays:     holidayHandler,
+		Reminders:    reminderHandler,
+		Webhooks:     webhookHandler,
+		Events:       eventStreamHandler,
+		AppPasswords: appPasswordHandler,
+		CalDAV:       calDAVHandler,
+		CalDAVSyncs:  calDAVSyncHandler,
+		Trash:        trashHandler,
+		Views:        calendarViewHandler,
+		Groups:       colleagueGroupHandler,
+		Links:        viewLinkHandler,
+		Calendars:    calendarHandler,
+		UserGroups:   userGroupHandler,
+		Departments:  departmentHandler,
 	})
 
-	protected := httptransport.RequireSession(authService, logger)(router)
+	// Idempotency keys are scoped to the principal, so the middleware runs
+	// inside the session check.
+	idempotent := httptransport.RequireIdempotency(idempotencyStore, cfg.IdempotencyKeyTTL, logger)(router)
+	protected := httptransport.RequireSession(authService, logger)(idempotent)
+	// Calendar clients cannot hold a session, so /dav/ uses basic auth with
+	// app passwords instead.
+	calDAVProtected := httptransport.RequireBasicAuth(appPasswordService, "Enterprise Scheduler", logger)(router)
 	handler := httptransport.RequestLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost && strings.EqualFold(r.URL.Path, "/sessions") {
 			router.ServeHTTP(w, r)
 			return
 		}
+		if r.URL.Path == httptransport.CalDAVWellKnownPath {
+			router.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, httptransport.CalDAVPrefix) {
+			calDAVProtected.ServeHTTP(w, r)
+			return
+		}
 		protected.ServeHTTP(w, r)
 	}))
 
@@ -98,6 +210,15 @@ func main() {
 		IdleTimeout:       60 * time.Second,
 	}
 
+	go reminderService.Run(ctx, cfg.ReminderInterval)
+	go webhookService.Run(ctx, cfg.WebhookInterval)
+	// Closes open event streams on shutdown so server.Shutdown is not held up
+	// by long-lived connections.
+	go eventStreamService.Run(ctx, cfg.EventStreamInterval)
+	go calDAVSyncService.Run(ctx, cfg.CalDAVSyncInterval)
+	go trashService.Run(ctx, cfg.TrashPurgeInterval)
+	go occurrenceIndexService.Run(ctx, cfg.OccurrenceRefreshInterval)
+
 	go func() {
 		<-ctx.Done()
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -114,6 +235,40 @@ func main() {
 	}
 }
 
+// runCommand runs a maintenance command given on the command line instead of
+// serving the API. rebuild-occurrences regenerates the occurrence index from
+// the stored recurrence rules.
+func runCommand(ctx context.Context, args []string, occurrenceIndex *application.OccurrenceIndexService, logger *slog.Logger) error {
+	switch args[0] {
+	case "rebuild-occurrences":
+		rebuilt, err := occurrenceIndex.Rebuild(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("occurrence index rebuilt", "schedule_count", rebuilt)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// reminderChannels builds the reminder delivery channels enabled by configuration.
+func reminderChannels(cfg config.Config) []notification.Channel {
+	var channels []notification.Channel
+	if cfg.SMTPAddr != "" {
+		channels = append(channels, notification.NewSMTPChannel(notification.SMTPConfig{
+			Addr:     cfg.SMTPAddr,
+			From:     cfg.SMTPFrom,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+		}))
+	}
+	if cfg.ReminderWebhookURL != "" {
+		channels = append(channels, notification.NewWebhookChannel(cfg.ReminderWebhookURL, nil))
+	}
+	return channels
+}
+
 func randomHex(bytes int) string {
 	if bytes <= 0 {
 		bytes = 16
@@ -284,6 +439,25 @@ func (a *userRepositoryAdapter) ListUsers(ctx context.Context) ([]application.Us
 	return users, nil
 }
 
+func (a *userRepositoryAdapter) ListUsersPage(ctx context.Context, filter application.UserRepositoryFilter) ([]application.User, error) {
+	models, err := a.repo.ListUsersPage(ctx, persistence.UserFilter{
+		AfterEmail: filter.AfterEmail,
+		AfterID:    filter.AfterID,
+		Limit:      filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	users := make([]application.User, 0, len(models))
+	for _, model := range models {
+		users = append(users, toApplicationUser(model))
+	}
+	return users, nil
+}
+
 type roomRepositoryAdapter struct {
 	repo persistence.RoomRepository
 }
@@ -326,6 +500,46 @@ func (a *roomRepositoryAdapter) DeleteRoom(ctx context.Context, id string) error
 	return a.repo.DeleteRoom(ctx, id)
 }
 
+func (a *roomRepositoryAdapter) TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	return a.repo.TrashRoom(ctx, id, deletedBy, deletedAt)
+}
+
+func (a *roomRepositoryAdapter) RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error {
+	return a.repo.RestoreRoom(ctx, id, restoredAt)
+}
+
+func (a *roomRepositoryAdapter) GetTrashedRoom(ctx context.Context, id string) (application.TrashedRoom, error) {
+	stored, err := a.repo.GetTrashedRoom(ctx, id)
+	if err != nil {
+		return application.TrashedRoom{}, err
+	}
+	return toApplicationTrashedRoom(stored), nil
+}
+
+func (a *roomRepositoryAdapter) ListTrashedRooms(ctx context.Context, deletedBy string) ([]application.TrashedRoom, error) {
+	models, err := a.repo.ListTrashedRooms(ctx, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	rooms := make([]application.TrashedRoom, 0, len(models))
+	for _, model := range models {
+		rooms = append(rooms, toApplicationTrashedRoom(model))
+	}
+	return rooms, nil
+}
+
+func (a *roomRepositoryAdapter) PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return a.repo.PurgeRooms(ctx, deletedBefore)
+}
+
+func toApplicationTrashedRoom(model persistence.Room) application.TrashedRoom {
+	trashed := application.TrashedRoom{Room: toApplicationRoom(model), DeletedBy: model.DeletedBy}
+	if model.DeletedAt != nil {
+		trashed.DeletedAt = *model.DeletedAt
+	}
+	return trashed
+}
+
 func (a *roomRepositoryAdapter) ListRooms(ctx context.Context) ([]application.Room, error) {
 	models, err := a.repo.ListRooms(ctx)
 	if err != nil {
@@ -341,6 +555,25 @@ func (a *roomRepositoryAdapter) ListRooms(ctx context.Context) ([]application.Ro
 	return rooms, nil
 }
 
+func (a *roomRepositoryAdapter) ListRoomsPage(ctx context.Context, filter application.RoomRepositoryFilter) ([]application.Room, error) {
+	models, err := a.repo.ListRoomsPage(ctx, persistence.RoomFilter{
+		AfterName: filter.AfterName,
+		AfterID:   filter.AfterID,
+		Limit:     filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	rooms := make([]application.Room, 0, len(models))
+	for _, model := range models {
+		rooms = append(rooms, toApplicationRoom(model))
+	}
+	return rooms, nil
+}
+
 type scheduleRepositoryAdapter struct {
 	repo persistence.ScheduleRepository
 }
@@ -383,11 +616,62 @@ func (a *scheduleRepositoryAdapter) DeleteSchedule(ctx context.Context, id strin
 	return a.repo.DeleteSchedule(ctx, id)
 }
 
+func (a *scheduleRepositoryAdapter) TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	return a.repo.TrashSchedule(ctx, id, deletedBy, deletedAt)
+}
+
+func (a *scheduleRepositoryAdapter) RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error {
+	return a.repo.RestoreSchedule(ctx, id, restoredAt)
+}
+
+func (a *scheduleRepositoryAdapter) GetTrashedSchedule(ctx context.Context, id string) (application.TrashedSchedule, error) {
+	stored, err := a.repo.GetTrashedSchedule(ctx, id)
+	if err != nil {
+		return application.TrashedSchedule{}, err
+	}
+	return toApplicationTrashedSchedule(stored), nil
+}
+
+func (a *scheduleRepositoryAdapter) ListTrashedSchedules(ctx context.Context, deletedBy string) ([]application.TrashedSchedule, error) {
+	models, err := a.repo.ListTrashedSchedules(ctx, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]application.TrashedSchedule, 0, len(models))
+	for _, model := range models {
+		schedules = append(schedules, toApplicationTrashedSchedule(model))
+	}
+	return schedules, nil
+}
+
+func (a *scheduleRepositoryAdapter) PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return a.repo.PurgeSchedules(ctx, deletedBefore)
+}
+
+func toApplicationTrashedSchedule(model persistence.Schedule) application.TrashedSchedule {
+	trashed := application.TrashedSchedule{Schedule: toApplicationSchedule(model), DeletedBy: model.DeletedBy}
+	if model.DeletedAt != nil {
+		trashed.DeletedAt = *model.DeletedAt
+	}
+	return trashed
+}
+
 func (a *scheduleRepositoryAdapter) ListSchedules(ctx context.Context, filter application.ScheduleRepositoryFilter) ([]application.Schedule, error) {
 	persistedFilter := persistence.ScheduleFilter{
-		ParticipantIDs: append([]string(nil), filter.ParticipantIDs...),
-		StartsAfter:    filter.StartsAfter,
-		EndsBefore:     filter.EndsBefore,
+		IDs:               append([]string(nil), filter.IDs...),
+		ParticipantIDs:    append([]string(nil), filter.ParticipantIDs...),
+		StartsAfter:       filter.StartsAfter,
+		EndsBefore:        filter.EndsBefore,
+		AllDayStartsAfter: cloneTime(filter.AllDayStartsAfter),
+		AllDayEndsBefore:  cloneTime(filter.AllDayEndsBefore),
+		RoomID:            cloneString(filter.RoomID),
+		CalendarID:        filter.CalendarID,
+		CreatorID:         filter.CreatorID,
+		TitlePrefix:       filter.TitlePrefix,
+		UpdatedSince:      cloneTime(filter.UpdatedSince),
+		AfterStart:        cloneTime(filter.AfterStart),
+		AfterID:           filter.AfterID,
+		Limit:             filter.Limit,
 	}
 	models, err := a.repo.ListSchedules(ctx, persistedFilter)
 	if err != nil {
@@ -403,6 +687,45 @@ func (a *scheduleRepositoryAdapter) ListSchedules(ctx context.Context, filter ap
 	return schedules, nil
 }
 
+func (a *scheduleRepositoryAdapter) ListConflictCandidates(ctx context.Context, filter application.ConflictCandidateFilter) ([]application.Schedule, error) {
+	models, err := a.repo.ListConflictCandidates(ctx, persistence.ScheduleConflictFilter{
+		ParticipantIDs: append([]string(nil), filter.ParticipantIDs...),
+		RoomID:         cloneString(filter.RoomID),
+		Start:          filter.Start,
+		End:            filter.End,
+		ExcludeID:      filter.ExcludeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]application.Schedule, 0, len(models))
+	for _, model := range models {
+		schedules = append(schedules, toApplicationSchedule(model))
+	}
+	return schedules, nil
+}
+
+func (a *scheduleRepositoryAdapter) ListScheduleChanges(ctx context.Context, filter application.ScheduleChangeFilter) ([]application.ScheduleChange, error) {
+	models, err := a.repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{
+		UserIDs:       append([]string(nil), filter.UserIDs...),
+		AfterSequence: filter.AfterSequence,
+		Limit:         filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]application.ScheduleChange, 0, len(models))
+	for _, model := range models {
+		changes = append(changes, application.ScheduleChange{
+			Sequence:   model.Sequence,
+			ScheduleID: model.ScheduleID,
+			Deleted:    model.Deleted,
+			ChangedAt:  model.ChangedAt,
+		})
+	}
+	return changes, nil
+}
+
 type userDirectoryAdapter struct {
 	repo persistence.UserRepository
 }
@@ -466,21 +789,42 @@ func (a *recurrenceRepositoryAdapter) SaveRecurrence(ctx context.Context, schedu
 
 	now := time.Now().UTC()
 	rule := persistence.RecurrenceRule{
-		ID:         a.idGenerator(),
-		ScheduleID: scheduleID,
-		Frequency:  toPersistenceFrequency(recurrence.Frequency),
-		Weekdays:   weekdays,
-		StartsOn:   start,
-		EndsOn:     recurrence.Until,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		ID:            a.idGenerator(),
+		ScheduleID:    scheduleID,
+		Frequency:     toPersistenceFrequency(recurrence.Frequency),
+		Weekdays:      weekdays,
+		StartsOn:      start,
+		EndsOn:        recurrence.Until,
+		HolidayPolicy: string(recurrence.HolidayPolicy),
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 	return a.repo.UpsertRecurrence(ctx, rule)
 }
 
 func (a *recurrenceRepositoryAdapter) ListRecurrencesForSchedules(ctx context.Context, scheduleIDs []string) (map[string][]application.RecurrenceRule, error) {
-	// This is a simplified implementation for now.
-	return nil, nil
+	rulesBySchedule := make(map[string][]application.RecurrenceRule)
+	for _, scheduleID := range scheduleIDs {
+		models, err := a.repo.ListRecurrencesForSchedule(ctx, scheduleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, model := range models {
+			weekdays := make([]string, 0, len(model.Weekdays))
+			for _, day := range model.Weekdays {
+				weekdays = append(weekdays, strings.ToLower(day.String()))
+			}
+			rulesBySchedule[scheduleID] = append(rulesBySchedule[scheduleID], application.RecurrenceRule{
+				ID:            model.ID,
+				Frequency:     toApplicationFrequency(model.Frequency),
+				Weekdays:      weekdays,
+				Until:         model.EndsOn,
+				StartsOn:      model.StartsOn,
+				HolidayPolicy: application.HolidayPolicy(model.HolidayPolicy),
+			})
+		}
+	}
+	return rulesBySchedule, nil
 }
 
 func (a *recurrenceRepositoryAdapter) DeleteRecurrencesForSchedule(ctx context.Context, scheduleID string) error {
@@ -517,6 +861,140 @@ func toPersistenceFrequency(freq string) int {
 	return 1 // Default to weekly
 }
 
+func toApplicationFrequency(freq int) string {
+	if freq == 0 {
+		return "daily"
+	}
+	return "weekly"
+}
+
+type scheduleRevisionRepositoryAdapter struct {
+	repo persistence.ScheduleRevisionRepository
+}
+
+func newScheduleRevisionRepositoryAdapter(repo persistence.ScheduleRevisionRepository) *scheduleRevisionRepositoryAdapter {
+	return &scheduleRevisionRepositoryAdapter{repo: repo}
+}
+
+func (a *scheduleRevisionRepositoryAdapter) AppendScheduleRevision(ctx context.Context, record application.ScheduleRevisionRecord) (int, error) {
+	return a.repo.AppendScheduleRevision(ctx, persistence.ScheduleRevision{
+		ScheduleID: record.ScheduleID,
+		ChangedBy:  record.ChangedBy,
+		ChangedAt:  record.ChangedAt,
+		Snapshot:   record.Snapshot,
+	})
+}
+
+func (a *scheduleRevisionRepositoryAdapter) ListScheduleRevisions(ctx context.Context, scheduleID string) ([]application.ScheduleRevisionRecord, error) {
+	stored, err := a.repo.ListScheduleRevisions(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]application.ScheduleRevisionRecord, 0, len(stored))
+	for _, revision := range stored {
+		records = append(records, toApplicationScheduleRevision(revision))
+	}
+	return records, nil
+}
+
+func (a *scheduleRevisionRepositoryAdapter) GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (application.ScheduleRevisionRecord, error) {
+	stored, err := a.repo.GetScheduleRevision(ctx, scheduleID, revision)
+	if err != nil {
+		return application.ScheduleRevisionRecord{}, err
+	}
+	return toApplicationScheduleRevision(stored), nil
+}
+
+func toApplicationScheduleRevision(model persistence.ScheduleRevision) application.ScheduleRevisionRecord {
+	return application.ScheduleRevisionRecord{
+		ScheduleID: model.ScheduleID,
+		Revision:   model.Revision,
+		ChangedBy:  model.ChangedBy,
+		ChangedAt:  model.ChangedAt,
+		Snapshot:   model.Snapshot,
+	}
+}
+
+type idempotencyStoreAdapter struct {
+	repo persistence.IdempotencyRepository
+}
+
+func newIdempotencyStoreAdapter(repo persistence.IdempotencyRepository) *idempotencyStoreAdapter {
+	return &idempotencyStoreAdapter{repo: repo}
+}
+
+func (a *idempotencyStoreAdapter) ReserveIdempotencyKey(ctx context.Context, response httptransport.IdempotentResponse) (httptransport.IdempotentResponse, bool, error) {
+	stored, reserved, err := a.repo.ReserveIdempotencyKey(ctx, persistence.IdempotencyRecord(response))
+	if err != nil {
+		return httptransport.IdempotentResponse{}, false, err
+	}
+	return httptransport.IdempotentResponse(stored), reserved, nil
+}
+
+func (a *idempotencyStoreAdapter) CompleteIdempotencyKey(ctx context.Context, response httptransport.IdempotentResponse) error {
+	return a.repo.CompleteIdempotencyKey(ctx, persistence.IdempotencyRecord(response))
+}
+
+func (a *idempotencyStoreAdapter) ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error {
+	return a.repo.ReleaseIdempotencyKey(ctx, principalID, key)
+}
+
+type occurrenceIndexAdapter struct {
+	repo persistence.OccurrenceRepository
+}
+
+func newOccurrenceIndexAdapter(repo persistence.OccurrenceRepository) *occurrenceIndexAdapter {
+	return &occurrenceIndexAdapter{repo: repo}
+}
+
+func (a *occurrenceIndexAdapter) MarkOccurrencesStale(ctx context.Context, scheduleID string) error {
+	return a.repo.MarkOccurrencesStale(ctx, scheduleID)
+}
+
+func (a *occurrenceIndexAdapter) MarkHolidayOccurrencesStale(ctx context.Context) error {
+	return a.repo.MarkHolidayOccurrencesStale(ctx)
+}
+
+func (a *occurrenceIndexAdapter) ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]application.OccurrenceRefresh, error) {
+	models, err := a.repo.ListOccurrenceRefreshes(ctx, horizon, limit)
+	if err != nil {
+		return nil, err
+	}
+	refreshes := make([]application.OccurrenceRefresh, 0, len(models))
+	for _, model := range models {
+		refreshes = append(refreshes, application.OccurrenceRefresh(model))
+	}
+	return refreshes, nil
+}
+
+func (a *occurrenceIndexAdapter) ReplaceOccurrences(ctx context.Context, refresh application.OccurrenceRefresh, until time.Time, occurrences []application.ScheduleOccurrence) error {
+	models := make([]persistence.Occurrence, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		models = append(models, persistence.Occurrence(occurrence))
+	}
+	return a.repo.ReplaceOccurrences(ctx, persistence.OccurrenceRefresh(refresh), until, models)
+}
+
+func (a *occurrenceIndexAdapter) ListOccurrences(ctx context.Context, filter application.OccurrenceFilter) (map[string][]application.ScheduleOccurrence, error) {
+	models, err := a.repo.ListOccurrences(ctx, persistence.OccurrenceFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	occurrences := make(map[string][]application.ScheduleOccurrence, len(models))
+	for scheduleID, list := range models {
+		converted := make([]application.ScheduleOccurrence, 0, len(list))
+		for _, model := range list {
+			converted = append(converted, application.ScheduleOccurrence(model))
+		}
+		occurrences[scheduleID] = converted
+	}
+	return occurrences, nil
+}
+
+func (a *occurrenceIndexAdapter) ResetOccurrences(ctx context.Context) (int, error) {
+	return a.repo.ResetOccurrences(ctx)
+}
+
 type sessionRepositoryAdapter struct {
 	repo persistence.SessionRepository
 }
@@ -591,101 +1069,1071 @@ func (a *credentialStoreAdapter) GetUser(ctx context.Context, id string) (applic
 	return toApplicationUser(stored), nil
 }
 
-func toApplicationUser(model persistence.User) application.User {
-	return application.User{
-		ID:          model.ID,
-		Email:       model.Email,
-		DisplayName: model.DisplayName,
-		IsAdmin:     model.IsAdmin,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
-	}
+type availabilityRepositoryAdapter struct {
+	repo persistence.AvailabilityRepository
 }
 
-func toPersistenceUser(user application.User, passwordHash string) persistence.User {
-	if passwordHash == "" {
-		passwordHash = user.ID
+func newAvailabilityRepositoryAdapter(repo persistence.AvailabilityRepository) *availabilityRepositoryAdapter {
+	return &availabilityRepositoryAdapter{repo: repo}
+}
+
+func (a *availabilityRepositoryAdapter) ListWorkingHours(ctx context.Context, userIDs []string) (map[string][]application.WorkingHours, error) {
+	models, err := a.repo.ListWorkingHours(ctx, userIDs)
+	if err != nil {
+		return nil, err
 	}
-	return persistence.User{
-		ID:           user.ID,
-		Email:        user.Email,
-		DisplayName:  user.DisplayName,
-		PasswordHash: passwordHash,
-		IsAdmin:      user.IsAdmin,
-		CreatedAt:    user.CreatedAt,
-		UpdatedAt:    user.UpdatedAt,
+	hours := make(map[string][]application.WorkingHours)
+	for _, model := range models {
+		hours[model.UserID] = append(hours[model.UserID], application.WorkingHours{
+			Weekday:     model.Weekday,
+			StartMinute: model.StartMinute,
+			EndMinute:   model.EndMinute,
+		})
 	}
+	return hours, nil
 }
 
-func toApplicationRoom(model persistence.Room) application.Room {
-	return application.Room{
-		ID:         model.ID,
-		Name:       model.Name,
-		Location:   model.Location,
-		Capacity:   model.Capacity,
-		Facilities: cloneString(model.Facilities),
-		CreatedAt:  model.CreatedAt,
-		UpdatedAt:  model.UpdatedAt,
+func (a *availabilityRepositoryAdapter) ReplaceWorkingHours(ctx context.Context, userID string, hours []application.WorkingHours) error {
+	models := make([]persistence.WorkingHours, 0, len(hours))
+	for _, window := range hours {
+		models = append(models, persistence.WorkingHours{
+			UserID:      userID,
+			Weekday:     window.Weekday,
+			StartMinute: window.StartMinute,
+			EndMinute:   window.EndMinute,
+		})
+	}
+	return a.repo.ReplaceWorkingHours(ctx, userID, models)
+}
+
+func (a *availabilityRepositoryAdapter) CreateOutOfOffice(ctx context.Context, entry application.OutOfOffice) (application.OutOfOffice, error) {
+	if err := a.repo.CreateOutOfOffice(ctx, toPersistenceOutOfOffice(entry)); err != nil {
+		return application.OutOfOffice{}, err
+	}
+	stored, err := a.repo.GetOutOfOffice(ctx, entry.ID)
+	if err != nil {
+		return application.OutOfOffice{}, err
 	}
+	return toApplicationOutOfOffice(stored), nil
 }
 
-func toPersistenceRoom(room application.Room) persistence.Room {
-	return persistence.Room{
-		ID:         room.ID,
-		Name:       room.Name,
-		Location:   room.Location,
-		Capacity:   room.Capacity,
-		Facilities: cloneString(room.Facilities),
-		CreatedAt:  room.CreatedAt,
-		UpdatedAt:  room.UpdatedAt,
+func (a *availabilityRepositoryAdapter) GetOutOfOffice(ctx context.Context, id string) (application.OutOfOffice, error) {
+	stored, err := a.repo.GetOutOfOffice(ctx, id)
+	if err != nil {
+		return application.OutOfOffice{}, err
 	}
+	return toApplicationOutOfOffice(stored), nil
 }
 
-func toApplicationSchedule(model persistence.Schedule) application.Schedule {
-	description := ""
-	if model.Memo != nil {
-		description = *model.Memo
+func (a *availabilityRepositoryAdapter) DeleteOutOfOffice(ctx context.Context, id string) error {
+	return a.repo.DeleteOutOfOffice(ctx, id)
+}
+
+func (a *availabilityRepositoryAdapter) ListOutOfOffice(ctx context.Context, filter application.OutOfOfficeFilter) ([]application.OutOfOffice, error) {
+	models, err := a.repo.ListOutOfOffice(ctx, persistence.OutOfOfficeFilter{
+		UserIDs:     append([]string(nil), filter.UserIDs...),
+		StartsAfter: cloneTime(filter.StartsAfter),
+		EndsBefore:  cloneTime(filter.EndsBefore),
+	})
+	if err != nil {
+		return nil, err
 	}
-	webURL := ""
-	if model.WebConferenceURL != nil {
-		webURL = *model.WebConferenceURL
+	if len(models) == 0 {
+		return nil, nil
 	}
-	return application.Schedule{
-		ID:               model.ID,
-		CreatorID:        model.CreatorID,
-		Title:            model.Title,
-		Description:      description,
-		Start:            model.Start,
-		End:              model.End,
-		RoomID:           cloneString(model.RoomID),
-		WebConferenceURL: webURL,
-		ParticipantIDs:   append([]string(nil), model.Participants...),
-		CreatedAt:        model.CreatedAt,
-		UpdatedAt:        model.UpdatedAt,
+	entries := make([]application.OutOfOffice, 0, len(models))
+	for _, model := range models {
+		entries = append(entries, toApplicationOutOfOffice(model))
 	}
+	return entries, nil
 }
 
-func toPersistenceSchedule(schedule application.Schedule) persistence.Schedule {
-	var memo *string
-	if strings.TrimSpace(schedule.Description) != "" {
-		memo = cloneString(&schedule.Description)
+type holidayRepositoryAdapter struct {
+	repo persistence.HolidayRepository
+}
+
+func newHolidayRepositoryAdapter(repo persistence.HolidayRepository) *holidayRepositoryAdapter {
+	return &holidayRepositoryAdapter{repo: repo}
+}
+
+func (a *holidayRepositoryAdapter) ListCompanyHolidays(ctx context.Context) ([]application.Holiday, error) {
+	models, err := a.repo.ListCompanyHolidays(ctx)
+	if err != nil {
+		return nil, err
 	}
-	var web *string
-	if strings.TrimSpace(schedule.WebConferenceURL) != "" {
-		web = cloneString(&schedule.WebConferenceURL)
+	if len(models) == 0 {
+		return nil, nil
 	}
-	return persistence.Schedule{
-		ID:               schedule.ID,
-		Title:            schedule.Title,
-		Start:            schedule.Start,
-		End:              schedule.End,
-		CreatorID:        schedule.CreatorID,
-		Memo:             memo,
-		Participants:     append([]string(nil), schedule.ParticipantIDs...),
-		RoomID:           cloneString(schedule.RoomID),
-		WebConferenceURL: web,
-		CreatedAt:        schedule.CreatedAt,
-		UpdatedAt:        schedule.UpdatedAt,
+	holidays := make([]application.Holiday, 0, len(models))
+	for _, model := range models {
+		holidays = append(holidays, application.Holiday{Date: model.Date, Name: model.Name, Kind: "company"})
+	}
+	return holidays, nil
+}
+
+func (a *holidayRepositoryAdapter) CreateCompanyHoliday(ctx context.Context, closure application.Holiday) (application.Holiday, error) {
+	if err := a.repo.CreateCompanyHoliday(ctx, persistence.CompanyHoliday{Date: closure.Date, Name: closure.Name, CreatedAt: time.Now()}); err != nil {
+		return application.Holiday{}, err
+	}
+	return closure, nil
+}
+
+func (a *holidayRepositoryAdapter) DeleteCompanyHoliday(ctx context.Context, date time.Time) error {
+	return a.repo.DeleteCompanyHoliday(ctx, date)
+}
+
+type reminderRepositoryAdapter struct {
+	repo persistence.ReminderRepository
+}
+
+func newReminderRepositoryAdapter(repo persistence.ReminderRepository) *reminderRepositoryAdapter {
+	return &reminderRepositoryAdapter{repo: repo}
+}
+
+func (a *reminderRepositoryAdapter) GetReminderPreferences(ctx context.Context, userID string) (application.ReminderPreferences, error) {
+	model, err := a.repo.GetReminderPreferences(ctx, userID)
+	if err != nil {
+		return application.ReminderPreferences{}, err
+	}
+	return toApplicationReminderPreferences(model), nil
+}
+
+func (a *reminderRepositoryAdapter) SaveReminderPreferences(ctx context.Context, prefs application.ReminderPreferences) error {
+	return a.repo.SaveReminderPreferences(ctx, persistence.ReminderPreferences{
+		UserID:        prefs.UserID,
+		MinutesBefore: append([]int(nil), prefs.MinutesBefore...),
+		Channels:      append([]string(nil), prefs.Channels...),
+		UpdatedAt:     prefs.UpdatedAt,
+	})
+}
+
+func (a *reminderRepositoryAdapter) ListReminderPreferences(ctx context.Context, userIDs []string) (map[string]application.ReminderPreferences, error) {
+	models, err := a.repo.ListReminderPreferences(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	prefsByUser := make(map[string]application.ReminderPreferences, len(models))
+	for _, model := range models {
+		prefsByUser[model.UserID] = toApplicationReminderPreferences(model)
+	}
+	return prefsByUser, nil
+}
+
+func (a *reminderRepositoryAdapter) ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error) {
+	return a.repo.ListRecurringScheduleIDs(ctx, activeFrom)
+}
+
+func (a *reminderRepositoryAdapter) ClaimReminderDelivery(ctx context.Context, delivery application.ReminderDelivery, maxAttempts int, lease time.Duration) (application.ReminderDelivery, bool, error) {
+	stored, claimed, err := a.repo.ClaimReminderDelivery(ctx, toPersistenceReminderDelivery(delivery), maxAttempts, lease)
+	if err != nil {
+		return application.ReminderDelivery{}, false, err
+	}
+	return toApplicationReminderDelivery(stored), claimed, nil
+}
+
+func (a *reminderRepositoryAdapter) FinishReminderDelivery(ctx context.Context, delivery application.ReminderDelivery) error {
+	return a.repo.FinishReminderDelivery(ctx, toPersistenceReminderDelivery(delivery))
+}
+
+func toApplicationReminderPreferences(model persistence.ReminderPreferences) application.ReminderPreferences {
+	return application.ReminderPreferences{
+		UserID:        model.UserID,
+		MinutesBefore: append([]int(nil), model.MinutesBefore...),
+		Channels:      append([]string{}, model.Channels...),
+		UpdatedAt:     model.UpdatedAt,
+	}
+}
+
+func toApplicationReminderDelivery(model persistence.ReminderDelivery) application.ReminderDelivery {
+	return application.ReminderDelivery{
+		ID:              model.ID,
+		ScheduleID:      model.ScheduleID,
+		OccurrenceStart: model.OccurrenceStart,
+		UserID:          model.UserID,
+		MinutesBefore:   model.MinutesBefore,
+		Channel:         model.Channel,
+		Status:          application.ReminderDeliveryStatus(model.Status),
+		Attempts:        model.Attempts,
+		LastError:       model.LastError,
+		DueAt:           model.DueAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+func toPersistenceReminderDelivery(delivery application.ReminderDelivery) persistence.ReminderDelivery {
+	return persistence.ReminderDelivery{
+		ID:              delivery.ID,
+		ScheduleID:      delivery.ScheduleID,
+		OccurrenceStart: delivery.OccurrenceStart,
+		UserID:          delivery.UserID,
+		MinutesBefore:   delivery.MinutesBefore,
+		Channel:         delivery.Channel,
+		Status:          string(delivery.Status),
+		Attempts:        delivery.Attempts,
+		LastError:       delivery.LastError,
+		DueAt:           delivery.DueAt,
+		UpdatedAt:       delivery.UpdatedAt,
+	}
+}
+
+type webhookRepositoryAdapter struct {
+	repo persistence.WebhookRepository
+}
+
+func newWebhookRepositoryAdapter(repo persistence.WebhookRepository) *webhookRepositoryAdapter {
+	return &webhookRepositoryAdapter{repo: repo}
+}
+
+func (a *webhookRepositoryAdapter) CreateWebhook(ctx context.Context, subscription application.WebhookSubscription) error {
+	return a.repo.CreateWebhook(ctx, toPersistenceWebhookSubscription(subscription))
+}
+
+func (a *webhookRepositoryAdapter) GetWebhook(ctx context.Context, id string) (application.WebhookSubscription, error) {
+	model, err := a.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return application.WebhookSubscription{}, err
+	}
+	return toApplicationWebhookSubscription(model), nil
+}
+
+func (a *webhookRepositoryAdapter) UpdateWebhook(ctx context.Context, subscription application.WebhookSubscription) error {
+	return a.repo.UpdateWebhook(ctx, toPersistenceWebhookSubscription(subscription))
+}
+
+func (a *webhookRepositoryAdapter) DeleteWebhook(ctx context.Context, id string) error {
+	return a.repo.DeleteWebhook(ctx, id)
+}
+
+func (a *webhookRepositoryAdapter) ListWebhooks(ctx context.Context) ([]application.WebhookSubscription, error) {
+	models, err := a.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := make([]application.WebhookSubscription, len(models))
+	for i, model := range models {
+		subscriptions[i] = toApplicationWebhookSubscription(model)
+	}
+	return subscriptions, nil
+}
+
+func (a *webhookRepositoryAdapter) AppendOutboxEvent(ctx context.Context, event application.OutboxEvent) error {
+	return a.repo.AppendOutboxEvent(ctx, persistence.OutboxEvent{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		ResourceID: event.ResourceID,
+		ActorID:    event.ActorID,
+		Payload:    append([]byte(nil), event.Payload...),
+		OccurredAt: event.OccurredAt,
+	})
+}
+
+func (a *webhookRepositoryAdapter) ListPendingOutboxEvents(ctx context.Context, limit int) ([]application.OutboxEvent, error) {
+	models, err := a.repo.ListPendingOutboxEvents(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toApplicationOutboxEvents(models), nil
+}
+
+func (a *webhookRepositoryAdapter) ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]application.OutboxEvent, error) {
+	models, err := a.repo.ListOutboxEventsAfter(ctx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toApplicationOutboxEvents(models), nil
+}
+
+func (a *webhookRepositoryAdapter) LatestOutboxSequence(ctx context.Context) (int64, error) {
+	return a.repo.LatestOutboxSequence(ctx)
+}
+
+func (a *webhookRepositoryAdapter) EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []application.WebhookDelivery, dispatchedAt time.Time) error {
+	models := make([]persistence.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		models[i] = toPersistenceWebhookDelivery(delivery)
+	}
+	return a.repo.EnqueueWebhookDeliveries(ctx, eventID, models, dispatchedAt)
+}
+
+func (a *webhookRepositoryAdapter) CreateWebhookDelivery(ctx context.Context, delivery application.WebhookDelivery) error {
+	return a.repo.CreateWebhookDelivery(ctx, toPersistenceWebhookDelivery(delivery))
+}
+
+func (a *webhookRepositoryAdapter) GetWebhookDelivery(ctx context.Context, id string) (application.WebhookDelivery, error) {
+	model, err := a.repo.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return application.WebhookDelivery{}, err
+	}
+	return toApplicationWebhookDelivery(model), nil
+}
+
+func (a *webhookRepositoryAdapter) UpdateWebhookDelivery(ctx context.Context, delivery application.WebhookDelivery) error {
+	return a.repo.UpdateWebhookDelivery(ctx, toPersistenceWebhookDelivery(delivery))
+}
+
+func (a *webhookRepositoryAdapter) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]application.WebhookDelivery, error) {
+	models, err := a.repo.ListDueWebhookDeliveries(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toApplicationWebhookDeliveries(models), nil
+}
+
+func (a *webhookRepositoryAdapter) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]application.WebhookDelivery, error) {
+	models, err := a.repo.ListWebhookDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toApplicationWebhookDeliveries(models), nil
+}
+
+type appPasswordRepositoryAdapter struct {
+	repo persistence.AppPasswordRepository
+}
+
+func newAppPasswordRepositoryAdapter(repo persistence.AppPasswordRepository) *appPasswordRepositoryAdapter {
+	return &appPasswordRepositoryAdapter{repo: repo}
+}
+
+func (a *appPasswordRepositoryAdapter) CreateAppPassword(ctx context.Context, password application.AppPassword) error {
+	return a.repo.CreateAppPassword(ctx, persistence.AppPassword{
+		ID:         password.ID,
+		UserID:     password.UserID,
+		Name:       password.Name,
+		TokenHash:  password.TokenHash,
+		CreatedAt:  password.CreatedAt,
+		LastUsedAt: cloneTime(password.LastUsedAt),
+	})
+}
+
+func (a *appPasswordRepositoryAdapter) GetAppPasswordByHash(ctx context.Context, tokenHash string) (application.AppPassword, error) {
+	model, err := a.repo.GetAppPasswordByHash(ctx, tokenHash)
+	if err != nil {
+		return application.AppPassword{}, err
+	}
+	return toApplicationAppPassword(model), nil
+}
+
+func (a *appPasswordRepositoryAdapter) ListAppPasswords(ctx context.Context, userID string) ([]application.AppPassword, error) {
+	models, err := a.repo.ListAppPasswords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	passwords := make([]application.AppPassword, len(models))
+	for i, model := range models {
+		passwords[i] = toApplicationAppPassword(model)
+	}
+	return passwords, nil
+}
+
+func (a *appPasswordRepositoryAdapter) DeleteAppPassword(ctx context.Context, userID, id string) error {
+	return a.repo.DeleteAppPassword(ctx, userID, id)
+}
+
+func (a *appPasswordRepositoryAdapter) TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error {
+	return a.repo.TouchAppPassword(ctx, id, usedAt)
+}
+
+func toApplicationAppPassword(model persistence.AppPassword) application.AppPassword {
+	return application.AppPassword{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Name:       model.Name,
+		TokenHash:  model.TokenHash,
+		CreatedAt:  model.CreatedAt,
+		LastUsedAt: cloneTime(model.LastUsedAt),
+	}
+}
+
+type colleagueGroupRepositoryAdapter struct {
+	repo persistence.ColleagueGroupRepository
+}
+
+func newColleagueGroupRepositoryAdapter(repo persistence.ColleagueGroupRepository) *colleagueGroupRepositoryAdapter {
+	return &colleagueGroupRepositoryAdapter{repo: repo}
+}
+
+func (a *colleagueGroupRepositoryAdapter) CreateColleagueGroup(ctx context.Context, group application.ColleagueGroup) error {
+	return a.repo.CreateColleagueGroup(ctx, toPersistenceColleagueGroup(group))
+}
+
+func (a *colleagueGroupRepositoryAdapter) GetColleagueGroup(ctx context.Context, id string) (application.ColleagueGroup, error) {
+	model, err := a.repo.GetColleagueGroup(ctx, id)
+	if err != nil {
+		return application.ColleagueGroup{}, err
+	}
+	return toApplicationColleagueGroup(model), nil
+}
+
+func (a *colleagueGroupRepositoryAdapter) UpdateColleagueGroup(ctx context.Context, group application.ColleagueGroup) error {
+	return a.repo.UpdateColleagueGroup(ctx, toPersistenceColleagueGroup(group))
+}
+
+func (a *colleagueGroupRepositoryAdapter) DeleteColleagueGroup(ctx context.Context, id string) error {
+	return a.repo.DeleteColleagueGroup(ctx, id)
+}
+
+func (a *colleagueGroupRepositoryAdapter) ListColleagueGroups(ctx context.Context, userID string) ([]application.ColleagueGroup, error) {
+	models, err := a.repo.ListColleagueGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]application.ColleagueGroup, len(models))
+	for i, model := range models {
+		groups[i] = toApplicationColleagueGroup(model)
+	}
+	return groups, nil
+}
+
+func toPersistenceColleagueGroup(group application.ColleagueGroup) persistence.ColleagueGroup {
+	return persistence.ColleagueGroup{
+		ID:         group.ID,
+		OwnerID:    group.OwnerID,
+		Name:       group.Name,
+		MemberIDs:  append([]string(nil), group.MemberIDs...),
+		SharedWith: append([]string(nil), group.SharedWith...),
+		CreatedAt:  group.CreatedAt,
+		UpdatedAt:  group.UpdatedAt,
+	}
+}
+
+func toApplicationColleagueGroup(model persistence.ColleagueGroup) application.ColleagueGroup {
+	return application.ColleagueGroup{
+		ID:         model.ID,
+		OwnerID:    model.OwnerID,
+		Name:       model.Name,
+		MemberIDs:  append([]string(nil), model.MemberIDs...),
+		SharedWith: append([]string(nil), model.SharedWith...),
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+type calendarRepositoryAdapter struct {
+	repo persistence.CalendarRepository
+}
+
+func newCalendarRepositoryAdapter(repo persistence.CalendarRepository) *calendarRepositoryAdapter {
+	return &calendarRepositoryAdapter{repo: repo}
+}
+
+func (a *calendarRepositoryAdapter) CreateCalendar(ctx context.Context, calendar application.Calendar) error {
+	return a.repo.CreateCalendar(ctx, toPersistenceCalendar(calendar))
+}
+
+func (a *calendarRepositoryAdapter) GetCalendar(ctx context.Context, id string) (application.Calendar, error) {
+	model, err := a.repo.GetCalendar(ctx, id)
+	if err != nil {
+		return application.Calendar{}, err
+	}
+	return toApplicationCalendar(model), nil
+}
+
+func (a *calendarRepositoryAdapter) UpdateCalendar(ctx context.Context, calendar application.Calendar) error {
+	return a.repo.UpdateCalendar(ctx, toPersistenceCalendar(calendar))
+}
+
+func (a *calendarRepositoryAdapter) DeleteCalendar(ctx context.Context, id string) error {
+	return a.repo.DeleteCalendar(ctx, id)
+}
+
+func (a *calendarRepositoryAdapter) ListCalendars(ctx context.Context, userID string) ([]application.Calendar, error) {
+	models, err := a.repo.ListCalendars(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	calendars := make([]application.Calendar, len(models))
+	for i, model := range models {
+		calendars[i] = toApplicationCalendar(model)
+	}
+	return calendars, nil
+}
+
+func toPersistenceCalendar(calendar application.Calendar) persistence.Calendar {
+	return persistence.Calendar{
+		ID:          calendar.ID,
+		Name:        calendar.Name,
+		Description: calendar.Description,
+		OwnerID:     calendar.OwnerID,
+		EditorIDs:   append([]string(nil), calendar.EditorIDs...),
+		ViewerIDs:   append([]string(nil), calendar.ViewerIDs...),
+		CreatedAt:   calendar.CreatedAt,
+		UpdatedAt:   calendar.UpdatedAt,
+	}
+}
+
+func toApplicationCalendar(model persistence.Calendar) application.Calendar {
+	return application.Calendar{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		OwnerID:     model.OwnerID,
+		EditorIDs:   append([]string(nil), model.EditorIDs...),
+		ViewerIDs:   append([]string(nil), model.ViewerIDs...),
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+type userGroupRepositoryAdapter struct {
+	repo persistence.UserGroupRepository
+}
+
+func newUserGroupRepositoryAdapter(repo persistence.UserGroupRepository) *userGroupRepositoryAdapter {
+	return &userGroupRepositoryAdapter{repo: repo}
+}
+
+func (a *userGroupRepositoryAdapter) CreateUserGroup(ctx context.Context, group application.UserGroup) error {
+	return a.repo.CreateUserGroup(ctx, toPersistenceUserGroup(group))
+}
+
+func (a *userGroupRepositoryAdapter) GetUserGroup(ctx context.Context, id string) (application.UserGroup, error) {
+	model, err := a.repo.GetUserGroup(ctx, id)
+	if err != nil {
+		return application.UserGroup{}, err
+	}
+	return toApplicationUserGroup(model), nil
+}
+
+func (a *userGroupRepositoryAdapter) UpdateUserGroup(ctx context.Context, group application.UserGroup) error {
+	return a.repo.UpdateUserGroup(ctx, toPersistenceUserGroup(group))
+}
+
+func (a *userGroupRepositoryAdapter) DeleteUserGroup(ctx context.Context, id string) error {
+	return a.repo.DeleteUserGroup(ctx, id)
+}
+
+func (a *userGroupRepositoryAdapter) ListUserGroups(ctx context.Context) ([]application.UserGroup, error) {
+	models, err := a.repo.ListUserGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]application.UserGroup, len(models))
+	for i, model := range models {
+		groups[i] = toApplicationUserGroup(model)
+	}
+	return groups, nil
+}
+
+func toPersistenceUserGroup(group application.UserGroup) persistence.UserGroup {
+	return persistence.UserGroup{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		OwnerID:     group.OwnerID,
+		MemberIDs:   append([]string(nil), group.MemberIDs...),
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+func toApplicationUserGroup(model persistence.UserGroup) application.UserGroup {
+	return application.UserGroup{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		OwnerID:     model.OwnerID,
+		MemberIDs:   append([]string(nil), model.MemberIDs...),
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+type departmentRepositoryAdapter struct {
+	repo persistence.DepartmentRepository
+}
+
+func newDepartmentRepositoryAdapter(repo persistence.DepartmentRepository) *departmentRepositoryAdapter {
+	return &departmentRepositoryAdapter{repo: repo}
+}
+
+func (a *departmentRepositoryAdapter) CreateDepartment(ctx context.Context, department application.Department) error {
+	return a.repo.CreateDepartment(ctx, toPersistenceDepartment(department))
+}
+
+func (a *departmentRepositoryAdapter) GetDepartment(ctx context.Context, id string) (application.Department, error) {
+	model, err := a.repo.GetDepartment(ctx, id)
+	if err != nil {
+		return application.Department{}, err
+	}
+	return toApplicationDepartment(model), nil
+}
+
+func (a *departmentRepositoryAdapter) UpdateDepartment(ctx context.Context, department application.Department) error {
+	return a.repo.UpdateDepartment(ctx, toPersistenceDepartment(department))
+}
+
+func (a *departmentRepositoryAdapter) DeleteDepartment(ctx context.Context, id string) error {
+	return a.repo.DeleteDepartment(ctx, id)
+}
+
+func (a *departmentRepositoryAdapter) ListDepartments(ctx context.Context) ([]application.Department, error) {
+	models, err := a.repo.ListDepartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	departments := make([]application.Department, len(models))
+	for i, model := range models {
+		departments[i] = toApplicationDepartment(model)
+	}
+	return departments, nil
+}
+
+func (a *departmentRepositoryAdapter) ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error) {
+	return a.repo.ListDepartmentMembers(ctx, departmentIDs)
+}
+
+func (a *departmentRepositoryAdapter) ListDirectReports(ctx context.Context, managerID string) ([]string, error) {
+	return a.repo.ListDirectReports(ctx, managerID)
+}
+
+func toPersistenceDepartment(department application.Department) persistence.Department {
+	return persistence.Department{
+		ID:        department.ID,
+		Name:      department.Name,
+		ParentID:  department.ParentID,
+		CreatedAt: department.CreatedAt,
+		UpdatedAt: department.UpdatedAt,
+	}
+}
+
+func toApplicationDepartment(model persistence.Department) application.Department {
+	return application.Department{
+		ID:        model.ID,
+		Name:      model.Name,
+		ParentID:  model.ParentID,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+type viewLinkRepositoryAdapter struct {
+	repo persistence.ViewLinkRepository
+}
+
+func newViewLinkRepositoryAdapter(repo persistence.ViewLinkRepository) *viewLinkRepositoryAdapter {
+	return &viewLinkRepositoryAdapter{repo: repo}
+}
+
+func (a *viewLinkRepositoryAdapter) CreateViewLink(ctx context.Context, link application.ViewLink) error {
+	return a.repo.CreateViewLink(ctx, persistence.ViewLink{
+		Code:           link.Code,
+		CreatorID:      link.CreatorID,
+		View:           string(link.View),
+		Date:           link.Date,
+		ParticipantIDs: append([]string(nil), link.ParticipantIDs...),
+		CreatedAt:      link.CreatedAt,
+	})
+}
+
+func (a *viewLinkRepositoryAdapter) GetViewLink(ctx context.Context, code string) (application.ViewLink, error) {
+	model, err := a.repo.GetViewLink(ctx, code)
+	if err != nil {
+		return application.ViewLink{}, err
+	}
+	return application.ViewLink{
+		Code:           model.Code,
+		CreatorID:      model.CreatorID,
+		View:           application.ListPeriod(model.View),
+		Date:           model.Date,
+		ParticipantIDs: append([]string(nil), model.ParticipantIDs...),
+		CreatedAt:      model.CreatedAt,
+	}, nil
+}
+
+type calDAVObjectRepositoryAdapter struct {
+	repo persistence.CalDAVObjectRepository
+}
+
+func newCalDAVObjectRepositoryAdapter(repo persistence.CalDAVObjectRepository) *calDAVObjectRepositoryAdapter {
+	return &calDAVObjectRepositoryAdapter{repo: repo}
+}
+
+func (a *calDAVObjectRepositoryAdapter) SaveCalDAVObject(ctx context.Context, object application.CalDAVObject) error {
+	return a.repo.SaveCalDAVObject(ctx, persistence.CalDAVObject(object))
+}
+
+func (a *calDAVObjectRepositoryAdapter) GetCalDAVObjectByName(ctx context.Context, resourceName string) (application.CalDAVObject, error) {
+	model, err := a.repo.GetCalDAVObjectByName(ctx, resourceName)
+	if err != nil {
+		return application.CalDAVObject{}, err
+	}
+	return application.CalDAVObject(model), nil
+}
+
+func (a *calDAVObjectRepositoryAdapter) ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]application.CalDAVObject, error) {
+	models, err := a.repo.ListCalDAVObjects(ctx, scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]application.CalDAVObject, len(models))
+	for i, model := range models {
+		objects[i] = application.CalDAVObject(model)
+	}
+	return objects, nil
+}
+
+type calDAVSyncRepositoryAdapter struct {
+	repo persistence.CalDAVSyncRepository
+}
+
+func newCalDAVSyncRepositoryAdapter(repo persistence.CalDAVSyncRepository) *calDAVSyncRepositoryAdapter {
+	return &calDAVSyncRepositoryAdapter{repo: repo}
+}
+
+func (a *calDAVSyncRepositoryAdapter) CreateCalDAVSyncAccount(ctx context.Context, account application.CalDAVSyncAccount) error {
+	return a.repo.CreateCalDAVSyncAccount(ctx, persistence.CalDAVSyncAccount{
+		ID:             account.ID,
+		UserID:         account.UserID,
+		CollectionURL:  account.CollectionURL,
+		Username:       account.Username,
+		Password:       account.Password,
+		ConflictPolicy: string(account.ConflictPolicy),
+		Active:         account.Active,
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	})
+}
+
+func (a *calDAVSyncRepositoryAdapter) GetCalDAVSyncAccount(ctx context.Context, id string) (application.CalDAVSyncAccount, error) {
+	model, err := a.repo.GetCalDAVSyncAccount(ctx, id)
+	if err != nil {
+		return application.CalDAVSyncAccount{}, err
+	}
+	return toApplicationCalDAVSyncAccount(model), nil
+}
+
+func (a *calDAVSyncRepositoryAdapter) ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]application.CalDAVSyncAccount, error) {
+	models, err := a.repo.ListCalDAVSyncAccounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]application.CalDAVSyncAccount, len(models))
+	for i, model := range models {
+		accounts[i] = toApplicationCalDAVSyncAccount(model)
+	}
+	return accounts, nil
+}
+
+func (a *calDAVSyncRepositoryAdapter) DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error {
+	return a.repo.DeleteCalDAVSyncAccount(ctx, userID, id)
+}
+
+func (a *calDAVSyncRepositoryAdapter) ListCalDAVSyncItems(ctx context.Context, accountID string) ([]application.CalDAVSyncItem, error) {
+	models, err := a.repo.ListCalDAVSyncItems(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]application.CalDAVSyncItem, len(models))
+	for i, model := range models {
+		items[i] = application.CalDAVSyncItem(model)
+	}
+	return items, nil
+}
+
+func (a *calDAVSyncRepositoryAdapter) SaveCalDAVSyncItem(ctx context.Context, item application.CalDAVSyncItem) error {
+	return a.repo.SaveCalDAVSyncItem(ctx, persistence.CalDAVSyncItem(item))
+}
+
+func (a *calDAVSyncRepositoryAdapter) DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error {
+	return a.repo.DeleteCalDAVSyncItem(ctx, accountID, remoteHref)
+}
+
+func (a *calDAVSyncRepositoryAdapter) CreateCalDAVSyncRun(ctx context.Context, run application.CalDAVSyncRun) error {
+	return a.repo.CreateCalDAVSyncRun(ctx, persistence.CalDAVSyncRun{
+		ID:            run.ID,
+		AccountID:     run.AccountID,
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+		Status:        string(run.Status),
+		PulledCreated: run.Pulled.Created,
+		PulledUpdated: run.Pulled.Updated,
+		PulledDeleted: run.Pulled.Deleted,
+		PushedCreated: run.Pushed.Created,
+		PushedUpdated: run.Pushed.Updated,
+		PushedDeleted: run.Pushed.Deleted,
+		Conflicts:     run.Conflicts,
+		Skipped:       run.Skipped,
+		Failures:      run.Failures,
+		Error:         run.Error,
+	})
+}
+
+func (a *calDAVSyncRepositoryAdapter) ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]application.CalDAVSyncRun, error) {
+	models, err := a.repo.ListCalDAVSyncRuns(ctx, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]application.CalDAVSyncRun, len(models))
+	for i, model := range models {
+		runs[i] = application.CalDAVSyncRun{
+			ID:         model.ID,
+			AccountID:  model.AccountID,
+			StartedAt:  model.StartedAt,
+			FinishedAt: model.FinishedAt,
+			Status:     application.CalDAVSyncRunStatus(model.Status),
+			Pulled:     application.CalDAVSyncCounts{Created: model.PulledCreated, Updated: model.PulledUpdated, Deleted: model.PulledDeleted},
+			Pushed:     application.CalDAVSyncCounts{Created: model.PushedCreated, Updated: model.PushedUpdated, Deleted: model.PushedDeleted},
+			Conflicts:  model.Conflicts,
+			Skipped:    model.Skipped,
+			Failures:   model.Failures,
+			Error:      model.Error,
+		}
+	}
+	return runs, nil
+}
+
+func toApplicationCalDAVSyncAccount(model persistence.CalDAVSyncAccount) application.CalDAVSyncAccount {
+	return application.CalDAVSyncAccount{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		CollectionURL:  model.CollectionURL,
+		Username:       model.Username,
+		Password:       model.Password,
+		ConflictPolicy: application.CalDAVSyncConflictPolicy(model.ConflictPolicy),
+		Active:         model.Active,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func toApplicationOutboxEvents(models []persistence.OutboxEvent) []application.OutboxEvent {
+	events := make([]application.OutboxEvent, len(models))
+	for i, model := range models {
+		events[i] = application.OutboxEvent{
+			ID:         model.ID,
+			Type:       application.EventType(model.Type),
+			ResourceID: model.ResourceID,
+			ActorID:    model.ActorID,
+			Payload:    append([]byte(nil), model.Payload...),
+			OccurredAt: model.OccurredAt,
+			Sequence:   model.Sequence,
+		}
+	}
+	return events
+}
+
+func toApplicationWebhookSubscription(model persistence.WebhookSubscription) application.WebhookSubscription {
+	return application.WebhookSubscription{
+		ID:         model.ID,
+		URL:        model.URL,
+		Secret:     model.Secret,
+		EventTypes: append([]string{}, model.EventTypes...),
+		Active:     model.Active,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+func toPersistenceWebhookSubscription(subscription application.WebhookSubscription) persistence.WebhookSubscription {
+	return persistence.WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: append([]string{}, subscription.EventTypes...),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func toApplicationWebhookDeliveries(models []persistence.WebhookDelivery) []application.WebhookDelivery {
+	deliveries := make([]application.WebhookDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = toApplicationWebhookDelivery(model)
+	}
+	return deliveries
+}
+
+func toApplicationWebhookDelivery(model persistence.WebhookDelivery) application.WebhookDelivery {
+	return application.WebhookDelivery{
+		ID:             model.ID,
+		SubscriptionID: model.SubscriptionID,
+		EventID:        model.EventID,
+		EventType:      application.EventType(model.EventType),
+		Payload:        append([]byte(nil), model.Payload...),
+		Status:         application.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		ResponseStatus: model.ResponseStatus,
+		LastError:      model.LastError,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func toPersistenceWebhookDelivery(delivery application.WebhookDelivery) persistence.WebhookDelivery {
+	return persistence.WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        append([]byte(nil), delivery.Payload...),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func toApplicationUser(model persistence.User) application.User {
+	return application.User{
+		ID:           model.ID,
+		Email:        model.Email,
+		DisplayName:  model.DisplayName,
+		IsAdmin:      model.IsAdmin,
+		TimeZone:     model.TimeZone,
+		DepartmentID: model.DepartmentID,
+		ManagerID:    model.ManagerID,
+		Location:     model.Location,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+		Version:      model.Version,
+	}
+}
+
+func toPersistenceUser(user application.User, passwordHash string) persistence.User {
+	if passwordHash == "" {
+		passwordHash = user.ID
+	}
+	return persistence.User{
+		ID:           user.ID,
+		Email:        user.Email,
+		DisplayName:  user.DisplayName,
+		PasswordHash: passwordHash,
+		IsAdmin:      user.IsAdmin,
+		TimeZone:     user.TimeZone,
+		DepartmentID: user.DepartmentID,
+		ManagerID:    user.ManagerID,
+		Location:     user.Location,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		Version:      user.Version,
+	}
+}
+
+func toApplicationRoom(model persistence.Room) application.Room {
+	return application.Room{
+		ID:         model.ID,
+		Name:       model.Name,
+		Location:   model.Location,
+		Capacity:   model.Capacity,
+		Facilities: cloneString(model.Facilities),
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+		Version:    model.Version,
+	}
+}
+
+func toPersistenceRoom(room application.Room) persistence.Room {
+	return persistence.Room{
+		ID:         room.ID,
+		Name:       room.Name,
+		Location:   room.Location,
+		Capacity:   room.Capacity,
+		Facilities: cloneString(room.Facilities),
+		CreatedAt:  room.CreatedAt,
+		UpdatedAt:  room.UpdatedAt,
+		Version:    room.Version,
+	}
+}
+
+func toApplicationSchedule(model persistence.Schedule) application.Schedule {
+	description := ""
+	if model.Memo != nil {
+		description = *model.Memo
+	}
+	webURL := ""
+	if model.WebConferenceURL != nil {
+		webURL = *model.WebConferenceURL
+	}
+	return application.Schedule{
+		ID:                  model.ID,
+		CreatorID:           model.CreatorID,
+		Title:               model.Title,
+		Description:         description,
+		Start:               model.Start,
+		End:                 model.End,
+		TimeZone:            model.TimeZone,
+		AllDay:              model.AllDay,
+		Busy:                model.Busy,
+		RoomID:              cloneString(model.RoomID),
+		WebConferenceURL:    webURL,
+		ParticipantIDs:      append([]string(nil), model.Participants...),
+		ReminderMinutes:     append([]int(nil), model.ReminderMinutes...),
+		CalendarID:          cloneString(model.CalendarID),
+		ParticipantGroupIDs: append([]string(nil), model.ParticipantGroups...),
+		CreatedAt:           model.CreatedAt,
+		UpdatedAt:           model.UpdatedAt,
+		Version:             model.Version,
+	}
+}
+
+func toPersistenceSchedule(schedule application.Schedule) persistence.Schedule {
+	var memo *string
+	if strings.TrimSpace(schedule.Description) != "" {
+		memo = cloneString(&schedule.Description)
+	}
+	var web *string
+	if strings.TrimSpace(schedule.WebConferenceURL) != "" {
+		web = cloneString(&schedule.WebConferenceURL)
+	}
+	return persistence.Schedule{
+		ID:                schedule.ID,
+		Title:             schedule.Title,
+		Start:             schedule.Start,
+		End:               schedule.End,
+		TimeZone:          schedule.TimeZone,
+		AllDay:            schedule.AllDay,
+		Busy:              schedule.Busy,
+		CreatorID:         schedule.CreatorID,
+		Memo:              memo,
+		Participants:      append([]string(nil), schedule.ParticipantIDs...),
+		RoomID:            cloneString(schedule.RoomID),
+		WebConferenceURL:  web,
+		ReminderMinutes:   append([]int(nil), schedule.ReminderMinutes...),
+		CalendarID:        cloneString(schedule.CalendarID),
+		ParticipantGroups: append([]string(nil), schedule.ParticipantGroupIDs...),
+		CreatedAt:         schedule.CreatedAt,
+		UpdatedAt:         schedule.UpdatedAt,
+		Version:           schedule.Version,
+	}
+}
+
+func toApplicationOutOfOffice(model persistence.OutOfOffice) application.OutOfOffice {
+	note := ""
+	if model.Note != nil {
+		note = *model.Note
+	}
+	return application.OutOfOffice{
+		ID:          model.ID,
+		UserID:      model.UserID,
+		Start:       model.Start,
+		End:         model.End,
+		Note:        note,
+		AutoDecline: model.AutoDecline,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func toPersistenceOutOfOffice(entry application.OutOfOffice) persistence.OutOfOffice {
+	var note *string
+	if strings.TrimSpace(entry.Note) != "" {
+		note = cloneString(&entry.Note)
+	}
+	return persistence.OutOfOffice{
+		ID:          entry.ID,
+		UserID:      entry.UserID,
+		Start:       entry.Start,
+		End:         entry.End,
+		Note:        note,
+		AutoDecline: entry.AutoDecline,
+		CreatedAt:   entry.CreatedAt,
+		UpdatedAt:   entry.UpdatedAt,
 	}
 }
 
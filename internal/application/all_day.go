@@ -0,0 +1,47 @@
+package application
+
+import "time"
+
+// normalizeAllDayInput converts the caller supplied dates of an all-day schedule
+// into floating bounds: UTC midnight of the first day and of the day after the
+// last day. Timed inputs are returned unchanged.
+func normalizeAllDayInput(input ScheduleInput) ScheduleInput {
+	if !input.AllDay {
+		return input
+	}
+	if !input.Start.IsZero() {
+		input.Start = floatingDate(input.Start)
+	}
+	if !input.End.IsZero() {
+		input.End = floatingDate(input.End).AddDate(0, 0, 1)
+	}
+	return input
+}
+
+// floatingDate returns UTC midnight of t's calendar date in t's own location.
+func floatingDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// floatingWallClock re-labels the wall-clock reading of t in loc as UTC so that
+// it can be compared against floating all-day bounds.
+func floatingWallClock(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+}
+
+// anchorFloating pins a floating all-day bound to midnight in loc.
+func anchorFloating(t time.Time, loc *time.Location) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// occupiedInterval returns the absolute time range a schedule occupies. All-day
+// schedules are anchored to midnight in their own time zone.
+func occupiedInterval(schedule Schedule) (time.Time, time.Time) {
+	if !schedule.AllDay {
+		return schedule.Start, schedule.End
+	}
+	loc := locationOrDefault(schedule.TimeZone)
+	return anchorFloating(schedule.Start, loc), anchorFloating(schedule.End, loc)
+}
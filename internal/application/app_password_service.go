@@ -0,0 +1,256 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxAppPasswordNameLength bounds the label users give an app password.
+const maxAppPasswordNameLength = 100
+
+// AppPasswordRepository persists app passwords by the hash of their secret.
+type AppPasswordRepository interface {
+	CreateAppPassword(ctx context.Context, password AppPassword) error
+	GetAppPasswordByHash(ctx context.Context, tokenHash string) (AppPassword, error)
+	// ListAppPasswords returns a user's app passwords, oldest first.
+	ListAppPasswords(ctx context.Context, userID string) ([]AppPassword, error)
+	DeleteAppPassword(ctx context.Context, userID, id string) error
+	TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// AppPasswordService issues and verifies app passwords, the per-client
+// secrets used for basic auth by clients that cannot hold a session.
+type AppPasswordService struct {
+	passwords       AppPasswordRepository
+	credentials     CredentialStore
+	idGenerator     func() string
+	secretGenerator func() string
+	now             func() time.Time
+	logger          *slog.Logger
+}
+
+// NewAppPasswordService constructs an app password service with the provided dependencies.
+func NewAppPasswordService(passwords AppPasswordRepository, credentials CredentialStore, idGenerator, secretGenerator func() string, now func() time.Time) *AppPasswordService {
+	return NewAppPasswordServiceWithLogger(passwords, credentials, idGenerator, secretGenerator, now, nil)
+}
+
+// NewAppPasswordServiceWithLogger constructs an app password service with a specified logger.
+func NewAppPasswordServiceWithLogger(passwords AppPasswordRepository, credentials CredentialStore, idGenerator, secretGenerator func() string, now func() time.Time, logger *slog.Logger) *AppPasswordService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if secretGenerator == nil {
+		secretGenerator = idGenerator
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &AppPasswordService{
+		passwords:       passwords,
+		credentials:     credentials,
+		idGenerator:     idGenerator,
+		secretGenerator: secretGenerator,
+		now:             now,
+		logger:          defaultLogger(logger),
+	}
+}
+
+func (s *AppPasswordService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "AppPasswordService", operation, attrs...)
+}
+
+// CreateAppPassword issues a new app password for a user. The secret is only
+// returned here.
+func (s *AppPasswordService) CreateAppPassword(ctx context.Context, params CreateAppPasswordParams) (password AppPassword, err error) {
+	if s == nil {
+		err = fmt.Errorf("AppPasswordService is nil")
+		return
+	}
+	if s.passwords == nil {
+		err = fmt.Errorf("app password repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateAppPassword",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create app password", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("app_password_id", password.ID).InfoContext(ctx, "app password created")
+	}()
+
+	if params.UserID != params.Principal.UserID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	name := strings.TrimSpace(params.Name)
+	vErr := &ValidationError{}
+	switch {
+	case name == "":
+		vErr.add("name", "app password name is required")
+	case len([]rune(name)) > maxAppPasswordNameLength:
+		vErr.add("name", "app password name must be at most 100 characters")
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	secret := s.secretGenerator()
+	password = AppPassword{
+		ID:        s.idGenerator(),
+		UserID:    params.UserID,
+		Name:      name,
+		Secret:    secret,
+		TokenHash: hashAppPassword(secret),
+		CreatedAt: s.now(),
+	}
+	if err = s.passwords.CreateAppPassword(ctx, password); err != nil {
+		err = mapAppPasswordRepoError(err)
+	}
+	return
+}
+
+// ListAppPasswords lists a user's app passwords without their secrets.
+func (s *AppPasswordService) ListAppPasswords(ctx context.Context, principal Principal, userID string) ([]AppPassword, error) {
+	if s == nil {
+		return nil, fmt.Errorf("AppPasswordService is nil")
+	}
+	if s.passwords == nil {
+		return nil, fmt.Errorf("app password repository not configured")
+	}
+	if userID != principal.UserID && !principal.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	passwords, err := s.passwords.ListAppPasswords(ctx, userID)
+	if err != nil {
+		err = mapAppPasswordRepoError(err)
+		s.loggerWith(ctx, "ListAppPasswords", "user_id", userID).ErrorContext(ctx, "failed to list app passwords", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	for i := range passwords {
+		passwords[i].TokenHash = ""
+	}
+	return passwords, nil
+}
+
+// DeleteAppPassword revokes one of a user's app passwords.
+func (s *AppPasswordService) DeleteAppPassword(ctx context.Context, principal Principal, userID, passwordID string) error {
+	if s == nil {
+		return fmt.Errorf("AppPasswordService is nil")
+	}
+	if s.passwords == nil {
+		return fmt.Errorf("app password repository not configured")
+	}
+	if userID != principal.UserID && !principal.IsAdmin {
+		return ErrUnauthorized
+	}
+
+	logger := s.loggerWith(ctx, "DeleteAppPassword",
+		"principal_id", principal.UserID,
+		"user_id", userID,
+		"app_password_id", passwordID,
+	)
+	if err := s.passwords.DeleteAppPassword(ctx, userID, passwordID); err != nil {
+		err = mapAppPasswordRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete app password", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "app password deleted")
+	return nil
+}
+
+// AuthenticateAppPassword verifies basic auth credentials made of a user's
+// e-mail address and one of their app passwords, returning the principal.
+func (s *AppPasswordService) AuthenticateAppPassword(ctx context.Context, email, secret string) (principal Principal, err error) {
+	if s == nil {
+		err = fmt.Errorf("AppPasswordService is nil")
+		return
+	}
+	if s.passwords == nil || s.credentials == nil {
+		err = fmt.Errorf("app password service not configured")
+		return
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	logger := s.loggerWith(ctx, "AuthenticateAppPassword", "email", email)
+	defer func() {
+		if err != nil {
+			logger.WarnContext(ctx, "app password authentication failed", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("principal_id", principal.UserID).InfoContext(ctx, "app password authenticated")
+	}()
+
+	if email == "" || secret == "" {
+		err = ErrInvalidCredentials
+		return
+	}
+
+	password, err := s.passwords.GetAppPasswordByHash(ctx, hashAppPassword(secret))
+	if err != nil {
+		if isNotFoundError(err) {
+			err = ErrInvalidCredentials
+		}
+		return
+	}
+
+	creds, err := s.credentials.GetUserCredentialsByEmail(ctx, email)
+	if err != nil {
+		if isNotFoundError(err) {
+			err = ErrInvalidCredentials
+		}
+		return
+	}
+	if creds.User.ID != password.UserID {
+		err = ErrInvalidCredentials
+		return
+	}
+	if creds.Disabled {
+		err = ErrAccountDisabled
+		return
+	}
+
+	if err = s.passwords.TouchAppPassword(ctx, password.ID, s.now()); err != nil {
+		return
+	}
+	user := creds.User
+	principal = Principal{UserID: user.ID, IsAdmin: user.IsAdmin, TimeZone: user.TimeZone}
+	return
+}
+
+// hashAppPassword returns the hex SHA-256 of an app password secret. Secrets
+// are random, so an unsalted digest suffices for lookup.
+func hashAppPassword(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func mapAppPasswordRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrForeignKeyViolation):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrDuplicate):
+		return ErrAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type appPasswordRepoStub struct {
+	passwords map[string]AppPassword
+	touched   map[string]time.Time
+}
+
+func newAppPasswordRepoStub() *appPasswordRepoStub {
+	return &appPasswordRepoStub{passwords: map[string]AppPassword{}, touched: map[string]time.Time{}}
+}
+
+func (a *appPasswordRepoStub) CreateAppPassword(ctx context.Context, password AppPassword) error {
+	a.passwords[password.ID] = password
+	return nil
+}
+
+func (a *appPasswordRepoStub) GetAppPasswordByHash(ctx context.Context, tokenHash string) (AppPassword, error) {
+	for _, password := range a.passwords {
+		if password.TokenHash == tokenHash {
+			return password, nil
+		}
+	}
+	return AppPassword{}, ErrNotFound
+}
+
+func (a *appPasswordRepoStub) ListAppPasswords(ctx context.Context, userID string) ([]AppPassword, error) {
+	var out []AppPassword
+	for _, password := range a.passwords {
+		if password.UserID == userID {
+			out = append(out, password)
+		}
+	}
+	return out, nil
+}
+
+func (a *appPasswordRepoStub) DeleteAppPassword(ctx context.Context, userID, id string) error {
+	if password, ok := a.passwords[id]; !ok || password.UserID != userID {
+		return ErrNotFound
+	}
+	delete(a.passwords, id)
+	return nil
+}
+
+func (a *appPasswordRepoStub) TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error {
+	a.touched[id] = usedAt
+	return nil
+}
+
+func TestAppPasswordService(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := newAppPasswordRepoStub()
+	creds := &credentialStoreStub{credentials: UserCredentials{User: User{ID: "alice", Email: "alice@example.com", TimeZone: "Asia/Tokyo"}}}
+	service := NewAppPasswordService(repo, creds, func() string { return "pw-1" }, func() string { return "s3cret" }, func() time.Time { return now })
+	alice := Principal{UserID: "alice"}
+
+	password, err := service.CreateAppPassword(context.Background(), CreateAppPasswordParams{Principal: alice, UserID: "alice", Name: " iPhone "})
+	if err != nil {
+		t.Fatalf("CreateAppPassword returned error: %v", err)
+	}
+	if password.Secret != "s3cret" || password.Name != "iPhone" || repo.passwords["pw-1"].TokenHash == "s3cret" || repo.passwords["pw-1"].TokenHash == "" {
+		t.Fatalf("expected the secret returned once and only its hash stored, got %#v / %#v", password, repo.passwords["pw-1"])
+	}
+
+	t.Run("authenticates with e-mail and secret", func(t *testing.T) {
+		principal, err := service.AuthenticateAppPassword(context.Background(), "Alice@Example.com", "s3cret")
+		if err != nil {
+			t.Fatalf("AuthenticateAppPassword returned error: %v", err)
+		}
+		if principal.UserID != "alice" || principal.TimeZone != "Asia/Tokyo" || !repo.touched["pw-1"].Equal(now) {
+			t.Fatalf("unexpected principal %#v (touched %v)", principal, repo.touched)
+		}
+		if _, err := service.AuthenticateAppPassword(context.Background(), "alice@example.com", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials for a wrong secret, got %v", err)
+		}
+		creds.credentials.Disabled = true
+		defer func() { creds.credentials.Disabled = false }()
+		if _, err := service.AuthenticateAppPassword(context.Background(), "alice@example.com", "s3cret"); !errors.Is(err, ErrAccountDisabled) {
+			t.Fatalf("expected ErrAccountDisabled, got %v", err)
+		}
+	})
+
+	t.Run("rejects another user's secret", func(t *testing.T) {
+		repo.passwords["pw-bob"] = AppPassword{ID: "pw-bob", UserID: "bob", TokenHash: hashAppPassword("bobs")}
+		if _, err := service.AuthenticateAppPassword(context.Background(), "alice@example.com", "bobs"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("limits management to the owner or administrators", func(t *testing.T) {
+		if _, err := service.CreateAppPassword(context.Background(), CreateAppPasswordParams{Principal: alice, UserID: "bob", Name: "x"}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		var vErr *ValidationError
+		if _, err := service.CreateAppPassword(context.Background(), CreateAppPasswordParams{Principal: alice, UserID: "alice"}); !errors.As(err, &vErr) || vErr.FieldErrors["name"] != "app password name is required" {
+			t.Fatalf("expected a name validation error, got %v", err)
+		}
+		listed, err := service.ListAppPasswords(context.Background(), Principal{UserID: "admin", IsAdmin: true}, "alice")
+		if err != nil || len(listed) != 1 || listed[0].TokenHash != "" {
+			t.Fatalf("expected one listed password without its hash, got %#v (%v)", listed, err)
+		}
+		if err := service.DeleteAppPassword(context.Background(), alice, "alice", "pw-bob"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound for another user's password, got %v", err)
+		}
+		if err := service.DeleteAppPassword(context.Background(), alice, "alice", "pw-1"); err != nil {
+			t.Fatalf("DeleteAppPassword returned error: %v", err)
+		}
+		if _, err := service.AuthenticateAppPassword(context.Background(), "alice@example.com", "s3cret"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("expected a revoked password to fail, got %v", err)
+		}
+	})
+}
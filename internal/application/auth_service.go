@@ -322,6 +322,6 @@ func (s *AuthService) ValidateSession(ctx context.Context, token string) (princi
 		return
 	}
 
-	principal = Principal{UserID: user.ID, IsAdmin: user.IsAdmin}
+	principal = Principal{UserID: user.ID, IsAdmin: user.IsAdmin, TimeZone: user.TimeZone}
 	return
 }
@@ -0,0 +1,411 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// AvailabilityRepository persists working hours and out-of-office periods.
+type AvailabilityRepository interface {
+	ListWorkingHours(ctx context.Context, userIDs []string) (map[string][]WorkingHours, error)
+	ReplaceWorkingHours(ctx context.Context, userID string, hours []WorkingHours) error
+	CreateOutOfOffice(ctx context.Context, entry OutOfOffice) (OutOfOffice, error)
+	GetOutOfOffice(ctx context.Context, id string) (OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, id string) error
+	ListOutOfOffice(ctx context.Context, filter OutOfOfficeFilter) ([]OutOfOffice, error)
+}
+
+// OutOfOfficeFilter narrows out-of-office queries to users and an overlapping range.
+type OutOfOfficeFilter struct {
+	UserIDs     []string
+	StartsAfter *time.Time
+	EndsBefore  *time.Time
+}
+
+// AvailabilityDirectory exposes participant availability to schedule operations.
+type AvailabilityDirectory interface {
+	ParticipantAvailability(ctx context.Context, userIDs []string, startsAfter, endsBefore *time.Time) (map[string]ParticipantAvailability, error)
+}
+
+// AvailabilityService manages per-user working hours and out-of-office periods.
+type AvailabilityService struct {
+	availability AvailabilityRepository
+	users        UserRepository
+	idGenerator  func() string
+	now          func() time.Time
+	logger       *slog.Logger
+}
+
+// NewAvailabilityService constructs an availability service with the provided dependencies.
+func NewAvailabilityService(availability AvailabilityRepository, users UserRepository, idGenerator func() string, now func() time.Time) *AvailabilityService {
+	return NewAvailabilityServiceWithLogger(availability, users, idGenerator, now, nil)
+}
+
+// NewAvailabilityServiceWithLogger constructs an availability service with a specified logger.
+func NewAvailabilityServiceWithLogger(availability AvailabilityRepository, users UserRepository, idGenerator func() string, now func() time.Time, logger *slog.Logger) *AvailabilityService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &AvailabilityService{
+		availability: availability,
+		users:        users,
+		idGenerator:  idGenerator,
+		now:          now,
+		logger:       defaultLogger(logger),
+	}
+}
+
+func (s *AvailabilityService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "AvailabilityService", operation, attrs...)
+}
+
+// GetWorkingHours returns a user's working hours. Any authenticated principal may
+// read them so that organizers can plan around colleagues.
+func (s *AvailabilityService) GetWorkingHours(ctx context.Context, principal Principal, userID string) ([]WorkingHours, error) {
+	if s == nil {
+		return nil, fmt.Errorf("AvailabilityService is nil")
+	}
+	if s.availability == nil {
+		return nil, fmt.Errorf("availability repository not configured")
+	}
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	hours, err := s.availability.ListWorkingHours(ctx, []string{userID})
+	if err != nil {
+		err = mapAvailabilityRepoError(err)
+		s.loggerWith(ctx, "GetWorkingHours", "principal_id", principal.UserID, "user_id", userID).
+			ErrorContext(ctx, "failed to load working hours", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return sortWorkingHours(hours[userID]), nil
+}
+
+// SetWorkingHours replaces a user's working hours. Users manage their own hours;
+// administrators may manage anyone's. An empty list removes the restriction.
+func (s *AvailabilityService) SetWorkingHours(ctx context.Context, params SetWorkingHoursParams) (hours []WorkingHours, err error) {
+	if s == nil {
+		err = fmt.Errorf("AvailabilityService is nil")
+		return
+	}
+	if s.availability == nil {
+		err = fmt.Errorf("availability repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "SetWorkingHours",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to set working hours", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("window_count", len(hours)).InfoContext(ctx, "working hours updated")
+	}()
+
+	if params.UserID != params.Principal.UserID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	normalized := sortWorkingHours(params.Hours)
+	if vErr := validateWorkingHours(normalized); vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	if err = s.ensureUserExists(ctx, params.UserID); err != nil {
+		return
+	}
+
+	if err = s.availability.ReplaceWorkingHours(ctx, params.UserID, normalized); err != nil {
+		err = mapAvailabilityRepoError(err)
+		return
+	}
+
+	hours = normalized
+	return
+}
+
+// CreateOutOfOffice records an out-of-office period for the user.
+func (s *AvailabilityService) CreateOutOfOffice(ctx context.Context, params CreateOutOfOfficeParams) (entry OutOfOffice, err error) {
+	if s == nil {
+		err = fmt.Errorf("AvailabilityService is nil")
+		return
+	}
+	if s.availability == nil {
+		err = fmt.Errorf("availability repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateOutOfOffice",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create out-of-office period", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("out_of_office_id", entry.ID).InfoContext(ctx, "out-of-office period created")
+	}()
+
+	if params.UserID != params.Principal.UserID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	input := params.Input
+	vErr := &ValidationError{}
+	if input.Start.IsZero() {
+		vErr.add("start", "start is required")
+	}
+	if input.End.IsZero() {
+		vErr.add("end", "end is required")
+	}
+	if !input.Start.IsZero() && !input.End.IsZero() && !input.Start.Before(input.End) {
+		vErr.add("time", "start must be before end")
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	if err = s.ensureUserExists(ctx, params.UserID); err != nil {
+		return
+	}
+
+	createdAt := s.now()
+	entry = OutOfOffice{
+		ID:          s.idGenerator(),
+		UserID:      params.UserID,
+		Start:       input.Start.UTC(),
+		End:         input.End.UTC(),
+		Note:        strings.TrimSpace(input.Note),
+		AutoDecline: input.AutoDecline,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+
+	entry, err = s.availability.CreateOutOfOffice(ctx, entry)
+	if err != nil {
+		err = mapAvailabilityRepoError(err)
+	}
+	return
+}
+
+// DeleteOutOfOffice removes one of the user's out-of-office periods.
+func (s *AvailabilityService) DeleteOutOfOffice(ctx context.Context, principal Principal, userID, entryID string) error {
+	if s == nil {
+		return fmt.Errorf("AvailabilityService is nil")
+	}
+	if s.availability == nil {
+		return fmt.Errorf("availability repository not configured")
+	}
+	if userID != principal.UserID && !principal.IsAdmin {
+		return ErrUnauthorized
+	}
+
+	logger := s.loggerWith(ctx, "DeleteOutOfOffice",
+		"principal_id", principal.UserID,
+		"user_id", userID,
+		"out_of_office_id", entryID,
+	)
+
+	existing, err := s.availability.GetOutOfOffice(ctx, entryID)
+	if err == nil && existing.UserID != userID {
+		err = ErrNotFound
+	}
+	if err == nil {
+		err = s.availability.DeleteOutOfOffice(ctx, entryID)
+	}
+	if err != nil {
+		err = mapAvailabilityRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete out-of-office period", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+
+	logger.InfoContext(ctx, "out-of-office period deleted")
+	return nil
+}
+
+// ListOutOfOffice returns the user's out-of-office periods overlapping the
+// optional range, ordered by start time.
+func (s *AvailabilityService) ListOutOfOffice(ctx context.Context, params ListOutOfOfficeParams) (entries []OutOfOffice, err error) {
+	if s == nil {
+		err = fmt.Errorf("AvailabilityService is nil")
+		return
+	}
+	if s.availability == nil {
+		return nil, nil
+	}
+
+	logger := s.loggerWith(ctx, "ListOutOfOffice",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list out-of-office periods", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(entries)).InfoContext(ctx, "out-of-office periods listed")
+	}()
+
+	if err = s.ensureUserExists(ctx, params.UserID); err != nil {
+		return
+	}
+
+	entries, err = s.availability.ListOutOfOffice(ctx, OutOfOfficeFilter{
+		UserIDs:     []string{params.UserID},
+		StartsAfter: params.StartsAfter,
+		EndsBefore:  params.EndsBefore,
+	})
+	if err != nil {
+		err = mapAvailabilityRepoError(err)
+		return
+	}
+	sortOutOfOffice(entries)
+	return
+}
+
+// ParticipantAvailability gathers working hours, time zones and overlapping
+// out-of-office periods for the given users. Users without any availability
+// data are omitted.
+func (s *AvailabilityService) ParticipantAvailability(ctx context.Context, userIDs []string, startsAfter, endsBefore *time.Time) (map[string]ParticipantAvailability, error) {
+	if s == nil || s.availability == nil {
+		return nil, nil
+	}
+	userIDs = sortStrings(uniqueStrings(userIDs))
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	hours, err := s.availability.ListWorkingHours(ctx, userIDs)
+	if err != nil {
+		return nil, mapAvailabilityRepoError(err)
+	}
+	absences, err := s.availability.ListOutOfOffice(ctx, OutOfOfficeFilter{
+		UserIDs:     userIDs,
+		StartsAfter: startsAfter,
+		EndsBefore:  endsBefore,
+	})
+	if err != nil {
+		return nil, mapAvailabilityRepoError(err)
+	}
+	sortOutOfOffice(absences)
+
+	result := make(map[string]ParticipantAvailability)
+	for userID, windows := range hours {
+		if len(windows) == 0 {
+			continue
+		}
+		profile := result[userID]
+		profile.WorkingHours = sortWorkingHours(windows)
+		result[userID] = profile
+	}
+	for _, absence := range absences {
+		profile := result[absence.UserID]
+		profile.OutOfOffice = append(profile.OutOfOffice, absence)
+		result[absence.UserID] = profile
+	}
+
+	if s.users != nil {
+		for userID, profile := range result {
+			user, err := s.users.GetUser(ctx, userID)
+			if err != nil {
+				if isNotFoundError(err) {
+					continue
+				}
+				return nil, err
+			}
+			profile.TimeZone = user.TimeZone
+			result[userID] = profile
+		}
+	}
+
+	return result, nil
+}
+
+func (s *AvailabilityService) ensureUserExists(ctx context.Context, userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return ErrNotFound
+	}
+	if s.users == nil {
+		return nil
+	}
+	if _, err := s.users.GetUser(ctx, userID); err != nil {
+		return mapAvailabilityRepoError(err)
+	}
+	return nil
+}
+
+func validateWorkingHours(hours []WorkingHours) *ValidationError {
+	vErr := &ValidationError{}
+	for i, window := range hours {
+		if window.Weekday < time.Sunday || window.Weekday > time.Saturday {
+			vErr.add("working_hours", "weekday is invalid")
+			continue
+		}
+		if window.StartMinute < 0 || window.EndMinute > 24*60 || window.StartMinute >= window.EndMinute {
+			vErr.add("working_hours", "working hours must start before they end within a day")
+			continue
+		}
+		if i > 0 && hours[i-1].Weekday == window.Weekday && hours[i-1].EndMinute > window.StartMinute {
+			vErr.add("working_hours", "working hours must not overlap")
+		}
+	}
+	return vErr
+}
+
+func sortWorkingHours(hours []WorkingHours) []WorkingHours {
+	out := make([]WorkingHours, len(hours))
+	copy(out, hours)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Weekday != out[j].Weekday {
+			return out[i].Weekday < out[j].Weekday
+		}
+		return out[i].StartMinute < out[j].StartMinute
+	})
+	return out
+}
+
+func sortOutOfOffice(entries []OutOfOffice) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Start.Equal(entries[j].Start) {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].Start.Before(entries[j].Start)
+	})
+}
+
+func mapAvailabilityRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound) {
+		return ErrNotFound
+	}
+	if errors.Is(err, persistence.ErrConstraintViolation) {
+		vErr := &ValidationError{}
+		vErr.add("time", "start must be before end")
+		return vErr
+	}
+	if errors.Is(err, persistence.ErrForeignKeyViolation) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type availabilityRepoStub struct {
+	hours       map[string][]WorkingHours
+	outOfOffice []OutOfOffice
+	listFilter  OutOfOfficeFilter
+	deletedID   string
+}
+
+func (a *availabilityRepoStub) ListWorkingHours(ctx context.Context, userIDs []string) (map[string][]WorkingHours, error) {
+	out := make(map[string][]WorkingHours)
+	for _, id := range userIDs {
+		if hours, ok := a.hours[id]; ok {
+			out[id] = hours
+		}
+	}
+	return out, nil
+}
+
+func (a *availabilityRepoStub) ReplaceWorkingHours(ctx context.Context, userID string, hours []WorkingHours) error {
+	if a.hours == nil {
+		a.hours = make(map[string][]WorkingHours)
+	}
+	a.hours[userID] = hours
+	return nil
+}
+
+func (a *availabilityRepoStub) CreateOutOfOffice(ctx context.Context, entry OutOfOffice) (OutOfOffice, error) {
+	a.outOfOffice = append(a.outOfOffice, entry)
+	return entry, nil
+}
+
+func (a *availabilityRepoStub) GetOutOfOffice(ctx context.Context, id string) (OutOfOffice, error) {
+	for _, entry := range a.outOfOffice {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return OutOfOffice{}, ErrNotFound
+}
+
+func (a *availabilityRepoStub) DeleteOutOfOffice(ctx context.Context, id string) error {
+	a.deletedID = id
+	return nil
+}
+
+func (a *availabilityRepoStub) ListOutOfOffice(ctx context.Context, filter OutOfOfficeFilter) ([]OutOfOffice, error) {
+	a.listFilter = filter
+	users := make(map[string]struct{}, len(filter.UserIDs))
+	for _, id := range filter.UserIDs {
+		users[id] = struct{}{}
+	}
+	var out []OutOfOffice
+	for _, entry := range a.outOfOffice {
+		if _, ok := users[entry.UserID]; !ok {
+			continue
+		}
+		if filter.StartsAfter != nil && !entry.End.After(*filter.StartsAfter) {
+			continue
+		}
+		if filter.EndsBefore != nil && !entry.Start.Before(*filter.EndsBefore) {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func TestAvailabilityService_SetWorkingHours(t *testing.T) {
+	t.Run("users manage only their own hours", func(t *testing.T) {
+		svc := NewAvailabilityService(&availabilityRepoStub{}, nil, nil, nil)
+		_, err := svc.SetWorkingHours(context.Background(), SetWorkingHoursParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-2",
+			Hours:     []WorkingHours{{Weekday: time.Monday, StartMinute: 9 * 60, EndMinute: 18 * 60}},
+		})
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("validates and sorts windows", func(t *testing.T) {
+		repo := &availabilityRepoStub{}
+		svc := NewAvailabilityService(repo, nil, nil, nil)
+
+		hours, err := svc.SetWorkingHours(context.Background(), SetWorkingHoursParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-1",
+			Hours: []WorkingHours{
+				{Weekday: time.Tuesday, StartMinute: 13 * 60, EndMinute: 18 * 60},
+				{Weekday: time.Monday, StartMinute: 9 * 60, EndMinute: 17 * 60},
+				{Weekday: time.Tuesday, StartMinute: 9 * 60, EndMinute: 12 * 60},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(hours) != 3 || hours[0].Weekday != time.Monday || hours[1].StartMinute != 9*60 {
+			t.Fatalf("expected windows sorted by weekday and start, got %+v", hours)
+		}
+		if len(repo.hours["user-1"]) != 3 {
+			t.Fatalf("expected windows to be persisted, got %+v", repo.hours)
+		}
+
+		_, err = svc.SetWorkingHours(context.Background(), SetWorkingHoursParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-1",
+			Hours: []WorkingHours{
+				{Weekday: time.Monday, StartMinute: 9 * 60, EndMinute: 13 * 60},
+				{Weekday: time.Monday, StartMinute: 12 * 60, EndMinute: 18 * 60},
+			},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["working_hours"] != "working hours must not overlap" {
+			t.Fatalf("expected overlap validation error, got %v", err)
+		}
+
+		_, err = svc.SetWorkingHours(context.Background(), SetWorkingHoursParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-1",
+			Hours:     []WorkingHours{{Weekday: time.Friday, StartMinute: 18 * 60, EndMinute: 9 * 60}},
+		})
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected validation error for reversed window, got %v", err)
+		}
+	})
+}
+
+func TestAvailabilityService_OutOfOffice(t *testing.T) {
+	start := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC)
+
+	t.Run("creates and lists entries", func(t *testing.T) {
+		repo := &availabilityRepoStub{}
+		svc := NewAvailabilityService(repo, nil, func() string { return "ooo-1" }, nil)
+
+		entry, err := svc.CreateOutOfOffice(context.Background(), CreateOutOfOfficeParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-1",
+			Input:     OutOfOfficeInput{Start: start, End: start.AddDate(0, 0, 3), Note: " Summer break ", AutoDecline: true},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if entry.ID != "ooo-1" || entry.Note != "Summer break" || !entry.AutoDecline {
+			t.Fatalf("unexpected entry %+v", entry)
+		}
+
+		entries, err := svc.ListOutOfOffice(context.Background(), ListOutOfOfficeParams{
+			Principal: Principal{UserID: "user-2"},
+			UserID:    "user-1",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(entries) != 1 || entries[0].ID != "ooo-1" {
+			t.Fatalf("expected entry to be listed, got %+v", entries)
+		}
+	})
+
+	t.Run("rejects reversed periods", func(t *testing.T) {
+		svc := NewAvailabilityService(&availabilityRepoStub{}, nil, nil, nil)
+		_, err := svc.CreateOutOfOffice(context.Background(), CreateOutOfOfficeParams{
+			Principal: Principal{UserID: "user-1"},
+			UserID:    "user-1",
+			Input:     OutOfOfficeInput{Start: start, End: start},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["time"] == "" {
+			t.Fatalf("expected time validation error, got %v", err)
+		}
+	})
+
+	t.Run("deletes only the owner's entries", func(t *testing.T) {
+		repo := &availabilityRepoStub{outOfOffice: []OutOfOffice{{ID: "ooo-1", UserID: "user-1", Start: start, End: start.AddDate(0, 0, 1)}}}
+		svc := NewAvailabilityService(repo, nil, nil, nil)
+
+		if err := svc.DeleteOutOfOffice(context.Background(), Principal{UserID: "user-2"}, "user-2", "ooo-1"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound for another user's entry, got %v", err)
+		}
+		if err := svc.DeleteOutOfOffice(context.Background(), Principal{UserID: "user-1"}, "user-1", "ooo-1"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if repo.deletedID != "ooo-1" {
+			t.Fatalf("expected entry to be deleted, got %q", repo.deletedID)
+		}
+	})
+}
+
+func TestAvailabilityService_ParticipantAvailability(t *testing.T) {
+	start := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC)
+	repo := &availabilityRepoStub{
+		hours: map[string][]WorkingHours{"user-1": {{Weekday: time.Monday, StartMinute: 9 * 60, EndMinute: 17 * 60}}},
+		outOfOffice: []OutOfOffice{
+			{ID: "ooo-1", UserID: "user-1", Start: start, End: start.AddDate(0, 0, 1)},
+			{ID: "ooo-2", UserID: "user-3", Start: start.AddDate(0, 1, 0), End: start.AddDate(0, 1, 1)},
+		},
+	}
+	users := &userRepoStub{getUser: User{ID: "user-1", TimeZone: "Europe/London"}}
+	svc := NewAvailabilityService(repo, users, nil, nil)
+
+	rangeEnd := start.AddDate(0, 0, 7)
+	profiles, err := svc.ParticipantAvailability(context.Background(), []string{"user-1", "user-2", "user-3"}, &start, &rangeEnd)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("expected only user-1 to have availability data, got %+v", profiles)
+	}
+	profile := profiles["user-1"]
+	if profile.TimeZone != "Europe/London" || len(profile.WorkingHours) != 1 || len(profile.OutOfOffice) != 1 {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+}
@@ -0,0 +1,796 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/ical"
+)
+
+const (
+	// CalDAVUserCalendarID names the calendar holding a user's own schedules.
+	CalDAVUserCalendarID = "default"
+	// calDAVRoomCalendarPrefix prefixes the calendar ID of a room's bookings.
+	calDAVRoomCalendarPrefix = "room-"
+	// calDAVResourceSuffix ends the name of every calendar object resource.
+	calDAVResourceSuffix = ".ics"
+	// calDAVBusySummary replaces the details of room bookings the caller is not part of.
+	calDAVBusySummary = "予約済み"
+)
+
+// CalDAVObjectRepository remembers the resource names and UIDs CalDAV clients
+// chose for the schedules they created.
+type CalDAVObjectRepository interface {
+	// SaveCalDAVObject inserts or replaces the mapping for object.ScheduleID.
+	SaveCalDAVObject(ctx context.Context, object CalDAVObject) error
+	GetCalDAVObjectByName(ctx context.Context, resourceName string) (CalDAVObject, error)
+	ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]CalDAVObject, error)
+}
+
+// CalDAVSchedules is the schedule API CalDAV requests are mapped onto. It is
+// implemented by ScheduleService.
+type CalDAVSchedules interface {
+	GetSchedule(ctx context.Context, principal Principal, scheduleID string) (Schedule, error)
+	CreateSchedule(ctx context.Context, params CreateScheduleParams) (Schedule, []ConflictWarning, error)
+	UpdateSchedule(ctx context.Context, params UpdateScheduleParams) (Schedule, []ConflictWarning, error)
+	DeleteSchedule(ctx context.Context, principal Principal, scheduleID string) error
+	ListSchedules(ctx context.Context, params ListSchedulesParams) ([]Schedule, []ConflictWarning, error)
+}
+
+// CalDAVRoomDirectory lists the rooms exposed as read-only calendars.
+type CalDAVRoomDirectory interface {
+	GetRoom(ctx context.Context, id string) (Room, error)
+	ListRooms(ctx context.Context) ([]Room, error)
+}
+
+// CalDAVService exposes schedules as CalDAV calendars: each user's own
+// schedules in a writable calendar and each room's bookings in a read-only one.
+// Resources are iCalendar objects; writes go through CalDAVSchedules so the
+// usual validation, authorization and conflict detection apply.
+type CalDAVService struct {
+	schedules   CalDAVSchedules
+	objects     CalDAVObjectRepository
+	recurrences RecurrenceRepository
+	users       CredentialStore
+	rooms       CalDAVRoomDirectory
+	logger      *slog.Logger
+}
+
+// NewCalDAVService wires dependencies for CalDAV operations.
+func NewCalDAVService(schedules CalDAVSchedules, objects CalDAVObjectRepository, recurrences RecurrenceRepository, users CredentialStore, rooms CalDAVRoomDirectory) *CalDAVService {
+	return NewCalDAVServiceWithLogger(schedules, objects, recurrences, users, rooms, nil)
+}
+
+// NewCalDAVServiceWithLogger wires dependencies and allows specifying a logger.
+func NewCalDAVServiceWithLogger(schedules CalDAVSchedules, objects CalDAVObjectRepository, recurrences RecurrenceRepository, users CredentialStore, rooms CalDAVRoomDirectory, logger *slog.Logger) *CalDAVService {
+	return &CalDAVService{
+		schedules:   schedules,
+		objects:     objects,
+		recurrences: recurrences,
+		users:       users,
+		rooms:       rooms,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *CalDAVService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "CalDAVService", operation, attrs...)
+}
+
+// Owner returns the user the principal authenticates as, for the principal
+// resource clients discover first.
+func (s *CalDAVService) Owner(ctx context.Context, principal Principal) (User, error) {
+	if err := s.ready(principal); err != nil {
+		return User{}, err
+	}
+	if s.users == nil {
+		return User{ID: principal.UserID}, nil
+	}
+	user, err := s.users.GetUser(ctx, principal.UserID)
+	if err != nil {
+		if isNotFoundError(err) {
+			err = ErrNotFound
+		}
+		return User{}, err
+	}
+	return user, nil
+}
+
+// Calendars lists the principal's calendar followed by one calendar per room.
+func (s *CalDAVService) Calendars(ctx context.Context, principal Principal) ([]CalDAVCalendar, error) {
+	if err := s.ready(principal); err != nil {
+		return nil, err
+	}
+
+	own, err := s.userCalendar(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+	calendars := []CalDAVCalendar{own}
+	if s.rooms != nil {
+		rooms, err := s.rooms.ListRooms(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range rooms {
+			calendars = append(calendars, roomCalendar(room))
+		}
+	}
+
+	for i := range calendars {
+		if calendars[i].CTag, err = s.calendarTag(ctx, principal, calendars[i]); err != nil {
+			return nil, err
+		}
+	}
+	return calendars, nil
+}
+
+// Calendar returns one of the principal's calendars by ID.
+func (s *CalDAVService) Calendar(ctx context.Context, principal Principal, calendarID string) (CalDAVCalendar, error) {
+	if err := s.ready(principal); err != nil {
+		return CalDAVCalendar{}, err
+	}
+	calendar, err := s.resolveCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return CalDAVCalendar{}, err
+	}
+	calendar.CTag, err = s.calendarTag(ctx, principal, calendar)
+	return calendar, err
+}
+
+// ListResources returns the resources of a calendar overlapping timeRange.
+// Recurring schedules are included when any occurrence overlaps.
+func (s *CalDAVService) ListResources(ctx context.Context, principal Principal, calendarID string, timeRange CalDAVTimeRange) ([]CalDAVResource, error) {
+	if err := s.ready(principal); err != nil {
+		return nil, err
+	}
+	calendar, err := s.resolveCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.calendarSchedules(ctx, principal, calendar, timeRange)
+	if err != nil {
+		s.loggerWith(ctx, "ListResources", "calendar_id", calendarID).ErrorContext(ctx, "failed to list calendar schedules", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return s.render(ctx, principal, calendar, schedules)
+}
+
+// GetResources returns the named resources of a calendar. Names that do not
+// resolve to a schedule in the calendar are omitted.
+func (s *CalDAVService) GetResources(ctx context.Context, principal Principal, calendarID string, names []string) ([]CalDAVResource, error) {
+	if err := s.ready(principal); err != nil {
+		return nil, err
+	}
+	calendar, err := s.resolveCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.findSchedules(ctx, principal, calendar, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.render(ctx, principal, calendar, schedules)
+}
+
+// GetResource returns one resource of a calendar.
+func (s *CalDAVService) GetResource(ctx context.Context, principal Principal, calendarID, name string) (CalDAVResource, error) {
+	resources, err := s.GetResources(ctx, principal, calendarID, []string{name})
+	if err != nil {
+		return CalDAVResource{}, err
+	}
+	if len(resources) == 0 {
+		return CalDAVResource{}, ErrNotFound
+	}
+	return resources[0], nil
+}
+
+// PutResource creates or replaces the schedule stored under a resource name
+// of the principal's calendar. IfMatch and IfNoneMatch are evaluated against
+// the current ETag and fail with ErrPreconditionFailed.
+func (s *CalDAVService) PutResource(ctx context.Context, params PutCalDAVResourceParams) (schedule Schedule, created bool, err error) {
+	principal := params.Principal
+	if err = s.ready(principal); err != nil {
+		return
+	}
+
+	logger := s.loggerWith(ctx, "PutResource",
+		"principal_id", principal.UserID,
+		"calendar_id", params.CalendarID,
+		"resource_name", params.Name,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to store calendar resource", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("schedule_id", schedule.ID, "created", created).InfoContext(ctx, "calendar resource stored")
+	}()
+
+	calendar, err := s.resolveCalendar(ctx, principal, params.CalendarID)
+	if err != nil {
+		return
+	}
+	if calendar.ReadOnly {
+		err = ErrUnauthorized
+		return
+	}
+	if !validResourceName(params.Name) {
+		err = ErrNotFound
+		return
+	}
+
+	event, err := decodeCalDAVEvent(params.Data, locationOrDefault(principal.TimeZone))
+	if err != nil {
+		return
+	}
+
+	found, err := s.findSchedules(ctx, principal, calendar, []string{params.Name})
+	if err != nil {
+		return
+	}
+	var existing *Schedule
+	if len(found) == 1 {
+		existing = &found[0]
+	}
+	if err = checkPreconditions(existing, params.IfMatch, params.IfNoneMatch); err != nil {
+		return
+	}
+
+	creatorID := principal.UserID
+	if existing != nil {
+		creatorID = existing.CreatorID
+	}
+	input, err := s.toScheduleInput(ctx, event, creatorID)
+	if err != nil {
+		return
+	}
+
+	if existing == nil {
+		schedule, _, err = s.schedules.CreateSchedule(ctx, CreateScheduleParams{Principal: principal, Input: input})
+		if err != nil {
+			return
+		}
+		created = true
+	} else {
+		// Events carry no calendar or live groups, so both stay as they are.
+		input.CalendarID = existing.CalendarID
+		input.ParticipantGroupIDs, input.ParticipantGroupMode = existing.ParticipantGroupIDs, ParticipantGroupLive
+		// UpdateSchedule only appends rules, so the rule is replaced here.
+		recurrence := input.Recurrence
+		input.Recurrence = nil
+		schedule, _, err = s.schedules.UpdateSchedule(ctx, UpdateScheduleParams{Principal: principal, ScheduleID: existing.ID, Input: input})
+		if err != nil {
+			return
+		}
+		if s.recurrences != nil {
+			if err = s.recurrences.DeleteRecurrencesForSchedule(ctx, schedule.ID); err != nil {
+				return
+			}
+			if recurrence != nil {
+				if err = s.recurrences.SaveRecurrence(ctx, schedule.ID, schedule.Start, *recurrence); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	if s.objects != nil {
+		err = s.objects.SaveCalDAVObject(ctx, CalDAVObject{ScheduleID: schedule.ID, ResourceName: params.Name, UID: event.UID})
+	}
+	return
+}
+
+// DeleteResource deletes the schedule stored under a resource name of the
+// principal's calendar. A non-empty ifMatch must match the current ETag.
+func (s *CalDAVService) DeleteResource(ctx context.Context, principal Principal, calendarID, name, ifMatch string) (err error) {
+	if err = s.ready(principal); err != nil {
+		return
+	}
+
+	logger := s.loggerWith(ctx, "DeleteResource",
+		"principal_id", principal.UserID,
+		"calendar_id", calendarID,
+		"resource_name", name,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to delete calendar resource", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.InfoContext(ctx, "calendar resource deleted")
+	}()
+
+	calendar, err := s.resolveCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return
+	}
+	if calendar.ReadOnly {
+		err = ErrUnauthorized
+		return
+	}
+
+	found, err := s.findSchedules(ctx, principal, calendar, []string{name})
+	if err != nil {
+		return
+	}
+	if len(found) == 0 {
+		err = ErrNotFound
+		return
+	}
+	if err = checkPreconditions(&found[0], ifMatch, ""); err != nil {
+		return
+	}
+	return s.schedules.DeleteSchedule(ctx, principal, found[0].ID)
+}
+
+// ScheduleETag returns the entity tag of a schedule's calendar resource. It
+// changes whenever the schedule is updated.
+func ScheduleETag(schedule Schedule) string {
+	h := fnv.New64a()
+	h.Write([]byte(schedule.ID))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(schedule.UpdatedAt.Unix(), 10)))
+	return `"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+func (s *CalDAVService) ready(principal Principal) error {
+	if s == nil {
+		return fmt.Errorf("CalDAVService is nil")
+	}
+	if s.schedules == nil {
+		return fmt.Errorf("schedule service not configured")
+	}
+	if principal.UserID == "" {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func (s *CalDAVService) userCalendar(ctx context.Context, principal Principal) (CalDAVCalendar, error) {
+	calendar := CalDAVCalendar{ID: CalDAVUserCalendarID, DisplayName: principal.UserID}
+	if s.users != nil {
+		user, err := s.users.GetUser(ctx, principal.UserID)
+		if err != nil && !isNotFoundError(err) {
+			return CalDAVCalendar{}, err
+		}
+		if user.DisplayName != "" {
+			calendar.DisplayName = user.DisplayName
+		}
+	}
+	return calendar, nil
+}
+
+func roomCalendar(room Room) CalDAVCalendar {
+	return CalDAVCalendar{
+		ID:          calDAVRoomCalendarPrefix + room.ID,
+		DisplayName: room.Name,
+		RoomID:      room.ID,
+		ReadOnly:    true,
+	}
+}
+
+func (s *CalDAVService) resolveCalendar(ctx context.Context, principal Principal, calendarID string) (CalDAVCalendar, error) {
+	if calendarID == CalDAVUserCalendarID {
+		return s.userCalendar(ctx, principal)
+	}
+	roomID, ok := strings.CutPrefix(calendarID, calDAVRoomCalendarPrefix)
+	if !ok || roomID == "" || s.rooms == nil {
+		return CalDAVCalendar{}, ErrNotFound
+	}
+	room, err := s.rooms.GetRoom(ctx, roomID)
+	if err != nil {
+		if isNotFoundError(err) {
+			err = ErrNotFound
+		}
+		return CalDAVCalendar{}, err
+	}
+	return roomCalendar(room), nil
+}
+
+// calendarTag fingerprints the names and ETags of every resource in a calendar.
+func (s *CalDAVService) calendarTag(ctx context.Context, principal Principal, calendar CalDAVCalendar) (string, error) {
+	schedules, err := s.calendarSchedules(ctx, principal, calendar, CalDAVTimeRange{})
+	if err != nil {
+		return "", err
+	}
+	tags := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		tags = append(tags, ScheduleETag(schedule))
+	}
+	sort.Strings(tags)
+	h := fnv.New64a()
+	for _, tag := range tags {
+		h.Write([]byte(tag))
+	}
+	return strconv.FormatUint(h.Sum64(), 36), nil
+}
+
+// calendarSchedules lists a calendar's schedules. Only the end of the range
+// is pushed down so that recurring series which began earlier are kept; the
+// start is applied to the base instance and expanded occurrences here.
+func (s *CalDAVService) calendarSchedules(ctx context.Context, principal Principal, calendar CalDAVCalendar, timeRange CalDAVTimeRange) ([]Schedule, error) {
+	params := ListSchedulesParams{Principal: principal, EndsBefore: timeRange.End}
+	if calendar.RoomID != "" {
+		roomID := calendar.RoomID
+		params = ListSchedulesParams{Principal: Principal{TimeZone: principal.TimeZone}, RoomID: &roomID, EndsBefore: timeRange.End}
+	}
+	schedules, _, err := s.schedules.ListSchedules(ctx, params)
+	if err != nil || timeRange.Start == nil {
+		return schedules, err
+	}
+
+	kept := schedules[:0]
+	for _, schedule := range schedules {
+		if _, end := occupiedInterval(schedule); end.After(*timeRange.Start) {
+			kept = append(kept, schedule)
+			continue
+		}
+		for _, occurrence := range schedule.Occurrences {
+			if occurrence.End.After(*timeRange.Start) {
+				kept = append(kept, schedule)
+				break
+			}
+		}
+	}
+	return kept, nil
+}
+
+// findSchedules resolves resource names to schedules of a calendar, skipping
+// names that do not resolve. A name is either one a client chose when creating
+// the schedule or "{schedule ID}.ics".
+func (s *CalDAVService) findSchedules(ctx context.Context, principal Principal, calendar CalDAVCalendar, names []string) ([]Schedule, error) {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		id, err := s.scheduleIDForName(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if calendar.RoomID != "" {
+		// Room bookings are only reachable through the room listing.
+		bookings, err := s.calendarSchedules(ctx, principal, calendar, CalDAVTimeRange{})
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]Schedule, len(bookings))
+		for _, booking := range bookings {
+			byID[booking.ID] = booking
+		}
+		var found []Schedule
+		for _, id := range ids {
+			if booking, ok := byID[id]; ok {
+				found = append(found, booking)
+			}
+		}
+		return found, nil
+	}
+
+	var found []Schedule
+	for _, id := range ids {
+		schedule, err := s.schedules.GetSchedule(ctx, principal, id)
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if schedule.CreatorID != principal.UserID && !containsString(schedule.ParticipantIDs, principal.UserID) {
+			continue
+		}
+		found = append(found, schedule)
+	}
+	return found, nil
+}
+
+func (s *CalDAVService) scheduleIDForName(ctx context.Context, name string) (string, error) {
+	if !validResourceName(name) {
+		return "", ErrNotFound
+	}
+	if s.objects == nil {
+		return strings.TrimSuffix(name, calDAVResourceSuffix), nil
+	}
+
+	object, err := s.objects.GetCalDAVObjectByName(ctx, name)
+	if err == nil {
+		return object.ScheduleID, nil
+	}
+	if !isNotFoundError(err) {
+		return "", err
+	}
+
+	// Schedules a client named itself are only reachable under that name.
+	id := strings.TrimSuffix(name, calDAVResourceSuffix)
+	objects, err := s.objects.ListCalDAVObjects(ctx, []string{id})
+	if err != nil {
+		return "", err
+	}
+	if len(objects) > 0 {
+		return "", ErrNotFound
+	}
+	return id, nil
+}
+
+func validResourceName(name string) bool {
+	base, ok := strings.CutSuffix(name, calDAVResourceSuffix)
+	return ok && base != "" && !strings.ContainsAny(name, "/\\")
+}
+
+// render encodes schedules as calendar resources. Room bookings the principal
+// takes no part in only reveal their time.
+func (s *CalDAVService) render(ctx context.Context, principal Principal, calendar CalDAVCalendar, schedules []Schedule) ([]CalDAVResource, error) {
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(schedules))
+	for i, schedule := range schedules {
+		ids[i] = schedule.ID
+	}
+
+	objects := map[string]CalDAVObject{}
+	if s.objects != nil {
+		stored, err := s.objects.ListCalDAVObjects(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range stored {
+			objects[object.ScheduleID] = object
+		}
+	}
+
+	var rules map[string][]RecurrenceRule
+	if s.recurrences != nil {
+		var err error
+		if rules, err = s.recurrences.ListRecurrencesForSchedules(ctx, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	roomNames := map[string]string{}
+	if s.rooms != nil {
+		rooms, err := s.rooms.ListRooms(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range rooms {
+			roomNames[room.ID] = room.Name
+		}
+	}
+
+	users := map[string]*User{}
+	resources := make([]CalDAVResource, 0, len(schedules))
+	for _, schedule := range schedules {
+		object, named := objects[schedule.ID]
+		event := ical.Event{
+			UID:          schedule.ID,
+			Summary:      schedule.Title,
+			Description:  schedule.Description,
+			URL:          schedule.WebConferenceURL,
+			Start:        schedule.Start,
+			End:          schedule.End,
+			AllDay:       schedule.AllDay,
+			TimeZone:     schedule.TimeZone,
+			Created:      schedule.CreatedAt,
+			LastModified: schedule.UpdatedAt,
+		}
+		if named {
+			event.UID = object.UID
+		}
+		if schedule.RoomID != nil {
+			event.Location = roomNames[*schedule.RoomID]
+		}
+		if scheduleRules := rules[schedule.ID]; len(scheduleRules) > 0 {
+			event.Recurrence = toICalRecurrence(scheduleRules[0])
+		}
+
+		involved := schedule.CreatorID == principal.UserID || containsString(schedule.ParticipantIDs, principal.UserID)
+		if calendar.ReadOnly && !involved && !principal.IsAdmin {
+			event.Summary = calDAVBusySummary
+			event.Description = ""
+			event.URL = ""
+		} else {
+			organizer, err := s.attendee(ctx, users, schedule.CreatorID)
+			if err != nil {
+				return nil, err
+			}
+			event.Organizer = organizer
+			for _, participantID := range schedule.ParticipantIDs {
+				attendee, err := s.attendee(ctx, users, participantID)
+				if err != nil {
+					return nil, err
+				}
+				if attendee != nil {
+					event.Attendees = append(event.Attendees, *attendee)
+				}
+			}
+		}
+
+		var data bytes.Buffer
+		if err := ical.Encode(&data, []ical.Event{event}); err != nil {
+			return nil, err
+		}
+		name := schedule.ID + calDAVResourceSuffix
+		if named {
+			name = object.ResourceName
+		}
+		resources = append(resources, CalDAVResource{
+			Name:     name,
+			ETag:     ScheduleETag(schedule),
+			Schedule: schedule,
+			Data:     data.Bytes(),
+		})
+	}
+	return resources, nil
+}
+
+func (s *CalDAVService) attendee(ctx context.Context, cache map[string]*User, userID string) (*ical.Attendee, error) {
+	if s.users == nil || userID == "" {
+		return nil, nil
+	}
+	user, ok := cache[userID]
+	if !ok {
+		found, err := s.users.GetUser(ctx, userID)
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+		if err == nil {
+			user = &found
+		}
+		cache[userID] = user
+	}
+	if user == nil || user.Email == "" {
+		return nil, nil
+	}
+	return &ical.Attendee{Email: user.Email, Name: user.DisplayName}, nil
+}
+
+func toICalRecurrence(rule RecurrenceRule) *ical.Recurrence {
+	return &ical.Recurrence{
+		Frequency: strings.ToUpper(rule.Frequency),
+		ByDay:     toTimeWeekdays(rule.Weekdays),
+		Until:     rule.Until,
+	}
+}
+
+// decodeCalDAVEvent reads the single VEVENT of an uploaded resource.
+func decodeCalDAVEvent(data []byte, floating *time.Location) (ical.Event, error) {
+	vErr := &ValidationError{}
+	events, err := ical.Decode(bytes.NewReader(data), floating)
+	switch {
+	case err != nil:
+		vErr.add("calendar_data", "calendar data is invalid")
+	case len(events) != 1:
+		vErr.add("calendar_data", "calendar data must contain exactly one event")
+	case strings.TrimSpace(events[0].UID) == "":
+		vErr.add("uid", "uid is required")
+	}
+	if vErr.HasErrors() {
+		return ical.Event{}, vErr
+	}
+	return events[0], nil
+}
+
+// toScheduleInput maps an event onto schedule fields. Attendees are matched to
+// users by e-mail and unknown addresses are dropped; the location selects the
+// room with the same name.
+func (s *CalDAVService) toScheduleInput(ctx context.Context, event ical.Event, creatorID string) (ScheduleInput, error) {
+	input := ScheduleInput{
+		Title:            strings.TrimSpace(event.Summary),
+		Description:      event.Description,
+		Start:            event.Start,
+		End:              event.End,
+		TimeZone:         event.TimeZone,
+		AllDay:           event.AllDay,
+		WebConferenceURL: strings.TrimSpace(event.URL),
+		ParticipantIDs:   []string{creatorID},
+	}
+	if event.AllDay {
+		// ScheduleInput names the last day; iCalendar names the day after it.
+		input.End = event.End.AddDate(0, 0, -1)
+		if input.End.Before(input.Start) {
+			input.End = input.Start
+		}
+	}
+
+	if s.users != nil {
+		for _, attendee := range event.Attendees {
+			creds, err := s.users.GetUserCredentialsByEmail(ctx, attendee.Email)
+			if isNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				return ScheduleInput{}, err
+			}
+			input.ParticipantIDs = append(input.ParticipantIDs, creds.User.ID)
+		}
+	}
+
+	if location := strings.TrimSpace(event.Location); location != "" && s.rooms != nil {
+		rooms, err := s.rooms.ListRooms(ctx)
+		if err != nil {
+			return ScheduleInput{}, err
+		}
+		for _, room := range rooms {
+			if strings.EqualFold(room.Name, location) {
+				roomID := room.ID
+				input.RoomID = &roomID
+				break
+			}
+		}
+	}
+
+	if event.Recurrence != nil {
+		recurrence, ok := toRecurrenceInput(*event.Recurrence, event.Start)
+		if !ok {
+			vErr := &ValidationError{}
+			vErr.add("recurrence", "recurrence rule is not supported")
+			return ScheduleInput{}, vErr
+		}
+		input.Recurrence = &recurrence
+	}
+	return input, nil
+}
+
+// toRecurrenceInput accepts the weekly weekday rules schedules can store.
+func toRecurrenceInput(rule ical.Recurrence, start time.Time) (RecurrenceInput, bool) {
+	if rule.Frequency != "WEEKLY" || rule.Interval > 1 || rule.Count > 0 {
+		return RecurrenceInput{}, false
+	}
+	days := rule.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{start.Weekday()}
+	}
+	weekdays := make([]string, 0, len(days))
+	for _, day := range days {
+		if day == time.Saturday || day == time.Sunday {
+			return RecurrenceInput{}, false
+		}
+		weekdays = append(weekdays, strings.ToLower(day.String()))
+	}
+	return RecurrenceInput{Frequency: "weekly", Weekdays: weekdays, Until: rule.Until}, true
+}
+
+// checkPreconditions evaluates If-Match and If-None-Match against the current
+// resource, which is nil when the name is unused.
+func checkPreconditions(existing *Schedule, ifMatch, ifNoneMatch string) error {
+	if ifNoneMatch = strings.TrimSpace(ifNoneMatch); ifNoneMatch != "" && existing != nil {
+		if ifNoneMatch == "*" || etagListContains(ifNoneMatch, ScheduleETag(*existing)) {
+			return ErrPreconditionFailed
+		}
+	}
+	if ifMatch = strings.TrimSpace(ifMatch); ifMatch != "" {
+		if existing == nil {
+			return ErrPreconditionFailed
+		}
+		if ifMatch != "*" && !etagListContains(ifMatch, ScheduleETag(*existing)) {
+			return ErrPreconditionFailed
+		}
+	}
+	return nil
+}
+
+func etagListContains(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
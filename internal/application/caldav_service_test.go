@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type calDAVObjectStub struct {
+	objects map[string]CalDAVObject
+}
+
+func (c *calDAVObjectStub) SaveCalDAVObject(ctx context.Context, object CalDAVObject) error {
+	c.objects[object.ScheduleID] = object
+	return nil
+}
+
+func (c *calDAVObjectStub) GetCalDAVObjectByName(ctx context.Context, resourceName string) (CalDAVObject, error) {
+	for _, object := range c.objects {
+		if object.ResourceName == resourceName {
+			return object, nil
+		}
+	}
+	return CalDAVObject{}, ErrNotFound
+}
+
+func (c *calDAVObjectStub) ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]CalDAVObject, error) {
+	var out []CalDAVObject
+	for _, id := range scheduleIDs {
+		if object, ok := c.objects[id]; ok {
+			out = append(out, object)
+		}
+	}
+	return out, nil
+}
+
+type calDAVUsersStub struct {
+	users []User
+}
+
+func (c *calDAVUsersStub) GetUserCredentialsByEmail(ctx context.Context, email string) (UserCredentials, error) {
+	for _, user := range c.users {
+		if strings.EqualFold(user.Email, email) {
+			return UserCredentials{User: user}, nil
+		}
+	}
+	return UserCredentials{}, ErrNotFound
+}
+
+func (c *calDAVUsersStub) GetUser(ctx context.Context, id string) (User, error) {
+	for _, user := range c.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return User{}, ErrNotFound
+}
+
+const calDAVTestEvent = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:client-uid\r\n" +
+	"SUMMARY:%s\r\n" +
+	"DTSTART;TZID=Asia/Tokyo:20240401T100000\r\n" +
+	"DTEND;TZID=Asia/Tokyo:20240401T110000\r\n" +
+	"LOCATION:Room A\r\n" +
+	"ATTENDEE;CN=Bob:mailto:bob@example.com\r\n" +
+	"ATTENDEE:mailto:outsider@example.org\r\n" +
+	"%s" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func calDAVEvent(summary, extra string) []byte {
+	return []byte(strings.Replace(strings.Replace(calDAVTestEvent, "%s", summary, 1), "%s", extra, 1))
+}
+
+func TestCalDAVService(t *testing.T) {
+	clock := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+	ids := 0
+	idGenerator := func() string {
+		ids++
+		return "sched-" + strconv.Itoa(ids)
+	}
+
+	repo := &filteringScheduleRepo{}
+	recurrences := &recurrenceRepoStub{}
+	schedules := NewScheduleService(repo, nil, nil, recurrences, idGenerator, now)
+	objects := &calDAVObjectStub{objects: map[string]CalDAVObject{}}
+	users := &calDAVUsersStub{users: []User{
+		{ID: "alice", Email: "alice@example.com", DisplayName: "Alice"},
+		{ID: "bob", Email: "bob@example.com", DisplayName: "Bob"},
+		{ID: "carol", Email: "carol@example.com", DisplayName: "Carol"},
+	}}
+	rooms := &roomRepoStub{getRoom: Room{ID: "room-a", Name: "Room A"}, list: []Room{{ID: "room-a", Name: "Room A"}}}
+	service := NewCalDAVService(schedules, objects, recurrences, users, rooms)
+	alice := Principal{UserID: "alice", TimeZone: "Asia/Tokyo"}
+	ctx := context.Background()
+
+	schedule, created, err := service.PutResource(ctx, PutCalDAVResourceParams{
+		Principal:   alice,
+		CalendarID:  CalDAVUserCalendarID,
+		Name:        "client.ics",
+		Data:        calDAVEvent("Planning", ""),
+		IfNoneMatch: "*",
+	})
+	if err != nil {
+		t.Fatalf("PutResource returned error: %v", err)
+	}
+	if !created || schedule.CreatorID != "alice" || strings.Join(schedule.ParticipantIDs, ",") != "alice,bob" || schedule.RoomID == nil || *schedule.RoomID != "room-a" {
+		t.Fatalf("unexpected created schedule %#v", schedule)
+	}
+	if !schedule.Start.Equal(time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)) || schedule.TimeZone != "Asia/Tokyo" {
+		t.Fatalf("unexpected schedule time %v (%s)", schedule.Start, schedule.TimeZone)
+	}
+	if objects.objects[schedule.ID] != (CalDAVObject{ScheduleID: schedule.ID, ResourceName: "client.ics", UID: "client-uid"}) {
+		t.Fatalf("expected the client's resource name to be stored, got %#v", objects.objects)
+	}
+
+	t.Run("serves the resource under the client's name", func(t *testing.T) {
+		resource, err := service.GetResource(ctx, alice, CalDAVUserCalendarID, "client.ics")
+		if err != nil {
+			t.Fatalf("GetResource returned error: %v", err)
+		}
+		data := string(resource.Data)
+		for _, want := range []string{"UID:client-uid\r\n", "SUMMARY:Planning\r\n", "LOCATION:Room A\r\n", "ATTENDEE;CN=Bob:mailto:bob@example.com\r\n"} {
+			if !strings.Contains(data, want) {
+				t.Fatalf("expected resource to contain %q:\n%s", want, data)
+			}
+		}
+		if resource.ETag != ScheduleETag(schedule) {
+			t.Fatalf("expected etag %s, got %s", ScheduleETag(schedule), resource.ETag)
+		}
+		if _, err := service.GetResource(ctx, alice, CalDAVUserCalendarID, schedule.ID+".ics"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected the default name to be hidden once a client named the resource, got %v", err)
+		}
+		if _, err := service.GetResource(ctx, Principal{UserID: "carol"}, CalDAVUserCalendarID, "client.ics"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected another user's calendar not to hold the resource, got %v", err)
+		}
+	})
+
+	t.Run("honours conditional headers", func(t *testing.T) {
+		put := PutCalDAVResourceParams{Principal: alice, CalendarID: CalDAVUserCalendarID, Name: "client.ics", Data: calDAVEvent("Planning v2", "")}
+		put.IfNoneMatch = "*"
+		if _, _, err := service.PutResource(ctx, put); !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("expected ErrPreconditionFailed for If-None-Match, got %v", err)
+		}
+		put.IfNoneMatch = ""
+		put.IfMatch = `"stale"`
+		if _, _, err := service.PutResource(ctx, put); !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("expected ErrPreconditionFailed for a stale If-Match, got %v", err)
+		}
+		put.IfMatch = ScheduleETag(schedule)
+		updated, created, err := service.PutResource(ctx, put)
+		if err != nil || created || updated.ID != schedule.ID || updated.Title != "Planning v2" {
+			t.Fatalf("expected an update of %s, got %#v created=%v err=%v", schedule.ID, updated, created, err)
+		}
+		if ScheduleETag(updated) == ScheduleETag(schedule) {
+			t.Fatal("expected the etag to change on update")
+		}
+		schedule = updated
+	})
+
+	t.Run("filters calendar-query by time range", func(t *testing.T) {
+		before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		after := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		resources, err := service.ListResources(ctx, alice, CalDAVUserCalendarID, CalDAVTimeRange{Start: &before, End: &after})
+		if err != nil || len(resources) != 1 || resources[0].Name != "client.ics" {
+			t.Fatalf("expected the resource in range, got %#v (%v)", resources, err)
+		}
+		resources, err = service.ListResources(ctx, alice, CalDAVUserCalendarID, CalDAVTimeRange{Start: &after})
+		if err != nil || len(resources) != 0 {
+			t.Fatalf("expected no resources after the event, got %#v (%v)", resources, err)
+		}
+	})
+
+	t.Run("exposes rooms read-only and masks other people's bookings", func(t *testing.T) {
+		carol := Principal{UserID: "carol"}
+		calendars, err := service.Calendars(ctx, carol)
+		if err != nil || len(calendars) != 2 || calendars[0].DisplayName != "Carol" || calendars[1].ID != "room-room-a" || !calendars[1].ReadOnly {
+			t.Fatalf("unexpected calendars %#v (%v)", calendars, err)
+		}
+		resources, err := service.ListResources(ctx, carol, "room-room-a", CalDAVTimeRange{})
+		if err != nil || len(resources) != 1 {
+			t.Fatalf("expected the room booking, got %#v (%v)", resources, err)
+		}
+		if data := string(resources[0].Data); !strings.Contains(data, "SUMMARY:"+calDAVBusySummary) || strings.Contains(data, "ATTENDEE") {
+			t.Fatalf("expected a masked booking:\n%s", data)
+		}
+		if _, _, err := service.PutResource(ctx, PutCalDAVResourceParams{Principal: carol, CalendarID: "room-room-a", Name: "x.ics", Data: calDAVEvent("x", "")}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized writing to a room calendar, got %v", err)
+		}
+	})
+
+	t.Run("rejects unsupported rules", func(t *testing.T) {
+		var vErr *ValidationError
+		_, _, err := service.PutResource(ctx, PutCalDAVResourceParams{Principal: alice, CalendarID: CalDAVUserCalendarID, Name: "daily.ics", Data: calDAVEvent("Daily", "RRULE:FREQ=DAILY\r\n")})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["recurrence"] != "recurrence rule is not supported" {
+			t.Fatalf("expected a recurrence validation error, got %v", err)
+		}
+	})
+
+	t.Run("deletes with a matching etag", func(t *testing.T) {
+		if err := service.DeleteResource(ctx, alice, CalDAVUserCalendarID, "client.ics", `"stale"`); !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+		}
+		if err := service.DeleteResource(ctx, alice, CalDAVUserCalendarID, "client.ics", ScheduleETag(schedule)); err != nil {
+			t.Fatalf("DeleteResource returned error: %v", err)
+		}
+		if _, err := service.GetResource(ctx, alice, CalDAVUserCalendarID, "client.ics"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after deletion, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,741 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/caldav"
+	"github.com/example/enterprise-scheduler/internal/ical"
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+const (
+	defaultCalDAVSyncRunLimit = 20
+	maxCalDAVSyncRunLimit     = 500
+)
+
+// CalDAVSyncRepository stores sync accounts, the mapping between remote
+// objects and schedules, and the history of sync runs.
+type CalDAVSyncRepository interface {
+	CreateCalDAVSyncAccount(ctx context.Context, account CalDAVSyncAccount) error
+	GetCalDAVSyncAccount(ctx context.Context, id string) (CalDAVSyncAccount, error)
+	// ListCalDAVSyncAccounts lists a user's accounts, or every account when
+	// userID is empty.
+	ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]CalDAVSyncAccount, error)
+	DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error
+	ListCalDAVSyncItems(ctx context.Context, accountID string) ([]CalDAVSyncItem, error)
+	// SaveCalDAVSyncItem inserts or replaces the mapping for item.RemoteHref.
+	SaveCalDAVSyncItem(ctx context.Context, item CalDAVSyncItem) error
+	DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error
+	CreateCalDAVSyncRun(ctx context.Context, run CalDAVSyncRun) error
+	// ListCalDAVSyncRuns returns an account's most recent runs first.
+	ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]CalDAVSyncRun, error)
+}
+
+// RemoteCalendarClient reads and writes objects of external CalDAV
+// collections. It is implemented by caldav.Client.
+type RemoteCalendarClient interface {
+	List(ctx context.Context, collection caldav.Collection) ([]caldav.Object, error)
+	Fetch(ctx context.Context, collection caldav.Collection, hrefs []string) ([]caldav.Object, error)
+	Put(ctx context.Context, collection caldav.Collection, href string, data []byte, etag string) (string, error)
+	Delete(ctx context.Context, collection caldav.Collection, href, etag string) error
+}
+
+// CalDAVSyncCalendar is the local side of a sync. It is implemented by
+// CalDAVService, whose writes go through ScheduleService.
+type CalDAVSyncCalendar interface {
+	ListResources(ctx context.Context, principal Principal, calendarID string, timeRange CalDAVTimeRange) ([]CalDAVResource, error)
+	PutResource(ctx context.Context, params PutCalDAVResourceParams) (Schedule, bool, error)
+	DeleteResource(ctx context.Context, principal Principal, calendarID, name, ifMatch string) error
+}
+
+// CalDAVSyncService keeps users' calendars in two-way sync with collections
+// on external CalDAV servers. Each run compares both sides with the ETags
+// recorded at the previous run, applies one-sided changes and settles
+// objects changed on both sides with the account's conflict policy.
+type CalDAVSyncService struct {
+	accounts    CalDAVSyncRepository
+	remote      RemoteCalendarClient
+	calendar    CalDAVSyncCalendar
+	users       CredentialStore
+	idGenerator func() string
+	now         func() time.Time
+	logger      *slog.Logger
+
+	// mu serialises runs so scheduled and manual syncs of an account never
+	// interleave.
+	mu sync.Mutex
+}
+
+// NewCalDAVSyncService constructs a sync service with the provided dependencies.
+func NewCalDAVSyncService(accounts CalDAVSyncRepository, remote RemoteCalendarClient, calendar CalDAVSyncCalendar, users CredentialStore, idGenerator func() string, now func() time.Time) *CalDAVSyncService {
+	return NewCalDAVSyncServiceWithLogger(accounts, remote, calendar, users, idGenerator, now, nil)
+}
+
+// NewCalDAVSyncServiceWithLogger constructs a sync service with a specified logger.
+func NewCalDAVSyncServiceWithLogger(accounts CalDAVSyncRepository, remote RemoteCalendarClient, calendar CalDAVSyncCalendar, users CredentialStore, idGenerator func() string, now func() time.Time, logger *slog.Logger) *CalDAVSyncService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &CalDAVSyncService{
+		accounts:    accounts,
+		remote:      remote,
+		calendar:    calendar,
+		users:       users,
+		idGenerator: idGenerator,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *CalDAVSyncService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "CalDAVSyncService", operation, attrs...)
+}
+
+// CreateSyncAccount connects a user's calendar to a remote collection.
+// Accounts reach out to arbitrary servers, so only administrators manage them.
+func (s *CalDAVSyncService) CreateSyncAccount(ctx context.Context, params CreateCalDAVSyncAccountParams) (account CalDAVSyncAccount, err error) {
+	if s == nil {
+		err = fmt.Errorf("CalDAVSyncService is nil")
+		return
+	}
+	if s.accounts == nil {
+		err = fmt.Errorf("caldav sync repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateSyncAccount",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create sync account", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("sync_account_id", account.ID).InfoContext(ctx, "sync account created")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	input, vErr := normalizeCalDAVSyncAccountInput(params.Input)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	now := s.now()
+	account = CalDAVSyncAccount{
+		ID:             s.idGenerator(),
+		UserID:         params.UserID,
+		CollectionURL:  input.CollectionURL,
+		Username:       input.Username,
+		Password:       input.Password,
+		ConflictPolicy: input.ConflictPolicy,
+		Active:         input.Active == nil || *input.Active,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err = s.accounts.CreateCalDAVSyncAccount(ctx, account); err != nil {
+		err = mapCalDAVSyncRepoError(err)
+	}
+	return
+}
+
+// ListSyncAccounts lists a user's sync accounts without their passwords.
+func (s *CalDAVSyncService) ListSyncAccounts(ctx context.Context, principal Principal, userID string) ([]CalDAVSyncAccount, error) {
+	if s == nil {
+		return nil, fmt.Errorf("CalDAVSyncService is nil")
+	}
+	if s.accounts == nil {
+		return nil, fmt.Errorf("caldav sync repository not configured")
+	}
+	if userID != principal.UserID && !principal.IsAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	accounts, err := s.accounts.ListCalDAVSyncAccounts(ctx, userID)
+	if err != nil {
+		err = mapCalDAVSyncRepoError(err)
+		s.loggerWith(ctx, "ListSyncAccounts", "user_id", userID).ErrorContext(ctx, "failed to list sync accounts", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	for i := range accounts {
+		accounts[i].Password = ""
+	}
+	return accounts, nil
+}
+
+// DeleteSyncAccount disconnects a remote collection. Schedules and remote
+// objects are left as they are.
+func (s *CalDAVSyncService) DeleteSyncAccount(ctx context.Context, principal Principal, userID, accountID string) error {
+	if s == nil {
+		return fmt.Errorf("CalDAVSyncService is nil")
+	}
+	if s.accounts == nil {
+		return fmt.Errorf("caldav sync repository not configured")
+	}
+	if !principal.IsAdmin {
+		return ErrUnauthorized
+	}
+
+	logger := s.loggerWith(ctx, "DeleteSyncAccount",
+		"principal_id", principal.UserID,
+		"user_id", userID,
+		"sync_account_id", accountID,
+	)
+	if err := s.accounts.DeleteCalDAVSyncAccount(ctx, userID, accountID); err != nil {
+		err = mapCalDAVSyncRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete sync account", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "sync account deleted")
+	return nil
+}
+
+// ListSyncRuns returns an account's most recent runs first. A zero limit
+// defaults to 20.
+func (s *CalDAVSyncService) ListSyncRuns(ctx context.Context, principal Principal, userID, accountID string, limit int) ([]CalDAVSyncRun, error) {
+	if s == nil {
+		return nil, fmt.Errorf("CalDAVSyncService is nil")
+	}
+	if limit == 0 {
+		limit = defaultCalDAVSyncRunLimit
+	}
+	if limit < 1 || limit > maxCalDAVSyncRunLimit {
+		vErr := &ValidationError{}
+		vErr.add("limit", "limit must be between 1 and 500")
+		return nil, vErr
+	}
+
+	if _, err := s.ownedAccount(ctx, principal, userID, accountID); err != nil {
+		return nil, err
+	}
+	runs, err := s.accounts.ListCalDAVSyncRuns(ctx, accountID, limit)
+	if err != nil {
+		err = mapCalDAVSyncRepoError(err)
+		s.loggerWith(ctx, "ListSyncRuns", "sync_account_id", accountID).ErrorContext(ctx, "failed to list sync runs", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return runs, nil
+}
+
+// SyncNow syncs one account immediately, whether or not it is active, and
+// returns the recorded run.
+func (s *CalDAVSyncService) SyncNow(ctx context.Context, principal Principal, userID, accountID string) (CalDAVSyncRun, error) {
+	if s == nil {
+		return CalDAVSyncRun{}, fmt.Errorf("CalDAVSyncService is nil")
+	}
+	account, err := s.ownedAccount(ctx, principal, userID, accountID)
+	if err != nil {
+		return CalDAVSyncRun{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.syncAccount(ctx, account)
+}
+
+// SyncAll syncs every active account and returns the number of runs.
+func (s *CalDAVSyncService) SyncAll(ctx context.Context) (int, error) {
+	if s == nil {
+		return 0, fmt.Errorf("CalDAVSyncService is nil")
+	}
+	if s.accounts == nil {
+		return 0, fmt.Errorf("caldav sync repository not configured")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts, err := s.accounts.ListCalDAVSyncAccounts(ctx, "")
+	if err != nil {
+		err = mapCalDAVSyncRepoError(err)
+		s.loggerWith(ctx, "SyncAll").ErrorContext(ctx, "failed to list sync accounts", "error", err, "error_kind", ErrorKind(err))
+		return 0, err
+	}
+	runs := 0
+	for _, account := range accounts {
+		if !account.Active {
+			continue
+		}
+		if ctx.Err() != nil {
+			return runs, ctx.Err()
+		}
+		if _, err := s.syncAccount(ctx, account); err != nil {
+			return runs, err
+		}
+		runs++
+	}
+	return runs, nil
+}
+
+// Run syncs all active accounts every interval until ctx is cancelled.
+func (s *CalDAVSyncService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Failures are recorded per run and retried on the next tick.
+		_, _ = s.SyncAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *CalDAVSyncService) ownedAccount(ctx context.Context, principal Principal, userID, accountID string) (CalDAVSyncAccount, error) {
+	if s.accounts == nil {
+		return CalDAVSyncAccount{}, fmt.Errorf("caldav sync repository not configured")
+	}
+	if userID != principal.UserID && !principal.IsAdmin {
+		return CalDAVSyncAccount{}, ErrUnauthorized
+	}
+	account, err := s.accounts.GetCalDAVSyncAccount(ctx, accountID)
+	if err != nil {
+		return CalDAVSyncAccount{}, mapCalDAVSyncRepoError(err)
+	}
+	if account.UserID != userID {
+		return CalDAVSyncAccount{}, ErrNotFound
+	}
+	return account, nil
+}
+
+// syncAccount performs one run for account and records it. The returned
+// error only reports a failure to record the run; sync failures are part of
+// the run.
+func (s *CalDAVSyncService) syncAccount(ctx context.Context, account CalDAVSyncAccount) (CalDAVSyncRun, error) {
+	logger := s.loggerWith(ctx, "SyncAccount", "sync_account_id", account.ID, "user_id", account.UserID)
+	run := CalDAVSyncRun{ID: s.idGenerator(), AccountID: account.ID, StartedAt: s.now()}
+
+	if err := s.sync(ctx, account, &run); err != nil {
+		run.Status = CalDAVSyncFailed
+		run.Error = err.Error()
+		logger.ErrorContext(ctx, "calendar sync failed", "error", err, "error_kind", ErrorKind(err))
+	} else if run.Failures > 0 {
+		run.Status = CalDAVSyncPartial
+	} else {
+		run.Status = CalDAVSyncSucceeded
+	}
+	run.FinishedAt = s.now()
+
+	logger.With(
+		"status", string(run.Status),
+		"pulled_created", run.Pulled.Created,
+		"pulled_updated", run.Pulled.Updated,
+		"pulled_deleted", run.Pulled.Deleted,
+		"pushed_created", run.Pushed.Created,
+		"pushed_updated", run.Pushed.Updated,
+		"pushed_deleted", run.Pushed.Deleted,
+		"conflict_count", run.Conflicts,
+		"skipped_count", run.Skipped,
+		"failure_count", run.Failures,
+	).InfoContext(ctx, "calendar sync finished")
+
+	if err := s.accounts.CreateCalDAVSyncRun(ctx, run); err != nil {
+		err = mapCalDAVSyncRepoError(err)
+		logger.ErrorContext(ctx, "failed to record sync run", "error", err, "error_kind", ErrorKind(err))
+		return run, err
+	}
+	return run, nil
+}
+
+// calDAVSync carries the state of one run: both sides as listed at its start
+// and the mappings recorded by the previous run.
+type calDAVSync struct {
+	service    *CalDAVSyncService
+	account    CalDAVSyncAccount
+	collection caldav.Collection
+	principal  Principal
+	run        *CalDAVSyncRun
+	logger     *slog.Logger
+
+	local   map[string]CalDAVResource // by schedule ID
+	remote  map[string]string         // ETag by href
+	fetched map[string]caldav.Object  // data of new and changed remote objects
+}
+
+func (s *CalDAVSyncService) sync(ctx context.Context, account CalDAVSyncAccount, run *CalDAVSyncRun) error {
+	if s.remote == nil || s.calendar == nil || s.users == nil {
+		return fmt.Errorf("caldav sync dependencies not configured")
+	}
+
+	user, err := s.users.GetUser(ctx, account.UserID)
+	if err != nil {
+		return fmt.Errorf("load user: %w", err)
+	}
+	state := &calDAVSync{
+		service:    s,
+		account:    account,
+		collection: caldav.Collection{URL: account.CollectionURL, Username: account.Username, Password: account.Password},
+		principal:  Principal{UserID: user.ID, IsAdmin: user.IsAdmin, TimeZone: user.TimeZone},
+		run:        run,
+		logger:     s.loggerWith(ctx, "SyncAccount", "sync_account_id", account.ID, "user_id", account.UserID),
+		local:      map[string]CalDAVResource{},
+		remote:     map[string]string{},
+		fetched:    map[string]caldav.Object{},
+	}
+
+	resources, err := s.calendar.ListResources(ctx, state.principal, CalDAVUserCalendarID, CalDAVTimeRange{})
+	if err != nil {
+		return fmt.Errorf("list schedules: %w", err)
+	}
+	for _, resource := range resources {
+		state.local[resource.Schedule.ID] = resource
+	}
+
+	objects, err := s.remote.List(ctx, state.collection)
+	if err != nil {
+		return fmt.Errorf("list remote collection: %w", err)
+	}
+	for _, object := range objects {
+		state.remote[object.Href] = object.ETag
+	}
+
+	items, err := s.accounts.ListCalDAVSyncItems(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("list sync items: %w", mapCalDAVSyncRepoError(err))
+	}
+	mapped := make(map[string]CalDAVSyncItem, len(items))
+	for _, item := range items {
+		mapped[item.RemoteHref] = item
+	}
+
+	// Only new and changed remote objects are fetched; they are the ones
+	// pulled or weighed in a conflict.
+	var hrefs []string
+	for _, object := range objects {
+		if item, ok := mapped[object.Href]; !ok || item.RemoteETag != object.ETag {
+			hrefs = append(hrefs, object.Href)
+		}
+	}
+	if len(hrefs) > 0 {
+		fetched, err := s.remote.Fetch(ctx, state.collection, hrefs)
+		if err != nil {
+			return fmt.Errorf("fetch remote objects: %w", err)
+		}
+		for _, object := range fetched {
+			state.fetched[object.Href] = object
+		}
+	}
+
+	synced := make(map[string]bool, len(items))
+	for _, item := range items {
+		synced[item.ScheduleID] = true
+		state.syncItem(ctx, item)
+	}
+	for _, object := range objects {
+		if _, ok := mapped[object.Href]; !ok {
+			state.pullCreate(ctx, object.Href)
+		}
+	}
+	for _, resource := range resources {
+		if synced[resource.Schedule.ID] {
+			continue
+		}
+		href, err := state.collection.Href(resource.Name)
+		if err != nil {
+			state.fail(ctx, "push", resource.Name, err)
+			continue
+		}
+		if _, taken := state.remote[href]; taken {
+			// The remote holds an unrelated object under the same name; it is
+			// imported above and this schedule is pushed on a later run.
+			state.fail(ctx, "push", href, caldav.ErrPreconditionFailed)
+			continue
+		}
+		state.push(ctx, CalDAVSyncItem{AccountID: account.ID, RemoteHref: href}, resource, "", &run.Pushed.Created)
+	}
+	return nil
+}
+
+// syncItem reconciles one previously synced object.
+func (c *calDAVSync) syncItem(ctx context.Context, item CalDAVSyncItem) {
+	remoteETag, remoteExists := c.remote[item.RemoteHref]
+	remoteChanged := remoteExists && remoteETag != item.RemoteETag
+
+	if item.ScheduleID == "" {
+		// A remote object that could not be imported is retried once it changes.
+		switch {
+		case !remoteExists:
+			c.forget(ctx, item)
+		case remoteChanged:
+			c.pullCreate(ctx, item.RemoteHref)
+		}
+		return
+	}
+
+	resource, localExists := c.local[item.ScheduleID]
+	localChanged := localExists && resource.ETag != item.LocalETag
+
+	switch {
+	case !localExists && !remoteExists:
+		c.forget(ctx, item)
+	case !localExists:
+		if remoteChanged {
+			c.run.Conflicts++
+			// A deleted schedule has no modification time, so latest keeps the edit.
+			if c.account.ConflictPolicy != CalDAVSyncLocalWins {
+				c.pullCreate(ctx, item.RemoteHref)
+				return
+			}
+		}
+		c.deleteRemote(ctx, item, remoteETag)
+	case !remoteExists:
+		if localChanged {
+			c.run.Conflicts++
+			if c.account.ConflictPolicy != CalDAVSyncRemoteWins {
+				c.push(ctx, item, resource, "", &c.run.Pushed.Created)
+				return
+			}
+		}
+		c.deleteLocal(ctx, item, resource)
+	case localChanged && remoteChanged:
+		c.run.Conflicts++
+		if c.localWins(item, resource) {
+			c.push(ctx, item, resource, remoteETag, &c.run.Pushed.Updated)
+			return
+		}
+		c.pullUpdate(ctx, item, resource)
+	case localChanged:
+		c.push(ctx, item, resource, item.RemoteETag, &c.run.Pushed.Updated)
+	case remoteChanged:
+		c.pullUpdate(ctx, item, resource)
+	}
+}
+
+// localWins settles an object changed on both sides.
+func (c *calDAVSync) localWins(item CalDAVSyncItem, resource CalDAVResource) bool {
+	switch c.account.ConflictPolicy {
+	case CalDAVSyncLocalWins:
+		return true
+	case CalDAVSyncRemoteWins:
+		return false
+	}
+	object, ok := c.fetched[item.RemoteHref]
+	if !ok {
+		return true
+	}
+	event, ok := calendarObjectEvent(object.Data)
+	if !ok || event.LastModified.IsZero() {
+		return false
+	}
+	return resource.Schedule.UpdatedAt.After(event.LastModified)
+}
+
+// pullCreate imports a remote object as a new schedule.
+func (c *calDAVSync) pullCreate(ctx context.Context, href string) {
+	object, ok := c.fetched[href]
+	if !ok {
+		// Deleted between listing and fetching; the next run forgets it.
+		return
+	}
+	item := CalDAVSyncItem{AccountID: c.account.ID, RemoteHref: href, RemoteETag: object.ETag}
+	if event, ok := calendarObjectEvent(object.Data); ok {
+		item.RemoteUID = event.UID
+	}
+
+	schedule, _, err := c.service.calendar.PutResource(ctx, PutCalDAVResourceParams{
+		Principal:   c.principal,
+		CalendarID:  CalDAVUserCalendarID,
+		Name:        c.service.idGenerator() + calDAVResourceSuffix,
+		Data:        object.Data,
+		IfNoneMatch: "*",
+	})
+	if err != nil {
+		if !c.skip(ctx, href, err) {
+			return
+		}
+		c.save(ctx, item)
+		return
+	}
+	item.ScheduleID = schedule.ID
+	item.LocalETag = ScheduleETag(schedule)
+	if c.save(ctx, item) {
+		c.run.Pulled.Created++
+	}
+}
+
+// pullUpdate applies a changed remote object to its schedule.
+func (c *calDAVSync) pullUpdate(ctx context.Context, item CalDAVSyncItem, resource CalDAVResource) {
+	object, ok := c.fetched[item.RemoteHref]
+	if !ok {
+		return
+	}
+	item.RemoteETag = object.ETag
+	if event, ok := calendarObjectEvent(object.Data); ok {
+		item.RemoteUID = event.UID
+	}
+
+	schedule, _, err := c.service.calendar.PutResource(ctx, PutCalDAVResourceParams{
+		Principal:  c.principal,
+		CalendarID: CalDAVUserCalendarID,
+		Name:       resource.Name,
+		Data:       object.Data,
+		IfMatch:    resource.ETag,
+	})
+	if err != nil {
+		if !c.skip(ctx, item.RemoteHref, err) {
+			return
+		}
+		// The remote change is dropped; the schedule is pushed again when it
+		// next changes.
+		item.LocalETag = resource.ETag
+		c.save(ctx, item)
+		return
+	}
+	item.LocalETag = ScheduleETag(schedule)
+	if c.save(ctx, item) {
+		c.run.Pulled.Updated++
+	}
+}
+
+// push writes a schedule to the remote collection. An empty etag creates
+// the object; otherwise it replaces the object holding etag.
+func (c *calDAVSync) push(ctx context.Context, item CalDAVSyncItem, resource CalDAVResource, etag string, counter *int) {
+	newETag, err := c.service.remote.Put(ctx, c.collection, item.RemoteHref, resource.Data, etag)
+	if err != nil {
+		c.fail(ctx, "push", item.RemoteHref, err)
+		return
+	}
+	item.RemoteETag = newETag
+	item.ScheduleID = resource.Schedule.ID
+	item.LocalETag = resource.ETag
+	if event, ok := calendarObjectEvent(resource.Data); ok {
+		item.RemoteUID = event.UID
+	}
+	if c.save(ctx, item) {
+		*counter++
+	}
+}
+
+// deleteLocal deletes the schedule of an object deleted remotely.
+func (c *calDAVSync) deleteLocal(ctx context.Context, item CalDAVSyncItem, resource CalDAVResource) {
+	err := c.service.calendar.DeleteResource(ctx, c.principal, CalDAVUserCalendarID, resource.Name, resource.ETag)
+	switch {
+	case err == nil:
+		c.run.Pulled.Deleted++
+	case isNotFoundError(err):
+	case errors.Is(err, ErrUnauthorized):
+		// Only the creator may delete the schedule; it stays on this calendar
+		// and is pushed again as a new object.
+		c.run.Skipped++
+	default:
+		c.fail(ctx, "delete local", item.RemoteHref, err)
+		return
+	}
+	c.forget(ctx, item)
+}
+
+// deleteRemote deletes the remote object of a schedule deleted locally.
+func (c *calDAVSync) deleteRemote(ctx context.Context, item CalDAVSyncItem, etag string) {
+	err := c.service.remote.Delete(ctx, c.collection, item.RemoteHref, etag)
+	switch {
+	case err == nil:
+		c.run.Pushed.Deleted++
+	case errors.Is(err, caldav.ErrNotFound):
+	default:
+		c.fail(ctx, "delete remote", item.RemoteHref, err)
+		return
+	}
+	c.forget(ctx, item)
+}
+
+// skip counts a remote object the calendar rejected, such as one with an
+// unsupported recurrence rule or a schedule the user may not edit, and
+// reports whether the rejection is permanent. Other errors are failures.
+func (c *calDAVSync) skip(ctx context.Context, href string, err error) bool {
+	var vErr *ValidationError
+	if errors.As(err, &vErr) || errors.Is(err, ErrUnauthorized) {
+		c.run.Skipped++
+		c.logger.WarnContext(ctx, "remote calendar object skipped", "remote_href", href, "error", err, "error_kind", ErrorKind(err))
+		return true
+	}
+	c.fail(ctx, "pull", href, err)
+	return false
+}
+
+func (c *calDAVSync) save(ctx context.Context, item CalDAVSyncItem) bool {
+	if err := c.service.accounts.SaveCalDAVSyncItem(ctx, item); err != nil {
+		c.fail(ctx, "save mapping", item.RemoteHref, mapCalDAVSyncRepoError(err))
+		return false
+	}
+	return true
+}
+
+func (c *calDAVSync) forget(ctx context.Context, item CalDAVSyncItem) {
+	if err := c.service.accounts.DeleteCalDAVSyncItem(ctx, item.AccountID, item.RemoteHref); err != nil {
+		c.fail(ctx, "delete mapping", item.RemoteHref, mapCalDAVSyncRepoError(err))
+	}
+}
+
+// fail counts an object that could not be synced. It keeps its mapping and
+// is retried on the next run.
+func (c *calDAVSync) fail(ctx context.Context, step, href string, err error) {
+	c.run.Failures++
+	if c.run.Error == "" {
+		c.run.Error = fmt.Sprintf("%s %s: %v", step, href, err)
+	}
+	c.logger.WarnContext(ctx, "calendar object sync failed", "step", step, "remote_href", href, "error", err, "error_kind", ErrorKind(err))
+}
+
+// calendarObjectEvent returns the event of an iCalendar object, for its UID
+// and modification time.
+func calendarObjectEvent(data []byte) (ical.Event, bool) {
+	events, err := ical.Decode(strings.NewReader(string(data)), time.UTC)
+	if err != nil || len(events) == 0 {
+		return ical.Event{}, false
+	}
+	return events[0], true
+}
+
+func normalizeCalDAVSyncAccountInput(input CalDAVSyncAccountInput) (CalDAVSyncAccountInput, *ValidationError) {
+	vErr := &ValidationError{}
+
+	input.CollectionURL = strings.TrimSpace(input.CollectionURL)
+	if input.CollectionURL == "" {
+		vErr.add("collection_url", "url is required")
+	} else if parsed, err := url.Parse(input.CollectionURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		vErr.add("collection_url", "url must be an absolute http or https URL")
+	}
+	input.Username = strings.TrimSpace(input.Username)
+
+	switch input.ConflictPolicy {
+	case "":
+		input.ConflictPolicy = CalDAVSyncLatestWins
+	case CalDAVSyncRemoteWins, CalDAVSyncLocalWins, CalDAVSyncLatestWins:
+	default:
+		vErr.add("conflict_policy", "conflict policy is invalid")
+	}
+
+	return input, vErr
+}
+
+func mapCalDAVSyncRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrForeignKeyViolation):
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,326 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/caldav"
+	"github.com/example/enterprise-scheduler/internal/caldav/caldavtest"
+)
+
+type calDAVSyncRepoStub struct {
+	accounts []CalDAVSyncAccount
+	items    map[string]CalDAVSyncItem
+	runs     []CalDAVSyncRun
+}
+
+func (c *calDAVSyncRepoStub) CreateCalDAVSyncAccount(ctx context.Context, account CalDAVSyncAccount) error {
+	c.accounts = append(c.accounts, account)
+	return nil
+}
+
+func (c *calDAVSyncRepoStub) GetCalDAVSyncAccount(ctx context.Context, id string) (CalDAVSyncAccount, error) {
+	for _, account := range c.accounts {
+		if account.ID == id {
+			return account, nil
+		}
+	}
+	return CalDAVSyncAccount{}, ErrNotFound
+}
+
+func (c *calDAVSyncRepoStub) ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]CalDAVSyncAccount, error) {
+	var out []CalDAVSyncAccount
+	for _, account := range c.accounts {
+		if userID == "" || account.UserID == userID {
+			out = append(out, account)
+		}
+	}
+	return out, nil
+}
+
+func (c *calDAVSyncRepoStub) DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error {
+	for i, account := range c.accounts {
+		if account.ID == id && account.UserID == userID {
+			c.accounts = append(c.accounts[:i], c.accounts[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (c *calDAVSyncRepoStub) ListCalDAVSyncItems(ctx context.Context, accountID string) ([]CalDAVSyncItem, error) {
+	var out []CalDAVSyncItem
+	for _, item := range c.items {
+		if item.AccountID == accountID {
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RemoteHref < out[j].RemoteHref })
+	return out, nil
+}
+
+func (c *calDAVSyncRepoStub) SaveCalDAVSyncItem(ctx context.Context, item CalDAVSyncItem) error {
+	c.items[item.AccountID+" "+item.RemoteHref] = item
+	return nil
+}
+
+func (c *calDAVSyncRepoStub) DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error {
+	delete(c.items, accountID+" "+remoteHref)
+	return nil
+}
+
+func (c *calDAVSyncRepoStub) CreateCalDAVSyncRun(ctx context.Context, run CalDAVSyncRun) error {
+	c.runs = append(c.runs, run)
+	return nil
+}
+
+func (c *calDAVSyncRepoStub) ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]CalDAVSyncRun, error) {
+	var out []CalDAVSyncRun
+	for i := len(c.runs) - 1; i >= 0 && len(out) < limit; i-- {
+		if c.runs[i].AccountID == accountID {
+			out = append(out, c.runs[i])
+		}
+	}
+	return out, nil
+}
+
+func remoteEvent(uid, summary string, lastModified time.Time) []byte {
+	return []byte(fmt.Sprintf("BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:%s\r\n"+
+		"SUMMARY:%s\r\n"+
+		"DTSTART:20240402T010000Z\r\n"+
+		"DTEND:20240402T020000Z\r\n"+
+		"LAST-MODIFIED:%s\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n", uid, summary, lastModified.UTC().Format("20060102T150405Z")))
+}
+
+func TestCalDAVSyncService(t *testing.T) {
+	clock := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+	ids := 0
+	idGenerator := func() string {
+		ids++
+		return "id-" + strconv.Itoa(ids)
+	}
+
+	repo := &filteringScheduleRepo{}
+	recurrences := &recurrenceRepoStub{}
+	schedules := NewScheduleService(repo, nil, nil, recurrences, idGenerator, now)
+	users := &calDAVUsersStub{users: []User{
+		{ID: "alice", Email: "alice@example.com", DisplayName: "Alice"},
+		{ID: "bob", Email: "bob@example.com", DisplayName: "Bob"},
+	}}
+	calendar := NewCalDAVService(schedules, &calDAVObjectStub{objects: map[string]CalDAVObject{}}, recurrences, users, &roomRepoStub{})
+	syncRepo := &calDAVSyncRepoStub{items: map[string]CalDAVSyncItem{}}
+	service := NewCalDAVSyncService(syncRepo, caldav.NewClient(nil), calendar, users, idGenerator, now)
+
+	server := caldavtest.NewServer()
+	t.Cleanup(server.Close)
+
+	admin := Principal{UserID: "admin", IsAdmin: true}
+	alice := Principal{UserID: "alice"}
+	ctx := context.Background()
+
+	t.Run("validates accounts and restricts them to administrators", func(t *testing.T) {
+		input := CalDAVSyncAccountInput{CollectionURL: server.Collection().URL}
+		if _, err := service.CreateSyncAccount(ctx, CreateCalDAVSyncAccountParams{Principal: alice, UserID: "alice", Input: input}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		_, err := service.CreateSyncAccount(ctx, CreateCalDAVSyncAccountParams{Principal: admin, UserID: "alice", Input: CalDAVSyncAccountInput{CollectionURL: "ftp://example.com", ConflictPolicy: "newest"}})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || len(vErr.FieldErrors) != 2 {
+			t.Fatalf("expected two field errors, got %v", err)
+		}
+	})
+
+	account, err := service.CreateSyncAccount(ctx, CreateCalDAVSyncAccountParams{
+		Principal: admin,
+		UserID:    "alice",
+		Input:     CalDAVSyncAccountInput{CollectionURL: server.Collection().URL, Username: caldavtest.Username, Password: caldavtest.Password},
+	})
+	if err != nil {
+		t.Fatalf("CreateSyncAccount returned error: %v", err)
+	}
+	if account.ConflictPolicy != CalDAVSyncLatestWins || !account.Active {
+		t.Fatalf("unexpected account defaults %#v", account)
+	}
+
+	remoteHref := server.Href("remote.ics")
+	server.PutObject("remote.ics", remoteEvent("remote-uid", "Remote", clock))
+	local, _, err := schedules.CreateSchedule(ctx, CreateScheduleParams{Principal: alice, Input: ScheduleInput{
+		CreatorID:      "alice",
+		Title:          "Local",
+		Start:          time.Date(2024, 4, 3, 1, 0, 0, 0, time.UTC),
+		End:            time.Date(2024, 4, 3, 2, 0, 0, 0, time.UTC),
+		ParticipantIDs: []string{"alice"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateSchedule returned error: %v", err)
+	}
+	localHref := server.Href(local.ID + ".ics")
+
+	var pulled Schedule
+	t.Run("pulls remote objects and pushes local schedules", func(t *testing.T) {
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Status != CalDAVSyncSucceeded || run.Pulled.Created != 1 || run.Pushed.Created != 1 || run.Failures != 0 {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		data, _, ok := server.Object(localHref)
+		if !ok || !strings.Contains(string(data), "SUMMARY:Local\r\n") {
+			t.Fatalf("expected the local schedule on the server, got %q", data)
+		}
+		item := syncRepo.items[account.ID+" "+remoteHref]
+		if item.RemoteUID != "remote-uid" || item.ScheduleID == "" {
+			t.Fatalf("unexpected mapping %#v", item)
+		}
+		pulled, err = schedules.GetSchedule(ctx, alice, item.ScheduleID)
+		if err != nil || pulled.Title != "Remote" || pulled.CreatorID != "alice" {
+			t.Fatalf("expected the imported schedule, got %#v (%v)", pulled, err)
+		}
+	})
+
+	t.Run("does nothing when neither side changed", func(t *testing.T) {
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Pulled != (CalDAVSyncCounts{}) || run.Pushed != (CalDAVSyncCounts{}) || run.Conflicts != 0 {
+			t.Fatalf("expected an empty run, got %#v", run)
+		}
+	})
+
+	t.Run("pulls remote updates and pushes local deletions", func(t *testing.T) {
+		server.PutObject("remote.ics", remoteEvent("remote-uid", "Remote v2", clock))
+		if err := schedules.DeleteSchedule(ctx, alice, local.ID); err != nil {
+			t.Fatalf("DeleteSchedule returned error: %v", err)
+		}
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Pulled.Updated != 1 || run.Pushed.Deleted != 1 || run.Status != CalDAVSyncSucceeded {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		if _, _, ok := server.Object(localHref); ok {
+			t.Fatal("expected the remote copy of the deleted schedule to be removed")
+		}
+		updated, err := schedules.GetSchedule(ctx, alice, pulled.ID)
+		if err != nil || updated.Title != "Remote v2" {
+			t.Fatalf("expected the remote update to be applied, got %#v (%v)", updated, err)
+		}
+		pulled = updated
+	})
+
+	t.Run("settles conflicts with the latest change", func(t *testing.T) {
+		server.PutObject("remote.ics", remoteEvent("remote-uid", "Remote stale", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+		input := ScheduleInput{CreatorID: "alice", Title: "Local edit", Start: pulled.Start, End: pulled.End, ParticipantIDs: pulled.ParticipantIDs}
+		if _, _, err := schedules.UpdateSchedule(ctx, UpdateScheduleParams{Principal: alice, ScheduleID: pulled.ID, Input: input}); err != nil {
+			t.Fatalf("UpdateSchedule returned error: %v", err)
+		}
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Conflicts != 1 || run.Pushed.Updated != 1 || run.Pulled.Updated != 0 {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		if data, _, _ := server.Object(remoteHref); !strings.Contains(string(data), "SUMMARY:Local edit\r\n") {
+			t.Fatalf("expected the local edit to win:\n%s", data)
+		}
+	})
+
+	t.Run("settles conflicts in favour of the remote when configured", func(t *testing.T) {
+		syncRepo.accounts[0].ConflictPolicy = CalDAVSyncRemoteWins
+		t.Cleanup(func() { syncRepo.accounts[0].ConflictPolicy = CalDAVSyncLatestWins })
+
+		current, err := schedules.GetSchedule(ctx, alice, pulled.ID)
+		if err != nil {
+			t.Fatalf("GetSchedule returned error: %v", err)
+		}
+		server.PutObject("remote.ics", remoteEvent("remote-uid", "Remote again", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+		input := ScheduleInput{CreatorID: "alice", Title: "Local again", Start: current.Start, End: current.End, ParticipantIDs: current.ParticipantIDs}
+		if _, _, err := schedules.UpdateSchedule(ctx, UpdateScheduleParams{Principal: alice, ScheduleID: pulled.ID, Input: input}); err != nil {
+			t.Fatalf("UpdateSchedule returned error: %v", err)
+		}
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Conflicts != 1 || run.Pulled.Updated != 1 {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		if updated, _ := schedules.GetSchedule(ctx, alice, pulled.ID); updated.Title != "Remote again" {
+			t.Fatalf("expected the remote edit to win, got %q", updated.Title)
+		}
+	})
+
+	t.Run("skips objects the calendar rejects until they change", func(t *testing.T) {
+		server.PutObject("broken.ics", []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:broken\r\nSUMMARY:No start\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Skipped != 1 || run.Failures != 0 || run.Status != CalDAVSyncSucceeded {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		if run, _ := service.SyncNow(ctx, alice, "alice", account.ID); run.Skipped != 0 {
+			t.Fatalf("expected the unchanged object not to be retried, got %#v", run)
+		}
+	})
+
+	t.Run("records failed runs", func(t *testing.T) {
+		syncRepo.accounts[0].Password = "wrong"
+		t.Cleanup(func() { syncRepo.accounts[0].Password = caldavtest.Password })
+
+		run, err := service.SyncNow(ctx, alice, "alice", account.ID)
+		if err != nil {
+			t.Fatalf("SyncNow returned error: %v", err)
+		}
+		if run.Status != CalDAVSyncFailed || run.Error == "" {
+			t.Fatalf("expected a failed run, got %#v", run)
+		}
+	})
+
+	t.Run("lists runs for the owner only", func(t *testing.T) {
+		runs, err := service.ListSyncRuns(ctx, alice, "alice", account.ID, 0)
+		if err != nil {
+			t.Fatalf("ListSyncRuns returned error: %v", err)
+		}
+		if len(runs) != len(syncRepo.runs) || runs[0].Status != CalDAVSyncFailed {
+			t.Fatalf("expected newest runs first, got %#v", runs)
+		}
+		if _, err := service.ListSyncRuns(ctx, Principal{UserID: "bob"}, "alice", account.ID, 0); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		if _, err := service.ListSyncRuns(ctx, Principal{UserID: "bob"}, "bob", account.ID, 0); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound for another user's account, got %v", err)
+		}
+		accounts, err := service.ListSyncAccounts(ctx, alice, "alice")
+		if err != nil || len(accounts) != 1 || accounts[0].Password != "" {
+			t.Fatalf("expected the account without its password, got %#v (%v)", accounts, err)
+		}
+	})
+
+	t.Run("syncs active accounts in the background", func(t *testing.T) {
+		before := len(syncRepo.runs)
+		runs, err := service.SyncAll(ctx)
+		if err != nil || runs != 1 || len(syncRepo.runs) != before+1 {
+			t.Fatalf("expected one run, got %d (%v)", runs, err)
+		}
+	})
+}
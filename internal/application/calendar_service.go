@@ -0,0 +1,420 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxCalendarNameLength bounds the name of a shared calendar.
+const maxCalendarNameLength = 100
+
+// CalendarRepository persists shared calendars with their editors and viewers.
+type CalendarRepository interface {
+	CreateCalendar(ctx context.Context, calendar Calendar) error
+	GetCalendar(ctx context.Context, id string) (Calendar, error)
+	UpdateCalendar(ctx context.Context, calendar Calendar) error
+	DeleteCalendar(ctx context.Context, id string) error
+	// ListCalendars returns the calendars a user owns, edits or views,
+	// ordered by name.
+	ListCalendars(ctx context.Context, userID string) ([]Calendar, error)
+}
+
+// CalendarDirectory resolves the role a principal holds on a shared calendar.
+// Calendars the principal holds no role on report ErrNotFound.
+type CalendarDirectory interface {
+	CalendarRole(ctx context.Context, principal Principal, calendarID string) (CalendarRole, error)
+}
+
+// CalendarService manages shared calendars such as team or office calendars
+// that schedules can belong to.
+type CalendarService struct {
+	calendars   CalendarRepository
+	users       UserDirectory
+	idGenerator func() string
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewCalendarService constructs a calendar service with the provided dependencies.
+func NewCalendarService(calendars CalendarRepository, users UserDirectory, idGenerator func() string, now func() time.Time) *CalendarService {
+	return NewCalendarServiceWithLogger(calendars, users, idGenerator, now, nil)
+}
+
+// NewCalendarServiceWithLogger constructs a calendar service with a specified logger.
+func NewCalendarServiceWithLogger(calendars CalendarRepository, users UserDirectory, idGenerator func() string, now func() time.Time, logger *slog.Logger) *CalendarService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &CalendarService{
+		calendars:   calendars,
+		users:       users,
+		idGenerator: idGenerator,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *CalendarService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "CalendarService", operation, attrs...)
+}
+
+// ListCalendars lists the calendars the principal owns, edits or views.
+func (s *CalendarService) ListCalendars(ctx context.Context, principal Principal) ([]Calendar, error) {
+	if s == nil {
+		return nil, fmt.Errorf("CalendarService is nil")
+	}
+	if s.calendars == nil {
+		return nil, fmt.Errorf("calendar repository not configured")
+	}
+
+	calendars, err := s.calendars.ListCalendars(ctx, principal.UserID)
+	if err != nil {
+		err = mapCalendarRepoError(err)
+		s.loggerWith(ctx, "ListCalendars", "principal_id", principal.UserID).ErrorContext(ctx, "failed to list calendars", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// GetCalendar returns a calendar the principal holds a role on. Other callers
+// get ErrNotFound.
+func (s *CalendarService) GetCalendar(ctx context.Context, principal Principal, calendarID string) (Calendar, error) {
+	if s == nil {
+		return Calendar{}, fmt.Errorf("CalendarService is nil")
+	}
+	if s.calendars == nil {
+		return Calendar{}, fmt.Errorf("calendar repository not configured")
+	}
+
+	calendar, err := s.calendars.GetCalendar(ctx, calendarID)
+	if err != nil {
+		return Calendar{}, mapCalendarRepoError(err)
+	}
+	if calendarRoleOf(principal, calendar) == "" {
+		return Calendar{}, ErrNotFound
+	}
+	return calendar, nil
+}
+
+// CalendarRole returns the role the principal holds on a calendar.
+// Administrators hold the owner role on every calendar.
+func (s *CalendarService) CalendarRole(ctx context.Context, principal Principal, calendarID string) (CalendarRole, error) {
+	calendar, err := s.GetCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return "", err
+	}
+	return calendarRoleOf(principal, calendar), nil
+}
+
+// CreateCalendar saves a new calendar. The principal owns it unless an
+// administrator names another owner.
+func (s *CalendarService) CreateCalendar(ctx context.Context, params CreateCalendarParams) (calendar Calendar, err error) {
+	if s == nil {
+		err = fmt.Errorf("CalendarService is nil")
+		return
+	}
+	if s.calendars == nil {
+		err = fmt.Errorf("calendar repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateCalendar", "principal_id", params.Principal.UserID)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create calendar", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("calendar_id", calendar.ID, "owner_id", calendar.OwnerID).InfoContext(ctx, "calendar created")
+	}()
+
+	input := params.Input
+	if params.Principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+	if input.OwnerID = strings.TrimSpace(input.OwnerID); input.OwnerID == "" {
+		input.OwnerID = params.Principal.UserID
+	}
+	if input.OwnerID != params.Principal.UserID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if input, err = s.normalizeInput(ctx, input); err != nil {
+		return
+	}
+
+	now := s.now()
+	calendar = Calendar{
+		ID:          s.idGenerator(),
+		Name:        input.Name,
+		Description: input.Description,
+		OwnerID:     input.OwnerID,
+		EditorIDs:   input.EditorIDs,
+		ViewerIDs:   input.ViewerIDs,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err = s.calendars.CreateCalendar(ctx, calendar); err != nil {
+		err = mapCalendarRepoError(err)
+	}
+	return
+}
+
+// UpdateCalendar replaces the details and access list of a calendar and may
+// hand it to another owner. Only its owner or an administrator may change it.
+func (s *CalendarService) UpdateCalendar(ctx context.Context, params UpdateCalendarParams) (calendar Calendar, err error) {
+	if s == nil {
+		err = fmt.Errorf("CalendarService is nil")
+		return
+	}
+	if s.calendars == nil {
+		err = fmt.Errorf("calendar repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "UpdateCalendar",
+		"principal_id", params.Principal.UserID,
+		"calendar_id", params.CalendarID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to update calendar", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("owner_id", calendar.OwnerID).InfoContext(ctx, "calendar updated")
+	}()
+
+	if calendar, err = s.ownedCalendar(ctx, params.Principal, params.CalendarID); err != nil {
+		return
+	}
+	input := params.Input
+	if input.OwnerID = strings.TrimSpace(input.OwnerID); input.OwnerID == "" {
+		input.OwnerID = calendar.OwnerID
+	}
+	if input, err = s.normalizeInput(ctx, input); err != nil {
+		return
+	}
+
+	calendar.Name, calendar.Description, calendar.OwnerID = input.Name, input.Description, input.OwnerID
+	calendar.EditorIDs, calendar.ViewerIDs = input.EditorIDs, input.ViewerIDs
+	calendar.UpdatedAt = s.now()
+	if err = s.calendars.UpdateCalendar(ctx, calendar); err != nil {
+		err = mapCalendarRepoError(err)
+	}
+	return
+}
+
+// DeleteCalendar removes a calendar. Its schedules are kept and no longer
+// belong to a calendar. Only its owner or an administrator may delete it.
+func (s *CalendarService) DeleteCalendar(ctx context.Context, principal Principal, calendarID string) error {
+	if s == nil {
+		return fmt.Errorf("CalendarService is nil")
+	}
+	if s.calendars == nil {
+		return fmt.Errorf("calendar repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteCalendar",
+		"principal_id", principal.UserID,
+		"calendar_id", calendarID,
+	)
+	if _, err := s.ownedCalendar(ctx, principal, calendarID); err != nil {
+		logger.ErrorContext(ctx, "failed to delete calendar", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	if err := s.calendars.DeleteCalendar(ctx, calendarID); err != nil {
+		err = mapCalendarRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete calendar", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "calendar deleted")
+	return nil
+}
+
+// ownedCalendar loads a calendar the principal may change. Editors and
+// viewers get ErrUnauthorized; anyone else gets ErrNotFound.
+func (s *CalendarService) ownedCalendar(ctx context.Context, principal Principal, calendarID string) (Calendar, error) {
+	calendar, err := s.GetCalendar(ctx, principal, calendarID)
+	if err != nil {
+		return Calendar{}, err
+	}
+	if calendarRoleOf(principal, calendar) != CalendarRoleOwner {
+		return Calendar{}, ErrUnauthorized
+	}
+	return calendar, nil
+}
+
+// normalizeInput trims the name and description, drops duplicate grants so
+// each user holds a single role, the highest given, and checks every
+// referenced user exists.
+func (s *CalendarService) normalizeInput(ctx context.Context, input CalendarInput) (CalendarInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	var editors, viewers []string
+	for _, userID := range uniqueStrings(input.EditorIDs) {
+		if userID != input.OwnerID {
+			editors = append(editors, userID)
+		}
+	}
+	for _, userID := range uniqueStrings(input.ViewerIDs) {
+		if userID != input.OwnerID && !containsString(editors, userID) {
+			viewers = append(viewers, userID)
+		}
+	}
+	input.EditorIDs, input.ViewerIDs = editors, viewers
+
+	vErr := &ValidationError{}
+	switch {
+	case input.Name == "":
+		vErr.add("name", "calendar name is required")
+	case len([]rune(input.Name)) > maxCalendarNameLength:
+		vErr.add("name", "calendar name must be at most 100 characters")
+	}
+	if s.users != nil {
+		for _, field := range []struct {
+			name string
+			ids  []string
+		}{
+			{"owner_id", []string{input.OwnerID}},
+			{"editor_ids", input.EditorIDs},
+			{"viewer_ids", input.ViewerIDs},
+		} {
+			if len(field.ids) == 0 {
+				continue
+			}
+			missing, err := s.users.MissingUserIDs(ctx, field.ids)
+			if err != nil {
+				return CalendarInput{}, err
+			}
+			if len(missing) > 0 {
+				vErr.add(field.name, fmt.Sprintf("unknown user ids: %s", strings.Join(missing, ", ")))
+			}
+		}
+	}
+	if vErr.HasErrors() {
+		return CalendarInput{}, vErr
+	}
+	return input, nil
+}
+
+// WithCalendars lets schedules belong to shared calendars whose roles grant
+// access to them, returning the service for chaining.
+func (s *ScheduleService) WithCalendars(directory CalendarDirectory) *ScheduleService {
+	if s != nil {
+		s.calendars = directory
+	}
+	return s
+}
+
+// calendarRole returns the role the principal holds on calendarID, or an
+// empty role when calendarID is nil or the principal holds none.
+func (s *ScheduleService) calendarRole(ctx context.Context, principal Principal, calendarID *string) (CalendarRole, error) {
+	if calendarID == nil || s.calendars == nil {
+		return "", nil
+	}
+	role, err := s.calendars.CalendarRole(ctx, principal, *calendarID)
+	if isNotFoundError(err) {
+		return "", nil
+	}
+	return role, err
+}
+
+// canEditSchedule reports whether the principal may change or delete a
+// schedule: its creator, an administrator, or an owner or editor of its calendar.
+func (s *ScheduleService) canEditSchedule(ctx context.Context, principal Principal, schedule Schedule) (bool, error) {
+	if schedule.CreatorID == principal.UserID || principal.IsAdmin {
+		return true, nil
+	}
+	role, err := s.calendarRole(ctx, principal, schedule.CalendarID)
+	return role == CalendarRoleOwner || role == CalendarRoleEditor, err
+}
+
+// canViewSchedule reports whether the principal may read a schedule: anyone
+// who may edit it, its participants, members of its live participant groups
+// and the viewers of its calendar.
+func (s *ScheduleService) canViewSchedule(ctx context.Context, principal Principal, schedule Schedule) (bool, error) {
+	if schedule.CreatorID == principal.UserID || containsString(schedule.ParticipantIDs, principal.UserID) || principal.IsAdmin {
+		return true, nil
+	}
+	if member, err := s.inParticipantGroups(ctx, principal.UserID, schedule); member || err != nil {
+		return member, err
+	}
+	role, err := s.calendarRole(ctx, principal, schedule.CalendarID)
+	return role != "", err
+}
+
+// ensureCalendarEditable checks the principal may add schedules to
+// calendarID. Calendars the principal cannot see are reported as not
+// existing; viewers get ErrUnauthorized.
+func (s *ScheduleService) ensureCalendarEditable(ctx context.Context, principal Principal, calendarID *string) error {
+	if calendarID == nil {
+		return nil
+	}
+	role, err := s.calendarRole(ctx, principal, calendarID)
+	if err != nil {
+		return err
+	}
+	switch role {
+	case CalendarRoleOwner, CalendarRoleEditor:
+		return nil
+	case CalendarRoleViewer:
+		return ErrUnauthorized
+	}
+	vErr := &ValidationError{}
+	vErr.add("calendar_id", "calendar does not exist")
+	return vErr
+}
+
+// resolveCalendarFilter checks the principal holds a role on the calendar a
+// listing is restricted to.
+func (s *ScheduleService) resolveCalendarFilter(ctx context.Context, params ListSchedulesParams) error {
+	if params.CalendarID == "" {
+		return nil
+	}
+	role, err := s.calendarRole(ctx, params.Principal, &params.CalendarID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		vErr := &ValidationError{}
+		vErr.add("calendar_id", "calendar does not exist")
+		return vErr
+	}
+	return nil
+}
+
+// calendarRoleOf returns the role the principal holds on calendar, or an
+// empty role when it holds none.
+func calendarRoleOf(principal Principal, calendar Calendar) CalendarRole {
+	switch {
+	case principal.IsAdmin || calendar.OwnerID == principal.UserID:
+		return CalendarRoleOwner
+	case containsString(calendar.EditorIDs, principal.UserID):
+		return CalendarRoleEditor
+	case containsString(calendar.ViewerIDs, principal.UserID):
+		return CalendarRoleViewer
+	}
+	return ""
+}
+
+func mapCalendarRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrDuplicate):
+		return ErrAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type calendarRepoStub struct {
+	calendars map[string]Calendar
+}
+
+func (c *calendarRepoStub) CreateCalendar(ctx context.Context, calendar Calendar) error {
+	if _, ok := c.calendars[calendar.ID]; ok {
+		return ErrAlreadyExists
+	}
+	c.calendars[calendar.ID] = calendar
+	return nil
+}
+
+func (c *calendarRepoStub) GetCalendar(ctx context.Context, id string) (Calendar, error) {
+	calendar, ok := c.calendars[id]
+	if !ok {
+		return Calendar{}, ErrNotFound
+	}
+	return calendar, nil
+}
+
+func (c *calendarRepoStub) UpdateCalendar(ctx context.Context, calendar Calendar) error {
+	c.calendars[calendar.ID] = calendar
+	return nil
+}
+
+func (c *calendarRepoStub) DeleteCalendar(ctx context.Context, id string) error {
+	delete(c.calendars, id)
+	return nil
+}
+
+func (c *calendarRepoStub) ListCalendars(ctx context.Context, userID string) ([]Calendar, error) {
+	var calendars []Calendar
+	for _, calendar := range c.calendars {
+		if calendarRoleOf(Principal{UserID: userID}, calendar) != "" {
+			calendars = append(calendars, calendar)
+		}
+	}
+	return calendars, nil
+}
+
+func TestCalendarService(t *testing.T) {
+	ctx := context.Background()
+	repo := &calendarRepoStub{calendars: map[string]Calendar{}}
+	users := &userDirectoryStub{}
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	svc := NewCalendarService(repo, users, func() string { return "cal-1" }, func() time.Time { return now })
+	alice := Principal{UserID: "alice"}
+	bob := Principal{UserID: "bob"}
+	carol := Principal{UserID: "carol"}
+	dave := Principal{UserID: "dave"}
+
+	calendar, err := svc.CreateCalendar(ctx, CreateCalendarParams{Principal: alice, Input: CalendarInput{
+		Name:      " Release Train ",
+		EditorIDs: []string{"bob", "bob", "alice"},
+		ViewerIDs: []string{"carol", "bob"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateCalendar returned error: %v", err)
+	}
+	if calendar.Name != "Release Train" || calendar.OwnerID != "alice" || len(calendar.EditorIDs) != 1 || len(calendar.ViewerIDs) != 1 || calendar.ViewerIDs[0] != "carol" {
+		t.Fatalf("expected a trimmed name and a single role per user, got %+v", calendar)
+	}
+	if _, err := svc.CreateCalendar(ctx, CreateCalendarParams{Principal: bob, Input: CalendarInput{Name: "Office Events", OwnerID: "alice"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected only administrators to create calendars for others, got %v", err)
+	}
+
+	for principal, want := range map[Principal]CalendarRole{alice: CalendarRoleOwner, bob: CalendarRoleEditor, carol: CalendarRoleViewer, {UserID: "root", IsAdmin: true}: CalendarRoleOwner} {
+		if role, err := svc.CalendarRole(ctx, principal, "cal-1"); err != nil || role != want {
+			t.Fatalf("expected %s to hold %s, got %q (err %v)", principal.UserID, want, role, err)
+		}
+	}
+	if _, err := svc.GetCalendar(ctx, dave, "cal-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the calendar to be hidden from dave, got %v", err)
+	}
+	if _, err := svc.UpdateCalendar(ctx, UpdateCalendarParams{Principal: bob, CalendarID: "cal-1", Input: CalendarInput{Name: "Mine"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected editors not to change the calendar, got %v", err)
+	}
+	if err := svc.DeleteCalendar(ctx, carol, "cal-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected viewers not to delete the calendar, got %v", err)
+	}
+
+	t.Run("validates the input", func(t *testing.T) {
+		_, err := svc.CreateCalendar(ctx, CreateCalendarParams{Principal: alice, Input: CalendarInput{Name: " "}})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["name"] != "calendar name is required" {
+			t.Fatalf("expected a name error, got %v", err)
+		}
+
+		users.missing = []string{"ghost"}
+		defer func() { users.missing = nil }()
+		_, err = svc.UpdateCalendar(ctx, UpdateCalendarParams{Principal: alice, CalendarID: "cal-1", Input: CalendarInput{Name: "Release Train", ViewerIDs: []string{"ghost"}}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["viewer_ids"] != "unknown user ids: ghost" {
+			t.Fatalf("expected unknown viewers to be reported, got %v", err)
+		}
+	})
+
+	t.Run("calendar roles drive schedule access", func(t *testing.T) {
+		releaseID := "cal-1"
+		schedules := &filteringScheduleRepo{schedules: []Schedule{
+			{ID: "cut", CreatorID: "alice", Title: "Release cut", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"alice"}, CalendarID: &releaseID},
+			{ID: "lunch", CreatorID: "alice", Title: "Lunch", Start: now, End: now.Add(time.Hour)},
+		}}
+		scheduleSvc := NewScheduleService(schedules, nil, nil, nil, func() string { return "freeze" }, func() time.Time { return now }).WithCalendars(svc)
+		input := ScheduleInput{Title: "Release cut (moved)", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), ParticipantIDs: []string{"alice"}, CalendarID: &releaseID}
+
+		if _, _, err := scheduleSvc.UpdateSchedule(ctx, UpdateScheduleParams{Principal: bob, ScheduleID: "cut", Input: input}); err != nil {
+			t.Fatalf("expected an editor to change the calendar's schedule, got %v", err)
+		}
+		if _, _, err := scheduleSvc.UpdateSchedule(ctx, UpdateScheduleParams{Principal: carol, ScheduleID: "cut", Input: input}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected a viewer not to change the schedule, got %v", err)
+		}
+		if _, err := scheduleSvc.GetSchedule(ctx, carol, "cut"); err != nil {
+			t.Fatalf("expected a viewer to read the schedule, got %v", err)
+		}
+		if _, err := scheduleSvc.GetSchedule(ctx, dave, "cut"); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected outsiders not to read the schedule, got %v", err)
+		}
+		if _, _, err := scheduleSvc.UpdateSchedule(ctx, UpdateScheduleParams{Principal: bob, ScheduleID: "lunch", Input: input}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected an editor not to change schedules off the calendar, got %v", err)
+		}
+
+		created, _, err := scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: bob, Input: ScheduleInput{Title: "Code freeze", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"alice"}, CalendarID: &releaseID}})
+		if err != nil || created.CalendarID == nil || *created.CalendarID != releaseID {
+			t.Fatalf("expected an editor to add a schedule to the calendar, got %+v (err %v)", created, err)
+		}
+		if _, _, err := scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: carol, Input: ScheduleInput{Title: "Demo", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"alice"}, CalendarID: &releaseID}}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected a viewer not to add schedules, got %v", err)
+		}
+		missingID := "missing"
+		_, _, err = scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: dave, Input: ScheduleInput{Title: "Demo", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"alice"}, CalendarID: &missingID}})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["calendar_id"] != "calendar does not exist" {
+			t.Fatalf("expected an unknown calendar to be rejected, got %v", err)
+		}
+
+		listed, _, err := scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: carol, CalendarID: releaseID})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(listed) != 2 {
+			t.Fatalf("expected the calendar's schedules without the caller's own filter, got %+v", listed)
+		}
+		_, err = scheduleSvc.ListSchedulesPage(ctx, ListSchedulesParams{Principal: dave, CalendarID: releaseID})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["calendar_id"] != "calendar does not exist" {
+			t.Fatalf("expected an inaccessible calendar to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestScheduleService_ListSchedules_CachesWarningsPerCalendar(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	repo := &calendarRepoStub{calendars: map[string]Calendar{
+		"cal-a": {ID: "cal-a", Name: "A", OwnerID: "alice"},
+		"cal-b": {ID: "cal-b", Name: "B", OwnerID: "alice"},
+	}}
+	calendars := NewCalendarService(repo, &userDirectoryStub{}, nil, func() time.Time { return now })
+	calendarA, calendarB := "cal-a", "cal-b"
+	schedules := &filteringScheduleRepo{schedules: []Schedule{
+		{ID: "a1", CreatorID: "alice", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"bob"}, Busy: true, CalendarID: &calendarA},
+		{ID: "a2", CreatorID: "alice", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"bob"}, Busy: true, CalendarID: &calendarA},
+		{ID: "b1", CreatorID: "alice", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"bob"}, Busy: true, CalendarID: &calendarB},
+	}}
+	svc := NewScheduleService(schedules, nil, nil, nil, nil, func() time.Time { return now }).WithCalendars(calendars)
+	alice := Principal{UserID: "alice"}
+
+	_, warnings, err := svc.ListSchedules(ctx, ListSchedulesParams{Principal: alice, CalendarID: calendarA})
+	if err != nil {
+		t.Fatalf("ListSchedules returned error: %v", err)
+	}
+	if len(warnings) == 0 {
+		t.Fatalf("expected calendar A's schedules to conflict")
+	}
+
+	listed, warnings, err := svc.ListSchedules(ctx, ListSchedulesParams{Principal: alice, CalendarID: calendarB})
+	if err != nil {
+		t.Fatalf("ListSchedules returned error: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != "b1" {
+		t.Fatalf("expected only calendar B's schedule, got %+v", listed)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings cached for calendar A, got %+v", warnings)
+	}
+}
@@ -0,0 +1,362 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultViewAxisStart and DefaultViewAxisEnd bound the time axis shown
+	// before the user scrolls, as offsets from midnight.
+	DefaultViewAxisStart = 8 * time.Hour
+	DefaultViewAxisEnd   = 18 * time.Hour
+)
+
+// CalendarViewSchedules lists the concrete schedule instances laid out in a view.
+type CalendarViewSchedules interface {
+	ListScheduleInstances(ctx context.Context, params ListScheduleInstancesParams) ([]ScheduleInstance, error)
+}
+
+// CalendarViewHolidays lists the holidays marked in a view.
+type CalendarViewHolidays interface {
+	ListHolidays(ctx context.Context, params ListHolidaysParams) ([]Holiday, error)
+}
+
+// CalendarViewParams wraps the data required to build a calendar view. Date
+// selects the period and defaults to today; ParticipantIDs are shown after
+// the principal, in the order given.
+type CalendarViewParams struct {
+	Principal      Principal
+	Period         ListPeriod
+	Date           time.Time
+	ParticipantIDs []string
+}
+
+// CalendarView is a day, week or month laid out for rendering: one lane per
+// participant, split into the days of the period.
+type CalendarView struct {
+	Period    ListPeriod
+	Start     time.Time
+	End       time.Time
+	AxisStart time.Duration
+	AxisEnd   time.Duration
+	Days      []CalendarDay
+	Lanes     []CalendarLane
+}
+
+// CalendarDay is one day of a view, starting at midnight in the caller's zone.
+// Holiday is nil on regular days.
+type CalendarDay struct {
+	Date    time.Time
+	Weekend bool
+	Holiday *Holiday
+}
+
+// CalendarLane holds a participant's schedules and absences. Days parallels
+// CalendarView.Days.
+type CalendarLane struct {
+	ParticipantID string
+	Days          []CalendarLaneDay
+}
+
+// CalendarLaneDay holds what a lane shows on one day. Timed events are placed
+// side by side: Column is an event's position among the Columns it overlaps.
+type CalendarLaneDay struct {
+	AllDay      []ScheduleInstance
+	Events      []CalendarEvent
+	OutOfOffice []CalendarOutOfOffice
+}
+
+// CalendarEvent is the part of a timed instance falling on one day.
+type CalendarEvent struct {
+	Instance ScheduleInstance
+	Start    time.Time
+	End      time.Time
+	Column   int
+	Columns  int
+}
+
+// CalendarOutOfOffice is the part of an absence falling on one day.
+type CalendarOutOfOffice struct {
+	Entry OutOfOffice
+	Start time.Time
+	End   time.Time
+}
+
+// CalendarViewService lays out day, week and month views so clients can
+// render them without computing overlaps themselves.
+type CalendarViewService struct {
+	schedules    CalendarViewSchedules
+	holidays     CalendarViewHolidays
+	availability AvailabilityDirectory
+	userGroups   UserGroupDirectory
+	now          func() time.Time
+	logger       *slog.Logger
+}
+
+// NewCalendarViewService wires dependencies for calendar views. Holidays and
+// availability are optional.
+func NewCalendarViewService(schedules CalendarViewSchedules, holidays CalendarViewHolidays, availability AvailabilityDirectory, now func() time.Time) *CalendarViewService {
+	return NewCalendarViewServiceWithLogger(schedules, holidays, availability, now, nil)
+}
+
+// NewCalendarViewServiceWithLogger wires dependencies and allows specifying a logger.
+func NewCalendarViewServiceWithLogger(schedules CalendarViewSchedules, holidays CalendarViewHolidays, availability AvailabilityDirectory, now func() time.Time, logger *slog.Logger) *CalendarViewService {
+	if now == nil {
+		now = time.Now
+	}
+	return &CalendarViewService{
+		schedules:    schedules,
+		holidays:     holidays,
+		availability: availability,
+		now:          now,
+		logger:       defaultLogger(logger),
+	}
+}
+
+// WithUserGroups places schedules in the lanes of the current members of
+// their live participant groups, returning the service for chaining.
+func (s *CalendarViewService) WithUserGroups(directory UserGroupDirectory) *CalendarViewService {
+	if s != nil {
+		s.userGroups = directory
+	}
+	return s
+}
+
+func (s *CalendarViewService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "CalendarViewService", operation, attrs...)
+}
+
+// CalendarView builds the view of the period containing params.Date. The
+// schedules shown follow ListScheduleInstances.
+func (s *CalendarViewService) CalendarView(ctx context.Context, params CalendarViewParams) (view CalendarView, err error) {
+	if s == nil {
+		err = fmt.Errorf("CalendarViewService is nil")
+		return
+	}
+	if s.schedules == nil {
+		err = fmt.Errorf("schedule service not configured")
+		return
+	}
+	if params.Period != ListPeriodDay && params.Period != ListPeriodWeek && params.Period != ListPeriodMonth {
+		vErr := &ValidationError{}
+		vErr.add("view", "view must be day, week or month")
+		err = vErr
+		return
+	}
+
+	participants := uniqueStrings(append([]string{params.Principal.UserID}, params.ParticipantIDs...))
+	logger := s.loggerWith(ctx, "CalendarView",
+		"principal_id", params.Principal.UserID,
+		"participant_filter_count", len(participants),
+		"period", string(params.Period),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to build calendar view", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("day_count", len(view.Days), "lane_count", len(view.Lanes)).InfoContext(ctx, "calendar view built")
+	}()
+
+	loc := locationOrDefault(params.Principal.TimeZone)
+	date := params.Date
+	if date.IsZero() {
+		date = s.now()
+	}
+	start, end := computePeriodRange(params.Period, date, loc)
+	view = CalendarView{
+		Period:    params.Period,
+		Start:     start,
+		End:       end,
+		AxisStart: DefaultViewAxisStart,
+		AxisEnd:   DefaultViewAxisEnd,
+	}
+
+	holidays, err := s.holidaysBetween(ctx, params.Principal, start, end)
+	if err != nil {
+		return
+	}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		calendarDay := CalendarDay{Date: day, Weekend: day.Weekday() == time.Saturday || day.Weekday() == time.Sunday}
+		if holiday, ok := holidays[floatingDate(day)]; ok {
+			calendarDay.Holiday = &holiday
+		}
+		view.Days = append(view.Days, calendarDay)
+	}
+
+	instances, err := s.schedules.ListScheduleInstances(ctx, ListScheduleInstancesParams{
+		Principal:      params.Principal,
+		ParticipantIDs: participants,
+		Start:          start,
+		End:            end,
+	})
+	if err != nil {
+		return
+	}
+
+	// Members of live participant groups get lane entries like direct participants.
+	if instances, err = withInstanceGroupMembers(ctx, s.userGroups, instances); err != nil {
+		return
+	}
+
+	var absences map[string]ParticipantAvailability
+	if s.availability != nil {
+		if absences, err = s.availability.ParticipantAvailability(ctx, participants, &start, &end); err != nil {
+			return
+		}
+	}
+
+	for _, participantID := range participants {
+		lane := CalendarLane{ParticipantID: participantID, Days: make([]CalendarLaneDay, len(view.Days))}
+		var involved []ScheduleInstance
+		for _, instance := range instances {
+			if instance.Schedule.CreatorID == participantID || containsString(instance.Schedule.ParticipantIDs, participantID) {
+				involved = append(involved, instance)
+			}
+		}
+		for i, day := range view.Days {
+			lane.Days[i] = layoutLaneDay(involved, absences[participantID].OutOfOffice, day.Date, day.Date.AddDate(0, 0, 1))
+		}
+		view.Lanes = append(view.Lanes, lane)
+	}
+	return
+}
+
+// withInstanceGroupMembers adds the current members of each instance's live
+// participant groups to its participants.
+func withInstanceGroupMembers(ctx context.Context, directory UserGroupDirectory, instances []ScheduleInstance) ([]ScheduleInstance, error) {
+	schedules := make([]Schedule, len(instances))
+	for i, instance := range instances {
+		schedules[i] = instance.Schedule
+	}
+	expanded, err := withParticipantGroupMembers(ctx, directory, schedules)
+	if err != nil {
+		return nil, err
+	}
+	withMembers := make([]ScheduleInstance, len(instances))
+	for i, instance := range instances {
+		instance.Schedule = expanded[i]
+		withMembers[i] = instance
+	}
+	return withMembers, nil
+}
+
+// holidaysBetween indexes the holidays of the years spanned by [start, end)
+// by their floating date.
+func (s *CalendarViewService) holidaysBetween(ctx context.Context, principal Principal, start, end time.Time) (map[time.Time]Holiday, error) {
+	holidays := make(map[time.Time]Holiday)
+	if s.holidays == nil {
+		return holidays, nil
+	}
+	last := end.Add(-time.Nanosecond)
+	for year := start.Year(); year <= last.Year(); year++ {
+		listed, err := s.holidays.ListHolidays(ctx, ListHolidaysParams{Principal: principal, Year: year})
+		if err != nil {
+			return nil, err
+		}
+		for _, holiday := range listed {
+			holidays[floatingDate(holiday.Date)] = holiday
+		}
+	}
+	return holidays, nil
+}
+
+// layoutLaneDay places the instances and absences overlapping [dayStart,
+// dayEnd). Timed instances are clipped to the day and overlapping ones are
+// spread over columns; all-day instances are matched by date.
+func layoutLaneDay(instances []ScheduleInstance, absences []OutOfOffice, dayStart, dayEnd time.Time) CalendarLaneDay {
+	var laneDay CalendarLaneDay
+	date := floatingDate(dayStart)
+	for _, instance := range instances {
+		if instance.Schedule.AllDay {
+			if !instance.Start.After(date) && instance.End.After(date) {
+				laneDay.AllDay = append(laneDay.AllDay, instance)
+			}
+			continue
+		}
+		if instance.Start.Before(dayEnd) && instance.End.After(dayStart) {
+			laneDay.Events = append(laneDay.Events, CalendarEvent{
+				Instance: instance,
+				Start:    latestTime(instance.Start, dayStart),
+				End:      earliestTime(instance.End, dayEnd),
+			})
+		}
+	}
+	assignEventColumns(laneDay.Events)
+
+	for _, entry := range absences {
+		if entry.Start.Before(dayEnd) && entry.End.After(dayStart) {
+			laneDay.OutOfOffice = append(laneDay.OutOfOffice, CalendarOutOfOffice{
+				Entry: entry,
+				Start: latestTime(entry.Start, dayStart),
+				End:   earliestTime(entry.End, dayEnd),
+			})
+		}
+	}
+	return laneDay
+}
+
+// assignEventColumns sorts events by start, longest first, and gives each the
+// leftmost column free at its start. Events that overlap directly or through
+// others form a group sharing one column count.
+func assignEventColumns(events []CalendarEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		if !events[i].End.Equal(events[j].End) {
+			return events[i].End.After(events[j].End)
+		}
+		return events[i].Instance.ID < events[j].Instance.ID
+	})
+
+	groupStart := 0
+	var columnEnds []time.Time
+	var groupEnd time.Time
+	closeGroup := func(upTo int) {
+		for i := groupStart; i < upTo; i++ {
+			events[i].Columns = len(columnEnds)
+		}
+	}
+	for i := range events {
+		if i > groupStart && !events[i].Start.Before(groupEnd) {
+			closeGroup(i)
+			groupStart, columnEnds = i, nil
+		}
+		column := len(columnEnds)
+		for c, columnEnd := range columnEnds {
+			if !events[i].Start.Before(columnEnd) {
+				column = c
+				break
+			}
+		}
+		if column == len(columnEnds) {
+			columnEnds = append(columnEnds, events[i].End)
+		} else {
+			columnEnds[column] = events[i].End
+		}
+		events[i].Column = column
+		if i == groupStart || events[i].End.After(groupEnd) {
+			groupEnd = events[i].End
+		}
+	}
+	closeGroup(len(events))
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliestTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type calendarViewSchedulesStub struct {
+	params    ListScheduleInstancesParams
+	instances []ScheduleInstance
+}
+
+func (c *calendarViewSchedulesStub) ListScheduleInstances(ctx context.Context, params ListScheduleInstancesParams) ([]ScheduleInstance, error) {
+	c.params = params
+	return c.instances, nil
+}
+
+type calendarViewHolidaysStub struct {
+	years []int
+}
+
+func (c *calendarViewHolidaysStub) ListHolidays(ctx context.Context, params ListHolidaysParams) ([]Holiday, error) {
+	c.years = append(c.years, params.Year)
+	return []Holiday{{Date: time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), Name: "昭和の日", Kind: "national"}}, nil
+}
+
+type calendarViewAvailabilityStub struct {
+	profiles map[string]ParticipantAvailability
+}
+
+func (c *calendarViewAvailabilityStub) ParticipantAvailability(ctx context.Context, userIDs []string, startsAfter, endsBefore *time.Time) (map[string]ParticipantAvailability, error) {
+	return c.profiles, nil
+}
+
+type userGroupDirectoryStub struct {
+	members map[string][]string
+}
+
+func (u *userGroupDirectoryStub) UserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	members, ok := u.members[groupID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return members, nil
+}
+
+func TestCalendarViewService_CalendarView(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 4, day, hour, minute, 0, 0, tokyo)
+	}
+	instance := func(id, owner string, start, end time.Time) ScheduleInstance {
+		return ScheduleInstance{ID: id, Start: start, End: end, Schedule: Schedule{ID: id, CreatorID: owner}}
+	}
+	schedules := &calendarViewSchedulesStub{instances: []ScheduleInstance{
+		instance("design", "alice", at(29, 10, 0), at(29, 11, 0)),
+		instance("sync", "alice", at(29, 10, 30), at(29, 11, 30)),
+		instance("lunch", "alice", at(29, 11, 0), at(29, 12, 0)),
+		instance("review", "alice", at(29, 13, 0), at(29, 14, 0)),
+		instance("release", "alice", at(30, 23, 0), time.Date(2024, 5, 1, 1, 0, 0, 0, tokyo)),
+		instance("interview", "bob", at(29, 10, 0), at(29, 11, 0)),
+		{ID: "offsite", Start: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+			Schedule: Schedule{ID: "offsite", CreatorID: "alice", AllDay: true}},
+	}}
+	holidays := &calendarViewHolidaysStub{}
+	availability := &calendarViewAvailabilityStub{profiles: map[string]ParticipantAvailability{
+		"bob": {OutOfOffice: []OutOfOffice{{ID: "ooo-1", UserID: "bob", Start: time.Date(2024, 5, 2, 12, 0, 0, 0, tokyo), End: time.Date(2024, 5, 3, 12, 0, 0, 0, tokyo)}}},
+	}}
+	svc := NewCalendarViewService(schedules, holidays, availability, nil)
+	principal := Principal{UserID: "alice", TimeZone: "Asia/Tokyo"}
+
+	view, err := svc.CalendarView(context.Background(), CalendarViewParams{
+		Principal:      principal,
+		Period:         ListPeriodWeek,
+		Date:           time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo),
+		ParticipantIDs: []string{"bob", "alice"},
+	})
+	if err != nil {
+		t.Fatalf("CalendarView returned error: %v", err)
+	}
+
+	if !view.Start.Equal(at(29, 0, 0)) || !view.End.Equal(time.Date(2024, 5, 6, 0, 0, 0, 0, tokyo)) || len(view.Days) != 7 {
+		t.Fatalf("expected the Monday-start week, got %s - %s with %d days", view.Start, view.End, len(view.Days))
+	}
+	if view.AxisStart != 8*time.Hour || view.AxisEnd != 18*time.Hour {
+		t.Fatalf("expected the default 08:00-18:00 axis, got %s-%s", view.AxisStart, view.AxisEnd)
+	}
+	if view.Days[0].Holiday == nil || view.Days[0].Holiday.Name != "昭和の日" || view.Days[1].Holiday != nil {
+		t.Fatalf("expected Monday to carry the holiday marker, got %+v", view.Days[:2])
+	}
+	if !view.Days[5].Weekend || !view.Days[6].Weekend || view.Days[4].Weekend {
+		t.Fatalf("expected Saturday and Sunday to be weekends")
+	}
+	if len(schedules.params.ParticipantIDs) != 2 || !schedules.params.Start.Equal(view.Start) {
+		t.Fatalf("unexpected instance query: %+v", schedules.params)
+	}
+	if len(view.Lanes) != 2 || view.Lanes[0].ParticipantID != "alice" || view.Lanes[1].ParticipantID != "bob" {
+		t.Fatalf("expected lanes for the caller then the selection, got %+v", view.Lanes)
+	}
+
+	monday := view.Lanes[0].Days[0].Events
+	want := []struct {
+		id              string
+		column, columns int
+	}{{"design", 0, 2}, {"sync", 1, 2}, {"lunch", 0, 2}, {"review", 0, 1}}
+	if len(monday) != len(want) {
+		t.Fatalf("expected %d Monday events, got %+v", len(want), monday)
+	}
+	for i, w := range want {
+		if monday[i].Instance.ID != w.id || monday[i].Column != w.column || monday[i].Columns != w.columns {
+			t.Fatalf("event %d: expected %s in column %d of %d, got %s in column %d of %d",
+				i, w.id, w.column, w.columns, monday[i].Instance.ID, monday[i].Column, monday[i].Columns)
+		}
+	}
+
+	tuesday, wednesday := view.Lanes[0].Days[1].Events, view.Lanes[0].Days[2].Events
+	if len(tuesday) != 1 || !tuesday[0].End.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo)) {
+		t.Fatalf("expected the overnight event clipped at midnight, got %+v", tuesday)
+	}
+	if len(wednesday) != 1 || !wednesday[0].Start.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo)) {
+		t.Fatalf("expected the overnight event to continue on Wednesday, got %+v", wednesday)
+	}
+	if allDay := view.Lanes[0].Days[3].AllDay; len(allDay) != 1 || allDay[0].ID != "offsite" {
+		t.Fatalf("expected the all-day event on Thursday, got %+v", allDay)
+	}
+
+	bob := view.Lanes[1]
+	if len(bob.Days[0].Events) != 1 || bob.Days[0].Events[0].Instance.ID != "interview" || bob.Days[0].Events[0].Columns != 1 {
+		t.Fatalf("expected only bob's own event in his lane, got %+v", bob.Days[0].Events)
+	}
+	if len(bob.Days[3].OutOfOffice) != 1 || len(bob.Days[4].OutOfOffice) != 1 || !bob.Days[4].OutOfOffice[0].Start.Equal(time.Date(2024, 5, 3, 0, 0, 0, 0, tokyo)) {
+		t.Fatalf("expected the absence split over Thursday and Friday, got %+v / %+v", bob.Days[3].OutOfOffice, bob.Days[4].OutOfOffice)
+	}
+
+	t.Run("month views span their days and every holiday year", func(t *testing.T) {
+		holidays.years = nil
+		view, err := svc.CalendarView(context.Background(), CalendarViewParams{Principal: principal, Period: ListPeriodWeek, Date: time.Date(2024, 12, 31, 0, 0, 0, 0, tokyo)})
+		if err != nil {
+			t.Fatalf("CalendarView returned error: %v", err)
+		}
+		if len(view.Days) != 7 || len(holidays.years) != 2 {
+			t.Fatalf("expected a week across the new year to list both years, got %d days and years %v", len(view.Days), holidays.years)
+		}
+
+		view, err = svc.CalendarView(context.Background(), CalendarViewParams{Principal: principal, Period: ListPeriodMonth, Date: time.Date(2024, 2, 10, 0, 0, 0, 0, tokyo)})
+		if err != nil || len(view.Days) != 29 {
+			t.Fatalf("expected 29 days in February 2024, got %d (err %v)", len(view.Days), err)
+		}
+	})
+
+	t.Run("rejects unknown periods", func(t *testing.T) {
+		var vErr *ValidationError
+		if _, err := svc.CalendarView(context.Background(), CalendarViewParams{Principal: principal, Period: "year"}); !errors.As(err, &vErr) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+	})
+}
+
+func TestCalendarViewService_CalendarView_GroupMembers(t *testing.T) {
+	start := time.Date(2024, 4, 29, 10, 0, 0, 0, time.UTC)
+	schedules := &calendarViewSchedulesStub{instances: []ScheduleInstance{{
+		ID: "all-hands", Start: start, End: start.Add(time.Hour),
+		Schedule: Schedule{ID: "all-hands", CreatorID: "alice", ParticipantGroupIDs: []string{"grp-sales"}},
+	}}}
+	groups := &userGroupDirectoryStub{members: map[string][]string{"grp-sales": {"bob"}}}
+	svc := NewCalendarViewService(schedules, nil, nil, nil).WithUserGroups(groups)
+
+	view, err := svc.CalendarView(context.Background(), CalendarViewParams{
+		Principal:      Principal{UserID: "carol", TimeZone: "UTC"},
+		Period:         ListPeriodDay,
+		Date:           start,
+		ParticipantIDs: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("CalendarView returned error: %v", err)
+	}
+
+	var bob *CalendarLane
+	for i := range view.Lanes {
+		if view.Lanes[i].ParticipantID == "bob" {
+			bob = &view.Lanes[i]
+		}
+	}
+	if bob == nil || len(bob.Days) != 1 || len(bob.Days[0].Events) != 1 || bob.Days[0].Events[0].Instance.ID != "all-hands" {
+		t.Fatalf("expected the group member's lane to show the schedule, got %+v", view.Lanes)
+	}
+	if participants := schedules.instances[0].Schedule.ParticipantIDs; len(participants) != 0 {
+		t.Fatalf("expected the listed instances to be left unchanged, got %v", participants)
+	}
+}
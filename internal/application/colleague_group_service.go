@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxColleagueGroupNameLength bounds the name users give a colleague group.
+const maxColleagueGroupNameLength = 100
+
+// ColleagueGroupRepository persists colleague groups with their members and shares.
+type ColleagueGroupRepository interface {
+	CreateColleagueGroup(ctx context.Context, group ColleagueGroup) error
+	GetColleagueGroup(ctx context.Context, id string) (ColleagueGroup, error)
+	UpdateColleagueGroup(ctx context.Context, group ColleagueGroup) error
+	DeleteColleagueGroup(ctx context.Context, id string) error
+	// ListColleagueGroups returns the groups a user owns or that are shared
+	// with them, ordered by name.
+	ListColleagueGroups(ctx context.Context, userID string) ([]ColleagueGroup, error)
+}
+
+// ColleagueGroupDirectory resolves a colleague group to its members on behalf
+// of a principal. Groups the principal cannot use report ErrNotFound.
+type ColleagueGroupDirectory interface {
+	ColleagueGroupMembers(ctx context.Context, principal Principal, groupID string) ([]string, error)
+}
+
+// ColleagueGroupService manages the colleague groups users save to select
+// several people at once when viewing calendars.
+type ColleagueGroupService struct {
+	groups      ColleagueGroupRepository
+	users       UserDirectory
+	idGenerator func() string
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewColleagueGroupService constructs a colleague group service with the provided dependencies.
+func NewColleagueGroupService(groups ColleagueGroupRepository, users UserDirectory, idGenerator func() string, now func() time.Time) *ColleagueGroupService {
+	return NewColleagueGroupServiceWithLogger(groups, users, idGenerator, now, nil)
+}
+
+// NewColleagueGroupServiceWithLogger constructs a colleague group service with a specified logger.
+func NewColleagueGroupServiceWithLogger(groups ColleagueGroupRepository, users UserDirectory, idGenerator func() string, now func() time.Time, logger *slog.Logger) *ColleagueGroupService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &ColleagueGroupService{
+		groups:      groups,
+		users:       users,
+		idGenerator: idGenerator,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *ColleagueGroupService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "ColleagueGroupService", operation, attrs...)
+}
+
+// ListColleagueGroups lists the groups the principal owns or that are shared
+// with them.
+func (s *ColleagueGroupService) ListColleagueGroups(ctx context.Context, principal Principal) ([]ColleagueGroup, error) {
+	if s == nil {
+		return nil, fmt.Errorf("ColleagueGroupService is nil")
+	}
+	if s.groups == nil {
+		return nil, fmt.Errorf("colleague group repository not configured")
+	}
+
+	groups, err := s.groups.ListColleagueGroups(ctx, principal.UserID)
+	if err != nil {
+		err = mapColleagueGroupRepoError(err)
+		s.loggerWith(ctx, "ListColleagueGroups", "principal_id", principal.UserID).ErrorContext(ctx, "failed to list colleague groups", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetColleagueGroup returns a group its owner, the users it is shared with or
+// an administrator can see. Other callers get ErrNotFound.
+func (s *ColleagueGroupService) GetColleagueGroup(ctx context.Context, principal Principal, groupID string) (ColleagueGroup, error) {
+	if s == nil {
+		return ColleagueGroup{}, fmt.Errorf("ColleagueGroupService is nil")
+	}
+	if s.groups == nil {
+		return ColleagueGroup{}, fmt.Errorf("colleague group repository not configured")
+	}
+
+	group, err := s.groups.GetColleagueGroup(ctx, groupID)
+	if err != nil {
+		return ColleagueGroup{}, mapColleagueGroupRepoError(err)
+	}
+	if !canUseColleagueGroup(principal, group) {
+		return ColleagueGroup{}, ErrNotFound
+	}
+	return group, nil
+}
+
+// ColleagueGroupMembers returns the members of a group the principal can use.
+func (s *ColleagueGroupService) ColleagueGroupMembers(ctx context.Context, principal Principal, groupID string) ([]string, error) {
+	group, err := s.GetColleagueGroup(ctx, principal, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return group.MemberIDs, nil
+}
+
+// CreateColleagueGroup saves a new group owned by the principal. Names are
+// unique per owner.
+func (s *ColleagueGroupService) CreateColleagueGroup(ctx context.Context, params CreateColleagueGroupParams) (group ColleagueGroup, err error) {
+	if s == nil {
+		err = fmt.Errorf("ColleagueGroupService is nil")
+		return
+	}
+	if s.groups == nil {
+		err = fmt.Errorf("colleague group repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateColleagueGroup", "principal_id", params.Principal.UserID)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create colleague group", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("group_id", group.ID, "member_count", len(group.MemberIDs)).InfoContext(ctx, "colleague group created")
+	}()
+
+	if params.Principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+	input, err := s.normalizeInput(ctx, params.Principal.UserID, params.Input)
+	if err != nil {
+		return
+	}
+
+	now := s.now()
+	group = ColleagueGroup{
+		ID:         s.idGenerator(),
+		OwnerID:    params.Principal.UserID,
+		Name:       input.Name,
+		MemberIDs:  input.MemberIDs,
+		SharedWith: input.SharedWith,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err = s.groups.CreateColleagueGroup(ctx, group); err != nil {
+		err = mapColleagueGroupRepoError(err)
+	}
+	return
+}
+
+// UpdateColleagueGroup replaces the name, members and shares of a group. Only
+// its owner or an administrator may change it.
+func (s *ColleagueGroupService) UpdateColleagueGroup(ctx context.Context, params UpdateColleagueGroupParams) (group ColleagueGroup, err error) {
+	if s == nil {
+		err = fmt.Errorf("ColleagueGroupService is nil")
+		return
+	}
+	if s.groups == nil {
+		err = fmt.Errorf("colleague group repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "UpdateColleagueGroup",
+		"principal_id", params.Principal.UserID,
+		"group_id", params.GroupID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to update colleague group", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("member_count", len(group.MemberIDs)).InfoContext(ctx, "colleague group updated")
+	}()
+
+	if group, err = s.ownedGroup(ctx, params.Principal, params.GroupID); err != nil {
+		return
+	}
+	input, err := s.normalizeInput(ctx, group.OwnerID, params.Input)
+	if err != nil {
+		return
+	}
+
+	group.Name, group.MemberIDs, group.SharedWith = input.Name, input.MemberIDs, input.SharedWith
+	group.UpdatedAt = s.now()
+	if err = s.groups.UpdateColleagueGroup(ctx, group); err != nil {
+		err = mapColleagueGroupRepoError(err)
+	}
+	return
+}
+
+// DeleteColleagueGroup removes a group. Only its owner or an administrator
+// may delete it.
+func (s *ColleagueGroupService) DeleteColleagueGroup(ctx context.Context, principal Principal, groupID string) error {
+	if s == nil {
+		return fmt.Errorf("ColleagueGroupService is nil")
+	}
+	if s.groups == nil {
+		return fmt.Errorf("colleague group repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteColleagueGroup",
+		"principal_id", principal.UserID,
+		"group_id", groupID,
+	)
+	if _, err := s.ownedGroup(ctx, principal, groupID); err != nil {
+		logger.ErrorContext(ctx, "failed to delete colleague group", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	if err := s.groups.DeleteColleagueGroup(ctx, groupID); err != nil {
+		err = mapColleagueGroupRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete colleague group", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "colleague group deleted")
+	return nil
+}
+
+// ownedGroup loads a group the principal may change. Users the group is only
+// shared with get ErrUnauthorized; anyone else gets ErrNotFound.
+func (s *ColleagueGroupService) ownedGroup(ctx context.Context, principal Principal, groupID string) (ColleagueGroup, error) {
+	group, err := s.GetColleagueGroup(ctx, principal, groupID)
+	if err != nil {
+		return ColleagueGroup{}, err
+	}
+	if group.OwnerID != principal.UserID && !principal.IsAdmin {
+		return ColleagueGroup{}, ErrUnauthorized
+	}
+	return group, nil
+}
+
+// normalizeInput trims the name, drops duplicate members and shares, drops the
+// owner from the shares and checks every referenced user exists.
+func (s *ColleagueGroupService) normalizeInput(ctx context.Context, ownerID string, input ColleagueGroupInput) (ColleagueGroupInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.MemberIDs = uniqueStrings(input.MemberIDs)
+	var shares []string
+	for _, userID := range uniqueStrings(input.SharedWith) {
+		if userID != ownerID {
+			shares = append(shares, userID)
+		}
+	}
+	input.SharedWith = shares
+
+	vErr := &ValidationError{}
+	switch {
+	case input.Name == "":
+		vErr.add("name", "group name is required")
+	case len([]rune(input.Name)) > maxColleagueGroupNameLength:
+		vErr.add("name", "group name must be at most 100 characters")
+	}
+	if len(input.MemberIDs) == 0 {
+		vErr.add("member_ids", "at least one member is required")
+	}
+	if s.users != nil {
+		for field, ids := range map[string][]string{"member_ids": input.MemberIDs, "shared_with": input.SharedWith} {
+			if len(ids) == 0 || vErr.FieldErrors[field] != "" {
+				continue
+			}
+			missing, err := s.users.MissingUserIDs(ctx, ids)
+			if err != nil {
+				return ColleagueGroupInput{}, err
+			}
+			if len(missing) > 0 {
+				vErr.add(field, fmt.Sprintf("unknown user ids: %s", strings.Join(missing, ", ")))
+			}
+		}
+	}
+	if vErr.HasErrors() {
+		return ColleagueGroupInput{}, vErr
+	}
+	return input, nil
+}
+
+// WithColleagueGroups lets schedule listings select the members of a
+// colleague group, returning the service for chaining.
+func (s *ScheduleService) WithColleagueGroups(directory ColleagueGroupDirectory) *ScheduleService {
+	if s != nil {
+		s.groups = directory
+	}
+	return s
+}
+
+// resolveColleagueGroup adds the members of params.GroupID to the participant
+// filter. Groups the principal cannot use are reported as not existing.
+func (s *ScheduleService) resolveColleagueGroup(ctx context.Context, params ListSchedulesParams) (ListSchedulesParams, error) {
+	if params.GroupID == "" {
+		return params, nil
+	}
+	var members []string
+	var err error
+	if s.groups != nil {
+		members, err = s.groups.ColleagueGroupMembers(ctx, params.Principal, params.GroupID)
+	}
+	if s.groups == nil || isNotFoundError(err) {
+		vErr := &ValidationError{}
+		vErr.add("group", "group does not exist")
+		return params, vErr
+	}
+	if err != nil {
+		return params, err
+	}
+	params.ParticipantIDs = uniqueStrings(append(append([]string(nil), params.ParticipantIDs...), members...))
+	return params, nil
+}
+
+func canUseColleagueGroup(principal Principal, group ColleagueGroup) bool {
+	return principal.IsAdmin || group.OwnerID == principal.UserID || containsString(group.SharedWith, principal.UserID)
+}
+
+func mapColleagueGroupRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrDuplicate):
+		return ErrAlreadyExists
+	}
+	return err
+}
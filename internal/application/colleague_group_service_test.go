@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type colleagueGroupRepoStub struct {
+	groups map[string]ColleagueGroup
+}
+
+func (c *colleagueGroupRepoStub) CreateColleagueGroup(ctx context.Context, group ColleagueGroup) error {
+	for _, existing := range c.groups {
+		if existing.OwnerID == group.OwnerID && existing.Name == group.Name {
+			return ErrAlreadyExists
+		}
+	}
+	c.groups[group.ID] = group
+	return nil
+}
+
+func (c *colleagueGroupRepoStub) GetColleagueGroup(ctx context.Context, id string) (ColleagueGroup, error) {
+	group, ok := c.groups[id]
+	if !ok {
+		return ColleagueGroup{}, ErrNotFound
+	}
+	return group, nil
+}
+
+func (c *colleagueGroupRepoStub) UpdateColleagueGroup(ctx context.Context, group ColleagueGroup) error {
+	c.groups[group.ID] = group
+	return nil
+}
+
+func (c *colleagueGroupRepoStub) DeleteColleagueGroup(ctx context.Context, id string) error {
+	delete(c.groups, id)
+	return nil
+}
+
+func (c *colleagueGroupRepoStub) ListColleagueGroups(ctx context.Context, userID string) ([]ColleagueGroup, error) {
+	var groups []ColleagueGroup
+	for _, group := range c.groups {
+		if group.OwnerID == userID || containsString(group.SharedWith, userID) {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func TestColleagueGroupService(t *testing.T) {
+	ctx := context.Background()
+	repo := &colleagueGroupRepoStub{groups: map[string]ColleagueGroup{}}
+	users := &userDirectoryStub{}
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	svc := NewColleagueGroupService(repo, users, func() string { return "group-1" }, func() time.Time { return now })
+	alice := Principal{UserID: "alice"}
+	bob := Principal{UserID: "bob"}
+	carol := Principal{UserID: "carol"}
+
+	group, err := svc.CreateColleagueGroup(ctx, CreateColleagueGroupParams{Principal: alice, Input: ColleagueGroupInput{
+		Name:       "  Platform team ",
+		MemberIDs:  []string{"carol", "dave", "carol"},
+		SharedWith: []string{"bob", "alice"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateColleagueGroup returned error: %v", err)
+	}
+	if group.Name != "Platform team" || len(group.MemberIDs) != 2 || len(group.SharedWith) != 1 || group.SharedWith[0] != "bob" {
+		t.Fatalf("expected a trimmed name, unique members and the owner dropped from shares, got %+v", group)
+	}
+
+	if members, err := svc.ColleagueGroupMembers(ctx, bob, "group-1"); err != nil || len(members) != 2 || members[0] != "carol" {
+		t.Fatalf("expected bob to use the shared group, got %v (err %v)", members, err)
+	}
+	if _, err := svc.GetColleagueGroup(ctx, carol, "group-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the group to be hidden from carol, got %v", err)
+	}
+	if _, err := svc.UpdateColleagueGroup(ctx, UpdateColleagueGroupParams{Principal: bob, GroupID: "group-1", Input: ColleagueGroupInput{Name: "Mine", MemberIDs: []string{"bob"}}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected bob not to change a group shared with him, got %v", err)
+	}
+	if err := svc.DeleteColleagueGroup(ctx, bob, "group-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected bob not to delete a group shared with him, got %v", err)
+	}
+
+	updated, err := svc.UpdateColleagueGroup(ctx, UpdateColleagueGroupParams{Principal: alice, GroupID: "group-1", Input: ColleagueGroupInput{Name: "Platform", MemberIDs: []string{"dave"}}})
+	if err != nil {
+		t.Fatalf("UpdateColleagueGroup returned error: %v", err)
+	}
+	if updated.OwnerID != "alice" || len(updated.SharedWith) != 0 || !updated.CreatedAt.Equal(now) {
+		t.Fatalf("expected the shares to be revoked and the owner kept, got %+v", updated)
+	}
+	if groups, err := svc.ListColleagueGroups(ctx, bob); err != nil || len(groups) != 0 {
+		t.Fatalf("expected bob to lose access, got %+v (err %v)", groups, err)
+	}
+
+	t.Run("validates the input", func(t *testing.T) {
+		_, err := svc.CreateColleagueGroup(ctx, CreateColleagueGroupParams{Principal: alice, Input: ColleagueGroupInput{Name: " "}})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["name"] == "" || vErr.FieldErrors["member_ids"] == "" {
+			t.Fatalf("expected name and member errors, got %v", err)
+		}
+
+		users.missing = []string{"ghost"}
+		defer func() { users.missing = nil }()
+		_, err = svc.CreateColleagueGroup(ctx, CreateColleagueGroupParams{Principal: alice, Input: ColleagueGroupInput{Name: "Ghosts", MemberIDs: []string{"ghost"}}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["member_ids"] != "unknown user ids: ghost" {
+			t.Fatalf("expected unknown members to be reported, got %v", err)
+		}
+	})
+
+	t.Run("schedule listings expand a group", func(t *testing.T) {
+		schedules := &filteringScheduleRepo{schedules: []Schedule{
+			{ID: "dave-1", CreatorID: "dave", Start: now, End: now.Add(time.Hour)},
+			{ID: "erin-1", CreatorID: "erin", Start: now, End: now.Add(time.Hour)},
+		}}
+		scheduleSvc := NewScheduleService(schedules, nil, nil, nil, nil, func() time.Time { return now }).WithColleagueGroups(svc)
+
+		listed, _, err := scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: alice, GroupID: "group-1"})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(listed) != 1 || listed[0].ID != "dave-1" {
+			t.Fatalf("expected the group member's schedule, got %+v", listed)
+		}
+
+		_, err = scheduleSvc.ListSchedulesPage(ctx, ListSchedulesParams{Principal: carol, GroupID: "group-1"})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["group"] != "group does not exist" {
+			t.Fatalf("expected an inaccessible group to be rejected, got %v", err)
+		}
+	})
+}
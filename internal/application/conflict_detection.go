@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/recurrence"
+	"github.com/example/enterprise-scheduler/internal/scheduler"
+)
+
+// conflictWindowPadding widens the window fetched for conflict detection so
+// all-day rows, stored as floating dates, are not missed in any time zone.
+// Exact overlap is decided on occupied intervals afterwards.
+const conflictWindowPadding = 24 * time.Hour
+
+// ConflictCandidateFilter bounds the schedules fetched for conflict detection.
+type ConflictCandidateFilter struct {
+	ParticipantIDs []string
+	RoomID         *string
+	Start          time.Time
+	End            time.Time
+	ExcludeID      string
+}
+
+// ConflictCandidateRepository lists the schedules that may conflict with a
+// candidate: those with one of ParticipantIDs or RoomID that overlap
+// [Start, End), and recurring ones whose series has not ended by Start.
+type ConflictCandidateRepository interface {
+	ListConflictCandidates(ctx context.Context, filter ConflictCandidateFilter) ([]Schedule, error)
+}
+
+// WithConflictCandidates makes conflict detection query only the schedules
+// sharing a participant or room with the candidate in its time window,
+// returning the service for chaining. Without it, conflict detection lists the
+// window through ListSchedules and does not see recurring schedules whose
+// first occurrence lies outside it.
+func (s *ScheduleService) WithConflictCandidates(repo ConflictCandidateRepository) *ScheduleService {
+	if s != nil {
+		s.conflicts = repo
+	}
+	return s
+}
+
+// detectConflicts reports participant and room conflicts between candidate and
+// the stored schedules, expanding recurring schedules within the candidate's
+// window so each occurrence is checked. A candidate recurring by rules is
+// checked at each of its own occurrences up to the occurrence horizon.
+func (s *ScheduleService) detectConflicts(ctx context.Context, candidate Schedule, rules []RecurrenceRule) ([]ConflictWarning, error) {
+	if s == nil || s.schedules == nil {
+		return nil, nil
+	}
+
+	// Members of live participant groups are checked one by one.
+	withMembers, err := withParticipantGroupMembers(ctx, s.userGroups, []Schedule{candidate})
+	if err != nil {
+		return nil, err
+	}
+	candidate = withMembers[0]
+
+	target := toSchedulerSchedule(candidate)
+	if len(target.Participants) == 0 && target.RoomID == nil {
+		return nil, nil
+	}
+
+	targets, err := s.candidateOccurrences(ctx, candidate, rules)
+	if err != nil {
+		return nil, err
+	}
+	from := target.Start.Add(-conflictWindowPadding)
+	to := target.End.Add(conflictWindowPadding)
+	for _, occurrence := range targets {
+		if padded := occurrence.Start.Add(-conflictWindowPadding); padded.Before(from) {
+			from = padded
+		}
+		if padded := occurrence.End.Add(conflictWindowPadding); padded.After(to) {
+			to = padded
+		}
+	}
+
+	var schedules []Schedule
+	if s.conflicts != nil {
+		schedules, err = s.conflicts.ListConflictCandidates(ctx, ConflictCandidateFilter{
+			ParticipantIDs: append([]string(nil), candidate.ParticipantIDs...),
+			RoomID:         candidate.RoomID,
+			Start:          from,
+			End:            to,
+			ExcludeID:      candidate.ID,
+		})
+	} else {
+		schedules, err = s.schedules.ListSchedules(ctx, ScheduleRepositoryFilter{StartsAfter: &from, EndsBefore: &to})
+	}
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	if schedules, err = withParticipantGroupMembers(ctx, s.userGroups, schedules); err != nil {
+		return nil, err
+	}
+	expanded, err := s.expandRecurrences(ctx, schedules, ListSchedulesParams{StartsAfter: &from, EndsBefore: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]scheduler.Schedule, 0, len(expanded))
+	for _, sched := range expanded {
+		existing = append(existing, toSchedulerSchedule(sched))
+		for _, occ := range sched.Occurrences {
+			if occ.Start.Equal(sched.Start) && occ.End.Equal(sched.End) {
+				continue
+			}
+			occurrence := sched
+			occurrence.Start, occurrence.End = occ.Start, occ.End
+			existing = append(existing, toSchedulerSchedule(occurrence))
+		}
+	}
+
+	conflicts := scheduler.DetectConflicts(existing, target)
+	for _, occurrence := range targets {
+		conflicts = append(conflicts, scheduler.DetectConflicts(existing, occurrence)...)
+	}
+	return toConflictWarnings(uniqueConflicts(conflicts)), nil
+}
+
+// candidateOccurrences expands rules over the occurrence horizon from the
+// candidate's start and returns the occurrences other than the candidate's
+// own time, ready for conflict detection.
+func (s *ScheduleService) candidateOccurrences(ctx context.Context, candidate Schedule, rules []RecurrenceRule) ([]scheduler.Schedule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	horizon := s.occurrenceHorizon
+	if horizon <= 0 {
+		horizon = DefaultOccurrenceHorizon
+	}
+	from, to := candidate.Start, candidate.Start.Add(horizon)
+
+	var holidays recurrence.HolidayCalendar
+	if s.holidays != nil && usesHolidayPolicy(map[string][]RecurrenceRule{candidate.ID: rules}) {
+		var err error
+		if holidays, err = s.holidays.HolidayCalendar(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	generated, err := generateOccurrences(candidate, rules, &from, &to, locationOrDefault(candidate.TimeZone), holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]scheduler.Schedule, 0, len(generated))
+	for _, occ := range generated {
+		if occ.Start.Equal(candidate.Start) && occ.End.Equal(candidate.End) {
+			continue
+		}
+		occurrence := candidate
+		occurrence.Start, occurrence.End = occ.Start, occ.End
+		occurrences = append(occurrences, toSchedulerSchedule(occurrence))
+	}
+	return occurrences, nil
+}
+
+// candidateRecurrenceRules returns the rules a schedule recurs by once written:
+// those of input when given, otherwise its stored rules when the write keeps
+// them.
+func (s *ScheduleService) candidateRecurrenceRules(ctx context.Context, schedule Schedule, input *RecurrenceInput, keepStored bool) ([]RecurrenceRule, error) {
+	if input != nil {
+		return []RecurrenceRule{{
+			Frequency:     input.Frequency,
+			Weekdays:      input.Weekdays,
+			Until:         input.Until,
+			StartsOn:      schedule.Start,
+			HolidayPolicy: input.HolidayPolicy,
+		}}, nil
+	}
+	if !keepStored || s.recurrences == nil || schedule.ID == "" {
+		return nil, nil
+	}
+
+	rulesBySchedule, err := s.recurrences.ListRecurrencesForSchedules(ctx, []string{schedule.ID})
+	if err != nil {
+		return nil, err
+	}
+	return rulesBySchedule[schedule.ID], nil
+}
+
+// uniqueConflicts drops repeats reported when several occurrences of one
+// recurring schedule overlap the candidate.
+func uniqueConflicts(conflicts []scheduler.Conflict) []scheduler.Conflict {
+	type conflictKey struct {
+		scheduleID, participant, room string
+		kind                          scheduler.ConflictType
+	}
+	seen := make(map[conflictKey]struct{}, len(conflicts))
+	unique := conflicts[:0]
+	for _, conflict := range conflicts {
+		key := conflictKey{scheduleID: conflict.WithScheduleID, participant: conflict.Participant, kind: conflict.Type}
+		if conflict.RoomID != nil {
+			key.room = *conflict.RoomID
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, conflict)
+	}
+	return unique
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type conflictCandidatesStub struct {
+	schedules []Schedule
+	filter    ConflictCandidateFilter
+	calls     int
+}
+
+func (c *conflictCandidatesStub) ListConflictCandidates(ctx context.Context, filter ConflictCandidateFilter) ([]Schedule, error) {
+	c.calls++
+	c.filter = filter
+	return append([]Schedule(nil), c.schedules...), nil
+}
+
+func TestScheduleService_DetectConflictsInWindow(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 6, 3, 1, 0, 0, 0, time.UTC) // Monday
+	end := start.Add(time.Hour)
+	roomID := "room-1"
+	input := ScheduleInput{Title: "Planning", Start: start, End: end, ParticipantIDs: []string{"bob"}, RoomID: &roomID, Busy: true}
+
+	t.Run("queries the candidate's window and checks recurring occurrences inside it", func(t *testing.T) {
+		seriesStart := start.AddDate(0, 0, -14)
+		candidates := &conflictCandidatesStub{schedules: []Schedule{{
+			ID:             "standup",
+			CreatorID:      "carol",
+			ParticipantIDs: []string{"bob"},
+			Start:          seriesStart,
+			End:            seriesStart.Add(30 * time.Minute),
+			TimeZone:       "UTC",
+		}}}
+		recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+			"standup": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: seriesStart}},
+		}}
+		svc := NewScheduleService(&filteringScheduleRepo{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, recurrences, func() string { return "sched-new" }, nil).
+			WithConflictCandidates(candidates)
+
+		_, warnings, err := svc.CreateSchedule(ctx, CreateScheduleParams{Principal: Principal{UserID: "alice"}, Input: input})
+		if err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+
+		if candidates.calls != 1 {
+			t.Fatalf("expected one candidate query, got %d", candidates.calls)
+		}
+		filter := candidates.filter
+		if !filter.Start.Equal(start.Add(-conflictWindowPadding)) || !filter.End.Equal(end.Add(conflictWindowPadding)) {
+			t.Fatalf("expected the padded candidate window, got %s - %s", filter.Start, filter.End)
+		}
+		if filter.RoomID == nil || *filter.RoomID != roomID || filter.ExcludeID != "sched-new" {
+			t.Fatalf("unexpected filter %+v", filter)
+		}
+		if !containsString(filter.ParticipantIDs, "bob") {
+			t.Fatalf("expected participants to be queried, got %v", filter.ParticipantIDs)
+		}
+
+		if len(warnings) != 1 || warnings[0].ScheduleID != "standup" || warnings[0].ParticipantID != "bob" {
+			t.Fatalf("expected one participant warning for this week's standup, got %+v", warnings)
+		}
+	})
+
+	t.Run("checks each occurrence of a recurring candidate", func(t *testing.T) {
+		weekTwo := start.AddDate(0, 0, 7)
+		candidates := &conflictCandidatesStub{schedules: []Schedule{{
+			ID:             "offsite",
+			CreatorID:      "carol",
+			ParticipantIDs: []string{"bob"},
+			Start:          weekTwo,
+			End:            weekTwo.Add(2 * time.Hour),
+			TimeZone:       "UTC",
+		}}}
+		svc := NewScheduleService(&filteringScheduleRepo{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, &recurrenceRepoStub{}, func() string { return "sched-new" }, nil).
+			WithConflictCandidates(candidates)
+
+		weekly := input
+		weekly.TimeZone = "UTC"
+		weekly.Recurrence = &RecurrenceInput{Frequency: "weekly", Weekdays: []string{"monday"}}
+		_, warnings, err := svc.CreateSchedule(ctx, CreateScheduleParams{Principal: Principal{UserID: "alice"}, Input: weekly})
+		if err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+
+		if candidates.filter.End.Before(weekTwo.Add(time.Hour)) {
+			t.Fatalf("expected the candidate query to cover the series, got %s - %s", candidates.filter.Start, candidates.filter.End)
+		}
+		if len(warnings) != 1 || warnings[0].ScheduleID != "offsite" || warnings[0].ParticipantID != "bob" {
+			t.Fatalf("expected one participant warning for the second week's occurrence, got %+v", warnings)
+		}
+	})
+
+	t.Run("falls back to a time-window listing", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, func() string { return "sched-new" }, nil)
+
+		if _, _, err := svc.CreateSchedule(ctx, CreateScheduleParams{Principal: Principal{UserID: "alice"}, Input: input}); err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+
+		filter := repo.listFilter
+		if filter.StartsAfter == nil || filter.EndsBefore == nil {
+			t.Fatalf("expected a bounded listing, got %+v", filter)
+		}
+		if !filter.StartsAfter.Equal(start.Add(-conflictWindowPadding)) || !filter.EndsBefore.Equal(end.Add(conflictWindowPadding)) {
+			t.Fatalf("expected the padded candidate window, got %s - %s", filter.StartsAfter, filter.EndsBefore)
+		}
+	})
+}
@@ -17,6 +17,10 @@ var (
 	ErrSessionExpired = errors.New("application: session expired")
 	// ErrSessionRevoked indicates the session has been explicitly revoked.
 	ErrSessionRevoked = errors.New("application: session revoked")
+	// ErrStreamClosed indicates the event stream service has shut down.
+	ErrStreamClosed = errors.New("application: event stream closed")
+	// ErrPreconditionFailed indicates a conditional write did not match the resource's current version.
+	ErrPreconditionFailed = errors.New("application: precondition failed")
 )
 
 // ValidationError captures field level validation issues that callers can surface to users.
@@ -0,0 +1,22 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VersionETag returns the ETag of the JSON representation of a schedule, room
+// or user at version. CalDAV serves schedules with ScheduleETag instead.
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// checkIfMatch fails with ErrPreconditionFailed unless ifMatch is empty, "*"
+// or lists the ETag of version.
+func checkIfMatch(ifMatch string, version int) error {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" || etagListContains(ifMatch, VersionETag(version)) {
+		return nil
+	}
+	return ErrPreconditionFailed
+}
@@ -0,0 +1,292 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// eventStreamBatchSize bounds the outbox page a stream reads per wake-up.
+const eventStreamBatchSize = 100
+
+// EventStreamRepository reads the event outbox in sequence order.
+type EventStreamRepository interface {
+	ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]OutboxEvent, error)
+	LatestOutboxSequence(ctx context.Context) (int64, error)
+}
+
+// EventStreamService pushes schedule events recorded in the outbox to live
+// streams. Run polls the outbox and wakes open streams when events are
+// appended; every stream then reads the events after its own cursor, so
+// resuming from a Last-Event-ID and live delivery share one code path and
+// work across server instances.
+type EventStreamService struct {
+	outbox     EventStreamRepository
+	userGroups UserGroupDirectory
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	latest int64
+	wake   chan struct{}
+	closed bool
+}
+
+// NewEventStreamService constructs an event stream service reading from outbox.
+func NewEventStreamService(outbox EventStreamRepository) *EventStreamService {
+	return NewEventStreamServiceWithLogger(outbox, nil)
+}
+
+// NewEventStreamServiceWithLogger constructs an event stream service with a specified logger.
+func NewEventStreamServiceWithLogger(outbox EventStreamRepository, logger *slog.Logger) *EventStreamService {
+	return &EventStreamService{
+		outbox: outbox,
+		logger: defaultLogger(logger),
+		wake:   make(chan struct{}),
+	}
+}
+
+// WithUserGroups delivers schedule events to the current members of the
+// schedule's live participant groups, returning the service for chaining.
+func (s *EventStreamService) WithUserGroups(directory UserGroupDirectory) *EventStreamService {
+	if s != nil {
+		s.userGroups = directory
+	}
+	return s
+}
+
+func (s *EventStreamService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "EventStreamService", operation, attrs...)
+}
+
+// OpenEventStream starts a stream of the schedule events relevant to the
+// principal. Without a LastEventID the stream starts after the newest event.
+func (s *EventStreamService) OpenEventStream(ctx context.Context, params OpenEventStreamParams) (stream *EventStream, err error) {
+	if s == nil {
+		err = fmt.Errorf("EventStreamService is nil")
+		return
+	}
+	if s.outbox == nil {
+		err = fmt.Errorf("event outbox not configured")
+		return
+	}
+	if params.Principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+
+	logger := s.loggerWith(ctx, "OpenEventStream",
+		"principal_id", params.Principal.UserID,
+		"watched_user_count", len(params.WatchedUserIDs),
+		"watched_room_count", len(params.WatchedRoomIDs),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to open event stream", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("cursor", stream.cursor).InfoContext(ctx, "event stream opened")
+	}()
+
+	if _, closed := s.waiter(); closed {
+		err = ErrStreamClosed
+		return
+	}
+
+	latest, err := s.outbox.LatestOutboxSequence(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to read outbox sequence: %w", err)
+		return
+	}
+
+	cursor := latest
+	if params.LastEventID != nil && *params.LastEventID >= 0 && *params.LastEventID < latest {
+		cursor = *params.LastEventID
+	}
+
+	stream = &EventStream{
+		service: s,
+		cursor:  cursor,
+		users:   make(map[string]struct{}, len(params.WatchedUserIDs)+1),
+		rooms:   make(map[string]struct{}, len(params.WatchedRoomIDs)),
+	}
+	stream.users[params.Principal.UserID] = struct{}{}
+	for _, id := range params.WatchedUserIDs {
+		stream.users[id] = struct{}{}
+	}
+	for _, id := range params.WatchedRoomIDs {
+		stream.rooms[id] = struct{}{}
+	}
+	return stream, nil
+}
+
+// Run polls the outbox every interval and wakes open streams when new events
+// arrive. When ctx is cancelled the service closes and every stream's Next
+// returns ErrStreamClosed, letting handlers finish before server shutdown.
+func (s *EventStreamService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.poll(ctx)
+		select {
+		case <-ctx.Done():
+			s.close()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *EventStreamService) poll(ctx context.Context) {
+	if s.outbox == nil {
+		return
+	}
+	latest, err := s.outbox.LatestOutboxSequence(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.loggerWith(ctx, "Run").WarnContext(ctx, "failed to poll event outbox", "error", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || latest <= s.latest {
+		return
+	}
+	s.latest = latest
+	close(s.wake)
+	s.wake = make(chan struct{})
+}
+
+func (s *EventStreamService) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.wake)
+}
+
+// waiter returns the channel closed on the next wake-up and whether the
+// service has shut down.
+func (s *EventStreamService) waiter() (<-chan struct{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.wake, s.closed
+}
+
+// EventStream is one client's position in the outbox.
+type EventStream struct {
+	service *EventStreamService
+	cursor  int64
+	users   map[string]struct{}
+	rooms   map[string]struct{}
+}
+
+// Next blocks until relevant events follow the stream's cursor and returns
+// them in order. It returns ctx.Err() when ctx ends first and ErrStreamClosed
+// once the service has shut down.
+func (st *EventStream) Next(ctx context.Context) ([]StreamEvent, error) {
+	for {
+		// Take the wake channel before reading so an append between the read
+		// and the wait is not missed.
+		wake, closed := st.service.waiter()
+		if closed {
+			return nil, ErrStreamClosed
+		}
+
+		events, err := st.service.outbox.ListOutboxEventsAfter(ctx, st.cursor, eventStreamBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event outbox: %w", err)
+		}
+
+		var out []StreamEvent
+		members := make(map[string][]string)
+		for _, event := range events {
+			relevant, err := st.relevant(ctx, event, members)
+			if err != nil {
+				return nil, err
+			}
+			st.cursor = event.Sequence
+			if relevant {
+				out = append(out, StreamEvent{Sequence: event.Sequence, Type: event.Type, Payload: event.Payload})
+			}
+		}
+		if len(out) > 0 {
+			return out, nil
+		}
+		if len(events) == eventStreamBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// relevant reports whether a schedule event involves a watched user or room.
+// Live participant groups count through their current members, which are
+// looked up once per group and kept in members.
+func (st *EventStream) relevant(ctx context.Context, event OutboxEvent, members map[string][]string) (bool, error) {
+	switch event.Type {
+	case EventScheduleCreated, EventScheduleUpdated, EventScheduleDeleted:
+	default:
+		return false, nil
+	}
+
+	var envelope struct {
+		Data scheduleEventData `json:"data"`
+	}
+	if err := json.Unmarshal(event.Payload, &envelope); err != nil {
+		return false, nil
+	}
+	data := envelope.Data
+
+	users := append([]string{data.CreatorID}, data.ParticipantIDs...)
+	groups := data.ParticipantGroupIDs
+	rooms := []*string{data.RoomID}
+	if data.Previous != nil {
+		users = append(users, data.Previous.ParticipantIDs...)
+		groups = append(append([]string(nil), groups...), data.Previous.ParticipantGroupIDs...)
+		rooms = append(rooms, data.Previous.RoomID)
+	}
+	for _, id := range rooms {
+		if id == nil {
+			continue
+		}
+		if _, ok := st.rooms[*id]; ok {
+			return true, nil
+		}
+	}
+
+	if directory := st.service.userGroups; directory != nil {
+		for _, groupID := range groups {
+			groupMembers, ok := members[groupID]
+			if !ok {
+				var err error
+				groupMembers, err = directory.UserGroupMembers(ctx, groupID)
+				if err != nil && !isNotFoundError(err) {
+					return false, fmt.Errorf("failed to resolve participant group: %w", err)
+				}
+				members[groupID] = groupMembers
+			}
+			users = append(users, groupMembers...)
+		}
+	}
+	for _, id := range users {
+		if _, ok := st.users[id]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
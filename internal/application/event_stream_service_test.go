@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEventStreamService(t *testing.T) {
+	roomA := "room-a"
+	publish := func(t *testing.T, publisher *WebhookService, eventType EventType, data any) {
+		t.Helper()
+		if err := publisher.PublishEvent(context.Background(), Event{Type: eventType, ResourceID: "sch", Data: data}); err != nil {
+			t.Fatalf("PublishEvent returned error: %v", err)
+		}
+	}
+	newPipeline := func() (*webhookRepoStub, *WebhookService, *EventStreamService) {
+		repo := newWebhookRepoStub()
+		counter := 0
+		ids := func() string {
+			counter++
+			return fmt.Sprintf("evt-%d", counter)
+		}
+		return repo, NewWebhookService(repo, nil, ids, nil, nil), NewEventStreamService(repo)
+	}
+
+	t.Run("filters relevant schedule events and resumes after last event id", func(t *testing.T) {
+		_, publisher, streams := newPipeline()
+
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "s1", CreatorID: "alice", ParticipantIDs: []string{"bob"}}))
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "s2", CreatorID: "carol", ParticipantIDs: []string{"carol"}}))
+		publish(t, publisher, EventRoomCreated, newRoomEventData(Room{ID: roomA}))
+		publish(t, publisher, EventScheduleUpdated, newScheduleEventData(Schedule{ID: "s3", CreatorID: "carol", RoomID: &roomA}))
+		publish(t, publisher, EventScheduleUpdated, newScheduleUpdatedEventData(
+			Schedule{ID: "s4", CreatorID: "carol", ParticipantIDs: []string{"carol"}},
+			Schedule{ID: "s4", CreatorID: "carol", ParticipantIDs: []string{"bob", "carol"}},
+		))
+		publish(t, publisher, EventScheduleDeleted, newScheduleEventData(Schedule{ID: "s5", CreatorID: "dave", ParticipantIDs: []string{"erin"}}))
+
+		from := int64(0)
+		stream, err := streams.OpenEventStream(context.Background(), OpenEventStreamParams{
+			Principal:      Principal{UserID: "bob"},
+			WatchedUserIDs: []string{"erin"},
+			WatchedRoomIDs: []string{roomA},
+			LastEventID:    &from,
+		})
+		if err != nil {
+			t.Fatalf("OpenEventStream returned error: %v", err)
+		}
+
+		events, err := stream.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		var sequences []int64
+		for _, event := range events {
+			sequences = append(sequences, event.Sequence)
+		}
+		// 1: bob participates, 4: watched room, 5: bob was removed, 6: watched colleague.
+		if want := []int64{1, 4, 5, 6}; !equalInt64s(sequences, want) {
+			t.Fatalf("expected sequences %v, got %v", want, sequences)
+		}
+
+		resumeFrom := int64(4)
+		stream, err = streams.OpenEventStream(context.Background(), OpenEventStreamParams{
+			Principal:   Principal{UserID: "bob"},
+			LastEventID: &resumeFrom,
+		})
+		if err != nil {
+			t.Fatalf("OpenEventStream returned error: %v", err)
+		}
+		events, err = stream.Next(context.Background())
+		if err != nil || len(events) != 1 || events[0].Sequence != 5 || events[0].Type != EventScheduleUpdated {
+			t.Fatalf("expected resumed event 5, got %#v (err=%v)", events, err)
+		}
+	})
+
+	t.Run("wakes on new events and closes on shutdown", func(t *testing.T) {
+		_, publisher, streams := newPipeline()
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "old", CreatorID: "bob"}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			streams.Run(ctx, 5*time.Millisecond)
+			close(done)
+		}()
+
+		stream, err := streams.OpenEventStream(context.Background(), OpenEventStreamParams{Principal: Principal{UserID: "bob"}})
+		if err != nil {
+			t.Fatalf("OpenEventStream returned error: %v", err)
+		}
+
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		if _, err := stream.Next(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected no backlog without last event id, got %v", err)
+		}
+		waitCancel()
+
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "new", CreatorID: "bob"}))
+		waitCtx, waitCancel = context.WithTimeout(context.Background(), time.Second)
+		events, err := stream.Next(waitCtx)
+		waitCancel()
+		if err != nil || len(events) != 1 || events[0].Sequence != 2 {
+			t.Fatalf("expected live event 2, got %#v (err=%v)", events, err)
+		}
+
+		cancel()
+		<-done
+		if _, err := stream.Next(context.Background()); !errors.Is(err, ErrStreamClosed) {
+			t.Fatalf("expected ErrStreamClosed after shutdown, got %v", err)
+		}
+		if _, err := streams.OpenEventStream(context.Background(), OpenEventStreamParams{Principal: Principal{UserID: "bob"}}); !errors.Is(err, ErrStreamClosed) {
+			t.Fatalf("expected ErrStreamClosed when opening after shutdown, got %v", err)
+		}
+	})
+
+	t.Run("delivers to the current members of live participant groups", func(t *testing.T) {
+		_, publisher, streams := newPipeline()
+		streams.WithUserGroups(&userGroupDirectoryStub{members: map[string][]string{"grp-sales": {"bob"}}})
+
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "s1", CreatorID: "carol", ParticipantGroupIDs: []string{"grp-sales"}}))
+		publish(t, publisher, EventScheduleCreated, newScheduleEventData(Schedule{ID: "s2", CreatorID: "carol", ParticipantGroupIDs: []string{"grp-gone"}}))
+		publish(t, publisher, EventScheduleUpdated, newScheduleUpdatedEventData(
+			Schedule{ID: "s1", CreatorID: "carol"},
+			Schedule{ID: "s1", CreatorID: "carol", ParticipantGroupIDs: []string{"grp-sales"}},
+		))
+
+		from := int64(0)
+		stream, err := streams.OpenEventStream(context.Background(), OpenEventStreamParams{Principal: Principal{UserID: "bob"}, LastEventID: &from})
+		if err != nil {
+			t.Fatalf("OpenEventStream returned error: %v", err)
+		}
+		events, err := stream.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		var sequences []int64
+		for _, event := range events {
+			sequences = append(sequences, event.Sequence)
+		}
+		// 1: bob is in the invited group, 3: the update removed his group.
+		if want := []int64{1, 3}; !equalInt64s(sequences, want) {
+			t.Fatalf("expected sequences %v, got %v", want, sequences)
+		}
+	})
+
+	t.Run("requires an authenticated principal", func(t *testing.T) {
+		_, _, streams := newPipeline()
+		if _, err := streams.OpenEventStream(context.Background(), OpenEventStreamParams{}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
+
+func equalInt64s(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventType names a domain event emitted by a service mutation.
+type EventType string
+
+const (
+	EventScheduleCreated EventType = "schedule.created"
+	EventScheduleUpdated EventType = "schedule.updated"
+	EventScheduleDeleted EventType = "schedule.deleted"
+	EventRoomCreated     EventType = "room.created"
+	EventRoomUpdated     EventType = "room.updated"
+	EventRoomDeleted     EventType = "room.deleted"
+	EventUserCreated     EventType = "user.created"
+	EventUserUpdated     EventType = "user.updated"
+	EventUserDeleted     EventType = "user.deleted"
+)
+
+// EventTypes lists every event type in a stable order.
+var EventTypes = []EventType{
+	EventScheduleCreated,
+	EventScheduleUpdated,
+	EventScheduleDeleted,
+	EventRoomCreated,
+	EventRoomUpdated,
+	EventRoomDeleted,
+	EventUserCreated,
+	EventUserUpdated,
+	EventUserDeleted,
+}
+
+// Event describes a completed mutation. Data is the JSON-serialisable
+// resource snapshot delivered to subscribers.
+type Event struct {
+	ID         string
+	Type       EventType
+	ResourceID string
+	ActorID    string
+	OccurredAt time.Time
+	Data       any
+}
+
+// EventPublisher durably records events for asynchronous delivery. Services
+// fail the mutation's request when publishing fails so that a successful
+// response always has its event recorded.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event Event) error
+}
+
+func isKnownEventType(value string) bool {
+	for _, eventType := range EventTypes {
+		if string(eventType) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func publishEvent(ctx context.Context, publisher EventPublisher, event Event) error {
+	if publisher == nil {
+		return nil
+	}
+	if err := publisher.PublishEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+type scheduleEventData struct {
+	ID                  string   `json:"id"`
+	CreatorID           string   `json:"creator_id"`
+	Title               string   `json:"title"`
+	Description         string   `json:"description,omitempty"`
+	Start               string   `json:"start"`
+	End                 string   `json:"end"`
+	TimeZone            string   `json:"time_zone,omitempty"`
+	AllDay              bool     `json:"all_day"`
+	Busy                bool     `json:"busy"`
+	RoomID              *string  `json:"room_id,omitempty"`
+	WebConferenceURL    string   `json:"web_conference_url,omitempty"`
+	ParticipantIDs      []string `json:"participant_ids"`
+	CalendarID          *string  `json:"calendar_id,omitempty"`
+	ParticipantGroupIDs []string `json:"participant_group_ids,omitempty"`
+	CreatedAt           string   `json:"created_at"`
+	UpdatedAt           string   `json:"updated_at"`
+
+	// Previous is set on schedule.updated so that consumers can notify users
+	// and rooms that the update removed.
+	Previous *scheduleAudience `json:"previous,omitempty"`
+}
+
+// scheduleAudience lists who and what a schedule involves.
+type scheduleAudience struct {
+	ParticipantIDs      []string `json:"participant_ids"`
+	RoomID              *string  `json:"room_id,omitempty"`
+	ParticipantGroupIDs []string `json:"participant_group_ids,omitempty"`
+}
+
+func newScheduleEventData(schedule Schedule) scheduleEventData {
+	return scheduleEventData{
+		ID:                  schedule.ID,
+		CreatorID:           schedule.CreatorID,
+		Title:               schedule.Title,
+		Description:         schedule.Description,
+		Start:               schedule.Start.UTC().Format(time.RFC3339),
+		End:                 schedule.End.UTC().Format(time.RFC3339),
+		TimeZone:            schedule.TimeZone,
+		AllDay:              schedule.AllDay,
+		Busy:                schedule.Busy,
+		RoomID:              schedule.RoomID,
+		WebConferenceURL:    schedule.WebConferenceURL,
+		ParticipantIDs:      append([]string{}, schedule.ParticipantIDs...),
+		CalendarID:          schedule.CalendarID,
+		ParticipantGroupIDs: append([]string(nil), schedule.ParticipantGroupIDs...),
+		CreatedAt:           schedule.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:           schedule.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func newScheduleUpdatedEventData(schedule, previous Schedule) scheduleEventData {
+	data := newScheduleEventData(schedule)
+	data.Previous = &scheduleAudience{
+		ParticipantIDs:      append([]string{}, previous.ParticipantIDs...),
+		RoomID:              previous.RoomID,
+		ParticipantGroupIDs: append([]string(nil), previous.ParticipantGroupIDs...),
+	}
+	return data
+}
+
+type roomEventData struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Location   string  `json:"location"`
+	Capacity   int     `json:"capacity"`
+	Facilities *string `json:"facilities,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+func newRoomEventData(room Room) roomEventData {
+	return roomEventData{
+		ID:         room.ID,
+		Name:       room.Name,
+		Location:   room.Location,
+		Capacity:   room.Capacity,
+		Facilities: room.Facilities,
+		CreatedAt:  room.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:  room.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+type userEventData struct {
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	IsAdmin      bool   `json:"is_admin"`
+	TimeZone     string `json:"time_zone,omitempty"`
+	DepartmentID string `json:"department_id,omitempty"`
+	ManagerID    string `json:"manager_id,omitempty"`
+	Location     string `json:"location,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+func newUserEventData(user User) userEventData {
+	return userEventData{
+		ID:           user.ID,
+		Email:        user.Email,
+		DisplayName:  user.DisplayName,
+		IsAdmin:      user.IsAdmin,
+		TimeZone:     user.TimeZone,
+		DepartmentID: user.DepartmentID,
+		ManagerID:    user.ManagerID,
+		Location:     user.Location,
+		CreatedAt:    user.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:    user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// deletedEventData is the payload of *.deleted events.
+type deletedEventData struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,250 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/holiday"
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/recurrence"
+)
+
+// HolidayRepository persists company closure days. Built-in public holidays
+// are computed and never stored.
+type HolidayRepository interface {
+	ListCompanyHolidays(ctx context.Context) ([]Holiday, error)
+	CreateCompanyHoliday(ctx context.Context, closure Holiday) (Holiday, error)
+	DeleteCompanyHoliday(ctx context.Context, date time.Time) error
+}
+
+// HolidayDirectory provides the holiday calendar consulted when expanding
+// recurrences with a holiday policy.
+type HolidayDirectory interface {
+	HolidayCalendar(ctx context.Context) (recurrence.HolidayCalendar, error)
+}
+
+// HolidayService exposes Japanese public holidays and manages company closure days.
+type HolidayService struct {
+	closures    HolidayRepository
+	occurrences OccurrenceIndex
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewHolidayService constructs a holiday service with the provided dependencies.
+func NewHolidayService(closures HolidayRepository, now func() time.Time) *HolidayService {
+	return NewHolidayServiceWithLogger(closures, now, nil)
+}
+
+// NewHolidayServiceWithLogger constructs a holiday service with a specified logger.
+func NewHolidayServiceWithLogger(closures HolidayRepository, now func() time.Time, logger *slog.Logger) *HolidayService {
+	if now == nil {
+		now = time.Now
+	}
+	return &HolidayService{
+		closures: closures,
+		now:      now,
+		logger:   defaultLogger(logger),
+	}
+}
+
+// WithOccurrenceIndex queues the materialized occurrences of rules with a
+// holiday policy for regeneration whenever a closure changes, returning the
+// service for chaining.
+func (s *HolidayService) WithOccurrenceIndex(index OccurrenceIndex) *HolidayService {
+	if s != nil {
+		s.occurrences = index
+	}
+	return s
+}
+
+// markHolidayOccurrencesStale runs after the closure is stored, so a failure is
+// logged rather than returned; the index can be repaired with a rebuild.
+func (s *HolidayService) markHolidayOccurrencesStale(ctx context.Context, logger *slog.Logger) {
+	if s.occurrences == nil {
+		return
+	}
+	if err := s.occurrences.MarkHolidayOccurrencesStale(ctx); err != nil {
+		logger.ErrorContext(ctx, "failed to queue occurrence refresh", "error", err)
+	}
+}
+
+func (s *HolidayService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "HolidayService", operation, attrs...)
+}
+
+// ListHolidays returns the public holidays and company closures of a year ordered
+// by date. A zero year selects the current year.
+func (s *HolidayService) ListHolidays(ctx context.Context, params ListHolidaysParams) (holidays []Holiday, err error) {
+	if s == nil {
+		err = fmt.Errorf("HolidayService is nil")
+		return
+	}
+
+	year := params.Year
+	if year == 0 {
+		year = s.now().In(locationOrDefault(params.Principal.TimeZone)).Year()
+	}
+
+	logger := s.loggerWith(ctx, "ListHolidays", "principal_id", params.Principal.UserID, "year", year)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list holidays", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(holidays)).InfoContext(ctx, "holidays listed")
+	}()
+
+	if year < holiday.MinYear || year > holiday.MaxYear {
+		vErr := &ValidationError{}
+		vErr.add("year", fmt.Sprintf("year must be between %d and %d", holiday.MinYear, holiday.MaxYear))
+		err = vErr
+		return
+	}
+
+	calendar, err := s.calendar(ctx)
+	if err != nil {
+		return
+	}
+	for _, h := range calendar.Holidays(year) {
+		holidays = append(holidays, Holiday{Date: h.Date, Name: h.Name, Kind: string(h.Kind)})
+	}
+	return
+}
+
+// CreateCompanyHoliday adds a company closure day. Only administrators may manage closures.
+func (s *HolidayService) CreateCompanyHoliday(ctx context.Context, params CreateCompanyHolidayParams) (closure Holiday, err error) {
+	if s == nil {
+		err = fmt.Errorf("HolidayService is nil")
+		return
+	}
+	if s.closures == nil {
+		err = fmt.Errorf("holiday repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateCompanyHoliday", "principal_id", params.Principal.UserID)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create company holiday", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("date", closure.Date.Format("2006-01-02")).InfoContext(ctx, "company holiday created")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	name := strings.TrimSpace(params.Input.Name)
+	vErr := &ValidationError{}
+	if params.Input.Date.IsZero() {
+		vErr.add("date", "date is required")
+	}
+	if name == "" {
+		vErr.add("name", "holiday name is required")
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	closure, err = s.closures.CreateCompanyHoliday(ctx, Holiday{
+		Date: holiday.DateOf(params.Input.Date),
+		Name: name,
+		Kind: string(holiday.KindCompany),
+	})
+	if err != nil {
+		err = mapHolidayRepoError(err)
+		return
+	}
+	s.markHolidayOccurrencesStale(ctx, logger)
+	return
+}
+
+// DeleteCompanyHoliday removes the company closure on the calendar date of date.
+func (s *HolidayService) DeleteCompanyHoliday(ctx context.Context, principal Principal, date time.Time) error {
+	if s == nil {
+		return fmt.Errorf("HolidayService is nil")
+	}
+	if s.closures == nil {
+		return fmt.Errorf("holiday repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteCompanyHoliday", "principal_id", principal.UserID, "date", date.Format("2006-01-02"))
+	if !principal.IsAdmin {
+		logger.WarnContext(ctx, "unauthorized company holiday delete attempt", "error_kind", ErrorKind(ErrUnauthorized))
+		return ErrUnauthorized
+	}
+
+	if err := s.closures.DeleteCompanyHoliday(ctx, holiday.DateOf(date)); err != nil {
+		err = mapHolidayRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete company holiday", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	s.markHolidayOccurrencesStale(ctx, logger)
+
+	logger.InfoContext(ctx, "company holiday deleted")
+	return nil
+}
+
+// HolidayCalendar returns a calendar of public holidays and current company closures.
+func (s *HolidayService) HolidayCalendar(ctx context.Context) (recurrence.HolidayCalendar, error) {
+	if s == nil {
+		return nil, fmt.Errorf("HolidayService is nil")
+	}
+	return s.calendar(ctx)
+}
+
+func (s *HolidayService) calendar(ctx context.Context) (*holiday.Calendar, error) {
+	if s.closures == nil {
+		return holiday.NewCalendar(nil), nil
+	}
+	stored, err := s.closures.ListCompanyHolidays(ctx)
+	if err != nil {
+		return nil, mapHolidayRepoError(err)
+	}
+	closures := make([]holiday.Holiday, 0, len(stored))
+	for _, closure := range stored {
+		closures = append(closures, holiday.Holiday{Date: closure.Date, Name: closure.Name})
+	}
+	return holiday.NewCalendar(closures), nil
+}
+
+func toRecurrenceHolidayPolicy(policy HolidayPolicy) recurrence.HolidayPolicy {
+	switch policy {
+	case HolidayPolicySkip:
+		return recurrence.HolidayPolicySkip
+	case HolidayPolicyNextBusinessDay:
+		return recurrence.HolidayPolicyNextBusinessDay
+	default:
+		return recurrence.HolidayPolicyNone
+	}
+}
+
+func isValidHolidayPolicy(policy HolidayPolicy) bool {
+	switch policy {
+	case HolidayPolicyNone, HolidayPolicySkip, HolidayPolicyNextBusinessDay:
+		return true
+	default:
+		return false
+	}
+}
+
+func mapHolidayRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound) {
+		return ErrNotFound
+	}
+	if errors.Is(err, persistence.ErrDuplicate) {
+		return ErrAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+type holidayRepoStub struct {
+	closures []Holiday
+	deleted  time.Time
+}
+
+func (h *holidayRepoStub) ListCompanyHolidays(ctx context.Context) ([]Holiday, error) {
+	return h.closures, nil
+}
+
+func (h *holidayRepoStub) CreateCompanyHoliday(ctx context.Context, closure Holiday) (Holiday, error) {
+	for _, existing := range h.closures {
+		if existing.Date.Equal(closure.Date) {
+			return Holiday{}, persistence.ErrDuplicate
+		}
+	}
+	h.closures = append(h.closures, closure)
+	return closure, nil
+}
+
+func (h *holidayRepoStub) DeleteCompanyHoliday(ctx context.Context, date time.Time) error {
+	h.deleted = date
+	return nil
+}
+
+func TestHolidayService_ListHolidays(t *testing.T) {
+	repo := &holidayRepoStub{closures: []Holiday{{Date: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), Name: "年末休業"}}}
+	now := func() time.Time { return time.Date(2024, 12, 31, 20, 0, 0, 0, time.UTC) }
+	svc := NewHolidayService(repo, now)
+
+	t.Run("merges closures with public holidays", func(t *testing.T) {
+		holidays, err := svc.ListHolidays(context.Background(), ListHolidaysParams{Principal: Principal{UserID: "user-1"}, Year: 2024})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(holidays) != 22 {
+			t.Fatalf("expected 21 public holidays and 1 closure, got %d", len(holidays))
+		}
+		last := holidays[len(holidays)-1]
+		if last.Name != "年末休業" || last.Kind != "company" {
+			t.Fatalf("expected closure last, got %+v", last)
+		}
+	})
+
+	t.Run("defaults to the caller's current year", func(t *testing.T) {
+		holidays, err := svc.ListHolidays(context.Background(), ListHolidaysParams{Principal: Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(holidays) == 0 || holidays[0].Date.Year() != 2025 {
+			t.Fatalf("expected 2025 holidays for a Tokyo caller, got %+v", holidays)
+		}
+	})
+
+	t.Run("rejects unsupported years", func(t *testing.T) {
+		_, err := svc.ListHolidays(context.Background(), ListHolidaysParams{Year: 1900})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["year"] == "" {
+			t.Fatalf("expected year validation error, got %v", err)
+		}
+	})
+}
+
+func TestHolidayService_CompanyHolidays(t *testing.T) {
+	repo := &holidayRepoStub{}
+	svc := NewHolidayService(repo, nil)
+	input := CompanyHolidayInput{Date: time.Date(2024, 8, 14, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60)), Name: " 夏季休業 "}
+
+	if _, err := svc.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{Principal: Principal{UserID: "user-1"}, Input: input}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	admin := Principal{UserID: "admin", IsAdmin: true}
+	closure, err := svc.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{Principal: admin, Input: input})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !closure.Date.Equal(time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC)) || closure.Name != "夏季休業" {
+		t.Fatalf("expected closure on the written calendar date, got %+v", closure)
+	}
+	if _, err := svc.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{Principal: admin, Input: input}); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists for duplicate closure, got %v", err)
+	}
+
+	calendar, err := svc.HolidayCalendar(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !calendar.IsHoliday(time.Date(2024, 8, 14, 10, 0, 0, 0, time.UTC)) {
+		t.Fatal("expected closure to be part of the calendar")
+	}
+
+	if err := svc.DeleteCompanyHoliday(context.Background(), admin, input.Date); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !repo.deleted.Equal(closure.Date) {
+		t.Fatalf("expected closure date to be deleted, got %v", repo.deleted)
+	}
+}
@@ -54,6 +54,10 @@ func ErrorKind(err error) string {
 		return "session_expired"
 	case errors.Is(err, ErrSessionRevoked):
 		return "session_revoked"
+	case errors.Is(err, ErrStreamClosed):
+		return "stream_closed"
+	case errors.Is(err, ErrPreconditionFailed):
+		return "precondition_failed"
 	}
 
 	var vErr *ValidationError
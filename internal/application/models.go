@@ -6,13 +6,28 @@ import "time"
 type Principal struct {
 	UserID  string
 	IsAdmin bool
+	// TimeZone is the caller's preferred IANA zone used for period boundaries and rendering.
+	TimeZone string
 }
 
+// HolidayPolicy selects how recurring occurrences that fall on a holiday are treated.
+type HolidayPolicy string
+
+const (
+	// HolidayPolicyNone keeps occurrences on holidays.
+	HolidayPolicyNone HolidayPolicy = ""
+	// HolidayPolicySkip drops occurrences on holidays.
+	HolidayPolicySkip HolidayPolicy = "skip"
+	// HolidayPolicyNextBusinessDay moves occurrences on holidays to the next business day.
+	HolidayPolicyNextBusinessDay HolidayPolicy = "next_business_day"
+)
+
 // RecurrenceInput captures caller provided recurrence rule fields.
 type RecurrenceInput struct {
-	Frequency string
-	Weekdays  []string
-	Until     *time.Time
+	Frequency     string
+	Weekdays      []string
+	Until         *time.Time
+	HolidayPolicy HolidayPolicy
 }
 
 // ScheduleInput captures caller provided schedule fields.
@@ -26,9 +41,31 @@ type ScheduleInput struct {
 	WebConferenceURL string
 	ParticipantIDs   []string
 	Recurrence       *RecurrenceInput
+	// CalendarID places the schedule on a shared calendar the principal can
+	// edit. Nil leaves it on no calendar.
+	CalendarID *string
+	// ParticipantGroupIDs invites user groups as a unit. ParticipantGroupMode
+	// decides whether their members are copied into the participants or the
+	// groups are kept as a live reference.
+	ParticipantGroupIDs  []string
+	ParticipantGroupMode ParticipantGroupMode
+	// TimeZone is the IANA zone the schedule was authored in. When empty the
+	// caller's zone (or DefaultTimeZone) is used.
+	TimeZone string
+	// AllDay marks a date-only schedule. Only the calendar dates of Start and End
+	// are used and End names the last day (inclusive).
+	AllDay bool
+	// Busy makes an all-day schedule block its participants in conflict detection.
+	// Timed schedules always block.
+	Busy bool
+	// ReminderMinutes lists how many minutes before the start participants are
+	// reminded. When empty each participant's own defaults apply.
+	ReminderMinutes []int
 }
 
 // Schedule represents a persisted meeting schedule.
+// AllDay schedules hold floating dates: Start is UTC midnight of the first day
+// and End is UTC midnight of the day after the last day.
 type Schedule struct {
 	ID               string
 	CreatorID        string
@@ -36,12 +73,27 @@ type Schedule struct {
 	Description      string
 	Start            time.Time
 	End              time.Time
+	TimeZone         string
+	AllDay           bool
+	Busy             bool
 	RoomID           *string
 	WebConferenceURL string
 	ParticipantIDs   []string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	Occurrences      []ScheduleOccurrence
+	ReminderMinutes  []int
+	// CalendarID names the shared calendar the schedule belongs to, if any.
+	// Its owner and editors may change the schedule and its viewers see it.
+	CalendarID *string
+	// ParticipantGroupIDs lists user groups invited as a live reference.
+	// Their current members take part alongside ParticipantIDs.
+	ParticipantGroupIDs []string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	// Version increases on every update and backs the schedule's ETag.
+	Version     int
+	Occurrences []ScheduleOccurrence
+	// BusyOnly marks a schedule shown to a manager of its participants who
+	// may only see when they are busy; its details are withheld.
+	BusyOnly bool
 }
 
 // ScheduleOccurrence represents an expanded occurrence generated from a recurrence rule.
@@ -53,11 +105,14 @@ type ScheduleOccurrence struct {
 }
 
 // ConflictWarning describes a scheduling conflict that should be surfaced to callers.
+// Availability warnings (outside working hours, out of office, auto-declined)
+// carry no ScheduleID; out-of-office warnings reference the OutOfOfficeID instead.
 type ConflictWarning struct {
 	ScheduleID    string
 	Type          string
 	ParticipantID string
 	RoomID        *string
+	OutOfOfficeID string
 }
 
 // CreateScheduleParams wraps the data required to create a schedule.
@@ -67,10 +122,12 @@ type CreateScheduleParams struct {
 }
 
 // UpdateScheduleParams wraps the data required to update an existing schedule.
+// IfMatch, when set, is an If-Match header value the current ETag must satisfy.
 type UpdateScheduleParams struct {
 	Principal  Principal
 	ScheduleID string
 	Input      ScheduleInput
+	IfMatch    string
 }
 
 // ListPeriod identifies the range preset requested for schedule listings.
@@ -95,6 +152,115 @@ type ListSchedulesParams struct {
 	EndsBefore      *time.Time
 	Period          ListPeriod
 	PeriodReference time.Time
+	RoomID          *string
+	CreatorID       string
+	TitlePrefix     string
+	UpdatedSince    *time.Time
+	Limit           int
+	Cursor          string
+	// GroupID adds the members of a colleague group the principal can use
+	// to ParticipantIDs.
+	GroupID string
+	// CalendarID restricts results to a shared calendar the principal holds a
+	// role on. The principal is then not implicitly added to ParticipantIDs.
+	CalendarID string
+	// UserGroupID adds the current members of a user group to ParticipantIDs.
+	UserGroupID string
+	// DepartmentID adds the members of a department and its subdepartments
+	// to ParticipantIDs.
+	DepartmentID string
+	// ReportsTo adds the direct reports of a user to ParticipantIDs.
+	ReportsTo string
+}
+
+// SchedulePage is a single page of schedules returned by a paginated listing.
+// OutOfOffice lists absences of the listed participants within the requested
+// range and is only populated on the first page.
+type SchedulePage struct {
+	Schedules   []Schedule
+	Warnings    []ConflictWarning
+	OutOfOffice []OutOfOffice
+	NextCursor  string
+}
+
+// ListScheduleInstancesParams wraps the data required to list the concrete
+// instances of schedules within [Start, End). The group, calendar and
+// organisation filters behave as in ListSchedulesParams.
+type ListScheduleInstancesParams struct {
+	Principal      Principal
+	ParticipantIDs []string
+	Start          time.Time
+	End            time.Time
+	GroupID        string
+	CalendarID     string
+	UserGroupID    string
+	DepartmentID   string
+	ReportsTo      string
+}
+
+// ScheduleInstance is one concrete instance of a schedule: a one-off schedule
+// itself or a single occurrence of a recurring one. ID is the schedule ID for
+// one-off schedules and the schedule ID plus the occurrence start for
+// recurring ones, so it stays the same across listings. Schedule carries the
+// series details without its occurrences.
+type ScheduleInstance struct {
+	ID       string
+	RuleID   string
+	Start    time.Time
+	End      time.Time
+	Schedule Schedule
+}
+
+// ScheduleChange is one entry of the schedule change log.
+type ScheduleChange struct {
+	Sequence   int64
+	ScheduleID string
+	Deleted    bool
+	ChangedAt  time.Time
+}
+
+// ScheduleChangeFilter narrows change log reads to changes after
+// AfterSequence that involved any of UserIDs. A zero Limit means unbounded.
+// Logs report an AfterSequence past their end as not found.
+type ScheduleChangeFilter struct {
+	UserIDs       []string
+	AfterSequence int64
+	Limit         int
+}
+
+// ListScheduleChangesParams requests the schedules changed since SyncToken.
+// ParticipantIDs defaults to the principal; an empty SyncToken starts a full sync.
+type ListScheduleChangesParams struct {
+	Principal      Principal
+	ParticipantIDs []string
+	SyncToken      string
+	Limit          int
+}
+
+// TombstoneReason explains why a schedule left the synced set.
+type TombstoneReason string
+
+const (
+	// TombstoneDeleted marks a schedule that no longer exists.
+	TombstoneDeleted TombstoneReason = "deleted"
+	// TombstoneRemoved marks a schedule that no longer involves the synced participants.
+	TombstoneRemoved TombstoneReason = "removed"
+)
+
+// ScheduleTombstone tells a syncing client to drop a schedule.
+type ScheduleTombstone struct {
+	ScheduleID string
+	DeletedAt  time.Time
+	Reason     TombstoneReason
+}
+
+// ScheduleChanges is one page of a delta sync. Clients store SyncToken and
+// pass it on the next request; HasMore reports that another page is ready.
+type ScheduleChanges struct {
+	Schedules  []Schedule
+	Tombstones []ScheduleTombstone
+	SyncToken  string
+	HasMore    bool
 }
 
 // RoomInput captures caller provided room fields.
@@ -114,6 +280,8 @@ type Room struct {
 	Facilities *string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	// Version increases on every update and backs the room's ETag.
+	Version int
 }
 
 // CreateRoomParams wraps the data required to create a room.
@@ -123,27 +291,55 @@ type CreateRoomParams struct {
 }
 
 // UpdateRoomParams wraps the data required to update a room.
+// IfMatch, when set, is an If-Match header value the current ETag must satisfy.
 type UpdateRoomParams struct {
 	Principal Principal
 	RoomID    string
 	Input     RoomInput
+	IfMatch   string
+}
+
+// ListRoomsParams wraps the data required to page through the room catalog.
+type ListRoomsParams struct {
+	Principal Principal
+	Limit     int
+	Cursor    string
 }
 
-// UserInput captures caller provided user attributes.
+// RoomPage is a single page of rooms returned by a paginated listing.
+type RoomPage struct {
+	Rooms      []Room
+	NextCursor string
+}
+
+// UserInput captures caller provided user attributes. Empty DepartmentID and
+// ManagerID leave the user without a department or manager.
 type UserInput struct {
-	Email       string
-	DisplayName string
-	IsAdmin     bool
+	Email        string
+	DisplayName  string
+	IsAdmin      bool
+	TimeZone     string
+	DepartmentID string
+	ManagerID    string
+	Location     string
 }
 
 // User represents an employee account exposed by the application services.
+// ManagerID names the user the account reports to; Location is the office
+// the user works from.
 type User struct {
-	ID          string
-	Email       string
-	DisplayName string
-	IsAdmin     bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           string
+	Email        string
+	DisplayName  string
+	IsAdmin      bool
+	TimeZone     string
+	DepartmentID string
+	ManagerID    string
+	Location     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// Version increases on every update and backs the user's ETag.
+	Version int
 }
 
 // CreateUserParams wraps the data required to create a user.
@@ -153,10 +349,79 @@ type CreateUserParams struct {
 }
 
 // UpdateUserParams wraps the data required to update a user.
+// IfMatch, when set, is an If-Match header value the current ETag must satisfy.
 type UpdateUserParams struct {
 	Principal Principal
 	UserID    string
 	Input     UserInput
+	IfMatch   string
+}
+
+// ListUsersParams wraps the data required to page through user accounts.
+type ListUsersParams struct {
+	Principal Principal
+	Limit     int
+	Cursor    string
+}
+
+// UserPage is a single page of users returned by a paginated listing.
+type UserPage struct {
+	Users      []User
+	NextCursor string
+}
+
+// UserImportRow is one user of a CSV import, matched to an existing account
+// by Email. Empty DisplayName and TimeZone keep the values of an existing
+// user; Department (a department name), ManagerEmail and Location always
+// replace them, and empty values clear them.
+type UserImportRow struct {
+	Email        string
+	DisplayName  string
+	TimeZone     string
+	Department   string
+	ManagerEmail string
+	Location     string
+}
+
+// ImportUsersParams wraps the rows of a user import.
+type ImportUsersParams struct {
+	Principal Principal
+	Rows      []UserImportRow
+}
+
+// UserImportResult lists the users an import created and updated.
+type UserImportResult struct {
+	Created []User
+	Updated []User
+}
+
+// Department is a unit of the organization. Departments nest through
+// ParentID, which is empty for top-level departments.
+type Department struct {
+	ID        string
+	Name      string
+	ParentID  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DepartmentInput holds the editable fields of a department.
+type DepartmentInput struct {
+	Name     string
+	ParentID string
+}
+
+// CreateDepartmentParams wraps the data required to create a department.
+type CreateDepartmentParams struct {
+	Principal Principal
+	Input     DepartmentInput
+}
+
+// UpdateDepartmentParams wraps the data required to change a department.
+type UpdateDepartmentParams struct {
+	Principal    Principal
+	DepartmentID string
+	Input        DepartmentInput
 }
 
 // UserCredentials models the authentication attributes persisted for a user.
@@ -203,3 +468,553 @@ type RefreshSessionParams struct {
 type RefreshSessionResult struct {
 	Session Session
 }
+
+// WorkingHours declares a working window on one weekday. Minutes are counted
+// from local midnight in the user's time zone; EndMinute is exclusive.
+type WorkingHours struct {
+	Weekday     time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+// OutOfOfficeInput captures caller provided out-of-office fields.
+type OutOfOfficeInput struct {
+	Start       time.Time
+	End         time.Time
+	Note        string
+	AutoDecline bool
+}
+
+// OutOfOffice represents a period during which a user is unavailable. When
+// AutoDecline is set, new invitations overlapping the period are declined.
+type OutOfOffice struct {
+	ID          string
+	UserID      string
+	Start       time.Time
+	End         time.Time
+	Note        string
+	AutoDecline bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SetWorkingHoursParams wraps the data required to replace a user's working hours.
+type SetWorkingHoursParams struct {
+	Principal Principal
+	UserID    string
+	Hours     []WorkingHours
+}
+
+// CreateOutOfOfficeParams wraps the data required to record an out-of-office period.
+type CreateOutOfOfficeParams struct {
+	Principal Principal
+	UserID    string
+	Input     OutOfOfficeInput
+}
+
+// ListOutOfOfficeParams wraps the data required to list a user's out-of-office periods.
+type ListOutOfOfficeParams struct {
+	Principal   Principal
+	UserID      string
+	StartsAfter *time.Time
+	EndsBefore  *time.Time
+}
+
+// ParticipantAvailability bundles the working hours and absences consulted
+// when scheduling a participant.
+type ParticipantAvailability struct {
+	TimeZone     string
+	WorkingHours []WorkingHours
+	OutOfOffice  []OutOfOffice
+}
+
+// Holiday is a public holiday or company closure day. Date is the calendar
+// date at UTC midnight; Kind is "national", "substitute", "citizens" or "company".
+type Holiday struct {
+	Date time.Time
+	Name string
+	Kind string
+}
+
+// CompanyHolidayInput captures caller provided closure day fields. Only the
+// calendar date of Date is used.
+type CompanyHolidayInput struct {
+	Date time.Time
+	Name string
+}
+
+// CreateCompanyHolidayParams wraps the data required to add a company closure day.
+type CreateCompanyHolidayParams struct {
+	Principal Principal
+	Input     CompanyHolidayInput
+}
+
+// ListHolidaysParams wraps the data required to list the holidays of a year.
+type ListHolidaysParams struct {
+	Principal Principal
+	Year      int
+}
+
+// ReminderPreferences holds a user's reminder defaults. MinutesBefore applies
+// to schedules that define no reminders of their own; Channels lists the
+// channels the user is reminded through, and an empty list opts out entirely.
+type ReminderPreferences struct {
+	UserID        string
+	MinutesBefore []int
+	Channels      []string
+	UpdatedAt     time.Time
+}
+
+// SetReminderPreferencesParams wraps the data required to replace a user's reminder preferences.
+type SetReminderPreferencesParams struct {
+	Principal     Principal
+	UserID        string
+	MinutesBefore []int
+	Channels      []string
+}
+
+// ReminderDeliveryStatus tracks a reminder through delivery.
+type ReminderDeliveryStatus string
+
+const (
+	// ReminderDeliverySending marks a claimed delivery. Deliveries left in this
+	// state by a crash are not retried so that a reminder is never sent twice.
+	ReminderDeliverySending ReminderDeliveryStatus = "sending"
+	// ReminderDeliverySent marks a delivery handed off to its channel.
+	ReminderDeliverySent ReminderDeliveryStatus = "sent"
+	// ReminderDeliveryFailed marks a delivery that may be retried.
+	ReminderDeliveryFailed ReminderDeliveryStatus = "failed"
+)
+
+// ReminderDelivery records one reminder of one occurrence to one user through
+// one channel. OccurrenceStart, UserID, MinutesBefore and Channel identify it.
+type ReminderDelivery struct {
+	ID              string
+	ScheduleID      string
+	OccurrenceStart time.Time
+	UserID          string
+	MinutesBefore   int
+	Channel         string
+	Status          ReminderDeliveryStatus
+	Attempts        int
+	LastError       string
+	DueAt           time.Time
+	UpdatedAt       time.Time
+}
+
+// ReminderDispatchResult summarises a dispatch run.
+type ReminderDispatchResult struct {
+	Sent   int
+	Failed int
+}
+
+// WebhookSubscription delivers events to URL. An empty EventTypes list
+// subscribes to every event type. Secret signs each payload.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookInput captures the mutable fields of a webhook subscription. A nil
+// Active leaves the current state unchanged and defaults to active on create.
+type WebhookInput struct {
+	URL        string
+	EventTypes []string
+	Active     *bool
+}
+
+// CreateWebhookParams wraps the data required to create a webhook subscription.
+type CreateWebhookParams struct {
+	Principal Principal
+	Input     WebhookInput
+}
+
+// UpdateWebhookParams wraps the data required to update a webhook subscription.
+type UpdateWebhookParams struct {
+	Principal Principal
+	WebhookID string
+	Input     WebhookInput
+}
+
+// OutboxEvent is a published event awaiting fan-out to webhook subscriptions.
+// Payload holds the JSON envelope delivered to subscribers. Sequence orders
+// events by commit and is assigned by the repository.
+type OutboxEvent struct {
+	ID         string
+	Type       EventType
+	ResourceID string
+	ActorID    string
+	Payload    []byte
+	OccurredAt time.Time
+	Sequence   int64
+}
+
+// WebhookDeliveryStatus tracks a webhook delivery through its attempts.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending marks a delivery waiting for its next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded marks a delivery acknowledged with a 2xx response.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed marks a delivery that exhausted its attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery records the attempts to deliver one event to one
+// subscription. Payload is loaded from the outbox event.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      EventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookDispatchResult summarises a webhook worker run.
+type WebhookDispatchResult struct {
+	Enqueued  int
+	Succeeded int
+	Retrying  int
+	Failed    int
+}
+
+// OpenEventStreamParams selects the schedule events pushed to a live stream.
+// Events are relevant when the principal or a watched user is the creator or a
+// participant, or when the schedule uses a watched room. LastEventID resumes
+// after a previously received event; nil starts from the newest event.
+type OpenEventStreamParams struct {
+	Principal      Principal
+	WatchedUserIDs []string
+	WatchedRoomIDs []string
+	LastEventID    *int64
+}
+
+// StreamEvent is one event delivered on a live stream. Payload is the same
+// JSON envelope delivered to webhook subscribers.
+type StreamEvent struct {
+	Sequence int64
+	Type     EventType
+	Payload  []byte
+}
+
+// AppPassword is a named secret a user issues to a client that only supports
+// basic auth, such as a CalDAV calendar. Only TokenHash is stored; Secret is
+// populated once, when the password is created.
+type AppPassword struct {
+	ID         string
+	UserID     string
+	Name       string
+	Secret     string
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// CreateAppPasswordParams wraps the data required to issue an app password.
+type CreateAppPasswordParams struct {
+	Principal Principal
+	UserID    string
+	Name      string
+}
+
+// ColleagueGroup is a named, ordered set of colleagues a user selects
+// together in calendar views. SharedWith lists the other users who may use
+// the group; only its owner can change it.
+type ColleagueGroup struct {
+	ID         string
+	OwnerID    string
+	Name       string
+	MemberIDs  []string
+	SharedWith []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ColleagueGroupInput holds the editable fields of a colleague group.
+type ColleagueGroupInput struct {
+	Name       string
+	MemberIDs  []string
+	SharedWith []string
+}
+
+// CreateColleagueGroupParams wraps the data required to save a colleague group.
+type CreateColleagueGroupParams struct {
+	Principal Principal
+	Input     ColleagueGroupInput
+}
+
+// UpdateColleagueGroupParams wraps the data required to change a colleague group.
+type UpdateColleagueGroupParams struct {
+	Principal Principal
+	GroupID   string
+	Input     ColleagueGroupInput
+}
+
+// ViewLink is a short code standing for a calendar view: the period shown,
+// the date it is opened on and the participants selected, as they were when
+// the link was created.
+type ViewLink struct {
+	Code           string
+	CreatorID      string
+	View           ListPeriod
+	Date           time.Time
+	ParticipantIDs []string
+	CreatedAt      time.Time
+}
+
+// CreateViewLinkParams wraps the data required to create a view link. The
+// members of GroupID are added to ParticipantIDs.
+type CreateViewLinkParams struct {
+	Principal      Principal
+	View           ListPeriod
+	Date           time.Time
+	ParticipantIDs []string
+	GroupID        string
+}
+
+// CalendarRole is the access a user holds on a shared calendar.
+type CalendarRole string
+
+const (
+	// CalendarRoleOwner may change and delete the calendar and its schedules.
+	CalendarRoleOwner CalendarRole = "owner"
+	// CalendarRoleEditor may add, change and delete the calendar's schedules.
+	CalendarRoleEditor CalendarRole = "editor"
+	// CalendarRoleViewer may only see the calendar's schedules.
+	CalendarRoleViewer CalendarRole = "viewer"
+)
+
+// Calendar is a shared calendar such as a team or office calendar that is
+// not tied to a person. Access is granted by role: the owner, EditorIDs and
+// ViewerIDs.
+type Calendar struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	EditorIDs   []string
+	ViewerIDs   []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CalendarInput holds the editable fields of a calendar. An empty OwnerID
+// keeps the current owner.
+type CalendarInput struct {
+	Name        string
+	Description string
+	OwnerID     string
+	EditorIDs   []string
+	ViewerIDs   []string
+}
+
+// CreateCalendarParams wraps the data required to create a calendar.
+type CreateCalendarParams struct {
+	Principal Principal
+	Input     CalendarInput
+}
+
+// UpdateCalendarParams wraps the data required to change a calendar.
+type UpdateCalendarParams struct {
+	Principal  Principal
+	CalendarID string
+	Input      CalendarInput
+}
+
+// ParticipantGroupMode decides how user groups invited to a schedule are kept.
+type ParticipantGroupMode string
+
+const (
+	// ParticipantGroupExpand copies the members of each group into the
+	// participants when the schedule is saved. It is the default.
+	ParticipantGroupExpand ParticipantGroupMode = "expand"
+	// ParticipantGroupLive keeps each group as a reference, so members who
+	// join or leave later are added to or dropped from the schedule.
+	ParticipantGroupLive ParticipantGroupMode = "live"
+)
+
+// UserGroup is a department or distribution list that can be invited to
+// schedules as a unit. Administrators manage every group; OwnerID, when set,
+// may also change the group.
+type UserGroup struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	MemberIDs   []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// UserGroupInput holds the editable fields of a user group. An empty OwnerID
+// leaves the group to administrators.
+type UserGroupInput struct {
+	Name        string
+	Description string
+	OwnerID     string
+	MemberIDs   []string
+}
+
+// CreateUserGroupParams wraps the data required to create a user group.
+type CreateUserGroupParams struct {
+	Principal Principal
+	Input     UserGroupInput
+}
+
+// UpdateUserGroupParams wraps the data required to change a user group.
+type UpdateUserGroupParams struct {
+	Principal Principal
+	GroupID   string
+	Input     UserGroupInput
+}
+
+// CalDAVCalendar is a calendar collection exposed over CalDAV. Every user has
+// one writable calendar; every room has a read-only calendar of its bookings.
+// CTag changes whenever any resource in the calendar changes.
+type CalDAVCalendar struct {
+	ID          string
+	DisplayName string
+	RoomID      string
+	ReadOnly    bool
+	CTag        string
+}
+
+// CalDAVObject records the resource name and iCalendar UID a CalDAV client
+// chose for a schedule.
+type CalDAVObject struct {
+	ScheduleID   string
+	ResourceName string
+	UID          string
+}
+
+// CalDAVResource is one calendar object resource: a schedule rendered as an
+// iCalendar object under Name.
+type CalDAVResource struct {
+	Name     string
+	ETag     string
+	Schedule Schedule
+	Data     []byte
+}
+
+// CalDAVTimeRange bounds a calendar-query. Nil bounds are open.
+type CalDAVTimeRange struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// PutCalDAVResourceParams wraps an iCalendar object uploaded to a calendar.
+// IfMatch and IfNoneMatch carry the request's conditional headers verbatim.
+type PutCalDAVResourceParams struct {
+	Principal   Principal
+	CalendarID  string
+	Name        string
+	Data        []byte
+	IfMatch     string
+	IfNoneMatch string
+}
+
+// CalDAVSyncConflictPolicy decides which side wins when an object changed both
+// locally and on the remote collection since the last sync.
+type CalDAVSyncConflictPolicy string
+
+const (
+	// CalDAVSyncRemoteWins keeps the remote object.
+	CalDAVSyncRemoteWins CalDAVSyncConflictPolicy = "remote_wins"
+	// CalDAVSyncLocalWins keeps the schedule.
+	CalDAVSyncLocalWins CalDAVSyncConflictPolicy = "local_wins"
+	// CalDAVSyncLatestWins keeps whichever side was modified last, comparing the
+	// remote LAST-MODIFIED with the schedule's update time.
+	CalDAVSyncLatestWins CalDAVSyncConflictPolicy = "latest"
+)
+
+// CalDAVSyncAccount connects a user's calendar to a collection on an external
+// CalDAV server. Password is never returned by the API.
+type CalDAVSyncAccount struct {
+	ID             string
+	UserID         string
+	CollectionURL  string
+	Username       string
+	Password       string
+	ConflictPolicy CalDAVSyncConflictPolicy
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// CalDAVSyncAccountInput captures the fields of a new sync account. An empty
+// ConflictPolicy defaults to latest and a nil Active to active.
+type CalDAVSyncAccountInput struct {
+	CollectionURL  string
+	Username       string
+	Password       string
+	ConflictPolicy CalDAVSyncConflictPolicy
+	Active         *bool
+}
+
+// CreateCalDAVSyncAccountParams wraps the data required to create a sync account.
+type CreateCalDAVSyncAccountParams struct {
+	Principal Principal
+	UserID    string
+	Input     CalDAVSyncAccountInput
+}
+
+// CalDAVSyncItem maps a remote object to the schedule it was last synced
+// with, recording both sides' ETags at that point. An empty ScheduleID marks
+// a remote object that could not be imported.
+type CalDAVSyncItem struct {
+	AccountID  string
+	RemoteHref string
+	RemoteUID  string
+	RemoteETag string
+	ScheduleID string
+	LocalETag  string
+}
+
+// CalDAVSyncRunStatus summarises the outcome of a sync run.
+type CalDAVSyncRunStatus string
+
+const (
+	// CalDAVSyncSucceeded marks a run that applied every change.
+	CalDAVSyncSucceeded CalDAVSyncRunStatus = "succeeded"
+	// CalDAVSyncPartial marks a run where some objects failed and will be retried.
+	CalDAVSyncPartial CalDAVSyncRunStatus = "partial"
+	// CalDAVSyncFailed marks a run that could not read either side.
+	CalDAVSyncFailed CalDAVSyncRunStatus = "failed"
+)
+
+// CalDAVSyncCounts counts the objects a sync run changed on one side.
+type CalDAVSyncCounts struct {
+	Created int
+	Updated int
+	Deleted int
+}
+
+// CalDAVSyncRun records one sync of an account. Pulled counts changes applied
+// to schedules, Pushed changes applied to the remote collection. Skipped
+// counts remote objects that cannot be represented as schedules.
+type CalDAVSyncRun struct {
+	ID         string
+	AccountID  string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Status     CalDAVSyncRunStatus
+	Pulled     CalDAVSyncCounts
+	Pushed     CalDAVSyncCounts
+	Conflicts  int
+	Skipped    int
+	Failures   int
+	Error      string
+}
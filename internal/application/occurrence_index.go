@@ -0,0 +1,311 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/recurrence"
+)
+
+// DefaultOccurrenceHorizon is how far ahead occurrences are materialized when
+// no horizon is configured.
+const DefaultOccurrenceHorizon = 365 * 24 * time.Hour
+
+// occurrenceRefreshBatch bounds the schedules regenerated per index query.
+const occurrenceRefreshBatch = 100
+
+// OccurrenceRefresh names a schedule whose materialized occurrences must be
+// regenerated, with the generation it had when it was listed.
+type OccurrenceRefresh struct {
+	ScheduleID string
+	Generation int64
+}
+
+// OccurrenceFilter selects the materialized occurrences of ScheduleIDs that
+// overlap [Start, End). A nil Start or End leaves that side open.
+type OccurrenceFilter struct {
+	ScheduleIDs []string
+	Start       *time.Time
+	End         *time.Time
+}
+
+// OccurrenceIndex stores the occurrences of recurring schedules materialized
+// up to a rolling horizon. MarkOccurrencesStale queues a schedule after its
+// rules or times change; ReplaceOccurrences fails with
+// persistence.ErrVersionConflict when the schedule was queued again since the
+// refresh was listed. ListOccurrences only returns schedules that are fresh
+// and materialized to at least End, so callers generate the rest.
+type OccurrenceIndex interface {
+	MarkOccurrencesStale(ctx context.Context, scheduleID string) error
+	MarkHolidayOccurrencesStale(ctx context.Context) error
+	ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]OccurrenceRefresh, error)
+	ReplaceOccurrences(ctx context.Context, refresh OccurrenceRefresh, until time.Time, occurrences []ScheduleOccurrence) error
+	ListOccurrences(ctx context.Context, filter OccurrenceFilter) (map[string][]ScheduleOccurrence, error)
+	ResetOccurrences(ctx context.Context) (int, error)
+}
+
+// WithOccurrenceIndex makes listings read recurring schedules from index and
+// queues schedules for regeneration when they change, returning the service
+// for chaining. Listings without an end bound are answered up to horizon.
+func (s *ScheduleService) WithOccurrenceIndex(index OccurrenceIndex, horizon time.Duration) *ScheduleService {
+	if s != nil {
+		if horizon <= 0 {
+			horizon = DefaultOccurrenceHorizon
+		}
+		s.occurrences = index
+		s.occurrenceHorizon = horizon
+	}
+	return s
+}
+
+// markOccurrencesStale queues scheduleID for regeneration. It runs inside the
+// write's transaction so the index never misses a committed change.
+func (s *ScheduleService) markOccurrencesStale(ctx context.Context, scheduleID string) error {
+	if s.occurrences == nil {
+		return nil
+	}
+	if err := s.occurrences.MarkOccurrencesStale(ctx, scheduleID); err != nil {
+		return fmt.Errorf("failed to queue occurrence refresh: %w", err)
+	}
+	return nil
+}
+
+// indexedOccurrences reads the materialized occurrences of the recurring
+// schedules in rulesBySchedule. The window is widened by a day on each side
+// because all-day occurrences are stored as floating dates; callers narrow the
+// result with occurrencesInRange.
+func (s *ScheduleService) indexedOccurrences(ctx context.Context, rulesBySchedule map[string][]RecurrenceRule, from, to *time.Time) (map[string][]ScheduleOccurrence, error) {
+	if s.occurrences == nil || len(rulesBySchedule) == 0 {
+		return nil, nil
+	}
+	filter := OccurrenceFilter{ScheduleIDs: make([]string, 0, len(rulesBySchedule))}
+	for id := range rulesBySchedule {
+		filter.ScheduleIDs = append(filter.ScheduleIDs, id)
+	}
+	filter.ScheduleIDs = sortStrings(filter.ScheduleIDs)
+	if from != nil {
+		start := from.Add(-conflictWindowPadding)
+		filter.Start = &start
+	}
+	if to != nil {
+		end := to.Add(conflictWindowPadding)
+		filter.End = &end
+	}
+	indexed, err := s.occurrences.ListOccurrences(ctx, filter)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return indexed, nil
+}
+
+// occurrencesInRange keeps the occurrences the recurrence engine would have
+// generated for the range: timed ones starting within [from, to], and all-day
+// ones overlapping the range read on the caller's wall clock.
+func occurrencesInRange(occurrences []ScheduleOccurrence, allDay bool, from, to *time.Time, callerLoc *time.Location) []ScheduleOccurrence {
+	var kept []ScheduleOccurrence
+	if allDay {
+		from, to = floatingBound(from, callerLoc), floatingBound(to, callerLoc)
+		if from != nil {
+			day := floatingDate(*from)
+			from = &day
+		}
+	}
+	for _, occ := range occurrences {
+		if allDay {
+			if (from != nil && !occ.End.After(*from)) || (to != nil && !occ.Start.Before(*to)) {
+				continue
+			}
+		} else if (from != nil && occ.Start.Before(*from)) || (to != nil && occ.Start.After(*to)) {
+			continue
+		}
+		kept = append(kept, occ)
+	}
+	return kept
+}
+
+// OccurrenceIndexService keeps the occurrence index materialized up to a
+// rolling horizon, regenerating schedules queued after their rules changed.
+type OccurrenceIndexService struct {
+	index       OccurrenceIndex
+	schedules   ScheduleRepository
+	recurrences RecurrenceRepository
+	holidays    HolidayDirectory
+	horizon     time.Duration
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewOccurrenceIndexService constructs an occurrence index service keeping
+// occurrences materialized for horizon.
+func NewOccurrenceIndexService(index OccurrenceIndex, schedules ScheduleRepository, recurrences RecurrenceRepository, horizon time.Duration, now func() time.Time) *OccurrenceIndexService {
+	return NewOccurrenceIndexServiceWithLogger(index, schedules, recurrences, horizon, now, nil)
+}
+
+// NewOccurrenceIndexServiceWithLogger constructs an occurrence index service with a specified logger.
+func NewOccurrenceIndexServiceWithLogger(index OccurrenceIndex, schedules ScheduleRepository, recurrences RecurrenceRepository, horizon time.Duration, now func() time.Time, logger *slog.Logger) *OccurrenceIndexService {
+	if horizon <= 0 {
+		horizon = DefaultOccurrenceHorizon
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &OccurrenceIndexService{
+		index:       index,
+		schedules:   schedules,
+		recurrences: recurrences,
+		horizon:     horizon,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+// WithHolidays applies the holiday policies of recurrence rules when
+// materializing occurrences, returning the service for chaining.
+func (s *OccurrenceIndexService) WithHolidays(directory HolidayDirectory) *OccurrenceIndexService {
+	if s != nil {
+		s.holidays = directory
+	}
+	return s
+}
+
+func (s *OccurrenceIndexService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "OccurrenceIndexService", operation, attrs...)
+}
+
+// horizonEnd is the instant occurrences are materialized to. It advances a day
+// at a time so each schedule is extended once a day rather than on every pass.
+func (s *OccurrenceIndexService) horizonEnd() time.Time {
+	return s.now().UTC().Add(s.horizon).Truncate(24 * time.Hour)
+}
+
+// Refresh regenerates the occurrences of queued schedules and extends the
+// others to the current horizon, returning how many schedules were refreshed.
+// Schedules that fail stay queued for the next pass.
+func (s *OccurrenceIndexService) Refresh(ctx context.Context) (refreshed int, err error) {
+	if s == nil {
+		err = fmt.Errorf("OccurrenceIndexService is nil")
+		return
+	}
+	if s.index == nil || s.schedules == nil || s.recurrences == nil {
+		err = fmt.Errorf("occurrence index not configured")
+		return
+	}
+
+	horizon := s.horizonEnd()
+	logger := s.loggerWith(ctx, "Refresh", "horizon", horizon)
+	failed := 0
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to refresh occurrence index", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		if refreshed > 0 || failed > 0 {
+			logger.With("refreshed_count", refreshed, "failed_count", failed).InfoContext(ctx, "occurrence index refreshed")
+		}
+	}()
+
+	var holidays recurrence.HolidayCalendar
+	if s.holidays != nil {
+		if holidays, err = s.holidays.HolidayCalendar(ctx); err != nil {
+			return
+		}
+	}
+
+	for {
+		var batch []OccurrenceRefresh
+		if batch, err = s.index.ListOccurrenceRefreshes(ctx, horizon, occurrenceRefreshBatch); err != nil {
+			return
+		}
+		progressed := 0
+		for _, refresh := range batch {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				return
+			}
+			if rErr := s.refreshSchedule(ctx, refresh, horizon, holidays); rErr != nil {
+				if errors.Is(rErr, persistence.ErrVersionConflict) {
+					// Queued again meanwhile; the next batch lists the new generation.
+					progressed++
+					continue
+				}
+				failed++
+				logger.WarnContext(ctx, "failed to refresh schedule occurrences", "schedule_id", refresh.ScheduleID, "error", rErr)
+				continue
+			}
+			refreshed++
+			progressed++
+		}
+		// A short batch is the last one; a batch of failures would only repeat.
+		if len(batch) < occurrenceRefreshBatch || progressed == 0 {
+			return
+		}
+	}
+}
+
+func (s *OccurrenceIndexService) refreshSchedule(ctx context.Context, refresh OccurrenceRefresh, horizon time.Time, holidays recurrence.HolidayCalendar) error {
+	schedule, err := s.schedules.GetSchedule(ctx, refresh.ScheduleID)
+	if err != nil {
+		if isNotFoundError(err) {
+			// Trashed schedules keep no occurrences until they are restored.
+			return s.index.ReplaceOccurrences(ctx, refresh, horizon, nil)
+		}
+		return err
+	}
+	rulesBySchedule, err := s.recurrences.ListRecurrencesForSchedules(ctx, []string{schedule.ID})
+	if err != nil {
+		return err
+	}
+	occurrences, err := generateOccurrences(schedule, rulesBySchedule[schedule.ID], nil, &horizon, time.UTC, holidays)
+	if err != nil {
+		return err
+	}
+	return s.index.ReplaceOccurrences(ctx, refresh, horizon, occurrences)
+}
+
+// Rebuild discards the whole occurrence index and regenerates it from the
+// stored recurrence rules, returning how many schedules were materialized. It
+// repairs an index that has drifted from the rules.
+func (s *OccurrenceIndexService) Rebuild(ctx context.Context) (rebuilt int, err error) {
+	if s == nil {
+		err = fmt.Errorf("OccurrenceIndexService is nil")
+		return
+	}
+	if s.index == nil {
+		err = fmt.Errorf("occurrence index not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "Rebuild")
+	var queued int
+	if queued, err = s.index.ResetOccurrences(ctx); err != nil {
+		logger.ErrorContext(ctx, "failed to reset occurrence index", "error", err, "error_kind", ErrorKind(err))
+		return
+	}
+	logger.With("queued_count", queued).InfoContext(ctx, "occurrence index reset")
+	return s.Refresh(ctx)
+}
+
+// Run refreshes the occurrence index every interval until ctx is cancelled.
+func (s *OccurrenceIndexService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Failures are logged and retried on the next tick.
+		_, _ = s.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+type occurrenceIndexStub struct {
+	generations  map[string]int64
+	stale        map[string]bool
+	until        map[string]time.Time
+	occurrences  map[string][]ScheduleOccurrence
+	marked       []string
+	holidayMarks int
+	resets       int
+}
+
+func newOccurrenceIndexStub() *occurrenceIndexStub {
+	return &occurrenceIndexStub{
+		generations: map[string]int64{},
+		stale:       map[string]bool{},
+		until:       map[string]time.Time{},
+		occurrences: map[string][]ScheduleOccurrence{},
+	}
+}
+
+func (o *occurrenceIndexStub) MarkOccurrencesStale(ctx context.Context, scheduleID string) error {
+	o.marked = append(o.marked, scheduleID)
+	o.generations[scheduleID]++
+	o.stale[scheduleID] = true
+	return nil
+}
+
+func (o *occurrenceIndexStub) MarkHolidayOccurrencesStale(ctx context.Context) error {
+	o.holidayMarks++
+	return nil
+}
+
+func (o *occurrenceIndexStub) ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]OccurrenceRefresh, error) {
+	var refreshes []OccurrenceRefresh
+	for id, generation := range o.generations {
+		if o.stale[id] || o.until[id].Before(horizon) {
+			refreshes = append(refreshes, OccurrenceRefresh{ScheduleID: id, Generation: generation})
+		}
+	}
+	return refreshes, nil
+}
+
+func (o *occurrenceIndexStub) ReplaceOccurrences(ctx context.Context, refresh OccurrenceRefresh, until time.Time, occurrences []ScheduleOccurrence) error {
+	if o.generations[refresh.ScheduleID] != refresh.Generation {
+		return persistence.ErrVersionConflict
+	}
+	o.stale[refresh.ScheduleID] = false
+	o.until[refresh.ScheduleID] = until
+	o.occurrences[refresh.ScheduleID] = occurrences
+	return nil
+}
+
+func (o *occurrenceIndexStub) ListOccurrences(ctx context.Context, filter OccurrenceFilter) (map[string][]ScheduleOccurrence, error) {
+	result := map[string][]ScheduleOccurrence{}
+	for _, id := range filter.ScheduleIDs {
+		until, ok := o.until[id]
+		if !ok || o.stale[id] || (filter.End != nil && until.Before(*filter.End)) {
+			continue
+		}
+		kept := []ScheduleOccurrence{}
+		for _, occ := range o.occurrences[id] {
+			if (filter.Start == nil || occ.End.After(*filter.Start)) && (filter.End == nil || occ.Start.Before(*filter.End)) {
+				kept = append(kept, occ)
+			}
+		}
+		result[id] = kept
+	}
+	return result, nil
+}
+
+func (o *occurrenceIndexStub) ResetOccurrences(ctx context.Context) (int, error) {
+	o.resets++
+	for id := range o.generations {
+		o.generations[id]++
+		o.stale[id] = true
+		delete(o.until, id)
+		delete(o.occurrences, id)
+	}
+	return len(o.generations), nil
+}
+
+func TestOccurrenceIndexService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	seriesStart := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC) // Monday
+	repo := &filteringScheduleRepo{schedules: []Schedule{{
+		ID:        "standup",
+		CreatorID: "alice",
+		Start:     seriesStart,
+		End:       seriesStart.Add(30 * time.Minute),
+		TimeZone:  "UTC",
+	}}}
+	recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+		"standup": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: seriesStart}},
+	}}
+	index := newOccurrenceIndexStub()
+	index.generations["standup"] = 1
+	index.stale["standup"] = true
+	index.generations["trashed"] = 1
+	index.stale["trashed"] = true
+
+	service := NewOccurrenceIndexService(index, repo, recurrences, 30*24*time.Hour, func() time.Time { return now })
+
+	refreshed, err := service.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if refreshed != 2 {
+		t.Fatalf("expected two schedules refreshed, got %d", refreshed)
+	}
+	horizon := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	if !index.until["standup"].Equal(horizon) {
+		t.Fatalf("expected occurrences materialized to %s, got %s", horizon, index.until["standup"])
+	}
+	if got := len(index.occurrences["standup"]); got != 5 {
+		t.Fatalf("expected five Monday occurrences up to the horizon, got %d", got)
+	}
+	if len(index.occurrences["trashed"]) != 0 || index.stale["trashed"] {
+		t.Fatalf("expected a missing schedule to be indexed without occurrences")
+	}
+
+	if refreshed, err = service.Refresh(ctx); err != nil || refreshed != 0 {
+		t.Fatalf("expected nothing left to refresh, got %d (err %v)", refreshed, err)
+	}
+
+	t.Run("rebuild regenerates every schedule", func(t *testing.T) {
+		rebuilt, err := service.Rebuild(ctx)
+		if err != nil {
+			t.Fatalf("Rebuild returned error: %v", err)
+		}
+		if index.resets != 1 || rebuilt != 2 || len(index.occurrences["standup"]) != 5 {
+			t.Fatalf("expected the index to be reset and rebuilt, got resets %d, rebuilt %d", index.resets, rebuilt)
+		}
+	})
+}
+
+func TestScheduleService_ListSchedulesWithOccurrenceIndex(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	seriesStart := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC) // Monday
+	standup := Schedule{
+		ID:        "standup",
+		CreatorID: "alice",
+		Start:     seriesStart,
+		End:       seriesStart.Add(30 * time.Minute),
+		TimeZone:  "UTC",
+	}
+	rules := map[string][]RecurrenceRule{
+		"standup": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: seriesStart}},
+	}
+	principal := Principal{UserID: "alice"}
+
+	t.Run("unbounded listings fail without an index", func(t *testing.T) {
+		svc := NewScheduleService(&filteringScheduleRepo{schedules: []Schedule{standup}}, nil, nil, &recurrenceRepoStub{rules: rules}, nil, func() time.Time { return now })
+		if _, _, err := svc.ListSchedules(ctx, ListSchedulesParams{Principal: principal}); err == nil {
+			t.Fatalf("expected an unbounded expansion error")
+		}
+	})
+
+	t.Run("reads fresh schedules from the index", func(t *testing.T) {
+		index := newOccurrenceIndexStub()
+		index.generations["standup"] = 1
+		index.until["standup"] = now.AddDate(0, 1, 0)
+		indexed := ScheduleOccurrence{ScheduleID: "standup", RuleID: "rule-1", Start: seriesStart.AddDate(0, 0, 7), End: seriesStart.AddDate(0, 0, 7).Add(30 * time.Minute)}
+		index.occurrences["standup"] = []ScheduleOccurrence{indexed}
+		svc := NewScheduleService(&filteringScheduleRepo{schedules: []Schedule{standup}}, nil, nil, &recurrenceRepoStub{rules: rules}, nil, func() time.Time { return now }).
+			WithOccurrenceIndex(index, 30*24*time.Hour)
+
+		schedules, _, err := svc.ListSchedules(ctx, ListSchedulesParams{Principal: principal})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(schedules) != 1 || len(schedules[0].Occurrences) != 1 || !schedules[0].Occurrences[0].Start.Equal(indexed.Start) {
+			t.Fatalf("expected the indexed occurrence, got %+v", schedules)
+		}
+
+		from := seriesStart.Add(-time.Hour)
+		to := seriesStart.AddDate(0, 0, 2)
+		schedules, _, err = svc.ListSchedules(ctx, ListSchedulesParams{Principal: principal, StartsAfter: &from, EndsBefore: &to})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(schedules) != 1 || len(schedules[0].Occurrences) != 0 {
+			t.Fatalf("expected indexed occurrences outside the range to be dropped, got %+v", schedules)
+		}
+	})
+
+	t.Run("generates schedules missing from the index up to the horizon", func(t *testing.T) {
+		index := newOccurrenceIndexStub()
+		svc := NewScheduleService(&filteringScheduleRepo{schedules: []Schedule{standup}}, nil, nil, &recurrenceRepoStub{rules: rules}, nil, func() time.Time { return now }).
+			WithOccurrenceIndex(index, 30*24*time.Hour)
+
+		schedules, _, err := svc.ListSchedules(ctx, ListSchedulesParams{Principal: principal})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(schedules) != 1 || len(schedules[0].Occurrences) != 5 {
+			t.Fatalf("expected five generated occurrences, got %+v", schedules)
+		}
+	})
+
+	t.Run("queues recurring schedules when they change", func(t *testing.T) {
+		index := newOccurrenceIndexStub()
+		svc := NewScheduleService(&filteringScheduleRepo{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, &recurrenceRepoStub{}, func() string { return "weekly" }, func() time.Time { return now }).
+			WithOccurrenceIndex(index, 0)
+
+		_, _, err := svc.CreateSchedule(ctx, CreateScheduleParams{Principal: principal, Input: ScheduleInput{
+			Title:          "Weekly",
+			Start:          seriesStart,
+			End:            seriesStart.Add(time.Hour),
+			ParticipantIDs: []string{"bob"},
+			Recurrence:     &RecurrenceInput{Frequency: "weekly", Weekdays: []string{"monday"}},
+		}})
+		if err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+		if len(index.marked) != 1 || index.marked[0] != "weekly" {
+			t.Fatalf("expected the new series to be queued, got %v", index.marked)
+		}
+	})
+}
+
+func TestHolidayService_QueuesHolidayOccurrences(t *testing.T) {
+	index := newOccurrenceIndexStub()
+	svc := NewHolidayService(&holidayRepoStub{}, nil).WithOccurrenceIndex(index)
+
+	_, err := svc.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{
+		Principal: Principal{UserID: "admin", IsAdmin: true},
+		Input:     CompanyHolidayInput{Date: time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC), Name: "Summer closure"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCompanyHoliday returned error: %v", err)
+	}
+	if index.holidayMarks != 1 {
+		t.Fatalf("expected holiday occurrences to be queued once, got %d", index.holidayMarks)
+	}
+
+	if _, err := svc.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{Principal: Principal{UserID: "bob"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if index.holidayMarks != 1 {
+		t.Fatalf("expected a rejected change not to queue occurrences, got %d", index.holidayMarks)
+	}
+}
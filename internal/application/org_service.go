@@ -0,0 +1,448 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+const (
+	// maxDepartmentNameLength bounds the name of a department.
+	maxDepartmentNameLength = 100
+	// busyScheduleTitle replaces the title of schedules a manager may only
+	// see as busy time.
+	busyScheduleTitle = "予定あり"
+)
+
+// DepartmentRepository persists departments and answers reporting-line queries.
+type DepartmentRepository interface {
+	CreateDepartment(ctx context.Context, department Department) error
+	GetDepartment(ctx context.Context, id string) (Department, error)
+	UpdateDepartment(ctx context.Context, department Department) error
+	DeleteDepartment(ctx context.Context, id string) error
+	// ListDepartments returns every department ordered by name.
+	ListDepartments(ctx context.Context) ([]Department, error)
+	// ListDepartmentMembers returns the users in any of the departments.
+	ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error)
+	// ListDirectReports returns the users whose manager is managerID.
+	ListDirectReports(ctx context.Context, managerID string) ([]string, error)
+}
+
+// OrgDirectory resolves the organization structure for schedule operations.
+// Unknown departments report ErrNotFound.
+type OrgDirectory interface {
+	DepartmentMembers(ctx context.Context, departmentID string) ([]string, error)
+	DirectReports(ctx context.Context, managerID string) ([]string, error)
+	ReportsTo(ctx context.Context, userID, managerID string) (bool, error)
+}
+
+// OrgService manages departments and answers questions about reporting lines.
+// Every user may see the departments; only administrators change them.
+type OrgService struct {
+	departments DepartmentRepository
+	users       UserRepository
+	idGenerator func() string
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewOrgService constructs an organization service with the provided dependencies.
+func NewOrgService(departments DepartmentRepository, users UserRepository, idGenerator func() string, now func() time.Time) *OrgService {
+	return NewOrgServiceWithLogger(departments, users, idGenerator, now, nil)
+}
+
+// NewOrgServiceWithLogger constructs an organization service with a specified logger.
+func NewOrgServiceWithLogger(departments DepartmentRepository, users UserRepository, idGenerator func() string, now func() time.Time, logger *slog.Logger) *OrgService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &OrgService{
+		departments: departments,
+		users:       users,
+		idGenerator: idGenerator,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *OrgService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "OrgService", operation, attrs...)
+}
+
+// ListDepartments lists every department by name.
+func (s *OrgService) ListDepartments(ctx context.Context, principal Principal) ([]Department, error) {
+	if s == nil {
+		return nil, fmt.Errorf("OrgService is nil")
+	}
+	if s.departments == nil {
+		return nil, fmt.Errorf("department repository not configured")
+	}
+
+	departments, err := s.departments.ListDepartments(ctx)
+	if err != nil {
+		err = mapDepartmentRepoError(err)
+		s.loggerWith(ctx, "ListDepartments", "principal_id", principal.UserID).ErrorContext(ctx, "failed to list departments", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return departments, nil
+}
+
+// GetDepartment returns a single department.
+func (s *OrgService) GetDepartment(ctx context.Context, principal Principal, departmentID string) (Department, error) {
+	if s == nil {
+		return Department{}, fmt.Errorf("OrgService is nil")
+	}
+	if s.departments == nil {
+		return Department{}, fmt.Errorf("department repository not configured")
+	}
+
+	department, err := s.departments.GetDepartment(ctx, departmentID)
+	if err != nil {
+		return Department{}, mapDepartmentRepoError(err)
+	}
+	return department, nil
+}
+
+// CreateDepartment saves a new department for administrators.
+func (s *OrgService) CreateDepartment(ctx context.Context, params CreateDepartmentParams) (department Department, err error) {
+	if s == nil {
+		err = fmt.Errorf("OrgService is nil")
+		return
+	}
+	if s.departments == nil {
+		err = fmt.Errorf("department repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateDepartment", "principal_id", params.Principal.UserID)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create department", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("department_id", department.ID).InfoContext(ctx, "department created")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	var input DepartmentInput
+	if input, err = s.normalizeInput(ctx, "", params.Input); err != nil {
+		return
+	}
+
+	now := s.now()
+	department = Department{
+		ID:        s.idGenerator(),
+		Name:      input.Name,
+		ParentID:  input.ParentID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err = s.departments.CreateDepartment(ctx, department); err != nil {
+		err = mapDepartmentRepoError(err)
+	}
+	return
+}
+
+// UpdateDepartment renames a department or moves it under another parent for
+// administrators.
+func (s *OrgService) UpdateDepartment(ctx context.Context, params UpdateDepartmentParams) (department Department, err error) {
+	if s == nil {
+		err = fmt.Errorf("OrgService is nil")
+		return
+	}
+	if s.departments == nil {
+		err = fmt.Errorf("department repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "UpdateDepartment",
+		"principal_id", params.Principal.UserID,
+		"department_id", params.DepartmentID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to update department", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.InfoContext(ctx, "department updated")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if department, err = s.GetDepartment(ctx, params.Principal, params.DepartmentID); err != nil {
+		return
+	}
+	var input DepartmentInput
+	if input, err = s.normalizeInput(ctx, department.ID, params.Input); err != nil {
+		return
+	}
+
+	department.Name, department.ParentID = input.Name, input.ParentID
+	department.UpdatedAt = s.now()
+	if err = s.departments.UpdateDepartment(ctx, department); err != nil {
+		err = mapDepartmentRepoError(err)
+	}
+	return
+}
+
+// DeleteDepartment removes a department for administrators. Its users and
+// subdepartments are left without one.
+func (s *OrgService) DeleteDepartment(ctx context.Context, principal Principal, departmentID string) error {
+	if s == nil {
+		return fmt.Errorf("OrgService is nil")
+	}
+	if s.departments == nil {
+		return fmt.Errorf("department repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteDepartment",
+		"principal_id", principal.UserID,
+		"department_id", departmentID,
+	)
+	if !principal.IsAdmin {
+		logger.ErrorContext(ctx, "failed to delete department", "error", ErrUnauthorized, "error_kind", ErrorKind(ErrUnauthorized))
+		return ErrUnauthorized
+	}
+	if err := s.departments.DeleteDepartment(ctx, departmentID); err != nil {
+		err = mapDepartmentRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete department", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "department deleted")
+	return nil
+}
+
+// DepartmentMembers returns the users in a department and its subdepartments.
+func (s *OrgService) DepartmentMembers(ctx context.Context, departmentID string) ([]string, error) {
+	if s == nil || s.departments == nil {
+		return nil, ErrNotFound
+	}
+	departments, err := s.departments.ListDepartments(ctx)
+	if err != nil {
+		return nil, mapDepartmentRepoError(err)
+	}
+	children := make(map[string][]string, len(departments))
+	found := false
+	for _, department := range departments {
+		children[department.ParentID] = append(children[department.ParentID], department.ID)
+		found = found || department.ID == departmentID
+	}
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	ids := []string{departmentID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	members, err := s.departments.ListDepartmentMembers(ctx, ids)
+	if err != nil {
+		return nil, mapDepartmentRepoError(err)
+	}
+	return members, nil
+}
+
+// DirectReports returns the users whose manager is managerID.
+func (s *OrgService) DirectReports(ctx context.Context, managerID string) ([]string, error) {
+	if s == nil || s.departments == nil {
+		return nil, nil
+	}
+	reports, err := s.departments.ListDirectReports(ctx, managerID)
+	if err != nil {
+		return nil, mapDepartmentRepoError(err)
+	}
+	return reports, nil
+}
+
+// ReportsTo reports whether userID reports to managerID, directly or through
+// other managers.
+func (s *OrgService) ReportsTo(ctx context.Context, userID, managerID string) (bool, error) {
+	if s == nil || s.users == nil || userID == "" || managerID == "" {
+		return false, nil
+	}
+	return reportsTo(ctx, s.users, userID, managerID)
+}
+
+// normalizeInput trims the name and checks the parent exists and is not the
+// department itself or one nested under it.
+func (s *OrgService) normalizeInput(ctx context.Context, departmentID string, input DepartmentInput) (DepartmentInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.ParentID = strings.TrimSpace(input.ParentID)
+
+	vErr := &ValidationError{}
+	switch {
+	case input.Name == "":
+		vErr.add("name", "department name is required")
+	case len([]rune(input.Name)) > maxDepartmentNameLength:
+		vErr.add("name", fmt.Sprintf("department name must be at most %d characters", maxDepartmentNameLength))
+	}
+
+	if input.ParentID != "" {
+		departments, err := s.departments.ListDepartments(ctx)
+		if err != nil {
+			return input, mapDepartmentRepoError(err)
+		}
+		parents := make(map[string]string, len(departments))
+		for _, department := range departments {
+			parents[department.ID] = department.ParentID
+		}
+		if _, ok := parents[input.ParentID]; !ok {
+			vErr.add("parent_id", "parent department does not exist")
+		} else {
+			// Walk up from the new parent; finding the department itself
+			// would nest it under its own subtree.
+			for id, steps := input.ParentID, 0; id != "" && steps <= len(parents); id, steps = parents[id], steps+1 {
+				if id == departmentID {
+					vErr.add("parent_id", "a department cannot be nested under itself")
+					break
+				}
+			}
+		}
+	}
+	if vErr.HasErrors() {
+		return input, vErr
+	}
+	return input, nil
+}
+
+// reportsTo walks the manager chain of userID looking for managerID. A chain
+// that loops back on itself ends the walk.
+func reportsTo(ctx context.Context, users UserRepository, userID, managerID string) (bool, error) {
+	seen := map[string]bool{userID: true}
+	for current := userID; ; {
+		user, err := users.GetUser(ctx, current)
+		if isNotFoundError(mapUserRepoError(err)) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case user.ManagerID == "" || seen[user.ManagerID]:
+			return false, nil
+		case user.ManagerID == managerID:
+			return true, nil
+		}
+		seen[user.ManagerID] = true
+		current = user.ManagerID
+	}
+}
+
+// WithOrg lets schedule listings filter by department and reporting line and
+// lets managers see when their reports are busy, returning the service for
+// chaining.
+func (s *ScheduleService) WithOrg(directory OrgDirectory) *ScheduleService {
+	if s != nil {
+		s.org = directory
+	}
+	return s
+}
+
+// resolveOrgFilter adds the members of params.DepartmentID and the direct
+// reports of params.ReportsTo to the participant filter.
+func (s *ScheduleService) resolveOrgFilter(ctx context.Context, params ListSchedulesParams) (ListSchedulesParams, error) {
+	if params.DepartmentID == "" && params.ReportsTo == "" {
+		return params, nil
+	}
+	var members []string
+	if params.DepartmentID != "" {
+		var err error
+		if s.org != nil {
+			members, err = s.org.DepartmentMembers(ctx, params.DepartmentID)
+		}
+		if s.org == nil || isNotFoundError(err) {
+			vErr := &ValidationError{}
+			vErr.add("department", "department does not exist")
+			return params, vErr
+		}
+		if err != nil {
+			return params, err
+		}
+	}
+	if params.ReportsTo != "" && s.org != nil {
+		reports, err := s.org.DirectReports(ctx, params.ReportsTo)
+		if err != nil {
+			return params, err
+		}
+		members = append(members, reports...)
+	}
+	params.ParticipantIDs = uniqueStrings(append(append([]string(nil), params.ParticipantIDs...), members...))
+	return params, nil
+}
+
+// busyOnlyView returns the schedule as a manager of anyone taking part in it
+// may see it: its time and the principal's reports among the participants,
+// with every other detail withheld. ok is false when the principal manages
+// none of them.
+func (s *ScheduleService) busyOnlyView(ctx context.Context, principal Principal, schedule Schedule) (view Schedule, ok bool, err error) {
+	if s.org == nil || principal.UserID == "" {
+		return Schedule{}, false, nil
+	}
+	expanded, err := withParticipantGroupMembers(ctx, s.userGroups, []Schedule{schedule})
+	if err != nil {
+		return Schedule{}, false, err
+	}
+
+	var reports []string
+	for _, userID := range uniqueStrings(append([]string{schedule.CreatorID}, expanded[0].ParticipantIDs...)) {
+		if userID == principal.UserID {
+			continue
+		}
+		managed, err := s.org.ReportsTo(ctx, userID, principal.UserID)
+		if err != nil {
+			return Schedule{}, false, err
+		}
+		if managed {
+			reports = append(reports, userID)
+		}
+	}
+	if len(reports) == 0 {
+		return Schedule{}, false, nil
+	}
+
+	view = Schedule{
+		ID:             schedule.ID,
+		Title:          busyScheduleTitle,
+		Start:          schedule.Start,
+		End:            schedule.End,
+		TimeZone:       schedule.TimeZone,
+		AllDay:         schedule.AllDay,
+		Busy:           schedule.Busy,
+		ParticipantIDs: sortStrings(reports),
+		CreatedAt:      schedule.CreatedAt,
+		UpdatedAt:      schedule.UpdatedAt,
+		Version:        schedule.Version,
+		Occurrences:    schedule.Occurrences,
+		BusyOnly:       true,
+	}
+	if containsString(reports, schedule.CreatorID) {
+		view.CreatorID = schedule.CreatorID
+	}
+	return view, true, nil
+}
+
+func mapDepartmentRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrDuplicate):
+		return ErrAlreadyExists
+	}
+	return err
+}
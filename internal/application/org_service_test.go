@@ -0,0 +1,265 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+// orgUserRepoStub keeps users by ID so reporting lines can be followed.
+type orgUserRepoStub struct {
+	users map[string]User
+}
+
+func (u *orgUserRepoStub) CreateUser(ctx context.Context, user User) (User, error) {
+	u.users[user.ID] = user
+	return user, nil
+}
+
+func (u *orgUserRepoStub) GetUser(ctx context.Context, id string) (User, error) {
+	user, ok := u.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+func (u *orgUserRepoStub) UpdateUser(ctx context.Context, user User) (User, error) {
+	u.users[user.ID] = user
+	return user, nil
+}
+
+func (u *orgUserRepoStub) DeleteUser(ctx context.Context, id string) error {
+	delete(u.users, id)
+	return nil
+}
+
+func (u *orgUserRepoStub) ListUsers(ctx context.Context) ([]User, error) {
+	var users []User
+	for _, user := range u.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (u *orgUserRepoStub) ListUsersPage(ctx context.Context, filter UserRepositoryFilter) ([]User, error) {
+	return u.ListUsers(ctx)
+}
+
+type departmentRepoStub struct {
+	departments map[string]Department
+	users       *orgUserRepoStub
+}
+
+func (d *departmentRepoStub) CreateDepartment(ctx context.Context, department Department) error {
+	d.departments[department.ID] = department
+	return nil
+}
+
+func (d *departmentRepoStub) GetDepartment(ctx context.Context, id string) (Department, error) {
+	department, ok := d.departments[id]
+	if !ok {
+		return Department{}, ErrNotFound
+	}
+	return department, nil
+}
+
+func (d *departmentRepoStub) UpdateDepartment(ctx context.Context, department Department) error {
+	d.departments[department.ID] = department
+	return nil
+}
+
+func (d *departmentRepoStub) DeleteDepartment(ctx context.Context, id string) error {
+	delete(d.departments, id)
+	return nil
+}
+
+func (d *departmentRepoStub) ListDepartments(ctx context.Context) ([]Department, error) {
+	var departments []Department
+	for _, department := range d.departments {
+		departments = append(departments, department)
+	}
+	sort.Slice(departments, func(i, j int) bool { return departments[i].Name < departments[j].Name })
+	return departments, nil
+}
+
+func (d *departmentRepoStub) ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error) {
+	users, _ := d.users.ListUsers(ctx)
+	var members []string
+	for _, user := range users {
+		if containsString(departmentIDs, user.DepartmentID) {
+			members = append(members, user.ID)
+		}
+	}
+	return members, nil
+}
+
+func (d *departmentRepoStub) ListDirectReports(ctx context.Context, managerID string) ([]string, error) {
+	users, _ := d.users.ListUsers(ctx)
+	var reports []string
+	for _, user := range users {
+		if user.ManagerID == managerID {
+			reports = append(reports, user.ID)
+		}
+	}
+	return reports, nil
+}
+
+func TestOrgService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	users := &orgUserRepoStub{users: map[string]User{
+		"alice": {ID: "alice", Email: "alice@example.com", DisplayName: "Alice"},
+		"bob":   {ID: "bob", Email: "bob@example.com", DisplayName: "Bob", ManagerID: "alice"},
+		"carol": {ID: "carol", Email: "carol@example.com", DisplayName: "Carol", ManagerID: "bob"},
+		"dave":  {ID: "dave", Email: "dave@example.com", DisplayName: "Dave"},
+	}}
+	departments := &departmentRepoStub{departments: map[string]Department{}, users: users}
+	ids := []string{"dep-sales", "dep-east"}
+	svc := NewOrgService(departments, users, func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}, func() time.Time { return now })
+	admin := Principal{UserID: "root", IsAdmin: true}
+	alice := Principal{UserID: "alice"}
+
+	if _, err := svc.CreateDepartment(ctx, CreateDepartmentParams{Principal: alice, Input: DepartmentInput{Name: "Sales"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected only administrators to create departments, got %v", err)
+	}
+	sales, err := svc.CreateDepartment(ctx, CreateDepartmentParams{Principal: admin, Input: DepartmentInput{Name: " Sales "}})
+	if err != nil || sales.Name != "Sales" || !sales.CreatedAt.Equal(now) {
+		t.Fatalf("expected a trimmed department, got %+v (err %v)", sales, err)
+	}
+	if _, err := svc.CreateDepartment(ctx, CreateDepartmentParams{Principal: admin, Input: DepartmentInput{Name: "Sales East", ParentID: "dep-sales"}}); err != nil {
+		t.Fatalf("CreateDepartment returned error: %v", err)
+	}
+
+	t.Run("validates the input", func(t *testing.T) {
+		var vErr *ValidationError
+		_, err := svc.CreateDepartment(ctx, CreateDepartmentParams{Principal: admin, Input: DepartmentInput{Name: " ", ParentID: "dep-none"}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["name"] != "department name is required" || vErr.FieldErrors["parent_id"] != "parent department does not exist" {
+			t.Fatalf("expected name and parent errors, got %v", err)
+		}
+		_, err = svc.UpdateDepartment(ctx, UpdateDepartmentParams{Principal: admin, DepartmentID: "dep-sales", Input: DepartmentInput{Name: "Sales", ParentID: "dep-east"}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["parent_id"] != "a department cannot be nested under itself" {
+			t.Fatalf("expected nesting under a subdepartment to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("users are placed in departments and reporting lines", func(t *testing.T) {
+		userSvc := NewUserService(users, func() string { return "erin" }, func() time.Time { return now }).WithDepartments(departments)
+
+		var vErr *ValidationError
+		_, err := userSvc.UpdateUser(ctx, UpdateUserParams{Principal: admin, UserID: "alice", Input: UserInput{
+			Email: "alice@example.com", DisplayName: "Alice", ManagerID: "carol", DepartmentID: "dep-none",
+		}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["manager_id"] != "a user cannot report to themselves or their own reports" || vErr.FieldErrors["department_id"] != "department does not exist" {
+			t.Fatalf("expected a reporting loop and unknown department to be rejected, got %v", err)
+		}
+		_, err = userSvc.CreateUser(ctx, CreateUserParams{Principal: admin, Input: UserInput{Email: "erin@example.com", DisplayName: "Erin", ManagerID: "ghost"}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["manager_id"] != "manager does not exist" {
+			t.Fatalf("expected an unknown manager to be rejected, got %v", err)
+		}
+
+		bob, err := userSvc.UpdateUser(ctx, UpdateUserParams{Principal: admin, UserID: "bob", Input: UserInput{
+			Email: "bob@example.com", DisplayName: "Bob", ManagerID: "alice", DepartmentID: "dep-sales", Location: " Tokyo ",
+		}})
+		if err != nil || bob.DepartmentID != "dep-sales" || bob.Location != "Tokyo" {
+			t.Fatalf("expected bob to join Sales, got %+v (err %v)", bob, err)
+		}
+	})
+
+	t.Run("users are imported with their reporting lines", func(t *testing.T) {
+		newIDs := []string{"frank", "gina"}
+		userSvc := NewUserService(users, func() string {
+			id := newIDs[0]
+			newIDs = newIDs[1:]
+			return id
+		}, func() time.Time { return now }).WithDepartments(departments)
+
+		var vErr *ValidationError
+		_, err := userSvc.ImportUsers(ctx, ImportUsersParams{Principal: admin, Rows: []UserImportRow{
+			{Email: "alice@example.com", ManagerEmail: "carol@example.com"},
+			{Email: "new@example.com", Department: "Marketing", ManagerEmail: "nobody@example.com"},
+		}})
+		if !errors.As(err, &vErr) ||
+			vErr.FieldErrors["rows[0].manager_email"] != "a user cannot report to themselves or their own reports" ||
+			vErr.FieldErrors["rows[1].department"] != "department does not exist" ||
+			vErr.FieldErrors["rows[1].manager_email"] != "manager does not exist" ||
+			vErr.FieldErrors["rows[1].display_name"] != "display name is required" {
+			t.Fatalf("expected every row to be checked, got %v", err)
+		}
+		if _, ok := users.users["new"]; ok || len(newIDs) != 2 {
+			t.Fatalf("expected nothing to be written for an invalid import")
+		}
+
+		result, err := userSvc.ImportUsers(ctx, ImportUsersParams{Principal: admin, Rows: []UserImportRow{
+			{Email: "Frank@example.com", DisplayName: "Frank", Department: "Sales East", ManagerEmail: "gina@example.com", Location: "Osaka"},
+			{Email: "gina@example.com", DisplayName: "Gina", Department: "Sales East", ManagerEmail: "alice@example.com"},
+			{Email: "dave@example.com", Department: "Sales", ManagerEmail: "gina@example.com"},
+		}})
+		if err != nil {
+			t.Fatalf("ImportUsers returned error: %v", err)
+		}
+		if len(result.Created) != 2 || len(result.Updated) != 1 {
+			t.Fatalf("expected two users created and one updated, got %+v", result)
+		}
+		frank := users.users["frank"]
+		if frank.Email != "frank@example.com" || frank.ManagerID != "gina" || frank.DepartmentID != "dep-east" || frank.Location != "Osaka" {
+			t.Fatalf("expected frank to report to the imported gina, got %+v", frank)
+		}
+		if dave := users.users["dave"]; dave.DisplayName != "Dave" || dave.ManagerID != "gina" || dave.DepartmentID != "dep-sales" {
+			t.Fatalf("expected dave to keep his name and move under gina, got %+v", dave)
+		}
+	})
+
+	t.Run("schedules are filtered and shown to managers", func(t *testing.T) {
+		schedules := &filteringScheduleRepo{schedules: []Schedule{
+			{ID: "frank-1on1", CreatorID: "frank", Title: "1on1", Description: "Career", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"frank", "dave"}},
+			{ID: "carol-dentist", CreatorID: "carol", Title: "Dentist", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"carol"}},
+		}}
+		scheduleSvc := NewScheduleService(schedules, nil, nil, nil, nil, func() time.Time { return now }).WithOrg(svc)
+
+		listed, _, err := scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: alice, DepartmentID: "dep-east"})
+		if err != nil || len(listed) != 1 || listed[0].ID != "frank-1on1" {
+			t.Fatalf("expected the schedules of Sales East, got %+v (err %v)", listed, err)
+		}
+		listed, _, err = scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: Principal{UserID: "bob"}, ReportsTo: "bob"})
+		if err != nil || len(listed) != 1 || listed[0].ID != "carol-dentist" {
+			t.Fatalf("expected the schedules of bob's direct reports, got %+v (err %v)", listed, err)
+		}
+		var vErr *ValidationError
+		if _, _, err := scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: alice, DepartmentID: "dep-none"}); !errors.As(err, &vErr) || vErr.FieldErrors["department"] != "department does not exist" {
+			t.Fatalf("expected an unknown department filter to be rejected, got %v", err)
+		}
+
+		view, err := scheduleSvc.GetSchedule(ctx, alice, "carol-dentist")
+		if err != nil {
+			t.Fatalf("expected a manager further up to see the schedule, got %v", err)
+		}
+		if !view.BusyOnly || view.Title != busyScheduleTitle || view.CreatorID != "carol" || !view.Start.Equal(now) {
+			t.Fatalf("expected a busy-only view, got %+v", view)
+		}
+		view, err = scheduleSvc.GetSchedule(ctx, Principal{UserID: "gina"}, "frank-1on1")
+		if err != nil || view.Description != "" || len(view.ParticipantIDs) != 2 {
+			t.Fatalf("expected gina to see only that her reports are busy, got %+v (err %v)", view, err)
+		}
+		if _, err := scheduleSvc.GetSchedule(ctx, Principal{UserID: "frank"}, "carol-dentist"); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected colleagues outside the reporting line to be refused, got %v", err)
+		}
+	})
+
+	if err := svc.DeleteDepartment(ctx, alice, "dep-east"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected only administrators to delete departments, got %v", err)
+	}
+	if err := svc.DeleteDepartment(ctx, admin, "dep-east"); err != nil {
+		t.Fatalf("DeleteDepartment returned error: %v", err)
+	}
+	if _, err := svc.GetDepartment(ctx, alice, "dep-east"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the department to be gone, got %v", err)
+	}
+}
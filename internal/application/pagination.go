@@ -0,0 +1,65 @@
+package application
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is applied when a listing request does not specify a limit.
+	DefaultPageSize = 100
+	// MaxPageSize caps the number of records a single listing page may return.
+	MaxPageSize = 500
+)
+
+// pageCursor is the decoded form of the opaque cursor handed to clients.
+// Key holds the primary sort value of the last returned record and ID breaks ties.
+type pageCursor struct {
+	Key string `json:"k"`
+	ID  string `json:"id"`
+}
+
+func encodeCursor(key, id string) string {
+	payload, err := json.Marshal(pageCursor{Key: key, ID: id})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCursor(raw string) (*pageCursor, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, true
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, false
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, false
+	}
+	if cursor.ID == "" {
+		return nil, false
+	}
+	return &cursor, true
+}
+
+// resolvePage validates the caller supplied limit and cursor, returning the
+// effective page size and the decoded cursor (nil for the first page).
+func resolvePage(limit int, rawCursor string) (int, *pageCursor, *ValidationError) {
+	vErr := &ValidationError{}
+	if limit < 0 || limit > MaxPageSize {
+		vErr.add("limit", "limit must be between 1 and 500")
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	cursor, ok := decodeCursor(rawCursor)
+	if !ok {
+		vErr.add("cursor", "cursor is invalid")
+	}
+	return limit, cursor, vErr
+}
@@ -0,0 +1,620 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/notification"
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/recurrence"
+)
+
+const (
+	// MaxReminderMinutes bounds how far ahead of a schedule a reminder may fire (one week).
+	MaxReminderMinutes = 7 * 24 * 60
+	// maxRemindersPerTarget bounds the reminders of a schedule or a user's defaults.
+	maxRemindersPerTarget = 5
+	// maxReminderAttempts bounds how often a failed delivery is retried.
+	maxReminderAttempts = 3
+	// defaultReminderGrace is how late a reminder may still be sent, e.g. after a restart.
+	defaultReminderGrace = 15 * time.Minute
+	// reminderClaimLease is how long a claimed delivery stays with its dispatcher
+	// before another may take it over. It is shorter than defaultReminderGrace so
+	// a reminder abandoned by a crash is still sent.
+	reminderClaimLease = 5 * time.Minute
+)
+
+// ReminderRepository persists reminder preferences and delivery state.
+type ReminderRepository interface {
+	// GetReminderPreferences returns ErrNotFound when the user never saved preferences.
+	GetReminderPreferences(ctx context.Context, userID string) (ReminderPreferences, error)
+	SaveReminderPreferences(ctx context.Context, prefs ReminderPreferences) error
+	ListReminderPreferences(ctx context.Context, userIDs []string) (map[string]ReminderPreferences, error)
+	// ListRecurringScheduleIDs returns schedules with a recurrence that has not ended before activeFrom.
+	ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error)
+	// ClaimReminderDelivery atomically records delivery as sending. It returns the
+	// stored delivery and true when the caller should send it: either the delivery
+	// is new, or it previously failed or was claimed more than lease ago without
+	// finishing, fewer than maxAttempts times.
+	ClaimReminderDelivery(ctx context.Context, delivery ReminderDelivery, maxAttempts int, lease time.Duration) (ReminderDelivery, bool, error)
+	FinishReminderDelivery(ctx context.Context, delivery ReminderDelivery) error
+}
+
+// ReminderService manages reminder preferences and dispatches due reminders
+// through the configured notification channels.
+type ReminderService struct {
+	reminders   ReminderRepository
+	schedules   ScheduleRepository
+	recurrences RecurrenceRepository
+	users       UserRepository
+	holidays    HolidayDirectory
+	userGroups  UserGroupDirectory
+	channels    []notification.Channel
+	idGenerator func() string
+	now         func() time.Time
+	grace       time.Duration
+	logger      *slog.Logger
+}
+
+// NewReminderService constructs a reminder service with the provided dependencies.
+func NewReminderService(reminders ReminderRepository, schedules ScheduleRepository, recurrences RecurrenceRepository, users UserRepository, channels []notification.Channel, idGenerator func() string, now func() time.Time) *ReminderService {
+	return NewReminderServiceWithLogger(reminders, schedules, recurrences, users, channels, idGenerator, now, nil)
+}
+
+// NewReminderServiceWithLogger constructs a reminder service with a specified logger.
+func NewReminderServiceWithLogger(reminders ReminderRepository, schedules ScheduleRepository, recurrences RecurrenceRepository, users UserRepository, channels []notification.Channel, idGenerator func() string, now func() time.Time, logger *slog.Logger) *ReminderService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &ReminderService{
+		reminders:   reminders,
+		schedules:   schedules,
+		recurrences: recurrences,
+		users:       users,
+		channels:    channels,
+		idGenerator: idGenerator,
+		now:         now,
+		grace:       defaultReminderGrace,
+		logger:      defaultLogger(logger),
+	}
+}
+
+// WithHolidays applies recurrence holiday policies when expanding occurrences.
+func (s *ReminderService) WithHolidays(directory HolidayDirectory) *ReminderService {
+	if s != nil {
+		s.holidays = directory
+	}
+	return s
+}
+
+// WithUserGroups reminds the current members of user groups invited to a
+// schedule as a live reference, returning the service for chaining.
+func (s *ReminderService) WithUserGroups(directory UserGroupDirectory) *ReminderService {
+	if s != nil {
+		s.userGroups = directory
+	}
+	return s
+}
+
+func (s *ReminderService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "ReminderService", operation, attrs...)
+}
+
+// GetReminderPreferences returns a user's reminder preferences. Users who never
+// saved preferences have no default reminders and use every configured channel.
+func (s *ReminderService) GetReminderPreferences(ctx context.Context, principal Principal, userID string) (ReminderPreferences, error) {
+	if s == nil {
+		return ReminderPreferences{}, fmt.Errorf("ReminderService is nil")
+	}
+	if s.reminders == nil {
+		return ReminderPreferences{}, fmt.Errorf("reminder repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "GetReminderPreferences", "principal_id", principal.UserID, "user_id", userID)
+	if userID != principal.UserID && !principal.IsAdmin {
+		logger.WarnContext(ctx, "unauthorized reminder preference read", "error_kind", ErrorKind(ErrUnauthorized))
+		return ReminderPreferences{}, ErrUnauthorized
+	}
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return ReminderPreferences{}, err
+	}
+
+	prefs, err := s.reminders.GetReminderPreferences(ctx, userID)
+	if err != nil {
+		err = mapReminderRepoError(err)
+		if !errors.Is(err, ErrNotFound) {
+			logger.ErrorContext(ctx, "failed to load reminder preferences", "error", err, "error_kind", ErrorKind(err))
+			return ReminderPreferences{}, err
+		}
+		prefs = s.defaultPreferences(userID)
+	}
+	return prefs, nil
+}
+
+// SetReminderPreferences replaces a user's reminder preferences. Users manage
+// their own preferences; administrators may manage anyone's.
+func (s *ReminderService) SetReminderPreferences(ctx context.Context, params SetReminderPreferencesParams) (prefs ReminderPreferences, err error) {
+	if s == nil {
+		err = fmt.Errorf("ReminderService is nil")
+		return
+	}
+	if s.reminders == nil {
+		err = fmt.Errorf("reminder repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "SetReminderPreferences",
+		"principal_id", params.Principal.UserID,
+		"user_id", params.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to set reminder preferences", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("reminder_count", len(prefs.MinutesBefore), "channel_count", len(prefs.Channels)).
+			InfoContext(ctx, "reminder preferences updated")
+	}()
+
+	if params.UserID != params.Principal.UserID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	vErr := &ValidationError{}
+	validateReminderMinutes("minutes_before", params.MinutesBefore, vErr)
+	channels := normalizeNames(params.Channels)
+	for _, channel := range channels {
+		if !s.hasChannel(channel) {
+			vErr.add("channels", "reminder channel is invalid")
+			break
+		}
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	if err = s.ensureUserExists(ctx, params.UserID); err != nil {
+		return
+	}
+
+	prefs = ReminderPreferences{
+		UserID:        params.UserID,
+		MinutesBefore: normalizeReminderMinutes(params.MinutesBefore),
+		Channels:      channels,
+		UpdatedAt:     s.now(),
+	}
+	if err = s.reminders.SaveReminderPreferences(ctx, prefs); err != nil {
+		err = mapReminderRepoError(err)
+	}
+	return
+}
+
+// Run dispatches due reminders every interval until ctx is cancelled.
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Failures are logged by DispatchDue and retried on the next tick.
+		_, _ = s.DispatchDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends every reminder that fell due within the grace period and
+// has not been delivered yet. Occurrences of recurring schedules are expanded.
+func (s *ReminderService) DispatchDue(ctx context.Context) (result ReminderDispatchResult, err error) {
+	if s == nil {
+		err = fmt.Errorf("ReminderService is nil")
+		return
+	}
+	if s.reminders == nil || s.schedules == nil {
+		err = fmt.Errorf("reminder dependencies not configured")
+		return
+	}
+	if len(s.channels) == 0 {
+		return
+	}
+
+	now := s.now().UTC()
+	windowStart := now.Add(-s.grace)
+
+	logger := s.loggerWith(ctx, "DispatchDue", "window_start", windowStart, "window_end", now)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to dispatch reminders", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		if result.Sent > 0 || result.Failed > 0 {
+			logger.With("sent_count", result.Sent, "failed_count", result.Failed).InfoContext(ctx, "reminders dispatched")
+		}
+	}()
+
+	occurrences, err := s.upcomingOccurrences(ctx, windowStart, now.Add(MaxReminderMinutes*time.Minute))
+	if err != nil {
+		return
+	}
+	if len(occurrences) == 0 {
+		return
+	}
+
+	recipients := make(map[string]struct{})
+	for _, occ := range occurrences {
+		for _, userID := range reminderRecipients(occ.schedule) {
+			recipients[userID] = struct{}{}
+		}
+	}
+	userIDs := make([]string, 0, len(recipients))
+	for userID := range recipients {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	prefs, err := s.reminders.ListReminderPreferences(ctx, userIDs)
+	if err != nil {
+		err = mapReminderRepoError(err)
+		return
+	}
+	users := make(map[string]*User, len(userIDs))
+
+	for _, occ := range occurrences {
+		for _, userID := range reminderRecipients(occ.schedule) {
+			userPrefs, ok := prefs[userID]
+			if !ok {
+				userPrefs = s.defaultPreferences(userID)
+			}
+			minutes := occ.schedule.ReminderMinutes
+			if len(minutes) == 0 {
+				minutes = userPrefs.MinutesBefore
+			}
+			for _, before := range minutes {
+				due := occ.start.Add(-time.Duration(before) * time.Minute)
+				if !due.After(windowStart) || due.After(now) {
+					continue
+				}
+				user, loadErr := s.recipient(ctx, users, userID)
+				if loadErr != nil {
+					err = loadErr
+					return
+				}
+				if user == nil {
+					continue
+				}
+				for _, channel := range s.channels {
+					if !containsString(userPrefs.Channels, channel.Name()) {
+						continue
+					}
+					claimed, sent, deliverErr := s.deliver(ctx, channel, occ, *user, before, due)
+					if deliverErr != nil {
+						err = deliverErr
+						return
+					}
+					switch {
+					case !claimed:
+					case sent:
+						result.Sent++
+					default:
+						result.Failed++
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// reminderOccurrence is one concrete start of a schedule within the dispatch range.
+type reminderOccurrence struct {
+	schedule Schedule
+	start    time.Time
+	end      time.Time
+}
+
+// upcomingOccurrences returns occurrences starting in (from, to]. All-day
+// occurrences start at midnight in the schedule's own zone.
+func (s *ReminderService) upcomingOccurrences(ctx context.Context, from, to time.Time) ([]reminderOccurrence, error) {
+	// Floating all-day bounds are widened by a day to cover every zone.
+	allDayFrom := floatingDate(from).AddDate(0, 0, -1)
+	allDayTo := floatingDate(to).AddDate(0, 0, 2)
+	listed, err := s.schedules.ListSchedules(ctx, ScheduleRepositoryFilter{
+		StartsAfter:       &from,
+		EndsBefore:        &to,
+		AllDayStartsAfter: &allDayFrom,
+		AllDayEndsBefore:  &allDayTo,
+	})
+	if err != nil && !isNotFoundError(err) {
+		return nil, mapScheduleRepoError(err)
+	}
+
+	byID := make(map[string]Schedule, len(listed))
+	order := make([]string, 0, len(listed))
+	for _, schedule := range listed {
+		if _, ok := byID[schedule.ID]; !ok {
+			order = append(order, schedule.ID)
+		}
+		byID[schedule.ID] = schedule
+	}
+
+	var rules map[string][]RecurrenceRule
+	if s.recurrences != nil {
+		recurringIDs, err := s.reminders.ListRecurringScheduleIDs(ctx, from)
+		if err != nil {
+			return nil, mapReminderRepoError(err)
+		}
+		for _, id := range recurringIDs {
+			if _, ok := byID[id]; ok {
+				continue
+			}
+			schedule, err := s.schedules.GetSchedule(ctx, id)
+			if err != nil {
+				if isNotFoundError(err) {
+					continue
+				}
+				return nil, mapScheduleRepoError(err)
+			}
+			byID[id] = schedule
+			order = append(order, id)
+		}
+		if len(recurringIDs) > 0 {
+			if rules, err = s.recurrences.ListRecurrencesForSchedules(ctx, recurringIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Members of live participant groups are reminded like participants.
+	schedules := make([]Schedule, 0, len(order))
+	for _, id := range order {
+		schedules = append(schedules, byID[id])
+	}
+	if schedules, err = withParticipantGroupMembers(ctx, s.userGroups, schedules); err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		byID[schedule.ID] = schedule
+	}
+
+	var holidays recurrence.HolidayCalendar
+	if s.holidays != nil && usesHolidayPolicy(rules) {
+		if holidays, err = s.holidays.HolidayCalendar(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var occurrences []reminderOccurrence
+	for _, id := range order {
+		schedule := byID[id]
+		loc := locationOrDefault(schedule.TimeZone)
+		seen := make(map[time.Time]struct{})
+		add := func(start, end time.Time) {
+			if schedule.AllDay {
+				start, end = anchorFloating(start, loc), anchorFloating(end, loc)
+			}
+			start, end = start.UTC(), end.UTC()
+			if !start.After(from) || start.After(to) {
+				return
+			}
+			if _, dup := seen[start]; dup {
+				return
+			}
+			seen[start] = struct{}{}
+			occurrences = append(occurrences, reminderOccurrence{schedule: schedule, start: start, end: end})
+		}
+
+		add(schedule.Start, schedule.End)
+
+		engine := recurrence.NewEngine(loc)
+		opts := recurrence.GenerateOptions{RangeStart: &from, RangeEnd: &to, Holidays: holidays}
+		if schedule.AllDay {
+			engine = recurrence.NewEngine(time.UTC)
+			opts = recurrence.GenerateOptions{RangeStart: &allDayFrom, RangeEnd: &allDayTo, AllDay: true, Holidays: holidays}
+		}
+		for _, rule := range rules[id] {
+			generated, err := engine.GenerateOccurrences(toRecurrenceRule(rule), schedule.Start, schedule.End, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, occ := range generated {
+				add(occ.Start, occ.End)
+			}
+		}
+	}
+	return occurrences, nil
+}
+
+// deliver claims and sends a single reminder. claimed is false when the
+// reminder was already handled; sent reports whether the channel accepted it.
+func (s *ReminderService) deliver(ctx context.Context, channel notification.Channel, occ reminderOccurrence, user User, before int, due time.Time) (claimed, sent bool, err error) {
+	delivery, claimed, err := s.reminders.ClaimReminderDelivery(ctx, ReminderDelivery{
+		ID:              s.idGenerator(),
+		ScheduleID:      occ.schedule.ID,
+		OccurrenceStart: occ.start,
+		UserID:          user.ID,
+		MinutesBefore:   before,
+		Channel:         channel.Name(),
+		Status:          ReminderDeliverySending,
+		DueAt:           due,
+		UpdatedAt:       s.now(),
+	}, maxReminderAttempts, reminderClaimLease)
+	if err != nil || !claimed {
+		return false, false, mapReminderRepoError(err)
+	}
+
+	sendErr := channel.Send(ctx, notification.Message{
+		ScheduleID:    occ.schedule.ID,
+		Title:         occ.schedule.Title,
+		Start:         occ.start,
+		End:           occ.end,
+		AllDay:        occ.schedule.AllDay,
+		MinutesBefore: before,
+		Recipient: notification.Recipient{
+			UserID:      user.ID,
+			Email:       user.Email,
+			DisplayName: user.DisplayName,
+			Location:    locationOrDefault(user.TimeZone),
+		},
+	})
+
+	sent = sendErr == nil
+	delivery.Status = ReminderDeliverySent
+	delivery.LastError = ""
+	if !sent {
+		delivery.Status = ReminderDeliveryFailed
+		delivery.LastError = sendErr.Error()
+		s.loggerWith(ctx, "DispatchDue",
+			"schedule_id", occ.schedule.ID,
+			"user_id", user.ID,
+			"channel", channel.Name(),
+			"attempt", delivery.Attempts,
+		).WarnContext(ctx, "reminder delivery failed", "error", sendErr)
+	}
+	delivery.UpdatedAt = s.now()
+	if err = s.reminders.FinishReminderDelivery(ctx, delivery); err != nil {
+		return true, false, mapReminderRepoError(err)
+	}
+	return true, sent, nil
+}
+
+// recipient loads and caches a user for the current dispatch run. Deleted users
+// yield nil.
+func (s *ReminderService) recipient(ctx context.Context, cache map[string]*User, userID string) (*User, error) {
+	if user, ok := cache[userID]; ok {
+		return user, nil
+	}
+	if s.users == nil {
+		user := &User{ID: userID}
+		cache[userID] = user
+		return user, nil
+	}
+	loaded, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		if isNotFoundError(err) {
+			cache[userID] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+	cache[userID] = &loaded
+	return &loaded, nil
+}
+
+func (s *ReminderService) defaultPreferences(userID string) ReminderPreferences {
+	return ReminderPreferences{UserID: userID, Channels: s.channelNames()}
+}
+
+func (s *ReminderService) channelNames() []string {
+	names := make([]string, 0, len(s.channels))
+	for _, channel := range s.channels {
+		names = append(names, channel.Name())
+	}
+	return names
+}
+
+func (s *ReminderService) hasChannel(name string) bool {
+	for _, channel := range s.channels {
+		if channel.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ReminderService) ensureUserExists(ctx context.Context, userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return ErrNotFound
+	}
+	if s.users == nil {
+		return nil
+	}
+	if _, err := s.users.GetUser(ctx, userID); err != nil {
+		if isNotFoundError(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// reminderRecipients returns the creator and participants of a schedule.
+func reminderRecipients(schedule Schedule) []string {
+	return sortStrings(uniqueStrings(append([]string{schedule.CreatorID}, schedule.ParticipantIDs...)))
+}
+
+// validateReminderMinutes reports reminder offsets outside [0, MaxReminderMinutes]
+// and lists longer than maxRemindersPerTarget.
+func validateReminderMinutes(field string, minutes []int, vErr *ValidationError) {
+	if len(normalizeReminderMinutes(minutes)) > maxRemindersPerTarget {
+		vErr.add(field, fmt.Sprintf("at most %d reminders are allowed", maxRemindersPerTarget))
+		return
+	}
+	for _, before := range minutes {
+		if before < 0 || before > MaxReminderMinutes {
+			vErr.add(field, fmt.Sprintf("reminder must be between 0 and %d minutes before start", MaxReminderMinutes))
+			return
+		}
+	}
+}
+
+// normalizeReminderMinutes sorts and de-duplicates reminder offsets.
+func normalizeReminderMinutes(minutes []int) []int {
+	if len(minutes) == 0 {
+		return nil
+	}
+	seen := make(map[int]struct{}, len(minutes))
+	out := make([]int, 0, len(minutes))
+	for _, before := range minutes {
+		if _, ok := seen[before]; ok {
+			continue
+		}
+		seen[before] = struct{}{}
+		out = append(out, before)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// normalizeNames trims, lowercases, de-duplicates and sorts channel or event type names.
+func normalizeNames(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if trimmed := strings.ToLower(strings.TrimSpace(value)); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return sortStrings(uniqueStrings(out))
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func mapReminderRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
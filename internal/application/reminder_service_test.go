@@ -0,0 +1,305 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/notification"
+)
+
+type reminderRepoStub struct {
+	prefs      map[string]ReminderPreferences
+	recurring  []string
+	deliveries map[string]ReminderDelivery
+}
+
+func newReminderRepoStub() *reminderRepoStub {
+	return &reminderRepoStub{
+		prefs:      make(map[string]ReminderPreferences),
+		deliveries: make(map[string]ReminderDelivery),
+	}
+}
+
+func (r *reminderRepoStub) GetReminderPreferences(ctx context.Context, userID string) (ReminderPreferences, error) {
+	prefs, ok := r.prefs[userID]
+	if !ok {
+		return ReminderPreferences{}, ErrNotFound
+	}
+	return prefs, nil
+}
+
+func (r *reminderRepoStub) SaveReminderPreferences(ctx context.Context, prefs ReminderPreferences) error {
+	r.prefs[prefs.UserID] = prefs
+	return nil
+}
+
+func (r *reminderRepoStub) ListReminderPreferences(ctx context.Context, userIDs []string) (map[string]ReminderPreferences, error) {
+	out := make(map[string]ReminderPreferences)
+	for _, id := range userIDs {
+		if prefs, ok := r.prefs[id]; ok {
+			out[id] = prefs
+		}
+	}
+	return out, nil
+}
+
+func (r *reminderRepoStub) ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error) {
+	return r.recurring, nil
+}
+
+func (r *reminderRepoStub) ClaimReminderDelivery(ctx context.Context, delivery ReminderDelivery, maxAttempts int, lease time.Duration) (ReminderDelivery, bool, error) {
+	key := fmt.Sprintf("%s|%s|%s|%d|%s", delivery.ScheduleID, delivery.OccurrenceStart.UTC().Format(time.RFC3339), delivery.UserID, delivery.MinutesBefore, delivery.Channel)
+	existing, ok := r.deliveries[key]
+	switch {
+	case !ok:
+		delivery.Attempts = 1
+	case existing.Status == ReminderDeliveryFailed && existing.Attempts < maxAttempts:
+		delivery = existing
+		delivery.Attempts++
+	default:
+		return existing, false, nil
+	}
+	delivery.Status = ReminderDeliverySending
+	r.deliveries[key] = delivery
+	return delivery, true, nil
+}
+
+func (r *reminderRepoStub) FinishReminderDelivery(ctx context.Context, delivery ReminderDelivery) error {
+	for key, existing := range r.deliveries {
+		if existing.ID == delivery.ID {
+			r.deliveries[key] = delivery
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+type usersByIDStub struct {
+	userRepoStub
+	users map[string]User
+}
+
+func (u *usersByIDStub) GetUser(ctx context.Context, id string) (User, error) {
+	user, ok := u.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+type channelStub struct {
+	name string
+	err  error
+	sent []notification.Message
+}
+
+func (c *channelStub) Name() string { return c.name }
+
+func (c *channelStub) Send(ctx context.Context, msg notification.Message) error {
+	c.sent = append(c.sent, msg)
+	return c.err
+}
+
+func reminderUsers() *usersByIDStub {
+	return &usersByIDStub{users: map[string]User{
+		"creator": {ID: "creator", Email: "creator@example.com", TimeZone: "Asia/Tokyo"},
+		"guest":   {ID: "guest", Email: "guest@example.com", TimeZone: "Asia/Tokyo"},
+	}}
+}
+
+func sequentialIDs() func() string {
+	next := 0
+	return func() string {
+		next++
+		return fmt.Sprintf("delivery-%d", next)
+	}
+}
+
+func TestReminderService_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends schedule reminders once to every recipient", func(t *testing.T) {
+		current := mustJST(t, 9).Add(50 * time.Minute)
+		schedules := &scheduleRepoStub{list: []Schedule{{
+			ID:              "schedule-1",
+			CreatorID:       "creator",
+			Title:           "定例",
+			Start:           mustJST(t, 10),
+			End:             mustJST(t, 11),
+			ParticipantIDs:  []string{"guest"},
+			ReminderMinutes: []int{10, 60},
+		}}}
+		repo := newReminderRepoStub()
+		email := &channelStub{name: notification.ChannelEmail}
+		svc := NewReminderService(repo, schedules, nil, reminderUsers(), []notification.Channel{email}, sequentialIDs(), func() time.Time { return current })
+
+		result, err := svc.DispatchDue(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Sent != 2 || len(email.sent) != 2 {
+			t.Fatalf("expected the 10 minute reminder for both recipients, got %+v (%d messages)", result, len(email.sent))
+		}
+		if email.sent[0].MinutesBefore != 10 || !email.sent[0].Start.Equal(mustJST(t, 10)) {
+			t.Fatalf("unexpected message: %+v", email.sent[0])
+		}
+
+		current = current.Add(time.Minute)
+		if result, err = svc.DispatchDue(ctx); err != nil || result.Sent != 0 {
+			t.Fatalf("expected nothing resent, got %+v (err %v)", result, err)
+		}
+		if len(email.sent) != 2 {
+			t.Fatalf("expected no duplicate deliveries, got %d messages", len(email.sent))
+		}
+	})
+
+	t.Run("falls back to user defaults and honours opt-out", func(t *testing.T) {
+		current := mustJST(t, 9).Add(45 * time.Minute)
+		schedules := &scheduleRepoStub{list: []Schedule{{
+			ID:             "schedule-1",
+			CreatorID:      "creator",
+			Title:          "定例",
+			Start:          mustJST(t, 10),
+			End:            mustJST(t, 11),
+			ParticipantIDs: []string{"guest"},
+		}}}
+		repo := newReminderRepoStub()
+		repo.prefs["creator"] = ReminderPreferences{UserID: "creator", MinutesBefore: []int{15}, Channels: []string{notification.ChannelWebhook}}
+		repo.prefs["guest"] = ReminderPreferences{UserID: "guest", MinutesBefore: []int{15}}
+		email := &channelStub{name: notification.ChannelEmail}
+		webhook := &channelStub{name: notification.ChannelWebhook}
+		svc := NewReminderService(repo, schedules, nil, reminderUsers(), []notification.Channel{email, webhook}, sequentialIDs(), func() time.Time { return current })
+
+		if _, err := svc.DispatchDue(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(email.sent) != 0 {
+			t.Fatalf("expected no mail, got %d", len(email.sent))
+		}
+		if len(webhook.sent) != 1 || webhook.sent[0].Recipient.UserID != "creator" {
+			t.Fatalf("expected a webhook reminder for the creator only, got %+v", webhook.sent)
+		}
+	})
+
+	t.Run("reminds about recurring occurrences", func(t *testing.T) {
+		base := mustJST(t, 10).AddDate(0, 0, -7)
+		current := mustJST(t, 9).Add(50 * time.Minute)
+		schedules := &scheduleRepoStub{schedule: Schedule{
+			ID:              "weekly",
+			CreatorID:       "creator",
+			Title:           "週次",
+			Start:           base,
+			End:             base.Add(time.Hour),
+			TimeZone:        "Asia/Tokyo",
+			ReminderMinutes: []int{10},
+		}}
+		repo := newReminderRepoStub()
+		repo.recurring = []string{"weekly"}
+		recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+			"weekly": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{base.Weekday().String()}, StartsOn: base}},
+		}}
+		email := &channelStub{name: notification.ChannelEmail}
+		svc := NewReminderService(repo, schedules, recurrences, reminderUsers(), []notification.Channel{email}, sequentialIDs(), func() time.Time { return current })
+
+		if _, err := svc.DispatchDue(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(email.sent) != 1 || !email.sent[0].Start.Equal(mustJST(t, 10)) {
+			t.Fatalf("expected a reminder for this week's occurrence, got %+v", email.sent)
+		}
+	})
+
+	t.Run("retries failed deliveries a bounded number of times", func(t *testing.T) {
+		current := mustJST(t, 9).Add(50 * time.Minute)
+		schedules := &scheduleRepoStub{list: []Schedule{{
+			ID:              "schedule-1",
+			CreatorID:       "creator",
+			Title:           "定例",
+			Start:           mustJST(t, 10),
+			End:             mustJST(t, 11),
+			ReminderMinutes: []int{10},
+		}}}
+		repo := newReminderRepoStub()
+		email := &channelStub{name: notification.ChannelEmail, err: errors.New("connection refused")}
+		svc := NewReminderService(repo, schedules, nil, reminderUsers(), []notification.Channel{email}, sequentialIDs(), func() time.Time { return current })
+
+		for i := 0; i < maxReminderAttempts+2; i++ {
+			result, err := svc.DispatchDue(ctx)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if i < maxReminderAttempts && result.Failed != 1 {
+				t.Fatalf("attempt %d: expected a failed delivery, got %+v", i+1, result)
+			}
+		}
+		if len(email.sent) != maxReminderAttempts {
+			t.Fatalf("expected %d attempts, got %d", maxReminderAttempts, len(email.sent))
+		}
+		for _, delivery := range repo.deliveries {
+			if delivery.Status != ReminderDeliveryFailed || delivery.LastError != "connection refused" {
+				t.Fatalf("unexpected delivery state: %+v", delivery)
+			}
+		}
+	})
+}
+
+func TestReminderService_SetReminderPreferences(t *testing.T) {
+	ctx := context.Background()
+	repo := newReminderRepoStub()
+	channels := []notification.Channel{&channelStub{name: notification.ChannelEmail}}
+	svc := NewReminderService(repo, nil, nil, reminderUsers(), channels, nil, nil)
+
+	t.Run("defaults to every channel", func(t *testing.T) {
+		prefs, err := svc.GetReminderPreferences(ctx, Principal{UserID: "guest"}, "guest")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(prefs.MinutesBefore) != 0 || len(prefs.Channels) != 1 || prefs.Channels[0] != notification.ChannelEmail {
+			t.Fatalf("unexpected defaults: %+v", prefs)
+		}
+	})
+
+	t.Run("normalizes and stores preferences", func(t *testing.T) {
+		prefs, err := svc.SetReminderPreferences(ctx, SetReminderPreferencesParams{
+			Principal:     Principal{UserID: "guest"},
+			UserID:        "guest",
+			MinutesBefore: []int{30, 10, 30},
+			Channels:      []string{" Email "},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(prefs.MinutesBefore) != 2 || prefs.MinutesBefore[0] != 10 || prefs.Channels[0] != notification.ChannelEmail {
+			t.Fatalf("unexpected preferences: %+v", prefs)
+		}
+		if _, ok := repo.prefs["guest"]; !ok {
+			t.Fatal("expected preferences to be saved")
+		}
+	})
+
+	t.Run("rejects unknown channels and out-of-range minutes", func(t *testing.T) {
+		_, err := svc.SetReminderPreferences(ctx, SetReminderPreferencesParams{
+			Principal:     Principal{UserID: "guest"},
+			UserID:        "guest",
+			MinutesBefore: []int{-5},
+			Channels:      []string{"sms"},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+		if vErr.FieldErrors["channels"] == "" || vErr.FieldErrors["minutes_before"] == "" {
+			t.Fatalf("unexpected field errors: %v", vErr.FieldErrors)
+		}
+	})
+
+	t.Run("rejects other users' preferences", func(t *testing.T) {
+		_, err := svc.SetReminderPreferences(ctx, SetReminderPreferencesParams{Principal: Principal{UserID: "guest"}, UserID: "creator"})
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
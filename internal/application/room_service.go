@@ -19,11 +19,23 @@ type RoomRepository interface {
 	UpdateRoom(ctx context.Context, room Room) (Room, error)
 	DeleteRoom(ctx context.Context, id string) error
 	ListRooms(ctx context.Context) ([]Room, error)
+	ListRoomsPage(ctx context.Context, filter RoomRepositoryFilter) ([]Room, error)
+}
+
+// RoomRepositoryFilter narrows paginated room queries. Results are ordered by
+// case-insensitive name then ID and start strictly after (AfterName, AfterID) when set.
+type RoomRepositoryFilter struct {
+	AfterName string
+	AfterID   string
+	Limit     int
 }
 
 // RoomService orchestrates validation, authorization, and persistence for rooms.
 type RoomService struct {
 	rooms       RoomRepository
+	events      EventPublisher
+	trash       RoomTrash
+	unitOfWork  UnitOfWork
 	idGenerator func() string
 	now         func() time.Time
 	logger      *slog.Logger
@@ -45,6 +57,33 @@ func NewRoomServiceWithLogger(rooms RoomRepository, idGenerator func() string, n
 	return &RoomService{rooms: rooms, idGenerator: idGenerator, now: now, logger: defaultLogger(logger)}
 }
 
+// WithEvents publishes room.created, room.updated and room.deleted events through
+// publisher, returning the service for chaining.
+func (s *RoomService) WithEvents(publisher EventPublisher) *RoomService {
+	if s != nil {
+		s.events = publisher
+	}
+	return s
+}
+
+// WithUnitOfWork makes room writes atomic with their outbox events, returning
+// the service for chaining.
+func (s *RoomService) WithUnitOfWork(unitOfWork UnitOfWork) *RoomService {
+	if s != nil {
+		s.unitOfWork = unitOfWork
+	}
+	return s
+}
+
+// inTransaction runs fn through the configured unit of work, or directly when
+// none is configured.
+func (s *RoomService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return s.unitOfWork.WithinTransaction(ctx, fn)
+}
+
 func (s *RoomService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
 	return serviceLogger(ctx, s.logger, "RoomService", operation, attrs...)
 }
@@ -92,14 +131,21 @@ func (s *RoomService) CreateRoom(ctx context.Context, params CreateRoomParams) (
 		return
 	}
 
-	var persisted Room
-	persisted, err = s.rooms.CreateRoom(ctx, room)
-	if err != nil {
-		err = mapRoomRepoError(err)
-		return
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		persisted, err := s.rooms.CreateRoom(ctx, room)
+		if err != nil {
+			return mapRoomRepoError(err)
+		}
 
-	room = persisted
+		room = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventRoomCreated,
+			ResourceID: room.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: room.CreatedAt,
+			Data:       newRoomEventData(room),
+		})
+	})
 	return
 }
 
@@ -136,6 +182,9 @@ func (s *RoomService) UpdateRoom(ctx context.Context, params UpdateRoomParams) (
 		err = mapRoomRepoError(err)
 		return
 	}
+	if err = checkIfMatch(params.IfMatch, existing.Version); err != nil {
+		return
+	}
 
 	vErr := validateRoomInput(params.Input)
 	if vErr.HasErrors() {
@@ -150,16 +199,26 @@ func (s *RoomService) UpdateRoom(ctx context.Context, params UpdateRoomParams) (
 	updated.Facilities = normalizeOptionalString(params.Input.Facilities)
 	updated.UpdatedAt = s.now()
 
-	room, err = s.rooms.UpdateRoom(ctx, updated)
-	if err != nil {
-		err = mapRoomRepoError(err)
-		return
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		persisted, err := s.rooms.UpdateRoom(ctx, updated)
+		if err != nil {
+			return mapRoomRepoError(err)
+		}
 
+		room = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventRoomUpdated,
+			ResourceID: room.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: room.UpdatedAt,
+			Data:       newRoomEventData(room),
+		})
+	})
 	return
 }
 
-// DeleteRoom removes an existing room when requested by an administrator.
+// DeleteRoom removes an existing room when requested by an administrator. When
+// a trash is configured the room is soft-deleted and can be restored until purged.
 func (s *RoomService) DeleteRoom(ctx context.Context, principal Principal, roomID string) error {
 	if s == nil {
 		return fmt.Errorf("RoomService is nil")
@@ -176,8 +235,26 @@ func (s *RoomService) DeleteRoom(ctx context.Context, principal Principal, roomI
 		"room_id", roomID,
 	)
 
-	if err := s.rooms.DeleteRoom(ctx, roomID); err != nil {
-		err = mapRoomRepoError(err)
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if s.trash != nil {
+			err = s.trash.TrashRoom(ctx, roomID, principal.UserID, s.now())
+		} else {
+			err = s.rooms.DeleteRoom(ctx, roomID)
+		}
+		if err != nil {
+			return mapRoomRepoError(err)
+		}
+
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventRoomDeleted,
+			ResourceID: roomID,
+			ActorID:    principal.UserID,
+			OccurredAt: s.now(),
+			Data:       deletedEventData{ID: roomID},
+		})
+	})
+	if err != nil {
 		logger.ErrorContext(ctx, "failed to delete room", "error", err, "error_kind", ErrorKind(err))
 		return err
 	}
@@ -186,6 +263,28 @@ func (s *RoomService) DeleteRoom(ctx context.Context, principal Principal, roomI
 	return nil
 }
 
+// GetRoom returns a single room for any authenticated user.
+func (s *RoomService) GetRoom(ctx context.Context, principal Principal, roomID string) (room Room, err error) {
+	if s == nil {
+		err = fmt.Errorf("RoomService is nil")
+		return
+	}
+	if principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+	if s.rooms == nil {
+		err = fmt.Errorf("room repository not configured")
+		return
+	}
+
+	room, err = s.rooms.GetRoom(ctx, roomID)
+	if err != nil {
+		return Room{}, mapRoomRepoError(err)
+	}
+	return room, nil
+}
+
 // ListRooms returns the catalog of rooms for any authenticated user.
 func (s *RoomService) ListRooms(ctx context.Context, principal Principal) (rooms []Room, err error) {
 	if s == nil {
@@ -226,6 +325,57 @@ func (s *RoomService) ListRooms(ctx context.Context, principal Principal) (rooms
 	return
 }
 
+// ListRoomsPage returns a single page of the room catalog ordered by name.
+func (s *RoomService) ListRoomsPage(ctx context.Context, params ListRoomsParams) (page RoomPage, err error) {
+	if s == nil {
+		err = fmt.Errorf("RoomService is nil")
+		return
+	}
+
+	limit, cursor, vErr := resolvePage(params.Limit, params.Cursor)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+	if s.rooms == nil {
+		return RoomPage{}, nil
+	}
+
+	logger := s.loggerWith(ctx, "ListRoomsPage",
+		"principal_id", params.Principal.UserID,
+		"limit", limit,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list rooms", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(page.Rooms), "has_more", page.NextCursor != "").InfoContext(ctx, "rooms listed")
+	}()
+
+	filter := RoomRepositoryFilter{Limit: limit + 1}
+	if cursor != nil {
+		filter.AfterName = cursor.Key
+		filter.AfterID = cursor.ID
+	}
+
+	var raw []Room
+	raw, err = s.rooms.ListRoomsPage(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	if len(raw) > limit {
+		raw = raw[:limit]
+		last := raw[len(raw)-1]
+		page.NextCursor = encodeCursor(last.Name, last.ID)
+	}
+	page.Rooms = make([]Room, len(raw))
+	copy(page.Rooms, raw)
+
+	return
+}
+
 func validateRoomInput(input RoomInput) *ValidationError {
 	vErr := &ValidationError{}
 
@@ -255,6 +405,9 @@ func mapRoomRepoError(err error) error {
 	if errors.Is(err, persistence.ErrDuplicate) {
 		return ErrAlreadyExists
 	}
+	if errors.Is(err, persistence.ErrVersionConflict) {
+		return ErrPreconditionFailed
+	}
 	if errors.Is(err, persistence.ErrConstraintViolation) {
 		vErr := &ValidationError{}
 		vErr.add("capacity", "capacity must be positive")
@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +26,8 @@ type roomRepoStub struct {
 
 	list    []Room
 	listErr error
+
+	pageFilter RoomRepositoryFilter
 }
 
 func (r *roomRepoStub) CreateRoom(ctx context.Context, room Room) (Room, error) {
@@ -72,6 +76,34 @@ func (r *roomRepoStub) ListRooms(ctx context.Context) ([]Room, error) {
 	return out, nil
 }
 
+func (r *roomRepoStub) ListRoomsPage(ctx context.Context, filter RoomRepositoryFilter) ([]Room, error) {
+	r.pageFilter = filter
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	ordered := make([]Room, len(r.list))
+	copy(ordered, r.list)
+	sort.Slice(ordered, func(i, j int) bool {
+		if strings.EqualFold(ordered[i].Name, ordered[j].Name) {
+			return ordered[i].ID < ordered[j].ID
+		}
+		return strings.ToLower(ordered[i].Name) < strings.ToLower(ordered[j].Name)
+	})
+	after := strings.ToLower(filter.AfterName)
+	out := make([]Room, 0, len(ordered))
+	for _, room := range ordered {
+		name := strings.ToLower(room.Name)
+		if filter.AfterID != "" && (name < after || (name == after && room.ID <= filter.AfterID)) {
+			continue
+		}
+		out = append(out, room)
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
 func TestRoomService_CreateRoom(t *testing.T) {
 	t.Run("requires administrator privileges", func(t *testing.T) {
 		svc := NewRoomService(nil, nil, nil)
@@ -293,6 +325,32 @@ func TestRoomService_UpdateRoom(t *testing.T) {
 			t.Fatalf("expected returned room to include ID, got %q", updated.ID)
 		}
 	})
+
+	t.Run("rejects If-Match values that name an older version", func(t *testing.T) {
+		repo := &roomRepoStub{getRoom: Room{ID: "room-1", Name: "Sakura", Location: "10F", Capacity: 20, Version: 3}}
+		svc := NewRoomService(repo, nil, nil)
+		params := UpdateRoomParams{
+			Principal: Principal{IsAdmin: true},
+			RoomID:    "room-1",
+			Input:     RoomInput{Name: "Maple", Location: "11F", Capacity: 30},
+			IfMatch:   `"2"`,
+		}
+
+		if _, err := svc.UpdateRoom(context.Background(), params); !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+		}
+		if repo.updated.ID != "" {
+			t.Fatalf("expected no update to reach the repository")
+		}
+
+		params.IfMatch = `"2", "3"`
+		if _, err := svc.UpdateRoom(context.Background(), params); err != nil {
+			t.Fatalf("expected the current ETag to be accepted, got %v", err)
+		}
+		if repo.updated.Version != 3 {
+			t.Fatalf("expected the read version to be passed to the repository, got %d", repo.updated.Version)
+		}
+	})
 }
 
 func TestRoomService_DeleteRoom(t *testing.T) {
@@ -368,6 +426,50 @@ func TestRoomService_ListRooms(t *testing.T) {
 	})
 }
 
+func TestRoomService_ListRoomsPage(t *testing.T) {
+	t.Run("pages through rooms in name order", func(t *testing.T) {
+		repo := &roomRepoStub{list: []Room{
+			{ID: "room-2", Name: "Beta"},
+			{ID: "room-3", Name: "alpha"},
+			{ID: "room-1", Name: "Alpha"},
+		}}
+		svc := NewRoomService(repo, nil, nil)
+		principal := Principal{UserID: "user-1"}
+
+		first, err := svc.ListRoomsPage(context.Background(), ListRoomsParams{Principal: principal, Limit: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(first.Rooms) != 2 || first.Rooms[0].ID != "room-1" || first.Rooms[1].ID != "room-3" {
+			t.Fatalf("unexpected first page: %+v", first.Rooms)
+		}
+		if first.NextCursor == "" {
+			t.Fatal("expected next cursor on first page")
+		}
+
+		second, err := svc.ListRoomsPage(context.Background(), ListRoomsParams{Principal: principal, Limit: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(second.Rooms) != 1 || second.Rooms[0].ID != "room-2" {
+			t.Fatalf("unexpected second page: %+v", second.Rooms)
+		}
+		if second.NextCursor != "" {
+			t.Fatalf("expected no cursor on final page, got %q", second.NextCursor)
+		}
+	})
+
+	t.Run("rejects negative limits", func(t *testing.T) {
+		svc := NewRoomService(&roomRepoStub{}, nil, nil)
+
+		_, err := svc.ListRoomsPage(context.Background(), ListRoomsParams{Limit: -1})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+	})
+}
+
 func TestMapRoomRepoError(t *testing.T) {
 	unexpected := errors.New("boom")
 
@@ -379,6 +481,7 @@ func TestMapRoomRepoError(t *testing.T) {
 		"application not found": {err: ErrNotFound, expected: ErrNotFound},
 		"persistence not found": {err: persistence.ErrNotFound, expected: ErrNotFound},
 		"duplicate":             {err: persistence.ErrDuplicate, expected: ErrAlreadyExists},
+		"version conflict":      {err: persistence.ErrVersionConflict, expected: ErrPreconditionFailed},
 		"constraint":            {err: persistence.ErrConstraintViolation, expected: &ValidationError{}},
 		"unexpected":            {err: unexpected, expected: unexpected},
 	}
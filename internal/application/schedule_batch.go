@@ -0,0 +1,227 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxScheduleBatchOperations bounds the number of operations in one batch.
+const MaxScheduleBatchOperations = 500
+
+// ScheduleBatchMode selects how ApplyScheduleBatch treats a failing operation.
+type ScheduleBatchMode string
+
+const (
+	// ScheduleBatchAllOrNothing applies every operation in one transaction and
+	// rolls them all back when one fails.
+	ScheduleBatchAllOrNothing ScheduleBatchMode = "all_or_nothing"
+	// ScheduleBatchBestEffort applies each operation on its own and keeps the
+	// ones that succeed.
+	ScheduleBatchBestEffort ScheduleBatchMode = "best_effort"
+)
+
+// ScheduleBatchOp names the single-schedule call an operation stands for.
+type ScheduleBatchOp string
+
+const (
+	ScheduleBatchCreate ScheduleBatchOp = "create"
+	ScheduleBatchUpdate ScheduleBatchOp = "update"
+	ScheduleBatchDelete ScheduleBatchOp = "delete"
+)
+
+// ScheduleBatchStatus reports what happened to one operation of a batch.
+type ScheduleBatchStatus string
+
+const (
+	// ScheduleBatchApplied operations were written and kept.
+	ScheduleBatchApplied ScheduleBatchStatus = "applied"
+	// ScheduleBatchFailed operations were rejected; Err holds the reason.
+	ScheduleBatchFailed ScheduleBatchStatus = "failed"
+	// ScheduleBatchRolledBack operations succeeded but were undone because a
+	// later operation of an all-or-nothing batch failed.
+	ScheduleBatchRolledBack ScheduleBatchStatus = "rolled_back"
+	// ScheduleBatchSkipped operations were not attempted because an earlier
+	// operation of an all-or-nothing batch failed.
+	ScheduleBatchSkipped ScheduleBatchStatus = "skipped"
+)
+
+// ScheduleBatchOperation is one create, update or delete of a batch. ScheduleID
+// applies to updates and deletes, Input to creates and updates, and IfMatch to
+// updates as in UpdateScheduleParams.
+type ScheduleBatchOperation struct {
+	Op         ScheduleBatchOp
+	ScheduleID string
+	IfMatch    string
+	Input      ScheduleInput
+}
+
+// ScheduleBatchParams carries a batch of schedule operations applied in order
+// on behalf of Principal. An empty Mode means ScheduleBatchAllOrNothing.
+type ScheduleBatchParams struct {
+	Principal  Principal
+	Mode       ScheduleBatchMode
+	Operations []ScheduleBatchOperation
+}
+
+// ScheduleBatchResult is the outcome of the operation at the same index.
+// Schedule is set for applied creates and updates.
+type ScheduleBatchResult struct {
+	Op         ScheduleBatchOp
+	ScheduleID string
+	Status     ScheduleBatchStatus
+	Schedule   *Schedule
+	Warnings   []ConflictWarning
+	Err        error
+}
+
+// ScheduleBatch reports the outcome of ApplyScheduleBatch. Committed is false
+// when an all-or-nothing batch was rolled back.
+type ScheduleBatch struct {
+	Mode      ScheduleBatchMode
+	Committed bool
+	Results   []ScheduleBatchResult
+}
+
+// errBatchAborted rolls back an all-or-nothing batch after a failed operation.
+var errBatchAborted = errors.New("schedule batch aborted")
+
+// ApplyScheduleBatch runs each operation through CreateSchedule, UpdateSchedule
+// or DeleteSchedule in order, so validation, authorization and conflict
+// warnings match single calls. Later operations see the writes of earlier ones.
+// Per-operation failures are reported in the results; the returned error is
+// reserved for a batch that cannot run at all.
+func (s *ScheduleService) ApplyScheduleBatch(ctx context.Context, params ScheduleBatchParams) (batch ScheduleBatch, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+
+	mode := params.Mode
+	if mode == "" {
+		mode = ScheduleBatchAllOrNothing
+	}
+	if vErr := validateScheduleBatch(mode, params.Operations); vErr.HasErrors() {
+		err = vErr
+		return
+	}
+	if mode == ScheduleBatchAllOrNothing && s.unitOfWork == nil {
+		err = fmt.Errorf("unit of work not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "ApplyScheduleBatch",
+		"principal_id", params.Principal.UserID,
+		"mode", string(mode),
+		"operation_count", len(params.Operations),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to apply schedule batch", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		failed := 0
+		for _, result := range batch.Results {
+			if result.Status == ScheduleBatchFailed {
+				failed++
+			}
+		}
+		logger.With("committed", batch.Committed, "failed_count", failed).InfoContext(ctx, "schedule batch applied")
+	}()
+
+	batch = ScheduleBatch{Mode: mode, Results: make([]ScheduleBatchResult, len(params.Operations))}
+	for i, op := range params.Operations {
+		batch.Results[i] = ScheduleBatchResult{Op: op.Op, ScheduleID: strings.TrimSpace(op.ScheduleID), Status: ScheduleBatchSkipped}
+	}
+
+	if mode == ScheduleBatchBestEffort {
+		for i, op := range params.Operations {
+			s.applyBatchOperation(ctx, params.Principal, op, &batch.Results[i])
+		}
+		batch.Committed = true
+		return
+	}
+
+	txErr := s.unitOfWork.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, op := range params.Operations {
+			if !s.applyBatchOperation(ctx, params.Principal, op, &batch.Results[i]) {
+				return errBatchAborted
+			}
+		}
+		return nil
+	})
+	// Conflict lookups inside the transaction may have cached rows that were
+	// never committed
+	if s.warningCache != nil {
+		s.warningCache.Invalidate()
+	}
+	switch {
+	case txErr == nil:
+		batch.Committed = true
+	case errors.Is(txErr, errBatchAborted):
+		for i := range batch.Results {
+			if batch.Results[i].Status == ScheduleBatchApplied {
+				batch.Results[i].Status = ScheduleBatchRolledBack
+				batch.Results[i].Schedule = nil
+			}
+		}
+	default:
+		err = txErr
+	}
+	return
+}
+
+// applyBatchOperation runs op and records its outcome in result, reporting
+// whether it succeeded.
+func (s *ScheduleService) applyBatchOperation(ctx context.Context, principal Principal, op ScheduleBatchOperation, result *ScheduleBatchResult) bool {
+	var (
+		schedule Schedule
+		warnings []ConflictWarning
+		err      error
+	)
+	switch op.Op {
+	case ScheduleBatchCreate:
+		schedule, warnings, err = s.CreateSchedule(ctx, CreateScheduleParams{Principal: principal, Input: op.Input})
+	case ScheduleBatchUpdate:
+		schedule, warnings, err = s.UpdateSchedule(ctx, UpdateScheduleParams{Principal: principal, ScheduleID: result.ScheduleID, Input: op.Input, IfMatch: op.IfMatch})
+	case ScheduleBatchDelete:
+		err = s.DeleteSchedule(ctx, principal, result.ScheduleID)
+	}
+	if err != nil {
+		result.Status = ScheduleBatchFailed
+		result.Err = err
+		return false
+	}
+
+	result.Status = ScheduleBatchApplied
+	result.Warnings = warnings
+	if op.Op != ScheduleBatchDelete {
+		result.ScheduleID = schedule.ID
+		result.Schedule = &schedule
+	}
+	return true
+}
+
+func validateScheduleBatch(mode ScheduleBatchMode, operations []ScheduleBatchOperation) *ValidationError {
+	vErr := &ValidationError{}
+	if mode != ScheduleBatchAllOrNothing && mode != ScheduleBatchBestEffort {
+		vErr.add("mode", "mode must be all_or_nothing or best_effort")
+	}
+	if len(operations) == 0 || len(operations) > MaxScheduleBatchOperations {
+		vErr.add("operations", fmt.Sprintf("operations must include between 1 and %d entries", MaxScheduleBatchOperations))
+	}
+	for i, op := range operations {
+		field := fmt.Sprintf("operations[%d]", i)
+		switch op.Op {
+		case ScheduleBatchCreate:
+		case ScheduleBatchUpdate, ScheduleBatchDelete:
+			if strings.TrimSpace(op.ScheduleID) == "" {
+				vErr.add(field, "schedule_id is required for update and delete")
+			}
+		default:
+			vErr.add(field, "op must be create, update or delete")
+		}
+	}
+	return vErr
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// snapshotUnitOfWork restores the repository contents when fn fails, as a
+// database rollback would.
+type snapshotUnitOfWork struct {
+	repo *filteringScheduleRepo
+}
+
+func (u snapshotUnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := append([]Schedule(nil), u.repo.schedules...)
+	if err := fn(ctx); err != nil {
+		u.repo.schedules = saved
+		return err
+	}
+	return nil
+}
+
+func TestScheduleService_ApplyScheduleBatch(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 6, 3, 1, 0, 0, 0, time.UTC)
+	alice := Principal{UserID: "alice"}
+	onboarding := func(title string, offset time.Duration) ScheduleInput {
+		return ScheduleInput{Title: title, Start: start.Add(offset), End: start.Add(offset + time.Hour), ParticipantIDs: []string{"alice", "bob"}, Busy: true}
+	}
+	newService := func(repo *filteringScheduleRepo) *ScheduleService {
+		ids := 0
+		return NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, func() string {
+			ids++
+			return []string{"sched-a", "sched-b", "sched-c"}[ids-1]
+		}, nil).WithUnitOfWork(snapshotUnitOfWork{repo: repo})
+	}
+
+	t.Run("applies operations in order and reports conflicts with earlier ones", func(t *testing.T) {
+		repo := &filteringScheduleRepo{}
+		batch, err := newService(repo).ApplyScheduleBatch(ctx, ScheduleBatchParams{
+			Principal: alice,
+			Operations: []ScheduleBatchOperation{
+				{Op: ScheduleBatchCreate, Input: onboarding("Orientation", 0)},
+				{Op: ScheduleBatchCreate, Input: onboarding("IT setup", 30*time.Minute)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ApplyScheduleBatch returned error: %v", err)
+		}
+		if !batch.Committed || batch.Mode != ScheduleBatchAllOrNothing || len(repo.schedules) != 2 {
+			t.Fatalf("expected both schedules to be committed, got %+v with %d stored", batch, len(repo.schedules))
+		}
+		second := batch.Results[1]
+		if second.Status != ScheduleBatchApplied || second.ScheduleID != "sched-b" || len(second.Warnings) == 0 || second.Warnings[0].ScheduleID != "sched-a" {
+			t.Fatalf("expected the second schedule to conflict with the first, got %+v", second)
+		}
+	})
+
+	t.Run("rolls back every operation when one fails", func(t *testing.T) {
+		repo := &filteringScheduleRepo{}
+		batch, err := newService(repo).ApplyScheduleBatch(ctx, ScheduleBatchParams{
+			Principal: alice,
+			Mode:      ScheduleBatchAllOrNothing,
+			Operations: []ScheduleBatchOperation{
+				{Op: ScheduleBatchCreate, Input: onboarding("Orientation", 0)},
+				{Op: ScheduleBatchUpdate, ScheduleID: "missing", Input: onboarding("Lunch", 3*time.Hour)},
+				{Op: ScheduleBatchDelete, ScheduleID: "sched-a"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ApplyScheduleBatch returned error: %v", err)
+		}
+		if batch.Committed || len(repo.schedules) != 0 {
+			t.Fatalf("expected nothing to be committed, got %+v with %d stored", batch, len(repo.schedules))
+		}
+		want := []ScheduleBatchStatus{ScheduleBatchRolledBack, ScheduleBatchFailed, ScheduleBatchSkipped}
+		for i, result := range batch.Results {
+			if result.Status != want[i] {
+				t.Fatalf("result %d: expected %s, got %s", i, want[i], result.Status)
+			}
+		}
+		if !errors.Is(batch.Results[1].Err, ErrNotFound) || batch.Results[0].Schedule != nil {
+			t.Fatalf("unexpected results %+v", batch.Results)
+		}
+	})
+
+	t.Run("keeps successful operations in best effort mode", func(t *testing.T) {
+		repo := &filteringScheduleRepo{schedules: []Schedule{{ID: "old", CreatorID: "alice", Start: start, End: start.Add(time.Hour)}}}
+		invalid := onboarding("Broken", 0)
+		invalid.End = invalid.Start.Add(-time.Hour)
+
+		batch, err := newService(repo).ApplyScheduleBatch(ctx, ScheduleBatchParams{
+			Principal: alice,
+			Mode:      ScheduleBatchBestEffort,
+			Operations: []ScheduleBatchOperation{
+				{Op: ScheduleBatchCreate, Input: invalid},
+				{Op: ScheduleBatchDelete, ScheduleID: "old"},
+				{Op: ScheduleBatchCreate, Input: onboarding("Orientation", 0)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ApplyScheduleBatch returned error: %v", err)
+		}
+		var vErr *ValidationError
+		if batch.Results[0].Status != ScheduleBatchFailed || !errors.As(batch.Results[0].Err, &vErr) {
+			t.Fatalf("expected the invalid create to fail validation, got %+v", batch.Results[0])
+		}
+		if batch.Results[1].Status != ScheduleBatchApplied || batch.Results[2].Status != ScheduleBatchApplied {
+			t.Fatalf("expected the other operations to apply, got %+v", batch.Results)
+		}
+		if len(repo.schedules) != 1 || repo.schedules[0].Title != "Orientation" {
+			t.Fatalf("expected only the new schedule to remain, got %+v", repo.schedules)
+		}
+	})
+
+	t.Run("rejects malformed batches before running them", func(t *testing.T) {
+		repo := &filteringScheduleRepo{}
+		_, err := newService(repo).ApplyScheduleBatch(ctx, ScheduleBatchParams{
+			Principal:  alice,
+			Mode:       "sometimes",
+			Operations: []ScheduleBatchOperation{{Op: "move"}, {Op: ScheduleBatchDelete}},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		for _, field := range []string{"mode", "operations[0]", "operations[1]"} {
+			if vErr.FieldErrors[field] == "" {
+				t.Fatalf("expected an error for %s, got %v", field, vErr.FieldErrors)
+			}
+		}
+	})
+}
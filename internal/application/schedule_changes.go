@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// ScheduleChangeLog reads the monotonic log of schedule writes and deletions.
+type ScheduleChangeLog interface {
+	ListScheduleChanges(ctx context.Context, filter ScheduleChangeFilter) ([]ScheduleChange, error)
+}
+
+// WithChangeLog enables delta sync through ListScheduleChanges backed by log,
+// returning the service for chaining.
+func (s *ScheduleService) WithChangeLog(log ScheduleChangeLog) *ScheduleService {
+	if s != nil {
+		s.changes = log
+	}
+	return s
+}
+
+// ListScheduleChanges returns the schedules created or updated since the sync
+// token and tombstones for those deleted or no longer involving the synced
+// participants. Each schedule appears once per page in its current state.
+func (s *ScheduleService) ListScheduleChanges(ctx context.Context, params ListScheduleChangesParams) (changes ScheduleChanges, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.schedules == nil || s.changes == nil {
+		err = fmt.Errorf("schedule change log not configured")
+		return
+	}
+	if params.Principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+
+	users := sortStrings(uniqueStrings(params.ParticipantIDs))
+	if len(users) == 0 {
+		users = []string{params.Principal.UserID}
+	}
+
+	limit, _, vErr := resolvePage(params.Limit, "")
+	after, ok := decodeSyncToken(params.SyncToken)
+	switch {
+	case !ok:
+		vErr.add("since", "sync token is invalid")
+	case after != nil && after.Filter != syncFilterKey(users):
+		vErr.add("since", "sync token does not match participants")
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	var afterSequence int64
+	if after != nil {
+		afterSequence = after.Sequence
+	}
+
+	logger := s.loggerWith(ctx, "ListScheduleChanges",
+		"principal_id", params.Principal.UserID,
+		"participant_filter_count", len(users),
+		"after_sequence", afterSequence,
+		"limit", limit,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list schedule changes", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With(
+			"result_count", len(changes.Schedules),
+			"tombstone_count", len(changes.Tombstones),
+			"has_more", changes.HasMore,
+		).InfoContext(ctx, "schedule changes listed")
+	}()
+
+	entries, err := s.changes.ListScheduleChanges(ctx, ScheduleChangeFilter{
+		UserIDs:       users,
+		AfterSequence: afterSequence,
+		Limit:         limit + 1,
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			// The log never reached the token's sequence, so it was issued
+			// by another database; the client has to sync from scratch
+			vErr.add("since", "sync token is unknown")
+			err = vErr
+			return
+		}
+		err = fmt.Errorf("failed to read schedule changes: %w", err)
+		return
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		changes.HasMore = true
+	}
+
+	// Keep only the newest change per schedule, in the order of those changes.
+	latest := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		latest[entry.ScheduleID] = i
+	}
+
+	involved := make(map[string]struct{}, len(users))
+	for _, id := range users {
+		involved[id] = struct{}{}
+	}
+
+	schedules, err := s.changedSchedules(ctx, entries, latest)
+	if err != nil {
+		return
+	}
+
+	for i, entry := range entries {
+		afterSequence = entry.Sequence
+		if latest[entry.ScheduleID] != i {
+			continue
+		}
+		tombstone := ScheduleTombstone{ScheduleID: entry.ScheduleID, DeletedAt: entry.ChangedAt, Reason: TombstoneDeleted}
+		if entry.Deleted {
+			changes.Tombstones = append(changes.Tombstones, tombstone)
+			continue
+		}
+
+		schedule, ok := schedules[entry.ScheduleID]
+		if !ok {
+			changes.Tombstones = append(changes.Tombstones, tombstone)
+			continue
+		}
+		if !involvesAny(schedule, involved) {
+			tombstone.Reason = TombstoneRemoved
+			changes.Tombstones = append(changes.Tombstones, tombstone)
+			continue
+		}
+		changes.Schedules = append(changes.Schedules, schedule)
+	}
+
+	changes.SyncToken = encodeSyncToken(syncToken{Sequence: afterSequence, Filter: syncFilterKey(users)})
+	return changes, nil
+}
+
+// changedSchedules loads the schedules still live behind the newest change of
+// each schedule in entries with a single repository call, keyed by ID.
+func (s *ScheduleService) changedSchedules(ctx context.Context, entries []ScheduleChange, latest map[string]int) (map[string]Schedule, error) {
+	var ids []string
+	for i, entry := range entries {
+		if latest[entry.ScheduleID] == i && !entry.Deleted {
+			ids = append(ids, entry.ScheduleID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	listed, err := s.schedules.ListSchedules(ctx, ScheduleRepositoryFilter{IDs: ids})
+	if err != nil && !isNotFoundError(err) {
+		return nil, mapScheduleRepoError(err)
+	}
+	schedules := make(map[string]Schedule, len(listed))
+	for _, schedule := range listed {
+		schedules[schedule.ID] = schedule
+	}
+	return schedules, nil
+}
+
+// involvesAny reports whether users includes the schedule's creator or a participant.
+func involvesAny(schedule Schedule, users map[string]struct{}) bool {
+	if _, ok := users[schedule.CreatorID]; ok {
+		return true
+	}
+	for _, id := range schedule.ParticipantIDs {
+		if _, ok := users[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// syncToken is the decoded form of the opaque sync token handed to clients.
+// Filter fingerprints the synced participants so a token is not replayed
+// against a different set, which would silently miss earlier changes.
+type syncToken struct {
+	Sequence int64  `json:"s"`
+	Filter   string `json:"f"`
+}
+
+func encodeSyncToken(token syncToken) string {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeSyncToken returns nil for an empty token, which starts a full sync.
+func decodeSyncToken(raw string) (*syncToken, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, true
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, false
+	}
+	var token syncToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, false
+	}
+	if token.Sequence < 0 || token.Filter == "" {
+		return nil, false
+	}
+	return &token, true
+}
+
+// syncFilterKey fingerprints a sorted participant list.
+func syncFilterKey(users []string) string {
+	hash := fnv.New64a()
+	for _, id := range users {
+		hash.Write([]byte(id))
+		hash.Write([]byte{0})
+	}
+	return strconv.FormatUint(hash.Sum64(), 36)
+}
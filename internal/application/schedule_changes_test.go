@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type changeLogStub struct {
+	changes []ScheduleChange
+	filter  ScheduleChangeFilter
+}
+
+func (c *changeLogStub) ListScheduleChanges(ctx context.Context, filter ScheduleChangeFilter) ([]ScheduleChange, error) {
+	c.filter = filter
+	if n := len(c.changes); filter.AfterSequence > 0 && (n == 0 || filter.AfterSequence > c.changes[n-1].Sequence) {
+		return nil, ErrNotFound
+	}
+	var out []ScheduleChange
+	for _, change := range c.changes {
+		if change.Sequence <= filter.AfterSequence {
+			continue
+		}
+		out = append(out, change)
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// countingScheduleRepo counts the reads issued to a filteringScheduleRepo.
+type countingScheduleRepo struct {
+	*filteringScheduleRepo
+	gets, lists int
+}
+
+func (c *countingScheduleRepo) GetSchedule(ctx context.Context, id string) (Schedule, error) {
+	c.gets++
+	return c.filteringScheduleRepo.GetSchedule(ctx, id)
+}
+
+func (c *countingScheduleRepo) ListSchedules(ctx context.Context, filter ScheduleRepositoryFilter) ([]Schedule, error) {
+	c.lists++
+	return c.filteringScheduleRepo.ListSchedules(ctx, filter)
+}
+
+func TestScheduleService_ListScheduleChanges(t *testing.T) {
+	changedAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := &filteringScheduleRepo{schedules: []Schedule{
+		{ID: "kept", CreatorID: "alice", ParticipantIDs: []string{"bob"}},
+		{ID: "moved", CreatorID: "carol", ParticipantIDs: []string{"carol"}},
+	}}
+	log := &changeLogStub{changes: []ScheduleChange{
+		{Sequence: 1, ScheduleID: "kept", ChangedAt: changedAt},
+		{Sequence: 2, ScheduleID: "moved", ChangedAt: changedAt},
+		{Sequence: 3, ScheduleID: "gone", ChangedAt: changedAt},
+		{Sequence: 4, ScheduleID: "gone", Deleted: true, ChangedAt: changedAt.Add(time.Hour)},
+		{Sequence: 5, ScheduleID: "kept", ChangedAt: changedAt.Add(2 * time.Hour)},
+	}}
+	service := NewScheduleService(repo, nil, nil, nil, nil, nil).WithChangeLog(log)
+	principal := Principal{UserID: "bob"}
+
+	t.Run("returns current schedules and tombstones with a resumable token", func(t *testing.T) {
+		changes, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+		if len(log.filter.UserIDs) != 1 || log.filter.UserIDs[0] != "bob" || log.filter.Limit != DefaultPageSize+1 {
+			t.Fatalf("unexpected change filter: %#v", log.filter)
+		}
+		if len(changes.Schedules) != 1 || changes.Schedules[0].ID != "kept" {
+			t.Fatalf("expected the kept schedule once, got %#v", changes.Schedules)
+		}
+		want := []ScheduleTombstone{
+			{ScheduleID: "moved", DeletedAt: changedAt, Reason: TombstoneRemoved},
+			{ScheduleID: "gone", DeletedAt: changedAt.Add(time.Hour), Reason: TombstoneDeleted},
+		}
+		if len(changes.Tombstones) != len(want) {
+			t.Fatalf("expected tombstones %#v, got %#v", want, changes.Tombstones)
+		}
+		for i := range want {
+			if changes.Tombstones[i] != want[i] {
+				t.Fatalf("expected tombstones %#v, got %#v", want, changes.Tombstones)
+			}
+		}
+		if changes.HasMore || changes.SyncToken == "" {
+			t.Fatalf("unexpected sync state: %#v", changes)
+		}
+
+		next, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, SyncToken: changes.SyncToken})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+		if log.filter.AfterSequence != 5 || len(next.Schedules) != 0 || len(next.Tombstones) != 0 || next.SyncToken != changes.SyncToken {
+			t.Fatalf("expected an empty delta after the token, got %#v (filter %#v)", next, log.filter)
+		}
+	})
+
+	t.Run("loads the changed schedules in one call", func(t *testing.T) {
+		counting := &countingScheduleRepo{filteringScheduleRepo: repo}
+		batched := NewScheduleService(counting, nil, nil, nil, nil, nil).WithChangeLog(log)
+		if _, err := batched.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal}); err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+		if counting.gets != 0 || counting.lists != 1 {
+			t.Fatalf("expected a single batched read, got %d gets and %d lists", counting.gets, counting.lists)
+		}
+	})
+
+	t.Run("pages through the log", func(t *testing.T) {
+		first, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+		if !first.HasMore || len(first.Schedules)+len(first.Tombstones) != 2 {
+			t.Fatalf("expected a full first page, got %#v", first)
+		}
+		second, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, Limit: 2, SyncToken: first.SyncToken})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+		if log.filter.AfterSequence != 2 || !second.HasMore {
+			t.Fatalf("expected the second page to resume after sequence 2, got %#v (filter %#v)", second, log.filter)
+		}
+	})
+
+	t.Run("rejects invalid or mismatched tokens", func(t *testing.T) {
+		changes, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges returned error: %v", err)
+		}
+
+		var vErr *ValidationError
+		_, err = service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, SyncToken: "not-a-token"})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["since"] != "sync token is invalid" {
+			t.Fatalf("expected invalid token error, got %v", err)
+		}
+		_, err = service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, ParticipantIDs: []string{"bob", "carol"}, SyncToken: changes.SyncToken})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["since"] != "sync token does not match participants" {
+			t.Fatalf("expected participant mismatch error, got %v", err)
+		}
+		unknown := encodeSyncToken(syncToken{Sequence: 99, Filter: syncFilterKey([]string{"bob"})})
+		_, err = service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{Principal: principal, SyncToken: unknown})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["since"] != "sync token is unknown" {
+			t.Fatalf("expected unknown token error, got %v", err)
+		}
+	})
+
+	t.Run("requires an authenticated principal", func(t *testing.T) {
+		if _, err := service.ListScheduleChanges(context.Background(), ListScheduleChangesParams{}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxScheduleInstanceWindow bounds the range ListScheduleInstances expands
+// recurring schedules over.
+const maxScheduleInstanceWindow = 366 * 24 * time.Hour
+
+// ListScheduleInstances flattens the schedules visible to the principal into
+// their concrete instances overlapping [Start, End): one-off schedules and each
+// occurrence of recurring ones, sorted by start. Visibility follows
+// ListSchedules, including its group, calendar and organisation filters.
+func (s *ScheduleService) ListScheduleInstances(ctx context.Context, params ListScheduleInstancesParams) (instances []ScheduleInstance, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.schedules == nil {
+		err = fmt.Errorf("schedule repository not configured")
+		return
+	}
+
+	vErr := &ValidationError{}
+	if params.Start.IsZero() {
+		vErr.add("start", "start is required")
+	}
+	if params.End.IsZero() {
+		vErr.add("end", "end is required")
+	}
+	if !params.Start.IsZero() && !params.End.IsZero() {
+		if !params.Start.Before(params.End) {
+			vErr.add("end", "start must be before end")
+		} else if params.End.Sub(params.Start) > maxScheduleInstanceWindow {
+			vErr.add("end", "range must not exceed 366 days")
+		}
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	logger := s.loggerWith(ctx, "ListScheduleInstances",
+		"principal_id", params.Principal.UserID,
+		"participant_filter_count", len(params.ParticipantIDs),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list schedule instances", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(instances)).InfoContext(ctx, "schedule instances listed")
+	}()
+
+	listParams, err := s.resolveListFilters(ctx, ListSchedulesParams{
+		Principal:      params.Principal,
+		ParticipantIDs: params.ParticipantIDs,
+		StartsAfter:    &params.Start,
+		EndsBefore:     &params.End,
+		GroupID:        params.GroupID,
+		CalendarID:     params.CalendarID,
+		UserGroupID:    params.UserGroupID,
+		DepartmentID:   params.DepartmentID,
+		ReportsTo:      params.ReportsTo,
+	})
+	if err != nil {
+		return
+	}
+	schedules, err := s.schedules.ListSchedules(ctx, s.buildListFilter(listParams))
+	if err != nil {
+		if isNotFoundError(err) {
+			err = nil
+		}
+		return
+	}
+
+	// The engine keeps occurrences starting in the range, so expand from a day
+	// earlier to catch those already running when the window opens.
+	from := params.Start.Add(-conflictWindowPadding)
+	listParams.StartsAfter = &from
+	expanded, rulesBySchedule, err := s.expandRecurrenceRules(ctx, schedules, listParams)
+	if err != nil {
+		return
+	}
+
+	callerLoc := locationOrDefault(params.Principal.TimeZone)
+	for _, schedule := range expanded {
+		instances = append(instances, scheduleInstances(schedule, len(rulesBySchedule[schedule.ID]) > 0, params.Start, params.End, callerLoc)...)
+	}
+	sortScheduleInstances(instances, callerLoc)
+	return
+}
+
+// scheduleInstances returns the instances of schedule overlapping [start, end).
+// The base of a recurring schedule is an instance too, listed once when an
+// occurrence repeats it. All-day instances are matched against the
+// window as read on the caller's wall clock.
+func scheduleInstances(schedule Schedule, recurring bool, start, end time.Time, callerLoc *time.Location) []ScheduleInstance {
+	if schedule.AllDay {
+		start, end = floatingWallClock(start, callerLoc), floatingWallClock(end, callerLoc)
+	}
+	series := schedule
+	series.Occurrences = nil
+
+	var instances []ScheduleInstance
+	seen := make(map[string]struct{})
+	add := func(ruleID string, from, to time.Time) {
+		if !from.Before(end) || !to.After(start) {
+			return
+		}
+		id := schedule.ID
+		if recurring {
+			id = scheduleInstanceID(schedule.ID, from, schedule.AllDay)
+		}
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		instances = append(instances, ScheduleInstance{ID: id, RuleID: ruleID, Start: from, End: to, Schedule: series})
+	}
+
+	if recurring {
+		for _, occurrence := range schedule.Occurrences {
+			add(occurrence.RuleID, occurrence.Start, occurrence.End)
+		}
+	}
+	add("", schedule.Start, schedule.End)
+	return instances
+}
+
+// scheduleInstanceID names an occurrence after its series and start, in the
+// form of an iCalendar RECURRENCE-ID.
+func scheduleInstanceID(scheduleID string, start time.Time, allDay bool) string {
+	if allDay {
+		return scheduleID + ":" + start.UTC().Format("20060102")
+	}
+	return scheduleID + ":" + start.UTC().Format("20060102T150405Z")
+}
+
+// sortScheduleInstances orders instances by the instant they start for the
+// caller, anchoring all-day instances to the caller's midnight.
+func sortScheduleInstances(instances []ScheduleInstance, callerLoc *time.Location) {
+	startOf := func(instance ScheduleInstance) time.Time {
+		if instance.Schedule.AllDay {
+			return anchorFloating(instance.Start, callerLoc)
+		}
+		return instance.Start
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := startOf(instances[i]), startOf(instances[j])
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return instances[i].ID < instances[j].ID
+	})
+}
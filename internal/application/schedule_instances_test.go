@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleService_ListScheduleInstances(t *testing.T) {
+	ctx := context.Background()
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 6, day, hour, minute, 0, 0, time.UTC)
+	}
+	repo := &filteringScheduleRepo{schedules: []Schedule{
+		{ID: "standup", CreatorID: "alice", Title: "Standup", Start: at(10, 9, 0), End: at(10, 9, 30), TimeZone: "UTC"},
+		{ID: "review", CreatorID: "bob", Title: "Review", Start: at(11, 13, 0), End: at(11, 14, 0), ParticipantIDs: []string{"alice"}},
+		{ID: "late", CreatorID: "alice", Title: "Late call", Start: at(9, 23, 30), End: at(10, 0, 30)},
+		{ID: "offsite", CreatorID: "alice", Title: "Offsite", Start: at(14, 0, 0), End: at(15, 0, 0), AllDay: true},
+		{ID: "next-week", CreatorID: "alice", Title: "Next week", Start: at(18, 9, 0), End: at(18, 10, 0)},
+		{ID: "private", CreatorID: "carol", Title: "Private", Start: at(12, 9, 0), End: at(12, 10, 0)},
+	}}
+	recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+		"standup": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday", "wednesday"}, StartsOn: at(10, 9, 0)}},
+	}}
+	svc := NewScheduleService(repo, nil, nil, recurrences, nil, func() time.Time { return at(1, 0, 0) })
+	principal := Principal{UserID: "alice", TimeZone: "UTC"}
+
+	instances, err := svc.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal, Start: at(10, 0, 0), End: at(17, 0, 0)})
+	if err != nil {
+		t.Fatalf("ListScheduleInstances returned error: %v", err)
+	}
+
+	want := []string{"late", "standup:20240610T090000Z", "review", "standup:20240612T090000Z", "offsite"}
+	if len(instances) != len(want) {
+		t.Fatalf("expected %d instances, got %+v", len(want), instances)
+	}
+	for i, id := range want {
+		if instances[i].ID != id {
+			t.Fatalf("expected instance %d to be %s, got %s", i, id, instances[i].ID)
+		}
+	}
+	standup := instances[3]
+	if standup.Schedule.ID != "standup" || standup.RuleID != "rule-1" || !standup.Start.Equal(at(12, 9, 0)) || standup.Schedule.Occurrences != nil {
+		t.Fatalf("unexpected occurrence instance: %+v", standup)
+	}
+
+	t.Run("rejects a missing or inverted window", func(t *testing.T) {
+		_, err := svc.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal, Start: at(17, 0, 0), End: at(10, 0, 0)})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["end"] == "" {
+			t.Fatalf("expected an end validation error, got %v", err)
+		}
+		if _, err := svc.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal}); !errors.As(err, &vErr) || vErr.FieldErrors["start"] == "" {
+			t.Fatalf("expected a start validation error, got %v", err)
+		}
+		_, err = svc.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal, Start: at(10, 0, 0), End: at(10, 0, 0).AddDate(1, 0, 2)})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["end"] != "range must not exceed 366 days" {
+			t.Fatalf("expected an oversized window to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("applies the user group filter", func(t *testing.T) {
+		groups := &userGroupDirectoryStub{members: map[string][]string{"grp-1": {"carol"}}}
+		grouped := NewScheduleService(repo, nil, nil, recurrences, nil, func() time.Time { return at(1, 0, 0) }).WithUserGroups(groups)
+
+		instances, err := grouped.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal, Start: at(12, 0, 0), End: at(13, 0, 0), UserGroupID: "grp-1"})
+		if err != nil {
+			t.Fatalf("ListScheduleInstances returned error: %v", err)
+		}
+		if len(instances) != 1 || instances[0].ID != "private" {
+			t.Fatalf("expected the group member's schedule, got %+v", instances)
+		}
+		var vErr *ValidationError
+		_, err = grouped.ListScheduleInstances(ctx, ListScheduleInstancesParams{Principal: principal, Start: at(12, 0, 0), End: at(13, 0, 0), UserGroupID: "grp-9"})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["user_group"] != "user group does not exist" {
+			t.Fatalf("expected an unknown group to be rejected, got %v", err)
+		}
+	})
+}
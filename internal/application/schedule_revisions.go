@@ -0,0 +1,455 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Schedule fields compared between revisions, in the order changes are reported.
+const (
+	RevisionFieldTitle               = "title"
+	RevisionFieldDescription         = "description"
+	RevisionFieldStart               = "start"
+	RevisionFieldEnd                 = "end"
+	RevisionFieldTimeZone            = "time_zone"
+	RevisionFieldAllDay              = "all_day"
+	RevisionFieldBusy                = "busy"
+	RevisionFieldRoomID              = "room_id"
+	RevisionFieldWebConferenceURL    = "web_conference_url"
+	RevisionFieldParticipantIDs      = "participant_ids"
+	RevisionFieldReminderMinutes     = "reminder_minutes"
+	RevisionFieldRecurrence          = "recurrence"
+	RevisionFieldCalendarID          = "calendar_id"
+	RevisionFieldParticipantGroupIDs = "participant_group_ids"
+)
+
+// ScheduleRevisionRecord is a stored revision whose snapshot is still encoded.
+type ScheduleRevisionRecord struct {
+	ScheduleID string
+	Revision   int
+	ChangedBy  string
+	ChangedAt  time.Time
+	Snapshot   []byte
+}
+
+// ScheduleRevisionRepository stores the version history of schedules.
+// AppendScheduleRevision assigns and returns the next revision number and
+// ListScheduleRevisions returns revisions in ascending order.
+type ScheduleRevisionRepository interface {
+	AppendScheduleRevision(ctx context.Context, record ScheduleRevisionRecord) (int, error)
+	ListScheduleRevisions(ctx context.Context, scheduleID string) ([]ScheduleRevisionRecord, error)
+	GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (ScheduleRevisionRecord, error)
+}
+
+// ScheduleSnapshot is the state of a schedule captured by a revision. All-day
+// snapshots hold the same floating bounds as Schedule.
+type ScheduleSnapshot struct {
+	Title               string
+	Description         string
+	Start               time.Time
+	End                 time.Time
+	TimeZone            string
+	AllDay              bool
+	Busy                bool
+	RoomID              *string
+	WebConferenceURL    string
+	ParticipantIDs      []string
+	ReminderMinutes     []int
+	Recurrence          *RecurrenceInput
+	CalendarID          *string
+	ParticipantGroupIDs []string
+}
+
+// ScheduleRevision is one version of a schedule. ChangedFields lists the
+// RevisionField names that differ from Base, the snapshot of BaseRevision;
+// Base is nil for the first revision.
+type ScheduleRevision struct {
+	ScheduleID    string
+	Revision      int
+	ChangedBy     string
+	ChangedAt     time.Time
+	Snapshot      ScheduleSnapshot
+	BaseRevision  int
+	Base          *ScheduleSnapshot
+	ChangedFields []string
+}
+
+// WithRevisions records a revision on every schedule write and enables the
+// revision history, diff and revert operations, returning the service for chaining.
+func (s *ScheduleService) WithRevisions(revisions ScheduleRevisionRepository) *ScheduleService {
+	if s != nil {
+		s.revisions = revisions
+	}
+	return s
+}
+
+// ListScheduleRevisions returns the revisions of a schedule the principal can
+// read, oldest first, each compared with the revision before it.
+func (s *ScheduleService) ListScheduleRevisions(ctx context.Context, principal Principal, scheduleID string) (revisions []ScheduleRevision, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.revisions == nil {
+		err = fmt.Errorf("schedule revisions not configured")
+		return
+	}
+	if err = s.checkRevisionAccess(ctx, principal, scheduleID); err != nil {
+		return
+	}
+
+	records, err := s.revisions.ListScheduleRevisions(ctx, scheduleID)
+	if err != nil {
+		err = mapScheduleRepoError(err)
+		return
+	}
+
+	var base *ScheduleRevision
+	for _, record := range records {
+		var revision ScheduleRevision
+		if revision, err = decodeScheduleRevision(record); err != nil {
+			return
+		}
+		if base != nil {
+			revision = compareScheduleRevisions(revision, *base)
+		}
+		revisions = append(revisions, revision)
+		base = &revision
+	}
+	return
+}
+
+// GetScheduleRevision returns one revision of a schedule the principal can read,
+// compared with compareTo or, when compareTo is zero, with the revision before it.
+func (s *ScheduleService) GetScheduleRevision(ctx context.Context, principal Principal, scheduleID string, revision, compareTo int) (result ScheduleRevision, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.revisions == nil {
+		err = fmt.Errorf("schedule revisions not configured")
+		return
+	}
+	if err = s.checkRevisionAccess(ctx, principal, scheduleID); err != nil {
+		return
+	}
+
+	if result, err = s.loadScheduleRevision(ctx, scheduleID, revision); err != nil {
+		return
+	}
+
+	if compareTo == 0 {
+		if revision == 1 {
+			return
+		}
+		compareTo = revision - 1
+	}
+	base, err := s.loadScheduleRevision(ctx, scheduleID, compareTo)
+	if err != nil {
+		if isNotFoundError(err) {
+			vErr := &ValidationError{}
+			vErr.add("compare_to", "revision does not exist")
+			err = vErr
+		}
+		return
+	}
+	result = compareScheduleRevisions(result, base)
+	return
+}
+
+// RevertSchedule writes the state of an earlier revision back to the schedule.
+// The write goes through UpdateSchedule, so it is validated, checked for
+// conflicts and recorded as a new revision.
+func (s *ScheduleService) RevertSchedule(ctx context.Context, principal Principal, scheduleID string, revision int) (Schedule, []ConflictWarning, error) {
+	if s == nil {
+		return Schedule{}, nil, fmt.Errorf("ScheduleService is nil")
+	}
+	if s.revisions == nil {
+		return Schedule{}, nil, fmt.Errorf("schedule revisions not configured")
+	}
+
+	target, err := s.loadScheduleRevision(ctx, scheduleID, revision)
+	if err != nil {
+		return Schedule{}, nil, err
+	}
+
+	snapshot := target.Snapshot
+	input := ScheduleInput{
+		Title:                snapshot.Title,
+		Description:          snapshot.Description,
+		Start:                snapshot.Start,
+		End:                  snapshot.End,
+		RoomID:               cloneStringPtr(snapshot.RoomID),
+		WebConferenceURL:     snapshot.WebConferenceURL,
+		ParticipantIDs:       append([]string(nil), snapshot.ParticipantIDs...),
+		Recurrence:           snapshot.Recurrence,
+		TimeZone:             snapshot.TimeZone,
+		AllDay:               snapshot.AllDay,
+		Busy:                 snapshot.Busy,
+		ReminderMinutes:      append([]int(nil), snapshot.ReminderMinutes...),
+		CalendarID:           cloneStringPtr(snapshot.CalendarID),
+		ParticipantGroupIDs:  append([]string(nil), snapshot.ParticipantGroupIDs...),
+		ParticipantGroupMode: ParticipantGroupLive,
+	}
+	if snapshot.AllDay {
+		// Inputs name the last day of an all-day schedule, not the day after
+		input.End = snapshot.End.AddDate(0, 0, -1)
+	}
+
+	return s.updateSchedule(ctx, UpdateScheduleParams{
+		Principal:  principal,
+		ScheduleID: scheduleID,
+		Input:      input,
+	}, snapshot.Recurrence == nil)
+}
+
+func (s *ScheduleService) loadScheduleRevision(ctx context.Context, scheduleID string, revision int) (ScheduleRevision, error) {
+	if revision <= 0 {
+		return ScheduleRevision{}, ErrNotFound
+	}
+	record, err := s.revisions.GetScheduleRevision(ctx, scheduleID, revision)
+	if err != nil {
+		return ScheduleRevision{}, mapScheduleRepoError(err)
+	}
+	return decodeScheduleRevision(record)
+}
+
+// recordRevision stores the current state of schedule as its next revision.
+func (s *ScheduleService) recordRevision(ctx context.Context, schedule Schedule, changedBy string, changedAt time.Time) error {
+	if s.revisions == nil {
+		return nil
+	}
+	recurrence, err := s.currentRecurrence(ctx, schedule.ID)
+	if err != nil {
+		return err
+	}
+	snapshot, err := encodeScheduleSnapshot(scheduleSnapshotOf(schedule, recurrence))
+	if err != nil {
+		return err
+	}
+	_, err = s.revisions.AppendScheduleRevision(ctx, ScheduleRevisionRecord{
+		ScheduleID: schedule.ID,
+		ChangedBy:  changedBy,
+		ChangedAt:  changedAt,
+		Snapshot:   snapshot,
+	})
+	return mapScheduleRepoError(err)
+}
+
+// ensureBaselineRevision records the state of a schedule written before
+// revisions were enabled, so its first update still shows where it came from.
+func (s *ScheduleService) ensureBaselineRevision(ctx context.Context, existing Schedule) error {
+	if s.revisions == nil {
+		return nil
+	}
+	records, err := s.revisions.ListScheduleRevisions(ctx, existing.ID)
+	if err != nil {
+		return mapScheduleRepoError(err)
+	}
+	if len(records) > 0 {
+		return nil
+	}
+	return s.recordRevision(ctx, existing, existing.CreatorID, existing.UpdatedAt)
+}
+
+func (s *ScheduleService) currentRecurrence(ctx context.Context, scheduleID string) (*RecurrenceInput, error) {
+	if s.recurrences == nil {
+		return nil, nil
+	}
+	rules, err := s.recurrences.ListRecurrencesForSchedules(ctx, []string{scheduleID})
+	if err != nil {
+		return nil, err
+	}
+	if len(rules[scheduleID]) == 0 {
+		return nil, nil
+	}
+	rule := rules[scheduleID][0]
+	return &RecurrenceInput{
+		Frequency:     rule.Frequency,
+		Weekdays:      append([]string(nil), rule.Weekdays...),
+		Until:         rule.Until,
+		HolidayPolicy: rule.HolidayPolicy,
+	}, nil
+}
+
+func scheduleSnapshotOf(schedule Schedule, recurrence *RecurrenceInput) ScheduleSnapshot {
+	return ScheduleSnapshot{
+		Title:               schedule.Title,
+		Description:         schedule.Description,
+		Start:               schedule.Start.UTC(),
+		End:                 schedule.End.UTC(),
+		TimeZone:            schedule.TimeZone,
+		AllDay:              schedule.AllDay,
+		Busy:                schedule.Busy,
+		RoomID:              cloneStringPtr(schedule.RoomID),
+		WebConferenceURL:    schedule.WebConferenceURL,
+		ParticipantIDs:      sortStrings(schedule.ParticipantIDs),
+		ReminderMinutes:     append([]int(nil), schedule.ReminderMinutes...),
+		Recurrence:          recurrence,
+		CalendarID:          cloneStringPtr(schedule.CalendarID),
+		ParticipantGroupIDs: sortStrings(schedule.ParticipantGroupIDs),
+	}
+}
+
+// compareScheduleRevisions sets the fields of revision that differ from base.
+func compareScheduleRevisions(revision, base ScheduleRevision) ScheduleRevision {
+	a, b := base.Snapshot, revision.Snapshot
+	changed := make([]string, 0)
+	mark := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+	mark(RevisionFieldTitle, a.Title != b.Title)
+	mark(RevisionFieldDescription, a.Description != b.Description)
+	mark(RevisionFieldStart, !a.Start.Equal(b.Start))
+	mark(RevisionFieldEnd, !a.End.Equal(b.End))
+	mark(RevisionFieldTimeZone, a.TimeZone != b.TimeZone)
+	mark(RevisionFieldAllDay, a.AllDay != b.AllDay)
+	mark(RevisionFieldBusy, a.Busy != b.Busy)
+	mark(RevisionFieldRoomID, stringValue(a.RoomID) != stringValue(b.RoomID))
+	mark(RevisionFieldWebConferenceURL, a.WebConferenceURL != b.WebConferenceURL)
+	mark(RevisionFieldParticipantIDs, !slices.Equal(a.ParticipantIDs, b.ParticipantIDs))
+	mark(RevisionFieldReminderMinutes, !slices.Equal(a.ReminderMinutes, b.ReminderMinutes))
+	mark(RevisionFieldRecurrence, !sameRecurrence(a.Recurrence, b.Recurrence))
+	mark(RevisionFieldCalendarID, stringValue(a.CalendarID) != stringValue(b.CalendarID))
+	mark(RevisionFieldParticipantGroupIDs, !slices.Equal(a.ParticipantGroupIDs, b.ParticipantGroupIDs))
+
+	revision.BaseRevision = base.Revision
+	revision.Base = &base.Snapshot
+	revision.ChangedFields = changed
+	return revision
+}
+
+func sameRecurrence(a, b *RecurrenceInput) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Frequency != b.Frequency || a.HolidayPolicy != b.HolidayPolicy || !slices.Equal(a.Weekdays, b.Weekdays) {
+		return false
+	}
+	if a.Until == nil || b.Until == nil {
+		return a.Until == nil && b.Until == nil
+	}
+	return a.Until.Equal(*b.Until)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func cloneStringPtr(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
+
+// storedScheduleSnapshot is the JSON document persisted for each revision.
+type storedScheduleSnapshot struct {
+	Title               string            `json:"title"`
+	Description         string            `json:"description,omitempty"`
+	Start               time.Time         `json:"start"`
+	End                 time.Time         `json:"end"`
+	TimeZone            string            `json:"time_zone,omitempty"`
+	AllDay              bool              `json:"all_day,omitempty"`
+	Busy                bool              `json:"busy,omitempty"`
+	RoomID              *string           `json:"room_id,omitempty"`
+	WebConferenceURL    string            `json:"web_conference_url,omitempty"`
+	ParticipantIDs      []string          `json:"participant_ids,omitempty"`
+	ReminderMinutes     []int             `json:"reminder_minutes,omitempty"`
+	Recurrence          *storedRecurrence `json:"recurrence,omitempty"`
+	CalendarID          *string           `json:"calendar_id,omitempty"`
+	ParticipantGroupIDs []string          `json:"participant_group_ids,omitempty"`
+}
+
+type storedRecurrence struct {
+	Frequency     string     `json:"frequency"`
+	Weekdays      []string   `json:"weekdays,omitempty"`
+	Until         *time.Time `json:"until,omitempty"`
+	HolidayPolicy string     `json:"holiday_policy,omitempty"`
+}
+
+func encodeScheduleSnapshot(snapshot ScheduleSnapshot) ([]byte, error) {
+	stored := storedScheduleSnapshot{
+		Title:               snapshot.Title,
+		Description:         snapshot.Description,
+		Start:               snapshot.Start,
+		End:                 snapshot.End,
+		TimeZone:            snapshot.TimeZone,
+		AllDay:              snapshot.AllDay,
+		Busy:                snapshot.Busy,
+		RoomID:              snapshot.RoomID,
+		WebConferenceURL:    snapshot.WebConferenceURL,
+		ParticipantIDs:      snapshot.ParticipantIDs,
+		ReminderMinutes:     snapshot.ReminderMinutes,
+		CalendarID:          snapshot.CalendarID,
+		ParticipantGroupIDs: snapshot.ParticipantGroupIDs,
+	}
+	if r := snapshot.Recurrence; r != nil {
+		stored.Recurrence = &storedRecurrence{
+			Frequency:     r.Frequency,
+			Weekdays:      r.Weekdays,
+			Until:         r.Until,
+			HolidayPolicy: string(r.HolidayPolicy),
+		}
+	}
+	return json.Marshal(stored)
+}
+
+func decodeScheduleRevision(record ScheduleRevisionRecord) (ScheduleRevision, error) {
+	var stored storedScheduleSnapshot
+	if err := json.Unmarshal(record.Snapshot, &stored); err != nil {
+		return ScheduleRevision{}, fmt.Errorf("decode revision %d of schedule %s: %w", record.Revision, record.ScheduleID, err)
+	}
+	snapshot := ScheduleSnapshot{
+		Title:               stored.Title,
+		Description:         stored.Description,
+		Start:               stored.Start,
+		End:                 stored.End,
+		TimeZone:            stored.TimeZone,
+		AllDay:              stored.AllDay,
+		Busy:                stored.Busy,
+		RoomID:              stored.RoomID,
+		WebConferenceURL:    stored.WebConferenceURL,
+		ParticipantIDs:      stored.ParticipantIDs,
+		ReminderMinutes:     stored.ReminderMinutes,
+		CalendarID:          stored.CalendarID,
+		ParticipantGroupIDs: stored.ParticipantGroupIDs,
+	}
+	if r := stored.Recurrence; r != nil {
+		snapshot.Recurrence = &RecurrenceInput{
+			Frequency:     r.Frequency,
+			Weekdays:      r.Weekdays,
+			Until:         r.Until,
+			HolidayPolicy: HolidayPolicy(r.HolidayPolicy),
+		}
+	}
+	return ScheduleRevision{
+		ScheduleID: record.ScheduleID,
+		Revision:   record.Revision,
+		ChangedBy:  record.ChangedBy,
+		ChangedAt:  record.ChangedAt,
+		Snapshot:   snapshot,
+	}, nil
+}
+
+// checkRevisionAccess allows reading the history of a schedule the principal
+// can read in full; a busy-only view withholds it.
+func (s *ScheduleService) checkRevisionAccess(ctx context.Context, principal Principal, scheduleID string) error {
+	schedule, err := s.GetSchedule(ctx, principal, scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule.BusyOnly {
+		return ErrUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type revisionRepoStub struct {
+	records []ScheduleRevisionRecord
+}
+
+func (r *revisionRepoStub) AppendScheduleRevision(ctx context.Context, record ScheduleRevisionRecord) (int, error) {
+	revision := 1
+	for _, existing := range r.records {
+		if existing.ScheduleID == record.ScheduleID {
+			revision = existing.Revision + 1
+		}
+	}
+	record.Revision = revision
+	r.records = append(r.records, record)
+	return revision, nil
+}
+
+func (r *revisionRepoStub) ListScheduleRevisions(ctx context.Context, scheduleID string) ([]ScheduleRevisionRecord, error) {
+	var out []ScheduleRevisionRecord
+	for _, record := range r.records {
+		if record.ScheduleID == scheduleID {
+			out = append(out, record)
+		}
+	}
+	return out, nil
+}
+
+func (r *revisionRepoStub) GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (ScheduleRevisionRecord, error) {
+	for _, record := range r.records {
+		if record.ScheduleID == scheduleID && record.Revision == revision {
+			return record, nil
+		}
+	}
+	return ScheduleRevisionRecord{}, ErrNotFound
+}
+
+func TestScheduleService_Revisions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	room := "room-1"
+	original := Schedule{ID: "sched-1", CreatorID: "alice", Title: "Planning", ParticipantIDs: []string{"alice", "bob"}, RoomID: &room, Start: now, End: now.Add(time.Hour), TimeZone: "Asia/Tokyo", Busy: true, UpdatedAt: now.Add(-time.Hour)}
+	repo := &filteringScheduleRepo{schedules: []Schedule{original}}
+	revisions := &revisionRepoStub{}
+	service := NewScheduleService(repo, nil, nil, &recurrenceRepoStub{}, nil, func() time.Time { return now }).WithRevisions(revisions)
+	alice := Principal{UserID: "alice"}
+
+	moved := ScheduleInput{Title: "Planning", ParticipantIDs: []string{"alice", "bob"}, Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Busy: true}
+	if _, _, err := service.UpdateSchedule(context.Background(), UpdateScheduleParams{Principal: alice, ScheduleID: "sched-1", Input: moved}); err != nil {
+		t.Fatalf("UpdateSchedule returned error: %v", err)
+	}
+
+	t.Run("records the state before the first update and every update", func(t *testing.T) {
+		history, err := service.ListScheduleRevisions(context.Background(), Principal{UserID: "bob"}, "sched-1")
+		if err != nil {
+			t.Fatalf("ListScheduleRevisions returned error: %v", err)
+		}
+		if len(history) != 2 {
+			t.Fatalf("expected 2 revisions, got %#v", history)
+		}
+		if history[0].Base != nil || history[0].ChangedBy != "alice" || !history[0].Snapshot.Start.Equal(now) {
+			t.Fatalf("unexpected baseline revision: %#v", history[0])
+		}
+		want := []string{RevisionFieldStart, RevisionFieldEnd, RevisionFieldRoomID}
+		if history[1].BaseRevision != 1 || !slices.Equal(history[1].ChangedFields, want) {
+			t.Fatalf("expected %v changed against revision 1, got %#v", want, history[1])
+		}
+	})
+
+	t.Run("hides revisions from users who cannot see the schedule", func(t *testing.T) {
+		if _, err := service.ListScheduleRevisions(context.Background(), Principal{UserID: "carol"}, "sched-1"); !errors.Is(err, ErrUnauthorized) && !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected the schedule to be hidden, got %v", err)
+		}
+	})
+
+	t.Run("compares with a chosen revision", func(t *testing.T) {
+		_, err := service.GetScheduleRevision(context.Background(), alice, "sched-1", 2, 5)
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["compare_to"] == "" {
+			t.Fatalf("expected compare_to validation error, got %v", err)
+		}
+		if _, err := service.GetScheduleRevision(context.Background(), alice, "sched-1", 7, 0); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("reverts through a regular update with conflict detection", func(t *testing.T) {
+		repo.schedules = append(repo.schedules, Schedule{ID: "sched-2", CreatorID: "dave", ParticipantIDs: []string{"dave"}, RoomID: &room, Start: now, End: now.Add(time.Hour), Busy: true})
+
+		schedule, warnings, err := service.RevertSchedule(context.Background(), alice, "sched-1", 1)
+		if err != nil {
+			t.Fatalf("RevertSchedule returned error: %v", err)
+		}
+		if !schedule.Start.Equal(now) || schedule.RoomID == nil || *schedule.RoomID != room {
+			t.Fatalf("expected the original time and room back, got %#v", schedule)
+		}
+		if len(warnings) != 1 || warnings[0].ScheduleID != "sched-2" {
+			t.Fatalf("expected a room conflict with sched-2, got %#v", warnings)
+		}
+
+		latest, err := service.GetScheduleRevision(context.Background(), alice, "sched-1", 3, 1)
+		if err != nil {
+			t.Fatalf("GetScheduleRevision returned error: %v", err)
+		}
+		if len(latest.ChangedFields) != 0 {
+			t.Fatalf("expected revision 3 to match revision 1, got %v", latest.ChangedFields)
+		}
+	})
+
+	t.Run("rejects reverts by other users", func(t *testing.T) {
+		if _, _, err := service.RevertSchedule(context.Background(), Principal{UserID: "carol"}, "sched-1", 2); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
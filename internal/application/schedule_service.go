@@ -26,10 +26,26 @@ type ScheduleRepository interface {
 }
 
 // ScheduleRepositoryFilter narrows queries issued to the schedule repository.
+// Results are ordered by start time then ID; when AfterStart is set only rows
+// strictly after the (AfterStart, AfterID) key are returned. A zero Limit means unbounded.
+// AllDayStartsAfter and AllDayEndsBefore bound all-day rows using floating
+// wall-clock times; when nil, StartsAfter and EndsBefore apply to every row.
+// IDs, when set, restricts results to those schedules.
 type ScheduleRepositoryFilter struct {
-	ParticipantIDs []string
-	StartsAfter    *time.Time
-	EndsBefore     *time.Time
+	IDs               []string
+	ParticipantIDs    []string
+	StartsAfter       *time.Time
+	EndsBefore        *time.Time
+	AllDayStartsAfter *time.Time
+	AllDayEndsBefore  *time.Time
+	RoomID            *string
+	CalendarID        string
+	CreatorID         string
+	TitlePrefix       string
+	UpdatedSince      *time.Time
+	AfterStart        *time.Time
+	AfterID           string
+	Limit             int
 }
 
 // UserDirectory exposes user lookup operations.
@@ -51,23 +67,38 @@ type RecurrenceRepository interface {
 
 // RecurrenceRule represents a persisted recurrence rule.
 type RecurrenceRule struct {
-	ID        string
-	Frequency string
-	Weekdays  []string
-	Until     *time.Time
-	StartsOn  time.Time
+	ID            string
+	Frequency     string
+	Weekdays      []string
+	Until         *time.Time
+	StartsOn      time.Time
+	HolidayPolicy HolidayPolicy
 }
 
 // ScheduleService orchestrates validation and persistence for schedule operations.
 type ScheduleService struct {
-	schedules    ScheduleRepository
-	users        UserDirectory
-	rooms        RoomCatalog
-	recurrences  RecurrenceRepository
-	warningCache *warningCache
-	idGenerator  func() string
-	now          func() time.Time
-	logger       *slog.Logger
+	schedules         ScheduleRepository
+	users             UserDirectory
+	rooms             RoomCatalog
+	recurrences       RecurrenceRepository
+	availability      AvailabilityDirectory
+	holidays          HolidayDirectory
+	events            EventPublisher
+	changes           ScheduleChangeLog
+	trash             ScheduleTrash
+	revisions         ScheduleRevisionRepository
+	conflicts         ConflictCandidateRepository
+	occurrences       OccurrenceIndex
+	occurrenceHorizon time.Duration
+	groups            ColleagueGroupDirectory
+	calendars         CalendarDirectory
+	userGroups        UserGroupDirectory
+	org               OrgDirectory
+	unitOfWork        UnitOfWork
+	warningCache      *warningCache
+	idGenerator       func() string
+	now               func() time.Time
+	logger            *slog.Logger
 }
 
 // NewScheduleService wires dependencies for schedule operations.
@@ -95,6 +126,33 @@ func NewScheduleServiceWithLogger(schedules ScheduleRepository, users UserDirect
 	}
 }
 
+// WithAvailability enables working-hours and out-of-office checks backed by
+// directory, returning the service for chaining.
+func (s *ScheduleService) WithAvailability(directory AvailabilityDirectory) *ScheduleService {
+	if s != nil {
+		s.availability = directory
+	}
+	return s
+}
+
+// WithHolidays lets recurrence rules skip or shift occurrences on the holidays
+// reported by directory, returning the service for chaining.
+func (s *ScheduleService) WithHolidays(directory HolidayDirectory) *ScheduleService {
+	if s != nil {
+		s.holidays = directory
+	}
+	return s
+}
+
+// WithEvents publishes schedule.created, schedule.updated and schedule.deleted
+// events through publisher, returning the service for chaining.
+func (s *ScheduleService) WithEvents(publisher EventPublisher) *ScheduleService {
+	if s != nil {
+		s.events = publisher
+	}
+	return s
+}
+
 func (s *ScheduleService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
 	return serviceLogger(ctx, s.logger, "ScheduleService", operation, attrs...)
 }
@@ -105,7 +163,7 @@ func (s *ScheduleService) CreateSchedule(ctx context.Context, params CreateSched
 		err = fmt.Errorf("ScheduleService is nil")
 		return
 	}
-	input := params.Input
+	input := normalizeAllDayInput(params.Input)
 	principal := params.Principal
 
 	if input.CreatorID == "" {
@@ -132,6 +190,10 @@ func (s *ScheduleService) CreateSchedule(ctx context.Context, params CreateSched
 		return
 	}
 
+	if input, err = s.resolveParticipantGroups(ctx, input); err != nil {
+		return
+	}
+
 	vErr := &ValidationError{}
 	validateScheduleCore(input, vErr)
 	if vErr.HasErrors() {
@@ -147,55 +209,94 @@ func (s *ScheduleService) CreateSchedule(ctx context.Context, params CreateSched
 		return
 	}
 
+	if err = s.ensureCalendarEditable(ctx, principal, input.CalendarID); err != nil {
+		return
+	}
+
 	createdAt := s.now()
 	schedule = Schedule{
-		ID:               s.idGenerator(),
-		CreatorID:        input.CreatorID,
-		Title:            strings.TrimSpace(input.Title),
-		Description:      input.Description,
-		Start:            input.Start,
-		End:              input.End,
-		RoomID:           input.RoomID,
-		WebConferenceURL: input.WebConferenceURL,
-		ParticipantIDs:   sortStrings(uniqueStrings(input.ParticipantIDs)),
-		CreatedAt:        createdAt,
-		UpdatedAt:        createdAt,
+		ID:                  s.idGenerator(),
+		CreatorID:           input.CreatorID,
+		Title:               strings.TrimSpace(input.Title),
+		Description:         input.Description,
+		Start:               input.Start.UTC(),
+		End:                 input.End.UTC(),
+		TimeZone:            resolveTimeZone(input.TimeZone, principal.TimeZone),
+		AllDay:              input.AllDay,
+		Busy:                input.Busy,
+		RoomID:              input.RoomID,
+		WebConferenceURL:    input.WebConferenceURL,
+		ParticipantIDs:      sortStrings(uniqueStrings(input.ParticipantIDs)),
+		ReminderMinutes:     normalizeReminderMinutes(input.ReminderMinutes),
+		CalendarID:          input.CalendarID,
+		ParticipantGroupIDs: input.ParticipantGroupIDs,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
 	}
 
 	if s.schedules == nil {
 		return
 	}
 
-	warnings, err = s.detectConflicts(ctx, schedule)
+	var availabilityWarnings []ConflictWarning
+	schedule, availabilityWarnings, err = s.checkAvailability(ctx, schedule, nil)
 	if err != nil {
 		return
 	}
 
-	var persisted Schedule
-	persisted, err = s.schedules.CreateSchedule(ctx, schedule)
+	var rules []RecurrenceRule
+	if rules, err = s.candidateRecurrenceRules(ctx, schedule, input.Recurrence, false); err != nil {
+		return
+	}
+	warnings, err = s.detectConflicts(ctx, schedule, rules)
 	if err != nil {
-		err = mapScheduleRepoError(err)
 		return
 	}
+	warnings = append(warnings, availabilityWarnings...)
 
-	if s.warningCache != nil {
-		s.warningCache.Invalidate()
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		persisted, err := s.schedules.CreateSchedule(ctx, schedule)
+		if err != nil {
+			return mapScheduleRepoError(err)
+		}
 
-	if input.Recurrence != nil && s.recurrences != nil {
-		if err = s.recurrences.SaveRecurrence(ctx, persisted.ID, persisted.Start, *input.Recurrence); err != nil {
-			// In a real application, we might want to roll back the schedule creation.
-			// For MVP, we'll just return the error.
-			return
+		if input.Recurrence != nil && s.recurrences != nil {
+			if err := s.recurrences.SaveRecurrence(ctx, persisted.ID, persisted.Start, *input.Recurrence); err != nil {
+				return err
+			}
+			if err := s.markOccurrencesStale(ctx, persisted.ID); err != nil {
+				return err
+			}
 		}
-	}
 
-	schedule = persisted
+		if err := s.recordRevision(ctx, persisted, principal.UserID, persisted.CreatedAt); err != nil {
+			return err
+		}
+
+		schedule = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventScheduleCreated,
+			ResourceID: schedule.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: schedule.CreatedAt,
+			Data:       newScheduleEventData(schedule),
+		})
+	})
+
+	if s.warningCache != nil {
+		s.warningCache.Invalidate()
+	}
 	return
 }
 
 // UpdateSchedule applies validation and authorization before updating persistence state.
-func (s *ScheduleService) UpdateSchedule(ctx context.Context, params UpdateScheduleParams) (schedule Schedule, warnings []ConflictWarning, err error) {
+func (s *ScheduleService) UpdateSchedule(ctx context.Context, params UpdateScheduleParams) (Schedule, []ConflictWarning, error) {
+	return s.updateSchedule(ctx, params, false)
+}
+
+// updateSchedule implements UpdateSchedule. clearRecurrence removes an existing
+// recurrence rule when the input carries none.
+func (s *ScheduleService) updateSchedule(ctx context.Context, params UpdateScheduleParams, clearRecurrence bool) (schedule Schedule, warnings []ConflictWarning, err error) {
 	if s == nil {
 		err = fmt.Errorf("ScheduleService is nil")
 		return
@@ -214,7 +315,7 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, params UpdateSched
 	}
 
 	principal := params.Principal
-	input := params.Input
+	input := normalizeAllDayInput(params.Input)
 
 	logger := s.loggerWith(ctx, "UpdateSchedule",
 		"principal_id", principal.UserID,
@@ -232,11 +333,23 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, params UpdateSched
 		).InfoContext(ctx, "schedule updated")
 	}()
 
-	if existing.CreatorID != principal.UserID && !principal.IsAdmin {
+	var editable bool
+	if editable, err = s.canEditSchedule(ctx, principal, existing); err != nil {
+		return
+	}
+	if !editable {
 		err = ErrUnauthorized
 		return
 	}
 
+	if err = checkIfMatch(params.IfMatch, existing.Version); err != nil {
+		return
+	}
+
+	if input, err = s.resolveParticipantGroups(ctx, input); err != nil {
+		return
+	}
+
 	vErr := &ValidationError{}
 	if input.CreatorID != "" && input.CreatorID != existing.CreatorID {
 		vErr.add("creator_id", "creator cannot be changed")
@@ -255,51 +368,97 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, params UpdateSched
 		return
 	}
 
+	if stringValue(input.CalendarID) != stringValue(existing.CalendarID) {
+		if err = s.ensureCalendarEditable(ctx, principal, input.CalendarID); err != nil {
+			return
+		}
+	}
+
 	updated := existing
 	updated.Title = strings.TrimSpace(input.Title)
 	updated.Description = input.Description
-	updated.Start = input.Start
-	updated.End = input.End
+	updated.Start = input.Start.UTC()
+	updated.End = input.End.UTC()
+	updated.TimeZone = resolveTimeZone(input.TimeZone, existing.TimeZone)
+	updated.AllDay = input.AllDay
+	updated.Busy = input.Busy
 	updated.RoomID = input.RoomID
 	updated.WebConferenceURL = input.WebConferenceURL
 	updated.ParticipantIDs = sortStrings(uniqueStrings(input.ParticipantIDs))
+	updated.ReminderMinutes = normalizeReminderMinutes(input.ReminderMinutes)
+	updated.CalendarID = input.CalendarID
+	updated.ParticipantGroupIDs = input.ParticipantGroupIDs
 	updated.UpdatedAt = s.now()
 
-	cleanupNeeded := needsRecurrenceCleanup(existing, updated, input.Recurrence)
-
-	warnings, err = s.detectConflicts(ctx, updated)
+	var availabilityWarnings []ConflictWarning
+	updated, availabilityWarnings, err = s.checkAvailability(ctx, updated, existing.ParticipantIDs)
 	if err != nil {
 		return
 	}
 
-	var persisted Schedule
-	persisted, err = s.schedules.UpdateSchedule(ctx, updated)
+	cleanupNeeded := clearRecurrence || needsRecurrenceCleanup(existing, updated, input.Recurrence)
+
+	var rules []RecurrenceRule
+	if rules, err = s.candidateRecurrenceRules(ctx, updated, input.Recurrence, !cleanupNeeded); err != nil {
+		return
+	}
+	warnings, err = s.detectConflicts(ctx, updated, rules)
 	if err != nil {
-		err = mapScheduleRepoError(err)
 		return
 	}
+	warnings = append(warnings, availabilityWarnings...)
 
-	if s.warningCache != nil {
-		s.warningCache.Invalidate()
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensureBaselineRevision(ctx, existing); err != nil {
+			return err
+		}
 
-	if cleanupNeeded && s.recurrences != nil {
-		if err = s.recurrences.DeleteRecurrencesForSchedule(ctx, persisted.ID); err != nil {
-			return
+		persisted, err := s.schedules.UpdateSchedule(ctx, updated)
+		if err != nil {
+			return mapScheduleRepoError(err)
 		}
-	}
 
-	if input.Recurrence != nil && s.recurrences != nil {
-		if err = s.recurrences.SaveRecurrence(ctx, persisted.ID, persisted.Start, *input.Recurrence); err != nil {
-			return
+		if cleanupNeeded && s.recurrences != nil {
+			if err := s.recurrences.DeleteRecurrencesForSchedule(ctx, persisted.ID); err != nil {
+				return err
+			}
 		}
-	}
 
-	schedule = persisted
+		if input.Recurrence != nil && s.recurrences != nil {
+			if err := s.recurrences.SaveRecurrence(ctx, persisted.ID, persisted.Start, *input.Recurrence); err != nil {
+				return err
+			}
+		}
+
+		// Occurrences follow the rule, the times and the zone they expand in.
+		if cleanupNeeded || input.Recurrence != nil || persisted.TimeZone != existing.TimeZone {
+			if err := s.markOccurrencesStale(ctx, persisted.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := s.recordRevision(ctx, persisted, principal.UserID, persisted.UpdatedAt); err != nil {
+			return err
+		}
+
+		schedule = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventScheduleUpdated,
+			ResourceID: schedule.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: schedule.UpdatedAt,
+			Data:       newScheduleUpdatedEventData(schedule, existing),
+		})
+	})
+
+	if s.warningCache != nil {
+		s.warningCache.Invalidate()
+	}
 	return
 }
 
-// DeleteSchedule ensures authorization before delegating to persistence.
+// DeleteSchedule ensures authorization before delegating to persistence. When
+// a trash is configured the schedule is soft-deleted and can be restored until purged.
 func (s *ScheduleService) DeleteSchedule(ctx context.Context, principal Principal, scheduleID string) error {
 	if s == nil {
 		return fmt.Errorf("ScheduleService is nil")
@@ -320,28 +479,91 @@ func (s *ScheduleService) DeleteSchedule(ctx context.Context, principal Principa
 		return err
 	}
 
-	if existing.CreatorID != principal.UserID && !principal.IsAdmin {
+	editable, err := s.canEditSchedule(ctx, principal, existing)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to check schedule access", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	if !editable {
 		return ErrUnauthorized
 	}
 
-	if err := s.schedules.DeleteSchedule(ctx, scheduleID); err != nil {
-		err = mapScheduleRepoError(err)
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if s.trash != nil {
+			// Recurrences stay with the trashed schedule so a restore brings them back
+			err = s.trash.TrashSchedule(ctx, scheduleID, principal.UserID, s.now())
+		} else {
+			err = s.schedules.DeleteSchedule(ctx, scheduleID)
+		}
+		if err != nil {
+			return mapScheduleRepoError(err)
+		}
+
+		if s.recurrences != nil && s.trash == nil {
+			if err := s.recurrences.DeleteRecurrencesForSchedule(ctx, scheduleID); err != nil {
+				return fmt.Errorf("failed to cleanup recurrences: %w", err)
+			}
+		}
+		if s.trash != nil {
+			if err := s.markOccurrencesStale(ctx, scheduleID); err != nil {
+				return err
+			}
+		}
+
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventScheduleDeleted,
+			ResourceID: scheduleID,
+			ActorID:    principal.UserID,
+			OccurredAt: s.now(),
+			Data:       newScheduleEventData(existing),
+		})
+	})
+
+	if s.warningCache != nil {
+		s.warningCache.Invalidate()
+	}
+	if err != nil {
 		logger.ErrorContext(ctx, "failed to delete schedule", "error", err, "error_kind", ErrorKind(err))
 		return err
 	}
+	logger.InfoContext(ctx, "schedule deleted")
+	return nil
+}
 
-	if s.warningCache != nil {
-		s.warningCache.Invalidate()
+// GetSchedule returns a schedule the principal created or takes part in, or
+// one on a calendar the principal holds a role on. Administrators may read
+// any schedule. Managers of anyone taking part get a busy-only view.
+func (s *ScheduleService) GetSchedule(ctx context.Context, principal Principal, scheduleID string) (schedule Schedule, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.schedules == nil {
+		err = fmt.Errorf("schedule repository not configured")
+		return
 	}
 
-	if s.recurrences != nil {
-		if err := s.recurrences.DeleteRecurrencesForSchedule(ctx, scheduleID); err != nil {
-			logger.ErrorContext(ctx, "failed to cleanup recurrences", "error", err, "error_kind", ErrorKind(err))
-			return err
+	schedule, err = s.schedules.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		err = mapScheduleRepoError(err)
+		return
+	}
+	visible, err := s.canViewSchedule(ctx, principal, schedule)
+	if err != nil {
+		return Schedule{}, err
+	}
+	if !visible {
+		view, ok, err := s.busyOnlyView(ctx, principal, schedule)
+		if err != nil {
+			return Schedule{}, err
 		}
+		if !ok {
+			return Schedule{}, ErrUnauthorized
+		}
+		return view, nil
 	}
-	logger.InfoContext(ctx, "schedule deleted")
-	return nil
+	return schedule, nil
 }
 
 // ListSchedules enumerates schedules visible to the requesting principal.
@@ -371,7 +593,113 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, params ListSchedule
 		).InfoContext(ctx, "schedules listed")
 	}()
 
+	if params, err = s.resolveListFilters(ctx, params); err != nil {
+		return
+	}
+	schedules, warnings, _, err = s.listSchedules(ctx, params, 0, nil)
+	return
+}
+
+// ListSchedulesPage enumerates a single page of schedules visible to the requesting principal.
+// Pages are ordered by start time then ID; NextCursor is empty on the final page.
+func (s *ScheduleService) ListSchedulesPage(ctx context.Context, params ListSchedulesParams) (page SchedulePage, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.schedules == nil {
+		err = fmt.Errorf("schedule repository not configured")
+		return
+	}
+
+	limit, cursor, vErr := resolvePage(params.Limit, params.Cursor)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	logger := s.loggerWith(ctx, "ListSchedulesPage",
+		"principal_id", params.Principal.UserID,
+		"participant_filter_count", len(params.ParticipantIDs),
+		"period", string(params.Period),
+		"limit", limit,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list schedules", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With(
+			"result_count", len(page.Schedules),
+			"warning_count", len(page.Warnings),
+			"has_more", page.NextCursor != "",
+		).InfoContext(ctx, "schedules listed")
+	}()
+
+	if params, err = s.resolveListFilters(ctx, params); err != nil {
+		return
+	}
+	page.Schedules, page.Warnings, page.NextCursor, err = s.listSchedules(ctx, params, limit, cursor)
+	if err != nil || cursor != nil {
+		return
+	}
+	page.OutOfOffice, err = s.listOutOfOffice(ctx, params)
+	return
+}
+
+// resolveListFilters expands the colleague group, user group and organisation
+// filters into participants and checks access to the calendar filter.
+func (s *ScheduleService) resolveListFilters(ctx context.Context, params ListSchedulesParams) (ListSchedulesParams, error) {
+	var err error
+	if params, err = s.resolveColleagueGroup(ctx, params); err != nil {
+		return params, err
+	}
+	if params, err = s.resolveUserGroupFilter(ctx, params); err != nil {
+		return params, err
+	}
+	if params, err = s.resolveOrgFilter(ctx, params); err != nil {
+		return params, err
+	}
+	return params, s.resolveCalendarFilter(ctx, params)
+}
+
+// listOutOfOffice returns the absences of the listed participants that overlap
+// the listing range so calendars can render them alongside schedules.
+func (s *ScheduleService) listOutOfOffice(ctx context.Context, params ListSchedulesParams) ([]OutOfOffice, error) {
+	if s.availability == nil {
+		return nil, nil
+	}
+	filter := s.buildListFilter(params)
+	profiles, err := s.availability.ParticipantAvailability(ctx, filter.ParticipantIDs, filter.StartsAfter, filter.EndsBefore)
+	if err != nil {
+		return nil, err
+	}
+	var entries []OutOfOffice
+	for _, profile := range profiles {
+		entries = append(entries, profile.OutOfOffice...)
+	}
+	sortOutOfOffice(entries)
+	return entries, nil
+}
+
+// listSchedules runs the shared listing pipeline. A zero limit disables pagination.
+func (s *ScheduleService) listSchedules(ctx context.Context, params ListSchedulesParams, limit int, cursor *pageCursor) (schedules []Schedule, warnings []ConflictWarning, nextCursor string, err error) {
 	filter := s.buildListFilter(params)
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+	if cursor != nil {
+		afterStart, parseErr := time.Parse(time.RFC3339, cursor.Key)
+		if parseErr != nil {
+			vErr := &ValidationError{}
+			vErr.add("cursor", "cursor is invalid")
+			err = vErr
+			return
+		}
+		filter.AfterStart = &afterStart
+		filter.AfterID = cursor.ID
+	}
+
 	cacheKey := ""
 	if s.warningCache != nil {
 		cacheKey = buildWarningCacheKey(params, filter)
@@ -382,9 +710,6 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, params ListSchedule
 	if err != nil {
 		if isNotFoundError(err) {
 			err = nil
-			schedules = nil
-			warnings = nil
-			return
 		}
 		return
 	}
@@ -398,9 +723,18 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, params ListSchedule
 		return ordered[i].Start.Before(ordered[j].Start)
 	})
 
-	schedules, err = s.expandRecurrences(ctx, ordered, params)
+	if limit > 0 && len(ordered) > limit {
+		ordered = ordered[:limit]
+		last := ordered[len(ordered)-1]
+		nextCursor = encodeCursor(last.Start.UTC().Format(time.RFC3339), last.ID)
+	}
+
+	// Period listings carry their window in the filter rather than the params.
+	expandParams := params
+	expandParams.StartsAfter, expandParams.EndsBefore = filter.StartsAfter, filter.EndsBefore
+	schedules, err = s.expandRecurrences(ctx, ordered, expandParams)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 	if cacheKey != "" {
 		if cached, ok := s.warningCache.Get(cacheKey); ok {
@@ -409,7 +743,12 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, params ListSchedule
 		}
 	}
 
-	warnings = detectListConflicts(schedules)
+	// Members of live participant groups conflict like direct participants.
+	var withMembers []Schedule
+	if withMembers, err = withParticipantGroupMembers(ctx, s.userGroups, schedules); err != nil {
+		return nil, nil, "", err
+	}
+	warnings = detectListConflicts(withMembers)
 	if cacheKey != "" {
 		s.warningCache.Store(cacheKey, warnings)
 	}
@@ -417,8 +756,16 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, params ListSchedule
 }
 
 func (s *ScheduleService) expandRecurrences(ctx context.Context, schedules []Schedule, params ListSchedulesParams) ([]Schedule, error) {
+	expanded, _, err := s.expandRecurrenceRules(ctx, schedules, params)
+	return expanded, err
+}
+
+// expandRecurrenceRules expands recurring schedules like expandRecurrences and
+// also returns the rules of each, so callers can tell recurring schedules
+// without an occurrence in range from one-off schedules.
+func (s *ScheduleService) expandRecurrenceRules(ctx context.Context, schedules []Schedule, params ListSchedulesParams) ([]Schedule, map[string][]RecurrenceRule, error) {
 	if s.recurrences == nil || len(schedules) == 0 {
-		return schedules, nil
+		return schedules, nil, nil
 	}
 
 	scheduleIDs := make([]string, len(schedules))
@@ -428,14 +775,33 @@ func (s *ScheduleService) expandRecurrences(ctx context.Context, schedules []Sch
 
 	rulesBySchedule, err := s.recurrences.ListRecurrencesForSchedules(ctx, scheduleIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(rulesBySchedule) == 0 {
-		return schedules, nil
+		return schedules, nil, nil
+	}
+
+	from, to := params.StartsAfter, params.EndsBefore
+	indexed, err := s.indexedOccurrences(ctx, rulesBySchedule, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if to == nil && s.occurrences != nil {
+		// Schedules missing from the index are generated up to the horizon
+		// it is kept to, so unbounded listings do not fail.
+		horizonEnd := s.now().Add(s.occurrenceHorizon)
+		to = &horizonEnd
 	}
 
-	engine := recurrence.NewEngine(nil) // Assuming JST
 	expanded := make([]Schedule, len(schedules))
+	callerLoc := locationOrDefault(params.Principal.TimeZone)
+
+	var holidays recurrence.HolidayCalendar
+	if s.holidays != nil && usesHolidayPolicy(rulesBySchedule) {
+		if holidays, err = s.holidays.HolidayCalendar(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	for i, schedule := range schedules {
 		rules := rulesBySchedule[schedule.ID]
@@ -444,44 +810,87 @@ func (s *ScheduleService) expandRecurrences(ctx context.Context, schedules []Sch
 			continue
 		}
 
-		var occurrences []ScheduleOccurrence
-		for _, rule := range rules {
-			opts := recurrence.GenerateOptions{
-				RangeStart: params.StartsAfter,
-				RangeEnd:   params.EndsBefore,
-			}
-			generated, err := engine.GenerateOccurrences(toRecurrenceRule(rule), schedule.Start, schedule.End, opts)
-			if err != nil {
-				return nil, err
-			}
-			for _, occ := range generated {
-				occurrences = append(occurrences, ScheduleOccurrence{
-					ScheduleID: occ.ScheduleID,
-					RuleID:     occ.RuleID,
-					Start:      occ.Start,
-					End:        occ.End,
-				})
-			}
+		if occurrences, ok := indexed[schedule.ID]; ok {
+			schedule.Occurrences = occurrencesInRange(occurrences, schedule.AllDay, from, params.EndsBefore, callerLoc)
+			expanded[i] = schedule
+			continue
+		}
+
+		occurrences, err := generateOccurrences(schedule, rules, from, to, callerLoc, holidays)
+		if err != nil {
+			return nil, nil, err
 		}
 		schedule.Occurrences = occurrences
 		expanded[i] = schedule
 	}
 
-	return expanded, nil
+	return expanded, rulesBySchedule, nil
+}
+
+// generateOccurrences expands the rules of schedule within [from, to]. A nil
+// from starts at the first occurrence.
+func generateOccurrences(schedule Schedule, rules []RecurrenceRule, from, to *time.Time, callerLoc *time.Location, holidays recurrence.HolidayCalendar) ([]ScheduleOccurrence, error) {
+	// Expand in the schedule's own zone so local start times hold across DST.
+	// All-day schedules hold floating dates, so they expand in UTC against
+	// the range as read on the caller's wall clock.
+	engine := recurrence.NewEngine(locationOrDefault(schedule.TimeZone))
+	opts := recurrence.GenerateOptions{
+		RangeStart: from,
+		RangeEnd:   to,
+		Holidays:   holidays,
+	}
+	if schedule.AllDay {
+		engine = recurrence.NewEngine(time.UTC)
+		opts = recurrence.GenerateOptions{
+			RangeStart: floatingBound(from, callerLoc),
+			RangeEnd:   floatingBound(to, callerLoc),
+			AllDay:     true,
+			Holidays:   holidays,
+		}
+	}
+
+	var occurrences []ScheduleOccurrence
+	for _, rule := range rules {
+		generated, err := engine.GenerateOccurrences(toRecurrenceRule(rule), schedule.Start, schedule.End, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, occ := range generated {
+			occurrences = append(occurrences, ScheduleOccurrence{
+				ScheduleID: occ.ScheduleID,
+				RuleID:     occ.RuleID,
+				Start:      occ.Start,
+				End:        occ.End,
+			})
+		}
+	}
+	return occurrences, nil
 }
 
 func toRecurrenceRule(rule RecurrenceRule) recurrence.Rule {
 	// This is a simplified conversion
 	return recurrence.Rule{
-		ID:         rule.ID,
-		ScheduleID: "",                         // Not needed for generation
-		Frequency:  recurrence.FrequencyWeekly, // Assuming weekly
-		Weekdays:   toTimeWeekdays(rule.Weekdays),
-		StartsOn:   rule.StartsOn,
-		EndsOn:     rule.Until,
+		ID:            rule.ID,
+		ScheduleID:    "",                         // Not needed for generation
+		Frequency:     recurrence.FrequencyWeekly, // Assuming weekly
+		Weekdays:      toTimeWeekdays(rule.Weekdays),
+		StartsOn:      rule.StartsOn,
+		EndsOn:        rule.Until,
+		HolidayPolicy: toRecurrenceHolidayPolicy(rule.HolidayPolicy),
 	}
 }
 
+func usesHolidayPolicy(rulesBySchedule map[string][]RecurrenceRule) bool {
+	for _, rules := range rulesBySchedule {
+		for _, rule := range rules {
+			if rule.HolidayPolicy != HolidayPolicyNone {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func toTimeWeekdays(days []string) []time.Weekday {
 	weekdays := make([]time.Weekday, 0, len(days))
 	for _, day := range days {
@@ -535,38 +944,106 @@ func (s *ScheduleService) ensureRoomExists(ctx context.Context, roomID *string)
 	return vErr
 }
 
-func (s *ScheduleService) detectConflicts(ctx context.Context, candidate Schedule) ([]ConflictWarning, error) {
-	if s == nil || s.schedules == nil {
-		return nil, nil
+// checkAvailability reports participants who are out of office or outside their
+// working hours. Participants not listed in alreadyInvited may auto-decline, in
+// which case they are removed from the returned schedule. The creator never
+// declines their own schedule, and members of live participant groups are
+// checked but never decline, as they are not invited one by one.
+func (s *ScheduleService) checkAvailability(ctx context.Context, candidate Schedule, alreadyInvited []string) (Schedule, []ConflictWarning, error) {
+	if s.availability == nil {
+		return candidate, nil, nil
+	}
+	withMembers, err := withParticipantGroupMembers(ctx, s.userGroups, []Schedule{candidate})
+	if err != nil {
+		return candidate, nil, err
+	}
+	checked := withMembers[0]
+	if len(checked.ParticipantIDs) == 0 {
+		return candidate, nil, nil
 	}
 
-	schedules, err := s.schedules.ListSchedules(ctx, ScheduleRepositoryFilter{})
+	start, end := occupiedInterval(candidate)
+	profiles, err := s.availability.ParticipantAvailability(ctx, checked.ParticipantIDs, &start, &end)
 	if err != nil {
-		if isNotFoundError(err) {
-			return nil, nil
+		return candidate, nil, err
+	}
+	if len(profiles) == 0 {
+		return candidate, nil, nil
+	}
+
+	invited := make(map[string]struct{}, len(alreadyInvited)+1)
+	for _, id := range alreadyInvited {
+		invited[id] = struct{}{}
+	}
+	for _, id := range checked.ParticipantIDs {
+		if !containsString(candidate.ParticipantIDs, id) {
+			invited[id] = struct{}{}
 		}
-		return nil, err
 	}
+	invited[candidate.CreatorID] = struct{}{}
 
-	existing := make([]scheduler.Schedule, 0, len(schedules))
-	for _, sched := range schedules {
-		existing = append(existing, toSchedulerSchedule(sched))
+	availability := make(map[string]scheduler.Availability, len(profiles))
+	for userID, profile := range profiles {
+		_, existing := invited[userID]
+		availability[userID] = toSchedulerAvailability(profile, candidate.AllDay, !existing)
 	}
 
-	conflicts := scheduler.DetectConflicts(existing, toSchedulerSchedule(candidate))
-	return toConflictWarnings(conflicts), nil
+	conflicts := scheduler.DetectAvailabilityConflicts(toSchedulerSchedule(checked), availability)
+	declined := make(map[string]struct{})
+	for _, conflict := range conflicts {
+		if conflict.Type == scheduler.ConflictTypeAutoDeclined {
+			declined[conflict.Participant] = struct{}{}
+		}
+	}
+	if len(declined) > 0 {
+		remaining := make([]string, 0, len(candidate.ParticipantIDs))
+		for _, id := range candidate.ParticipantIDs {
+			if _, ok := declined[id]; !ok {
+				remaining = append(remaining, id)
+			}
+		}
+		candidate.ParticipantIDs = remaining
+	}
+
+	return candidate, toConflictWarnings(conflicts), nil
+}
+
+// toSchedulerAvailability converts a participant profile. Working hours do not
+// apply to all-day schedules, and only new invitees honour auto-decline.
+func toSchedulerAvailability(profile ParticipantAvailability, allDay, canDecline bool) scheduler.Availability {
+	availability := scheduler.Availability{Location: locationOrDefault(profile.TimeZone)}
+	if !allDay {
+		for _, window := range profile.WorkingHours {
+			availability.WorkingHours = append(availability.WorkingHours, scheduler.WorkingWindow{
+				Weekday: window.Weekday,
+				Start:   time.Duration(window.StartMinute) * time.Minute,
+				End:     time.Duration(window.EndMinute) * time.Minute,
+			})
+		}
+	}
+	for _, entry := range profile.OutOfOffice {
+		availability.Absences = append(availability.Absences, scheduler.Absence{
+			ID:          entry.ID,
+			Start:       entry.Start,
+			End:         entry.End,
+			AutoDecline: entry.AutoDecline && canDecline,
+		})
+	}
+	return availability
 }
 
 func toSchedulerSchedule(schedule Schedule) scheduler.Schedule {
 	participants := make([]string, len(schedule.ParticipantIDs))
 	copy(participants, schedule.ParticipantIDs)
 
+	start, end := occupiedInterval(schedule)
 	return scheduler.Schedule{
 		ID:           schedule.ID,
 		Participants: participants,
 		RoomID:       schedule.RoomID,
-		Start:        schedule.Start,
-		End:          schedule.End,
+		Start:        start,
+		End:          end,
+		Transparent:  schedule.AllDay && !schedule.Busy,
 	}
 }
 
@@ -584,6 +1061,7 @@ func toConflictWarnings(conflicts []scheduler.Conflict) []ConflictWarning {
 		if conflict.Participant != "" {
 			warning.ParticipantID = conflict.Participant
 		}
+		warning.OutOfOfficeID = conflict.AbsenceID
 		if conflict.RoomID != nil {
 			roomID := *conflict.RoomID
 			warning.RoomID = &roomID
@@ -600,14 +1078,14 @@ func validateScheduleCore(input ScheduleInput, vErr *ValidationError) {
 
 	if input.Start.IsZero() {
 		vErr.add("start", "start is required")
-	} else if !isJapanStandardTime(input.Start) {
-		vErr.add("start", "start must be in Asia/Tokyo (JST)")
 	}
 
 	if input.End.IsZero() {
 		vErr.add("end", "end is required")
-	} else if !isJapanStandardTime(input.End) {
-		vErr.add("end", "end must be in Asia/Tokyo (JST)")
+	}
+
+	if !isValidTimeZone(input.TimeZone) {
+		vErr.add("time_zone", "time zone is invalid")
 	}
 
 	if !input.Start.IsZero() && !input.End.IsZero() && !input.Start.Before(input.End) {
@@ -620,9 +1098,15 @@ func validateScheduleCore(input ScheduleInput, vErr *ValidationError) {
 		}
 	}
 
-	if len(input.ParticipantIDs) == 0 {
+	if len(input.ParticipantIDs) == 0 && len(input.ParticipantGroupIDs) == 0 {
 		vErr.add("participants", "at least one participant is required")
 	}
+
+	if input.Recurrence != nil && !isValidHolidayPolicy(input.Recurrence.HolidayPolicy) {
+		vErr.add("holiday_policy", "holiday policy is invalid")
+	}
+
+	validateReminderMinutes("reminder_minutes", input.ReminderMinutes, vErr)
 }
 
 func uniqueStrings(values []string) []string {
@@ -673,7 +1157,7 @@ func sortStrings(values []string) []string {
 func (s *ScheduleService) buildListFilter(params ListSchedulesParams) ScheduleRepositoryFilter {
 	participants := make([]string, 0, len(params.ParticipantIDs)+1)
 	participants = append(participants, params.ParticipantIDs...)
-	if params.Principal.UserID != "" {
+	if params.Principal.UserID != "" && params.CalendarID == "" {
 		participants = append(participants, params.Principal.UserID)
 	}
 	participants = sortStrings(uniqueStrings(participants))
@@ -683,9 +1167,10 @@ func (s *ScheduleService) buildListFilter(params ListSchedulesParams) ScheduleRe
 
 	startsAfter := params.StartsAfter
 	endsBefore := params.EndsBefore
+	loc := locationOrDefault(params.Principal.TimeZone)
 
 	if params.Period != ListPeriodNone {
-		start, end := computePeriodRange(params.Period, params.PeriodReference)
+		start, end := computePeriodRange(params.Period, params.PeriodReference, loc)
 		if startsAfter == nil {
 			startsAfter = &start
 		}
@@ -694,96 +1179,84 @@ func (s *ScheduleService) buildListFilter(params ListSchedulesParams) ScheduleRe
 		}
 	}
 
+	var roomID *string
+	if params.RoomID != nil {
+		if trimmed := strings.TrimSpace(*params.RoomID); trimmed != "" {
+			roomID = &trimmed
+		}
+	}
+
+	// All-day rows are dated, so a "day" listing matches them by the caller's
+	// calendar rather than by the schedule's own zone.
 	return ScheduleRepositoryFilter{
-		ParticipantIDs: participants,
-		StartsAfter:    startsAfter,
-		EndsBefore:     endsBefore,
+		ParticipantIDs:    participants,
+		StartsAfter:       startsAfter,
+		EndsBefore:        endsBefore,
+		AllDayStartsAfter: floatingBound(startsAfter, loc),
+		AllDayEndsBefore:  floatingBound(endsBefore, loc),
+		RoomID:            roomID,
+		CalendarID:        params.CalendarID,
+		CreatorID:         strings.TrimSpace(params.CreatorID),
+		TitlePrefix:       strings.TrimSpace(params.TitlePrefix),
+		UpdatedSince:      params.UpdatedSince,
+	}
+}
+
+func floatingBound(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
 	}
+	floating := floatingWallClock(*t, loc)
+	return &floating
 }
 
-func computePeriodRange(period ListPeriod, reference time.Time) (time.Time, time.Time) {
+// computePeriodRange returns the [start, end) bounds of the period containing
+// reference, using calendar boundaries in loc.
+func computePeriodRange(period ListPeriod, reference time.Time, loc *time.Location) (time.Time, time.Time) {
 	switch period {
 	case ListPeriodDay:
-		start := startOfDay(reference)
+		start := startOfDay(reference, loc)
 		return start, start.AddDate(0, 0, 1)
 	case ListPeriodWeek:
-		start := startOfWeek(reference)
+		start := startOfWeek(reference, loc)
 		return start, start.AddDate(0, 0, 7)
 	case ListPeriodMonth:
-		start := startOfMonth(reference)
+		start := startOfMonth(reference, loc)
 		return start, start.AddDate(0, 1, 0)
 	default:
 		return time.Time{}, time.Time{}
 	}
 }
 
-func startOfDay(t time.Time) time.Time {
-	loc := jstLocation()
-	inJST := t.In(loc)
-	return time.Date(inJST.Year(), inJST.Month(), inJST.Day(), 0, 0, 0, 0, loc)
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
 }
 
-func startOfWeek(t time.Time) time.Time {
-	start := startOfDay(t)
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	start := startOfDay(t, loc)
 	weekday := int(start.Weekday())
 	// Adjust so Monday is start of week. In Go, Monday == 1, Sunday == 0.
 	offset := (weekday + 6) % 7
 	return start.AddDate(0, 0, -offset)
 }
 
-func startOfMonth(t time.Time) time.Time {
-	start := startOfDay(t)
+func startOfMonth(t time.Time, loc *time.Location) time.Time {
+	start := startOfDay(t, loc)
 	return time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
 }
 
-func jstLocation() *time.Location {
-	return time.FixedZone("JST", 9*60*60)
-}
-
-func isJapanStandardTime(t time.Time) bool {
-	if t.IsZero() {
-		return false
-	}
-	name, offset := t.Zone()
-	if offset != 9*60*60 {
-		return false
-	}
-	if name == "Asia/Tokyo" || name == "JST" {
-		return true
-	}
-	if loc := t.Location(); loc != nil {
-		if loc.String() == "Asia/Tokyo" || loc.String() == "JST" {
-			return true
-		}
-	}
-	return false
-}
-
 func detectListConflicts(schedules []Schedule) []ConflictWarning {
 	if len(schedules) <= 1 {
 		return nil
 	}
 
-	warnings := make([]ConflictWarning, 0)
 	converted := make([]scheduler.Schedule, len(schedules))
 	for i, sched := range schedules {
 		converted[i] = toSchedulerSchedule(sched)
 	}
 
-	for i, candidate := range schedules {
-		if i+1 >= len(schedules) {
-			break
-		}
-		existing := converted[i+1:]
-		conflicts := scheduler.DetectConflicts(existing, toSchedulerSchedule(candidate))
-		warnings = append(warnings, toConflictWarnings(conflicts)...)
-	}
-
-	if len(warnings) == 0 {
-		return nil
-	}
-
-	return warnings
+	return toConflictWarnings(scheduler.DetectConflictsAmong(converted))
 }
 
 func mapScheduleRepoError(err error) error {
@@ -799,6 +1272,9 @@ func mapScheduleRepoError(err error) error {
 	if errors.Is(err, persistence.ErrDuplicate) {
 		return ErrAlreadyExists
 	}
+	if errors.Is(err, persistence.ErrVersionConflict) {
+		return ErrPreconditionFailed
+	}
 	if errors.Is(err, persistence.ErrConstraintViolation) {
 		vErr := &ValidationError{}
 		vErr.add("time", "start must be before end")
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -112,16 +115,60 @@ func (f *filteringScheduleRepo) ListSchedules(ctx context.Context, filter Schedu
 			filtered = append(filtered, sched)
 		}
 	}
+	if filter.Limit > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if filtered[i].Start.Equal(filtered[j].Start) {
+				return filtered[i].ID < filtered[j].ID
+			}
+			return filtered[i].Start.Before(filtered[j].Start)
+		})
+		if len(filtered) > filter.Limit {
+			filtered = filtered[:filter.Limit]
+		}
+	}
 	return filtered, nil
 }
 
 func matchesScheduleFilter(schedule Schedule, filter ScheduleRepositoryFilter) bool {
-	if filter.StartsAfter != nil && !schedule.End.After(filter.StartsAfter.UTC()) {
+	startsAfter, endsBefore := filter.StartsAfter, filter.EndsBefore
+	if schedule.AllDay && filter.AllDayStartsAfter != nil {
+		startsAfter = filter.AllDayStartsAfter
+	}
+	if schedule.AllDay && filter.AllDayEndsBefore != nil {
+		endsBefore = filter.AllDayEndsBefore
+	}
+	if startsAfter != nil && !schedule.End.After(startsAfter.UTC()) {
+		return false
+	}
+	if endsBefore != nil && !schedule.Start.Before(endsBefore.UTC()) {
+		return false
+	}
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, schedule.ID) {
+		return false
+	}
+	if filter.RoomID != nil && (schedule.RoomID == nil || *schedule.RoomID != *filter.RoomID) {
+		return false
+	}
+	if filter.CreatorID != "" && schedule.CreatorID != filter.CreatorID {
+		return false
+	}
+	if filter.CalendarID != "" && (schedule.CalendarID == nil || *schedule.CalendarID != filter.CalendarID) {
+		return false
+	}
+	if filter.TitlePrefix != "" && !strings.HasPrefix(strings.ToLower(schedule.Title), strings.ToLower(filter.TitlePrefix)) {
 		return false
 	}
-	if filter.EndsBefore != nil && !schedule.Start.Before(filter.EndsBefore.UTC()) {
+	if filter.UpdatedSince != nil && schedule.UpdatedAt.Before(*filter.UpdatedSince) {
 		return false
 	}
+	if filter.AfterStart != nil {
+		if schedule.Start.Before(*filter.AfterStart) {
+			return false
+		}
+		if schedule.Start.Equal(*filter.AfterStart) && schedule.ID <= filter.AfterID {
+			return false
+		}
+	}
 	if len(filter.ParticipantIDs) == 0 {
 		return true
 	}
@@ -170,6 +217,7 @@ type recurrenceRepoStub struct {
 	savedStart      time.Time
 	deletedIDs      []string
 	err             error
+	rules           map[string][]RecurrenceRule
 }
 
 func (r *recurrenceRepoStub) SaveRecurrence(ctx context.Context, scheduleID string, start time.Time, recurrence RecurrenceInput) error {
@@ -183,7 +231,7 @@ func (r *recurrenceRepoStub) SaveRecurrence(ctx context.Context, scheduleID stri
 }
 
 func (r *recurrenceRepoStub) ListRecurrencesForSchedules(ctx context.Context, scheduleIDs []string) (map[string][]RecurrenceRule, error) {
-	return nil, nil
+	return r.rules, nil
 }
 
 func (r *recurrenceRepoStub) DeleteRecurrencesForSchedule(ctx context.Context, scheduleID string) error {
@@ -1226,32 +1274,167 @@ func TestScheduleService_ListSchedules_PeriodFilters(t *testing.T) {
 	})
 }
 
-func TestScheduleService_CreateSchedule_EnforcesJapanStandardTime(t *testing.T) {
-	t.Run("rejects start times outside Asia/Tokyo", func(t *testing.T) {
+func TestScheduleService_ListSchedulesPage(t *testing.T) {
+	newRepo := func() *filteringScheduleRepo {
+		roomA := "room-a"
+		return &filteringScheduleRepo{schedules: []Schedule{
+			{ID: "schedule-3", CreatorID: "user-1", Title: "Weekly sync", Start: mustJST(t, 11), End: mustJST(t, 12), UpdatedAt: mustJST(t, 8)},
+			{ID: "schedule-1", CreatorID: "user-1", Title: "Design review", Start: mustJST(t, 9), End: mustJST(t, 10), RoomID: &roomA, UpdatedAt: mustJST(t, 6)},
+			{ID: "schedule-2", CreatorID: "user-2", Title: "Weekly planning", Start: mustJST(t, 9), End: mustJST(t, 10), ParticipantIDs: []string{"user-1"}, UpdatedAt: mustJST(t, 7)},
+		}}
+	}
+
+	t.Run("walks pages in start then ID order", func(t *testing.T) {
+		svc := NewScheduleService(newRepo(), &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+		params := ListSchedulesParams{Principal: Principal{UserID: "user-1"}, Limit: 2}
+
+		first, err := svc.ListSchedulesPage(context.Background(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(first.Schedules) != 2 || first.Schedules[0].ID != "schedule-1" || first.Schedules[1].ID != "schedule-2" {
+			t.Fatalf("unexpected first page: %+v", first.Schedules)
+		}
+		if first.NextCursor == "" {
+			t.Fatal("expected next cursor on first page")
+		}
+
+		params.Cursor = first.NextCursor
+		second, err := svc.ListSchedulesPage(context.Background(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(second.Schedules) != 1 || second.Schedules[0].ID != "schedule-3" {
+			t.Fatalf("unexpected second page: %+v", second.Schedules)
+		}
+		if second.NextCursor != "" {
+			t.Fatalf("expected no cursor on final page, got %q", second.NextCursor)
+		}
+	})
+
+	t.Run("forwards attribute filters to the repository", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+		roomID := " room-a "
+		since := mustJST(t, 7)
+
+		_, err := svc.ListSchedulesPage(context.Background(), ListSchedulesParams{
+			Principal:    Principal{UserID: "user-1"},
+			RoomID:       &roomID,
+			CreatorID:    "user-2",
+			TitlePrefix:  " Weekly ",
+			UpdatedSince: &since,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		filter := repo.listFilter
+		if filter.RoomID == nil || *filter.RoomID != "room-a" {
+			t.Fatalf("expected trimmed room filter, got %+v", filter.RoomID)
+		}
+		if filter.CreatorID != "user-2" || filter.TitlePrefix != "Weekly" {
+			t.Fatalf("unexpected creator/title filters: %+v", filter)
+		}
+		if filter.UpdatedSince == nil || !filter.UpdatedSince.Equal(since) {
+			t.Fatalf("expected updated-since filter, got %v", filter.UpdatedSince)
+		}
+		if filter.Limit != DefaultPageSize+1 {
+			t.Fatalf("expected default limit plus lookahead, got %d", filter.Limit)
+		}
+	})
+
+	t.Run("filters by title prefix and updated since", func(t *testing.T) {
+		svc := NewScheduleService(newRepo(), &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+		since := mustJST(t, 7)
+
+		page, err := svc.ListSchedulesPage(context.Background(), ListSchedulesParams{
+			Principal:    Principal{UserID: "user-1"},
+			TitlePrefix:  "weekly",
+			UpdatedSince: &since,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(page.Schedules) != 2 || page.Schedules[0].ID != "schedule-2" || page.Schedules[1].ID != "schedule-3" {
+			t.Fatalf("unexpected filtered schedules: %+v", page.Schedules)
+		}
+	})
+
+	t.Run("rejects malformed cursors", func(t *testing.T) {
 		svc := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
 
-		_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+		_, err := svc.ListSchedulesPage(context.Background(), ListSchedulesParams{
+			Principal: Principal{UserID: "user-1"},
+			Cursor:    encodeCursor("not-a-time", "schedule-1"),
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if _, ok := vErr.FieldErrors["cursor"]; !ok {
+			t.Fatalf("expected cursor field error, got %v", vErr.FieldErrors)
+		}
+	})
+}
+
+func TestScheduleService_CreateSchedule_TimeZones(t *testing.T) {
+	t.Run("accepts times authored outside Asia/Tokyo and stores them in UTC", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, func() string { return "schedule-1" }, nil)
+
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Fatalf("failed to load America/New_York: %v", err)
+		}
+		start := time.Date(2024, 3, 14, 9, 0, 0, 0, newYork)
+
+		_, _, err = svc.CreateSchedule(context.Background(), CreateScheduleParams{
 			Principal: Principal{UserID: "user-1"},
 			Input: ScheduleInput{
 				CreatorID:      "user-1",
 				Title:          "Design sync",
-				Start:          time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC),
-				End:            mustJST(t, 10),
+				Start:          start,
+				End:            start.Add(time.Hour),
+				TimeZone:       "America/New_York",
 				ParticipantIDs: []string{"user-1"},
 			},
 		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
 
-		var vErr *ValidationError
-		if !errors.As(err, &vErr) {
-			t.Fatalf("expected validation error, got %v", err)
+		if repo.created.Start.Location() != time.UTC || !repo.created.Start.Equal(start) {
+			t.Fatalf("expected start stored as UTC instant, got %v", repo.created.Start)
 		}
+		if repo.created.TimeZone != "America/New_York" {
+			t.Fatalf("expected originating zone to be stored, got %q", repo.created.TimeZone)
+		}
+	})
+
+	t.Run("defaults to the caller's zone", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
 
-		if _, ok := vErr.FieldErrors["start"]; !ok {
-			t.Fatalf("expected start timezone validation error, got %v", vErr.FieldErrors)
+		_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+			Principal: Principal{UserID: "user-1", TimeZone: "Europe/London"},
+			Input: ScheduleInput{
+				CreatorID:      "user-1",
+				Title:          "Design sync",
+				Start:          time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC),
+				End:            time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC),
+				ParticipantIDs: []string{"user-1"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if repo.created.TimeZone != "Europe/London" {
+			t.Fatalf("expected caller zone, got %q", repo.created.TimeZone)
 		}
 	})
 
-	t.Run("rejects end times outside Asia/Tokyo", func(t *testing.T) {
+	t.Run("rejects unknown zones", func(t *testing.T) {
 		svc := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
 
 		_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
@@ -1260,7 +1443,8 @@ func TestScheduleService_CreateSchedule_EnforcesJapanStandardTime(t *testing.T)
 				CreatorID:      "user-1",
 				Title:          "Design sync",
 				Start:          mustJST(t, 9),
-				End:            time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC),
+				End:            mustJST(t, 10),
+				TimeZone:       "Mars/Olympus_Mons",
 				ParticipantIDs: []string{"user-1"},
 			},
 		})
@@ -1269,11 +1453,435 @@ func TestScheduleService_CreateSchedule_EnforcesJapanStandardTime(t *testing.T)
 		if !errors.As(err, &vErr) {
 			t.Fatalf("expected validation error, got %v", err)
 		}
+		if _, ok := vErr.FieldErrors["time_zone"]; !ok {
+			t.Fatalf("expected time_zone validation error, got %v", vErr.FieldErrors)
+		}
+	})
+}
+
+func TestScheduleService_ListSchedules_ExpandsRecurrencesInScheduleZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load America/New_York: %v", err)
+	}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, newYork)
+	until := time.Date(2024, 3, 18, 23, 0, 0, 0, newYork)
+	rangeEnd := time.Date(2024, 3, 31, 0, 0, 0, 0, newYork)
+
+	repo := &scheduleRepoStub{list: []Schedule{{
+		ID:             "schedule-ny",
+		CreatorID:      "user-1",
+		Title:          "Standup",
+		Start:          start.UTC(),
+		End:            start.Add(15 * time.Minute).UTC(),
+		TimeZone:       "America/New_York",
+		ParticipantIDs: []string{"user-1"},
+	}}}
+	recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+		"schedule-ny": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: start.UTC(), Until: &until}},
+	}}
+	svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, recurrences, nil, nil)
+
+	schedules, _, err := svc.ListSchedules(context.Background(), ListSchedulesParams{
+		Principal:  Principal{UserID: "user-1"},
+		EndsBefore: &rangeEnd,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(schedules) != 1 || len(schedules[0].Occurrences) != 3 {
+		t.Fatalf("expected three occurrences, got %+v", schedules)
+	}
+	for _, occurrence := range schedules[0].Occurrences {
+		if local := occurrence.Start.In(newYork); local.Hour() != 9 {
+			t.Fatalf("expected 09:00 New York wall clock, got %v", local)
+		}
+	}
+}
 
-		if _, ok := vErr.FieldErrors["end"]; !ok {
-			t.Fatalf("expected end timezone validation error, got %v", vErr.FieldErrors)
+func TestScheduleService_ListSchedules_AppliesHolidayPolicy(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load Asia/Tokyo: %v", err)
+	}
+	// Mondays from 2024-04-22; 2024-04-29 (昭和の日) and 2024-05-06 (振替休日) are holidays.
+	start := time.Date(2024, 4, 22, 10, 0, 0, 0, tokyo)
+	rangeEnd := time.Date(2024, 5, 14, 0, 0, 0, 0, tokyo)
+
+	for _, tc := range []struct {
+		policy HolidayPolicy
+		want   []string
+	}{
+		{HolidayPolicyNone, []string{"2024-04-22", "2024-04-29", "2024-05-06", "2024-05-13"}},
+		{HolidayPolicySkip, []string{"2024-04-22", "2024-05-13"}},
+		{HolidayPolicyNextBusinessDay, []string{"2024-04-22", "2024-04-30", "2024-05-07", "2024-05-13"}},
+	} {
+		repo := &scheduleRepoStub{list: []Schedule{{
+			ID:             "schedule-weekly",
+			CreatorID:      "user-1",
+			Title:          "Weekly sync",
+			Start:          start.UTC(),
+			End:            start.Add(time.Hour).UTC(),
+			TimeZone:       "Asia/Tokyo",
+			ParticipantIDs: []string{"user-1"},
+		}}}
+		recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+			"schedule-weekly": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: start.UTC(), HolidayPolicy: tc.policy}},
+		}}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, recurrences, nil, nil).
+			WithHolidays(NewHolidayService(nil, nil))
+
+		schedules, _, err := svc.ListSchedules(context.Background(), ListSchedulesParams{
+			Principal:  Principal{UserID: "user-1"},
+			EndsBefore: &rangeEnd,
+		})
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", tc.policy, err)
+		}
+		var got []string
+		for _, occurrence := range schedules[0].Occurrences {
+			got = append(got, occurrence.Start.In(tokyo).Format("2006-01-02"))
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("%q: expected occurrences %v, got %v", tc.policy, tc.want, got)
 		}
+	}
+}
+
+func TestScheduleService_CreateSchedule_RejectsUnknownHolidayPolicy(t *testing.T) {
+	svc := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, &recurrenceRepoStub{}, nil, nil)
+	start := mustJST(t, 10)
+
+	_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+		Principal: Principal{UserID: "user-1"},
+		Input: ScheduleInput{
+			CreatorID:      "user-1",
+			Title:          "Weekly sync",
+			Start:          start,
+			End:            start.Add(time.Hour),
+			ParticipantIDs: []string{"user-1"},
+			Recurrence:     &RecurrenceInput{Frequency: "weekly", Weekdays: []string{"thursday"}, HolidayPolicy: "postpone"},
+		},
 	})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.FieldErrors["holiday_policy"] != "holiday policy is invalid" {
+		t.Fatalf("expected holiday policy validation error, got %v", err)
+	}
+}
+
+func TestScheduleService_CreateSchedule_StoresReminders(t *testing.T) {
+	repo := &scheduleRepoStub{}
+	svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, func() string { return "schedule-1" }, nil)
+	start := mustJST(t, 10)
+	input := ScheduleInput{
+		CreatorID:       "user-1",
+		Title:           "Weekly sync",
+		Start:           start,
+		End:             start.Add(time.Hour),
+		ParticipantIDs:  []string{"user-1"},
+		ReminderMinutes: []int{60, 10, 60},
+	}
+
+	if _, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{Principal: Principal{UserID: "user-1"}, Input: input}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := repo.created.ReminderMinutes; len(got) != 2 || got[0] != 10 || got[1] != 60 {
+		t.Fatalf("expected sorted unique reminders, got %v", got)
+	}
+
+	input.ReminderMinutes = []int{MaxReminderMinutes + 1}
+	_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{Principal: Principal{UserID: "user-1"}, Input: input})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.FieldErrors["reminder_minutes"] == "" {
+		t.Fatalf("expected reminder validation error, got %v", err)
+	}
+}
+
+func TestComputePeriodRange_UsesCallerZone(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load Europe/London: %v", err)
+	}
+	reference := time.Date(2024, 3, 31, 12, 0, 0, 0, london)
+
+	start, end := computePeriodRange(ListPeriodDay, reference, london)
+	if !start.Equal(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected local midnight start, got %v", start)
+	}
+	// The clocks go forward on 2024-03-31 in London, so the day spans 23 hours.
+	if end.Sub(start) != 23*time.Hour {
+		t.Fatalf("expected 23 hour DST day, got %v", end.Sub(start))
+	}
+}
+
+func TestScheduleService_CreateSchedule_AllDay(t *testing.T) {
+	t.Run("stores inclusive dates as floating day bounds", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+
+		_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+			Principal: Principal{UserID: "user-1"},
+			Input: ScheduleInput{
+				CreatorID:      "user-1",
+				Title:          "Offsite",
+				Start:          time.Date(2024, 5, 1, 15, 30, 0, 0, time.UTC),
+				End:            time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+				AllDay:         true,
+				ParticipantIDs: []string{"user-1"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !repo.created.AllDay {
+			t.Fatalf("expected all-day flag to be stored")
+		}
+		if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC); !repo.created.Start.Equal(want) {
+			t.Fatalf("expected start %v, got %v", want, repo.created.Start)
+		}
+		if want := time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC); !repo.created.End.Equal(want) {
+			t.Fatalf("expected exclusive end %v, got %v", want, repo.created.End)
+		}
+	})
+
+	t.Run("accepts single day events and rejects reversed dates", func(t *testing.T) {
+		svc := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+		day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		if _, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+			Principal: Principal{UserID: "user-1"},
+			Input:     ScheduleInput{Title: "Holiday", Start: day, End: day, AllDay: true, ParticipantIDs: []string{"user-1"}},
+		}); err != nil {
+			t.Fatalf("expected single day event to be accepted, got %v", err)
+		}
+
+		_, _, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+			Principal: Principal{UserID: "user-1"},
+			Input:     ScheduleInput{Title: "Holiday", Start: day, End: day.AddDate(0, 0, -1), AllDay: true, ParticipantIDs: []string{"user-1"}},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["time"] == "" {
+			t.Fatalf("expected time validation error, got %v", err)
+		}
+	})
+
+	t.Run("only busy all-day events conflict with participants", func(t *testing.T) {
+		existing := Schedule{
+			ID:             "all-day",
+			CreatorID:      "user-1",
+			Title:          "Conference",
+			Start:          time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+			End:            time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			TimeZone:       "Asia/Tokyo",
+			AllDay:         true,
+			ParticipantIDs: []string{"user-1"},
+		}
+		input := ScheduleInput{
+			Title:          "Review",
+			Start:          mustJST(t, 10),
+			End:            mustJST(t, 11),
+			ParticipantIDs: []string{"user-1"},
+		}
+
+		repo := &scheduleRepoStub{list: []Schedule{existing}}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+		_, warnings, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{Principal: Principal{UserID: "user-1"}, Input: input})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(warnings) != 0 {
+			t.Fatalf("expected free all-day event not to conflict, got %+v", warnings)
+		}
+
+		existing.Busy = true
+		repo.list = []Schedule{existing}
+		_, warnings, err = svc.CreateSchedule(context.Background(), CreateScheduleParams{Principal: Principal{UserID: "user-1"}, Input: input})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(warnings) != 1 || warnings[0].Type != string(scheduler.ConflictTypeParticipant) {
+			t.Fatalf("expected participant conflict with busy all-day event, got %+v", warnings)
+		}
+	})
+}
+
+func TestScheduleService_ListSchedules_AllDayUsesCallerCalendar(t *testing.T) {
+	repo := &filteringScheduleRepo{schedules: []Schedule{
+		{
+			ID:             "holiday",
+			CreatorID:      "user-1",
+			Title:          "Holiday",
+			Start:          time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+			End:            time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC),
+			TimeZone:       "Asia/Tokyo",
+			AllDay:         true,
+			ParticipantIDs: []string{"user-1"},
+		},
+	}}
+	svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load America/New_York: %v", err)
+	}
+	principal := Principal{UserID: "user-1", TimeZone: "America/New_York"}
+
+	for day, want := range map[int]int{2: 0, 3: 1, 4: 0} {
+		schedules, _, err := svc.ListSchedules(context.Background(), ListSchedulesParams{
+			Principal:       principal,
+			Period:          ListPeriodDay,
+			PeriodReference: time.Date(2024, 5, day, 12, 0, 0, 0, newYork),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(schedules) != want {
+			t.Fatalf("day %d: expected %d schedules, got %d", day, want, len(schedules))
+		}
+	}
+}
+
+func TestScheduleService_ListSchedules_ExpandsAllDayRecurrences(t *testing.T) {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	rangeStart := time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+	rangeEnd := time.Date(2024, 3, 19, 0, 0, 0, 0, time.UTC)
+
+	repo := &scheduleRepoStub{list: []Schedule{{
+		ID:             "schedule-trip",
+		CreatorID:      "user-1",
+		Title:          "Site visit",
+		Start:          start,
+		End:            start.AddDate(0, 0, 2),
+		TimeZone:       "America/New_York",
+		AllDay:         true,
+		ParticipantIDs: []string{"user-1"},
+	}}}
+	recurrences := &recurrenceRepoStub{rules: map[string][]RecurrenceRule{
+		"schedule-trip": {{ID: "rule-1", Frequency: "weekly", Weekdays: []string{"monday"}, StartsOn: start}},
+	}}
+	svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, recurrences, nil, nil)
+
+	schedules, _, err := svc.ListSchedules(context.Background(), ListSchedulesParams{
+		Principal:   Principal{UserID: "user-1", TimeZone: "UTC"},
+		StartsAfter: &rangeStart,
+		EndsBefore:  &rangeEnd,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(schedules) != 1 || len(schedules[0].Occurrences) != 2 {
+		t.Fatalf("expected two occurrences, got %+v", schedules)
+	}
+	for _, occurrence := range schedules[0].Occurrences {
+		if occurrence.Start.Hour() != 0 || occurrence.End.Sub(occurrence.Start) != 48*time.Hour {
+			t.Fatalf("expected two-day floating occurrence, got %v - %v", occurrence.Start, occurrence.End)
+		}
+	}
+}
+
+func TestScheduleService_CreateSchedule_AvailabilityWarnings(t *testing.T) {
+	dayStart := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	newAvailability := func() *AvailabilityService {
+		repo := &availabilityRepoStub{
+			hours: map[string][]WorkingHours{
+				// 09:00-18:00 in Asia/Tokyo, the default zone.
+				"user-2": {{Weekday: time.Thursday, StartMinute: 9 * 60, EndMinute: 18 * 60}},
+			},
+			outOfOffice: []OutOfOffice{
+				{ID: "ooo-3", UserID: "user-3", Start: dayStart, End: dayStart.AddDate(0, 0, 1)},
+				{ID: "ooo-4", UserID: "user-4", Start: dayStart, End: dayStart.AddDate(0, 0, 1), AutoDecline: true},
+			},
+		}
+		return NewAvailabilityService(repo, nil, nil, nil)
+	}
+
+	t.Run("warns about working hours and out-of-office and auto-declines", func(t *testing.T) {
+		repo := &scheduleRepoStub{}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil).WithAvailability(newAvailability())
+
+		_, warnings, err := svc.CreateSchedule(context.Background(), CreateScheduleParams{
+			Principal: Principal{UserID: "user-1"},
+			Input: ScheduleInput{
+				Title:          "Late review",
+				Start:          mustJST(t, 18),
+				End:            mustJST(t, 19),
+				ParticipantIDs: []string{"user-1", "user-2", "user-3", "user-4"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		byParticipant := make(map[string]ConflictWarning)
+		for _, warning := range warnings {
+			byParticipant[warning.ParticipantID] = warning
+		}
+		if byParticipant["user-2"].Type != string(scheduler.ConflictTypeOutsideWorkingHours) {
+			t.Fatalf("expected outside working hours warning for user-2, got %+v", warnings)
+		}
+		if w := byParticipant["user-3"]; w.Type != string(scheduler.ConflictTypeOutOfOffice) || w.OutOfOfficeID != "ooo-3" {
+			t.Fatalf("expected out-of-office warning for user-3, got %+v", warnings)
+		}
+		if byParticipant["user-4"].Type != string(scheduler.ConflictTypeAutoDeclined) {
+			t.Fatalf("expected auto-decline warning for user-4, got %+v", warnings)
+		}
+		if got := repo.created.ParticipantIDs; len(got) != 3 || slices.Contains(got, "user-4") {
+			t.Fatalf("expected user-4 to be removed from participants, got %v", got)
+		}
+	})
+
+	t.Run("existing participants are not auto-declined on update", func(t *testing.T) {
+		repo := &scheduleRepoStub{schedule: Schedule{
+			ID:             "schedule-1",
+			CreatorID:      "user-1",
+			Title:          "Weekly",
+			Start:          mustJST(t, 10),
+			End:            mustJST(t, 11),
+			ParticipantIDs: []string{"user-1", "user-4"},
+		}}
+		svc := NewScheduleService(repo, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil).WithAvailability(newAvailability())
+
+		_, warnings, err := svc.UpdateSchedule(context.Background(), UpdateScheduleParams{
+			Principal:  Principal{UserID: "user-1"},
+			ScheduleID: "schedule-1",
+			Input: ScheduleInput{
+				Title:          "Weekly",
+				Start:          mustJST(t, 10),
+				End:            mustJST(t, 11),
+				ParticipantIDs: []string{"user-1", "user-4"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(warnings) != 1 || warnings[0].Type != string(scheduler.ConflictTypeOutOfOffice) {
+			t.Fatalf("expected plain out-of-office warning, got %+v", warnings)
+		}
+		if len(repo.updated.ParticipantIDs) != 2 {
+			t.Fatalf("expected participants to be kept, got %v", repo.updated.ParticipantIDs)
+		}
+	})
+}
+
+func TestScheduleService_ListSchedulesPage_IncludesOutOfOffice(t *testing.T) {
+	dayStart := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	availability := NewAvailabilityService(&availabilityRepoStub{outOfOffice: []OutOfOffice{
+		{ID: "ooo-1", UserID: "user-2", Start: dayStart, End: dayStart.AddDate(0, 0, 1)},
+		{ID: "ooo-2", UserID: "user-3", Start: dayStart, End: dayStart.AddDate(0, 0, 1)},
+	}}, nil, nil, nil)
+	svc := NewScheduleService(&filteringScheduleRepo{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, nil, nil).WithAvailability(availability)
+
+	page, err := svc.ListSchedulesPage(context.Background(), ListSchedulesParams{
+		Principal:       Principal{UserID: "user-1"},
+		ParticipantIDs:  []string{"user-2"},
+		Period:          ListPeriodDay,
+		PeriodReference: mustJST(t, 12),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.OutOfOffice) != 1 || page.OutOfOffice[0].ID != "ooo-1" {
+		t.Fatalf("expected out-of-office entry for listed participant, got %+v", page.OutOfOffice)
+	}
 }
 
 func TestScheduleService_UpdateSchedule_CleansUpRecurrences(t *testing.T) {
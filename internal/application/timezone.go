@@ -0,0 +1,52 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the IANA database so zones resolve on minimal hosts
+)
+
+// DefaultTimeZone is applied to users and schedules that do not specify an IANA zone.
+const DefaultTimeZone = "Asia/Tokyo"
+
+var errLocalTimeZone = errors.New("host-dependent time zone is not allowed")
+
+// loadTimeZone resolves an IANA zone name. Empty names resolve to DefaultTimeZone.
+func loadTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	// time.LoadLocation accepts "Local", whose meaning depends on the host.
+	if strings.EqualFold(name, "local") {
+		return nil, errLocalTimeZone
+	}
+	return time.LoadLocation(name)
+}
+
+// locationOrDefault resolves name, falling back to DefaultTimeZone when it is empty or unknown.
+func locationOrDefault(name string) *time.Location {
+	if loc, err := loadTimeZone(name); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(DefaultTimeZone); err == nil {
+		return loc
+	}
+	return time.FixedZone("JST", 9*60*60)
+}
+
+// resolveTimeZone returns the first non-empty zone name, or DefaultTimeZone.
+func resolveTimeZone(names ...string) string {
+	for _, name := range names {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			return trimmed
+		}
+	}
+	return DefaultTimeZone
+}
+
+func isValidTimeZone(name string) bool {
+	_, err := loadTimeZone(name)
+	return err == nil
+}
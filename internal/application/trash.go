@@ -0,0 +1,463 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// DefaultTrashRetention is how long deleted items stay restorable when no
+// retention is configured.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashKind identifies the type of a trashed item.
+type TrashKind string
+
+const (
+	TrashKindSchedule TrashKind = "schedule"
+	TrashKindRoom     TrashKind = "room"
+)
+
+// TrashedSchedule is a soft-deleted schedule together with who deleted it and when.
+type TrashedSchedule struct {
+	Schedule  Schedule
+	DeletedAt time.Time
+	DeletedBy string
+}
+
+// TrashedRoom is a soft-deleted room together with who deleted it and when.
+type TrashedRoom struct {
+	Room      Room
+	DeletedAt time.Time
+	DeletedBy string
+}
+
+// TrashItem is an entry of a user's trash. Exactly one of Schedule and Room is set.
+type TrashItem struct {
+	Kind      TrashKind
+	ID        string
+	Name      string
+	DeletedAt time.Time
+	PurgeAt   time.Time
+	Schedule  *Schedule
+	Room      *Room
+}
+
+// ScheduleTrash soft-deletes schedules and reads them back from the trash.
+// ListTrashedSchedules returns every trashed schedule when deletedBy is empty.
+type ScheduleTrash interface {
+	TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error
+	RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error
+	GetTrashedSchedule(ctx context.Context, id string) (TrashedSchedule, error)
+	ListTrashedSchedules(ctx context.Context, deletedBy string) ([]TrashedSchedule, error)
+	PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// RoomTrash soft-deletes rooms and reads them back from the trash.
+// ListTrashedRooms returns every trashed room when deletedBy is empty.
+type RoomTrash interface {
+	TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error
+	RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error
+	GetTrashedRoom(ctx context.Context, id string) (TrashedRoom, error)
+	ListTrashedRooms(ctx context.Context, deletedBy string) ([]TrashedRoom, error)
+	PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// WithTrash makes DeleteSchedule move schedules into trash instead of removing
+// them, and enables RestoreSchedule, returning the service for chaining.
+func (s *ScheduleService) WithTrash(trash ScheduleTrash) *ScheduleService {
+	if s != nil {
+		s.trash = trash
+	}
+	return s
+}
+
+// RestoreSchedule brings a trashed schedule back for anyone who could edit
+// it: its creator, an administrator or an editor of its calendar. Conflicts
+// with schedules booked in the meantime are reported as warnings, as on
+// creation.
+func (s *ScheduleService) RestoreSchedule(ctx context.Context, principal Principal, scheduleID string) (schedule Schedule, warnings []ConflictWarning, err error) {
+	if s == nil {
+		err = fmt.Errorf("ScheduleService is nil")
+		return
+	}
+	if s.schedules == nil || s.trash == nil {
+		err = fmt.Errorf("schedule trash not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "RestoreSchedule",
+		"principal_id", principal.UserID,
+		"schedule_id", scheduleID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to restore schedule", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("warning_count", len(warnings)).InfoContext(ctx, "schedule restored")
+	}()
+
+	trashed, err := s.trash.GetTrashedSchedule(ctx, scheduleID)
+	if err != nil {
+		err = mapScheduleRepoError(err)
+		return
+	}
+	var editable bool
+	if editable, err = s.canEditSchedule(ctx, principal, trashed.Schedule); err != nil {
+		return
+	}
+	if !editable {
+		err = ErrUnauthorized
+		return
+	}
+
+	// The trashed schedule is not listed yet, so it cannot conflict with itself.
+	// Its recurrences were kept in the trash and come back with it.
+	var rules []RecurrenceRule
+	if rules, err = s.candidateRecurrenceRules(ctx, trashed.Schedule, nil, true); err != nil {
+		return
+	}
+	warnings, err = s.detectConflicts(ctx, trashed.Schedule, rules)
+	if err != nil {
+		return
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.trash.RestoreSchedule(ctx, scheduleID, s.now()); err != nil {
+			return mapScheduleRepoError(err)
+		}
+		restored, err := s.schedules.GetSchedule(ctx, scheduleID)
+		if err != nil {
+			return mapScheduleRepoError(err)
+		}
+		if err := s.markOccurrencesStale(ctx, scheduleID); err != nil {
+			return err
+		}
+
+		schedule = restored
+		// Subscribers saw the schedule deleted, so its return is announced as a creation
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventScheduleCreated,
+			ResourceID: schedule.ID,
+			ActorID:    principal.UserID,
+			OccurredAt: s.now(),
+			Data:       newScheduleEventData(schedule),
+		})
+	})
+
+	if s.warningCache != nil {
+		s.warningCache.Invalidate()
+	}
+	return
+}
+
+// WithTrash makes DeleteRoom move rooms into trash instead of removing them,
+// and enables RestoreRoom, returning the service for chaining.
+func (s *RoomService) WithTrash(trash RoomTrash) *RoomService {
+	if s != nil {
+		s.trash = trash
+	}
+	return s
+}
+
+// RestoreRoom brings a trashed room back for administrators. Schedules keep
+// their booking while the room is in the trash.
+func (s *RoomService) RestoreRoom(ctx context.Context, principal Principal, roomID string) (room Room, err error) {
+	if s == nil {
+		err = fmt.Errorf("RoomService is nil")
+		return
+	}
+	if !principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if s.rooms == nil || s.trash == nil {
+		err = fmt.Errorf("room trash not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "RestoreRoom",
+		"principal_id", principal.UserID,
+		"room_id", roomID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to restore room", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.InfoContext(ctx, "room restored")
+	}()
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.trash.RestoreRoom(ctx, roomID, s.now()); err != nil {
+			return mapRoomRepoError(err)
+		}
+		restored, err := s.rooms.GetRoom(ctx, roomID)
+		if err != nil {
+			return mapRoomRepoError(err)
+		}
+
+		room = restored
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventRoomCreated,
+			ResourceID: room.ID,
+			ActorID:    principal.UserID,
+			OccurredAt: s.now(),
+			Data:       newRoomEventData(room),
+		})
+	})
+	return
+}
+
+// TrashService lists deleted items and purges them once the retention period has passed.
+type TrashService struct {
+	schedules  ScheduleTrash
+	rooms      RoomTrash
+	bookings   ScheduleRepository
+	events     EventPublisher
+	unitOfWork UnitOfWork
+	retention  time.Duration
+	now        func() time.Time
+	logger     *slog.Logger
+}
+
+// NewTrashService constructs a trash service keeping items for retention.
+func NewTrashService(schedules ScheduleTrash, rooms RoomTrash, retention time.Duration, now func() time.Time) *TrashService {
+	return NewTrashServiceWithLogger(schedules, rooms, retention, now, nil)
+}
+
+// NewTrashServiceWithLogger constructs a trash service with a specified logger.
+func NewTrashServiceWithLogger(schedules ScheduleTrash, rooms RoomTrash, retention time.Duration, now func() time.Time, logger *slog.Logger) *TrashService {
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &TrashService{
+		schedules: schedules,
+		rooms:     rooms,
+		retention: retention,
+		now:       now,
+		logger:    defaultLogger(logger),
+	}
+}
+
+// WithEvents publishes schedule.updated for the schedules a purged room is
+// removed from, reading them through bookings, and returns the service for
+// chaining.
+func (s *TrashService) WithEvents(publisher EventPublisher, bookings ScheduleRepository) *TrashService {
+	if s != nil {
+		s.events = publisher
+		s.bookings = bookings
+	}
+	return s
+}
+
+// WithUnitOfWork makes purging a room atomic with the events of the schedules
+// it is removed from, returning the service for chaining.
+func (s *TrashService) WithUnitOfWork(unitOfWork UnitOfWork) *TrashService {
+	if s != nil {
+		s.unitOfWork = unitOfWork
+	}
+	return s
+}
+
+// inTransaction runs fn through the configured unit of work, or directly when
+// none is configured.
+func (s *TrashService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return s.unitOfWork.WithinTransaction(ctx, fn)
+}
+
+func (s *TrashService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "TrashService", operation, attrs...)
+}
+
+// ListTrash returns the schedules and rooms the principal deleted, most recently deleted first.
+func (s *TrashService) ListTrash(ctx context.Context, principal Principal) (items []TrashItem, err error) {
+	if s == nil {
+		err = fmt.Errorf("TrashService is nil")
+		return
+	}
+	if principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+
+	logger := s.loggerWith(ctx, "ListTrash",
+		"principal_id", principal.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list trash", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(items)).InfoContext(ctx, "trash listed")
+	}()
+
+	if s.schedules != nil {
+		var schedules []TrashedSchedule
+		if schedules, err = s.schedules.ListTrashedSchedules(ctx, principal.UserID); err != nil {
+			err = mapScheduleRepoError(err)
+			return
+		}
+		for _, trashed := range schedules {
+			schedule := trashed.Schedule
+			items = append(items, TrashItem{
+				Kind:      TrashKindSchedule,
+				ID:        schedule.ID,
+				Name:      schedule.Title,
+				DeletedAt: trashed.DeletedAt,
+				PurgeAt:   trashed.DeletedAt.Add(s.retention),
+				Schedule:  &schedule,
+			})
+		}
+	}
+	if s.rooms != nil {
+		var rooms []TrashedRoom
+		if rooms, err = s.rooms.ListTrashedRooms(ctx, principal.UserID); err != nil {
+			err = mapRoomRepoError(err)
+			return
+		}
+		for _, trashed := range rooms {
+			room := trashed.Room
+			items = append(items, TrashItem{
+				Kind:      TrashKindRoom,
+				ID:        room.ID,
+				Name:      room.Name,
+				DeletedAt: trashed.DeletedAt,
+				PurgeAt:   trashed.DeletedAt.Add(s.retention),
+				Room:      &room,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return
+}
+
+// Purge permanently deletes items that have been in the trash longer than the
+// retention period and returns how many were removed.
+func (s *TrashService) Purge(ctx context.Context) (purged int, err error) {
+	if s == nil {
+		err = fmt.Errorf("TrashService is nil")
+		return
+	}
+
+	cutoff := s.now().Add(-s.retention)
+	logger := s.loggerWith(ctx, "Purge",
+		"deleted_before", cutoff,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to purge trash", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		if purged > 0 {
+			logger.With("purged_count", purged).InfoContext(ctx, "trash purged")
+		}
+	}()
+
+	// Schedules go first so purging a room does not rewrite bookings about to be removed
+	if s.schedules != nil {
+		var n int
+		if n, err = s.schedules.PurgeSchedules(ctx, cutoff); err != nil {
+			err = mapScheduleRepoError(err)
+			return
+		}
+		purged += n
+	}
+	if s.rooms != nil {
+		var n int
+		err = s.inTransaction(ctx, func(ctx context.Context) error {
+			booked, err := s.bookedSchedules(ctx, cutoff)
+			if err != nil {
+				return err
+			}
+			if n, err = s.rooms.PurgeRooms(ctx, cutoff); err != nil {
+				return mapRoomRepoError(err)
+			}
+			return s.publishDetached(ctx, booked)
+		})
+		if err != nil {
+			return
+		}
+		purged += n
+	}
+	return
+}
+
+// bookedSchedules lists the live schedules booked in rooms trashed before
+// cutoff, which purging the rooms is about to change.
+func (s *TrashService) bookedSchedules(ctx context.Context, cutoff time.Time) ([]Schedule, error) {
+	if s.events == nil || s.bookings == nil {
+		return nil, nil
+	}
+	rooms, err := s.rooms.ListTrashedRooms(ctx, "")
+	if err != nil {
+		return nil, mapRoomRepoError(err)
+	}
+	var booked []Schedule
+	for _, room := range rooms {
+		if !room.DeletedAt.Before(cutoff) {
+			continue
+		}
+		roomID := room.Room.ID
+		schedules, err := s.bookings.ListSchedules(ctx, ScheduleRepositoryFilter{RoomID: &roomID})
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+		booked = append(booked, schedules...)
+	}
+	return booked, nil
+}
+
+// publishDetached publishes schedule.updated for each schedule that lost its
+// room, with the room in the previous audience so its watchers are told.
+func (s *TrashService) publishDetached(ctx context.Context, booked []Schedule) error {
+	for _, previous := range booked {
+		schedule, err := s.bookings.GetSchedule(ctx, previous.ID)
+		if err != nil {
+			return mapScheduleRepoError(err)
+		}
+		err = publishEvent(ctx, s.events, Event{
+			Type:       EventScheduleUpdated,
+			ResourceID: schedule.ID,
+			OccurredAt: schedule.UpdatedAt,
+			Data:       newScheduleUpdatedEventData(schedule, previous),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run purges expired trash every interval until ctx is cancelled.
+func (s *TrashService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Failures are logged and retried on the next tick.
+		_, _ = s.Purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
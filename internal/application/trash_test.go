@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// trashStub moves schedules between a filteringScheduleRepo and an in-memory trash.
+type trashStub struct {
+	repo          *filteringScheduleRepo
+	schedules     map[string]TrashedSchedule
+	rooms         map[string]TrashedRoom
+	purgedBefore  time.Time
+	purgeSchedule int
+	purgeRoom     int
+}
+
+func newTrashStub(repo *filteringScheduleRepo) *trashStub {
+	return &trashStub{repo: repo, schedules: map[string]TrashedSchedule{}, rooms: map[string]TrashedRoom{}}
+}
+
+func (t *trashStub) TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	schedule, err := t.repo.GetSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	t.schedules[id] = TrashedSchedule{Schedule: schedule, DeletedAt: deletedAt, DeletedBy: deletedBy}
+	return t.repo.DeleteSchedule(ctx, id)
+}
+
+func (t *trashStub) RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error {
+	trashed, ok := t.schedules[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(t.schedules, id)
+	trashed.Schedule.UpdatedAt = restoredAt
+	_, err := t.repo.CreateSchedule(ctx, trashed.Schedule)
+	return err
+}
+
+func (t *trashStub) GetTrashedSchedule(ctx context.Context, id string) (TrashedSchedule, error) {
+	trashed, ok := t.schedules[id]
+	if !ok {
+		return TrashedSchedule{}, ErrNotFound
+	}
+	return trashed, nil
+}
+
+func (t *trashStub) ListTrashedSchedules(ctx context.Context, deletedBy string) ([]TrashedSchedule, error) {
+	var out []TrashedSchedule
+	for _, trashed := range t.schedules {
+		if trashed.DeletedBy == deletedBy {
+			out = append(out, trashed)
+		}
+	}
+	return out, nil
+}
+
+func (t *trashStub) PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error) {
+	t.purgedBefore = deletedBefore
+	return t.purgeSchedule, nil
+}
+
+func (t *trashStub) TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	t.rooms[id] = TrashedRoom{Room: Room{ID: id, Name: "Room " + id}, DeletedAt: deletedAt, DeletedBy: deletedBy}
+	return nil
+}
+
+func (t *trashStub) RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error {
+	if _, ok := t.rooms[id]; !ok {
+		return ErrNotFound
+	}
+	delete(t.rooms, id)
+	return nil
+}
+
+func (t *trashStub) GetTrashedRoom(ctx context.Context, id string) (TrashedRoom, error) {
+	trashed, ok := t.rooms[id]
+	if !ok {
+		return TrashedRoom{}, ErrNotFound
+	}
+	return trashed, nil
+}
+
+func (t *trashStub) ListTrashedRooms(ctx context.Context, deletedBy string) ([]TrashedRoom, error) {
+	var out []TrashedRoom
+	for _, trashed := range t.rooms {
+		if deletedBy == "" || trashed.DeletedBy == deletedBy {
+			out = append(out, trashed)
+		}
+	}
+	return out, nil
+}
+
+func (t *trashStub) PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error) {
+	for id, trashed := range t.rooms {
+		if !trashed.DeletedAt.Before(deletedBefore) {
+			continue
+		}
+		for i, schedule := range t.repo.schedules {
+			if schedule.RoomID != nil && *schedule.RoomID == id {
+				t.repo.schedules[i].RoomID = nil
+			}
+		}
+	}
+	return t.purgeRoom, nil
+}
+
+func TestScheduleService_TrashAndRestore(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	room := "room-1"
+	repo := &filteringScheduleRepo{schedules: []Schedule{
+		{ID: "sched-1", CreatorID: "alice", Title: "Planning", ParticipantIDs: []string{"alice"}, RoomID: &room, Start: now, End: now.Add(time.Hour), Busy: true},
+	}}
+	trash := newTrashStub(repo)
+	recurrences := &recurrenceRepoStub{}
+	service := NewScheduleService(repo, nil, nil, recurrences, nil, func() time.Time { return now }).WithTrash(trash)
+	alice := Principal{UserID: "alice"}
+
+	if err := service.DeleteSchedule(context.Background(), alice, "sched-1"); err != nil {
+		t.Fatalf("DeleteSchedule returned error: %v", err)
+	}
+	if _, err := service.GetSchedule(context.Background(), alice, "sched-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected trashed schedule to be hidden, got %v", err)
+	}
+	if trashed := trash.schedules["sched-1"]; trashed.DeletedBy != "alice" || !trashed.DeletedAt.Equal(now) {
+		t.Fatalf("unexpected trash entry: %#v", trashed)
+	}
+	if len(recurrences.deletedIDs) != 0 {
+		t.Fatalf("expected recurrences to be kept for restore, got %v", recurrences.deletedIDs)
+	}
+
+	// Another booking takes the room while the schedule is in the trash
+	repo.schedules = append(repo.schedules, Schedule{ID: "sched-2", CreatorID: "bob", RoomID: &room, Start: now, End: now.Add(time.Hour), Busy: true})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		_, _, err := service.RestoreSchedule(context.Background(), Principal{UserID: "bob"}, "sched-1")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("restores for the creator with conflict warnings", func(t *testing.T) {
+		schedule, warnings, err := service.RestoreSchedule(context.Background(), alice, "sched-1")
+		if err != nil {
+			t.Fatalf("RestoreSchedule returned error: %v", err)
+		}
+		if schedule.ID != "sched-1" || schedule.Title != "Planning" {
+			t.Fatalf("unexpected restored schedule: %#v", schedule)
+		}
+		if len(warnings) != 1 || warnings[0].ScheduleID != "sched-2" {
+			t.Fatalf("expected a room conflict with sched-2, got %#v", warnings)
+		}
+	})
+
+	t.Run("reports schedules that are not in the trash as missing", func(t *testing.T) {
+		_, _, err := service.RestoreSchedule(context.Background(), alice, "sched-1")
+		if !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestRoomService_TrashAndRestore(t *testing.T) {
+	repo := &roomRepoStub{getRoom: Room{ID: "room-1", Name: "Room room-1"}}
+	trash := newTrashStub(&filteringScheduleRepo{})
+	service := NewRoomService(repo, nil, nil).WithTrash(trash)
+	admin := Principal{UserID: "admin", IsAdmin: true}
+
+	if err := service.DeleteRoom(context.Background(), admin, "room-1"); err != nil {
+		t.Fatalf("DeleteRoom returned error: %v", err)
+	}
+	if repo.deletedID != "" {
+		t.Fatalf("expected a soft delete, but the room was removed")
+	}
+	if _, ok := trash.rooms["room-1"]; !ok {
+		t.Fatalf("expected room in trash")
+	}
+
+	if _, err := service.RestoreRoom(context.Background(), Principal{UserID: "alice"}, "room-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	room, err := service.RestoreRoom(context.Background(), admin, "room-1")
+	if err != nil {
+		t.Fatalf("RestoreRoom returned error: %v", err)
+	}
+	if room.ID != "room-1" {
+		t.Fatalf("unexpected restored room: %#v", room)
+	}
+	if _, err := service.RestoreRoom(context.Background(), admin, "room-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTrashService(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	trash := newTrashStub(&filteringScheduleRepo{})
+	trash.schedules["sched-1"] = TrashedSchedule{Schedule: Schedule{ID: "sched-1", Title: "Planning"}, DeletedAt: now.Add(-2 * time.Hour), DeletedBy: "alice"}
+	trash.schedules["sched-2"] = TrashedSchedule{Schedule: Schedule{ID: "sched-2", Title: "Other"}, DeletedAt: now, DeletedBy: "bob"}
+	trash.rooms["room-1"] = TrashedRoom{Room: Room{ID: "room-1", Name: "Annex"}, DeletedAt: now.Add(-time.Hour), DeletedBy: "alice"}
+	service := NewTrashService(trash, trash, 7*24*time.Hour, func() time.Time { return now })
+
+	t.Run("lists the caller's deleted items newest first", func(t *testing.T) {
+		items, err := service.ListTrash(context.Background(), Principal{UserID: "alice"})
+		if err != nil {
+			t.Fatalf("ListTrash returned error: %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("expected 2 items, got %#v", items)
+		}
+		if items[0].Kind != TrashKindRoom || items[0].Name != "Annex" || items[0].Room == nil {
+			t.Fatalf("expected the room first, got %#v", items[0])
+		}
+		if items[1].Kind != TrashKindSchedule || items[1].ID != "sched-1" || items[1].Schedule == nil {
+			t.Fatalf("expected the schedule second, got %#v", items[1])
+		}
+		if want := now.Add(-2*time.Hour + 7*24*time.Hour); !items[1].PurgeAt.Equal(want) {
+			t.Fatalf("expected purge at %v, got %v", want, items[1].PurgeAt)
+		}
+	})
+
+	t.Run("requires an authenticated caller", func(t *testing.T) {
+		if _, err := service.ListTrash(context.Background(), Principal{}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("purges items older than the retention period", func(t *testing.T) {
+		trash.purgeSchedule, trash.purgeRoom = 2, 1
+		purged, err := service.Purge(context.Background())
+		if err != nil {
+			t.Fatalf("Purge returned error: %v", err)
+		}
+		if purged != 3 {
+			t.Fatalf("expected 3 purged items, got %d", purged)
+		}
+		if want := now.Add(-7 * 24 * time.Hour); !trash.purgedBefore.Equal(want) {
+			t.Fatalf("expected cutoff %v, got %v", want, trash.purgedBefore)
+		}
+	})
+}
+
+func TestTrashService_PurgeRoomPublishesDetachedSchedules(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	roomID := "room-1"
+	repo := &filteringScheduleRepo{schedules: []Schedule{
+		{ID: "booked", CreatorID: "alice", Title: "Review", RoomID: &roomID, ParticipantIDs: []string{"bob"}},
+		{ID: "elsewhere", CreatorID: "alice", Title: "Sync"},
+	}}
+	trash := newTrashStub(repo)
+	trash.rooms[roomID] = TrashedRoom{Room: Room{ID: roomID, Name: "Annex"}, DeletedAt: now.Add(-30 * 24 * time.Hour), DeletedBy: "alice"}
+	trash.purgeRoom = 1
+	var published []Event
+	publisher := eventPublisherFunc(func(ctx context.Context, event Event) error {
+		published = append(published, event)
+		return nil
+	})
+	service := NewTrashService(trash, trash, 7*24*time.Hour, func() time.Time { return now }).
+		WithEvents(publisher, repo)
+
+	purged, err := service.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("expected 1 purged item, got %d", purged)
+	}
+	if len(published) != 1 || published[0].Type != EventScheduleUpdated || published[0].ResourceID != "booked" {
+		t.Fatalf("expected schedule.updated for the booked schedule, got %+v", published)
+	}
+	data, ok := published[0].Data.(scheduleEventData)
+	if !ok {
+		t.Fatalf("expected schedule update data, got %T", published[0].Data)
+	}
+	if data.RoomID != nil || data.Previous.RoomID == nil || *data.Previous.RoomID != roomID {
+		t.Fatalf("expected the purged room to be reported as the previous room, got %+v", data)
+	}
+}
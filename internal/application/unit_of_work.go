@@ -0,0 +1,29 @@
+package application
+
+import "context"
+
+// UnitOfWork runs fn in a single transaction. Repository calls made with the
+// context passed to fn commit together, or roll back together when fn returns
+// an error. Calls nested inside fn join the same transaction.
+type UnitOfWork interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WithUnitOfWork makes schedule writes atomic: the schedule, its participants,
+// recurrence, revision and outbox event commit or roll back together. It
+// returns the service for chaining.
+func (s *ScheduleService) WithUnitOfWork(uow UnitOfWork) *ScheduleService {
+	if s != nil {
+		s.unitOfWork = uow
+	}
+	return s
+}
+
+// inTransaction runs fn through the configured unit of work, or directly when
+// none is configured.
+func (s *ScheduleService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return s.unitOfWork.WithinTransaction(ctx, fn)
+}
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type txContextKey struct{}
+
+// unitOfWorkStub marks the context it hands to fn and counts how each
+// transaction ended.
+type unitOfWorkStub struct {
+	committed  int
+	rolledBack int
+}
+
+func (u *unitOfWorkStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(context.WithValue(ctx, txContextKey{}, true)); err != nil {
+		u.rolledBack++
+		return err
+	}
+	u.committed++
+	return nil
+}
+
+type eventPublisherFunc func(ctx context.Context, event Event) error
+
+func (f eventPublisherFunc) PublishEvent(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+func TestScheduleService_UnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	principal := Principal{UserID: "user-1"}
+	input := ScheduleInput{
+		Title:          "定例",
+		Start:          start,
+		End:            start.Add(time.Hour),
+		ParticipantIDs: []string{"user-1"},
+		Recurrence:     &RecurrenceInput{Frequency: "weekly", Weekdays: []string{"wednesday"}},
+	}
+
+	t.Run("writes the schedule, recurrence and event in one transaction", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		recurrences := &recurrenceRepoStub{}
+		var published []EventType
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error {
+			if ctx.Value(txContextKey{}) == nil {
+				t.Fatalf("expected %s to be published inside the transaction", event.Type)
+			}
+			published = append(published, event.Type)
+			return nil
+		})
+		service := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, recurrences, func() string { return "sched-1" }, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		if _, _, err := service.CreateSchedule(ctx, CreateScheduleParams{Principal: principal, Input: input}); err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+		if uow.committed != 1 || uow.rolledBack != 0 {
+			t.Fatalf("expected one committed transaction, got %+v", uow)
+		}
+		if recurrences.savedScheduleID != "sched-1" || len(published) != 1 || published[0] != EventScheduleCreated {
+			t.Fatalf("expected the recurrence and event to be written, got %q and %v", recurrences.savedScheduleID, published)
+		}
+	})
+
+	t.Run("rolls back the schedule when the recurrence cannot be saved", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		failure := errors.New("disk full")
+		published := false
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error {
+			published = true
+			return nil
+		})
+		service := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, &recurrenceRepoStub{err: failure}, func() string { return "sched-1" }, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		if _, _, err := service.CreateSchedule(ctx, CreateScheduleParams{Principal: principal, Input: input}); !errors.Is(err, failure) {
+			t.Fatalf("expected the recurrence failure, got %v", err)
+		}
+		if uow.committed != 0 || uow.rolledBack != 1 {
+			t.Fatalf("expected the transaction to roll back, got %+v", uow)
+		}
+		if published {
+			t.Fatalf("expected no event for a rolled back schedule")
+		}
+	})
+
+	t.Run("rolls back a deletion whose event cannot be recorded", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		failure := errors.New("outbox unavailable")
+		repo := &scheduleRepoStub{schedule: Schedule{ID: "sched-1", CreatorID: "user-1", Start: start, End: start.Add(time.Hour)}}
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error { return failure })
+		service := NewScheduleService(repo, nil, nil, &recurrenceRepoStub{}, nil, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		if err := service.DeleteSchedule(ctx, principal, "sched-1"); !errors.Is(err, failure) {
+			t.Fatalf("expected the outbox failure, got %v", err)
+		}
+		if uow.rolledBack != 1 {
+			t.Fatalf("expected the deletion to roll back, got %+v", uow)
+		}
+	})
+}
+
+func TestRoomService_UnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	admin := Principal{UserID: "admin-1", IsAdmin: true}
+
+	t.Run("writes the room and its event in one transaction", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		var published []EventType
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error {
+			if ctx.Value(txContextKey{}) == nil {
+				t.Fatalf("expected %s to be published inside the transaction", event.Type)
+			}
+			published = append(published, event.Type)
+			return nil
+		})
+		repo := &roomRepoStub{getRoom: Room{ID: "room-1", Name: "Annex", Location: "Floor 2", Capacity: 4, Version: 1}}
+		service := NewRoomService(repo, func() string { return "room-2" }, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		input := RoomInput{Name: "Conference Room", Location: "Floor 10", Capacity: 10}
+		if _, err := service.CreateRoom(ctx, CreateRoomParams{Principal: admin, Input: input}); err != nil {
+			t.Fatalf("CreateRoom returned error: %v", err)
+		}
+		if _, err := service.UpdateRoom(ctx, UpdateRoomParams{Principal: admin, RoomID: "room-1", Input: input}); err != nil {
+			t.Fatalf("UpdateRoom returned error: %v", err)
+		}
+		if err := service.DeleteRoom(ctx, admin, "room-1"); err != nil {
+			t.Fatalf("DeleteRoom returned error: %v", err)
+		}
+		if uow.committed != 3 || uow.rolledBack != 0 {
+			t.Fatalf("expected three committed transactions, got %+v", uow)
+		}
+		if len(published) != 3 || published[0] != EventRoomCreated || published[1] != EventRoomUpdated || published[2] != EventRoomDeleted {
+			t.Fatalf("expected created, updated and deleted events, got %v", published)
+		}
+	})
+
+	t.Run("rolls back a deletion whose event cannot be recorded", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		failure := errors.New("outbox unavailable")
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error { return failure })
+		service := NewRoomService(&roomRepoStub{}, nil, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		if err := service.DeleteRoom(ctx, admin, "room-1"); !errors.Is(err, failure) {
+			t.Fatalf("expected the outbox failure, got %v", err)
+		}
+		if uow.committed != 0 || uow.rolledBack != 1 {
+			t.Fatalf("expected the deletion to roll back, got %+v", uow)
+		}
+	})
+}
+
+func TestUserService_UnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	admin := Principal{UserID: "admin-1", IsAdmin: true}
+
+	t.Run("writes the user and its event in one transaction", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		var published []EventType
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error {
+			if ctx.Value(txContextKey{}) == nil {
+				t.Fatalf("expected %s to be published inside the transaction", event.Type)
+			}
+			published = append(published, event.Type)
+			return nil
+		})
+		repo := &userRepoStub{getUser: User{ID: "user-1", Email: "user@example.com", DisplayName: "User", Version: 1}}
+		service := NewUserService(repo, func() string { return "user-2" }, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		input := UserInput{Email: "employee@example.com", DisplayName: "Employee"}
+		if _, err := service.CreateUser(ctx, CreateUserParams{Principal: admin, Input: input}); err != nil {
+			t.Fatalf("CreateUser returned error: %v", err)
+		}
+		if _, err := service.UpdateUser(ctx, UpdateUserParams{Principal: admin, UserID: "user-1", Input: input}); err != nil {
+			t.Fatalf("UpdateUser returned error: %v", err)
+		}
+		if err := service.DeleteUser(ctx, admin, "user-1"); err != nil {
+			t.Fatalf("DeleteUser returned error: %v", err)
+		}
+		if uow.committed != 3 || uow.rolledBack != 0 {
+			t.Fatalf("expected three committed transactions, got %+v", uow)
+		}
+		if len(published) != 3 || published[0] != EventUserCreated || published[1] != EventUserUpdated || published[2] != EventUserDeleted {
+			t.Fatalf("expected created, updated and deleted events, got %v", published)
+		}
+	})
+
+	t.Run("rolls back a creation whose event cannot be recorded", func(t *testing.T) {
+		uow := &unitOfWorkStub{}
+		failure := errors.New("outbox unavailable")
+		events := eventPublisherFunc(func(ctx context.Context, event Event) error { return failure })
+		service := NewUserService(&userRepoStub{}, func() string { return "user-2" }, nil).
+			WithEvents(events).
+			WithUnitOfWork(uow)
+
+		_, err := service.CreateUser(ctx, CreateUserParams{Principal: admin, Input: UserInput{Email: "employee@example.com", DisplayName: "Employee"}})
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected the outbox failure, got %v", err)
+		}
+		if uow.committed != 0 || uow.rolledBack != 1 {
+			t.Fatalf("expected the creation to roll back, got %+v", uow)
+		}
+	})
+}
@@ -0,0 +1,443 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxUserGroupNameLength bounds the name of a user group.
+const maxUserGroupNameLength = 100
+
+// UserGroupRepository persists user groups with their members.
+type UserGroupRepository interface {
+	CreateUserGroup(ctx context.Context, group UserGroup) error
+	GetUserGroup(ctx context.Context, id string) (UserGroup, error)
+	UpdateUserGroup(ctx context.Context, group UserGroup) error
+	DeleteUserGroup(ctx context.Context, id string) error
+	// ListUserGroups returns every group ordered by name.
+	ListUserGroups(ctx context.Context) ([]UserGroup, error)
+}
+
+// UserGroupDirectory resolves a user group to its current members. Unknown
+// groups report ErrNotFound.
+type UserGroupDirectory interface {
+	UserGroupMembers(ctx context.Context, groupID string) ([]string, error)
+}
+
+// UserGroupService manages the departments and distribution lists that can be
+// invited to schedules as a unit. Every user may see the groups; only
+// administrators create them, and a group's owner may also change it.
+type UserGroupService struct {
+	groups      UserGroupRepository
+	users       UserDirectory
+	idGenerator func() string
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewUserGroupService constructs a user group service with the provided dependencies.
+func NewUserGroupService(groups UserGroupRepository, users UserDirectory, idGenerator func() string, now func() time.Time) *UserGroupService {
+	return NewUserGroupServiceWithLogger(groups, users, idGenerator, now, nil)
+}
+
+// NewUserGroupServiceWithLogger constructs a user group service with a specified logger.
+func NewUserGroupServiceWithLogger(groups UserGroupRepository, users UserDirectory, idGenerator func() string, now func() time.Time, logger *slog.Logger) *UserGroupService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &UserGroupService{
+		groups:      groups,
+		users:       users,
+		idGenerator: idGenerator,
+		now:         now,
+		logger:      defaultLogger(logger),
+	}
+}
+
+func (s *UserGroupService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "UserGroupService", operation, attrs...)
+}
+
+// ListUserGroups lists every user group by name.
+func (s *UserGroupService) ListUserGroups(ctx context.Context, principal Principal) ([]UserGroup, error) {
+	if s == nil {
+		return nil, fmt.Errorf("UserGroupService is nil")
+	}
+	if s.groups == nil {
+		return nil, fmt.Errorf("user group repository not configured")
+	}
+
+	groups, err := s.groups.ListUserGroups(ctx)
+	if err != nil {
+		err = mapUserGroupRepoError(err)
+		s.loggerWith(ctx, "ListUserGroups", "principal_id", principal.UserID).ErrorContext(ctx, "failed to list user groups", "error", err, "error_kind", ErrorKind(err))
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetUserGroup returns a user group with its members.
+func (s *UserGroupService) GetUserGroup(ctx context.Context, principal Principal, groupID string) (UserGroup, error) {
+	if s == nil {
+		return UserGroup{}, fmt.Errorf("UserGroupService is nil")
+	}
+	if s.groups == nil {
+		return UserGroup{}, fmt.Errorf("user group repository not configured")
+	}
+
+	group, err := s.groups.GetUserGroup(ctx, groupID)
+	if err != nil {
+		return UserGroup{}, mapUserGroupRepoError(err)
+	}
+	return group, nil
+}
+
+// UserGroupMembers returns the current members of a user group.
+func (s *UserGroupService) UserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	group, err := s.GetUserGroup(ctx, Principal{}, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return group.MemberIDs, nil
+}
+
+// CreateUserGroup saves a new user group. Only administrators may create
+// groups, optionally naming an owner who may change it afterwards.
+func (s *UserGroupService) CreateUserGroup(ctx context.Context, params CreateUserGroupParams) (group UserGroup, err error) {
+	if s == nil {
+		err = fmt.Errorf("UserGroupService is nil")
+		return
+	}
+	if s.groups == nil {
+		err = fmt.Errorf("user group repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateUserGroup", "principal_id", params.Principal.UserID)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create user group", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("group_id", group.ID, "member_count", len(group.MemberIDs)).InfoContext(ctx, "user group created")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	var input UserGroupInput
+	if input, err = s.normalizeInput(ctx, params.Input); err != nil {
+		return
+	}
+
+	now := s.now()
+	group = UserGroup{
+		ID:          s.idGenerator(),
+		Name:        input.Name,
+		Description: input.Description,
+		OwnerID:     input.OwnerID,
+		MemberIDs:   input.MemberIDs,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err = s.groups.CreateUserGroup(ctx, group); err != nil {
+		err = mapUserGroupRepoError(err)
+	}
+	return
+}
+
+// UpdateUserGroup replaces the details and members of a user group. Its owner
+// or an administrator may change it; only administrators may change the owner.
+// An empty OwnerID keeps the current owner.
+func (s *UserGroupService) UpdateUserGroup(ctx context.Context, params UpdateUserGroupParams) (group UserGroup, err error) {
+	if s == nil {
+		err = fmt.Errorf("UserGroupService is nil")
+		return
+	}
+	if s.groups == nil {
+		err = fmt.Errorf("user group repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "UpdateUserGroup",
+		"principal_id", params.Principal.UserID,
+		"group_id", params.GroupID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to update user group", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("member_count", len(group.MemberIDs)).InfoContext(ctx, "user group updated")
+	}()
+
+	if group, err = s.managedGroup(ctx, params.Principal, params.GroupID); err != nil {
+		return
+	}
+	input := params.Input
+	if input.OwnerID = strings.TrimSpace(input.OwnerID); input.OwnerID == "" {
+		input.OwnerID = group.OwnerID
+	}
+	if input.OwnerID != group.OwnerID && !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if input, err = s.normalizeInput(ctx, input); err != nil {
+		return
+	}
+
+	group.Name, group.Description, group.OwnerID = input.Name, input.Description, input.OwnerID
+	group.MemberIDs = input.MemberIDs
+	group.UpdatedAt = s.now()
+	if err = s.groups.UpdateUserGroup(ctx, group); err != nil {
+		err = mapUserGroupRepoError(err)
+	}
+	return
+}
+
+// DeleteUserGroup removes a user group. Schedules it was invited to as a live
+// reference lose its members; expanded invitations are kept. Its owner or an
+// administrator may delete it.
+func (s *UserGroupService) DeleteUserGroup(ctx context.Context, principal Principal, groupID string) error {
+	if s == nil {
+		return fmt.Errorf("UserGroupService is nil")
+	}
+	if s.groups == nil {
+		return fmt.Errorf("user group repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteUserGroup",
+		"principal_id", principal.UserID,
+		"group_id", groupID,
+	)
+	if _, err := s.managedGroup(ctx, principal, groupID); err != nil {
+		logger.ErrorContext(ctx, "failed to delete user group", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	if err := s.groups.DeleteUserGroup(ctx, groupID); err != nil {
+		err = mapUserGroupRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete user group", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+	logger.InfoContext(ctx, "user group deleted")
+	return nil
+}
+
+// managedGroup loads a group the principal may change: its owner or an
+// administrator. Anyone else gets ErrUnauthorized.
+func (s *UserGroupService) managedGroup(ctx context.Context, principal Principal, groupID string) (UserGroup, error) {
+	group, err := s.GetUserGroup(ctx, principal, groupID)
+	if err != nil {
+		return UserGroup{}, err
+	}
+	if !principal.IsAdmin && (group.OwnerID == "" || group.OwnerID != principal.UserID) {
+		return UserGroup{}, ErrUnauthorized
+	}
+	return group, nil
+}
+
+// normalizeInput trims the name and description, drops duplicate members and
+// checks the owner and every member exist.
+func (s *UserGroupService) normalizeInput(ctx context.Context, input UserGroupInput) (UserGroupInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	input.OwnerID = strings.TrimSpace(input.OwnerID)
+	input.MemberIDs = uniqueStrings(input.MemberIDs)
+
+	vErr := &ValidationError{}
+	switch {
+	case input.Name == "":
+		vErr.add("name", "user group name is required")
+	case len([]rune(input.Name)) > maxUserGroupNameLength:
+		vErr.add("name", "user group name must be at most 100 characters")
+	}
+	if s.users != nil {
+		var ownerIDs []string
+		if input.OwnerID != "" {
+			ownerIDs = []string{input.OwnerID}
+		}
+		for _, field := range []struct {
+			name string
+			ids  []string
+		}{
+			{"owner_id", ownerIDs},
+			{"member_ids", input.MemberIDs},
+		} {
+			if len(field.ids) == 0 {
+				continue
+			}
+			missing, err := s.users.MissingUserIDs(ctx, field.ids)
+			if err != nil {
+				return UserGroupInput{}, err
+			}
+			if len(missing) > 0 {
+				vErr.add(field.name, fmt.Sprintf("unknown user ids: %s", strings.Join(missing, ", ")))
+			}
+		}
+	}
+	if vErr.HasErrors() {
+		return UserGroupInput{}, vErr
+	}
+	return input, nil
+}
+
+// WithUserGroups lets user groups be invited to schedules and used as a
+// listing filter, returning the service for chaining.
+func (s *ScheduleService) WithUserGroups(directory UserGroupDirectory) *ScheduleService {
+	if s != nil {
+		s.userGroups = directory
+	}
+	return s
+}
+
+// resolveParticipantGroups applies the user groups invited by input. In
+// expand mode their members are added to ParticipantIDs and the groups
+// dropped; in live mode the groups are kept as a reference.
+func (s *ScheduleService) resolveParticipantGroups(ctx context.Context, input ScheduleInput) (ScheduleInput, error) {
+	vErr := &ValidationError{}
+	mode := input.ParticipantGroupMode
+	switch mode {
+	case "":
+		mode = ParticipantGroupExpand
+	case ParticipantGroupExpand, ParticipantGroupLive:
+	default:
+		vErr.add("participant_group_mode", "participant group mode must be expand or live")
+	}
+
+	var groupIDs []string
+	for _, groupID := range input.ParticipantGroupIDs {
+		if groupID = strings.TrimSpace(groupID); groupID != "" {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	groupIDs = uniqueStrings(groupIDs)
+
+	var members, missing []string
+	for _, groupID := range groupIDs {
+		var groupMembers []string
+		var err error
+		if s.userGroups != nil {
+			groupMembers, err = s.userGroups.UserGroupMembers(ctx, groupID)
+		}
+		if s.userGroups == nil || isNotFoundError(err) {
+			missing = append(missing, groupID)
+			continue
+		}
+		if err != nil {
+			return input, err
+		}
+		members = append(members, groupMembers...)
+	}
+	if len(missing) > 0 {
+		vErr.add("participant_group_ids", fmt.Sprintf("unknown user group ids: %s", strings.Join(missing, ", ")))
+	}
+	if vErr.HasErrors() {
+		return input, vErr
+	}
+
+	input.ParticipantGroupMode = mode
+	if mode == ParticipantGroupExpand {
+		input.ParticipantIDs = append(append([]string(nil), input.ParticipantIDs...), members...)
+		input.ParticipantGroupIDs = nil
+		return input, nil
+	}
+	input.ParticipantGroupIDs = sortStrings(groupIDs)
+	return input, nil
+}
+
+// withParticipantGroupMembers returns copies of schedules whose ParticipantIDs
+// also hold the current members of their live participant groups, so checks
+// that work on participants see each member. Groups that no longer exist are
+// skipped; without a directory schedules are returned as they are.
+func withParticipantGroupMembers(ctx context.Context, directory UserGroupDirectory, schedules []Schedule) ([]Schedule, error) {
+	if directory == nil {
+		return schedules, nil
+	}
+	members := make(map[string][]string)
+	var expanded []Schedule
+	for i, schedule := range schedules {
+		if len(schedule.ParticipantGroupIDs) == 0 {
+			continue
+		}
+		if expanded == nil {
+			expanded = append([]Schedule(nil), schedules...)
+		}
+		participants := append([]string(nil), schedule.ParticipantIDs...)
+		for _, groupID := range schedule.ParticipantGroupIDs {
+			groupMembers, ok := members[groupID]
+			if !ok {
+				var err error
+				groupMembers, err = directory.UserGroupMembers(ctx, groupID)
+				if err != nil && !isNotFoundError(err) {
+					return nil, err
+				}
+				members[groupID] = groupMembers
+			}
+			participants = append(participants, groupMembers...)
+		}
+		expanded[i].ParticipantIDs = sortStrings(uniqueStrings(participants))
+	}
+	if expanded == nil {
+		return schedules, nil
+	}
+	return expanded, nil
+}
+
+// inParticipantGroups reports whether userID is a current member of one of
+// the schedule's live participant groups.
+func (s *ScheduleService) inParticipantGroups(ctx context.Context, userID string, schedule Schedule) (bool, error) {
+	if len(schedule.ParticipantGroupIDs) == 0 {
+		return false, nil
+	}
+	expanded, err := withParticipantGroupMembers(ctx, s.userGroups, []Schedule{schedule})
+	if err != nil {
+		return false, err
+	}
+	return containsString(expanded[0].ParticipantIDs, userID), nil
+}
+
+// resolveUserGroupFilter adds the current members of params.UserGroupID to
+// the participant filter.
+func (s *ScheduleService) resolveUserGroupFilter(ctx context.Context, params ListSchedulesParams) (ListSchedulesParams, error) {
+	if params.UserGroupID == "" {
+		return params, nil
+	}
+	var members []string
+	var err error
+	if s.userGroups != nil {
+		members, err = s.userGroups.UserGroupMembers(ctx, params.UserGroupID)
+	}
+	if s.userGroups == nil || isNotFoundError(err) {
+		vErr := &ValidationError{}
+		vErr.add("user_group", "user group does not exist")
+		return params, vErr
+	}
+	if err != nil {
+		return params, err
+	}
+	params.ParticipantIDs = uniqueStrings(append(append([]string(nil), params.ParticipantIDs...), members...))
+	return params, nil
+}
+
+func mapUserGroupRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrDuplicate):
+		return ErrAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type userGroupRepoStub struct {
+	groups map[string]UserGroup
+}
+
+func (u *userGroupRepoStub) CreateUserGroup(ctx context.Context, group UserGroup) error {
+	if _, ok := u.groups[group.ID]; ok {
+		return ErrAlreadyExists
+	}
+	u.groups[group.ID] = group
+	return nil
+}
+
+func (u *userGroupRepoStub) GetUserGroup(ctx context.Context, id string) (UserGroup, error) {
+	group, ok := u.groups[id]
+	if !ok {
+		return UserGroup{}, ErrNotFound
+	}
+	return group, nil
+}
+
+func (u *userGroupRepoStub) UpdateUserGroup(ctx context.Context, group UserGroup) error {
+	u.groups[group.ID] = group
+	return nil
+}
+
+func (u *userGroupRepoStub) DeleteUserGroup(ctx context.Context, id string) error {
+	delete(u.groups, id)
+	return nil
+}
+
+func (u *userGroupRepoStub) ListUserGroups(ctx context.Context) ([]UserGroup, error) {
+	var groups []UserGroup
+	for _, group := range u.groups {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func TestUserGroupService(t *testing.T) {
+	ctx := context.Background()
+	repo := &userGroupRepoStub{groups: map[string]UserGroup{}}
+	users := &userDirectoryStub{}
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	svc := NewUserGroupService(repo, users, func() string { return "grp-1" }, func() time.Time { return now })
+	admin := Principal{UserID: "root", IsAdmin: true}
+	alice := Principal{UserID: "alice"}
+	bob := Principal{UserID: "bob"}
+	erin := Principal{UserID: "erin"}
+
+	if _, err := svc.CreateUserGroup(ctx, CreateUserGroupParams{Principal: alice, Input: UserGroupInput{Name: "Sales"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected only administrators to create groups, got %v", err)
+	}
+	group, err := svc.CreateUserGroup(ctx, CreateUserGroupParams{Principal: admin, Input: UserGroupInput{
+		Name:      " Sales ",
+		OwnerID:   "alice",
+		MemberIDs: []string{"bob", "carol", "bob"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateUserGroup returned error: %v", err)
+	}
+	if group.Name != "Sales" || group.OwnerID != "alice" || len(group.MemberIDs) != 2 {
+		t.Fatalf("expected a trimmed name and unique members, got %+v", group)
+	}
+
+	if _, err := svc.UpdateUserGroup(ctx, UpdateUserGroupParams{Principal: bob, GroupID: "grp-1", Input: UserGroupInput{Name: "Mine"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected members not to change the group, got %v", err)
+	}
+	if _, err := svc.UpdateUserGroup(ctx, UpdateUserGroupParams{Principal: alice, GroupID: "grp-1", Input: UserGroupInput{Name: "Sales", OwnerID: "bob"}}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected only administrators to change the owner, got %v", err)
+	}
+	if err := svc.DeleteUserGroup(ctx, bob, "grp-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected members not to delete the group, got %v", err)
+	}
+
+	t.Run("validates the input", func(t *testing.T) {
+		_, err := svc.CreateUserGroup(ctx, CreateUserGroupParams{Principal: admin, Input: UserGroupInput{Name: " "}})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["name"] != "user group name is required" {
+			t.Fatalf("expected a name error, got %v", err)
+		}
+
+		users.missing = []string{"ghost"}
+		defer func() { users.missing = nil }()
+		_, err = svc.UpdateUserGroup(ctx, UpdateUserGroupParams{Principal: alice, GroupID: "grp-1", Input: UserGroupInput{Name: "Sales", MemberIDs: []string{"ghost"}}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["member_ids"] != "unknown user ids: ghost" {
+			t.Fatalf("expected unknown members to be reported, got %v", err)
+		}
+	})
+
+	t.Run("groups are invited as a unit", func(t *testing.T) {
+		schedules := &filteringScheduleRepo{schedules: []Schedule{
+			{ID: "standup", CreatorID: "carol", Title: "Standup", Start: now, End: now.Add(time.Hour), ParticipantIDs: []string{"carol"}},
+		}}
+		ids := []string{"expanded", "live"}
+		scheduleSvc := NewScheduleService(schedules, nil, nil, nil, func() string {
+			id := ids[0]
+			ids = ids[1:]
+			return id
+		}, func() time.Time { return now }).WithUserGroups(svc)
+
+		expanded, warnings, err := scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: erin, Input: ScheduleInput{
+			Title: "Kickoff", Start: now, End: now.Add(time.Hour), ParticipantGroupIDs: []string{"grp-1"},
+		}})
+		if err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+		if len(expanded.ParticipantIDs) != 2 || expanded.ParticipantIDs[0] != "bob" || len(expanded.ParticipantGroupIDs) != 0 {
+			t.Fatalf("expected the group to be expanded into participants, got %+v", expanded)
+		}
+		if len(warnings) != 1 || warnings[0].ParticipantID != "carol" || warnings[0].ScheduleID != "standup" {
+			t.Fatalf("expected a conflict for the busy member, got %+v", warnings)
+		}
+
+		live, warnings, err := scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: erin, Input: ScheduleInput{
+			Title: "All hands", Start: now, End: now.Add(time.Hour), ParticipantGroupIDs: []string{"grp-1"}, ParticipantGroupMode: ParticipantGroupLive,
+		}})
+		if err != nil {
+			t.Fatalf("CreateSchedule returned error: %v", err)
+		}
+		if len(live.ParticipantIDs) != 0 || len(live.ParticipantGroupIDs) != 1 {
+			t.Fatalf("expected the group to be kept as a reference, got %+v", live)
+		}
+		conflicted := map[string]bool{}
+		for _, warning := range warnings {
+			conflicted[warning.ParticipantID] = true
+		}
+		if !conflicted["bob"] || !conflicted["carol"] {
+			t.Fatalf("expected conflicts to be reported per member, got %+v", warnings)
+		}
+
+		if _, err := svc.UpdateUserGroup(ctx, UpdateUserGroupParams{Principal: alice, GroupID: "grp-1", Input: UserGroupInput{Name: "Sales", MemberIDs: []string{"bob", "carol", "dave"}}}); err != nil {
+			t.Fatalf("UpdateUserGroup returned error: %v", err)
+		}
+		dave := Principal{UserID: "dave"}
+		if _, err := scheduleSvc.GetSchedule(ctx, dave, "live"); err != nil {
+			t.Fatalf("expected a new member to see the live invitation, got %v", err)
+		}
+		if _, err := scheduleSvc.GetSchedule(ctx, dave, "expanded"); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected an expanded invitation to keep its members, got %v", err)
+		}
+
+		listed, _, err := scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: erin, UserGroupID: "grp-1"})
+		if err != nil {
+			t.Fatalf("ListSchedules returned error: %v", err)
+		}
+		if len(listed) != 3 || listed[2].ID != "standup" {
+			t.Fatalf("expected the schedules of the group's members alongside the caller's own, got %+v", listed)
+		}
+
+		var vErr *ValidationError
+		_, _, err = scheduleSvc.CreateSchedule(ctx, CreateScheduleParams{Principal: erin, Input: ScheduleInput{
+			Title: "Demo", Start: now, End: now.Add(time.Hour), ParticipantGroupIDs: []string{"grp-9"}, ParticipantGroupMode: "copy",
+		}})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["participant_group_ids"] != "unknown user group ids: grp-9" || vErr.FieldErrors["participant_group_mode"] == "" {
+			t.Fatalf("expected unknown groups and modes to be rejected, got %v", err)
+		}
+		_, _, err = scheduleSvc.ListSchedules(ctx, ListSchedulesParams{Principal: erin, UserGroupID: "grp-9"})
+		if !errors.As(err, &vErr) || vErr.FieldErrors["user_group"] != "user group does not exist" {
+			t.Fatalf("expected an unknown group filter to be rejected, got %v", err)
+		}
+	})
+
+	if err := svc.DeleteUserGroup(ctx, admin, "grp-1"); err != nil {
+		t.Fatalf("DeleteUserGroup returned error: %v", err)
+	}
+	if _, err := svc.GetUserGroup(ctx, alice, "grp-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the group to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// MaxUserImportRows bounds the rows of a single user import.
+const MaxUserImportRows = 5000
+
+// ImportUsers creates and updates users from the rows of a CSV import for
+// administrators. Rows are matched to existing users by email; managers may
+// be other rows of the same import. Every row is validated before anything is
+// written, and the writes commit together when a unit of work is configured.
+func (s *UserService) ImportUsers(ctx context.Context, params ImportUsersParams) (result UserImportResult, err error) {
+	if s == nil {
+		err = fmt.Errorf("UserService is nil")
+		return
+	}
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if s.users == nil {
+		err = fmt.Errorf("user repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "ImportUsers",
+		"principal_id", params.Principal.UserID,
+		"row_count", len(params.Rows),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to import users", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With(
+			"created_count", len(result.Created),
+			"updated_count", len(result.Updated),
+		).InfoContext(ctx, "users imported")
+	}()
+
+	if len(params.Rows) == 0 || len(params.Rows) > MaxUserImportRows {
+		vErr := &ValidationError{}
+		vErr.add("rows", fmt.Sprintf("rows must include between 1 and %d entries", MaxUserImportRows))
+		err = vErr
+		return
+	}
+
+	existing, err := s.users.ListUsers(ctx)
+	if err != nil {
+		err = mapUserRepoError(err)
+		return
+	}
+	byEmail := make(map[string]User, len(existing))
+	emailByID := make(map[string]string, len(existing))
+	for _, user := range existing {
+		byEmail[strings.ToLower(user.Email)] = user
+		emailByID[user.ID] = strings.ToLower(user.Email)
+	}
+	departmentIDs, err := s.departmentIDsByName(ctx, params.Rows)
+	if err != nil {
+		return
+	}
+
+	rows, vErr := normalizeUserImportRows(params.Rows, byEmail, departmentIDs)
+
+	// managers maps every email to its manager's email once the import is
+	// applied, so rows that would close a reporting loop can be rejected.
+	managers := make(map[string]string, len(existing)+len(rows))
+	for _, user := range existing {
+		if user.ManagerID != "" {
+			managers[emailByID[user.ID]] = emailByID[user.ManagerID]
+		}
+	}
+	for _, row := range rows {
+		managers[row.Email] = row.ManagerEmail
+	}
+	for i, row := range rows {
+		if row.ManagerEmail == "" || row.ManagerEmail == row.Email {
+			continue
+		}
+		if _, ok := byEmail[row.ManagerEmail]; !ok && !importsEmail(rows, row.ManagerEmail) {
+			vErr.add(fmt.Sprintf("rows[%d].manager_email", i), "manager does not exist")
+			continue
+		}
+		if managesSelf(managers, row.Email) {
+			vErr.add(fmt.Sprintf("rows[%d].manager_email", i), "a user cannot report to themselves or their own reports")
+		}
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	// New users get their IDs up front so rows can name them as managers.
+	ids := make(map[string]string, len(existing)+len(rows))
+	for email, user := range byEmail {
+		ids[email] = user.ID
+	}
+	for _, row := range rows {
+		if _, ok := byEmail[row.Email]; !ok {
+			ids[row.Email] = s.idGenerator()
+		}
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		result = UserImportResult{}
+		now := s.now()
+		// Create new users without a manager first, since their manager may
+		// be created later in the same import.
+		created := make(map[string]User)
+		for _, row := range rows {
+			if _, ok := byEmail[row.Email]; ok {
+				continue
+			}
+			user, err := s.users.CreateUser(ctx, User{
+				ID:           ids[row.Email],
+				Email:        row.Email,
+				DisplayName:  row.DisplayName,
+				TimeZone:     resolveTimeZone(row.TimeZone),
+				DepartmentID: departmentIDs[row.Department],
+				Location:     row.Location,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+			if err != nil {
+				return mapUserRepoError(err)
+			}
+			created[row.Email] = user
+		}
+
+		for _, row := range rows {
+			user, isNew := created[row.Email]
+			if !isNew {
+				user = byEmail[row.Email]
+				if row.DisplayName != "" {
+					user.DisplayName = row.DisplayName
+				}
+				user.TimeZone = resolveTimeZone(row.TimeZone, user.TimeZone)
+				user.DepartmentID = departmentIDs[row.Department]
+				user.Location = row.Location
+			}
+			user.ManagerID = ids[row.ManagerEmail]
+			if !isNew || user.ManagerID != "" {
+				user.UpdatedAt = now
+				updated, err := s.users.UpdateUser(ctx, user)
+				if err != nil {
+					return mapUserRepoError(err)
+				}
+				user = updated
+			}
+
+			event := Event{Type: EventUserUpdated, ResourceID: user.ID, ActorID: params.Principal.UserID, OccurredAt: now, Data: newUserEventData(user)}
+			if isNew {
+				event.Type = EventUserCreated
+				result.Created = append(result.Created, user)
+			} else {
+				result.Updated = append(result.Updated, user)
+			}
+			if err := publishEvent(ctx, s.events, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		result = UserImportResult{}
+	}
+	return
+}
+
+// inTransaction runs fn through the configured unit of work, or directly when
+// none is configured.
+func (s *UserService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return s.unitOfWork.WithinTransaction(ctx, fn)
+}
+
+// departmentIDsByName resolves the department names the rows use.
+func (s *UserService) departmentIDsByName(ctx context.Context, rows []UserImportRow) (map[string]string, error) {
+	ids := map[string]string{"": ""}
+	if s.departments == nil {
+		return ids, nil
+	}
+	needed := false
+	for _, row := range rows {
+		needed = needed || strings.TrimSpace(row.Department) != ""
+	}
+	if !needed {
+		return ids, nil
+	}
+	departments, err := s.departments.ListDepartments(ctx)
+	if err != nil {
+		return nil, mapDepartmentRepoError(err)
+	}
+	for _, department := range departments {
+		ids[department.Name] = department.ID
+	}
+	return ids, nil
+}
+
+// normalizeUserImportRows trims the rows and checks each on its own, leaving
+// reporting lines to the caller.
+func normalizeUserImportRows(rows []UserImportRow, existing map[string]User, departmentIDs map[string]string) ([]UserImportRow, *ValidationError) {
+	vErr := &ValidationError{}
+	normalized := make([]UserImportRow, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		field := func(name string) string { return fmt.Sprintf("rows[%d].%s", i, name) }
+		row = UserImportRow{
+			Email:        strings.ToLower(strings.TrimSpace(row.Email)),
+			DisplayName:  strings.TrimSpace(row.DisplayName),
+			TimeZone:     strings.TrimSpace(row.TimeZone),
+			Department:   strings.TrimSpace(row.Department),
+			ManagerEmail: strings.ToLower(strings.TrimSpace(row.ManagerEmail)),
+			Location:     strings.TrimSpace(row.Location),
+		}
+		normalized[i] = row
+
+		switch _, parseErr := mail.ParseAddress(row.Email); {
+		case row.Email == "":
+			vErr.add(field("email"), "email is required")
+		case parseErr != nil:
+			vErr.add(field("email"), "email is invalid")
+		case seen[row.Email]:
+			vErr.add(field("email"), "email appears more than once")
+		}
+		seen[row.Email] = true
+
+		if _, ok := existing[row.Email]; !ok && row.DisplayName == "" {
+			vErr.add(field("display_name"), "display name is required")
+		}
+		if row.TimeZone != "" && !isValidTimeZone(row.TimeZone) {
+			vErr.add(field("time_zone"), "time zone is invalid")
+		}
+		if _, ok := departmentIDs[row.Department]; !ok {
+			vErr.add(field("department"), "department does not exist")
+		}
+		if row.ManagerEmail != "" && row.ManagerEmail == row.Email {
+			vErr.add(field("manager_email"), "a user cannot report to themselves or their own reports")
+		}
+		if len([]rune(row.Location)) > maxUserLocationLength {
+			vErr.add(field("location"), fmt.Sprintf("location must be at most %d characters", maxUserLocationLength))
+		}
+	}
+	return normalized, vErr
+}
+
+func importsEmail(rows []UserImportRow, email string) bool {
+	for _, row := range rows {
+		if row.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// managesSelf reports whether following managers up from email leads back to
+// it.
+func managesSelf(managers map[string]string, email string) bool {
+	seen := map[string]bool{}
+	for current := managers[email]; current != "" && !seen[current]; current = managers[current] {
+		if current == email {
+			return true
+		}
+		seen[current] = true
+	}
+	return false
+}
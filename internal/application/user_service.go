@@ -13,6 +13,9 @@ import (
 	"github.com/example/enterprise-scheduler/internal/persistence"
 )
 
+// maxUserLocationLength bounds a user's office location.
+const maxUserLocationLength = 100
+
 // UserRepository captures the persistence operations needed by the user service.
 type UserRepository interface {
 	CreateUser(ctx context.Context, user User) (User, error)
@@ -20,11 +23,23 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user User) (User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ListUsers(ctx context.Context) ([]User, error)
+	ListUsersPage(ctx context.Context, filter UserRepositoryFilter) ([]User, error)
+}
+
+// UserRepositoryFilter narrows paginated user queries. Results are ordered by
+// email then ID and start strictly after the (AfterEmail, AfterID) key when set.
+type UserRepositoryFilter struct {
+	AfterEmail string
+	AfterID    string
+	Limit      int
 }
 
 // UserService orchestrates validation, authorization, and persistence for users.
 type UserService struct {
 	users       UserRepository
+	departments DepartmentRepository
+	unitOfWork  UnitOfWork
+	events      EventPublisher
 	idGenerator func() string
 	now         func() time.Time
 	logger      *slog.Logger
@@ -46,6 +61,33 @@ func NewUserServiceWithLogger(users UserRepository, idGenerator func() string, n
 	return &UserService{users: users, idGenerator: idGenerator, now: now, logger: defaultLogger(logger)}
 }
 
+// WithEvents publishes user.created, user.updated and user.deleted events through
+// publisher, returning the service for chaining.
+func (s *UserService) WithEvents(publisher EventPublisher) *UserService {
+	if s != nil {
+		s.events = publisher
+	}
+	return s
+}
+
+// WithDepartments lets users be placed in departments, returning the service
+// for chaining.
+func (s *UserService) WithDepartments(departments DepartmentRepository) *UserService {
+	if s != nil {
+		s.departments = departments
+	}
+	return s
+}
+
+// WithUnitOfWork makes user writes atomic with their outbox events and runs
+// user imports in a single transaction, returning the service for chaining.
+func (s *UserService) WithUnitOfWork(unitOfWork UnitOfWork) *UserService {
+	if s != nil {
+		s.unitOfWork = unitOfWork
+	}
+	return s
+}
+
 func (s *UserService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
 	return serviceLogger(ctx, s.logger, "UserService", operation, attrs...)
 }
@@ -74,17 +116,24 @@ func (s *UserService) CreateUser(ctx context.Context, params CreateUserParams) (
 
 	normalized := normalizeUserInput(params.Input)
 	vErr := validateUserInput(normalized)
+	if err = s.validateOrg(ctx, "", normalized, vErr); err != nil {
+		return
+	}
 	if vErr.HasErrors() {
 		err = vErr
 		return
 	}
 
 	user = User{
-		ID:          s.idGenerator(),
-		Email:       normalized.Email,
-		DisplayName: normalized.DisplayName,
-		IsAdmin:     normalized.IsAdmin,
-		CreatedAt:   s.now(),
+		ID:           s.idGenerator(),
+		Email:        normalized.Email,
+		DisplayName:  normalized.DisplayName,
+		IsAdmin:      normalized.IsAdmin,
+		TimeZone:     resolveTimeZone(normalized.TimeZone),
+		DepartmentID: normalized.DepartmentID,
+		ManagerID:    normalized.ManagerID,
+		Location:     normalized.Location,
+		CreatedAt:    s.now(),
 	}
 	user.UpdatedAt = user.CreatedAt
 
@@ -92,14 +141,21 @@ func (s *UserService) CreateUser(ctx context.Context, params CreateUserParams) (
 		return
 	}
 
-	var persisted User
-	persisted, err = s.users.CreateUser(ctx, user)
-	if err != nil {
-		err = mapUserRepoError(err)
-		return
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		persisted, err := s.users.CreateUser(ctx, user)
+		if err != nil {
+			return mapUserRepoError(err)
+		}
 
-	user = persisted
+		user = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventUserCreated,
+			ResourceID: user.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: user.CreatedAt,
+			Data:       newUserEventData(user),
+		})
+	})
 	return
 }
 
@@ -135,9 +191,15 @@ func (s *UserService) UpdateUser(ctx context.Context, params UpdateUserParams) (
 		err = mapUserRepoError(err)
 		return
 	}
+	if err = checkIfMatch(params.IfMatch, user.Version); err != nil {
+		return
+	}
 
 	normalized := normalizeUserInput(params.Input)
 	vErr := validateUserInput(normalized)
+	if err = s.validateOrg(ctx, user.ID, normalized, vErr); err != nil {
+		return
+	}
 	if vErr.HasErrors() {
 		err = vErr
 		return
@@ -146,14 +208,27 @@ func (s *UserService) UpdateUser(ctx context.Context, params UpdateUserParams) (
 	user.Email = normalized.Email
 	user.DisplayName = normalized.DisplayName
 	user.IsAdmin = normalized.IsAdmin
+	user.TimeZone = resolveTimeZone(normalized.TimeZone, user.TimeZone)
+	user.DepartmentID = normalized.DepartmentID
+	user.ManagerID = normalized.ManagerID
+	user.Location = normalized.Location
 	user.UpdatedAt = s.now()
 
-	user, err = s.users.UpdateUser(ctx, user)
-	if err != nil {
-		err = mapUserRepoError(err)
-		return
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		persisted, err := s.users.UpdateUser(ctx, user)
+		if err != nil {
+			return mapUserRepoError(err)
+		}
 
+		user = persisted
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventUserUpdated,
+			ResourceID: user.ID,
+			ActorID:    params.Principal.UserID,
+			OccurredAt: user.UpdatedAt,
+			Data:       newUserEventData(user),
+		})
+	})
 	return
 }
 
@@ -174,8 +249,20 @@ func (s *UserService) DeleteUser(ctx context.Context, principal Principal, userI
 		"user_id", userID,
 	)
 
-	if err := s.users.DeleteUser(ctx, userID); err != nil {
-		err = mapUserRepoError(err)
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.users.DeleteUser(ctx, userID); err != nil {
+			return mapUserRepoError(err)
+		}
+
+		return publishEvent(ctx, s.events, Event{
+			Type:       EventUserDeleted,
+			ResourceID: userID,
+			ActorID:    principal.UserID,
+			OccurredAt: s.now(),
+			Data:       deletedEventData{ID: userID},
+		})
+	})
+	if err != nil {
 		logger.ErrorContext(ctx, "failed to delete user", "error", err, "error_kind", ErrorKind(err))
 		return err
 	}
@@ -184,6 +271,28 @@ func (s *UserService) DeleteUser(ctx context.Context, principal Principal, userI
 	return nil
 }
 
+// GetUser returns a single user to administrators and to the user themselves.
+func (s *UserService) GetUser(ctx context.Context, principal Principal, userID string) (user User, err error) {
+	if s == nil {
+		err = fmt.Errorf("UserService is nil")
+		return
+	}
+	if !principal.IsAdmin && principal.UserID != userID {
+		err = ErrUnauthorized
+		return
+	}
+	if s.users == nil {
+		err = fmt.Errorf("user repository not configured")
+		return
+	}
+
+	user, err = s.users.GetUser(ctx, userID)
+	if err != nil {
+		return User{}, mapUserRepoError(err)
+	}
+	return user, nil
+}
+
 // ListUsers returns all users for administrators.
 func (s *UserService) ListUsers(ctx context.Context, principal Principal) (users []User, err error) {
 	if s == nil {
@@ -228,6 +337,61 @@ func (s *UserService) ListUsers(ctx context.Context, principal Principal) (users
 	return
 }
 
+// ListUsersPage returns a single page of users ordered by email for administrators.
+func (s *UserService) ListUsersPage(ctx context.Context, params ListUsersParams) (page UserPage, err error) {
+	if s == nil {
+		err = fmt.Errorf("UserService is nil")
+		return
+	}
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	limit, cursor, vErr := resolvePage(params.Limit, params.Cursor)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+	if s.users == nil {
+		return UserPage{}, nil
+	}
+
+	logger := s.loggerWith(ctx, "ListUsersPage",
+		"principal_id", params.Principal.UserID,
+		"limit", limit,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list users", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("result_count", len(page.Users), "has_more", page.NextCursor != "").InfoContext(ctx, "users listed")
+	}()
+
+	filter := UserRepositoryFilter{Limit: limit + 1}
+	if cursor != nil {
+		filter.AfterEmail = cursor.Key
+		filter.AfterID = cursor.ID
+	}
+
+	var raw []User
+	raw, err = s.users.ListUsersPage(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	if len(raw) > limit {
+		raw = raw[:limit]
+		last := raw[len(raw)-1]
+		page.NextCursor = encodeCursor(last.Email, last.ID)
+	}
+	page.Users = make([]User, len(raw))
+	copy(page.Users, raw)
+
+	return
+}
+
 func normalizeUserInput(input UserInput) UserInput {
 	email := strings.TrimSpace(input.Email)
 	email = strings.ToLower(email)
@@ -235,9 +399,13 @@ func normalizeUserInput(input UserInput) UserInput {
 	displayName := strings.TrimSpace(input.DisplayName)
 
 	return UserInput{
-		Email:       email,
-		DisplayName: displayName,
-		IsAdmin:     input.IsAdmin,
+		Email:        email,
+		DisplayName:  displayName,
+		IsAdmin:      input.IsAdmin,
+		TimeZone:     strings.TrimSpace(input.TimeZone),
+		DepartmentID: strings.TrimSpace(input.DepartmentID),
+		ManagerID:    strings.TrimSpace(input.ManagerID),
+		Location:     strings.TrimSpace(input.Location),
 	}
 }
 
@@ -254,9 +422,60 @@ func validateUserInput(input UserInput) *ValidationError {
 		vErr.add("display_name", "display name is required")
 	}
 
+	if !isValidTimeZone(input.TimeZone) {
+		vErr.add("time_zone", "time zone is invalid")
+	}
+
+	if len([]rune(input.Location)) > maxUserLocationLength {
+		vErr.add("location", fmt.Sprintf("location must be at most %d characters", maxUserLocationLength))
+	}
+
 	return vErr
 }
 
+// validateOrg checks the department and manager of userID exist and that the
+// manager does not already report to the user. userID is empty for new users.
+func (s *UserService) validateOrg(ctx context.Context, userID string, input UserInput, vErr *ValidationError) error {
+	if input.DepartmentID != "" {
+		var err error
+		if s.departments != nil {
+			_, err = s.departments.GetDepartment(ctx, input.DepartmentID)
+		}
+		switch err = mapDepartmentRepoError(err); {
+		case s.departments == nil || errors.Is(err, ErrNotFound):
+			vErr.add("department_id", "department does not exist")
+		case err != nil:
+			return err
+		}
+	}
+
+	if input.ManagerID == "" || s.users == nil {
+		return nil
+	}
+	if input.ManagerID == userID {
+		vErr.add("manager_id", "a user cannot report to themselves or their own reports")
+		return nil
+	}
+	if _, err := s.users.GetUser(ctx, input.ManagerID); err != nil {
+		if err = mapUserRepoError(err); errors.Is(err, ErrNotFound) {
+			vErr.add("manager_id", "manager does not exist")
+			return nil
+		}
+		return err
+	}
+	if userID == "" {
+		return nil
+	}
+	cycle, err := reportsTo(ctx, s.users, input.ManagerID, userID)
+	if err != nil {
+		return mapUserRepoError(err)
+	}
+	if cycle {
+		vErr.add("manager_id", "a user cannot report to themselves or their own reports")
+	}
+	return nil
+}
+
 func mapUserRepoError(err error) error {
 	if err == nil {
 		return nil
@@ -270,5 +489,8 @@ func mapUserRepoError(err error) error {
 	if errors.Is(err, persistence.ErrDuplicate) {
 		return ErrAlreadyExists
 	}
+	if errors.Is(err, persistence.ErrVersionConflict) {
+		return ErrPreconditionFailed
+	}
 	return err
 }
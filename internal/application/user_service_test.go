@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"sort"
 	"testing"
 	"time"
 
@@ -24,6 +25,8 @@ type userRepoStub struct {
 
 	list    []User
 	listErr error
+
+	pageFilter UserRepositoryFilter
 }
 
 func (u *userRepoStub) CreateUser(ctx context.Context, user User) (User, error) {
@@ -72,6 +75,32 @@ func (u *userRepoStub) ListUsers(ctx context.Context) ([]User, error) {
 	return out, nil
 }
 
+func (u *userRepoStub) ListUsersPage(ctx context.Context, filter UserRepositoryFilter) ([]User, error) {
+	u.pageFilter = filter
+	if u.listErr != nil {
+		return nil, u.listErr
+	}
+	ordered := make([]User, len(u.list))
+	copy(ordered, u.list)
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].Email == ordered[j].Email {
+			return ordered[i].ID < ordered[j].ID
+		}
+		return ordered[i].Email < ordered[j].Email
+	})
+	out := make([]User, 0, len(ordered))
+	for _, user := range ordered {
+		if filter.AfterID != "" && (user.Email < filter.AfterEmail || (user.Email == filter.AfterEmail && user.ID <= filter.AfterID)) {
+			continue
+		}
+		out = append(out, user)
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	t.Run("requires administrator privileges", func(t *testing.T) {
 		svc := NewUserService(nil, nil, nil)
@@ -89,6 +118,34 @@ func TestUserService_CreateUser(t *testing.T) {
 		}
 	})
 
+	t.Run("defaults and validates the preferred time zone", func(t *testing.T) {
+		repo := &userRepoStub{}
+		svc := NewUserService(repo, func() string { return "user-1" }, nil)
+
+		user, err := svc.CreateUser(context.Background(), CreateUserParams{
+			Principal: Principal{IsAdmin: true},
+			Input:     UserInput{Email: "employee@example.com", DisplayName: "Employee"},
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if user.TimeZone != DefaultTimeZone {
+			t.Fatalf("expected default time zone, got %q", user.TimeZone)
+		}
+
+		_, err = svc.CreateUser(context.Background(), CreateUserParams{
+			Principal: Principal{IsAdmin: true},
+			Input:     UserInput{Email: "employee@example.com", DisplayName: "Employee", TimeZone: "Nowhere/City"},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if _, ok := vErr.FieldErrors["time_zone"]; !ok {
+			t.Fatalf("expected time_zone error, got %v", vErr.FieldErrors)
+		}
+	})
+
 	t.Run("validates input fields including email format", func(t *testing.T) {
 		svc := NewUserService(nil, nil, nil)
 
@@ -314,6 +371,87 @@ func TestUserService_ListUsers(t *testing.T) {
 	})
 }
 
+func TestUserService_ListUsersPage(t *testing.T) {
+	t.Run("requires administrator privileges", func(t *testing.T) {
+		svc := NewUserService(&userRepoStub{}, nil, nil)
+
+		_, err := svc.ListUsersPage(context.Background(), ListUsersParams{Principal: Principal{IsAdmin: false}})
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("walks pages using the returned cursor", func(t *testing.T) {
+		repo := &userRepoStub{list: []User{
+			{ID: "user-3", Email: "carol@example.com"},
+			{ID: "user-1", Email: "alice@example.com"},
+			{ID: "user-2", Email: "bob@example.com"},
+		}}
+		svc := NewUserService(repo, nil, nil)
+		principal := Principal{UserID: "admin", IsAdmin: true}
+
+		first, err := svc.ListUsersPage(context.Background(), ListUsersParams{Principal: principal, Limit: 2})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if repo.pageFilter.Limit != 3 {
+			t.Fatalf("expected repository to be asked for limit+1 rows, got %d", repo.pageFilter.Limit)
+		}
+		if len(first.Users) != 2 || first.Users[0].ID != "user-1" || first.Users[1].ID != "user-2" {
+			t.Fatalf("unexpected first page: %+v", first.Users)
+		}
+		if first.NextCursor == "" {
+			t.Fatal("expected next cursor on first page")
+		}
+
+		second, err := svc.ListUsersPage(context.Background(), ListUsersParams{Principal: principal, Limit: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if repo.pageFilter.AfterEmail != "bob@example.com" || repo.pageFilter.AfterID != "user-2" {
+			t.Fatalf("expected keyset bound from cursor, got %+v", repo.pageFilter)
+		}
+		if len(second.Users) != 1 || second.Users[0].ID != "user-3" {
+			t.Fatalf("unexpected second page: %+v", second.Users)
+		}
+		if second.NextCursor != "" {
+			t.Fatalf("expected no cursor on final page, got %q", second.NextCursor)
+		}
+	})
+
+	t.Run("applies the default page size", func(t *testing.T) {
+		repo := &userRepoStub{}
+		svc := NewUserService(repo, nil, nil)
+
+		if _, err := svc.ListUsersPage(context.Background(), ListUsersParams{Principal: Principal{IsAdmin: true}}); err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if repo.pageFilter.Limit != DefaultPageSize+1 {
+			t.Fatalf("expected default limit, got %d", repo.pageFilter.Limit)
+		}
+	})
+
+	t.Run("rejects invalid limit and cursor", func(t *testing.T) {
+		svc := NewUserService(&userRepoStub{}, nil, nil)
+
+		_, err := svc.ListUsersPage(context.Background(), ListUsersParams{
+			Principal: Principal{IsAdmin: true},
+			Limit:     MaxPageSize + 1,
+			Cursor:    "not-a-cursor!",
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if _, ok := vErr.FieldErrors["limit"]; !ok {
+			t.Fatalf("expected limit error, got %v", vErr.FieldErrors)
+		}
+		if _, ok := vErr.FieldErrors["cursor"]; !ok {
+			t.Fatalf("expected cursor error, got %v", vErr.FieldErrors)
+		}
+	})
+}
+
 func TestUserService_DeleteUser(t *testing.T) {
 	t.Run("requires administrator privileges", func(t *testing.T) {
 		svc := NewUserService(nil, nil, nil)
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxViewLinkCodeAttempts bounds how often a colliding code is regenerated.
+const maxViewLinkCodeAttempts = 5
+
+// ViewLinkRepository persists view links by code. Creating a link with a code
+// already taken reports a duplicate.
+type ViewLinkRepository interface {
+	CreateViewLink(ctx context.Context, link ViewLink) error
+	GetViewLink(ctx context.Context, code string) (ViewLink, error)
+}
+
+// ViewLinkService issues short links that reopen a calendar view with the
+// same period, date and participants.
+type ViewLinkService struct {
+	links         ViewLinkRepository
+	users         UserDirectory
+	groups        ColleagueGroupDirectory
+	codeGenerator func() string
+	now           func() time.Time
+	logger        *slog.Logger
+}
+
+// NewViewLinkService constructs a view link service with the provided
+// dependencies. The user and group directories are optional.
+func NewViewLinkService(links ViewLinkRepository, users UserDirectory, groups ColleagueGroupDirectory, codeGenerator func() string, now func() time.Time) *ViewLinkService {
+	return NewViewLinkServiceWithLogger(links, users, groups, codeGenerator, now, nil)
+}
+
+// NewViewLinkServiceWithLogger constructs a view link service with a specified logger.
+func NewViewLinkServiceWithLogger(links ViewLinkRepository, users UserDirectory, groups ColleagueGroupDirectory, codeGenerator func() string, now func() time.Time, logger *slog.Logger) *ViewLinkService {
+	if codeGenerator == nil {
+		codeGenerator = func() string { return "" }
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &ViewLinkService{
+		links:         links,
+		users:         users,
+		groups:        groups,
+		codeGenerator: codeGenerator,
+		now:           now,
+		logger:        defaultLogger(logger),
+	}
+}
+
+func (s *ViewLinkService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "ViewLinkService", operation, attrs...)
+}
+
+// CreateViewLink stores a link to a view. The participant set is fixed when
+// the link is created: the creator first, then the selected participants and
+// the members of the group. Date defaults to today in the creator's zone.
+func (s *ViewLinkService) CreateViewLink(ctx context.Context, params CreateViewLinkParams) (link ViewLink, err error) {
+	if s == nil {
+		err = fmt.Errorf("ViewLinkService is nil")
+		return
+	}
+	if s.links == nil {
+		err = fmt.Errorf("view link repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateViewLink",
+		"principal_id", params.Principal.UserID,
+		"period", string(params.View),
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create view link", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("code", link.Code, "participant_count", len(link.ParticipantIDs)).InfoContext(ctx, "view link created")
+	}()
+
+	if params.Principal.UserID == "" {
+		err = ErrUnauthorized
+		return
+	}
+
+	vErr := &ValidationError{}
+	if params.View != ListPeriodDay && params.View != ListPeriodWeek && params.View != ListPeriodMonth {
+		vErr.add("view", "view must be day, week or month")
+	}
+	participants := append([]string{params.Principal.UserID}, params.ParticipantIDs...)
+	if params.GroupID != "" && s.groups != nil {
+		members, groupErr := s.groups.ColleagueGroupMembers(ctx, params.Principal, params.GroupID)
+		switch {
+		case isNotFoundError(groupErr):
+			vErr.add("group", "group does not exist")
+		case groupErr != nil:
+			err = groupErr
+			return
+		default:
+			participants = append(participants, members...)
+		}
+	}
+	participants = uniqueStrings(participants)
+	if s.users != nil && len(params.ParticipantIDs) > 0 {
+		missing, lookupErr := s.users.MissingUserIDs(ctx, uniqueStrings(params.ParticipantIDs))
+		if lookupErr != nil {
+			err = lookupErr
+			return
+		}
+		if len(missing) > 0 {
+			vErr.add("participants", fmt.Sprintf("unknown user ids: %s", strings.Join(missing, ", ")))
+		}
+	}
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	now := s.now()
+	date := params.Date
+	if date.IsZero() {
+		date = now.In(locationOrDefault(params.Principal.TimeZone))
+	}
+	link = ViewLink{
+		CreatorID:      params.Principal.UserID,
+		View:           params.View,
+		Date:           time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		ParticipantIDs: participants,
+		CreatedAt:      now,
+	}
+	for attempt := 0; attempt < maxViewLinkCodeAttempts; attempt++ {
+		link.Code = s.codeGenerator()
+		err = s.links.CreateViewLink(ctx, link)
+		if !errors.Is(err, persistence.ErrDuplicate) && !errors.Is(err, ErrAlreadyExists) {
+			break
+		}
+	}
+	if errors.Is(err, persistence.ErrDuplicate) {
+		err = ErrAlreadyExists
+	}
+	return
+}
+
+// GetViewLink resolves a link for any authenticated user.
+func (s *ViewLinkService) GetViewLink(ctx context.Context, principal Principal, code string) (ViewLink, error) {
+	if s == nil {
+		return ViewLink{}, fmt.Errorf("ViewLinkService is nil")
+	}
+	if s.links == nil {
+		return ViewLink{}, fmt.Errorf("view link repository not configured")
+	}
+	if principal.UserID == "" {
+		return ViewLink{}, ErrUnauthorized
+	}
+
+	link, err := s.links.GetViewLink(ctx, code)
+	if err != nil {
+		if isNotFoundError(err) {
+			err = ErrNotFound
+		}
+		s.loggerWith(ctx, "GetViewLink", "principal_id", principal.UserID, "code", code).ErrorContext(ctx, "failed to resolve view link", "error", err, "error_kind", ErrorKind(err))
+		return ViewLink{}, err
+	}
+	return link, nil
+}
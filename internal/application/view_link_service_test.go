@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+type viewLinkRepoStub struct {
+	links map[string]ViewLink
+}
+
+func (v *viewLinkRepoStub) CreateViewLink(ctx context.Context, link ViewLink) error {
+	if _, ok := v.links[link.Code]; ok {
+		return persistence.ErrDuplicate
+	}
+	v.links[link.Code] = link
+	return nil
+}
+
+func (v *viewLinkRepoStub) GetViewLink(ctx context.Context, code string) (ViewLink, error) {
+	link, ok := v.links[code]
+	if !ok {
+		return ViewLink{}, persistence.ErrNotFound
+	}
+	return link, nil
+}
+
+type colleagueGroupDirectoryStub struct {
+	members map[string][]string
+}
+
+func (c *colleagueGroupDirectoryStub) ColleagueGroupMembers(ctx context.Context, principal Principal, groupID string) ([]string, error) {
+	members, ok := c.members[groupID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return members, nil
+}
+
+func TestViewLinkService(t *testing.T) {
+	ctx := context.Background()
+	repo := &viewLinkRepoStub{links: map[string]ViewLink{"taken1": {Code: "taken1"}}}
+	groups := &colleagueGroupDirectoryStub{members: map[string][]string{"group-1": {"carol", "bob"}}}
+	codes := []string{"taken1", "a1b2c3"}
+	nextCode := func() string {
+		code := codes[0]
+		codes = codes[1:]
+		return code
+	}
+	now := time.Date(2024, 6, 2, 16, 0, 0, 0, time.UTC)
+	svc := NewViewLinkService(repo, &userDirectoryStub{}, groups, nextCode, func() time.Time { return now })
+	alice := Principal{UserID: "alice", TimeZone: "Asia/Tokyo"}
+
+	link, err := svc.CreateViewLink(ctx, CreateViewLinkParams{Principal: alice, View: ListPeriodWeek, ParticipantIDs: []string{"bob"}, GroupID: "group-1"})
+	if err != nil {
+		t.Fatalf("CreateViewLink returned error: %v", err)
+	}
+	if link.Code != "a1b2c3" {
+		t.Fatalf("expected a taken code to be regenerated, got %q", link.Code)
+	}
+	want := []string{"alice", "bob", "carol"}
+	if len(link.ParticipantIDs) != len(want) {
+		t.Fatalf("expected participants %v, got %v", want, link.ParticipantIDs)
+	}
+	for i, id := range want {
+		if link.ParticipantIDs[i] != id {
+			t.Fatalf("expected participants %v, got %v", want, link.ParticipantIDs)
+		}
+	}
+	if !link.Date.Equal(time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the date to default to today in the creator's zone, got %s", link.Date)
+	}
+
+	resolved, err := svc.GetViewLink(ctx, Principal{UserID: "dave"}, "a1b2c3")
+	if err != nil || resolved.View != ListPeriodWeek || resolved.CreatorID != "alice" {
+		t.Fatalf("expected any user to resolve the link, got %+v (err %v)", resolved, err)
+	}
+	if _, err := svc.GetViewLink(ctx, Principal{}, "a1b2c3"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected anonymous callers to be rejected, got %v", err)
+	}
+	if _, err := svc.GetViewLink(ctx, alice, "zzzzzz"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected unknown codes to report not found, got %v", err)
+	}
+
+	t.Run("validates the view and group", func(t *testing.T) {
+		_, err := svc.CreateViewLink(ctx, CreateViewLinkParams{Principal: alice, View: "year", GroupID: "missing"})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["view"] == "" || vErr.FieldErrors["group"] != "group does not exist" {
+			t.Fatalf("expected view and group errors, got %v", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -142,5 +143,35 @@ func buildWarningCacheKey(params ListSchedulesParams, filter ScheduleRepositoryF
 	builder.WriteString(startsAfter)
 	builder.WriteString("|")
 	builder.WriteString(endsBefore)
+	builder.WriteString("|")
+	if filter.RoomID != nil {
+		builder.WriteString(*filter.RoomID)
+	}
+	builder.WriteString("|")
+	builder.WriteString(filter.CreatorID)
+	builder.WriteString("|")
+	builder.WriteString(filter.TitlePrefix)
+	builder.WriteString("|")
+	if filter.UpdatedSince != nil {
+		builder.WriteString(filter.UpdatedSince.UTC().Format(time.RFC3339Nano))
+	}
+	builder.WriteString("|")
+	if filter.AfterStart != nil {
+		builder.WriteString(filter.AfterStart.UTC().Format(time.RFC3339Nano))
+	}
+	builder.WriteString("|")
+	builder.WriteString(filter.AfterID)
+	builder.WriteString("|")
+	builder.WriteString(strconv.Itoa(filter.Limit))
+	builder.WriteString("|")
+	if filter.AllDayStartsAfter != nil {
+		builder.WriteString(filter.AllDayStartsAfter.UTC().Format(time.RFC3339Nano))
+	}
+	builder.WriteString("|")
+	if filter.AllDayEndsBefore != nil {
+		builder.WriteString(filter.AllDayEndsBefore.UTC().Format(time.RFC3339Nano))
+	}
+	builder.WriteString("|")
+	builder.WriteString(filter.CalendarID)
 	return builder.String()
 }
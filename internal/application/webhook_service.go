@@ -0,0 +1,612 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/notification"
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+const (
+	// maxWebhookAttempts bounds how often a delivery is attempted before it is marked failed.
+	maxWebhookAttempts = 8
+	// webhookBaseBackoff is the delay before the second attempt; it doubles per attempt.
+	webhookBaseBackoff = 30 * time.Second
+	// webhookMaxBackoff caps the delay between attempts.
+	webhookMaxBackoff = time.Hour
+	// webhookBatchSize bounds the events and deliveries handled per worker run.
+	webhookBatchSize = 100
+)
+
+// WebhookRepository persists webhook subscriptions, the event outbox and
+// delivery logs.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, subscription WebhookSubscription) error
+	GetWebhook(ctx context.Context, id string) (WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, subscription WebhookSubscription) error
+	DeleteWebhook(ctx context.Context, id string) error
+	ListWebhooks(ctx context.Context) ([]WebhookSubscription, error)
+
+	AppendOutboxEvent(ctx context.Context, event OutboxEvent) error
+	// ListPendingOutboxEvents returns events not yet fanned out, oldest first.
+	ListPendingOutboxEvents(ctx context.Context, limit int) ([]OutboxEvent, error)
+	// EnqueueWebhookDeliveries stores deliveries for eventID and marks the event
+	// dispatched in a single transaction.
+	EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []WebhookDelivery, dispatchedAt time.Time) error
+
+	CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, id string) (WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	// ListDueWebhookDeliveries returns pending deliveries of active subscriptions
+	// whose next attempt is at or before now, with their payloads.
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	// ListWebhookDeliveries returns a subscription's deliveries, newest first.
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]WebhookDelivery, error)
+}
+
+// WebhookPoster sends one signed webhook request and reports the response status.
+type WebhookPoster interface {
+	Post(ctx context.Context, req notification.SignedRequest) (int, error)
+}
+
+// WebhookService manages webhook subscriptions, records published events in
+// the outbox and delivers them with exponential-backoff retries.
+type WebhookService struct {
+	webhooks        WebhookRepository
+	poster          WebhookPoster
+	idGenerator     func() string
+	secretGenerator func() string
+	now             func() time.Time
+	logger          *slog.Logger
+}
+
+// NewWebhookService constructs a webhook service with the provided dependencies.
+func NewWebhookService(webhooks WebhookRepository, poster WebhookPoster, idGenerator, secretGenerator func() string, now func() time.Time) *WebhookService {
+	return NewWebhookServiceWithLogger(webhooks, poster, idGenerator, secretGenerator, now, nil)
+}
+
+// NewWebhookServiceWithLogger constructs a webhook service with a specified logger.
+func NewWebhookServiceWithLogger(webhooks WebhookRepository, poster WebhookPoster, idGenerator, secretGenerator func() string, now func() time.Time, logger *slog.Logger) *WebhookService {
+	if idGenerator == nil {
+		idGenerator = func() string { return "" }
+	}
+	if secretGenerator == nil {
+		secretGenerator = idGenerator
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &WebhookService{
+		webhooks:        webhooks,
+		poster:          poster,
+		idGenerator:     idGenerator,
+		secretGenerator: secretGenerator,
+		now:             now,
+		logger:          defaultLogger(logger),
+	}
+}
+
+func (s *WebhookService) loggerWith(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	return serviceLogger(ctx, s.logger, "WebhookService", operation, attrs...)
+}
+
+// PublishEvent implements EventPublisher by appending the event's JSON
+// envelope to the outbox.
+func (s *WebhookService) PublishEvent(ctx context.Context, event Event) error {
+	if s == nil {
+		return fmt.Errorf("WebhookService is nil")
+	}
+	if s.webhooks == nil {
+		return fmt.Errorf("webhook repository not configured")
+	}
+
+	if event.ID == "" {
+		event.ID = s.idGenerator()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now()
+	}
+
+	payload, err := json.Marshal(eventEnvelope{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		OccurredAt: event.OccurredAt.UTC().Format(time.RFC3339Nano),
+		ActorID:    event.ActorID,
+		ResourceID: event.ResourceID,
+		Data:       event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	return s.webhooks.AppendOutboxEvent(ctx, OutboxEvent{
+		ID:         event.ID,
+		Type:       event.Type,
+		ResourceID: event.ResourceID,
+		ActorID:    event.ActorID,
+		Payload:    payload,
+		OccurredAt: event.OccurredAt,
+	})
+}
+
+// eventEnvelope is the JSON body posted to webhook subscribers.
+type eventEnvelope struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	OccurredAt string `json:"occurred_at"`
+	ActorID    string `json:"actor_id,omitempty"`
+	ResourceID string `json:"resource_id"`
+	Data       any    `json:"data"`
+}
+
+// CreateWebhook registers a subscription for administrators. The generated
+// signing secret is only returned here.
+func (s *WebhookService) CreateWebhook(ctx context.Context, params CreateWebhookParams) (subscription WebhookSubscription, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "CreateWebhook",
+		"principal_id", params.Principal.UserID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create webhook", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("webhook_id", subscription.ID).InfoContext(ctx, "webhook created")
+	}()
+
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	input, vErr := normalizeWebhookInput(params.Input)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	now := s.now()
+	subscription = WebhookSubscription{
+		ID:         s.idGenerator(),
+		URL:        input.URL,
+		Secret:     s.secretGenerator(),
+		EventTypes: input.EventTypes,
+		Active:     input.Active == nil || *input.Active,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if s.webhooks == nil {
+		return
+	}
+	if err = s.webhooks.CreateWebhook(ctx, subscription); err != nil {
+		err = mapWebhookRepoError(err)
+	}
+	return
+}
+
+// UpdateWebhook replaces a subscription's URL and event filter and optionally
+// toggles it. The signing secret is kept.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, params UpdateWebhookParams) (subscription WebhookSubscription, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+	if !params.Principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if s.webhooks == nil {
+		err = fmt.Errorf("webhook repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "UpdateWebhook",
+		"principal_id", params.Principal.UserID,
+		"webhook_id", params.WebhookID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to update webhook", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.InfoContext(ctx, "webhook updated")
+	}()
+
+	subscription, err = s.webhooks.GetWebhook(ctx, params.WebhookID)
+	if err != nil {
+		err = mapWebhookRepoError(err)
+		return
+	}
+
+	input, vErr := normalizeWebhookInput(params.Input)
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+
+	subscription.URL = input.URL
+	subscription.EventTypes = input.EventTypes
+	if input.Active != nil {
+		subscription.Active = *input.Active
+	}
+	subscription.UpdatedAt = s.now()
+
+	if err = s.webhooks.UpdateWebhook(ctx, subscription); err != nil {
+		err = mapWebhookRepoError(err)
+	}
+	return
+}
+
+// DeleteWebhook removes a subscription and its delivery log for administrators.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, principal Principal, webhookID string) error {
+	if s == nil {
+		return fmt.Errorf("WebhookService is nil")
+	}
+	if !principal.IsAdmin {
+		return ErrUnauthorized
+	}
+	if s.webhooks == nil {
+		return fmt.Errorf("webhook repository not configured")
+	}
+
+	logger := s.loggerWith(ctx, "DeleteWebhook",
+		"principal_id", principal.UserID,
+		"webhook_id", webhookID,
+	)
+
+	if err := s.webhooks.DeleteWebhook(ctx, webhookID); err != nil {
+		err = mapWebhookRepoError(err)
+		logger.ErrorContext(ctx, "failed to delete webhook", "error", err, "error_kind", ErrorKind(err))
+		return err
+	}
+
+	logger.InfoContext(ctx, "webhook deleted")
+	return nil
+}
+
+// ListWebhooks returns every subscription for administrators.
+func (s *WebhookService) ListWebhooks(ctx context.Context, principal Principal) (subscriptions []WebhookSubscription, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+	if !principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if s.webhooks == nil {
+		return nil, nil
+	}
+
+	subscriptions, err = s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		err = mapWebhookRepoError(err)
+		s.loggerWith(ctx, "ListWebhooks", "principal_id", principal.UserID).
+			ErrorContext(ctx, "failed to list webhooks", "error", err, "error_kind", ErrorKind(err))
+	}
+	return
+}
+
+// ListWebhookDeliveries returns the most recent deliveries of a subscription
+// for administrators. limit defaults to DefaultPageSize.
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, principal Principal, webhookID string, limit int) (deliveries []WebhookDelivery, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+	if !principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+
+	limit, _, vErr := resolvePage(limit, "")
+	if vErr.HasErrors() {
+		err = vErr
+		return
+	}
+	if s.webhooks == nil {
+		return nil, nil
+	}
+
+	logger := s.loggerWith(ctx, "ListWebhookDeliveries",
+		"principal_id", principal.UserID,
+		"webhook_id", webhookID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to list webhook deliveries", "error", err, "error_kind", ErrorKind(err))
+		}
+	}()
+
+	if _, err = s.webhooks.GetWebhook(ctx, webhookID); err != nil {
+		err = mapWebhookRepoError(err)
+		return
+	}
+	deliveries, err = s.webhooks.ListWebhookDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		err = mapWebhookRepoError(err)
+	}
+	return
+}
+
+// RedeliverWebhook queues a fresh delivery of the event behind deliveryID.
+// The original delivery and its log are kept.
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, principal Principal, webhookID, deliveryID string) (delivery WebhookDelivery, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+	if !principal.IsAdmin {
+		err = ErrUnauthorized
+		return
+	}
+	if s.webhooks == nil {
+		err = fmt.Errorf("webhook repository not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "RedeliverWebhook",
+		"principal_id", principal.UserID,
+		"webhook_id", webhookID,
+		"delivery_id", deliveryID,
+	)
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to redeliver webhook", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		logger.With("redelivery_id", delivery.ID).InfoContext(ctx, "webhook redelivery queued")
+	}()
+
+	original, err := s.webhooks.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		err = mapWebhookRepoError(err)
+		return
+	}
+	if original.SubscriptionID != webhookID {
+		err = ErrNotFound
+		return
+	}
+
+	now := s.now()
+	delivery = WebhookDelivery{
+		ID:             s.idGenerator(),
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err = s.webhooks.CreateWebhookDelivery(ctx, delivery); err != nil {
+		err = mapWebhookRepoError(err)
+	}
+	return
+}
+
+// Run fans out and delivers webhooks every interval until ctx is cancelled.
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Failures are logged by DispatchPending and retried on the next tick.
+		_, _ = s.DispatchPending(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending turns outbox events into deliveries for every matching
+// active subscription, then attempts the deliveries that are due.
+func (s *WebhookService) DispatchPending(ctx context.Context) (result WebhookDispatchResult, err error) {
+	if s == nil {
+		err = fmt.Errorf("WebhookService is nil")
+		return
+	}
+	if s.webhooks == nil || s.poster == nil {
+		err = fmt.Errorf("webhook dependencies not configured")
+		return
+	}
+
+	logger := s.loggerWith(ctx, "DispatchPending")
+	defer func() {
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to dispatch webhooks", "error", err, "error_kind", ErrorKind(err))
+			return
+		}
+		if result != (WebhookDispatchResult{}) {
+			logger.With(
+				"enqueued_count", result.Enqueued,
+				"succeeded_count", result.Succeeded,
+				"retrying_count", result.Retrying,
+				"failed_count", result.Failed,
+			).InfoContext(ctx, "webhooks dispatched")
+		}
+	}()
+
+	if result.Enqueued, err = s.fanOut(ctx); err != nil {
+		return
+	}
+
+	due, err := s.webhooks.ListDueWebhookDeliveries(ctx, s.now(), webhookBatchSize)
+	if err != nil {
+		err = mapWebhookRepoError(err)
+		return
+	}
+	subscriptions := make(map[string]*WebhookSubscription)
+	for _, delivery := range due {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			loaded, getErr := s.webhooks.GetWebhook(ctx, delivery.SubscriptionID)
+			switch {
+			case getErr == nil:
+				subscription = &loaded
+			case errors.Is(mapWebhookRepoError(getErr), ErrNotFound):
+			default:
+				err = mapWebhookRepoError(getErr)
+				return
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil {
+			continue
+		}
+
+		delivery = s.attempt(ctx, *subscription, delivery)
+		if err = s.webhooks.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			err = mapWebhookRepoError(err)
+			return
+		}
+		switch delivery.Status {
+		case WebhookDeliverySucceeded:
+			result.Succeeded++
+		case WebhookDeliveryFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+	return
+}
+
+// fanOut enqueues one delivery per matching active subscription for each
+// pending outbox event and returns the number of deliveries created.
+func (s *WebhookService) fanOut(ctx context.Context) (int, error) {
+	events, err := s.webhooks.ListPendingOutboxEvents(ctx, webhookBatchSize)
+	if err != nil || len(events) == 0 {
+		return 0, mapWebhookRepoError(err)
+	}
+	subscriptions, err := s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return 0, mapWebhookRepoError(err)
+	}
+
+	enqueued := 0
+	for _, event := range events {
+		now := s.now()
+		var deliveries []WebhookDelivery
+		for _, subscription := range subscriptions {
+			if !subscription.Active || !subscribesTo(subscription, event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, WebhookDelivery{
+				ID:             s.idGenerator(),
+				SubscriptionID: subscription.ID,
+				EventID:        event.ID,
+				EventType:      event.Type,
+				Status:         WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+		if err := s.webhooks.EnqueueWebhookDeliveries(ctx, event.ID, deliveries, now); err != nil {
+			return enqueued, mapWebhookRepoError(err)
+		}
+		enqueued += len(deliveries)
+	}
+	return enqueued, nil
+}
+
+// attempt posts delivery once and returns it with the outcome recorded.
+func (s *WebhookService) attempt(ctx context.Context, subscription WebhookSubscription, delivery WebhookDelivery) WebhookDelivery {
+	now := s.now()
+	status, postErr := s.poster.Post(ctx, notification.SignedRequest{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventType:  string(delivery.EventType),
+		DeliveryID: delivery.ID,
+		Timestamp:  now,
+		Body:       delivery.Payload,
+	})
+
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+	if postErr == nil {
+		delivery.Status = WebhookDeliverySucceeded
+		delivery.LastError = ""
+		return delivery
+	}
+
+	delivery.LastError = postErr.Error()
+	if delivery.Attempts >= maxWebhookAttempts {
+		delivery.Status = WebhookDeliveryFailed
+	} else {
+		delivery.Status = WebhookDeliveryPending
+		delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+	}
+	s.loggerWith(ctx, "DispatchPending",
+		"webhook_id", subscription.ID,
+		"delivery_id", delivery.ID,
+		"attempt", delivery.Attempts,
+		"response_status", status,
+	).WarnContext(ctx, "webhook delivery failed", "error", postErr)
+	return delivery
+}
+
+// webhookBackoff returns the delay after the given number of failed attempts.
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookBaseBackoff
+	for i := 1; i < attempts && delay < webhookMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxBackoff {
+		delay = webhookMaxBackoff
+	}
+	return delay
+}
+
+func subscribesTo(subscription WebhookSubscription, eventType EventType) bool {
+	return len(subscription.EventTypes) == 0 || containsString(subscription.EventTypes, string(eventType))
+}
+
+func normalizeWebhookInput(input WebhookInput) (WebhookInput, *ValidationError) {
+	vErr := &ValidationError{}
+
+	input.URL = strings.TrimSpace(input.URL)
+	if input.URL == "" {
+		vErr.add("url", "url is required")
+	} else if parsed, err := url.Parse(input.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		vErr.add("url", "url must be an absolute http or https URL")
+	}
+
+	input.EventTypes = normalizeNames(input.EventTypes)
+	for _, eventType := range input.EventTypes {
+		if !isKnownEventType(eventType) {
+			vErr.add("event_types", "event type is invalid")
+			break
+		}
+	}
+
+	return input, vErr
+}
+
+func mapWebhookRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, persistence.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,383 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/notification"
+)
+
+type webhookRepoStub struct {
+	mu            sync.Mutex
+	subscriptions map[string]WebhookSubscription
+	events        []OutboxEvent
+	dispatched    map[string]bool
+	deliveries    []WebhookDelivery
+	appendErr     error
+}
+
+func newWebhookRepoStub(subscriptions ...WebhookSubscription) *webhookRepoStub {
+	repo := &webhookRepoStub{subscriptions: make(map[string]WebhookSubscription), dispatched: make(map[string]bool)}
+	for _, subscription := range subscriptions {
+		repo.subscriptions[subscription.ID] = subscription
+	}
+	return repo
+}
+
+func (r *webhookRepoStub) CreateWebhook(ctx context.Context, subscription WebhookSubscription) error {
+	r.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (r *webhookRepoStub) GetWebhook(ctx context.Context, id string) (WebhookSubscription, error) {
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return WebhookSubscription{}, ErrNotFound
+	}
+	return subscription, nil
+}
+
+func (r *webhookRepoStub) UpdateWebhook(ctx context.Context, subscription WebhookSubscription) error {
+	r.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (r *webhookRepoStub) DeleteWebhook(ctx context.Context, id string) error {
+	if _, ok := r.subscriptions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *webhookRepoStub) ListWebhooks(ctx context.Context) ([]WebhookSubscription, error) {
+	out := make([]WebhookSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		out = append(out, subscription)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *webhookRepoStub) AppendOutboxEvent(ctx context.Context, event OutboxEvent) error {
+	if r.appendErr != nil {
+		return r.appendErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.Sequence = int64(len(r.events) + 1)
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *webhookRepoStub) ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []OutboxEvent
+	for _, event := range r.events {
+		if event.Sequence > after && len(out) < limit {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (r *webhookRepoStub) LatestOutboxSequence(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.events)), nil
+}
+
+func (r *webhookRepoStub) ListPendingOutboxEvents(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	var out []OutboxEvent
+	for _, event := range r.events {
+		if !r.dispatched[event.ID] {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (r *webhookRepoStub) EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []WebhookDelivery, dispatchedAt time.Time) error {
+	r.deliveries = append(r.deliveries, deliveries...)
+	r.dispatched[eventID] = true
+	return nil
+}
+
+func (r *webhookRepoStub) CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *webhookRepoStub) GetWebhookDelivery(ctx context.Context, id string) (WebhookDelivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return WebhookDelivery{}, ErrNotFound
+}
+
+func (r *webhookRepoStub) UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error {
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == delivery.ID {
+			delivery.Payload = nil
+			r.deliveries[i] = delivery
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *webhookRepoStub) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	var out []WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != WebhookDeliveryPending || delivery.NextAttemptAt.After(now) || !r.subscriptions[delivery.SubscriptionID].Active {
+			continue
+		}
+		for _, event := range r.events {
+			if event.ID == delivery.EventID {
+				delivery.Payload = event.Payload
+			}
+		}
+		out = append(out, delivery)
+	}
+	return out, nil
+}
+
+func (r *webhookRepoStub) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]WebhookDelivery, error) {
+	var out []WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			out = append(out, delivery)
+		}
+	}
+	return out, nil
+}
+
+type posterStub struct {
+	status   int
+	err      error
+	requests []notification.SignedRequest
+}
+
+func (p *posterStub) Post(ctx context.Context, req notification.SignedRequest) (int, error) {
+	p.requests = append(p.requests, req)
+	return p.status, p.err
+}
+
+func TestWebhookService_DispatchPending(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, time.May, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("delivers events to matching active subscriptions once", func(t *testing.T) {
+		current := base
+		repo := newWebhookRepoStub(
+			WebhookSubscription{ID: "all", URL: "https://hooks.example.com/all", Secret: "s1", Active: true},
+			WebhookSubscription{ID: "rooms", URL: "https://hooks.example.com/rooms", Secret: "s2", EventTypes: []string{"room.created"}, Active: true},
+			WebhookSubscription{ID: "paused", URL: "https://hooks.example.com/paused", Secret: "s3"},
+		)
+		poster := &posterStub{status: 200}
+		svc := NewWebhookService(repo, poster, sequentialIDs(), nil, func() time.Time { return current })
+
+		err := svc.PublishEvent(ctx, Event{
+			Type:       EventScheduleCreated,
+			ResourceID: "schedule-1",
+			ActorID:    "user-1",
+			Data:       deletedEventData{ID: "schedule-1"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result, err := svc.DispatchPending(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Enqueued != 1 || result.Succeeded != 1 || len(poster.requests) != 1 {
+			t.Fatalf("expected one delivery to the catch-all subscription, got %+v (%d requests)", result, len(poster.requests))
+		}
+		req := poster.requests[0]
+		if req.URL != "https://hooks.example.com/all" || req.Secret != "s1" || req.EventType != "schedule.created" {
+			t.Fatalf("unexpected request: %+v", req)
+		}
+		var envelope eventEnvelope
+		if err := json.Unmarshal(req.Body, &envelope); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if envelope.Type != "schedule.created" || envelope.ResourceID != "schedule-1" || envelope.ActorID != "user-1" || envelope.ID == "" {
+			t.Fatalf("unexpected envelope: %+v", envelope)
+		}
+
+		if result, err = svc.DispatchPending(ctx); err != nil || result != (WebhookDispatchResult{}) {
+			t.Fatalf("expected nothing left to dispatch, got %+v (err %v)", result, err)
+		}
+	})
+
+	t.Run("retries with exponential backoff and gives up", func(t *testing.T) {
+		current := base
+		repo := newWebhookRepoStub(WebhookSubscription{ID: "all", URL: "https://hooks.example.com/all", Active: true})
+		poster := &posterStub{status: 503, err: errors.New("webhook responded with status 503")}
+		svc := NewWebhookService(repo, poster, sequentialIDs(), nil, func() time.Time { return current })
+
+		if err := svc.PublishEvent(ctx, Event{Type: EventRoomDeleted, ResourceID: "room-1"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result, _ := svc.DispatchPending(ctx); result.Retrying != 1 {
+			t.Fatalf("expected the delivery to be rescheduled, got %+v", result)
+		}
+		if next := repo.deliveries[0].NextAttemptAt; !next.Equal(base.Add(30 * time.Second)) {
+			t.Fatalf("expected next attempt after 30s, got %v", next)
+		}
+
+		current = base.Add(29 * time.Second)
+		if _, _ = svc.DispatchPending(ctx); len(poster.requests) != 1 {
+			t.Fatalf("expected no attempt before the backoff elapsed, got %d", len(poster.requests))
+		}
+
+		current = base.Add(30 * time.Second)
+		if _, _ = svc.DispatchPending(ctx); len(poster.requests) != 2 {
+			t.Fatalf("expected a second attempt, got %d", len(poster.requests))
+		}
+		if next := repo.deliveries[0].NextAttemptAt; !next.Equal(current.Add(time.Minute)) {
+			t.Fatalf("expected the backoff to double, got %v", next.Sub(current))
+		}
+
+		for i := 0; i < maxWebhookAttempts; i++ {
+			current = current.Add(webhookMaxBackoff)
+			if _, err := svc.DispatchPending(ctx); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		delivery := repo.deliveries[0]
+		if len(poster.requests) != maxWebhookAttempts || delivery.Status != WebhookDeliveryFailed {
+			t.Fatalf("expected %d attempts and a failed delivery, got %d attempts and %+v", maxWebhookAttempts, len(poster.requests), delivery)
+		}
+		if delivery.ResponseStatus != 503 || delivery.LastError == "" {
+			t.Fatalf("expected the last response to be logged, got %+v", delivery)
+		}
+	})
+}
+
+func TestWebhookService_ManageSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	admin := Principal{UserID: "admin", IsAdmin: true}
+	now := time.Date(2024, time.May, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("creates subscriptions with a generated secret", func(t *testing.T) {
+		repo := newWebhookRepoStub()
+		svc := NewWebhookService(repo, nil, func() string { return "webhook-1" }, func() string { return "secret" }, func() time.Time { return now })
+
+		subscription, err := svc.CreateWebhook(ctx, CreateWebhookParams{
+			Principal: admin,
+			Input:     WebhookInput{URL: " https://hooks.example.com/a ", EventTypes: []string{"Schedule.Created", "schedule.created"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if subscription.Secret != "secret" || !subscription.Active || len(subscription.EventTypes) != 1 || subscription.URL != "https://hooks.example.com/a" {
+			t.Fatalf("unexpected subscription: %+v", subscription)
+		}
+	})
+
+	t.Run("validates url and event types", func(t *testing.T) {
+		svc := NewWebhookService(newWebhookRepoStub(), nil, nil, nil, nil)
+
+		_, err := svc.CreateWebhook(ctx, CreateWebhookParams{
+			Principal: admin,
+			Input:     WebhookInput{URL: "ftp://hooks.example.com", EventTypes: []string{"meeting.moved"}},
+		})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.FieldErrors["url"] == "" || vErr.FieldErrors["event_types"] == "" {
+			t.Fatalf("expected url and event type errors, got %v", err)
+		}
+	})
+
+	t.Run("requires administrator privileges", func(t *testing.T) {
+		svc := NewWebhookService(newWebhookRepoStub(), nil, nil, nil, nil)
+
+		if _, err := svc.ListWebhooks(ctx, Principal{UserID: "user-1"}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("redelivers into a new delivery", func(t *testing.T) {
+		repo := newWebhookRepoStub(WebhookSubscription{ID: "webhook-1", Active: true})
+		repo.deliveries = []WebhookDelivery{{ID: "delivery-0", SubscriptionID: "webhook-1", EventID: "event-1", EventType: EventUserCreated, Status: WebhookDeliveryFailed, Attempts: maxWebhookAttempts}}
+		svc := NewWebhookService(repo, nil, sequentialIDs(), nil, func() time.Time { return now })
+
+		if _, err := svc.RedeliverWebhook(ctx, admin, "other", "delivery-0"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound for a foreign delivery, got %v", err)
+		}
+		delivery, err := svc.RedeliverWebhook(ctx, admin, "webhook-1", "delivery-0")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if delivery.ID == "delivery-0" || delivery.EventID != "event-1" || delivery.Status != WebhookDeliveryPending || delivery.Attempts != 0 {
+			t.Fatalf("unexpected redelivery: %+v", delivery)
+		}
+		if len(repo.deliveries) != 2 || repo.deliveries[0].Status != WebhookDeliveryFailed {
+			t.Fatalf("expected the original delivery to be kept, got %+v", repo.deliveries)
+		}
+	})
+}
+
+func TestServices_PublishEvents(t *testing.T) {
+	ctx := context.Background()
+	admin := Principal{UserID: "admin", IsAdmin: true}
+
+	t.Run("records mutations in the outbox", func(t *testing.T) {
+		repo := newWebhookRepoStub()
+		events := NewWebhookService(repo, nil, sequentialIDs(), nil, nil)
+
+		rooms := NewRoomService(&roomRepoStub{}, func() string { return "room-1" }, nil).WithEvents(events)
+		if _, err := rooms.CreateRoom(ctx, CreateRoomParams{Principal: admin, Input: RoomInput{Name: "A", Location: "1F", Capacity: 4}}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		users := NewUserService(&userRepoStub{}, func() string { return "user-1" }, nil).WithEvents(events)
+		if err := users.DeleteUser(ctx, admin, "user-1"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		start := mustJST(t, 10)
+		schedules := NewScheduleService(&scheduleRepoStub{}, &userDirectoryStub{}, &roomCatalogStub{exists: true}, nil, func() string { return "schedule-1" }, nil).WithEvents(events)
+		_, _, err := schedules.CreateSchedule(ctx, CreateScheduleParams{
+			Principal: Principal{UserID: "user-1"},
+			Input:     ScheduleInput{CreatorID: "user-1", Title: "定例", Start: start, End: start.Add(time.Hour), ParticipantIDs: []string{"user-1"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(repo.events) != 3 {
+			t.Fatalf("expected three events, got %d", len(repo.events))
+		}
+		want := []EventType{EventRoomCreated, EventUserDeleted, EventScheduleCreated}
+		for i, event := range repo.events {
+			if event.Type != want[i] {
+				t.Fatalf("event %d: expected %s, got %s", i, want[i], event.Type)
+			}
+		}
+		var envelope struct {
+			Data scheduleEventData `json:"data"`
+		}
+		if err := json.Unmarshal(repo.events[2].Payload, &envelope); err != nil || envelope.Data.Title != "定例" {
+			t.Fatalf("unexpected schedule payload %s (err %v)", repo.events[2].Payload, err)
+		}
+	})
+
+	t.Run("fails the mutation when the event cannot be recorded", func(t *testing.T) {
+		repo := newWebhookRepoStub()
+		repo.appendErr = errors.New("disk full")
+		events := NewWebhookService(repo, nil, sequentialIDs(), nil, nil)
+		rooms := NewRoomService(&roomRepoStub{}, nil, nil).WithEvents(events)
+
+		if err := rooms.DeleteRoom(ctx, admin, "room-1"); err == nil {
+			t.Fatal("expected the outbox failure to be reported")
+		}
+	})
+}
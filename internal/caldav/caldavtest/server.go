@@ -0,0 +1,222 @@
+// Package caldavtest provides an in-memory CalDAV collection served over
+// HTTP, standing in for an external calendar server in tests.
+package caldavtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/example/enterprise-scheduler/internal/caldav"
+)
+
+// Credentials accepted by the server.
+const (
+	Username = "sync-user"
+	Password = "sync-password"
+)
+
+// CollectionPath is the path of the single calendar collection served.
+const CollectionPath = "/calendars/sync-user/work/"
+
+// Server serves one calendar collection supporting PROPFIND, the
+// calendar-multiget REPORT and conditional PUT and DELETE. ETags change on
+// every write.
+type Server struct {
+	*httptest.Server
+
+	// OmitPutETag makes PUT responses omit the ETag header, as servers that
+	// rewrite objects do.
+	OmitPutETag bool
+
+	mu      sync.Mutex
+	objects map[string]object
+	version int
+}
+
+type object struct {
+	etag string
+	data []byte
+}
+
+// NewServer starts a server with an empty collection. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{objects: map[string]object{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Collection returns the collection with valid credentials.
+func (s *Server) Collection() caldav.Collection {
+	return caldav.Collection{URL: s.URL + CollectionPath, Username: Username, Password: Password}
+}
+
+// Href returns the path of the object called name.
+func (s *Server) Href(name string) string {
+	return CollectionPath + name
+}
+
+// PutObject stores an object as if another client had written it and returns
+// its ETag.
+func (s *Server) PutObject(name string, data []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store(s.Href(name), data)
+}
+
+// DeleteObject removes an object as if another client had deleted it.
+func (s *Server) DeleteObject(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, s.Href(name))
+}
+
+// Object returns the data and ETag of the object at href.
+func (s *Server) Object(href string) ([]byte, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[href]
+	return obj.data, obj.etag, ok
+}
+
+// Hrefs returns the paths of all objects, sorted.
+func (s *Server) Hrefs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.objects)
+}
+
+func (s *Server) store(href string, data []byte) string {
+	s.version++
+	etag := `"v` + strconv.Itoa(s.version) + `"`
+	s.objects[href] = object{etag: etag, data: append([]byte(nil), data...)}
+	return etag
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if username, password, ok := r.BasicAuth(); !ok || username != Username || password != Password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="caldavtest"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, CollectionPath) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	href := r.URL.EscapedPath()
+	isCollection := href == CollectionPath
+	switch {
+	case r.Method == "PROPFIND" && isCollection:
+		responses := []string{response(CollectionPath, "<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>")}
+		if r.Header.Get("Depth") != "0" {
+			for _, href := range sortedKeys(s.objects) {
+				responses = append(responses, response(href, "<d:resourcetype/><d:getetag>"+escape(s.objects[href].etag)+"</d:getetag>"))
+			}
+		}
+		writeMultistatus(w, responses)
+	case r.Method == "PROPFIND":
+		obj, ok := s.objects[href]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeMultistatus(w, []string{response(href, "<d:getetag>"+escape(obj.etag)+"</d:getetag>")})
+	case r.Method == "REPORT" && isCollection:
+		var report struct {
+			Hrefs []string `xml:"DAV: href"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&report); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var responses []string
+		for _, href := range report.Hrefs {
+			obj, ok := s.objects[href]
+			if !ok {
+				responses = append(responses, "<d:response><d:href>"+escape(href)+"</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>")
+				continue
+			}
+			responses = append(responses, response(href, "<d:getetag>"+escape(obj.etag)+"</d:getetag><c:calendar-data>"+escape(string(obj.data))+"</c:calendar-data>"))
+		}
+		writeMultistatus(w, responses)
+	case r.Method == http.MethodPut && !isCollection:
+		current, exists := s.objects[href]
+		if !preconditionsHold(r, current.etag, exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		etag := s.store(href, data)
+		if !s.OmitPutETag {
+			w.Header().Set("ETag", etag)
+		}
+		if exists {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete && !isCollection:
+		current, exists := s.objects[href]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !preconditionsHold(r, current.etag, exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(s.objects, href)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func preconditionsHold(r *http.Request, etag string, exists bool) bool {
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || match != etag) {
+		return false
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		return false
+	}
+	return true
+}
+
+func response(href, props string) string {
+	return fmt.Sprintf("<d:response><d:href>%s</d:href><d:propstat><d:prop>%s</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>", escape(href), props)
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []string) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`+strings.Join(responses, "")+`</d:multistatus>`)
+}
+
+func escape(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+func sortedKeys(objects map[string]object) []string {
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
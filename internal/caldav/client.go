@@ -0,0 +1,326 @@
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Errors reported for conditional requests and missing objects.
+var (
+	ErrNotFound           = errors.New("caldav: object not found")
+	ErrPreconditionFailed = errors.New("caldav: precondition failed")
+)
+
+const (
+	defaultClientTimeout = 30 * time.Second
+	// multigetBatchSize bounds the hrefs requested per calendar-multiget.
+	multigetBatchSize = 50
+	// maxResponseBytes bounds multistatus and error bodies read from a server.
+	maxResponseBytes = 16 << 20
+)
+
+// Collection identifies a remote calendar collection and the credentials used
+// to access it with basic auth.
+type Collection struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// Href returns the absolute path of a new object called name in the collection.
+func (c Collection) Href(name string) (string, error) {
+	base, err := url.Parse(c.URL)
+	if err != nil {
+		return "", fmt.Errorf("parse collection url: %w", err)
+	}
+	return strings.TrimSuffix(base.EscapedPath(), "/") + "/" + url.PathEscape(name), nil
+}
+
+// Object is a calendar object in a remote collection. Data is only set by Fetch.
+type Object struct {
+	Href string
+	ETag string
+	Data []byte
+}
+
+// Client talks to CalDAV servers over HTTP.
+type Client struct {
+	client *http.Client
+}
+
+// NewClient constructs a client. A nil client uses one with a thirty second
+// timeout.
+func NewClient(client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: defaultClientTimeout}
+	}
+	return &Client{client: client}
+}
+
+// List returns the href and ETag of every calendar object in the collection.
+func (c *Client) List(ctx context.Context, collection Collection) ([]Object, error) {
+	body := `<?xml version="1.0" encoding="utf-8"?>` +
+		`<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getetag/></d:prop></d:propfind>`
+	ms, err := c.multistatus(ctx, collection, "PROPFIND", collection.URL, "1", body)
+	if err != nil {
+		return nil, err
+	}
+
+	self, err := c.resolve(collection, collection.URL)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]Object, 0, len(ms.Responses))
+	for _, response := range ms.Responses {
+		href, err := c.resolve(collection, response.Href)
+		if err != nil {
+			return nil, err
+		}
+		props, ok := response.found()
+		if !ok || strings.TrimSuffix(href, "/") == strings.TrimSuffix(self, "/") || props.ResourceType.Collection != nil {
+			continue
+		}
+		objects = append(objects, Object{Href: href, ETag: props.ETag})
+	}
+	return objects, nil
+}
+
+// Fetch returns the objects at hrefs with their data. Hrefs the server no
+// longer holds are omitted.
+func (c *Client) Fetch(ctx context.Context, collection Collection, hrefs []string) ([]Object, error) {
+	var objects []Object
+	for start := 0; start < len(hrefs); start += multigetBatchSize {
+		end := min(start+multigetBatchSize, len(hrefs))
+
+		var body strings.Builder
+		body.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+		body.WriteString(`<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+		body.WriteString(`<d:prop><d:getetag/><c:calendar-data/></d:prop>`)
+		for _, href := range hrefs[start:end] {
+			body.WriteString("<d:href>")
+			_ = xml.EscapeText(&body, []byte(href))
+			body.WriteString("</d:href>")
+		}
+		body.WriteString(`</c:calendar-multiget>`)
+
+		ms, err := c.multistatus(ctx, collection, "REPORT", collection.URL, "1", body.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, response := range ms.Responses {
+			props, ok := response.found()
+			if !ok || props.CalendarData == "" {
+				continue
+			}
+			href, err := c.resolve(collection, response.Href)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, Object{Href: href, ETag: props.ETag, Data: []byte(props.CalendarData)})
+		}
+	}
+	return objects, nil
+}
+
+// Put writes data to href and returns the object's new ETag. An empty etag
+// only creates the object (If-None-Match: *); otherwise the object is only
+// replaced while it still has etag. Either mismatch reports
+// ErrPreconditionFailed.
+func (c *Client) Put(ctx context.Context, collection Collection, href string, data []byte, etag string) (string, error) {
+	req, err := c.newRequest(ctx, collection, http.MethodPut, href, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if etag == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else {
+		req.Header.Set("If-Match", etag)
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	if newETag := res.Header.Get("ETag"); newETag != "" {
+		return newETag, nil
+	}
+
+	// Servers that rewrite the object omit the ETag (RFC 4791 section 5.3.4),
+	// so it is read back.
+	body := `<?xml version="1.0" encoding="utf-8"?>` +
+		`<d:propfind xmlns:d="DAV:"><d:prop><d:getetag/></d:prop></d:propfind>`
+	ms, err := c.multistatus(ctx, collection, "PROPFIND", href, "0", body)
+	if err != nil {
+		return "", err
+	}
+	for _, response := range ms.Responses {
+		if props, ok := response.found(); ok && props.ETag != "" {
+			return props.ETag, nil
+		}
+	}
+	return "", fmt.Errorf("caldav: server returned no etag for %s", href)
+}
+
+// Delete removes the object at href while it still has etag. An empty etag
+// deletes unconditionally.
+func (c *Client) Delete(ctx context.Context, collection Collection, href, etag string) error {
+	req, err := c.newRequest(ctx, collection, http.MethodDelete, href, nil)
+	if err != nil {
+		return err
+	}
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	_, err = c.do(req)
+	return err
+}
+
+func (c *Client) multistatus(ctx context.Context, collection Collection, method, target, depth, body string) (multistatus, error) {
+	req, err := c.newRequest(ctx, collection, method, target, strings.NewReader(body))
+	if err != nil {
+		return multistatus{}, err
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", depth)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return multistatus{}, fmt.Errorf("caldav %s %s: %w", method, req.URL.Redacted(), err)
+	}
+	defer res.Body.Close()
+	if err := statusError(res); err != nil {
+		return multistatus{}, err
+	}
+	if res.StatusCode != http.StatusMultiStatus {
+		return multistatus{}, fmt.Errorf("caldav %s %s: expected multistatus, got status %d", method, req.URL.Redacted(), res.StatusCode)
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(&ms); err != nil {
+		return multistatus{}, fmt.Errorf("caldav %s %s: decode multistatus: %w", method, req.URL.Redacted(), err)
+	}
+	return ms, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, collection Collection, method, target string, body io.Reader) (*http.Request, error) {
+	if c == nil || c.client == nil {
+		return nil, errors.New("caldav client is nil")
+	}
+	base, err := url.Parse(collection.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse collection url: %w", err)
+	}
+	ref, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("parse href %q: %w", target, err)
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != base.Scheme || resolved.Host != base.Host {
+		return nil, fmt.Errorf("caldav: href %q is outside the collection's server", target)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, resolved.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("build caldav request: %w", err)
+	}
+	if collection.Username != "" || collection.Password != "" {
+		req.SetBasicAuth(collection.Username, collection.Password)
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("caldav %s %s: %w", req.Method, req.URL.Redacted(), err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBytes))
+	if err := statusError(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// resolve returns href as an absolute, escaped path on the collection's server.
+func (c *Client) resolve(collection Collection, href string) (string, error) {
+	base, err := url.Parse(collection.URL)
+	if err != nil {
+		return "", fmt.Errorf("parse collection url: %w", err)
+	}
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return "", fmt.Errorf("parse href %q: %w", href, err)
+	}
+	resolved := base.ResolveReference(ref)
+	escaped := resolved.EscapedPath()
+	if strings.HasSuffix(escaped, "/") {
+		return path.Clean(escaped) + "/", nil
+	}
+	return path.Clean(escaped), nil
+}
+
+func statusError(res *http.Response) error {
+	switch {
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		return ErrNotFound
+	case res.StatusCode == http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return fmt.Errorf("caldav %s %s: server responded with status %d", res.Request.Method, res.Request.URL.Redacted(), res.StatusCode)
+	}
+	return nil
+}
+
+type multistatus struct {
+	Responses []msResponse `xml:"DAV: response"`
+}
+
+type msResponse struct {
+	Href      string       `xml:"DAV: href"`
+	Status    string       `xml:"DAV: status"`
+	Propstats []msPropstat `xml:"DAV: propstat"`
+}
+
+type msPropstat struct {
+	Status string `xml:"DAV: status"`
+	Prop   msProp `xml:"DAV: prop"`
+}
+
+type msProp struct {
+	ETag         string `xml:"DAV: getetag"`
+	CalendarData string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+	} `xml:"DAV: resourcetype"`
+}
+
+// found returns the properties the server reported with a 2xx status.
+func (r msResponse) found() (msProp, bool) {
+	if r.Status != "" && !successStatus(r.Status) {
+		return msProp{}, false
+	}
+	for _, propstat := range r.Propstats {
+		if successStatus(propstat.Status) {
+			return propstat.Prop, true
+		}
+	}
+	return msProp{}, false
+}
+
+// successStatus reports whether an HTTP status line such as
+// "HTTP/1.1 200 OK" carries a 2xx code.
+func successStatus(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) >= 2 && strings.HasPrefix(fields[1], "2")
+}
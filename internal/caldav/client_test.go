@@ -0,0 +1,96 @@
+package caldav_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/example/enterprise-scheduler/internal/caldav"
+	"github.com/example/enterprise-scheduler/internal/caldav/caldavtest"
+)
+
+const testObject = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:a&b\r\nSUMMARY:<Planning>\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func TestClient(t *testing.T) {
+	server := caldavtest.NewServer()
+	t.Cleanup(server.Close)
+	collection := server.Collection()
+	client := caldav.NewClient(nil)
+	ctx := context.Background()
+
+	etag := server.PutObject("remote.ics", []byte(testObject))
+
+	t.Run("lists objects without the collection itself", func(t *testing.T) {
+		objects, err := client.List(ctx, collection)
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(objects) != 1 || objects[0].Href != server.Href("remote.ics") || objects[0].ETag != etag {
+			t.Fatalf("unexpected objects %#v", objects)
+		}
+	})
+
+	t.Run("fetches object data and skips missing hrefs", func(t *testing.T) {
+		objects, err := client.Fetch(ctx, collection, []string{server.Href("remote.ics"), server.Href("gone.ics")})
+		if err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+		if len(objects) != 1 || string(objects[0].Data) != testObject || objects[0].ETag != etag {
+			t.Fatalf("unexpected objects %#v", objects)
+		}
+	})
+
+	t.Run("creates and replaces objects conditionally", func(t *testing.T) {
+		href, err := collection.Href("new event.ics")
+		if err != nil {
+			t.Fatalf("Href returned error: %v", err)
+		}
+		if href != caldavtest.CollectionPath+"new%20event.ics" {
+			t.Fatalf("unexpected href %q", href)
+		}
+
+		created, err := client.Put(ctx, collection, href, []byte(testObject), "")
+		if err != nil {
+			t.Fatalf("Put returned error: %v", err)
+		}
+		if _, err := client.Put(ctx, collection, href, []byte(testObject), ""); !errors.Is(err, caldav.ErrPreconditionFailed) {
+			t.Fatalf("expected create over an existing object to fail, got %v", err)
+		}
+
+		server.OmitPutETag = true
+		replaced, err := client.Put(ctx, collection, href, []byte(testObject), created)
+		if err != nil {
+			t.Fatalf("Put returned error: %v", err)
+		}
+		if _, current, _ := server.Object(href); replaced == created || replaced != current {
+			t.Fatalf("expected the new etag to be read back, got %q (server %q)", replaced, current)
+		}
+		if _, err := client.Put(ctx, collection, href, []byte(testObject), created); !errors.Is(err, caldav.ErrPreconditionFailed) {
+			t.Fatalf("expected a stale etag to fail, got %v", err)
+		}
+	})
+
+	t.Run("deletes objects conditionally", func(t *testing.T) {
+		href := server.Href("remote.ics")
+		if err := client.Delete(ctx, collection, href, `"stale"`); !errors.Is(err, caldav.ErrPreconditionFailed) {
+			t.Fatalf("expected a stale etag to fail, got %v", err)
+		}
+		if err := client.Delete(ctx, collection, href, etag); err != nil {
+			t.Fatalf("Delete returned error: %v", err)
+		}
+		if err := client.Delete(ctx, collection, href, ""); !errors.Is(err, caldav.ErrNotFound) {
+			t.Fatalf("expected a missing object to be reported, got %v", err)
+		}
+	})
+
+	t.Run("rejects bad credentials and foreign hrefs", func(t *testing.T) {
+		bad := collection
+		bad.Password = "wrong"
+		if _, err := client.List(ctx, bad); err == nil {
+			t.Fatal("expected an authentication failure")
+		}
+		if err := client.Delete(ctx, collection, "https://elsewhere.example.com/x.ics", ""); err == nil {
+			t.Fatal("expected an href on another server to be refused")
+		}
+	})
+}
@@ -0,0 +1,9 @@
+// Package caldav is a minimal CalDAV (RFC 4791) client used to sync with
+// calendar collections on external servers.
+//
+// A Client lists the objects of a Collection with their ETags, fetches object
+// data with calendar-multiget and writes or deletes objects conditionally with
+// If-Match / If-None-Match. Object hrefs are absolute paths on the server, as
+// returned by the server's multistatus responses.
+// It does no retries or sync bookkeeping: both are owned by the caller.
+package caldav
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -15,6 +16,23 @@ type Config struct {
 	SessionSecret   string
 	SessionTTL      time.Duration
 	MaxRoomCapacity int
+
+	SMTPAddr           string
+	SMTPFrom           string
+	SMTPUsername       string
+	SMTPPassword       string
+	ReminderWebhookURL string
+	ReminderInterval   time.Duration
+
+	WebhookInterval     time.Duration
+	EventStreamInterval time.Duration
+	CalDAVSyncInterval  time.Duration
+	TrashRetention      time.Duration
+	TrashPurgeInterval  time.Duration
+	IdempotencyKeyTTL   time.Duration
+
+	OccurrenceHorizon         time.Duration
+	OccurrenceRefreshInterval time.Duration
 }
 
 // Load parses configuration values from the current process environment.
@@ -27,6 +45,17 @@ func Load() (Config, error) {
 		SQLiteDSN:       "file:scheduler.db?_foreign_keys=on",
 		SessionTTL:      24 * time.Hour,
 		MaxRoomCapacity: 0,
+
+		ReminderInterval:    time.Minute,
+		WebhookInterval:     10 * time.Second,
+		EventStreamInterval: time.Second,
+		CalDAVSyncInterval:  5 * time.Minute,
+		TrashRetention:      30 * 24 * time.Hour,
+		TrashPurgeInterval:  time.Hour,
+		IdempotencyKeyTTL:   24 * time.Hour,
+
+		OccurrenceHorizon:         365 * 24 * time.Hour,
+		OccurrenceRefreshInterval: time.Minute,
 	}
 
 	missing := make([]string, 0, 1)
@@ -69,6 +98,104 @@ func Load() (Config, error) {
 		}
 	}
 
+	cfg.SMTPAddr = strings.TrimSpace(os.Getenv("SCHEDULER_SMTP_ADDR"))
+	cfg.SMTPFrom = strings.TrimSpace(os.Getenv("SCHEDULER_SMTP_FROM"))
+	cfg.SMTPUsername = strings.TrimSpace(os.Getenv("SCHEDULER_SMTP_USERNAME"))
+	cfg.SMTPPassword = os.Getenv("SCHEDULER_SMTP_PASSWORD")
+	if cfg.SMTPAddr != "" && cfg.SMTPFrom == "" {
+		missing = append(missing, "SCHEDULER_SMTP_FROM")
+	}
+
+	if webhookURL := strings.TrimSpace(os.Getenv("SCHEDULER_REMINDER_WEBHOOK_URL")); webhookURL != "" {
+		parsed, err := url.Parse(webhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			invalid = append(invalid, "SCHEDULER_REMINDER_WEBHOOK_URL")
+		} else {
+			cfg.ReminderWebhookURL = webhookURL
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_REMINDER_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_REMINDER_INTERVAL")
+		} else {
+			cfg.ReminderInterval = interval
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_WEBHOOK_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_WEBHOOK_INTERVAL")
+		} else {
+			cfg.WebhookInterval = interval
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_EVENT_STREAM_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_EVENT_STREAM_INTERVAL")
+		} else {
+			cfg.EventStreamInterval = interval
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_CALDAV_SYNC_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_CALDAV_SYNC_INTERVAL")
+		} else {
+			cfg.CalDAVSyncInterval = interval
+		}
+	}
+
+	if retentionValue := strings.TrimSpace(os.Getenv("SCHEDULER_TRASH_RETENTION")); retentionValue != "" {
+		retention, err := time.ParseDuration(retentionValue)
+		if err != nil || retention <= 0 {
+			invalid = append(invalid, "SCHEDULER_TRASH_RETENTION")
+		} else {
+			cfg.TrashRetention = retention
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_TRASH_PURGE_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_TRASH_PURGE_INTERVAL")
+		} else {
+			cfg.TrashPurgeInterval = interval
+		}
+	}
+
+	if ttlValue := strings.TrimSpace(os.Getenv("SCHEDULER_IDEMPOTENCY_KEY_TTL")); ttlValue != "" {
+		ttl, err := time.ParseDuration(ttlValue)
+		if err != nil || ttl <= 0 {
+			invalid = append(invalid, "SCHEDULER_IDEMPOTENCY_KEY_TTL")
+		} else {
+			cfg.IdempotencyKeyTTL = ttl
+		}
+	}
+
+	if horizonValue := strings.TrimSpace(os.Getenv("SCHEDULER_OCCURRENCE_HORIZON")); horizonValue != "" {
+		horizon, err := time.ParseDuration(horizonValue)
+		if err != nil || horizon <= 0 {
+			invalid = append(invalid, "SCHEDULER_OCCURRENCE_HORIZON")
+		} else {
+			cfg.OccurrenceHorizon = horizon
+		}
+	}
+
+	if intervalValue := strings.TrimSpace(os.Getenv("SCHEDULER_OCCURRENCE_REFRESH_INTERVAL")); intervalValue != "" {
+		interval, err := time.ParseDuration(intervalValue)
+		if err != nil || interval <= 0 {
+			invalid = append(invalid, "SCHEDULER_OCCURRENCE_REFRESH_INTERVAL")
+		} else {
+			cfg.OccurrenceRefreshInterval = interval
+		}
+	}
+
 	if len(missing) > 0 {
 		return Config{}, fmt.Errorf("必須の環境変数が設定されていません: %s", strings.Join(missing, ", "))
 	}
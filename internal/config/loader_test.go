@@ -86,4 +86,193 @@ func TestLoader_ParseEnvironment(t *testing.T) {
 			t.Fatalf("unexpected DSN: %q", cfg.SQLiteDSN)
 		}
 	})
+	t.Run("parses reminder delivery settings", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+		t.Setenv("SCHEDULER_SMTP_ADDR", "smtp.example.com:587")
+		t.Setenv("SCHEDULER_SMTP_FROM", "scheduler@example.com")
+		t.Setenv("SCHEDULER_REMINDER_WEBHOOK_URL", "https://hooks.example.com/reminders")
+		t.Setenv("SCHEDULER_REMINDER_INTERVAL", "30s")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+
+		if cfg.SMTPAddr != "smtp.example.com:587" || cfg.SMTPFrom != "scheduler@example.com" {
+			t.Fatalf("unexpected SMTP settings: %q %q", cfg.SMTPAddr, cfg.SMTPFrom)
+		}
+		if cfg.ReminderWebhookURL != "https://hooks.example.com/reminders" {
+			t.Fatalf("unexpected webhook URL: %q", cfg.ReminderWebhookURL)
+		}
+		if cfg.ReminderInterval != 30*time.Second {
+			t.Fatalf("expected reminder interval 30s, got %s", cfg.ReminderInterval)
+		}
+	})
+
+	t.Run("rejects invalid reminder settings", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+		t.Setenv("SCHEDULER_SMTP_ADDR", "")
+		t.Setenv("SCHEDULER_REMINDER_WEBHOOK_URL", "ftp://hooks.example.com")
+		t.Setenv("SCHEDULER_REMINDER_INTERVAL", "0s")
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected error for invalid reminder settings")
+		}
+		expected := "環境変数の値が不正です: SCHEDULER_REMINDER_WEBHOOK_URL, SCHEDULER_REMINDER_INTERVAL"
+		if err.Error() != expected {
+			t.Fatalf("unexpected error message: %q", err.Error())
+		}
+	})
+
+	t.Run("parses webhook dispatch interval", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.WebhookInterval != 10*time.Second {
+			t.Fatalf("expected default webhook interval 10s, got %s", cfg.WebhookInterval)
+		}
+
+		t.Setenv("SCHEDULER_WEBHOOK_INTERVAL", "5s")
+		if cfg, err = Load(); err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.WebhookInterval != 5*time.Second {
+			t.Fatalf("expected webhook interval 5s, got %s", cfg.WebhookInterval)
+		}
+
+		t.Setenv("SCHEDULER_WEBHOOK_INTERVAL", "-1s")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_WEBHOOK_INTERVAL" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parses calendar sync interval", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.CalDAVSyncInterval != 5*time.Minute {
+			t.Fatalf("expected default calendar sync interval 5m, got %s", cfg.CalDAVSyncInterval)
+		}
+
+		t.Setenv("SCHEDULER_CALDAV_SYNC_INTERVAL", "90s")
+		if cfg, err = Load(); err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.CalDAVSyncInterval != 90*time.Second {
+			t.Fatalf("expected calendar sync interval 90s, got %s", cfg.CalDAVSyncInterval)
+		}
+
+		t.Setenv("SCHEDULER_CALDAV_SYNC_INTERVAL", "soon")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_CALDAV_SYNC_INTERVAL" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parses event stream poll interval", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+		t.Setenv("SCHEDULER_EVENT_STREAM_INTERVAL", "250ms")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.EventStreamInterval != 250*time.Millisecond {
+			t.Fatalf("expected event stream interval 250ms, got %s", cfg.EventStreamInterval)
+		}
+
+		t.Setenv("SCHEDULER_EVENT_STREAM_INTERVAL", "soon")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_EVENT_STREAM_INTERVAL" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parses trash retention and purge interval", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.TrashRetention != 30*24*time.Hour || cfg.TrashPurgeInterval != time.Hour {
+			t.Fatalf("expected default trash retention 720h and purge interval 1h, got %s and %s", cfg.TrashRetention, cfg.TrashPurgeInterval)
+		}
+
+		t.Setenv("SCHEDULER_TRASH_RETENTION", "168h")
+		t.Setenv("SCHEDULER_TRASH_PURGE_INTERVAL", "15m")
+		if cfg, err = Load(); err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.TrashRetention != 168*time.Hour || cfg.TrashPurgeInterval != 15*time.Minute {
+			t.Fatalf("expected trash retention 168h and purge interval 15m, got %s and %s", cfg.TrashRetention, cfg.TrashPurgeInterval)
+		}
+
+		t.Setenv("SCHEDULER_TRASH_RETENTION", "-1h")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_TRASH_RETENTION" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parses idempotency key ttl", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.IdempotencyKeyTTL != 24*time.Hour {
+			t.Fatalf("expected default idempotency key ttl 24h, got %s", cfg.IdempotencyKeyTTL)
+		}
+
+		t.Setenv("SCHEDULER_IDEMPOTENCY_KEY_TTL", "2h")
+		if cfg, err = Load(); err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.IdempotencyKeyTTL != 2*time.Hour {
+			t.Fatalf("expected idempotency key ttl 2h, got %s", cfg.IdempotencyKeyTTL)
+		}
+
+		t.Setenv("SCHEDULER_IDEMPOTENCY_KEY_TTL", "0s")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_IDEMPOTENCY_KEY_TTL" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parses occurrence horizon and refresh interval", func(t *testing.T) {
+		t.Setenv("SCHEDULER_SESSION_SECRET", "secret-value")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.OccurrenceHorizon != 365*24*time.Hour || cfg.OccurrenceRefreshInterval != time.Minute {
+			t.Fatalf("expected default occurrence horizon 8760h and refresh interval 1m, got %s and %s", cfg.OccurrenceHorizon, cfg.OccurrenceRefreshInterval)
+		}
+
+		t.Setenv("SCHEDULER_OCCURRENCE_HORIZON", "2160h")
+		t.Setenv("SCHEDULER_OCCURRENCE_REFRESH_INTERVAL", "30s")
+		if cfg, err = Load(); err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if cfg.OccurrenceHorizon != 2160*time.Hour || cfg.OccurrenceRefreshInterval != 30*time.Second {
+			t.Fatalf("expected occurrence horizon 2160h and refresh interval 30s, got %s and %s", cfg.OccurrenceHorizon, cfg.OccurrenceRefreshInterval)
+		}
+
+		t.Setenv("SCHEDULER_OCCURRENCE_HORIZON", "forever")
+		_, err = Load()
+		if err == nil || err.Error() != "環境変数の値が不正です: SCHEDULER_OCCURRENCE_HORIZON" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
 }
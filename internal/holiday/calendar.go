@@ -0,0 +1,138 @@
+package holiday
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind classifies why a day is a holiday.
+type Kind string
+
+const (
+	// KindNational is a day named by the Japanese national holiday law.
+	KindNational Kind = "national"
+	// KindSubstitute is a 振替休日 following a national holiday on Sunday.
+	KindSubstitute Kind = "substitute"
+	// KindCitizens is a 国民の休日 sandwiched between two national holidays.
+	KindCitizens Kind = "citizens"
+	// KindCompany is a company-specific closure day.
+	KindCompany Kind = "company"
+)
+
+// Holiday is a named non-working day. Date is the calendar date at UTC midnight.
+type Holiday struct {
+	Date time.Time
+	Name string
+	Kind Kind
+}
+
+// Date returns the calendar date at UTC midnight, the form used for Holiday.Date.
+func Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// DateOf returns the calendar date of t as read in t's own location.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return Date(y, m, d)
+}
+
+// Calendar combines the built-in Japanese public holidays with company closure
+// days. A Calendar is safe for concurrent use.
+type Calendar struct {
+	closures map[time.Time]Holiday
+
+	mu       sync.Mutex
+	japanese map[int]map[time.Time]Holiday
+}
+
+// NewCalendar returns a calendar that also treats closures as holidays. A closure
+// on a public holiday takes precedence so its company name is reported.
+func NewCalendar(closures []Holiday) *Calendar {
+	c := &Calendar{
+		closures: make(map[time.Time]Holiday, len(closures)),
+		japanese: make(map[int]map[time.Time]Holiday),
+	}
+	for _, closure := range closures {
+		closure.Date = DateOf(closure.Date)
+		closure.Kind = KindCompany
+		c.closures[closure.Date] = closure
+	}
+	return c
+}
+
+// IsHoliday reports whether the calendar date of day, read in day's own
+// location, is a public holiday or company closure.
+func (c *Calendar) IsHoliday(day time.Time) bool {
+	_, ok := c.Lookup(day)
+	return ok
+}
+
+// Lookup returns the holiday falling on the calendar date of day, if any.
+func (c *Calendar) Lookup(day time.Time) (Holiday, bool) {
+	date := DateOf(day)
+	if c == nil {
+		h, ok := indexJapanese(date.Year())[date]
+		return h, ok
+	}
+	if h, ok := c.closures[date]; ok {
+		return h, true
+	}
+	h, ok := c.japaneseYear(date.Year())[date]
+	return h, ok
+}
+
+// IsBusinessDay reports whether day is a weekday that is not a holiday.
+func (c *Calendar) IsBusinessDay(day time.Time) bool {
+	switch day.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.IsHoliday(day)
+}
+
+// Holidays lists the public holidays and closures of year ordered by date.
+func (c *Calendar) Holidays(year int) []Holiday {
+	merged := make(map[time.Time]Holiday)
+	for date, h := range c.japaneseYear(year) {
+		merged[date] = h
+	}
+	if c != nil {
+		for date, h := range c.closures {
+			if date.Year() == year {
+				merged[date] = h
+			}
+		}
+	}
+
+	out := make([]Holiday, 0, len(merged))
+	for _, h := range merged {
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
+
+func (c *Calendar) japaneseYear(year int) map[time.Time]Holiday {
+	if c == nil {
+		return indexJapanese(year)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.japanese[year]; ok {
+		return cached
+	}
+	index := indexJapanese(year)
+	c.japanese[year] = index
+	return index
+}
+
+func indexJapanese(year int) map[time.Time]Holiday {
+	holidays := Japanese(year)
+	index := make(map[time.Time]Holiday, len(holidays))
+	for _, h := range holidays {
+		index[h.Date] = h
+	}
+	return index
+}
@@ -0,0 +1,7 @@
+// Package holiday computes Japanese public holidays and combines them with
+// company closure days into a Calendar.
+//
+// Holidays are computed from the national holiday law rather than loaded from a
+// table: fixed dates, Happy Monday rules, the equinox approximation for 春分の日 and
+// 秋分の日, 振替休日 and 国民の休日, plus the one-off days of 2019-2021.
+package holiday
@@ -0,0 +1,192 @@
+package holiday
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// MinYear is the first year for which Japanese holidays are computed. The
+	// equinox approximation used for 春分の日 and 秋分の日 holds from 1980 on.
+	MinYear = 1980
+	// MaxYear is the last year for which Japanese holidays are computed.
+	MaxYear = 2099
+)
+
+// Japanese returns the Japanese public holidays of year ordered by date,
+// including 振替休日 (substitute holidays) and 国民の休日 (days sandwiched between
+// two holidays). Years outside [MinYear, MaxYear] yield nil.
+func Japanese(year int) []Holiday {
+	if year < MinYear || year > MaxYear {
+		return nil
+	}
+
+	national := nationalHolidays(year)
+	isNational := make(map[time.Time]bool, len(national))
+	byDate := make(map[time.Time]Holiday, len(national)+4)
+	for _, h := range national {
+		isNational[h.Date] = true
+		byDate[h.Date] = h
+	}
+
+	// 国民の休日: a weekday between two national holidays (since 1985-12-27).
+	if year >= 1986 {
+		for _, h := range national {
+			middle := h.Date.AddDate(0, 0, 1)
+			if isNational[middle] || middle.Weekday() == time.Sunday {
+				continue
+			}
+			if isNational[middle.AddDate(0, 0, 1)] {
+				byDate[middle] = Holiday{Date: middle, Name: "国民の休日", Kind: KindCitizens}
+			}
+		}
+	}
+
+	// 振替休日: a national holiday on Sunday moves to the next non-holiday day
+	// (only to Monday before 2007).
+	for _, h := range national {
+		if h.Date.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := h.Date.AddDate(0, 0, 1)
+		if year >= 2007 {
+			for {
+				if _, ok := byDate[substitute]; !ok {
+					break
+				}
+				substitute = substitute.AddDate(0, 0, 1)
+			}
+		} else if _, ok := byDate[substitute]; ok {
+			continue
+		}
+		if substitute.Year() == year {
+			byDate[substitute] = Holiday{Date: substitute, Name: "振替休日", Kind: KindSubstitute}
+		}
+	}
+
+	out := make([]Holiday, 0, len(byDate))
+	for _, h := range byDate {
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
+
+func nationalHolidays(year int) []Holiday {
+	var out []Holiday
+	add := func(month time.Month, day int, name string) {
+		out = append(out, Holiday{Date: Date(year, month, day), Name: name, Kind: KindNational})
+	}
+
+	add(time.January, 1, "元日")
+	if year >= 2000 {
+		add(time.January, nthMonday(year, time.January, 2), "成人の日")
+	} else {
+		add(time.January, 15, "成人の日")
+	}
+	add(time.February, 11, "建国記念の日")
+	if year >= 2020 {
+		add(time.February, 23, "天皇誕生日")
+	}
+	add(time.March, vernalEquinoxDay(year), "春分の日")
+	switch {
+	case year >= 2007:
+		add(time.April, 29, "昭和の日")
+	case year >= 1989:
+		add(time.April, 29, "みどりの日")
+	default:
+		add(time.April, 29, "天皇誕生日")
+	}
+	if year == 2019 {
+		add(time.May, 1, "即位の日")
+	}
+	add(time.May, 3, "憲法記念日")
+	if year >= 2007 {
+		add(time.May, 4, "みどりの日")
+	}
+	add(time.May, 5, "こどもの日")
+
+	switch {
+	case year == 2020:
+		add(time.July, 23, "海の日")
+	case year == 2021:
+		add(time.July, 22, "海の日")
+	case year >= 2003:
+		add(time.July, nthMonday(year, time.July, 3), "海の日")
+	case year >= 1996:
+		add(time.July, 20, "海の日")
+	}
+
+	switch {
+	case year == 2020:
+		add(time.August, 10, "山の日")
+	case year == 2021:
+		add(time.August, 8, "山の日")
+	case year >= 2016:
+		add(time.August, 11, "山の日")
+	}
+
+	if year >= 2003 {
+		add(time.September, nthMonday(year, time.September, 3), "敬老の日")
+	} else {
+		add(time.September, 15, "敬老の日")
+	}
+	add(time.September, autumnalEquinoxDay(year), "秋分の日")
+
+	switch {
+	case year == 2020:
+		add(time.July, 24, "スポーツの日")
+	case year == 2021:
+		add(time.July, 23, "スポーツの日")
+	case year >= 2020:
+		add(time.October, nthMonday(year, time.October, 2), "スポーツの日")
+	case year >= 2000:
+		add(time.October, nthMonday(year, time.October, 2), "体育の日")
+	default:
+		add(time.October, 10, "体育の日")
+	}
+
+	if year == 2019 {
+		add(time.October, 22, "即位礼正殿の儀")
+	}
+	if year == 1990 {
+		add(time.November, 12, "即位礼正殿の儀")
+	}
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+	if year >= 1989 && year <= 2018 {
+		add(time.December, 23, "天皇誕生日")
+	}
+	if year == 1989 {
+		add(time.February, 24, "昭和天皇の大喪の礼")
+	}
+	if year == 1993 {
+		add(time.June, 9, "皇太子徳仁親王の結婚の儀")
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
+
+// nthMonday returns the day of month of the nth Monday.
+func nthMonday(year int, month time.Month, n int) int {
+	first := Date(year, month, 1)
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return 1 + offset + 7*(n-1)
+}
+
+// vernalEquinoxDay approximates the March equinox date in JST (valid 1980-2099).
+func vernalEquinoxDay(year int) int {
+	return equinoxDay(20.8431, year)
+}
+
+// autumnalEquinoxDay approximates the September equinox date in JST (valid 1980-2099).
+func autumnalEquinoxDay(year int) int {
+	return equinoxDay(23.2488, year)
+}
+
+func equinoxDay(base float64, year int) int {
+	elapsed := float64(year - 1980)
+	return int(math.Floor(base + 0.242194*elapsed - math.Floor(elapsed/4)))
+}
@@ -0,0 +1,103 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapanese(t *testing.T) {
+	t.Run("2024 includes substitute holidays", func(t *testing.T) {
+		want := []string{
+			"2024-01-01", "2024-01-08", "2024-02-11", "2024-02-12", "2024-02-23",
+			"2024-03-20", "2024-04-29", "2024-05-03", "2024-05-04", "2024-05-05",
+			"2024-05-06", "2024-07-15", "2024-08-11", "2024-08-12", "2024-09-16",
+			"2024-09-22", "2024-09-23", "2024-10-14", "2024-11-03", "2024-11-04",
+			"2024-11-23",
+		}
+		got := Japanese(2024)
+		if len(got) != len(want) {
+			t.Fatalf("expected %d holidays, got %d: %v", len(want), len(got), dates(got))
+		}
+		for i, h := range got {
+			if h.Date.Format("2006-01-02") != want[i] {
+				t.Fatalf("holiday %d: expected %s, got %s (%s)", i, want[i], h.Date.Format("2006-01-02"), h.Name)
+			}
+		}
+		if got[3].Kind != KindSubstitute || got[3].Name != "振替休日" {
+			t.Fatalf("expected 2024-02-12 to be a substitute holiday, got %+v", got[3])
+		}
+	})
+
+	t.Run("citizens holidays fill single gaps", func(t *testing.T) {
+		cal := NewCalendar(nil)
+		for _, day := range []time.Time{Date(2019, time.April, 30), Date(2019, time.May, 2), Date(2009, time.September, 22), Date(2026, time.September, 22)} {
+			h, ok := cal.Lookup(day)
+			if !ok || h.Kind != KindCitizens {
+				t.Fatalf("expected %s to be a citizens holiday, got %+v", day.Format("2006-01-02"), h)
+			}
+		}
+	})
+
+	t.Run("equinox days follow the approximation", func(t *testing.T) {
+		cases := map[int][2]int{2020: {20, 22}, 2023: {21, 23}, 2025: {20, 23}, 2030: {20, 23}}
+		for year, want := range cases {
+			if got := vernalEquinoxDay(year); got != want[0] {
+				t.Fatalf("%d vernal equinox: expected %d, got %d", year, want[0], got)
+			}
+			if got := autumnalEquinoxDay(year); got != want[1] {
+				t.Fatalf("%d autumnal equinox: expected %d, got %d", year, want[1], got)
+			}
+		}
+	})
+
+	t.Run("olympic years move holidays", func(t *testing.T) {
+		cal := NewCalendar(nil)
+		for _, day := range []time.Time{Date(2021, time.July, 22), Date(2021, time.July, 23), Date(2021, time.August, 9)} {
+			if !cal.IsHoliday(day) {
+				t.Fatalf("expected %s to be a holiday", day.Format("2006-01-02"))
+			}
+		}
+		if cal.IsHoliday(Date(2021, time.October, 11)) {
+			t.Fatal("expected sports day 2021 to have moved to July")
+		}
+	})
+
+	t.Run("years outside the supported range are empty", func(t *testing.T) {
+		if got := Japanese(MaxYear + 1); got != nil {
+			t.Fatalf("expected nil, got %v", dates(got))
+		}
+	})
+}
+
+func TestCalendar(t *testing.T) {
+	cal := NewCalendar([]Holiday{
+		{Date: Date(2024, time.December, 30), Name: "年末休業"},
+		{Date: Date(2024, time.November, 23), Name: "創立記念日"},
+	})
+
+	if !cal.IsHoliday(time.Date(2024, time.December, 30, 10, 0, 0, 0, time.FixedZone("JST", 9*60*60))) {
+		t.Fatal("expected closure to be a holiday in any zone")
+	}
+	if h, _ := cal.Lookup(Date(2024, time.November, 23)); h.Kind != KindCompany || h.Name != "創立記念日" {
+		t.Fatalf("expected closure to take precedence, got %+v", h)
+	}
+	if cal.IsBusinessDay(Date(2024, time.December, 28)) {
+		t.Fatal("expected Saturday not to be a business day")
+	}
+	if !cal.IsBusinessDay(Date(2024, time.December, 27)) {
+		t.Fatal("expected Friday 2024-12-27 to be a business day")
+	}
+
+	holidays := cal.Holidays(2024)
+	if len(holidays) != 22 || holidays[len(holidays)-1].Name != "年末休業" {
+		t.Fatalf("expected closures merged into the year, got %v", dates(holidays))
+	}
+}
+
+func dates(holidays []Holiday) []string {
+	out := make([]string, len(holidays))
+	for i, h := range holidays {
+		out[i] = h.Date.Format("2006-01-02") + " " + h.Name
+	}
+	return out
+}
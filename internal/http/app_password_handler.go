@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type appPasswordService interface {
+	ListAppPasswords(ctx context.Context, principal application.Principal, userID string) ([]application.AppPassword, error)
+	CreateAppPassword(ctx context.Context, params application.CreateAppPasswordParams) (application.AppPassword, error)
+	DeleteAppPassword(ctx context.Context, principal application.Principal, userID, passwordID string) error
+}
+
+// AppPasswordHandler serves the app passwords nested under /users/{id}/app-passwords.
+type AppPasswordHandler struct {
+	service   appPasswordService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewAppPasswordHandler(service appPasswordService, logger *slog.Logger) *AppPasswordHandler {
+	base := defaultLogger(logger)
+	return &AppPasswordHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *AppPasswordHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "AppPasswordHandler", operation, attrs...)
+}
+
+func (h *AppPasswordHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "List", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for app passwords")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID, "user_id", userID)
+
+	passwords, err := h.service.ListAppPasswords(r.Context(), principal, userID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "app password list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]appPasswordDTO, 0, len(passwords))
+	for _, password := range passwords {
+		items = append(items, toAppPasswordDTO(password, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "app passwords listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listAppPasswordsResponse{AppPasswords: items})
+}
+
+func (h *AppPasswordHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Create", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for app passwords")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req appPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode app password", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID, "user_id", userID)
+
+	password, err := h.service.CreateAppPassword(r.Context(), application.CreateAppPasswordParams{
+		Principal: principal,
+		UserID:    userID,
+		Name:      req.Name,
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "app password creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	dto := toAppPasswordDTO(password, displayLocation(principal))
+	dto.Password = password.Secret
+
+	logger.With("app_password_id", password.ID).InfoContext(r.Context(), "app password created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, appPasswordResponse{AppPassword: dto})
+}
+
+func (h *AppPasswordHandler) Delete(w http.ResponseWriter, r *http.Request, passwordID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Delete", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for app passwords")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "user_id", userID, "app_password_id", passwordID)
+
+	if err := h.service.DeleteAppPassword(r.Context(), principal, userID, passwordID); err != nil {
+		logger.ErrorContext(r.Context(), "app password delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "app password deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type appPasswordRequest struct {
+	Name string `json:"name"`
+}
+
+type listAppPasswordsResponse struct {
+	AppPasswords []appPasswordDTO `json:"app_passwords"`
+}
+
+type appPasswordResponse struct {
+	AppPassword appPasswordDTO `json:"app_password"`
+}
+
+// appPasswordDTO carries the secret only in the creation response.
+type appPasswordDTO struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Password   string  `json:"password,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	LastUsedAt *string `json:"last_used_at"`
+}
+
+func toAppPasswordDTO(password application.AppPassword, loc *time.Location) appPasswordDTO {
+	dto := appPasswordDTO{
+		ID:        password.ID,
+		Name:      password.Name,
+		CreatedAt: formatInLocation(password.CreatedAt, loc),
+	}
+	if password.LastUsedAt != nil {
+		usedAt := formatInLocation(*password.LastUsedAt, loc)
+		dto.LastUsedAt = &usedAt
+	}
+	return dto
+}
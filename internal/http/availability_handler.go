@@ -0,0 +1,338 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type availabilityService interface {
+	GetWorkingHours(ctx context.Context, principal application.Principal, userID string) ([]application.WorkingHours, error)
+	SetWorkingHours(ctx context.Context, params application.SetWorkingHoursParams) ([]application.WorkingHours, error)
+	CreateOutOfOffice(ctx context.Context, params application.CreateOutOfOfficeParams) (application.OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, principal application.Principal, userID, entryID string) error
+	ListOutOfOffice(ctx context.Context, params application.ListOutOfOfficeParams) ([]application.OutOfOffice, error)
+}
+
+// AvailabilityHandler serves working hours and out-of-office periods nested under /users/{id}.
+type AvailabilityHandler struct {
+	service   availabilityService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewAvailabilityHandler(service availabilityService, logger *slog.Logger) *AvailabilityHandler {
+	base := defaultLogger(logger)
+	return &AvailabilityHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *AvailabilityHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "AvailabilityHandler", operation, attrs...)
+}
+
+func (h *AvailabilityHandler) GetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "GetWorkingHours", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for working hours")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "GetWorkingHours", "principal_id", principal.UserID, "user_id", userID)
+
+	hours, err := h.service.GetWorkingHours(r.Context(), principal, userID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "working hours lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("window_count", len(hours)).InfoContext(r.Context(), "working hours fetched")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, workingHoursResponse{WorkingHours: toWorkingHoursDTOs(hours)})
+}
+
+func (h *AvailabilityHandler) SetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "SetWorkingHours", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for working hours")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req workingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "SetWorkingHours", "principal_id", principal.UserID, "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode working hours", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "SetWorkingHours", "principal_id", principal.UserID, "user_id", userID)
+
+	hours, err := h.service.SetWorkingHours(r.Context(), application.SetWorkingHoursParams{
+		Principal: principal,
+		UserID:    userID,
+		Hours:     req.toWorkingHours(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "working hours update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "working hours updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, workingHoursResponse{WorkingHours: toWorkingHoursDTOs(hours)})
+}
+
+func (h *AvailabilityHandler) ListOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "ListOutOfOffice", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for out-of-office list")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	params := application.ListOutOfOfficeParams{Principal: principal, UserID: userID}
+	if ts := parseTime(r.URL.Query().Get("starts_after")); !ts.IsZero() {
+		params.StartsAfter = &ts
+	}
+	if ts := parseTime(r.URL.Query().Get("ends_before")); !ts.IsZero() {
+		params.EndsBefore = &ts
+	}
+
+	logger := h.log(r.Context(), "ListOutOfOffice", "principal_id", principal.UserID, "user_id", userID)
+
+	entries, err := h.service.ListOutOfOffice(r.Context(), params)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "out-of-office list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("result_count", len(entries)).InfoContext(r.Context(), "out-of-office periods listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listOutOfOfficeResponse{OutOfOffice: toOutOfOfficeDTOs(entries, displayLocation(principal))})
+}
+
+func (h *AvailabilityHandler) CreateOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "CreateOutOfOffice", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for out-of-office creation")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req outOfOfficeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "CreateOutOfOffice", "principal_id", principal.UserID, "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode out-of-office request", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "CreateOutOfOffice", "principal_id", principal.UserID, "user_id", userID)
+
+	entry, err := h.service.CreateOutOfOffice(r.Context(), application.CreateOutOfOfficeParams{
+		Principal: principal,
+		UserID:    userID,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "out-of-office creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("out_of_office_id", entry.ID).InfoContext(r.Context(), "out-of-office period created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, outOfOfficeResponse{OutOfOffice: toOutOfOfficeDTO(entry, displayLocation(principal))})
+}
+
+func (h *AvailabilityHandler) DeleteOutOfOffice(w http.ResponseWriter, r *http.Request, entryID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "DeleteOutOfOffice", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for out-of-office delete")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+	if strings.TrimSpace(entryID) == "" {
+		h.log(r.Context(), "DeleteOutOfOffice", "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "missing out-of-office id for delete")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidOutOfOfficeID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "DeleteOutOfOffice", "principal_id", principal.UserID, "user_id", userID, "out_of_office_id", entryID)
+	if err := h.service.DeleteOutOfOffice(r.Context(), principal, userID, entryID); err != nil {
+		logger.ErrorContext(r.Context(), "out-of-office delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "out-of-office period deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type workingHoursRequest struct {
+	WorkingHours []workingHoursDTO `json:"working_hours"`
+}
+
+// toWorkingHours converts the request windows. Unknown weekdays and malformed
+// clock times are passed through as out-of-range values so that the service
+// reports them as validation errors.
+func (r workingHoursRequest) toWorkingHours() []application.WorkingHours {
+	hours := make([]application.WorkingHours, 0, len(r.WorkingHours))
+	for _, window := range r.WorkingHours {
+		hours = append(hours, application.WorkingHours{
+			Weekday:     parseWeekday(window.Weekday),
+			StartMinute: parseClock(window.Start),
+			EndMinute:   parseClock(window.End),
+		})
+	}
+	return hours
+}
+
+type workingHoursResponse struct {
+	WorkingHours []workingHoursDTO `json:"working_hours"`
+}
+
+type workingHoursDTO struct {
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+func toWorkingHoursDTOs(hours []application.WorkingHours) []workingHoursDTO {
+	out := make([]workingHoursDTO, 0, len(hours))
+	for _, window := range hours {
+		out = append(out, workingHoursDTO{
+			Weekday: strings.ToLower(window.Weekday.String()),
+			Start:   formatClock(window.StartMinute),
+			End:     formatClock(window.EndMinute),
+		})
+	}
+	return out
+}
+
+func parseWeekday(value string) time.Weekday {
+	value = strings.TrimSpace(value)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(value, day.String()) || strings.EqualFold(value, day.String()[:3]) {
+			return day
+		}
+	}
+	return -1
+}
+
+// parseClock reads an HH:MM wall-clock time as minutes after midnight. "24:00"
+// is accepted so that windows can run until the end of the day.
+func parseClock(value string) int {
+	value = strings.TrimSpace(value)
+	if value == "24:00" {
+		return 24 * 60
+	}
+	ts, err := time.Parse("15:04", value)
+	if err != nil {
+		return -1
+	}
+	return ts.Hour()*60 + ts.Minute()
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+type outOfOfficeRequest struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Note        string `json:"note"`
+	AutoDecline bool   `json:"auto_decline"`
+}
+
+func (r outOfOfficeRequest) toInput() application.OutOfOfficeInput {
+	return application.OutOfOfficeInput{
+		Start:       parseTime(r.Start),
+		End:         parseTime(r.End),
+		Note:        strings.TrimSpace(r.Note),
+		AutoDecline: r.AutoDecline,
+	}
+}
+
+type outOfOfficeResponse struct {
+	OutOfOffice outOfOfficeDTO `json:"out_of_office"`
+}
+
+type listOutOfOfficeResponse struct {
+	OutOfOffice []outOfOfficeDTO `json:"out_of_office"`
+}
+
+type outOfOfficeDTO struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Note        string `json:"note,omitempty"`
+	AutoDecline bool   `json:"auto_decline"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func toOutOfOfficeDTO(entry application.OutOfOffice, loc *time.Location) outOfOfficeDTO {
+	return outOfOfficeDTO{
+		ID:          entry.ID,
+		UserID:      entry.UserID,
+		Start:       formatInLocation(entry.Start, loc),
+		End:         formatInLocation(entry.End, loc),
+		Note:        entry.Note,
+		AutoDecline: entry.AutoDecline,
+		CreatedAt:   entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:   entry.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func toOutOfOfficeDTOs(entries []application.OutOfOffice, loc *time.Location) []outOfOfficeDTO {
+	if len(entries) == 0 {
+		return nil
+	}
+	out := make([]outOfOfficeDTO, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, toOutOfOfficeDTO(entry, loc))
+	}
+	return out
+}
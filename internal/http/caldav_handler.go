@@ -0,0 +1,680 @@
+package http
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+const (
+	// CalDAVPrefix is the path every CalDAV resource lives under.
+	CalDAVPrefix = "/dav/"
+	// CalDAVWellKnownPath is the RFC 6764 discovery path redirected to CalDAVPrefix.
+	CalDAVWellKnownPath = "/.well-known/caldav"
+
+	methodPropfind = "PROPFIND"
+	methodReport   = "REPORT"
+
+	davNamespace    = "DAV:"
+	calDAVNamespace = "urn:ietf:params:xml:ns:caldav"
+	calServerNS     = "http://calendarserver.org/ns/"
+
+	calDAVTimeLayout   = "20060102T150405Z"
+	maxCalendarObject  = 1 << 20
+	calendarObjectType = "text/calendar; charset=utf-8"
+)
+
+type calDAVService interface {
+	Owner(ctx context.Context, principal application.Principal) (application.User, error)
+	Calendars(ctx context.Context, principal application.Principal) ([]application.CalDAVCalendar, error)
+	Calendar(ctx context.Context, principal application.Principal, calendarID string) (application.CalDAVCalendar, error)
+	ListResources(ctx context.Context, principal application.Principal, calendarID string, timeRange application.CalDAVTimeRange) ([]application.CalDAVResource, error)
+	GetResources(ctx context.Context, principal application.Principal, calendarID string, names []string) ([]application.CalDAVResource, error)
+	GetResource(ctx context.Context, principal application.Principal, calendarID, name string) (application.CalDAVResource, error)
+	PutResource(ctx context.Context, params application.PutCalDAVResourceParams) (application.Schedule, bool, error)
+	DeleteResource(ctx context.Context, principal application.Principal, calendarID, name, ifMatch string) error
+}
+
+// CalDAVHandler serves the CalDAV subset under /dav/: principal discovery,
+// the calendar home, PROPFIND, calendar-query and calendar-multiget REPORTs
+// and GET/PUT/DELETE of iCalendar resources. Errors are reported with bare
+// status codes, as WebDAV clients expect.
+type CalDAVHandler struct {
+	service calDAVService
+	logger  *slog.Logger
+}
+
+func NewCalDAVHandler(service calDAVService, logger *slog.Logger) *CalDAVHandler {
+	return &CalDAVHandler{service: service, logger: defaultLogger(logger)}
+}
+
+func (h *CalDAVHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "CalDAVHandler", operation, attrs...)
+}
+
+// WellKnown redirects service discovery to the CalDAV root.
+func (h *CalDAVHandler) WellKnown(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, CalDAVPrefix, http.StatusMovedPermanently)
+}
+
+// Options advertises CalDAV support.
+func (h *CalDAVHandler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	w.WriteHeader(http.StatusOK)
+}
+
+// PropfindRoot points clients at the authenticated user's principal.
+func (h *CalDAVHandler) PropfindRoot(w http.ResponseWriter, r *http.Request) {
+	principal, _ := PrincipalFromContext(r.Context())
+	request, ok := h.readPropfind(w, r, "PropfindRoot")
+	if !ok {
+		return
+	}
+
+	props := davProps{}
+	props.set(davNamespace, "resourcetype", "<d:collection/>")
+	props.set(davNamespace, "current-user-principal", davHref(principalPath(principal.UserID)))
+
+	h.writeMultistatus(r.Context(), w, []davResponse{request.response(CalDAVPrefix, props)})
+}
+
+// PropfindPrincipal describes a user's principal, including their calendar home.
+func (h *CalDAVHandler) PropfindPrincipal(w http.ResponseWriter, r *http.Request, userID string) {
+	if !h.owns(w, r, "PropfindPrincipal", userID) {
+		return
+	}
+	request, ok := h.readPropfind(w, r, "PropfindPrincipal")
+	if !ok {
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	user, err := h.service.Owner(r.Context(), principal)
+	if err != nil {
+		h.handleError(r.Context(), w, "PropfindPrincipal", err)
+		return
+	}
+
+	props := davProps{}
+	props.set(davNamespace, "resourcetype", "<d:collection/><d:principal/>")
+	props.set(davNamespace, "displayname", davText(firstNonEmpty(user.DisplayName, user.ID)))
+	props.set(davNamespace, "current-user-principal", davHref(principalPath(user.ID)))
+	props.set(davNamespace, "principal-URL", davHref(principalPath(user.ID)))
+	props.set(calDAVNamespace, "calendar-home-set", davHref(calendarHomePath(user.ID)))
+	if user.Email != "" {
+		props.set(calDAVNamespace, "calendar-user-address-set", davHref("mailto:"+user.Email))
+	}
+
+	h.writeMultistatus(r.Context(), w, []davResponse{request.response(principalPath(user.ID), props)})
+}
+
+// PropfindHome describes the calendar home and, at depth 1, its calendars.
+func (h *CalDAVHandler) PropfindHome(w http.ResponseWriter, r *http.Request, userID string) {
+	if !h.owns(w, r, "PropfindHome", userID) {
+		return
+	}
+	request, ok := h.readPropfind(w, r, "PropfindHome")
+	if !ok {
+		return
+	}
+
+	props := davProps{}
+	props.set(davNamespace, "resourcetype", "<d:collection/>")
+	props.set(davNamespace, "current-user-principal", davHref(principalPath(userID)))
+	responses := []davResponse{request.response(calendarHomePath(userID), props)}
+
+	if davDepth(r) > 0 {
+		principal, _ := PrincipalFromContext(r.Context())
+		calendars, err := h.service.Calendars(r.Context(), principal)
+		if err != nil {
+			h.handleError(r.Context(), w, "PropfindHome", err)
+			return
+		}
+		for _, calendar := range calendars {
+			responses = append(responses, request.response(calendarPath(userID, calendar.ID), calendarProps(userID, calendar)))
+		}
+	}
+
+	h.writeMultistatus(r.Context(), w, responses)
+}
+
+// PropfindCalendar describes a calendar and, at depth 1, its resources.
+func (h *CalDAVHandler) PropfindCalendar(w http.ResponseWriter, r *http.Request, userID, calendarID string) {
+	if !h.owns(w, r, "PropfindCalendar", userID) {
+		return
+	}
+	request, ok := h.readPropfind(w, r, "PropfindCalendar")
+	if !ok {
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	calendar, err := h.service.Calendar(r.Context(), principal, calendarID)
+	if err != nil {
+		h.handleError(r.Context(), w, "PropfindCalendar", err)
+		return
+	}
+	responses := []davResponse{request.response(calendarPath(userID, calendar.ID), calendarProps(userID, calendar))}
+
+	if davDepth(r) > 0 {
+		resources, err := h.service.ListResources(r.Context(), principal, calendarID, application.CalDAVTimeRange{})
+		if err != nil {
+			h.handleError(r.Context(), w, "PropfindCalendar", err)
+			return
+		}
+		for _, resource := range resources {
+			responses = append(responses, request.response(resourcePath(userID, calendarID, resource.Name), resourceProps(resource)))
+		}
+	}
+
+	h.writeMultistatus(r.Context(), w, responses)
+}
+
+// PropfindResource describes a single calendar object resource.
+func (h *CalDAVHandler) PropfindResource(w http.ResponseWriter, r *http.Request, userID, calendarID, name string) {
+	if !h.owns(w, r, "PropfindResource", userID) {
+		return
+	}
+	request, ok := h.readPropfind(w, r, "PropfindResource")
+	if !ok {
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	resource, err := h.service.GetResource(r.Context(), principal, calendarID, name)
+	if err != nil {
+		h.handleError(r.Context(), w, "PropfindResource", err)
+		return
+	}
+	h.writeMultistatus(r.Context(), w, []davResponse{request.response(resourcePath(userID, calendarID, resource.Name), resourceProps(resource))})
+}
+
+// Report answers calendar-query and calendar-multiget REPORTs on a calendar.
+func (h *CalDAVHandler) Report(w http.ResponseWriter, r *http.Request, userID, calendarID string) {
+	if !h.owns(w, r, "Report", userID) {
+		return
+	}
+
+	var report calendarReport
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxCalendarObject)).Decode(&report); err != nil {
+		h.log(r.Context(), "Report", "calendar_id", calendarID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode report", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	request := davPropfind{Prop: report.Prop}
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Report", "principal_id", principal.UserID, "calendar_id", calendarID, "report", report.XMLName.Local)
+
+	var (
+		resources []application.CalDAVResource
+		missing   []string
+		err       error
+	)
+	switch report.XMLName {
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-query"}:
+		timeRange, events, ok := report.Filter.eventRange()
+		if !ok {
+			logger.ErrorContext(r.Context(), "invalid calendar-query filter", "error_kind", "bad_request")
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if events {
+			resources, err = h.service.ListResources(r.Context(), principal, calendarID, timeRange)
+		}
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-multiget"}:
+		names := make([]string, 0, len(report.Hrefs))
+		for _, href := range report.Hrefs {
+			names = append(names, resourceNameFromHref(href))
+		}
+		resources, err = h.service.GetResources(r.Context(), principal, calendarID, names)
+		found := make(map[string]bool, len(resources))
+		for _, resource := range resources {
+			found[resource.Name] = true
+		}
+		for _, href := range report.Hrefs {
+			if !found[resourceNameFromHref(href)] {
+				missing = append(missing, href)
+			}
+		}
+	default:
+		logger.WarnContext(r.Context(), "unsupported report", "error_kind", "bad_request")
+		writeDAVError(w, http.StatusForbidden, "<d:supported-report/>")
+		return
+	}
+	if err != nil {
+		h.handleError(r.Context(), w, "Report", err)
+		return
+	}
+
+	responses := make([]davResponse, 0, len(resources)+len(missing))
+	for _, resource := range resources {
+		props := resourceProps(resource)
+		props.set(calDAVNamespace, "calendar-data", davText(string(resource.Data)))
+		responses = append(responses, request.response(resourcePath(userID, calendarID, resource.Name), props))
+	}
+	for _, href := range missing {
+		responses = append(responses, davResponse{Href: href, Status: davStatus(http.StatusNotFound)})
+	}
+
+	logger.With("result_count", len(resources)).InfoContext(r.Context(), "calendar report served")
+	h.writeMultistatus(r.Context(), w, responses)
+}
+
+// Get serves a resource as an iCalendar object.
+func (h *CalDAVHandler) Get(w http.ResponseWriter, r *http.Request, userID, calendarID, name string) {
+	if !h.owns(w, r, "Get", userID) {
+		return
+	}
+	principal, _ := PrincipalFromContext(r.Context())
+	resource, err := h.service.GetResource(r.Context(), principal, calendarID, name)
+	if err != nil {
+		h.handleError(r.Context(), w, "Get", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", calendarObjectType)
+	w.Header().Set("ETag", resource.ETag)
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(resource.Data)
+	}
+}
+
+// Put creates or replaces a resource. No ETag is returned because the stored
+// object is re-rendered from the schedule rather than kept verbatim.
+func (h *CalDAVHandler) Put(w http.ResponseWriter, r *http.Request, userID, calendarID, name string) {
+	if !h.owns(w, r, "Put", userID) {
+		return
+	}
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Put", "principal_id", principal.UserID, "calendar_id", calendarID, "resource_name", name)
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCalendarObject))
+	if err != nil {
+		logger.ErrorContext(r.Context(), "failed to read calendar object", "error", err, "error_kind", "bad_request")
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	schedule, created, err := h.service.PutResource(r.Context(), application.PutCalDAVResourceParams{
+		Principal:   principal,
+		CalendarID:  calendarID,
+		Name:        name,
+		Data:        data,
+		IfMatch:     r.Header.Get("If-Match"),
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+	})
+	if err != nil {
+		h.handleError(r.Context(), w, "Put", err)
+		return
+	}
+
+	logger.With("schedule_id", schedule.ID, "created", created).InfoContext(r.Context(), "calendar object stored")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete removes a resource and the schedule behind it.
+func (h *CalDAVHandler) Delete(w http.ResponseWriter, r *http.Request, userID, calendarID, name string) {
+	if !h.owns(w, r, "Delete", userID) {
+		return
+	}
+	principal, _ := PrincipalFromContext(r.Context())
+	if err := h.service.DeleteResource(r.Context(), principal, calendarID, name, r.Header.Get("If-Match")); err != nil {
+		h.handleError(r.Context(), w, "Delete", err)
+		return
+	}
+	h.log(r.Context(), "Delete", "principal_id", principal.UserID, "calendar_id", calendarID, "resource_name", name).InfoContext(r.Context(), "calendar object deleted")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// owns rejects access to another user's principal or calendar home.
+func (h *CalDAVHandler) owns(w http.ResponseWriter, r *http.Request, operation, userID string) bool {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return false
+	}
+	principal, _ := PrincipalFromContext(r.Context())
+	if principal.UserID == "" || principal.UserID != userID {
+		h.log(r.Context(), operation, "principal_id", principal.UserID, "user_id", userID, "error_kind", "unauthorized").WarnContext(r.Context(), "calendar home of another user requested")
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (h *CalDAVHandler) readPropfind(w http.ResponseWriter, r *http.Request, operation string) (davPropfind, bool) {
+	var request davPropfind
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCalendarObject))
+	if err == nil && len(strings.TrimSpace(string(body))) > 0 {
+		err = xml.Unmarshal(body, &request)
+	}
+	if err != nil {
+		h.log(r.Context(), operation, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode propfind", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return davPropfind{}, false
+	}
+	return request, true
+}
+
+func (h *CalDAVHandler) handleError(ctx context.Context, w http.ResponseWriter, operation string, err error) {
+	logger := h.log(ctx, operation, "error", err, "error_kind", application.ErrorKind(err))
+	var vErr *application.ValidationError
+	switch {
+	case errors.Is(err, application.ErrUnauthorized):
+		logger.WarnContext(ctx, "calendar access denied")
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	case errors.Is(err, application.ErrNotFound):
+		logger.WarnContext(ctx, "calendar resource not found")
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	case errors.Is(err, application.ErrAlreadyExists):
+		logger.WarnContext(ctx, "calendar resource conflict")
+		http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+	case errors.Is(err, application.ErrPreconditionFailed):
+		logger.InfoContext(ctx, "calendar precondition failed")
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+	case errors.As(err, &vErr):
+		logger.InfoContext(ctx, "calendar object rejected")
+		condition := "<c:valid-calendar-object-resource/>"
+		if _, ok := vErr.FieldErrors["calendar_data"]; ok {
+			condition = "<c:valid-calendar-data/>"
+		}
+		writeDAVError(w, http.StatusForbidden, condition)
+	default:
+		logger.ErrorContext(ctx, "calendar request failed")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+func (h *CalDAVHandler) writeMultistatus(ctx context.Context, w http.ResponseWriter, responses []davResponse) {
+	body, err := xml.Marshal(davMultistatus{
+		DAV:       davNamespace,
+		CalDAV:    calDAVNamespace,
+		CalServer: calServerNS,
+		Responses: responses,
+	})
+	if err != nil {
+		h.log(ctx, "writeMultistatus").ErrorContext(ctx, "failed to encode multistatus", "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, xml.Header)
+	_, _ = w.Write(body)
+}
+
+func writeDAVError(w http.ResponseWriter, status int, condition string) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header+`<d:error xmlns:d="`+davNamespace+`" xmlns:c="`+calDAVNamespace+`">`+condition+`</d:error>`)
+}
+
+func calendarProps(userID string, calendar application.CalDAVCalendar) davProps {
+	privileges := "<d:privilege><d:read/></d:privilege>"
+	if !calendar.ReadOnly {
+		privileges += "<d:privilege><d:write/></d:privilege><d:privilege><d:write-content/></d:privilege><d:privilege><d:bind/></d:privilege><d:privilege><d:unbind/></d:privilege>"
+	}
+
+	props := davProps{}
+	props.set(davNamespace, "resourcetype", "<d:collection/><c:calendar/>")
+	props.set(davNamespace, "displayname", davText(calendar.DisplayName))
+	props.set(davNamespace, "current-user-principal", davHref(principalPath(userID)))
+	props.set(davNamespace, "current-user-privilege-set", privileges)
+	props.set(davNamespace, "supported-report-set", `<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report><d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>`)
+	props.set(davNamespace, "getetag", davText(`"`+calendar.CTag+`"`))
+	props.set(calDAVNamespace, "supported-calendar-component-set", `<c:comp name="VEVENT"/>`)
+	props.set(calServerNS, "getctag", davText(calendar.CTag))
+	return props
+}
+
+func resourceProps(resource application.CalDAVResource) davProps {
+	props := davProps{}
+	props.set(davNamespace, "resourcetype", "")
+	props.set(davNamespace, "getetag", davText(resource.ETag))
+	props.set(davNamespace, "getcontenttype", davText(calendarObjectType))
+	return props
+}
+
+func principalPath(userID string) string {
+	return CalDAVPrefix + "principals/" + url.PathEscape(userID) + "/"
+}
+
+func calendarHomePath(userID string) string {
+	return CalDAVPrefix + "calendars/" + url.PathEscape(userID) + "/"
+}
+
+func calendarPath(userID, calendarID string) string {
+	return calendarHomePath(userID) + url.PathEscape(calendarID) + "/"
+}
+
+func resourcePath(userID, calendarID, name string) string {
+	return calendarPath(userID, calendarID) + url.PathEscape(name)
+}
+
+// resourceNameFromHref returns the last path segment of an absolute or
+// path-only href.
+func resourceNameFromHref(href string) string {
+	path := href
+	if parsed, err := url.Parse(strings.TrimSpace(href)); err == nil {
+		path = parsed.Path
+	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
+
+// davDepth reads the Depth header; infinity is served as 1.
+func davDepth(r *http.Request) int {
+	if strings.TrimSpace(r.Header.Get("Depth")) == "0" {
+		return 0
+	}
+	return 1
+}
+
+func davStatus(status int) string {
+	return "HTTP/1.1 " + strconv.Itoa(status) + " " + http.StatusText(status)
+}
+
+func davText(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+func davHref(href string) string {
+	return "<d:href>" + davText(href) + "</d:href>"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// davProps maps property names to their rendered element content.
+type davProps map[xml.Name]string
+
+func (p davProps) set(space, local, content string) {
+	p[xml.Name{Space: space, Local: local}] = content
+}
+
+// davPropfind is a PROPFIND body. An empty body or allprop selects every
+// property except calendar-data.
+type davPropfind struct {
+	XMLName  xml.Name      `xml:"DAV: propfind"`
+	AllProp  *struct{}     `xml:"DAV: allprop"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *davPropNames `xml:"DAV: prop"`
+}
+
+type davPropNames struct {
+	Names []davPropName `xml:",any"`
+}
+
+type davPropName struct {
+	XMLName xml.Name
+}
+
+// response renders the requested properties of one resource, splitting them
+// into found (200) and unknown (404) propstats.
+func (p davPropfind) response(href string, props davProps) davResponse {
+	var found, missing strings.Builder
+	if p.Prop == nil || p.AllProp != nil {
+		names := make([]xml.Name, 0, len(props))
+		for name := range props {
+			if name.Local != "calendar-data" {
+				names = append(names, name)
+			}
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if names[i].Space == names[j].Space {
+				return names[i].Local < names[j].Local
+			}
+			return names[i].Space < names[j].Space
+		})
+		for _, name := range names {
+			writePropElement(&found, name, props[name], p.PropName != nil)
+		}
+	} else {
+		for _, requested := range p.Prop.Names {
+			if content, ok := props[requested.XMLName]; ok {
+				writePropElement(&found, requested.XMLName, content, false)
+				continue
+			}
+			writePropElement(&missing, requested.XMLName, "", true)
+		}
+	}
+
+	response := davResponse{Href: href}
+	if found.Len() > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davPropXML{Inner: found.String()}, Status: davStatus(http.StatusOK)})
+	}
+	if missing.Len() > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davPropXML{Inner: missing.String()}, Status: davStatus(http.StatusNotFound)})
+	}
+	if len(response.Propstats) == 0 {
+		response.Propstats = []davPropstat{{Status: davStatus(http.StatusOK)}}
+	}
+	return response
+}
+
+var davPrefixes = map[string]string{
+	davNamespace:    "d",
+	calDAVNamespace: "c",
+	calServerNS:     "cs",
+}
+
+func writePropElement(b *strings.Builder, name xml.Name, content string, empty bool) {
+	prefix, known := davPrefixes[name.Space]
+	tag := prefix + ":" + name.Local
+	open := tag
+	if !known {
+		tag = name.Local
+		open = tag + ` xmlns="` + davText(name.Space) + `"`
+	}
+	if empty || content == "" {
+		b.WriteString("<" + open + "/>")
+		return
+	}
+	b.WriteString("<" + open + ">" + content + "</" + tag + ">")
+}
+
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"d:multistatus"`
+	DAV       string        `xml:"xmlns:d,attr"`
+	CalDAV    string        `xml:"xmlns:c,attr"`
+	CalServer string        `xml:"xmlns:cs,attr"`
+	Responses []davResponse `xml:"d:response"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"d:href"`
+	Propstats []davPropstat `xml:"d:propstat,omitempty"`
+	Status    string        `xml:"d:status,omitempty"`
+}
+
+type davPropstat struct {
+	Prop   davPropXML `xml:"d:prop"`
+	Status string     `xml:"d:status"`
+}
+
+type davPropXML struct {
+	Inner string `xml:",innerxml"`
+}
+
+// calendarReport is a calendar-query or calendar-multiget REPORT body.
+type calendarReport struct {
+	XMLName xml.Name
+	Prop    *davPropNames      `xml:"DAV: prop"`
+	Hrefs   []string           `xml:"DAV: href"`
+	Filter  calendarCompFilter `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+}
+
+type calendarCompFilter struct {
+	Name      string               `xml:"name,attr"`
+	TimeRange *calendarTimeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Comps     []calendarCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type calendarTimeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// eventRange extracts the VEVENT time-range of a calendar-query filter.
+// events is false when the filter selects another component type, which
+// this server never stores.
+func (f calendarCompFilter) eventRange() (timeRange application.CalDAVTimeRange, events bool, ok bool) {
+	if f.Name != "" && !strings.EqualFold(f.Name, "VCALENDAR") {
+		return timeRange, false, false
+	}
+	if len(f.Comps) == 0 {
+		return timeRange, true, true
+	}
+	for _, comp := range f.Comps {
+		if !strings.EqualFold(comp.Name, "VEVENT") {
+			continue
+		}
+		if comp.TimeRange == nil {
+			return timeRange, true, true
+		}
+		for _, bound := range []struct {
+			value  string
+			target **time.Time
+		}{{comp.TimeRange.Start, &timeRange.Start}, {comp.TimeRange.End, &timeRange.End}} {
+			if bound.value == "" {
+				continue
+			}
+			t, err := time.Parse(calDAVTimeLayout, bound.value)
+			if err != nil {
+				return application.CalDAVTimeRange{}, false, false
+			}
+			*bound.target = &t
+		}
+		return timeRange, true, true
+	}
+	return timeRange, false, true
+}
@@ -0,0 +1,295 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type calDAVSyncService interface {
+	ListSyncAccounts(ctx context.Context, principal application.Principal, userID string) ([]application.CalDAVSyncAccount, error)
+	CreateSyncAccount(ctx context.Context, params application.CreateCalDAVSyncAccountParams) (application.CalDAVSyncAccount, error)
+	DeleteSyncAccount(ctx context.Context, principal application.Principal, userID, accountID string) error
+	ListSyncRuns(ctx context.Context, principal application.Principal, userID, accountID string, limit int) ([]application.CalDAVSyncRun, error)
+	SyncNow(ctx context.Context, principal application.Principal, userID, accountID string) (application.CalDAVSyncRun, error)
+}
+
+// CalDAVSyncHandler serves the remote calendar syncs nested under
+// /users/{id}/calendar-syncs.
+type CalDAVSyncHandler struct {
+	service   calDAVSyncService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewCalDAVSyncHandler(service calDAVSyncService, logger *slog.Logger) *CalDAVSyncHandler {
+	base := defaultLogger(logger)
+	return &CalDAVSyncHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *CalDAVSyncHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "CalDAVSyncHandler", operation, attrs...)
+}
+
+func (h *CalDAVSyncHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "List", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for calendar syncs")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID, "user_id", userID)
+
+	accounts, err := h.service.ListSyncAccounts(r.Context(), principal, userID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar sync list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]calDAVSyncDTO, 0, len(accounts))
+	for _, account := range accounts {
+		items = append(items, toCalDAVSyncDTO(account, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "calendar syncs listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listCalDAVSyncsResponse{Syncs: items})
+}
+
+func (h *CalDAVSyncHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Create", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for calendar syncs")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req calDAVSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode calendar sync", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID, "user_id", userID)
+
+	account, err := h.service.CreateSyncAccount(r.Context(), application.CreateCalDAVSyncAccountParams{
+		Principal: principal,
+		UserID:    userID,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar sync creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("sync_account_id", account.ID).InfoContext(r.Context(), "calendar sync created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, calDAVSyncResponse{Sync: toCalDAVSyncDTO(account, displayLocation(principal))})
+}
+
+func (h *CalDAVSyncHandler) Delete(w http.ResponseWriter, r *http.Request, accountID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Delete", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for calendar syncs")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "user_id", userID, "sync_account_id", accountID)
+
+	if err := h.service.DeleteSyncAccount(r.Context(), principal, userID, accountID); err != nil {
+		logger.ErrorContext(r.Context(), "calendar sync delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "calendar sync deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+func (h *CalDAVSyncHandler) ListRuns(w http.ResponseWriter, r *http.Request, accountID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "ListRuns", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for calendar syncs")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		h.log(r.Context(), "ListRuns", "principal_id", principal.UserID, "sync_account_id", accountID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+
+	logger := h.log(r.Context(), "ListRuns", "principal_id", principal.UserID, "user_id", userID, "sync_account_id", accountID)
+
+	runs, err := h.service.ListSyncRuns(r.Context(), principal, userID, accountID, limit)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar sync run list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]calDAVSyncRunDTO, 0, len(runs))
+	for _, run := range runs {
+		items = append(items, toCalDAVSyncRunDTO(run, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "calendar sync runs listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listCalDAVSyncRunsResponse{Runs: items})
+}
+
+// SyncNow runs a sync immediately and responds with the recorded run.
+func (h *CalDAVSyncHandler) SyncNow(w http.ResponseWriter, r *http.Request, accountID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "SyncNow", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for calendar syncs")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "SyncNow", "principal_id", principal.UserID, "user_id", userID, "sync_account_id", accountID)
+
+	run, err := h.service.SyncNow(r.Context(), principal, userID, accountID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar sync failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("sync_run_id", run.ID, "status", string(run.Status)).InfoContext(r.Context(), "calendar sync run")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, calDAVSyncRunResponse{Run: toCalDAVSyncRunDTO(run, displayLocation(principal))})
+}
+
+type calDAVSyncRequest struct {
+	CollectionURL  string `json:"collection_url"`
+	Username       string `json:"username"`
+	Password       string `json:"password"`
+	ConflictPolicy string `json:"conflict_policy"`
+	Active         *bool  `json:"active"`
+}
+
+func (r calDAVSyncRequest) toInput() application.CalDAVSyncAccountInput {
+	return application.CalDAVSyncAccountInput{
+		CollectionURL:  r.CollectionURL,
+		Username:       r.Username,
+		Password:       r.Password,
+		ConflictPolicy: application.CalDAVSyncConflictPolicy(strings.TrimSpace(r.ConflictPolicy)),
+		Active:         r.Active,
+	}
+}
+
+type listCalDAVSyncsResponse struct {
+	Syncs []calDAVSyncDTO `json:"syncs"`
+}
+
+type calDAVSyncResponse struct {
+	Sync calDAVSyncDTO `json:"sync"`
+}
+
+// calDAVSyncDTO never carries the remote password.
+type calDAVSyncDTO struct {
+	ID             string `json:"id"`
+	CollectionURL  string `json:"collection_url"`
+	Username       string `json:"username"`
+	ConflictPolicy string `json:"conflict_policy"`
+	Active         bool   `json:"active"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func toCalDAVSyncDTO(account application.CalDAVSyncAccount, loc *time.Location) calDAVSyncDTO {
+	return calDAVSyncDTO{
+		ID:             account.ID,
+		CollectionURL:  account.CollectionURL,
+		Username:       account.Username,
+		ConflictPolicy: string(account.ConflictPolicy),
+		Active:         account.Active,
+		CreatedAt:      formatInLocation(account.CreatedAt, loc),
+	}
+}
+
+type listCalDAVSyncRunsResponse struct {
+	Runs []calDAVSyncRunDTO `json:"runs"`
+}
+
+type calDAVSyncRunResponse struct {
+	Run calDAVSyncRunDTO `json:"run"`
+}
+
+type calDAVSyncRunDTO struct {
+	ID         string              `json:"id"`
+	Status     string              `json:"status"`
+	StartedAt  string              `json:"started_at"`
+	FinishedAt string              `json:"finished_at"`
+	Pulled     calDAVSyncCountsDTO `json:"pulled"`
+	Pushed     calDAVSyncCountsDTO `json:"pushed"`
+	Conflicts  int                 `json:"conflicts"`
+	Skipped    int                 `json:"skipped"`
+	Failures   int                 `json:"failures"`
+	Error      string              `json:"error,omitempty"`
+}
+
+type calDAVSyncCountsDTO struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+func toCalDAVSyncRunDTO(run application.CalDAVSyncRun, loc *time.Location) calDAVSyncRunDTO {
+	return calDAVSyncRunDTO{
+		ID:         run.ID,
+		Status:     string(run.Status),
+		StartedAt:  formatInLocation(run.StartedAt, loc),
+		FinishedAt: formatInLocation(run.FinishedAt, loc),
+		Pulled:     calDAVSyncCountsDTO(run.Pulled),
+		Pushed:     calDAVSyncCountsDTO(run.Pushed),
+		Conflicts:  run.Conflicts,
+		Skipped:    run.Skipped,
+		Failures:   run.Failures,
+		Error:      run.Error,
+	}
+}
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type calendarService interface {
+	ListCalendars(ctx context.Context, principal application.Principal) ([]application.Calendar, error)
+	GetCalendar(ctx context.Context, principal application.Principal, calendarID string) (application.Calendar, error)
+	CreateCalendar(ctx context.Context, params application.CreateCalendarParams) (application.Calendar, error)
+	UpdateCalendar(ctx context.Context, params application.UpdateCalendarParams) (application.Calendar, error)
+	DeleteCalendar(ctx context.Context, principal application.Principal, calendarID string) error
+}
+
+// CalendarHandler serves shared calendars and their access lists.
+type CalendarHandler struct {
+	service   calendarService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewCalendarHandler(service calendarService, logger *slog.Logger) *CalendarHandler {
+	base := defaultLogger(logger)
+	return &CalendarHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *CalendarHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "CalendarHandler", operation, attrs...)
+}
+
+// List returns the calendars the caller owns, edits or views.
+func (h *CalendarHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	calendars, err := h.service.ListCalendars(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]calendarDTO, 0, len(calendars))
+	for _, calendar := range calendars {
+		items = append(items, toCalendarDTO(calendar, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "calendars listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listCalendarsResponse{Calendars: items})
+}
+
+func (h *CalendarHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req calendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode calendar", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	calendar, err := h.service.CreateCalendar(r.Context(), application.CreateCalendarParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("calendar_id", calendar.ID).InfoContext(r.Context(), "calendar created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, calendarResponse{Calendar: toCalendarDTO(calendar, displayLocation(principal))})
+}
+
+func (h *CalendarHandler) Get(w http.ResponseWriter, r *http.Request, calendarID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "calendar_id", calendarID)
+
+	calendar, err := h.service.GetCalendar(r.Context(), principal, calendarID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "calendar retrieved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, calendarResponse{Calendar: toCalendarDTO(calendar, displayLocation(principal))})
+}
+
+func (h *CalendarHandler) Update(w http.ResponseWriter, r *http.Request, calendarID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req calendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "calendar_id", calendarID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode calendar", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Update", "principal_id", principal.UserID, "calendar_id", calendarID)
+
+	calendar, err := h.service.UpdateCalendar(r.Context(), application.UpdateCalendarParams{
+		Principal:  principal,
+		CalendarID: calendarID,
+		Input:      req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "calendar updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, calendarResponse{Calendar: toCalendarDTO(calendar, displayLocation(principal))})
+}
+
+func (h *CalendarHandler) Delete(w http.ResponseWriter, r *http.Request, calendarID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "calendar_id", calendarID)
+
+	if err := h.service.DeleteCalendar(r.Context(), principal, calendarID); err != nil {
+		logger.ErrorContext(r.Context(), "calendar delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "calendar deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type calendarRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	OwnerID     string   `json:"owner_id"`
+	EditorIDs   []string `json:"editor_ids"`
+	ViewerIDs   []string `json:"viewer_ids"`
+}
+
+func (req calendarRequest) toInput() application.CalendarInput {
+	return application.CalendarInput{
+		Name:        req.Name,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		EditorIDs:   req.EditorIDs,
+		ViewerIDs:   req.ViewerIDs,
+	}
+}
+
+type listCalendarsResponse struct {
+	Calendars []calendarDTO `json:"calendars"`
+}
+
+type calendarResponse struct {
+	Calendar calendarDTO `json:"calendar"`
+}
+
+type calendarDTO struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	OwnerID     string   `json:"owner_id"`
+	EditorIDs   []string `json:"editor_ids"`
+	ViewerIDs   []string `json:"viewer_ids"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+func toCalendarDTO(calendar application.Calendar, loc *time.Location) calendarDTO {
+	dto := calendarDTO{
+		ID:          calendar.ID,
+		Name:        calendar.Name,
+		Description: calendar.Description,
+		OwnerID:     calendar.OwnerID,
+		EditorIDs:   calendar.EditorIDs,
+		ViewerIDs:   calendar.ViewerIDs,
+		CreatedAt:   formatInLocation(calendar.CreatedAt, loc),
+		UpdatedAt:   formatInLocation(calendar.UpdatedAt, loc),
+	}
+	if dto.EditorIDs == nil {
+		dto.EditorIDs = []string{}
+	}
+	if dto.ViewerIDs == nil {
+		dto.ViewerIDs = []string{}
+	}
+	return dto
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type calendarViewService interface {
+	CalendarView(ctx context.Context, params application.CalendarViewParams) (application.CalendarView, error)
+}
+
+// CalendarViewHandler serves day, week and month views laid out for the UI.
+type CalendarViewHandler struct {
+	service   calendarViewService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewCalendarViewHandler(service calendarViewService, logger *slog.Logger) *CalendarViewHandler {
+	base := defaultLogger(logger)
+	return &CalendarViewHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *CalendarViewHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "CalendarViewHandler", operation, attrs...)
+}
+
+// Get renders the view of period containing the `date` query parameter, or
+// today when it is omitted.
+func (h *CalendarViewHandler) Get(w http.ResponseWriter, r *http.Request, period string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	values := r.URL.Query()
+	loc := displayLocation(principal)
+	params := application.CalendarViewParams{
+		Principal:      principal,
+		Period:         application.ListPeriod(period),
+		ParticipantIDs: parseCSV(values.Get("participants")),
+	}
+	if raw := strings.TrimSpace(values.Get("date")); raw != "" {
+		date, err := time.ParseInLocation(dateLayout, raw, loc)
+		if err != nil {
+			h.log(r.Context(), "Get", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid date parameter", "date", raw)
+			h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidHolidayDate)
+			return
+		}
+		params.Date = date
+	}
+
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "period", period)
+	view, err := h.service.CalendarView(r.Context(), params)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "calendar view failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("day_count", len(view.Days), "lane_count", len(view.Lanes)).InfoContext(r.Context(), "calendar view rendered")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, toCalendarViewResponse(view, loc))
+}
+
+type calendarViewResponse struct {
+	View  string            `json:"view"`
+	Start string            `json:"start"`
+	End   string            `json:"end"`
+	Axis  calendarAxisDTO   `json:"axis"`
+	Days  []calendarDayDTO  `json:"days"`
+	Lanes []calendarLaneDTO `json:"lanes"`
+}
+
+type calendarAxisDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type calendarDayDTO struct {
+	Date    string      `json:"date"`
+	Weekend bool        `json:"weekend"`
+	Holiday *holidayDTO `json:"holiday,omitempty"`
+}
+
+type calendarLaneDTO struct {
+	ParticipantID string               `json:"participant_id"`
+	Days          []calendarLaneDayDTO `json:"days"`
+}
+
+type calendarLaneDayDTO struct {
+	Date        string                   `json:"date"`
+	AllDay      []scheduleInstanceDTO    `json:"all_day"`
+	Events      []calendarEventDTO       `json:"events"`
+	OutOfOffice []calendarOutOfOfficeDTO `json:"out_of_office"`
+}
+
+// calendarEventDTO is an instance with the part of it drawn on one day and
+// its place among overlapping events.
+type calendarEventDTO struct {
+	scheduleInstanceDTO
+	SegmentStart string `json:"segment_start"`
+	SegmentEnd   string `json:"segment_end"`
+	Column       int    `json:"column"`
+	Columns      int    `json:"columns"`
+}
+
+type calendarOutOfOfficeDTO struct {
+	ID           string `json:"id"`
+	Note         string `json:"note,omitempty"`
+	SegmentStart string `json:"segment_start"`
+	SegmentEnd   string `json:"segment_end"`
+}
+
+func toCalendarViewResponse(view application.CalendarView, loc *time.Location) calendarViewResponse {
+	response := calendarViewResponse{
+		View:  string(view.Period),
+		Start: formatInLocation(view.Start, loc),
+		End:   formatInLocation(view.End, loc),
+		Axis:  calendarAxisDTO{Start: formatAxisOffset(view.AxisStart), End: formatAxisOffset(view.AxisEnd)},
+		Days:  make([]calendarDayDTO, 0, len(view.Days)),
+		Lanes: make([]calendarLaneDTO, 0, len(view.Lanes)),
+	}
+	for _, day := range view.Days {
+		dto := calendarDayDTO{Date: day.Date.Format(dateLayout), Weekend: day.Weekend}
+		if day.Holiday != nil {
+			holiday := toHolidayDTO(*day.Holiday)
+			dto.Holiday = &holiday
+		}
+		response.Days = append(response.Days, dto)
+	}
+	for _, lane := range view.Lanes {
+		laneDTO := calendarLaneDTO{ParticipantID: lane.ParticipantID, Days: make([]calendarLaneDayDTO, 0, len(lane.Days))}
+		for i, day := range lane.Days {
+			dayDTO := calendarLaneDayDTO{
+				Date:        response.Days[i].Date,
+				AllDay:      make([]scheduleInstanceDTO, 0, len(day.AllDay)),
+				Events:      make([]calendarEventDTO, 0, len(day.Events)),
+				OutOfOffice: make([]calendarOutOfOfficeDTO, 0, len(day.OutOfOffice)),
+			}
+			for _, instance := range day.AllDay {
+				dayDTO.AllDay = append(dayDTO.AllDay, toScheduleInstanceDTO(instance, loc))
+			}
+			for _, event := range day.Events {
+				dayDTO.Events = append(dayDTO.Events, calendarEventDTO{
+					scheduleInstanceDTO: toScheduleInstanceDTO(event.Instance, loc),
+					SegmentStart:        formatInLocation(event.Start, loc),
+					SegmentEnd:          formatInLocation(event.End, loc),
+					Column:              event.Column,
+					Columns:             event.Columns,
+				})
+			}
+			for _, absence := range day.OutOfOffice {
+				dayDTO.OutOfOffice = append(dayDTO.OutOfOffice, calendarOutOfOfficeDTO{
+					ID:           absence.Entry.ID,
+					Note:         absence.Entry.Note,
+					SegmentStart: formatInLocation(absence.Start, loc),
+					SegmentEnd:   formatInLocation(absence.End, loc),
+				})
+			}
+			laneDTO.Days = append(laneDTO.Days, dayDTO)
+		}
+		response.Lanes = append(response.Lanes, laneDTO)
+	}
+	return response
+}
+
+// formatAxisOffset renders an offset from midnight as HH:MM.
+func formatAxisOffset(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type colleagueGroupService interface {
+	ListColleagueGroups(ctx context.Context, principal application.Principal) ([]application.ColleagueGroup, error)
+	GetColleagueGroup(ctx context.Context, principal application.Principal, groupID string) (application.ColleagueGroup, error)
+	CreateColleagueGroup(ctx context.Context, params application.CreateColleagueGroupParams) (application.ColleagueGroup, error)
+	UpdateColleagueGroup(ctx context.Context, params application.UpdateColleagueGroupParams) (application.ColleagueGroup, error)
+	DeleteColleagueGroup(ctx context.Context, principal application.Principal, groupID string) error
+}
+
+// ColleagueGroupHandler serves the caller's saved colleague groups.
+type ColleagueGroupHandler struct {
+	service   colleagueGroupService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewColleagueGroupHandler(service colleagueGroupService, logger *slog.Logger) *ColleagueGroupHandler {
+	base := defaultLogger(logger)
+	return &ColleagueGroupHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *ColleagueGroupHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "ColleagueGroupHandler", operation, attrs...)
+}
+
+// List returns the groups the caller owns or that are shared with them.
+func (h *ColleagueGroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	groups, err := h.service.ListColleagueGroups(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "colleague group list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]colleagueGroupDTO, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, toColleagueGroupDTO(group, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "colleague groups listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listColleagueGroupsResponse{Groups: items})
+}
+
+func (h *ColleagueGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req colleagueGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode colleague group", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	group, err := h.service.CreateColleagueGroup(r.Context(), application.CreateColleagueGroupParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "colleague group creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("group_id", group.ID).InfoContext(r.Context(), "colleague group created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, colleagueGroupResponse{Group: toColleagueGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *ColleagueGroupHandler) Get(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "group_id", groupID)
+
+	group, err := h.service.GetColleagueGroup(r.Context(), principal, groupID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "colleague group lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "colleague group retrieved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, colleagueGroupResponse{Group: toColleagueGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *ColleagueGroupHandler) Update(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req colleagueGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "group_id", groupID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode colleague group", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Update", "principal_id", principal.UserID, "group_id", groupID)
+
+	group, err := h.service.UpdateColleagueGroup(r.Context(), application.UpdateColleagueGroupParams{
+		Principal: principal,
+		GroupID:   groupID,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "colleague group update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "colleague group updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, colleagueGroupResponse{Group: toColleagueGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *ColleagueGroupHandler) Delete(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "group_id", groupID)
+
+	if err := h.service.DeleteColleagueGroup(r.Context(), principal, groupID); err != nil {
+		logger.ErrorContext(r.Context(), "colleague group delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "colleague group deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type colleagueGroupRequest struct {
+	Name       string   `json:"name"`
+	MemberIDs  []string `json:"member_ids"`
+	SharedWith []string `json:"shared_with"`
+}
+
+func (req colleagueGroupRequest) toInput() application.ColleagueGroupInput {
+	return application.ColleagueGroupInput{Name: req.Name, MemberIDs: req.MemberIDs, SharedWith: req.SharedWith}
+}
+
+type listColleagueGroupsResponse struct {
+	Groups []colleagueGroupDTO `json:"groups"`
+}
+
+type colleagueGroupResponse struct {
+	Group colleagueGroupDTO `json:"group"`
+}
+
+type colleagueGroupDTO struct {
+	ID         string   `json:"id"`
+	OwnerID    string   `json:"owner_id"`
+	Name       string   `json:"name"`
+	MemberIDs  []string `json:"member_ids"`
+	SharedWith []string `json:"shared_with"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+func toColleagueGroupDTO(group application.ColleagueGroup, loc *time.Location) colleagueGroupDTO {
+	dto := colleagueGroupDTO{
+		ID:         group.ID,
+		OwnerID:    group.OwnerID,
+		Name:       group.Name,
+		MemberIDs:  group.MemberIDs,
+		SharedWith: group.SharedWith,
+		CreatedAt:  formatInLocation(group.CreatedAt, loc),
+		UpdatedAt:  formatInLocation(group.UpdatedAt, loc),
+	}
+	if dto.MemberIDs == nil {
+		dto.MemberIDs = []string{}
+	}
+	if dto.SharedWith == nil {
+		dto.SharedWith = []string{}
+	}
+	return dto
+}
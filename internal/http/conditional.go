@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+// setVersionETag sets the ETag header for a schedule, room or user at version.
+func setVersionETag(w http.ResponseWriter, version int) {
+	if version > 0 {
+		w.Header().Set("ETag", application.VersionETag(version))
+	}
+}
+
+// requireIfMatch returns the If-Match header of an update. PUT requests must
+// name the version they replace, so a missing header is answered with 428.
+func requireIfMatch(w http.ResponseWriter, r *http.Request, rs responder) (string, bool) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		rs.writeError(r.Context(), w, http.StatusPreconditionRequired, errIfMatchRequired)
+		return "", false
+	}
+	return ifMatch, true
+}
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type departmentService interface {
+	ListDepartments(ctx context.Context, principal application.Principal) ([]application.Department, error)
+	GetDepartment(ctx context.Context, principal application.Principal, departmentID string) (application.Department, error)
+	CreateDepartment(ctx context.Context, params application.CreateDepartmentParams) (application.Department, error)
+	UpdateDepartment(ctx context.Context, params application.UpdateDepartmentParams) (application.Department, error)
+	DeleteDepartment(ctx context.Context, principal application.Principal, departmentID string) error
+}
+
+// DepartmentHandler serves the departments of the organization.
+type DepartmentHandler struct {
+	service   departmentService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewDepartmentHandler(service departmentService, logger *slog.Logger) *DepartmentHandler {
+	base := defaultLogger(logger)
+	return &DepartmentHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *DepartmentHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "DepartmentHandler", operation, attrs...)
+}
+
+// List returns every department by name.
+func (h *DepartmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	departments, err := h.service.ListDepartments(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "department list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]departmentDTO, 0, len(departments))
+	for _, department := range departments {
+		items = append(items, toDepartmentDTO(department, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "departments listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listDepartmentsResponse{Departments: items})
+}
+
+func (h *DepartmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req departmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode department", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	department, err := h.service.CreateDepartment(r.Context(), application.CreateDepartmentParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "department creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("department_id", department.ID).InfoContext(r.Context(), "department created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, departmentResponse{Department: toDepartmentDTO(department, displayLocation(principal))})
+}
+
+func (h *DepartmentHandler) Get(w http.ResponseWriter, r *http.Request, departmentID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "department_id", departmentID)
+
+	department, err := h.service.GetDepartment(r.Context(), principal, departmentID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "department lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "department retrieved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, departmentResponse{Department: toDepartmentDTO(department, displayLocation(principal))})
+}
+
+func (h *DepartmentHandler) Update(w http.ResponseWriter, r *http.Request, departmentID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req departmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "department_id", departmentID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode department", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Update", "principal_id", principal.UserID, "department_id", departmentID)
+
+	department, err := h.service.UpdateDepartment(r.Context(), application.UpdateDepartmentParams{
+		Principal:    principal,
+		DepartmentID: departmentID,
+		Input:        req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "department update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "department updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, departmentResponse{Department: toDepartmentDTO(department, displayLocation(principal))})
+}
+
+func (h *DepartmentHandler) Delete(w http.ResponseWriter, r *http.Request, departmentID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "department_id", departmentID)
+
+	if err := h.service.DeleteDepartment(r.Context(), principal, departmentID); err != nil {
+		logger.ErrorContext(r.Context(), "department delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "department deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type departmentRequest struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+}
+
+func (req departmentRequest) toInput() application.DepartmentInput {
+	return application.DepartmentInput{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+}
+
+type listDepartmentsResponse struct {
+	Departments []departmentDTO `json:"departments"`
+}
+
+type departmentResponse struct {
+	Department departmentDTO `json:"department"`
+}
+
+type departmentDTO struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ParentID  string `json:"parent_id,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toDepartmentDTO(department application.Department, loc *time.Location) departmentDTO {
+	return departmentDTO{
+		ID:        department.ID,
+		Name:      department.Name,
+		ParentID:  department.ParentID,
+		CreatedAt: formatInLocation(department.CreatedAt, loc),
+		UpdatedAt: formatInLocation(department.UpdatedAt, loc),
+	}
+}
@@ -16,6 +16,65 @@
 //     schedule management endpoints exchanging the `scheduleDTO` payload defined in
 //     schedule_handler.go. Schedule responses include conflict warnings and expanded
 //     recurrence occurrences.
+//   - POST /schedules:batch: applies up to 500 schedule creates, updates and deletes
+//     through the same service calls as the single endpoints, defined in
+//     schedule_batch_handler.go. `all_or_nothing` runs them in one transaction while
+//     `best_effort` keeps the ones that succeed; each result carries its own status.
+//   - GET /schedules/changes?since=&participants=: delta sync returning schedules
+//     created or updated since the sync token, `deleted` tombstones and the next
+//     `sync_token`. Tokens are bound to the participant filter.
+//   - GET/PUT /users/{id}/working-hours, GET/POST /users/{id}/out-of-office and
+//     DELETE /users/{id}/out-of-office/{entryID}: per-user availability endpoints
+//     defined in availability_handler.go. Schedule writes warn with
+//     `outside_working_hours`, `out_of_office` or `auto_declined` based on them.
+//   - GET /holidays?year=, POST /holidays and DELETE /holidays/{date}: Japanese public
+//     holidays merged with company closure days, defined in holiday_handler.go.
+//     Closures are managed by administrators.
+//   - GET/PUT /users/{id}/reminders: per-user reminder defaults and channel opt-in
+//     defined in reminder_handler.go. Schedules may override the timing with
+//     `reminder_minutes`.
+//   - GET/POST /webhooks, PUT/DELETE /webhooks/{id}, GET /webhooks/{id}/deliveries and
+//     POST /webhooks/{id}/deliveries/{deliveryID}/redeliver: administrator managed
+//     webhook subscriptions and delivery logs defined in webhook_handler.go. Schedule,
+//     room and user mutations emit events that are signed and delivered asynchronously.
+//   - GET /events/stream?participants=&rooms=: Server-Sent Events stream of schedule
+//     events relevant to the caller, watched colleagues and watched rooms, defined in
+//     event_stream_handler.go. Supports Last-Event-ID resume and sends heartbeats.
+//   - GET/POST /users/{id}/app-passwords and DELETE /users/{id}/app-passwords/{passwordID}:
+//     app passwords for clients that cannot hold a session, defined in
+//     app_password_handler.go. The secret is only returned on creation.
+//   - /dav/ and /.well-known/caldav: a CalDAV subset defined in caldav_handler.go
+//     serving one calendar per user and a read-only calendar per room. Requests use
+//     basic auth with an e-mail address and an app password (see RequireBasicAuth).
+//   - GET/POST /users/{id}/calendar-syncs, DELETE /users/{id}/calendar-syncs/{syncID}
+//     and GET/POST /users/{id}/calendar-syncs/{syncID}/runs: connections syncing a
+//     user's calendar with an external CalDAV collection and their run history,
+//     defined in caldav_sync_handler.go. POST .../runs syncs immediately.
+//   - GET /trash, POST /schedules/{id}/restore and POST /rooms/{id}/restore: deleted
+//     schedules and rooms stay in the caller's trash, defined in trash_handler.go, until
+//     the retention period passes and they are purged.
+//   - GET /schedules/{id}/revisions, GET /schedules/{id}/revisions/{n} and
+//     POST /schedules/{id}/revisions/{n}/revert: the version history of a schedule
+//     with field-level changes, defined in schedule_revision_handler.go. `compare_to`
+//     picks the revision to diff against; a revert is validated like an update.
+//
+// List endpoints (GET /users, /rooms, /schedules) are cursor paginated: `limit`
+// (default 100, max 500) bounds the page and the opaque `next_cursor` from a
+// response is passed back as `cursor` to fetch the following page. GET /schedules
+// additionally accepts `room_id`, `creator_id`, `title_prefix` and `updated_since`.
+//
+// Single schedule, room and user responses carry the record's version as an
+// ETag. PUT /schedules/{id}, /rooms/{id} and /users/{id} require it back in
+// If-Match: a missing header is answered with 428 and a stale one with 412.
+//
+// RequireIdempotency, defined in idempotency.go, lets authenticated POST and PUT
+// requests carry an Idempotency-Key. The first response is stored per principal
+// and key; a retry with the same body replays it and a different body is
+// rejected with 422.
+//
+// Schedules with `all_day` set exchange `start`/`end` as inclusive YYYY-MM-DD dates
+// and only produce participant conflicts when `busy` is also set. A recurrence may
+// set `holiday_policy` to `skip` or `next_business_day` to avoid holidays.
 //
 // Request/response DTOs live alongside their respective handlers so tests and
 // documentation share the same ground truth.
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+// defaultHeartbeatInterval keeps idle streams alive through proxies.
+const defaultHeartbeatInterval = 15 * time.Second
+
+type eventStreamService interface {
+	OpenEventStream(ctx context.Context, params application.OpenEventStreamParams) (*application.EventStream, error)
+}
+
+// EventStreamHandler serves GET /events/stream as Server-Sent Events.
+type EventStreamHandler struct {
+	service   eventStreamService
+	responder responder
+	logger    *slog.Logger
+	heartbeat time.Duration
+}
+
+func NewEventStreamHandler(service eventStreamService, logger *slog.Logger) *EventStreamHandler {
+	base := defaultLogger(logger)
+	return &EventStreamHandler{service: service, responder: newResponder(base), logger: base, heartbeat: defaultHeartbeatInterval}
+}
+
+func (h *EventStreamHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "EventStreamHandler", operation, attrs...)
+}
+
+// Stream pushes schedule events relevant to the caller, the colleagues listed
+// in `participants` and the rooms listed in `rooms`. Each event carries its
+// outbox sequence as the SSE id so reconnecting clients resume through the
+// Last-Event-ID header (or the `last_event_id` query parameter).
+func (h *EventStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	principal, _ := PrincipalFromContext(ctx)
+	params := application.OpenEventStreamParams{
+		Principal:      principal,
+		WatchedUserIDs: parseCSV(r.URL.Query().Get("participants")),
+		WatchedRoomIDs: parseCSV(r.URL.Query().Get("rooms")),
+	}
+
+	rawLastEventID := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if rawLastEventID == "" {
+		rawLastEventID = strings.TrimSpace(r.URL.Query().Get("last_event_id"))
+	}
+	if rawLastEventID != "" {
+		lastEventID, err := strconv.ParseInt(rawLastEventID, 10, 64)
+		if err != nil || lastEventID < 0 {
+			h.log(ctx, "Stream", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(ctx, "invalid last event id", "last_event_id", rawLastEventID)
+			h.responder.writeError(ctx, w, http.StatusBadRequest, errInvalidLastEventID)
+			return
+		}
+		params.LastEventID = &lastEventID
+	}
+
+	logger := h.log(ctx, "Stream", "principal_id", principal.UserID)
+
+	stream, err := h.service.OpenEventStream(ctx, params)
+	if err != nil {
+		if errors.Is(err, application.ErrStreamClosed) {
+			logger.WarnContext(ctx, "event stream rejected during shutdown")
+			h.responder.writeError(ctx, w, http.StatusServiceUnavailable, errServiceShuttingDown)
+			return
+		}
+		logger.ErrorContext(ctx, "event stream open failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(ctx, w, err)
+		return
+	}
+
+	// The server's write timeout would otherwise cut long-lived streams.
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		logger.ErrorContext(ctx, "event stream flush unsupported", "error", err)
+		return
+	}
+	logger.InfoContext(ctx, "event stream started")
+
+	sent := 0
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, h.heartbeat)
+		events, err := stream.Next(waitCtx)
+		cancel()
+
+		switch {
+		case err == nil:
+			for _, event := range events {
+				_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, event.Payload)
+				if err != nil {
+					break
+				}
+				sent++
+			}
+		case ctx.Err() != nil:
+			logger.With("sent_count", sent).InfoContext(ctx, "event stream closed by client")
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case errors.Is(err, application.ErrStreamClosed):
+			logger.With("sent_count", sent).InfoContext(ctx, "event stream closed for shutdown")
+			return
+		default:
+			logger.ErrorContext(ctx, "event stream failed", "error", err, "error_kind", application.ErrorKind(err))
+			return
+		}
+
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			logger.With("sent_count", sent).InfoContext(ctx, "event stream write failed", "error", err)
+			return
+		}
+	}
+}
@@ -461,6 +461,7 @@ func TestScheduleHandlers(t *testing.T) {
 		}
 
 		req := httptest.NewRequest(http.MethodPut, "/schedules/schedule-1", bytes.NewReader(body))
+		req.Header.Set("If-Match", `"3"`)
 		ctx := ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"})
 		ctx = ContextWithScheduleID(ctx, "schedule-1")
 		req = req.WithContext(ctx)
@@ -548,6 +549,68 @@ func TestScheduleHandlers(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateAllDay accepts dates and renders them back", func(t *testing.T) {
+		t.Parallel()
+
+		var capturedParams application.CreateScheduleParams
+		service := &fakeScheduleService{
+			createScheduleFunc: func(ctx context.Context, params application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
+				capturedParams = params
+				return application.Schedule{
+					ID:             "schedule-offsite",
+					Title:          params.Input.Title,
+					Start:          mustParse(t, "2024-05-01T00:00:00Z"),
+					End:            mustParse(t, "2024-05-04T00:00:00Z"),
+					AllDay:         true,
+					Busy:           true,
+					ParticipantIDs: params.Input.ParticipantIDs,
+				}, nil, nil
+			},
+		}
+
+		handler := NewScheduleHandler(service, nil)
+
+		body, err := json.Marshal(map[string]any{
+			"title":           "Offsite",
+			"start":           "2024-05-01",
+			"end":             "2024-05-03",
+			"all_day":         true,
+			"busy":            true,
+			"participant_ids": []string{"user-1"},
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal payload: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/schedules", bytes.NewReader(body))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1", TimeZone: "America/New_York"}))
+		recorder := httptest.NewRecorder()
+
+		handler.Create(recorder, req)
+
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		if res.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", res.StatusCode)
+		}
+		input := capturedParams.Input
+		if !input.AllDay || !input.Busy {
+			t.Fatalf("expected all-day busy input, got %+v", input)
+		}
+		if !input.Start.Equal(mustParse(t, "2024-05-01T00:00:00Z")) || !input.End.Equal(mustParse(t, "2024-05-03T00:00:00Z")) {
+			t.Fatalf("expected date-only bounds, got %v - %v", input.Start, input.End)
+		}
+
+		var payload scheduleResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Schedule.Start != "2024-05-01" || payload.Schedule.End != "2024-05-03" || !payload.Schedule.AllDay {
+			t.Fatalf("expected inclusive dates in response, got %+v", payload.Schedule)
+		}
+	})
+
 	t.Run("expand recurrences in list responses", func(t *testing.T) {
 		occurrenceStart := mustParse(t, "2024-05-01T09:00:00+09:00")
 		occurrenceEnd := mustParse(t, "2024-05-01T10:00:00+09:00")
@@ -649,6 +712,7 @@ func TestScheduleHandlers(t *testing.T) {
 				handler := NewScheduleHandler(service, nil)
 
 				req := httptest.NewRequest(http.MethodPut, "/schedules/sched-999", bytes.NewReader([]byte(`{"title":"Update"}`)))
+				req.Header.Set("If-Match", `"3"`)
 				ctx := ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"})
 				ctx = ContextWithScheduleID(ctx, "sched-999")
 				req = req.WithContext(ctx)
@@ -766,6 +830,136 @@ func TestScheduleHandlers(t *testing.T) {
 		}
 	})
 
+	t.Run("renders times in the caller's preferred zone", func(t *testing.T) {
+		var captured application.ListSchedulesParams
+		service := &fakeScheduleService{
+			listSchedulesFunc: func(ctx context.Context, params application.ListSchedulesParams) ([]application.Schedule, []application.ConflictWarning, error) {
+				captured = params
+				return []application.Schedule{{
+					ID:             "sched-1",
+					CreatorID:      "user-1",
+					Title:          "Standup",
+					Start:          mustParse(t, "2024-07-01T13:00:00Z"),
+					End:            mustParse(t, "2024-07-01T13:15:00Z"),
+					TimeZone:       "America/New_York",
+					ParticipantIDs: []string{"user-1"},
+				}}, nil, nil
+			},
+		}
+
+		handler := NewScheduleHandler(service, nil)
+
+		principal := application.Principal{UserID: "user-1", TimeZone: "America/New_York"}
+		req := httptest.NewRequest(http.MethodGet, "/schedules?day=2024-07-01", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		handler.List(recorder, req)
+
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		var payload listSchedulesResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Schedules) != 1 {
+			t.Fatalf("expected 1 schedule, got %d", len(payload.Schedules))
+		}
+		if payload.Schedules[0].Start != "2024-07-01T09:00:00-04:00" {
+			t.Fatalf("expected start rendered in New York time, got %q", payload.Schedules[0].Start)
+		}
+		if payload.Schedules[0].TimeZone != "America/New_York" {
+			t.Fatalf("expected originating zone in payload, got %q", payload.Schedules[0].TimeZone)
+		}
+		if _, offset := captured.PeriodReference.Zone(); offset != -4*60*60 {
+			t.Fatalf("expected day preset parsed in caller zone, got %v", captured.PeriodReference)
+		}
+	})
+
+	t.Run("maps pagination and attribute filters", func(t *testing.T) {
+		var captured application.ListSchedulesParams
+		service := &fakeScheduleService{
+			listSchedulesPageFunc: func(ctx context.Context, params application.ListSchedulesParams) (application.SchedulePage, error) {
+				captured = params
+				return application.SchedulePage{NextCursor: "next-token"}, nil
+			},
+		}
+
+		handler := NewScheduleHandler(service, nil)
+
+		values := url.Values{}
+		values.Set("limit", "25")
+		values.Set("cursor", "abc")
+		values.Set("room_id", "room-1")
+		values.Set("creator_id", "user-2")
+		values.Set("title_prefix", "週次")
+		values.Set("group", "group-1")
+		values.Set("calendar_id", "cal-1")
+		values.Set("user_group", "grp-1")
+		values.Set("department", "dep-sales")
+		values.Set("reports_to", "me")
+		values.Set("updated_since", "2024-04-01T00:00:00Z")
+		req := httptest.NewRequest(http.MethodGet, "/schedules?"+values.Encode(), nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		handler.List(recorder, req)
+
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", res.StatusCode)
+		}
+		if captured.Limit != 25 || captured.Cursor != "abc" {
+			t.Fatalf("unexpected pagination params: limit=%d cursor=%q", captured.Limit, captured.Cursor)
+		}
+		if captured.RoomID == nil || *captured.RoomID != "room-1" {
+			t.Fatalf("expected room filter, got %v", captured.RoomID)
+		}
+		if captured.CreatorID != "user-2" || captured.TitlePrefix != "週次" || captured.GroupID != "group-1" || captured.CalendarID != "cal-1" || captured.UserGroupID != "grp-1" || captured.DepartmentID != "dep-sales" || captured.ReportsTo != "user-1" {
+			t.Fatalf("unexpected attribute filters: %#v", captured)
+		}
+		if captured.UpdatedSince == nil || !captured.UpdatedSince.Equal(mustParse(t, "2024-04-01T00:00:00Z")) {
+			t.Fatalf("unexpected updated_since: %v", captured.UpdatedSince)
+		}
+
+		var payload struct {
+			NextCursor string `json:"next_cursor"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.NextCursor != "next-token" {
+			t.Fatalf("expected next_cursor in response, got %q", payload.NextCursor)
+		}
+	})
+
+	t.Run("rejects non-numeric limit", func(t *testing.T) {
+		service := &fakeScheduleService{
+			listSchedulesPageFunc: func(ctx context.Context, params application.ListSchedulesParams) (application.SchedulePage, error) {
+				t.Fatal("ListSchedulesPage should not be called for invalid limit")
+				return application.SchedulePage{}, nil
+			},
+		}
+
+		handler := NewScheduleHandler(service, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/schedules?limit=ten", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		handler.List(recorder, req)
+
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", res.StatusCode)
+		}
+	})
+
 	t.Run("missing or forbidden schedules map to 404 or 403", func(t *testing.T) {
 		cases := []struct {
 			name     string
@@ -961,133 +1155,2348 @@ func TestRoomHandlers(t *testing.T) {
 	})
 }
 
-type fakeAuthService struct {
-	authenticateFunc func(context.Context, application.AuthenticateParams) (application.AuthenticateResult, error)
-	revokeFunc       func(context.Context, string) error
-}
+func TestAvailabilityHandlers(t *testing.T) {
+	t.Run("round trips working hours through the nested user route", func(t *testing.T) {
+		var captured application.SetWorkingHoursParams
+		service := &fakeAvailabilityService{
+			setWorkingHoursFunc: func(ctx context.Context, params application.SetWorkingHoursParams) ([]application.WorkingHours, error) {
+				captured = params
+				return params.Hours, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), Availability: NewAvailabilityHandler(service, nil)})
 
-func (f *fakeAuthService) Authenticate(ctx context.Context, params application.AuthenticateParams) (application.AuthenticateResult, error) {
-	if f.authenticateFunc != nil {
-		return f.authenticateFunc(ctx, params)
-	}
-	return application.AuthenticateResult{}, nil
-}
+		body := []byte(`{"working_hours":[{"weekday":"monday","start":"09:00","end":"18:00"},{"weekday":"Fri","start":"10:30","end":"24:00"}]}`)
+		req := httptest.NewRequest(http.MethodPut, "/users/user-1/working-hours", bytes.NewReader(body))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
 
-func (f *fakeAuthService) RevokeSession(ctx context.Context, token string) error {
-	if f.revokeFunc != nil {
-		return f.revokeFunc(ctx, token)
-	}
-	return nil
-}
+		router.ServeHTTP(recorder, req)
 
-type fakeUserService struct {
-	createUserFunc func(context.Context, application.CreateUserParams) (application.User, error)
-	updateUserFunc func(context.Context, application.UpdateUserParams) (application.User, error)
-	deleteUserFunc func(context.Context, application.Principal, string) error
-	listUsersFunc  func(context.Context, application.Principal) ([]application.User, error)
-}
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
 
-func (f *fakeUserService) CreateUser(ctx context.Context, params application.CreateUserParams) (application.User, error) {
-	if f.createUserFunc != nil {
-		return f.createUserFunc(ctx, params)
-	}
-	return application.User{}, nil
-}
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", res.StatusCode)
+		}
+		if captured.UserID != "user-1" || len(captured.Hours) != 2 {
+			t.Fatalf("unexpected params captured: %#v", captured)
+		}
+		if captured.Hours[1].Weekday != time.Friday || captured.Hours[1].StartMinute != 10*60+30 || captured.Hours[1].EndMinute != 24*60 {
+			t.Fatalf("unexpected window parsed: %#v", captured.Hours[1])
+		}
 
-func (f *fakeUserService) UpdateUser(ctx context.Context, params application.UpdateUserParams) (application.User, error) {
-	if f.updateUserFunc != nil {
-		return f.updateUserFunc(ctx, params)
-	}
-	return application.User{}, nil
-}
+		var payload workingHoursResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.WorkingHours) != 2 || payload.WorkingHours[0] != (workingHoursDTO{Weekday: "monday", Start: "09:00", End: "18:00"}) || payload.WorkingHours[1].End != "24:00" {
+			t.Fatalf("unexpected working hours payload: %#v", payload.WorkingHours)
+		}
+	})
 
-func (f *fakeUserService) DeleteUser(ctx context.Context, principal application.Principal, userID string) error {
-	if f.deleteUserFunc != nil {
-		return f.deleteUserFunc(ctx, principal, userID)
-	}
-	return nil
-}
+	t.Run("creates and deletes out-of-office periods", func(t *testing.T) {
+		var deleted string
+		service := &fakeAvailabilityService{
+			createOutOfOfficeFunc: func(ctx context.Context, params application.CreateOutOfOfficeParams) (application.OutOfOffice, error) {
+				if !params.Input.AutoDecline || params.Input.Note != "夏季休暇" {
+					t.Fatalf("unexpected input: %#v", params.Input)
+				}
+				return application.OutOfOffice{
+					ID:          "ooo-1",
+					UserID:      params.UserID,
+					Start:       params.Input.Start,
+					End:         params.Input.End,
+					Note:        params.Input.Note,
+					AutoDecline: true,
+				}, nil
+			},
+			deleteOutOfOfficeFunc: func(ctx context.Context, principal application.Principal, userID, entryID string) error {
+				deleted = userID + "/" + entryID
+				return nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), Availability: NewAvailabilityHandler(service, nil)})
+		principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
 
-func (f *fakeUserService) ListUsers(ctx context.Context, principal application.Principal) ([]application.User, error) {
-	if f.listUsersFunc != nil {
-		return f.listUsersFunc(ctx, principal)
-	}
-	return nil, nil
-}
+		body := []byte(`{"start":"2024-08-13T00:00:00+09:00","end":"2024-08-16T00:00:00+09:00","note":"夏季休暇","auto_decline":true}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/user-1/out-of-office", bytes.NewReader(body))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
 
-type fakeRoomService struct {
-	createRoomFunc func(context.Context, application.CreateRoomParams) (application.Room, error)
-	updateRoomFunc func(context.Context, application.UpdateRoomParams) (application.Room, error)
-	deleteRoomFunc func(context.Context, application.Principal, string) error
-	listRoomsFunc  func(context.Context, application.Principal) ([]application.Room, error)
-}
+		router.ServeHTTP(recorder, req)
 
-func (f *fakeRoomService) CreateRoom(ctx context.Context, params application.CreateRoomParams) (application.Room, error) {
-	if f.createRoomFunc != nil {
-		return f.createRoomFunc(ctx, params)
-	}
-	return application.Room{}, nil
-}
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
 
-func (f *fakeRoomService) UpdateRoom(ctx context.Context, params application.UpdateRoomParams) (application.Room, error) {
-	if f.updateRoomFunc != nil {
-		return f.updateRoomFunc(ctx, params)
-	}
-	return application.Room{}, nil
-}
+		if res.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", res.StatusCode)
+		}
+		var payload outOfOfficeResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.OutOfOffice.ID != "ooo-1" || payload.OutOfOffice.Start != "2024-08-13T00:00:00+09:00" {
+			t.Fatalf("unexpected out-of-office payload: %#v", payload.OutOfOffice)
+		}
 
-func (f *fakeRoomService) DeleteRoom(ctx context.Context, principal application.Principal, roomID string) error {
-	if f.deleteRoomFunc != nil {
-		return f.deleteRoomFunc(ctx, principal, roomID)
-	}
-	return nil
-}
+		req = httptest.NewRequest(http.MethodDelete, "/users/user-1/out-of-office/ooo-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
 
-func (f *fakeRoomService) ListRooms(ctx context.Context, principal application.Principal) ([]application.Room, error) {
-	if f.listRoomsFunc != nil {
-		return f.listRoomsFunc(ctx, principal)
-	}
-	return nil, nil
-}
+		router.ServeHTTP(recorder, req)
 
-type fakeScheduleService struct {
-	createScheduleFunc func(context.Context, application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
-	updateScheduleFunc func(context.Context, application.UpdateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
-	deleteScheduleFunc func(context.Context, application.Principal, string) error
-	listSchedulesFunc  func(context.Context, application.ListSchedulesParams) ([]application.Schedule, []application.ConflictWarning, error)
-}
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204 No Content, got %d", recorder.Code)
+		}
+		if deleted != "user-1/ooo-1" {
+			t.Fatalf("unexpected delete target: %q", deleted)
+		}
+	})
 
-func (f *fakeScheduleService) CreateSchedule(ctx context.Context, params application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
-	if f.createScheduleFunc != nil {
-		return f.createScheduleFunc(ctx, params)
-	}
-	return application.Schedule{}, nil, nil
-}
+	t.Run("localizes working hours validation errors", func(t *testing.T) {
+		service := &fakeAvailabilityService{
+			setWorkingHoursFunc: func(ctx context.Context, params application.SetWorkingHoursParams) ([]application.WorkingHours, error) {
+				if params.Hours[0].Weekday != -1 {
+					t.Fatalf("expected unknown weekday to be passed through, got %v", params.Hours[0].Weekday)
+				}
+				return nil, &application.ValidationError{FieldErrors: map[string]string{"working_hours": "weekday is invalid"}}
+			},
+		}
+		handler := NewAvailabilityHandler(service, nil)
 
-func (f *fakeScheduleService) UpdateSchedule(ctx context.Context, params application.UpdateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
-	if f.updateScheduleFunc != nil {
-		return f.updateScheduleFunc(ctx, params)
-	}
-	return application.Schedule{}, nil, nil
-}
+		req := httptest.NewRequest(http.MethodPut, "/users/user-1/working-hours", bytes.NewReader([]byte(`{"working_hours":[{"weekday":"someday","start":"09:00","end":"18:00"}]}`)))
+		req = req.WithContext(ContextWithUserID(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}), "user-1"))
+		recorder := httptest.NewRecorder()
 
-func (f *fakeScheduleService) DeleteSchedule(ctx context.Context, principal application.Principal, scheduleID string) error {
-	if f.deleteScheduleFunc != nil {
-		return f.deleteScheduleFunc(ctx, principal, scheduleID)
-	}
-	return nil
-}
+		handler.SetWorkingHours(recorder, req)
 
-func (f *fakeScheduleService) ListSchedules(ctx context.Context, params application.ListSchedulesParams) ([]application.Schedule, []application.ConflictWarning, error) {
-	if f.listSchedulesFunc != nil {
-		return f.listSchedulesFunc(ctx, params)
-	}
-	return nil, nil, nil
-}
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
 
-func mustParse(t *testing.T, value string) time.Time {
-	t.Helper()
-	parsed, err := time.Parse(time.RFC3339Nano, value)
+		if res.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422 Unprocessable Entity, got %d", res.StatusCode)
+		}
+		var payload struct {
+			Errors map[string]string `json:"errors"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Errors["working_hours"] != "曜日の指定が不正です。" {
+			t.Fatalf("unexpected working_hours error: %q", payload.Errors["working_hours"])
+		}
+	})
+}
+
+func TestHolidayHandlers(t *testing.T) {
+	t.Run("list holidays for a year", func(t *testing.T) {
+		var captured application.ListHolidaysParams
+		service := &fakeHolidayService{
+			listHolidaysFunc: func(ctx context.Context, params application.ListHolidaysParams) ([]application.Holiday, error) {
+				captured = params
+				return []application.Holiday{
+					{Date: time.Date(2024, time.February, 12, 0, 0, 0, 0, time.UTC), Name: "振替休日", Kind: "substitute"},
+					{Date: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), Name: "年末休業", Kind: "company"},
+				}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Holidays: NewHolidayHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/holidays?year=2024", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		res := recorder.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", res.StatusCode)
+		}
+		if captured.Year != 2024 {
+			t.Fatalf("expected year 2024 to be requested, got %d", captured.Year)
+		}
+		var payload listHolidaysResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Year != 2024 || len(payload.Holidays) != 2 {
+			t.Fatalf("unexpected payload: %#v", payload)
+		}
+		if got := payload.Holidays[0]; got.Date != "2024-02-12" || got.Kind != "substitute" {
+			t.Fatalf("unexpected holiday: %#v", got)
+		}
+	})
+
+	t.Run("rejects non-numeric year", func(t *testing.T) {
+		service := &fakeHolidayService{
+			listHolidaysFunc: func(ctx context.Context, params application.ListHolidaysParams) ([]application.Holiday, error) {
+				t.Fatalf("service should not be called")
+				return nil, nil
+			},
+		}
+		handler := NewHolidayHandler(service, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/holidays?year=next", nil)
+		recorder := httptest.NewRecorder()
+
+		handler.List(recorder, req)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 Bad Request, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("create and delete company holiday", func(t *testing.T) {
+		var deleted time.Time
+		service := &fakeHolidayService{
+			createCompanyHolidayFunc: func(ctx context.Context, params application.CreateCompanyHolidayParams) (application.Holiday, error) {
+				if params.Input.Name != "創立記念日" || params.Input.Date.Format("2006-01-02") != "2024-06-03" {
+					t.Fatalf("unexpected input: %#v", params.Input)
+				}
+				return application.Holiday{Date: params.Input.Date, Name: params.Input.Name, Kind: "company"}, nil
+			},
+			deleteCompanyHolidayFunc: func(ctx context.Context, principal application.Principal, date time.Time) error {
+				deleted = date
+				return nil
+			},
+		}
+		router := NewRouter(RouterConfig{Holidays: NewHolidayHandler(service, nil)})
+		principal := application.Principal{UserID: "admin", IsAdmin: true}
+
+		req := httptest.NewRequest(http.MethodPost, "/holidays", bytes.NewReader([]byte(`{"date":"2024-06-03","name":"創立記念日"}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", recorder.Code)
+		}
+		var payload holidayResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Holiday.Date != "2024-06-03" || payload.Holiday.Kind != "company" {
+			t.Fatalf("unexpected holiday: %#v", payload.Holiday)
+		}
+
+		req = httptest.NewRequest(http.MethodDelete, "/holidays/2024-06-03", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204 No Content, got %d", recorder.Code)
+		}
+		if !deleted.Equal(time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected date deleted: %v", deleted)
+		}
+	})
+}
+
+func TestReminderHandlers(t *testing.T) {
+	t.Run("get and set reminder preferences", func(t *testing.T) {
+		var captured application.SetReminderPreferencesParams
+		service := &fakeReminderService{
+			getFunc: func(ctx context.Context, principal application.Principal, userID string) (application.ReminderPreferences, error) {
+				return application.ReminderPreferences{UserID: userID, Channels: []string{"email", "webhook"}}, nil
+			},
+			setFunc: func(ctx context.Context, params application.SetReminderPreferencesParams) (application.ReminderPreferences, error) {
+				captured = params
+				return application.ReminderPreferences{UserID: params.UserID, MinutesBefore: params.MinutesBefore, Channels: params.Channels}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), Reminders: NewReminderHandler(service, nil)})
+		principal := application.Principal{UserID: "user-1"}
+
+		req := httptest.NewRequest(http.MethodGet, "/users/user-1/reminders", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		var payload reminderPreferencesDTO
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.UserID != "user-1" || len(payload.Channels) != 2 || payload.MinutesBefore == nil {
+			t.Fatalf("unexpected payload: %#v", payload)
+		}
+
+		req = httptest.NewRequest(http.MethodPut, "/users/user-1/reminders", bytes.NewReader([]byte(`{"minutes_before":[10,60],"channels":["email"]}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		if captured.UserID != "user-1" || len(captured.MinutesBefore) != 2 || captured.Channels[0] != "email" {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+	})
+
+	t.Run("translates validation errors", func(t *testing.T) {
+		service := &fakeReminderService{
+			setFunc: func(ctx context.Context, params application.SetReminderPreferencesParams) (application.ReminderPreferences, error) {
+				vErr := &application.ValidationError{FieldErrors: map[string]string{"channels": "reminder channel is invalid"}}
+				return application.ReminderPreferences{}, vErr
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), Reminders: NewReminderHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPut, "/users/user-1/reminders", bytes.NewReader([]byte(`{"channels":["sms"]}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422 Unprocessable Entity, got %d", recorder.Code)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte("通知チャネル")) {
+			t.Fatalf("expected translated message, got %s", recorder.Body.String())
+		}
+	})
+}
+
+func TestScheduleChangesHandler(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+
+	t.Run("route changes ahead of schedule ids", func(t *testing.T) {
+		var captured application.ListScheduleChangesParams
+		deletedAt := time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)
+		service := &fakeScheduleService{
+			listScheduleChangesFunc: func(ctx context.Context, params application.ListScheduleChangesParams) (application.ScheduleChanges, error) {
+				captured = params
+				return application.ScheduleChanges{
+					Schedules: []application.Schedule{{
+						ID:        "sched-1",
+						CreatorID: "user-1",
+						Title:     "Standup",
+						Start:     time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+						End:       time.Date(2024, 4, 2, 1, 0, 0, 0, time.UTC),
+					}},
+					Tombstones: []application.ScheduleTombstone{{ScheduleID: "sched-2", DeletedAt: deletedAt, Reason: application.TombstoneDeleted}},
+					SyncToken:  "next-token",
+					HasMore:    true,
+				}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/schedules/changes?since=prev-token&participants=user-1,user-2&limit=50", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.SyncToken != "prev-token" || captured.Limit != 50 || len(captured.ParticipantIDs) != 2 || captured.Principal.UserID != "user-1" {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		var payload scheduleChangesResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Schedules) != 1 || payload.Schedules[0].ID != "sched-1" {
+			t.Fatalf("unexpected schedules: %#v", payload.Schedules)
+		}
+		if len(payload.Deleted) != 1 || payload.Deleted[0].ID != "sched-2" || payload.Deleted[0].Reason != "deleted" || payload.Deleted[0].DeletedAt != "2024-04-01T01:00:00Z" {
+			t.Fatalf("unexpected tombstones: %#v", payload.Deleted)
+		}
+		if payload.SyncToken != "next-token" || !payload.HasMore {
+			t.Fatalf("unexpected sync state: %#v", payload)
+		}
+	})
+
+	t.Run("translate invalid sync token", func(t *testing.T) {
+		service := &fakeScheduleService{
+			listScheduleChangesFunc: func(ctx context.Context, params application.ListScheduleChangesParams) (application.ScheduleChanges, error) {
+				return application.ScheduleChanges{}, &application.ValidationError{FieldErrors: map[string]string{"since": "sync token is invalid"}}
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/schedules/changes?since=garbage", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", recorder.Code)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte("同期トークンの形式が不正です。")) {
+			t.Fatalf("expected translated message, got %s", recorder.Body.String())
+		}
+	})
+}
+
+func TestOccurrencesHandler(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
+
+	t.Run("list flattened instances", func(t *testing.T) {
+		var captured application.ListScheduleInstancesParams
+		roomID := "room-1"
+		service := &fakeScheduleService{
+			listInstancesFunc: func(ctx context.Context, params application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error) {
+				captured = params
+				series := application.Schedule{ID: "sched-1", CreatorID: "user-1", Title: "Standup", RoomID: &roomID, ParticipantIDs: []string{"user-2"}}
+				return []application.ScheduleInstance{{
+					ID:       "sched-1:20240401T000000Z",
+					RuleID:   "rule-1",
+					Start:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+					End:      time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+					Schedule: series,
+				}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/occurrences?start=2024-04-01T00:00:00Z&end=2024-04-08T00:00:00Z", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if !captured.Start.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) || !captured.End.Equal(time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected window: %#v", captured)
+		}
+		if len(captured.ParticipantIDs) != 1 || captured.ParticipantIDs[0] != "user-1" {
+			t.Fatalf("expected participants to default to the caller, got %v", captured.ParticipantIDs)
+		}
+
+		var payload listOccurrencesResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Occurrences) != 1 {
+			t.Fatalf("expected one occurrence, got %#v", payload.Occurrences)
+		}
+		got := payload.Occurrences[0]
+		if got.ID != "sched-1:20240401T000000Z" || got.ScheduleID != "sched-1" || got.RuleID != "rule-1" || got.Title != "Standup" {
+			t.Fatalf("unexpected occurrence: %#v", got)
+		}
+		if got.Start != "2024-04-01T09:00:00+09:00" || got.RoomID == nil || *got.RoomID != "room-1" {
+			t.Fatalf("expected the occurrence rendered in the caller's zone, got %#v", got)
+		}
+	})
+
+	t.Run("pass the list filters through", func(t *testing.T) {
+		var captured application.ListScheduleInstancesParams
+		service := &fakeScheduleService{
+			listInstancesFunc: func(ctx context.Context, params application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error) {
+				captured = params
+				return nil, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/occurrences?start=2024-04-01T00:00:00Z&end=2024-04-08T00:00:00Z&calendar_id=cal-1&user_group=grp-1&group=team&department=dep-1&reports_to=me", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.CalendarID != "cal-1" || captured.UserGroupID != "grp-1" || captured.GroupID != "team" || captured.DepartmentID != "dep-1" || captured.ReportsTo != "user-1" {
+			t.Fatalf("expected the list filters to be passed through, got %#v", captured)
+		}
+	})
+
+	t.Run("translate a missing window", func(t *testing.T) {
+		service := &fakeScheduleService{
+			listInstancesFunc: func(ctx context.Context, params application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error) {
+				return nil, &application.ValidationError{FieldErrors: map[string]string{"end": "end is required"}}
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/occurrences?start=2024-04-01T00:00:00Z", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", recorder.Code)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte("終了日時は必須です。")) {
+			t.Fatalf("expected translated message, got %s", recorder.Body.String())
+		}
+	})
+}
+
+type fakeCalendarViewService struct {
+	calendarViewFunc func(context.Context, application.CalendarViewParams) (application.CalendarView, error)
+}
+
+func (f *fakeCalendarViewService) CalendarView(ctx context.Context, params application.CalendarViewParams) (application.CalendarView, error) {
+	if f.calendarViewFunc != nil {
+		return f.calendarViewFunc(ctx, params)
+	}
+	return application.CalendarView{}, nil
+}
+
+func TestCalendarViewHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	t.Run("render a laid out week", func(t *testing.T) {
+		var captured application.CalendarViewParams
+		service := &fakeCalendarViewService{
+			calendarViewFunc: func(ctx context.Context, params application.CalendarViewParams) (application.CalendarView, error) {
+				captured = params
+				monday := time.Date(2024, 4, 29, 0, 0, 0, 0, tokyo)
+				instance := application.ScheduleInstance{
+					ID:       "sched-1",
+					Start:    monday.Add(10 * time.Hour),
+					End:      monday.Add(11 * time.Hour),
+					Schedule: application.Schedule{ID: "sched-1", CreatorID: "user-1", Title: "Design"},
+				}
+				return application.CalendarView{
+					Period:    application.ListPeriodWeek,
+					Start:     monday,
+					End:       monday.AddDate(0, 0, 7),
+					AxisStart: application.DefaultViewAxisStart,
+					AxisEnd:   application.DefaultViewAxisEnd,
+					Days:      []application.CalendarDay{{Date: monday, Holiday: &application.Holiday{Date: time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), Name: "昭和の日", Kind: "national"}}},
+					Lanes: []application.CalendarLane{{ParticipantID: "user-1", Days: []application.CalendarLaneDay{{
+						Events: []application.CalendarEvent{{Instance: instance, Start: instance.Start, End: instance.End, Column: 1, Columns: 2}},
+					}}}},
+				}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Views: NewCalendarViewHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/views/week?date=2024-05-01&participants=user-2", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Period != application.ListPeriodWeek || !captured.Date.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo)) || len(captured.ParticipantIDs) != 1 {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		var payload calendarViewResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.View != "week" || payload.Axis.Start != "08:00" || payload.Axis.End != "18:00" {
+			t.Fatalf("unexpected view header: %#v", payload)
+		}
+		if len(payload.Days) != 1 || payload.Days[0].Date != "2024-04-29" || payload.Days[0].Holiday == nil || payload.Days[0].Holiday.Name != "昭和の日" {
+			t.Fatalf("unexpected days: %#v", payload.Days)
+		}
+		events := payload.Lanes[0].Days[0].Events
+		if len(events) != 1 || events[0].ID != "sched-1" || events[0].Title != "Design" || events[0].Column != 1 || events[0].Columns != 2 {
+			t.Fatalf("unexpected events: %#v", events)
+		}
+		if events[0].SegmentStart != "2024-04-29T10:00:00+09:00" {
+			t.Fatalf("expected the segment rendered in the caller's zone, got %s", events[0].SegmentStart)
+		}
+	})
+
+	t.Run("reject unknown views and malformed dates", func(t *testing.T) {
+		router := NewRouter(RouterConfig{Views: NewCalendarViewHandler(&fakeCalendarViewService{}, nil)})
+
+		for path, status := range map[string]int{
+			"/views/year":                   http.StatusNotFound,
+			"/views/day?date=2024-13-01":    http.StatusBadRequest,
+			"/views/month?date=next-friday": http.StatusBadRequest,
+		} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != status {
+				t.Fatalf("%s: expected status %d, got %d", path, status, recorder.Code)
+			}
+		}
+	})
+}
+
+type fakeColleagueGroupService struct {
+	listFunc   func(context.Context, application.Principal) ([]application.ColleagueGroup, error)
+	createFunc func(context.Context, application.CreateColleagueGroupParams) (application.ColleagueGroup, error)
+	deleteFunc func(context.Context, application.Principal, string) error
+}
+
+func (f *fakeColleagueGroupService) ListColleagueGroups(ctx context.Context, principal application.Principal) ([]application.ColleagueGroup, error) {
+	if f.listFunc != nil {
+		return f.listFunc(ctx, principal)
+	}
+	return nil, nil
+}
+
+func (f *fakeColleagueGroupService) GetColleagueGroup(ctx context.Context, principal application.Principal, groupID string) (application.ColleagueGroup, error) {
+	return application.ColleagueGroup{}, application.ErrNotFound
+}
+
+func (f *fakeColleagueGroupService) CreateColleagueGroup(ctx context.Context, params application.CreateColleagueGroupParams) (application.ColleagueGroup, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.ColleagueGroup{}, nil
+}
+
+func (f *fakeColleagueGroupService) UpdateColleagueGroup(ctx context.Context, params application.UpdateColleagueGroupParams) (application.ColleagueGroup, error) {
+	return application.ColleagueGroup{}, nil
+}
+
+func (f *fakeColleagueGroupService) DeleteColleagueGroup(ctx context.Context, principal application.Principal, groupID string) error {
+	if f.deleteFunc != nil {
+		return f.deleteFunc(ctx, principal, groupID)
+	}
+	return nil
+}
+
+type fakeCalendarService struct {
+	createFunc func(context.Context, application.CreateCalendarParams) (application.Calendar, error)
+	updateFunc func(context.Context, application.UpdateCalendarParams) (application.Calendar, error)
+}
+
+func (f *fakeCalendarService) ListCalendars(ctx context.Context, principal application.Principal) ([]application.Calendar, error) {
+	return nil, nil
+}
+
+func (f *fakeCalendarService) GetCalendar(ctx context.Context, principal application.Principal, calendarID string) (application.Calendar, error) {
+	return application.Calendar{}, application.ErrNotFound
+}
+
+func (f *fakeCalendarService) CreateCalendar(ctx context.Context, params application.CreateCalendarParams) (application.Calendar, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.Calendar{}, nil
+}
+
+func (f *fakeCalendarService) UpdateCalendar(ctx context.Context, params application.UpdateCalendarParams) (application.Calendar, error) {
+	if f.updateFunc != nil {
+		return f.updateFunc(ctx, params)
+	}
+	return application.Calendar{}, nil
+}
+
+func (f *fakeCalendarService) DeleteCalendar(ctx context.Context, principal application.Principal, calendarID string) error {
+	return nil
+}
+
+type fakeUserGroupService struct {
+	createFunc func(context.Context, application.CreateUserGroupParams) (application.UserGroup, error)
+	deleteFunc func(context.Context, application.Principal, string) error
+}
+
+func (f *fakeUserGroupService) ListUserGroups(ctx context.Context, principal application.Principal) ([]application.UserGroup, error) {
+	return nil, nil
+}
+
+func (f *fakeUserGroupService) GetUserGroup(ctx context.Context, principal application.Principal, groupID string) (application.UserGroup, error) {
+	return application.UserGroup{}, application.ErrNotFound
+}
+
+func (f *fakeUserGroupService) CreateUserGroup(ctx context.Context, params application.CreateUserGroupParams) (application.UserGroup, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.UserGroup{}, nil
+}
+
+func (f *fakeUserGroupService) UpdateUserGroup(ctx context.Context, params application.UpdateUserGroupParams) (application.UserGroup, error) {
+	return application.UserGroup{}, nil
+}
+
+func (f *fakeUserGroupService) DeleteUserGroup(ctx context.Context, principal application.Principal, groupID string) error {
+	if f.deleteFunc != nil {
+		return f.deleteFunc(ctx, principal, groupID)
+	}
+	return nil
+}
+
+type fakeViewLinkService struct {
+	createFunc func(context.Context, application.CreateViewLinkParams) (application.ViewLink, error)
+	getFunc    func(context.Context, application.Principal, string) (application.ViewLink, error)
+}
+
+func (f *fakeViewLinkService) CreateViewLink(ctx context.Context, params application.CreateViewLinkParams) (application.ViewLink, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.ViewLink{}, nil
+}
+
+func (f *fakeViewLinkService) GetViewLink(ctx context.Context, principal application.Principal, code string) (application.ViewLink, error) {
+	if f.getFunc != nil {
+		return f.getFunc(ctx, principal, code)
+	}
+	return application.ViewLink{}, application.ErrNotFound
+}
+
+func TestColleagueGroupHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
+
+	t.Run("create and list groups", func(t *testing.T) {
+		var captured application.CreateColleagueGroupParams
+		created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		service := &fakeColleagueGroupService{
+			createFunc: func(ctx context.Context, params application.CreateColleagueGroupParams) (application.ColleagueGroup, error) {
+				captured = params
+				return application.ColleagueGroup{ID: "group-1", OwnerID: "user-1", Name: params.Input.Name, MemberIDs: params.Input.MemberIDs, CreatedAt: created, UpdatedAt: created}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Groups: NewColleagueGroupHandler(service, nil)})
+
+		body := bytes.NewBufferString(`{"name":"Platform team","member_ids":["user-2","user-3"],"shared_with":["user-4"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/colleague-groups", body)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Principal.UserID != "user-1" || len(captured.Input.MemberIDs) != 2 || len(captured.Input.SharedWith) != 1 {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		var payload colleagueGroupResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Group.ID != "group-1" || payload.Group.CreatedAt != "2024-06-01T09:00:00+09:00" || payload.Group.SharedWith == nil {
+			t.Fatalf("unexpected group: %#v", payload.Group)
+		}
+	})
+
+	t.Run("validation errors and forbidden deletes", func(t *testing.T) {
+		service := &fakeColleagueGroupService{
+			createFunc: func(ctx context.Context, params application.CreateColleagueGroupParams) (application.ColleagueGroup, error) {
+				return application.ColleagueGroup{}, &application.ValidationError{FieldErrors: map[string]string{"member_ids": "at least one member is required"}}
+			},
+			deleteFunc: func(ctx context.Context, principal application.Principal, groupID string) error {
+				return application.ErrUnauthorized
+			},
+		}
+		router := NewRouter(RouterConfig{Groups: NewColleagueGroupHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/colleague-groups", bytes.NewBufferString(`{"name":"Empty"}`))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusUnprocessableEntity || !bytes.Contains(recorder.Body.Bytes(), []byte("メンバーを 1 名以上指定してください。")) {
+			t.Fatalf("expected a translated validation error, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodDelete, "/colleague-groups/group-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestCalendarHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
+
+	t.Run("create a calendar", func(t *testing.T) {
+		var captured application.CreateCalendarParams
+		created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		service := &fakeCalendarService{
+			createFunc: func(ctx context.Context, params application.CreateCalendarParams) (application.Calendar, error) {
+				captured = params
+				return application.Calendar{ID: "cal-1", Name: params.Input.Name, OwnerID: "user-1", EditorIDs: params.Input.EditorIDs, CreatedAt: created, UpdatedAt: created}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Calendars: NewCalendarHandler(service, nil)})
+
+		body := bytes.NewBufferString(`{"name":"Release Train","description":"Cut dates","editor_ids":["user-2"],"viewer_ids":["user-3"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/calendars", body)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Input.Description != "Cut dates" || len(captured.Input.EditorIDs) != 1 || len(captured.Input.ViewerIDs) != 1 {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		var payload calendarResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Calendar.ID != "cal-1" || payload.Calendar.CreatedAt != "2024-06-01T09:00:00+09:00" || payload.Calendar.ViewerIDs == nil {
+			t.Fatalf("unexpected calendar: %#v", payload.Calendar)
+		}
+	})
+
+	t.Run("editors cannot change the calendar", func(t *testing.T) {
+		service := &fakeCalendarService{
+			updateFunc: func(ctx context.Context, params application.UpdateCalendarParams) (application.Calendar, error) {
+				if params.CalendarID != "cal-1" {
+					t.Fatalf("unexpected calendar id %q", params.CalendarID)
+				}
+				return application.Calendar{}, application.ErrUnauthorized
+			},
+		}
+		router := NewRouter(RouterConfig{Calendars: NewCalendarHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPut, "/calendars/cal-1", bytes.NewBufferString(`{"name":"Mine"}`))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", recorder.Code)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/calendars/cal-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestUserGroupHandlers(t *testing.T) {
+	admin := application.Principal{UserID: "root", IsAdmin: true, TimeZone: "Asia/Tokyo"}
+
+	t.Run("create a user group", func(t *testing.T) {
+		var captured application.CreateUserGroupParams
+		created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		service := &fakeUserGroupService{
+			createFunc: func(ctx context.Context, params application.CreateUserGroupParams) (application.UserGroup, error) {
+				captured = params
+				return application.UserGroup{ID: "grp-1", Name: params.Input.Name, OwnerID: params.Input.OwnerID, MemberIDs: params.Input.MemberIDs, CreatedAt: created, UpdatedAt: created}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{UserGroups: NewUserGroupHandler(service, nil)})
+
+		body := bytes.NewBufferString(`{"name":"Sales","owner_id":"user-1","member_ids":["user-2","user-3"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/user-groups", body)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Input.OwnerID != "user-1" || len(captured.Input.MemberIDs) != 2 {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		var payload userGroupResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Group.ID != "grp-1" || payload.Group.CreatedAt != "2024-06-01T09:00:00+09:00" || len(payload.Group.MemberIDs) != 2 {
+			t.Fatalf("unexpected user group: %#v", payload.Group)
+		}
+	})
+
+	t.Run("validation errors are translated", func(t *testing.T) {
+		service := &fakeUserGroupService{
+			createFunc: func(ctx context.Context, params application.CreateUserGroupParams) (application.UserGroup, error) {
+				vErr := &application.ValidationError{FieldErrors: map[string]string{"name": "user group name is required"}}
+				return application.UserGroup{}, vErr
+			},
+		}
+		router := NewRouter(RouterConfig{UserGroups: NewUserGroupHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/user-groups", bytes.NewBufferString(`{"name":" "}`))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", recorder.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Errors["name"] != "ユーザーグループ名は必須です。" {
+			t.Fatalf("unexpected errors: %#v", payload.Errors)
+		}
+	})
+
+	t.Run("members cannot delete the group", func(t *testing.T) {
+		service := &fakeUserGroupService{
+			deleteFunc: func(ctx context.Context, principal application.Principal, groupID string) error {
+				if groupID != "grp-1" {
+					t.Fatalf("unexpected group id %q", groupID)
+				}
+				return application.ErrUnauthorized
+			},
+		}
+		router := NewRouter(RouterConfig{UserGroups: NewUserGroupHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodDelete, "/user-groups/grp-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-2"}))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("schedules invite groups", func(t *testing.T) {
+		var captured application.CreateScheduleParams
+		service := &fakeScheduleService{
+			createScheduleFunc: func(ctx context.Context, params application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
+				captured = params
+				return application.Schedule{ID: "sched-1", Title: params.Input.Title, ParticipantGroupIDs: params.Input.ParticipantGroupIDs}, nil, nil
+			},
+		}
+		handler := NewScheduleHandler(service, nil)
+
+		body := bytes.NewBufferString(`{"title":"All hands","start":"2024-06-03T09:00:00Z","end":"2024-06-03T10:00:00Z","participant_group_ids":["grp-1"],"participant_group_mode":"live"}`)
+		req := httptest.NewRequest(http.MethodPost, "/schedules", body)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		handler.Create(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Input.ParticipantGroupMode != application.ParticipantGroupLive || len(captured.Input.ParticipantGroupIDs) != 1 {
+			t.Fatalf("unexpected input: %#v", captured.Input)
+		}
+		var payload struct {
+			Schedule scheduleDTO `json:"schedule"`
+		}
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Schedule.ParticipantGroupIDs) != 1 || payload.Schedule.ParticipantGroupIDs[0] != "grp-1" {
+			t.Fatalf("unexpected schedule: %#v", payload.Schedule)
+		}
+	})
+}
+
+type fakeDepartmentService struct {
+	createFunc func(context.Context, application.CreateDepartmentParams) (application.Department, error)
+	updateFunc func(context.Context, application.UpdateDepartmentParams) (application.Department, error)
+}
+
+func (f *fakeDepartmentService) ListDepartments(ctx context.Context, principal application.Principal) ([]application.Department, error) {
+	return []application.Department{{ID: "dep-sales", Name: "Sales"}, {ID: "dep-east", Name: "Sales East", ParentID: "dep-sales"}}, nil
+}
+
+func (f *fakeDepartmentService) GetDepartment(ctx context.Context, principal application.Principal, departmentID string) (application.Department, error) {
+	return application.Department{}, application.ErrNotFound
+}
+
+func (f *fakeDepartmentService) CreateDepartment(ctx context.Context, params application.CreateDepartmentParams) (application.Department, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.Department{}, nil
+}
+
+func (f *fakeDepartmentService) UpdateDepartment(ctx context.Context, params application.UpdateDepartmentParams) (application.Department, error) {
+	if f.updateFunc != nil {
+		return f.updateFunc(ctx, params)
+	}
+	return application.Department{}, nil
+}
+
+func (f *fakeDepartmentService) DeleteDepartment(ctx context.Context, principal application.Principal, departmentID string) error {
+	return nil
+}
+
+func TestDepartmentHandlers(t *testing.T) {
+	admin := application.Principal{UserID: "root", IsAdmin: true, TimeZone: "Asia/Tokyo"}
+
+	t.Run("create a department", func(t *testing.T) {
+		var captured application.CreateDepartmentParams
+		created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		service := &fakeDepartmentService{
+			createFunc: func(ctx context.Context, params application.CreateDepartmentParams) (application.Department, error) {
+				captured = params
+				return application.Department{ID: "dep-east", Name: params.Input.Name, ParentID: params.Input.ParentID, CreatedAt: created, UpdatedAt: created}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Departments: NewDepartmentHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/departments", bytes.NewBufferString(`{"name":"Sales East","parent_id":"dep-sales"}`))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Input.Name != "Sales East" || captured.Input.ParentID != "dep-sales" {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+		var payload departmentResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Department.ID != "dep-east" || payload.Department.ParentID != "dep-sales" || payload.Department.CreatedAt != "2024-06-01T09:00:00+09:00" {
+			t.Fatalf("unexpected department: %#v", payload.Department)
+		}
+	})
+
+	t.Run("list departments", func(t *testing.T) {
+		router := NewRouter(RouterConfig{Departments: NewDepartmentHandler(&fakeDepartmentService{}, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/departments", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		var payload listDepartmentsResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if recorder.Code != http.StatusOK || len(payload.Departments) != 2 || payload.Departments[1].ParentID != "dep-sales" {
+			t.Fatalf("unexpected response %d: %#v", recorder.Code, payload)
+		}
+	})
+
+	t.Run("nesting errors are translated", func(t *testing.T) {
+		service := &fakeDepartmentService{
+			updateFunc: func(ctx context.Context, params application.UpdateDepartmentParams) (application.Department, error) {
+				if params.DepartmentID != "dep-sales" {
+					t.Fatalf("unexpected department id %q", params.DepartmentID)
+				}
+				return application.Department{}, &application.ValidationError{FieldErrors: map[string]string{"parent_id": "a department cannot be nested under itself"}}
+			},
+		}
+		router := NewRouter(RouterConfig{Departments: NewDepartmentHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPut, "/departments/dep-sales", bytes.NewBufferString(`{"name":"Sales","parent_id":"dep-east"}`))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", recorder.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Errors["parent_id"] != "部署を自身またはその配下の部署の下に置くことはできません。" {
+			t.Fatalf("unexpected errors: %#v", payload.Errors)
+		}
+	})
+
+	t.Run("import users from CSV", func(t *testing.T) {
+		var captured application.ImportUsersParams
+		service := &fakeUserService{
+			importUsersFunc: func(ctx context.Context, params application.ImportUsersParams) (application.UserImportResult, error) {
+				captured = params
+				return application.UserImportResult{Created: []application.User{{ID: "user-9", Email: "bob@example.com", DepartmentID: "dep-east", ManagerID: "user-1", Location: "Osaka"}}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(service, nil)})
+
+		body := "email,display_name,department,manager_email,location\nbob@example.com,Bob,Sales East,alice@example.com,Osaka\n"
+		req := httptest.NewRequest(http.MethodPost, "/users:import", bytes.NewBufferString(body))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if len(captured.Rows) != 1 || captured.Rows[0] != (application.UserImportRow{Email: "bob@example.com", DisplayName: "Bob", Department: "Sales East", ManagerEmail: "alice@example.com", Location: "Osaka"}) {
+			t.Fatalf("unexpected rows: %#v", captured.Rows)
+		}
+		var payload userImportResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Created) != 1 || payload.Created[0].ManagerID != "user-1" || payload.Updated == nil {
+			t.Fatalf("unexpected import result: %#v", payload)
+		}
+	})
+
+	t.Run("imports need the org columns", func(t *testing.T) {
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/users:import", bytes.NewBufferString("email,display_name\nbob@example.com,Bob\n"))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestViewLinkHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", TimeZone: "Asia/Tokyo"}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	link := application.ViewLink{
+		Code: "a1b2c3d4", CreatorID: "user-1", View: application.ListPeriodWeek,
+		Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), ParticipantIDs: []string{"user-1", "user-2"},
+	}
+
+	t.Run("create a link", func(t *testing.T) {
+		var captured application.CreateViewLinkParams
+		service := &fakeViewLinkService{
+			createFunc: func(ctx context.Context, params application.CreateViewLinkParams) (application.ViewLink, error) {
+				captured = params
+				return link, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Links: NewViewLinkHandler(service, nil)})
+
+		body := bytes.NewBufferString(`{"view":"week","date":"2024-05-01","participants":["user-2"],"group_id":"group-1"}`)
+		req := httptest.NewRequest(http.MethodPost, "/links", body)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.View != application.ListPeriodWeek || captured.GroupID != "group-1" || !captured.Date.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo)) {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+		var payload viewLinkResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Link.Code != "a1b2c3d4" || payload.Link.Path != "/views/week?date=2024-05-01&participants=user-1,user-2" {
+			t.Fatalf("unexpected link: %#v", payload.Link)
+		}
+	})
+
+	t.Run("resolve a link", func(t *testing.T) {
+		var resolvedBy string
+		service := &fakeViewLinkService{
+			getFunc: func(ctx context.Context, principal application.Principal, code string) (application.ViewLink, error) {
+				if code != "a1b2c3d4" {
+					return application.ViewLink{}, application.ErrNotFound
+				}
+				resolvedBy = principal.UserID
+				return link, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Links: NewViewLinkHandler(service, nil)})
+
+		for path, status := range map[string]int{
+			"/links/a1b2c3d4": http.StatusOK,
+			"/links/zzzzzzzz": http.StatusNotFound,
+			"/links/a/b":      http.StatusNotFound,
+		} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-9"}))
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != status {
+				t.Fatalf("%s: expected status %d, got %d", path, status, recorder.Code)
+			}
+		}
+		if resolvedBy != "user-9" {
+			t.Fatalf("expected the link resolved for the caller, got %q", resolvedBy)
+		}
+	})
+}
+
+func TestWebhookHandlers(t *testing.T) {
+	admin := application.Principal{UserID: "admin-1", IsAdmin: true}
+
+	t.Run("create returns secret once and list hides it", func(t *testing.T) {
+		var captured application.CreateWebhookParams
+		stored := application.WebhookSubscription{ID: "wh-1", URL: "https://hooks.example.com", Secret: "s3cret", EventTypes: []string{"schedule.created"}, Active: true}
+		service := &fakeWebhookService{
+			createFunc: func(ctx context.Context, params application.CreateWebhookParams) (application.WebhookSubscription, error) {
+				captured = params
+				return stored, nil
+			},
+			listFunc: func(ctx context.Context, principal application.Principal) ([]application.WebhookSubscription, error) {
+				return []application.WebhookSubscription{stored}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Webhooks: NewWebhookHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader([]byte(`{"url":"https://hooks.example.com","event_types":["schedule.created"],"active":true}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", recorder.Code)
+		}
+		var created webhookResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if created.Webhook.Secret != "s3cret" {
+			t.Fatalf("expected secret in creation response, got %#v", created.Webhook)
+		}
+		if captured.Input.URL != "https://hooks.example.com" || len(captured.Input.EventTypes) != 1 || captured.Input.Active == nil || !*captured.Input.Active {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		if bytes.Contains(recorder.Body.Bytes(), []byte("s3cret")) {
+			t.Fatalf("list response must not include secrets: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("routes deliveries and redeliver", func(t *testing.T) {
+		var listedID, redeliveredID string
+		var listedLimit int
+		service := &fakeWebhookService{
+			deliveriesFunc: func(ctx context.Context, principal application.Principal, webhookID string, limit int) ([]application.WebhookDelivery, error) {
+				listedID, listedLimit = webhookID, limit
+				return []application.WebhookDelivery{{ID: "d-1", EventType: application.EventRoomCreated, Status: application.WebhookDeliveryFailed, Attempts: 8, ResponseStatus: 500}}, nil
+			},
+			redeliverFunc: func(ctx context.Context, principal application.Principal, webhookID, deliveryID string) (application.WebhookDelivery, error) {
+				redeliveredID = webhookID + "/" + deliveryID
+				return application.WebhookDelivery{ID: "d-2", Status: application.WebhookDeliveryPending}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Webhooks: NewWebhookHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/wh-1/deliveries?limit=20", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		var listed listWebhookDeliveriesResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&listed); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if listedID != "wh-1" || listedLimit != 20 || len(listed.Deliveries) != 1 || listed.Deliveries[0].ResponseStatus != 500 {
+			t.Fatalf("unexpected deliveries: id=%q limit=%d payload=%#v", listedID, listedLimit, listed)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/webhooks/wh-1/deliveries/d-1/redeliver", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusAccepted {
+			t.Fatalf("expected status 202 Accepted, got %d", recorder.Code)
+		}
+		if redeliveredID != "wh-1/d-1" {
+			t.Fatalf("unexpected redelivery target: %q", redeliveredID)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/webhooks/wh-1/deliveries/d-1", nil)
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404 Not Found, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("maps errors", func(t *testing.T) {
+		service := &fakeWebhookService{
+			updateFunc: func(ctx context.Context, params application.UpdateWebhookParams) (application.WebhookSubscription, error) {
+				return application.WebhookSubscription{}, &application.ValidationError{FieldErrors: map[string]string{"event_types": "event type is invalid"}}
+			},
+			deleteFunc: func(ctx context.Context, principal application.Principal, webhookID string) error {
+				return application.ErrUnauthorized
+			},
+		}
+		router := NewRouter(RouterConfig{Webhooks: NewWebhookHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPut, "/webhooks/wh-1", bytes.NewReader([]byte(`{"url":"https://hooks.example.com","event_types":["bogus"]}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), admin))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422 Unprocessable Entity, got %d", recorder.Code)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte("イベント種別")) {
+			t.Fatalf("expected translated message, got %s", recorder.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodDelete, "/webhooks/wh-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 Forbidden, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestEventStreamHandler(t *testing.T) {
+	outbox := &fakeOutbox{events: []application.OutboxEvent{
+		{Sequence: 1, Type: application.EventScheduleCreated, Payload: []byte(`{"type":"schedule.created","data":{"id":"s1","creator_id":"user-1","participant_ids":[]}}`)},
+		{Sequence: 2, Type: application.EventScheduleCreated, Payload: []byte(`{"type":"schedule.created","data":{"id":"s2","creator_id":"user-2","participant_ids":["user-3"]}}`)},
+		{Sequence: 3, Type: application.EventScheduleDeleted, Payload: []byte(`{"type":"schedule.deleted","data":{"id":"s3","creator_id":"user-2","participant_ids":[],"room_id":"room-1"}}`)},
+	}}
+
+	t.Run("replays after last event id and sends heartbeats", func(t *testing.T) {
+		handler := NewEventStreamHandler(application.NewEventStreamService(outbox), nil)
+		handler.heartbeat = 5 * time.Millisecond
+		router := RequestLogger(nil)(NewRouter(RouterConfig{Events: handler}))
+
+		ctx, cancel := context.WithCancel(ContextWithPrincipal(context.Background(), application.Principal{UserID: "user-1"}))
+		req := httptest.NewRequest(http.MethodGet, "/events/stream?rooms=room-1", nil).WithContext(ctx)
+		req.Header.Set("Last-Event-ID", "0")
+		recorder := httptest.NewRecorder()
+
+		done := make(chan struct{})
+		go func() {
+			router.ServeHTTP(recorder, req)
+			close(done)
+		}()
+		time.Sleep(30 * time.Millisecond)
+		cancel()
+		<-done
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+			t.Fatalf("unexpected content type: %q", got)
+		}
+		body := recorder.Body.Bytes()
+		if !bytes.Contains(body, []byte("id: 1\nevent: schedule.created\ndata: {")) || !bytes.Contains(body, []byte("id: 3\nevent: schedule.deleted\n")) {
+			t.Fatalf("expected relevant events in stream, got %q", body)
+		}
+		if bytes.Contains(body, []byte("id: 2\n")) {
+			t.Fatalf("unrelated event leaked into stream: %q", body)
+		}
+		if !bytes.Contains(body, []byte(": heartbeat\n\n")) {
+			t.Fatalf("expected heartbeat comment, got %q", body)
+		}
+	})
+
+	t.Run("rejects invalid last event id", func(t *testing.T) {
+		router := NewRouter(RouterConfig{Events: NewEventStreamHandler(application.NewEventStreamService(outbox), nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/events/stream?last_event_id=abc", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 Bad Request, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("refuses streams after shutdown", func(t *testing.T) {
+		service := application.NewEventStreamService(outbox)
+		stopped, stop := context.WithCancel(context.Background())
+		stop()
+		service.Run(stopped, time.Millisecond)
+		router := NewRouter(RouterConfig{Events: NewEventStreamHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodGet, "/events/stream", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), application.Principal{UserID: "user-1"}))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503 Service Unavailable, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestAppPasswordHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+
+	t.Run("create returns password once and list hides it", func(t *testing.T) {
+		var captured application.CreateAppPasswordParams
+		created := application.AppPassword{ID: "ap-1", UserID: "user-1", Name: "Thunderbird", Secret: "s3cret", CreatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+		service := &fakeAppPasswordService{
+			createFunc: func(ctx context.Context, params application.CreateAppPasswordParams) (application.AppPassword, error) {
+				captured = params
+				return created, nil
+			},
+			listFunc: func(ctx context.Context, principal application.Principal, userID string) ([]application.AppPassword, error) {
+				listed := created
+				listed.Secret = ""
+				return []application.AppPassword{listed}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), AppPasswords: NewAppPasswordHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodPost, "/users/user-1/app-passwords", bytes.NewReader([]byte(`{"name":"Thunderbird"}`)))
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", recorder.Code)
+		}
+		var response appPasswordResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.AppPassword.Password != "s3cret" || response.AppPassword.ID != "ap-1" {
+			t.Fatalf("unexpected response: %#v", response.AppPassword)
+		}
+		if captured.UserID != "user-1" || captured.Name != "Thunderbird" || captured.Principal.UserID != "user-1" {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/users/user-1/app-passwords", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d", recorder.Code)
+		}
+		if bytes.Contains(recorder.Body.Bytes(), []byte("s3cret")) || !bytes.Contains(recorder.Body.Bytes(), []byte(`"last_used_at":null`)) {
+			t.Fatalf("unexpected list response: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("delete routes password id", func(t *testing.T) {
+		var deleted string
+		service := &fakeAppPasswordService{
+			deleteFunc: func(ctx context.Context, principal application.Principal, userID, passwordID string) error {
+				deleted = userID + "/" + passwordID
+				return nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), AppPasswords: NewAppPasswordHandler(service, nil)})
+
+		req := httptest.NewRequest(http.MethodDelete, "/users/user-1/app-passwords/ap-1", nil)
+		req = req.WithContext(ContextWithPrincipal(req.Context(), principal))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204 No Content, got %d", recorder.Code)
+		}
+		if deleted != "user-1/ap-1" {
+			t.Fatalf("unexpected delete target %q", deleted)
+		}
+	})
+}
+
+func TestCalDAVSyncHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", IsAdmin: true}
+	newRequest := func(method, target, body string) *http.Request {
+		req := httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+
+	t.Run("create hides the remote password", func(t *testing.T) {
+		var captured application.CreateCalDAVSyncAccountParams
+		service := &fakeCalDAVSyncService{
+			createFunc: func(ctx context.Context, params application.CreateCalDAVSyncAccountParams) (application.CalDAVSyncAccount, error) {
+				captured = params
+				return application.CalDAVSyncAccount{ID: "sync-1", UserID: params.UserID, CollectionURL: params.Input.CollectionURL, Password: params.Input.Password, ConflictPolicy: params.Input.ConflictPolicy, Active: true}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), CalDAVSyncs: NewCalDAVSyncHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/users/user-1/calendar-syncs", `{"collection_url":"https://dav.example.com/cal/","password":"remote-secret","conflict_policy":"remote_wins"}`))
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", recorder.Code)
+		}
+		if bytes.Contains(recorder.Body.Bytes(), []byte("remote-secret")) || !bytes.Contains(recorder.Body.Bytes(), []byte(`"conflict_policy":"remote_wins"`)) {
+			t.Fatalf("unexpected response: %s", recorder.Body.String())
+		}
+		if captured.UserID != "user-1" || captured.Input.ConflictPolicy != application.CalDAVSyncRemoteWins {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+	})
+
+	t.Run("runs a sync and lists runs", func(t *testing.T) {
+		var syncedID string
+		var listedLimit int
+		run := application.CalDAVSyncRun{ID: "run-1", Status: application.CalDAVSyncPartial, Pulled: application.CalDAVSyncCounts{Created: 2}, Failures: 1, Error: "push: precondition failed"}
+		service := &fakeCalDAVSyncService{
+			syncFunc: func(ctx context.Context, principal application.Principal, userID, accountID string) (application.CalDAVSyncRun, error) {
+				syncedID = userID + "/" + accountID
+				return run, nil
+			},
+			runsFunc: func(ctx context.Context, principal application.Principal, userID, accountID string, limit int) ([]application.CalDAVSyncRun, error) {
+				listedLimit = limit
+				return []application.CalDAVSyncRun{run}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{}, nil), CalDAVSyncs: NewCalDAVSyncHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/users/user-1/calendar-syncs/sync-1/runs", ""))
+		if recorder.Code != http.StatusCreated || syncedID != "user-1/sync-1" {
+			t.Fatalf("expected a sync of user-1/sync-1, got %d for %q", recorder.Code, syncedID)
+		}
+		var response calDAVSyncRunResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Run.Status != "partial" || response.Run.Pulled.Created != 2 || response.Run.Failures != 1 {
+			t.Fatalf("unexpected run: %#v", response.Run)
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/users/user-1/calendar-syncs/sync-1/runs?limit=5", ""))
+		if recorder.Code != http.StatusOK || listedLimit != 5 {
+			t.Fatalf("expected runs with limit 5, got %d (limit %d)", recorder.Code, listedLimit)
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/users/user-1/calendar-syncs/sync-1/other", ""))
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for an unknown subresource, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestTrashHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+	newRequest := func(method, target string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+
+	t.Run("lists the caller's trash", func(t *testing.T) {
+		deletedAt := time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)
+		service := &fakeTrashService{
+			listFunc: func(ctx context.Context, p application.Principal) ([]application.TrashItem, error) {
+				if p.UserID != "user-1" {
+					t.Fatalf("unexpected principal: %#v", p)
+				}
+				return []application.TrashItem{{
+					Kind:      application.TrashKindSchedule,
+					ID:        "sched-1",
+					Name:      "Standup",
+					DeletedAt: deletedAt,
+					PurgeAt:   deletedAt.Add(30 * 24 * time.Hour),
+					Schedule:  &application.Schedule{ID: "sched-1", Title: "Standup"},
+				}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Trash: NewTrashHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/trash"))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var payload listTrashResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Items) != 1 || payload.Items[0].Kind != "schedule" || payload.Items[0].PurgeAt != "2024-05-01T01:00:00Z" || payload.Items[0].Schedule == nil || payload.Items[0].Room != nil {
+			t.Fatalf("unexpected items: %#v", payload.Items)
+		}
+	})
+
+	t.Run("restores schedules and rooms", func(t *testing.T) {
+		var restoredSchedule, restoredRoom string
+		schedules := &fakeScheduleService{
+			restoreScheduleFunc: func(ctx context.Context, p application.Principal, scheduleID string) (application.Schedule, []application.ConflictWarning, error) {
+				restoredSchedule = scheduleID
+				return application.Schedule{ID: scheduleID}, []application.ConflictWarning{{ScheduleID: "sched-2", Type: "participant", ParticipantID: "user-1"}}, nil
+			},
+		}
+		rooms := &fakeRoomService{
+			restoreRoomFunc: func(ctx context.Context, p application.Principal, roomID string) (application.Room, error) {
+				restoredRoom = roomID
+				return application.Room{}, application.ErrUnauthorized
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(schedules, nil), Rooms: NewRoomHandler(rooms, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/schedules/sched-1/restore"))
+		if recorder.Code != http.StatusOK || restoredSchedule != "sched-1" {
+			t.Fatalf("expected sched-1 restored, got %d for %q", recorder.Code, restoredSchedule)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte(`"schedule_id":"sched-2"`)) {
+			t.Fatalf("expected conflict warnings, got %s", recorder.Body.String())
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/rooms/room-1/restore"))
+		if recorder.Code != http.StatusForbidden || restoredRoom != "room-1" {
+			t.Fatalf("expected 403 for room-1, got %d for %q", recorder.Code, restoredRoom)
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/schedules/sched-1/restore"))
+		if recorder.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405, got %d", recorder.Code)
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/schedules/sched-1/other"))
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for an unknown subresource, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestScheduleRevisionHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+	newRequest := func(method, target string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	room := "room-1"
+	first := application.ScheduleSnapshot{Title: "Planning", Start: start, End: start.Add(time.Hour), ParticipantIDs: []string{"user-1"}}
+	second := first
+	second.Start, second.End, second.RoomID = start.Add(2*time.Hour), start.Add(3*time.Hour), &room
+	revision := application.ScheduleRevision{
+		ScheduleID:    "sched-1",
+		Revision:      2,
+		ChangedBy:     "user-2",
+		ChangedAt:     start.Add(-time.Hour),
+		Snapshot:      second,
+		BaseRevision:  1,
+		Base:          &first,
+		ChangedFields: []string{application.RevisionFieldStart, application.RevisionFieldEnd, application.RevisionFieldRoomID},
+	}
+
+	t.Run("lists revisions with field changes", func(t *testing.T) {
+		service := &fakeScheduleService{
+			listScheduleRevisionsFunc: func(ctx context.Context, p application.Principal, scheduleID string) ([]application.ScheduleRevision, error) {
+				if scheduleID != "sched-1" {
+					t.Fatalf("unexpected schedule id %q", scheduleID)
+				}
+				return []application.ScheduleRevision{{ScheduleID: "sched-1", Revision: 1, ChangedBy: "user-1", Snapshot: first}, revision}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/schedules/sched-1/revisions"))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var payload listScheduleRevisionsResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(payload.Revisions) != 2 || len(payload.Revisions[0].Changes) != 0 {
+			t.Fatalf("unexpected revisions: %#v", payload.Revisions)
+		}
+		changes := payload.Revisions[1].Changes
+		if payload.Revisions[1].ComparedTo != 1 || len(changes) != 3 {
+			t.Fatalf("expected 3 changes against revision 1, got %#v", payload.Revisions[1])
+		}
+		if changes[0].Field != "start" || changes[0].From != "2024-05-01T09:00:00Z" || changes[0].To != "2024-05-01T11:00:00Z" {
+			t.Fatalf("unexpected start change: %#v", changes[0])
+		}
+		if changes[2].Field != "room_id" || changes[2].From != nil || changes[2].To != "room-1" {
+			t.Fatalf("unexpected room change: %#v", changes[2])
+		}
+	})
+
+	t.Run("gets a revision compared to another", func(t *testing.T) {
+		var gotRevision, gotCompareTo int
+		service := &fakeScheduleService{
+			getScheduleRevisionFunc: func(ctx context.Context, p application.Principal, scheduleID string, n, compareTo int) (application.ScheduleRevision, error) {
+				gotRevision, gotCompareTo = n, compareTo
+				return revision, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/schedules/sched-1/revisions/2?compare_to=1"))
+		if recorder.Code != http.StatusOK || gotRevision != 2 || gotCompareTo != 1 {
+			t.Fatalf("expected revision 2 against 1, got %d for %d/%d: %s", recorder.Code, gotRevision, gotCompareTo, recorder.Body.String())
+		}
+		var payload scheduleRevisionResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Revision.ChangedBy != "user-2" || payload.Revision.Schedule.Start != "2024-05-01T11:00:00Z" {
+			t.Fatalf("unexpected revision: %#v", payload.Revision)
+		}
+
+		for _, target := range []string{"/schedules/sched-1/revisions/zero", "/schedules/sched-1/revisions/0", "/schedules/sched-1/revisions/2?compare_to=x"} {
+			recorder = httptest.NewRecorder()
+			router.ServeHTTP(recorder, newRequest(http.MethodGet, target))
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400 for %s, got %d", target, recorder.Code)
+			}
+		}
+	})
+
+	t.Run("reverts to a revision", func(t *testing.T) {
+		var reverted int
+		service := &fakeScheduleService{
+			revertScheduleFunc: func(ctx context.Context, p application.Principal, scheduleID string, n int) (application.Schedule, []application.ConflictWarning, error) {
+				reverted = n
+				if n == 9 {
+					return application.Schedule{}, nil, application.ErrNotFound
+				}
+				return application.Schedule{ID: scheduleID, Title: "Planning"}, []application.ConflictWarning{{ScheduleID: "sched-2", Type: "room"}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/schedules/sched-1/revisions/1/revert"))
+		if recorder.Code != http.StatusOK || reverted != 1 {
+			t.Fatalf("expected revert to revision 1, got %d for %d", recorder.Code, reverted)
+		}
+		if !bytes.Contains(recorder.Body.Bytes(), []byte(`"schedule_id":"sched-2"`)) {
+			t.Fatalf("expected conflict warnings, got %s", recorder.Body.String())
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPost, "/schedules/sched-1/revisions/9/revert"))
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for a missing revision, got %d", recorder.Code)
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodGet, "/schedules/sched-1/revisions/1/revert"))
+		if recorder.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405, got %d", recorder.Code)
+		}
+	})
+}
+
+func TestConditionalUpdateHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1", IsAdmin: true}
+	newRequest := func(method, target, body string) *http.Request {
+		req := httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("returns the version as an ETag on GET", func(t *testing.T) {
+		router := NewRouter(RouterConfig{
+			Schedules: NewScheduleHandler(&fakeScheduleService{
+				getScheduleFunc: func(ctx context.Context, p application.Principal, scheduleID string) (application.Schedule, error) {
+					return application.Schedule{ID: scheduleID, Title: "Planning", Start: start, End: start.Add(time.Hour), Version: 4}, nil
+				},
+			}, nil),
+			Rooms: NewRoomHandler(&fakeRoomService{
+				getRoomFunc: func(ctx context.Context, p application.Principal, roomID string) (application.Room, error) {
+					return application.Room{ID: roomID, Name: "Orion", Version: 2}, nil
+				},
+			}, nil),
+			Users: NewUserHandler(&fakeUserService{
+				getUserFunc: func(ctx context.Context, p application.Principal, userID string) (application.User, error) {
+					return application.User{ID: userID, Email: "user@example.com", Version: 7}, nil
+				},
+			}, nil),
+		})
+
+		for target, want := range map[string]string{"/schedules/sched-1": `"4"`, "/rooms/room-1": `"2"`, "/users/user-1": `"7"`} {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, newRequest(http.MethodGet, target, ""))
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("GET %s: expected status 200 OK, got %d: %s", target, recorder.Code, recorder.Body.String())
+			}
+			if got := recorder.Header().Get("ETag"); got != want {
+				t.Fatalf("GET %s: expected ETag %s, got %q", target, want, got)
+			}
+		}
+	})
+
+	t.Run("requires If-Match on PUT", func(t *testing.T) {
+		called := false
+		router := NewRouter(RouterConfig{Rooms: NewRoomHandler(&fakeRoomService{
+			updateRoomFunc: func(ctx context.Context, params application.UpdateRoomParams) (application.Room, error) {
+				called = true
+				return application.Room{}, nil
+			},
+		}, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(http.MethodPut, "/rooms/room-1", `{"name":"Orion","location":"3F","capacity":8}`))
+
+		if recorder.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected status 428, got %d", recorder.Code)
+		}
+		if called {
+			t.Fatalf("expected the update not to reach the service")
+		}
+	})
+
+	t.Run("passes If-Match through and maps stale versions to 412", func(t *testing.T) {
+		var ifMatch string
+		router := NewRouter(RouterConfig{Users: NewUserHandler(&fakeUserService{
+			updateUserFunc: func(ctx context.Context, params application.UpdateUserParams) (application.User, error) {
+				ifMatch = params.IfMatch
+				return application.User{}, application.ErrPreconditionFailed
+			},
+		}, nil)})
+
+		req := newRequest(http.MethodPut, "/users/user-1", `{"email":"user@example.com","display_name":"User"}`)
+		req.Header.Set("If-Match", `"6"`)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if ifMatch != `"6"` {
+			t.Fatalf("expected If-Match to reach the service, got %q", ifMatch)
+		}
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected status 412, got %d", recorder.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.ErrorCode != "PRECONDITION_FAILED" {
+			t.Fatalf("expected PRECONDITION_FAILED, got %#v", payload)
+		}
+	})
+}
+
+func TestScheduleBatchHandler(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/schedules:batch", bytes.NewReader([]byte(body)))
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+	start := time.Date(2024, 6, 3, 1, 0, 0, 0, time.UTC)
+
+	t.Run("reports each operation with the status of the single call", func(t *testing.T) {
+		var params application.ScheduleBatchParams
+		service := &fakeScheduleService{
+			applyScheduleBatchFunc: func(ctx context.Context, p application.ScheduleBatchParams) (application.ScheduleBatch, error) {
+				params = p
+				created := application.Schedule{ID: "sched-1", Title: "Orientation", Start: start, End: start.Add(time.Hour), Version: 1}
+				return application.ScheduleBatch{Mode: application.ScheduleBatchBestEffort, Committed: true, Results: []application.ScheduleBatchResult{
+					{Op: application.ScheduleBatchCreate, ScheduleID: "sched-1", Status: application.ScheduleBatchApplied, Schedule: &created},
+					{Op: application.ScheduleBatchDelete, ScheduleID: "sched-9", Status: application.ScheduleBatchFailed, Err: application.ErrNotFound},
+				}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(`{"mode":"best_effort","operations":[{"op":"create","schedule":{"title":"Orientation","start":"2024-06-03T01:00:00Z","end":"2024-06-03T02:00:00Z"}},{"op":"delete","schedule_id":"sched-9"}]}`))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 OK, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if params.Mode != application.ScheduleBatchBestEffort || len(params.Operations) != 2 || params.Operations[0].Input.Title != "Orientation" || params.Operations[1].ScheduleID != "sched-9" {
+			t.Fatalf("unexpected params %+v", params)
+		}
+		var payload scheduleBatchResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		first, second := payload.Results[0], payload.Results[1]
+		if first.HTTPStatus != http.StatusCreated || first.ETag != `"1"` || first.Schedule == nil || first.Schedule.ID != "sched-1" {
+			t.Fatalf("unexpected create result %+v", first)
+		}
+		if second.HTTPStatus != http.StatusNotFound || second.Error == nil || second.Error.ErrorCode != "RESOURCE_NOT_FOUND" {
+			t.Fatalf("unexpected delete result %+v", second)
+		}
+	})
+
+	t.Run("answers a rolled back batch with the failing operation's status", func(t *testing.T) {
+		service := &fakeScheduleService{
+			applyScheduleBatchFunc: func(ctx context.Context, p application.ScheduleBatchParams) (application.ScheduleBatch, error) {
+				return application.ScheduleBatch{Mode: application.ScheduleBatchAllOrNothing, Results: []application.ScheduleBatchResult{
+					{Op: application.ScheduleBatchCreate, ScheduleID: "sched-1", Status: application.ScheduleBatchRolledBack},
+					{Op: application.ScheduleBatchUpdate, ScheduleID: "sched-2", Status: application.ScheduleBatchFailed, Err: application.ErrPreconditionFailed},
+					{Op: application.ScheduleBatchDelete, ScheduleID: "sched-3", Status: application.ScheduleBatchSkipped},
+				}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(`{"operations":[{"op":"create"},{"op":"update","schedule_id":"sched-2","if_match":"\"2\""},{"op":"delete","schedule_id":"sched-3"}]}`))
+
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected status 412, got %d", recorder.Code)
+		}
+		var payload scheduleBatchResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if payload.Committed || payload.Results[0].HTTPStatus != http.StatusFailedDependency || payload.Results[2].Status != "skipped" {
+			t.Fatalf("unexpected response %+v", payload)
+		}
+	})
+
+	t.Run("requires if_match on updates", func(t *testing.T) {
+		called := false
+		service := &fakeScheduleService{
+			applyScheduleBatchFunc: func(ctx context.Context, p application.ScheduleBatchParams) (application.ScheduleBatch, error) {
+				called = true
+				return application.ScheduleBatch{}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{Schedules: NewScheduleHandler(service, nil)})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest(`{"operations":[{"op":"update","schedule_id":"sched-2","schedule":{"title":"Lunch"}}]}`))
+
+		if recorder.Code != http.StatusPreconditionRequired || called {
+			t.Fatalf("expected 428 without calling the service, got %d (called %v)", recorder.Code, called)
+		}
+	})
+}
+
+func TestCalDAVHandlers(t *testing.T) {
+	principal := application.Principal{UserID: "user-1"}
+	newRequest := func(method, target, body string) *http.Request {
+		req := httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+
+	t.Run("principal propfind advertises calendar home", func(t *testing.T) {
+		service := &fakeCalDAVService{
+			ownerFunc: func(ctx context.Context, principal application.Principal) (application.User, error) {
+				return application.User{ID: "user-1", Email: "alice@example.com", DisplayName: "Alice"}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{CalDAV: NewCalDAVHandler(service, nil)})
+
+		req := newRequest(methodPropfind, "/dav/principals/user-1/", `<?xml version="1.0"?><d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><c:calendar-home-set/><d:getetag/></d:prop></d:propfind>`)
+		req.Header.Set("Depth", "0")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusMultiStatus {
+			t.Fatalf("expected status 207 Multi-Status, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		body := recorder.Body.String()
+		if !bytes.Contains([]byte(body), []byte("<d:href>/dav/calendars/user-1/</d:href>")) {
+			t.Fatalf("expected calendar home in response: %s", body)
+		}
+		if !bytes.Contains([]byte(body), []byte("404 Not Found")) {
+			t.Fatalf("expected unknown property reported as missing: %s", body)
+		}
+	})
+
+	t.Run("calendar-query passes event time range", func(t *testing.T) {
+		var captured application.CalDAVTimeRange
+		service := &fakeCalDAVService{
+			listFunc: func(ctx context.Context, principal application.Principal, calendarID string, timeRange application.CalDAVTimeRange) ([]application.CalDAVResource, error) {
+				captured = timeRange
+				return []application.CalDAVResource{{Name: "abc.ics", ETag: `"e1"`, Data: []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")}}, nil
+			},
+		}
+		router := NewRouter(RouterConfig{CalDAV: NewCalDAVHandler(service, nil)})
+
+		req := newRequest(methodReport, "/dav/calendars/user-1/default/", `<?xml version="1.0"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT"><c:time-range start="20240401T000000Z" end="20240501T000000Z"/></c:comp-filter></c:comp-filter></c:filter>
+</c:calendar-query>`)
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusMultiStatus {
+			t.Fatalf("expected status 207 Multi-Status, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if captured.Start == nil || !captured.Start.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) ||
+			captured.End == nil || !captured.End.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected time range: %#v", captured)
+		}
+		body := recorder.Body.Bytes()
+		if !bytes.Contains(body, []byte("/dav/calendars/user-1/default/abc.ics")) || !bytes.Contains(body, []byte("BEGIN:VCALENDAR")) {
+			t.Fatalf("expected resource with calendar data: %s", body)
+		}
+	})
+
+	t.Run("put creates resource and maps precondition failure", func(t *testing.T) {
+		var captured application.PutCalDAVResourceParams
+		fail := false
+		service := &fakeCalDAVService{
+			putFunc: func(ctx context.Context, params application.PutCalDAVResourceParams) (application.Schedule, bool, error) {
+				captured = params
+				if fail {
+					return application.Schedule{}, false, application.ErrPreconditionFailed
+				}
+				return application.Schedule{ID: "s1"}, true, nil
+			},
+		}
+		router := NewRouter(RouterConfig{CalDAV: NewCalDAVHandler(service, nil)})
+
+		req := newRequest(http.MethodPut, "/dav/calendars/user-1/default/abc.ics", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		req.Header.Set("If-None-Match", "*")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 Created, got %d", recorder.Code)
+		}
+		if captured.CalendarID != "default" || captured.Name != "abc.ics" || captured.IfNoneMatch != "*" || len(captured.Data) == 0 {
+			t.Fatalf("unexpected params: %#v", captured)
+		}
+
+		fail = true
+		req = newRequest(http.MethodPut, "/dav/calendars/user-1/default/abc.ics", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		req.Header.Set("If-Match", `"stale"`)
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected status 412 Precondition Failed, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("rejects another user's collection", func(t *testing.T) {
+		router := NewRouter(RouterConfig{CalDAV: NewCalDAVHandler(&fakeCalDAVService{}, nil)})
+
+		req := newRequest(methodPropfind, "/dav/calendars/user-2/", "")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 Forbidden, got %d", recorder.Code)
+		}
+	})
+}
+
+type fakeAuthService struct {
+	authenticateFunc func(context.Context, application.AuthenticateParams) (application.AuthenticateResult, error)
+	revokeFunc       func(context.Context, string) error
+}
+
+func (f *fakeAuthService) Authenticate(ctx context.Context, params application.AuthenticateParams) (application.AuthenticateResult, error) {
+	if f.authenticateFunc != nil {
+		return f.authenticateFunc(ctx, params)
+	}
+	return application.AuthenticateResult{}, nil
+}
+
+func (f *fakeAuthService) RevokeSession(ctx context.Context, token string) error {
+	if f.revokeFunc != nil {
+		return f.revokeFunc(ctx, token)
+	}
+	return nil
+}
+
+type fakeUserService struct {
+	createUserFunc func(context.Context, application.CreateUserParams) (application.User, error)
+	updateUserFunc func(context.Context, application.UpdateUserParams) (application.User, error)
+	deleteUserFunc func(context.Context, application.Principal, string) error
+	getUserFunc    func(context.Context, application.Principal, string) (application.User, error)
+	listUsersFunc  func(context.Context, application.Principal) ([]application.User, error)
+
+	listUsersPageFunc func(context.Context, application.ListUsersParams) (application.UserPage, error)
+	importUsersFunc   func(context.Context, application.ImportUsersParams) (application.UserImportResult, error)
+}
+
+func (f *fakeUserService) ImportUsers(ctx context.Context, params application.ImportUsersParams) (application.UserImportResult, error) {
+	if f.importUsersFunc != nil {
+		return f.importUsersFunc(ctx, params)
+	}
+	return application.UserImportResult{}, nil
+}
+
+func (f *fakeUserService) CreateUser(ctx context.Context, params application.CreateUserParams) (application.User, error) {
+	if f.createUserFunc != nil {
+		return f.createUserFunc(ctx, params)
+	}
+	return application.User{}, nil
+}
+
+func (f *fakeUserService) UpdateUser(ctx context.Context, params application.UpdateUserParams) (application.User, error) {
+	if f.updateUserFunc != nil {
+		return f.updateUserFunc(ctx, params)
+	}
+	return application.User{}, nil
+}
+
+func (f *fakeUserService) GetUser(ctx context.Context, principal application.Principal, userID string) (application.User, error) {
+	if f.getUserFunc != nil {
+		return f.getUserFunc(ctx, principal, userID)
+	}
+	return application.User{}, nil
+}
+
+func (f *fakeUserService) DeleteUser(ctx context.Context, principal application.Principal, userID string) error {
+	if f.deleteUserFunc != nil {
+		return f.deleteUserFunc(ctx, principal, userID)
+	}
+	return nil
+}
+
+func (f *fakeUserService) ListUsersPage(ctx context.Context, params application.ListUsersParams) (application.UserPage, error) {
+	if f.listUsersPageFunc != nil {
+		return f.listUsersPageFunc(ctx, params)
+	}
+	if f.listUsersFunc != nil {
+		users, err := f.listUsersFunc(ctx, params.Principal)
+		return application.UserPage{Users: users}, err
+	}
+	return application.UserPage{}, nil
+}
+
+type fakeRoomService struct {
+	createRoomFunc  func(context.Context, application.CreateRoomParams) (application.Room, error)
+	updateRoomFunc  func(context.Context, application.UpdateRoomParams) (application.Room, error)
+	deleteRoomFunc  func(context.Context, application.Principal, string) error
+	restoreRoomFunc func(context.Context, application.Principal, string) (application.Room, error)
+	getRoomFunc     func(context.Context, application.Principal, string) (application.Room, error)
+	listRoomsFunc   func(context.Context, application.Principal) ([]application.Room, error)
+}
+
+func (f *fakeRoomService) CreateRoom(ctx context.Context, params application.CreateRoomParams) (application.Room, error) {
+	if f.createRoomFunc != nil {
+		return f.createRoomFunc(ctx, params)
+	}
+	return application.Room{}, nil
+}
+
+func (f *fakeRoomService) UpdateRoom(ctx context.Context, params application.UpdateRoomParams) (application.Room, error) {
+	if f.updateRoomFunc != nil {
+		return f.updateRoomFunc(ctx, params)
+	}
+	return application.Room{}, nil
+}
+
+func (f *fakeRoomService) GetRoom(ctx context.Context, principal application.Principal, roomID string) (application.Room, error) {
+	if f.getRoomFunc != nil {
+		return f.getRoomFunc(ctx, principal, roomID)
+	}
+	return application.Room{}, nil
+}
+
+func (f *fakeRoomService) DeleteRoom(ctx context.Context, principal application.Principal, roomID string) error {
+	if f.deleteRoomFunc != nil {
+		return f.deleteRoomFunc(ctx, principal, roomID)
+	}
+	return nil
+}
+
+func (f *fakeRoomService) RestoreRoom(ctx context.Context, principal application.Principal, roomID string) (application.Room, error) {
+	if f.restoreRoomFunc != nil {
+		return f.restoreRoomFunc(ctx, principal, roomID)
+	}
+	return application.Room{}, nil
+}
+
+func (f *fakeRoomService) ListRoomsPage(ctx context.Context, params application.ListRoomsParams) (application.RoomPage, error) {
+	if f.listRoomsFunc != nil {
+		rooms, err := f.listRoomsFunc(ctx, params.Principal)
+		return application.RoomPage{Rooms: rooms}, err
+	}
+	return application.RoomPage{}, nil
+}
+
+type fakeScheduleService struct {
+	createScheduleFunc        func(context.Context, application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
+	updateScheduleFunc        func(context.Context, application.UpdateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
+	deleteScheduleFunc        func(context.Context, application.Principal, string) error
+	restoreScheduleFunc       func(context.Context, application.Principal, string) (application.Schedule, []application.ConflictWarning, error)
+	getScheduleFunc           func(context.Context, application.Principal, string) (application.Schedule, error)
+	listScheduleRevisionsFunc func(context.Context, application.Principal, string) ([]application.ScheduleRevision, error)
+	getScheduleRevisionFunc   func(context.Context, application.Principal, string, int, int) (application.ScheduleRevision, error)
+	revertScheduleFunc        func(context.Context, application.Principal, string, int) (application.Schedule, []application.ConflictWarning, error)
+	listSchedulesFunc         func(context.Context, application.ListSchedulesParams) ([]application.Schedule, []application.ConflictWarning, error)
+
+	listSchedulesPageFunc   func(context.Context, application.ListSchedulesParams) (application.SchedulePage, error)
+	listScheduleChangesFunc func(context.Context, application.ListScheduleChangesParams) (application.ScheduleChanges, error)
+	applyScheduleBatchFunc  func(context.Context, application.ScheduleBatchParams) (application.ScheduleBatch, error)
+	listInstancesFunc       func(context.Context, application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error)
+}
+
+func (f *fakeScheduleService) CreateSchedule(ctx context.Context, params application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
+	if f.createScheduleFunc != nil {
+		return f.createScheduleFunc(ctx, params)
+	}
+	return application.Schedule{}, nil, nil
+}
+
+func (f *fakeScheduleService) UpdateSchedule(ctx context.Context, params application.UpdateScheduleParams) (application.Schedule, []application.ConflictWarning, error) {
+	if f.updateScheduleFunc != nil {
+		return f.updateScheduleFunc(ctx, params)
+	}
+	return application.Schedule{}, nil, nil
+}
+
+func (f *fakeScheduleService) GetSchedule(ctx context.Context, principal application.Principal, scheduleID string) (application.Schedule, error) {
+	if f.getScheduleFunc != nil {
+		return f.getScheduleFunc(ctx, principal, scheduleID)
+	}
+	return application.Schedule{}, nil
+}
+
+func (f *fakeScheduleService) DeleteSchedule(ctx context.Context, principal application.Principal, scheduleID string) error {
+	if f.deleteScheduleFunc != nil {
+		return f.deleteScheduleFunc(ctx, principal, scheduleID)
+	}
+	return nil
+}
+
+func (f *fakeScheduleService) RestoreSchedule(ctx context.Context, principal application.Principal, scheduleID string) (application.Schedule, []application.ConflictWarning, error) {
+	if f.restoreScheduleFunc != nil {
+		return f.restoreScheduleFunc(ctx, principal, scheduleID)
+	}
+	return application.Schedule{}, nil, nil
+}
+
+func (f *fakeScheduleService) ListScheduleRevisions(ctx context.Context, principal application.Principal, scheduleID string) ([]application.ScheduleRevision, error) {
+	if f.listScheduleRevisionsFunc != nil {
+		return f.listScheduleRevisionsFunc(ctx, principal, scheduleID)
+	}
+	return nil, nil
+}
+
+func (f *fakeScheduleService) GetScheduleRevision(ctx context.Context, principal application.Principal, scheduleID string, revision, compareTo int) (application.ScheduleRevision, error) {
+	if f.getScheduleRevisionFunc != nil {
+		return f.getScheduleRevisionFunc(ctx, principal, scheduleID, revision, compareTo)
+	}
+	return application.ScheduleRevision{}, nil
+}
+
+func (f *fakeScheduleService) RevertSchedule(ctx context.Context, principal application.Principal, scheduleID string, revision int) (application.Schedule, []application.ConflictWarning, error) {
+	if f.revertScheduleFunc != nil {
+		return f.revertScheduleFunc(ctx, principal, scheduleID, revision)
+	}
+	return application.Schedule{}, nil, nil
+}
+
+func (f *fakeScheduleService) ListSchedulesPage(ctx context.Context, params application.ListSchedulesParams) (application.SchedulePage, error) {
+	if f.listSchedulesPageFunc != nil {
+		return f.listSchedulesPageFunc(ctx, params)
+	}
+	if f.listSchedulesFunc != nil {
+		schedules, warnings, err := f.listSchedulesFunc(ctx, params)
+		return application.SchedulePage{Schedules: schedules, Warnings: warnings}, err
+	}
+	return application.SchedulePage{}, nil
+}
+
+func (f *fakeScheduleService) ListScheduleInstances(ctx context.Context, params application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error) {
+	if f.listInstancesFunc != nil {
+		return f.listInstancesFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (f *fakeScheduleService) ApplyScheduleBatch(ctx context.Context, params application.ScheduleBatchParams) (application.ScheduleBatch, error) {
+	if f.applyScheduleBatchFunc != nil {
+		return f.applyScheduleBatchFunc(ctx, params)
+	}
+	return application.ScheduleBatch{}, nil
+}
+
+func (f *fakeScheduleService) ListScheduleChanges(ctx context.Context, params application.ListScheduleChangesParams) (application.ScheduleChanges, error) {
+	if f.listScheduleChangesFunc != nil {
+		return f.listScheduleChangesFunc(ctx, params)
+	}
+	return application.ScheduleChanges{}, nil
+}
+
+type fakeAvailabilityService struct {
+	getWorkingHoursFunc   func(context.Context, application.Principal, string) ([]application.WorkingHours, error)
+	setWorkingHoursFunc   func(context.Context, application.SetWorkingHoursParams) ([]application.WorkingHours, error)
+	createOutOfOfficeFunc func(context.Context, application.CreateOutOfOfficeParams) (application.OutOfOffice, error)
+	deleteOutOfOfficeFunc func(context.Context, application.Principal, string, string) error
+	listOutOfOfficeFunc   func(context.Context, application.ListOutOfOfficeParams) ([]application.OutOfOffice, error)
+}
+
+func (f *fakeAvailabilityService) GetWorkingHours(ctx context.Context, principal application.Principal, userID string) ([]application.WorkingHours, error) {
+	if f.getWorkingHoursFunc != nil {
+		return f.getWorkingHoursFunc(ctx, principal, userID)
+	}
+	return nil, nil
+}
+
+func (f *fakeAvailabilityService) SetWorkingHours(ctx context.Context, params application.SetWorkingHoursParams) ([]application.WorkingHours, error) {
+	if f.setWorkingHoursFunc != nil {
+		return f.setWorkingHoursFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (f *fakeAvailabilityService) CreateOutOfOffice(ctx context.Context, params application.CreateOutOfOfficeParams) (application.OutOfOffice, error) {
+	if f.createOutOfOfficeFunc != nil {
+		return f.createOutOfOfficeFunc(ctx, params)
+	}
+	return application.OutOfOffice{}, nil
+}
+
+func (f *fakeAvailabilityService) DeleteOutOfOffice(ctx context.Context, principal application.Principal, userID, entryID string) error {
+	if f.deleteOutOfOfficeFunc != nil {
+		return f.deleteOutOfOfficeFunc(ctx, principal, userID, entryID)
+	}
+	return nil
+}
+
+func (f *fakeAvailabilityService) ListOutOfOffice(ctx context.Context, params application.ListOutOfOfficeParams) ([]application.OutOfOffice, error) {
+	if f.listOutOfOfficeFunc != nil {
+		return f.listOutOfOfficeFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339Nano, value)
 	if err != nil {
 		parsed, err = time.Parse(time.RFC3339, value)
 	}
@@ -1108,3 +3517,238 @@ func slicesEqual(a, b []string) bool {
 	}
 	return true
 }
+
+type fakeHolidayService struct {
+	listHolidaysFunc         func(context.Context, application.ListHolidaysParams) ([]application.Holiday, error)
+	createCompanyHolidayFunc func(context.Context, application.CreateCompanyHolidayParams) (application.Holiday, error)
+	deleteCompanyHolidayFunc func(context.Context, application.Principal, time.Time) error
+}
+
+func (f *fakeHolidayService) ListHolidays(ctx context.Context, params application.ListHolidaysParams) ([]application.Holiday, error) {
+	if f.listHolidaysFunc != nil {
+		return f.listHolidaysFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (f *fakeHolidayService) CreateCompanyHoliday(ctx context.Context, params application.CreateCompanyHolidayParams) (application.Holiday, error) {
+	if f.createCompanyHolidayFunc != nil {
+		return f.createCompanyHolidayFunc(ctx, params)
+	}
+	return application.Holiday{}, nil
+}
+
+func (f *fakeHolidayService) DeleteCompanyHoliday(ctx context.Context, principal application.Principal, date time.Time) error {
+	if f.deleteCompanyHolidayFunc != nil {
+		return f.deleteCompanyHolidayFunc(ctx, principal, date)
+	}
+	return nil
+}
+
+type fakeReminderService struct {
+	getFunc func(context.Context, application.Principal, string) (application.ReminderPreferences, error)
+	setFunc func(context.Context, application.SetReminderPreferencesParams) (application.ReminderPreferences, error)
+}
+
+func (f *fakeReminderService) GetReminderPreferences(ctx context.Context, principal application.Principal, userID string) (application.ReminderPreferences, error) {
+	if f.getFunc != nil {
+		return f.getFunc(ctx, principal, userID)
+	}
+	return application.ReminderPreferences{}, nil
+}
+
+func (f *fakeReminderService) SetReminderPreferences(ctx context.Context, params application.SetReminderPreferencesParams) (application.ReminderPreferences, error) {
+	if f.setFunc != nil {
+		return f.setFunc(ctx, params)
+	}
+	return application.ReminderPreferences{}, nil
+}
+
+type fakeWebhookService struct {
+	listFunc       func(context.Context, application.Principal) ([]application.WebhookSubscription, error)
+	createFunc     func(context.Context, application.CreateWebhookParams) (application.WebhookSubscription, error)
+	updateFunc     func(context.Context, application.UpdateWebhookParams) (application.WebhookSubscription, error)
+	deleteFunc     func(context.Context, application.Principal, string) error
+	deliveriesFunc func(context.Context, application.Principal, string, int) ([]application.WebhookDelivery, error)
+	redeliverFunc  func(context.Context, application.Principal, string, string) (application.WebhookDelivery, error)
+}
+
+func (f *fakeWebhookService) ListWebhooks(ctx context.Context, principal application.Principal) ([]application.WebhookSubscription, error) {
+	if f.listFunc != nil {
+		return f.listFunc(ctx, principal)
+	}
+	return nil, nil
+}
+
+func (f *fakeWebhookService) CreateWebhook(ctx context.Context, params application.CreateWebhookParams) (application.WebhookSubscription, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.WebhookSubscription{}, nil
+}
+
+func (f *fakeWebhookService) UpdateWebhook(ctx context.Context, params application.UpdateWebhookParams) (application.WebhookSubscription, error) {
+	if f.updateFunc != nil {
+		return f.updateFunc(ctx, params)
+	}
+	return application.WebhookSubscription{}, nil
+}
+
+func (f *fakeWebhookService) DeleteWebhook(ctx context.Context, principal application.Principal, webhookID string) error {
+	if f.deleteFunc != nil {
+		return f.deleteFunc(ctx, principal, webhookID)
+	}
+	return nil
+}
+
+func (f *fakeWebhookService) ListWebhookDeliveries(ctx context.Context, principal application.Principal, webhookID string, limit int) ([]application.WebhookDelivery, error) {
+	if f.deliveriesFunc != nil {
+		return f.deliveriesFunc(ctx, principal, webhookID, limit)
+	}
+	return nil, nil
+}
+
+func (f *fakeWebhookService) RedeliverWebhook(ctx context.Context, principal application.Principal, webhookID, deliveryID string) (application.WebhookDelivery, error) {
+	if f.redeliverFunc != nil {
+		return f.redeliverFunc(ctx, principal, webhookID, deliveryID)
+	}
+	return application.WebhookDelivery{}, nil
+}
+
+type fakeOutbox struct {
+	events []application.OutboxEvent
+}
+
+func (f *fakeOutbox) ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]application.OutboxEvent, error) {
+	var out []application.OutboxEvent
+	for _, event := range f.events {
+		if event.Sequence > after && len(out) < limit {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeOutbox) LatestOutboxSequence(ctx context.Context) (int64, error) {
+	return int64(len(f.events)), nil
+}
+
+type fakeAppPasswordService struct {
+	listFunc   func(context.Context, application.Principal, string) ([]application.AppPassword, error)
+	createFunc func(context.Context, application.CreateAppPasswordParams) (application.AppPassword, error)
+	deleteFunc func(context.Context, application.Principal, string, string) error
+}
+
+func (f *fakeAppPasswordService) ListAppPasswords(ctx context.Context, principal application.Principal, userID string) ([]application.AppPassword, error) {
+	if f.listFunc != nil {
+		return f.listFunc(ctx, principal, userID)
+	}
+	return nil, nil
+}
+
+func (f *fakeAppPasswordService) CreateAppPassword(ctx context.Context, params application.CreateAppPasswordParams) (application.AppPassword, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.AppPassword{}, nil
+}
+
+func (f *fakeAppPasswordService) DeleteAppPassword(ctx context.Context, principal application.Principal, userID, passwordID string) error {
+	if f.deleteFunc != nil {
+		return f.deleteFunc(ctx, principal, userID, passwordID)
+	}
+	return nil
+}
+
+type fakeCalDAVService struct {
+	ownerFunc func(context.Context, application.Principal) (application.User, error)
+	listFunc  func(context.Context, application.Principal, string, application.CalDAVTimeRange) ([]application.CalDAVResource, error)
+	putFunc   func(context.Context, application.PutCalDAVResourceParams) (application.Schedule, bool, error)
+}
+
+func (f *fakeCalDAVService) Owner(ctx context.Context, principal application.Principal) (application.User, error) {
+	if f.ownerFunc != nil {
+		return f.ownerFunc(ctx, principal)
+	}
+	return application.User{ID: principal.UserID}, nil
+}
+
+func (f *fakeCalDAVService) Calendars(ctx context.Context, principal application.Principal) ([]application.CalDAVCalendar, error) {
+	return nil, nil
+}
+
+func (f *fakeCalDAVService) Calendar(ctx context.Context, principal application.Principal, calendarID string) (application.CalDAVCalendar, error) {
+	return application.CalDAVCalendar{ID: calendarID}, nil
+}
+
+func (f *fakeCalDAVService) ListResources(ctx context.Context, principal application.Principal, calendarID string, timeRange application.CalDAVTimeRange) ([]application.CalDAVResource, error) {
+	if f.listFunc != nil {
+		return f.listFunc(ctx, principal, calendarID, timeRange)
+	}
+	return nil, nil
+}
+
+func (f *fakeCalDAVService) GetResources(ctx context.Context, principal application.Principal, calendarID string, names []string) ([]application.CalDAVResource, error) {
+	return nil, nil
+}
+
+func (f *fakeCalDAVService) GetResource(ctx context.Context, principal application.Principal, calendarID, name string) (application.CalDAVResource, error) {
+	return application.CalDAVResource{}, application.ErrNotFound
+}
+
+func (f *fakeCalDAVService) PutResource(ctx context.Context, params application.PutCalDAVResourceParams) (application.Schedule, bool, error) {
+	if f.putFunc != nil {
+		return f.putFunc(ctx, params)
+	}
+	return application.Schedule{}, false, nil
+}
+
+func (f *fakeCalDAVService) DeleteResource(ctx context.Context, principal application.Principal, calendarID, name, ifMatch string) error {
+	return nil
+}
+
+type fakeCalDAVSyncService struct {
+	createFunc func(context.Context, application.CreateCalDAVSyncAccountParams) (application.CalDAVSyncAccount, error)
+	runsFunc   func(context.Context, application.Principal, string, string, int) ([]application.CalDAVSyncRun, error)
+	syncFunc   func(context.Context, application.Principal, string, string) (application.CalDAVSyncRun, error)
+}
+
+func (f *fakeCalDAVSyncService) ListSyncAccounts(ctx context.Context, principal application.Principal, userID string) ([]application.CalDAVSyncAccount, error) {
+	return nil, nil
+}
+
+func (f *fakeCalDAVSyncService) CreateSyncAccount(ctx context.Context, params application.CreateCalDAVSyncAccountParams) (application.CalDAVSyncAccount, error) {
+	if f.createFunc != nil {
+		return f.createFunc(ctx, params)
+	}
+	return application.CalDAVSyncAccount{}, nil
+}
+
+func (f *fakeCalDAVSyncService) DeleteSyncAccount(ctx context.Context, principal application.Principal, userID, accountID string) error {
+	return nil
+}
+
+func (f *fakeCalDAVSyncService) ListSyncRuns(ctx context.Context, principal application.Principal, userID, accountID string, limit int) ([]application.CalDAVSyncRun, error) {
+	if f.runsFunc != nil {
+		return f.runsFunc(ctx, principal, userID, accountID, limit)
+	}
+	return nil, nil
+}
+
+func (f *fakeCalDAVSyncService) SyncNow(ctx context.Context, principal application.Principal, userID, accountID string) (application.CalDAVSyncRun, error) {
+	if f.syncFunc != nil {
+		return f.syncFunc(ctx, principal, userID, accountID)
+	}
+	return application.CalDAVSyncRun{}, nil
+}
+
+type fakeTrashService struct {
+	listFunc func(context.Context, application.Principal) ([]application.TrashItem, error)
+}
+
+func (f *fakeTrashService) ListTrash(ctx context.Context, principal application.Principal) ([]application.TrashItem, error) {
+	if f.listFunc != nil {
+		return f.listFunc(ctx, principal)
+	}
+	return nil, nil
+}
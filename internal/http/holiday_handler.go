@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type holidayService interface {
+	ListHolidays(ctx context.Context, params application.ListHolidaysParams) ([]application.Holiday, error)
+	CreateCompanyHoliday(ctx context.Context, params application.CreateCompanyHolidayParams) (application.Holiday, error)
+	DeleteCompanyHoliday(ctx context.Context, principal application.Principal, date time.Time) error
+}
+
+// HolidayHandler serves the holiday calendar and company closure days.
+type HolidayHandler struct {
+	service   holidayService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewHolidayHandler(service holidayService, logger *slog.Logger) *HolidayHandler {
+	base := defaultLogger(logger)
+	return &HolidayHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *HolidayHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "HolidayHandler", operation, attrs...)
+}
+
+func (h *HolidayHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	params := application.ListHolidaysParams{Principal: principal}
+	if raw := strings.TrimSpace(r.URL.Query().Get("year")); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			h.log(r.Context(), "List", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid year parameter", "year", raw)
+			h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidYear)
+			return
+		}
+		params.Year = year
+	}
+
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID, "year", params.Year)
+
+	holidays, err := h.service.ListHolidays(r.Context(), params)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "holiday list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	year := params.Year
+	if len(holidays) > 0 {
+		year = holidays[0].Date.Year()
+	}
+
+	logger.With("result_count", len(holidays)).InfoContext(r.Context(), "holidays listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listHolidaysResponse{Year: year, Holidays: toHolidayDTOs(holidays)})
+}
+
+func (h *HolidayHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req companyHolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode company holiday", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	closure, err := h.service.CreateCompanyHoliday(r.Context(), application.CreateCompanyHolidayParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "company holiday creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("date", closure.Date.Format(dateLayout)).InfoContext(r.Context(), "company holiday created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, holidayResponse{Holiday: toHolidayDTO(closure)})
+}
+
+func (h *HolidayHandler) Delete(w http.ResponseWriter, r *http.Request, date string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	day, err := time.Parse(dateLayout, strings.TrimSpace(date))
+	if err != nil {
+		h.log(r.Context(), "Delete", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid holiday date", "date", date)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidHolidayDate)
+		return
+	}
+
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "date", day.Format(dateLayout))
+	if err := h.service.DeleteCompanyHoliday(r.Context(), principal, day); err != nil {
+		logger.ErrorContext(r.Context(), "company holiday delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "company holiday deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type companyHolidayRequest struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+func (r companyHolidayRequest) toInput() application.CompanyHolidayInput {
+	return application.CompanyHolidayInput{
+		Date: parseDate(r.Date),
+		Name: strings.TrimSpace(r.Name),
+	}
+}
+
+type listHolidaysResponse struct {
+	Year     int          `json:"year"`
+	Holidays []holidayDTO `json:"holidays"`
+}
+
+type holidayResponse struct {
+	Holiday holidayDTO `json:"holiday"`
+}
+
+type holidayDTO struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+func toHolidayDTO(h application.Holiday) holidayDTO {
+	return holidayDTO{
+		Date: h.Date.Format(dateLayout),
+		Name: h.Name,
+		Kind: h.Kind,
+	}
+}
+
+func toHolidayDTOs(holidays []application.Holiday) []holidayDTO {
+	out := make([]holidayDTO, 0, len(holidays))
+	for _, h := range holidays {
+		out = append(out, toHolidayDTO(h))
+	}
+	return out
+}
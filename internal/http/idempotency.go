@@ -0,0 +1,236 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IdempotencyKeyHeader names the request header that makes a POST or PUT safe
+// to retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header value.
+const maxIdempotencyKeyLength = 255
+
+// maxJSONRequestBody bounds the JSON request bodies buffered by the server.
+const maxJSONRequestBody = 1 << 20
+
+// replayedHeaders are the response headers stored with an idempotent response.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotentResponse is the response remembered for a principal's
+// Idempotency-Key. StatusCode is 0 while the first request is in flight.
+type IdempotentResponse struct {
+	PrincipalID string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IdempotencyStore persists idempotent responses. ReserveIdempotencyKey claims
+// the key for the first request and otherwise returns the stored response.
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, response IdempotentResponse) (IdempotentResponse, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, response IdempotentResponse) error
+	ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error
+}
+
+// RequireIdempotency replays the stored response when an authenticated POST or
+// PUT is retried with the same Idempotency-Key and body for ttl after the first
+// attempt. Reusing a key with a different body is rejected with 422 and a
+// retry while the first attempt is still running with 409. Server errors are
+// not stored so the request can be retried. It must run after the principal
+// has been attached to the request context.
+func RequireIdempotency(store IdempotencyStore, ttl time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	base := defaultLogger(logger)
+	responder := newResponder(base)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost && r.Method != http.MethodPut {
+				next.ServeHTTP(w, r)
+				return
+			}
+			values := r.Header.Values(IdempotencyKeyHeader)
+			if len(values) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok || principal.UserID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			audit := LoggerFromContext(r.Context())
+			if audit == nil {
+				audit = base
+			}
+			audit = audit.With("middleware", "RequireIdempotency", "user_id", principal.UserID)
+
+			if store == nil {
+				audit.ErrorContext(r.Context(), "idempotency store not configured")
+				responder.writeJSON(r.Context(), w, http.StatusInternalServerError, errorResponse{Message: localizedStatusMessage(http.StatusInternalServerError)})
+				return
+			}
+
+			key := strings.TrimSpace(values[0])
+			if len(values) > 1 || key == "" || len(key) > maxIdempotencyKeyLength {
+				audit.InfoContext(r.Context(), "invalid idempotency key", "error_kind", "bad_request")
+				responder.writeJSON(r.Context(), w, http.StatusBadRequest, errorResponse{ErrorCode: "IDEMPOTENCY_KEY_INVALID", Message: errInvalidIdempotencyKey.Error()})
+				return
+			}
+			audit = audit.With("idempotency_key", key)
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONRequestBody))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				audit.InfoContext(r.Context(), "request body too large", "limit", tooLarge.Limit, "error_kind", "bad_request")
+				responder.writeJSON(r.Context(), w, http.StatusRequestEntityTooLarge, errorResponse{ErrorCode: "REQUEST_BODY_TOO_LARGE", Message: errRequestBodyTooLarge.Error()})
+				return
+			}
+			if err != nil {
+				audit.ErrorContext(r.Context(), "failed to read request body", "error", err, "error_kind", "bad_request")
+				responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(r, body)
+			now := time.Now().UTC()
+			stored, reserved, err := store.ReserveIdempotencyKey(r.Context(), IdempotentResponse{
+				PrincipalID: principal.UserID,
+				Key:         key,
+				Fingerprint: fingerprint,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			})
+			if err != nil {
+				audit.ErrorContext(r.Context(), "failed to reserve idempotency key", "error", err, "error_kind", "internal")
+				responder.writeJSON(r.Context(), w, http.StatusInternalServerError, errorResponse{ErrorCode: "INTERNAL_SERVER_ERROR", Message: localizedStatusMessage(http.StatusInternalServerError)})
+				return
+			}
+
+			if !reserved {
+				switch {
+				case stored.Fingerprint != fingerprint:
+					audit.WarnContext(r.Context(), "idempotency key reused with a different request", "error_kind", "validation")
+					responder.writeJSON(r.Context(), w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: "IDEMPOTENCY_KEY_REUSED", Message: errIdempotencyKeyReused.Error()})
+				case stored.StatusCode == 0:
+					audit.InfoContext(r.Context(), "idempotent request still in progress", "error_kind", "conflict")
+					responder.writeJSON(r.Context(), w, http.StatusConflict, errorResponse{ErrorCode: "IDEMPOTENCY_KEY_IN_USE", Message: errIdempotencyKeyInUse.Error()})
+				default:
+					audit.InfoContext(r.Context(), "idempotent response replayed", "status", stored.StatusCode)
+					replayIdempotentResponse(w, stored)
+				}
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w}
+			completed := false
+			// Runs after the client may have gone away, so the outcome is
+			// recorded on a context that outlives the request.
+			storeCtx := context.WithoutCancel(r.Context())
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.ReleaseIdempotencyKey(storeCtx, principal.UserID, key); err != nil {
+					audit.ErrorContext(r.Context(), "failed to release idempotency key", "error", err)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.statusCode()
+			if status >= http.StatusInternalServerError {
+				audit.InfoContext(r.Context(), "idempotency key released after server error", "status", status)
+				return
+			}
+			headers := make(map[string]string, len(replayedHeaders))
+			for _, name := range replayedHeaders {
+				if value := recorder.Header().Get(name); value != "" {
+					headers[name] = value
+				}
+			}
+			stored.StatusCode = status
+			stored.Headers = headers
+			stored.Body = recorder.body.Bytes()
+			if err := store.CompleteIdempotencyKey(storeCtx, stored); err != nil {
+				audit.ErrorContext(r.Context(), "failed to store idempotent response", "error", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// requestFingerprint identifies a request by method, target and body so that
+// a reused key can be told apart from a retry.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method)
+	io.WriteString(hash, " ")
+	io.WriteString(hash, r.URL.RequestURI())
+	io.WriteString(hash, "\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, stored IdempotentResponse) {
+	for name, value := range stored.Headers {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	if len(stored.Body) > 0 {
+		_, _ = w.Write(stored.Body)
+	}
+}
+
+// idempotencyRecorder passes a response through while keeping a copy of its
+// status and body.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *idempotencyRecorder) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotencyRecorder) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *idempotencyRecorder) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
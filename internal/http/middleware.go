@@ -81,6 +81,74 @@ func RequireSession(validator SessionValidator, logger *slog.Logger) func(http.H
 	}
 }
 
+// AppPasswordAuthenticator verifies basic auth credentials backed by app passwords.
+type AppPasswordAuthenticator interface {
+	AuthenticateAppPassword(ctx context.Context, email, password string) (application.Principal, error)
+}
+
+// RequireBasicAuth authenticates requests from clients that cannot hold a
+// session, such as CalDAV calendars, with HTTP basic auth using an e-mail
+// address and an app password. Failures are challenged for realm.
+func RequireBasicAuth(authenticator AppPasswordAuthenticator, realm string, logger *slog.Logger) func(http.Handler) http.Handler {
+	base := defaultLogger(logger)
+	responder := newResponder(base)
+	challenge := `Basic realm="` + strings.ReplaceAll(realm, `"`, "") + `", charset="UTF-8"`
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authenticator == nil {
+				base.ErrorContext(r.Context(), "app password authenticator not configured", "middleware", "RequireBasicAuth")
+				responder.writeJSON(r.Context(), w, http.StatusInternalServerError, errorResponse{Message: "認証中にエラーが発生しました。"})
+				return
+			}
+
+			audit := LoggerFromContext(r.Context())
+			if audit == nil {
+				audit = base
+			}
+			audit = audit.With("middleware", "RequireBasicAuth")
+
+			unauthorized := func() {
+				w.Header().Set("WWW-Authenticate", challenge)
+				responder.writeJSON(r.Context(), w, http.StatusUnauthorized, errorResponse{
+					ErrorCode: "AUTH_INVALID_CREDENTIALS",
+					Message:   "メールアドレスまたはアプリパスワードが正しくありません。",
+				})
+			}
+
+			email, password, ok := r.BasicAuth()
+			if !ok {
+				audit.InfoContext(r.Context(), "basic auth credentials missing", "error_kind", "unauthorized")
+				unauthorized()
+				return
+			}
+
+			principal, err := authenticator.AuthenticateAppPassword(r.Context(), email, password)
+			if err != nil {
+				switch {
+				case errors.Is(err, application.ErrInvalidCredentials), errors.Is(err, application.ErrAccountDisabled):
+					audit.WarnContext(r.Context(), "basic auth rejected", "error", err, "error_kind", application.ErrorKind(err))
+					unauthorized()
+				default:
+					audit.ErrorContext(r.Context(), "basic auth failed", "error", err, "error_kind", application.ErrorKind(err))
+					responder.writeJSON(r.Context(), w, http.StatusInternalServerError, errorResponse{
+						ErrorCode: "INTERNAL_ERROR",
+						Message:   "認証中にエラーが発生しました。",
+					})
+				}
+				return
+			}
+
+			audit = audit.With("user_id", principal.UserID)
+			audit.InfoContext(r.Context(), "basic auth validated")
+
+			ctx := ContextWithPrincipal(r.Context(), principal)
+			ctx = ContextWithLogger(ctx, audit)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func RequestLogger(base *slog.Logger) func(http.Handler) http.Handler {
 	if base == nil {
 		base = slog.Default()
@@ -130,3 +198,9 @@ func (w *responseWriter) Write(p []byte) (int, error) {
 	w.bytes += n
 	return n, err
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController so that
+// streaming handlers can flush and adjust deadlines.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/example/enterprise-scheduler/internal/application"
 )
@@ -228,6 +231,261 @@ func TestSessionMiddleware(t *testing.T) {
 	})
 }
 
+func TestBasicAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("challenges requests without credentials", func(t *testing.T) {
+		t.Parallel()
+
+		authenticator := &fakeAppPasswordAuthenticator{}
+		handler := RequireBasicAuth(authenticator, "Scheduler", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("next handler should not be called when authentication fails")
+		}))
+
+		req := httptest.NewRequest(methodPropfind, "/dav/", nil)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401 Unauthorized, got %d", recorder.Code)
+		}
+		if got := recorder.Header().Get("WWW-Authenticate"); got != `Basic realm="Scheduler", charset="UTF-8"` {
+			t.Fatalf("unexpected challenge %q", got)
+		}
+		if authenticator.calls != 0 {
+			t.Fatalf("expected authenticator not to be invoked, got %d", authenticator.calls)
+		}
+	})
+
+	t.Run("rejects invalid app passwords", func(t *testing.T) {
+		t.Parallel()
+
+		authenticator := &fakeAppPasswordAuthenticator{err: application.ErrInvalidCredentials}
+		handler := RequireBasicAuth(authenticator, "Scheduler", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("next handler should not be called when authentication fails")
+		}))
+
+		req := httptest.NewRequest(methodPropfind, "/dav/", nil)
+		req.SetBasicAuth("alice@example.com", "wrong")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401 Unauthorized, got %d", recorder.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode error response: %v", err)
+		}
+		if payload.ErrorCode != "AUTH_INVALID_CREDENTIALS" {
+			t.Fatalf("expected error code AUTH_INVALID_CREDENTIALS, got %q", payload.ErrorCode)
+		}
+	})
+
+	t.Run("injects principal for valid app passwords", func(t *testing.T) {
+		t.Parallel()
+
+		authenticator := &fakeAppPasswordAuthenticator{principal: application.Principal{UserID: "user-1"}}
+		var got application.Principal
+		handler := RequireBasicAuth(authenticator, "Scheduler", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = PrincipalFromContext(r.Context())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		req := httptest.NewRequest(methodPropfind, "/dav/", nil)
+		req.SetBasicAuth("alice@example.com", "s3cret")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204 No Content, got %d", recorder.Code)
+		}
+		if got.UserID != "user-1" {
+			t.Fatalf("expected principal user-1, got %#v", got)
+		}
+		if authenticator.email != "alice@example.com" || authenticator.password != "s3cret" {
+			t.Fatalf("unexpected credentials %q/%q", authenticator.email, authenticator.password)
+		}
+	})
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Parallel()
+
+	principal := application.Principal{UserID: "user-1"}
+	newRequest := func(method, key, body string) *http.Request {
+		req := httptest.NewRequest(method, "/schedules", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		return req.WithContext(ContextWithPrincipal(req.Context(), principal))
+	}
+
+	t.Run("replays the stored response for a retried request", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		calls := 0
+		handler := RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Header().Set("ETag", `"1"`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"echo":` + string(body) + `}`))
+		}))
+
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newRequest(http.MethodPost, "key-1", `{"title":"Kickoff"}`))
+		second := httptest.NewRecorder()
+		handler.ServeHTTP(second, newRequest(http.MethodPost, "key-1", `{"title":"Kickoff"}`))
+
+		if calls != 1 {
+			t.Fatalf("expected the handler to run once, got %d", calls)
+		}
+		if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+			t.Fatalf("expected replayed 201 %q, got %d %q", first.Body.String(), second.Code, second.Body.String())
+		}
+		if second.Header().Get("ETag") != `"1"` || second.Header().Get("Idempotent-Replayed") != "true" {
+			t.Fatalf("unexpected replay headers %v", second.Header())
+		}
+		if first.Header().Get("Idempotent-Replayed") != "" {
+			t.Fatal("expected the first response not to be marked as replayed")
+		}
+	})
+
+	t.Run("rejects a reused key with a different body", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		handler := RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPost, "key-1", `{"title":"Kickoff"}`))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(http.MethodPost, "key-1", `{"title":"Retro"}`))
+
+		if recorder.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", recorder.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode error response: %v", err)
+		}
+		if payload.ErrorCode != "IDEMPOTENCY_KEY_REUSED" {
+			t.Fatalf("expected error code IDEMPOTENCY_KEY_REUSED, got %q", payload.ErrorCode)
+		}
+	})
+
+	t.Run("answers 409 while the first request is running", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		var nested *httptest.ResponseRecorder
+		var handler http.Handler
+		handler = RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if nested == nil {
+				nested = httptest.NewRecorder()
+				handler.ServeHTTP(nested, newRequest(http.MethodPost, "key-1", `{}`))
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPost, "key-1", `{}`))
+
+		if nested.Code != http.StatusConflict {
+			t.Fatalf("expected status 409 for the concurrent retry, got %d", nested.Code)
+		}
+	})
+
+	t.Run("does not keep server errors", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		calls := 0
+		handler := RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPut, "key-1", `{}`))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(http.MethodPut, "key-1", `{}`))
+
+		if calls != 2 || recorder.Code != http.StatusOK {
+			t.Fatalf("expected the retry to run the handler again, got %d calls and status %d", calls, recorder.Code)
+		}
+	})
+
+	t.Run("ignores requests without a key and rejects malformed keys", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		calls := 0
+		handler := RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPost, "", `{}`))
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPost, "", `{}`))
+		if calls != 2 || len(store.records) != 0 {
+			t.Fatalf("expected requests without a key to pass through, got %d calls and %d records", calls, len(store.records))
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(http.MethodPost, strings.Repeat("k", 256), `{}`))
+		if recorder.Code != http.StatusBadRequest || calls != 2 {
+			t.Fatalf("expected status 400 without calling the handler, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("rejects an oversized body without reserving the key", func(t *testing.T) {
+		t.Parallel()
+
+		store := newFakeIdempotencyStore()
+		calls := 0
+		handler := RequireIdempotency(store, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(http.MethodPost, "key-1", `{"memo":"`+strings.Repeat("x", maxJSONRequestBody)+`"}`))
+		if recorder.Code != http.StatusRequestEntityTooLarge || calls != 0 || len(store.records) != 0 {
+			t.Fatalf("expected status 413 without calling the handler, got %d with %d calls and %d records", recorder.Code, calls, len(store.records))
+		}
+		if !strings.Contains(recorder.Body.String(), "REQUEST_BODY_TOO_LARGE") {
+			t.Fatalf("expected a too-large error code, got %s", recorder.Body.String())
+		}
+	})
+}
+
+type fakeAppPasswordAuthenticator struct {
+	principal application.Principal
+	err       error
+	calls     int
+	email     string
+	password  string
+}
+
+func (f *fakeAppPasswordAuthenticator) AuthenticateAppPassword(ctx context.Context, email, password string) (application.Principal, error) {
+	f.calls++
+	f.email, f.password = email, password
+	if f.err != nil {
+		return application.Principal{}, f.err
+	}
+	return f.principal, nil
+}
+
 type fakeSessionValidator struct {
 	principal application.Principal
 	err       error
@@ -242,6 +500,40 @@ func (f *fakeSessionValidator) ValidateSession(ctx context.Context, token string
 	return f.principal, nil
 }
 
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotentResponse
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]IdempotentResponse)}
+}
+
+func (f *fakeIdempotencyStore) ReserveIdempotencyKey(ctx context.Context, response IdempotentResponse) (IdempotentResponse, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := response.PrincipalID + "/" + response.Key
+	if stored, ok := f.records[id]; ok && stored.ExpiresAt.After(response.CreatedAt) {
+		return stored, false, nil
+	}
+	f.records[id] = response
+	return response, true, nil
+}
+
+func (f *fakeIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, response IdempotentResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records[response.PrincipalID+"/"+response.Key] = response
+	return nil
+}
+
+func (f *fakeIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.records, principalID+"/"+key)
+	return nil
+}
+
 func parseLogEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
 	t.Helper()
 	raw := strings.TrimSpace(buf.String())
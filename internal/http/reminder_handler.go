@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type reminderService interface {
+	GetReminderPreferences(ctx context.Context, principal application.Principal, userID string) (application.ReminderPreferences, error)
+	SetReminderPreferences(ctx context.Context, params application.SetReminderPreferencesParams) (application.ReminderPreferences, error)
+}
+
+// ReminderHandler serves per-user reminder defaults nested under /users/{id}/reminders.
+type ReminderHandler struct {
+	service   reminderService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewReminderHandler(service reminderService, logger *slog.Logger) *ReminderHandler {
+	base := defaultLogger(logger)
+	return &ReminderHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *ReminderHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "ReminderHandler", operation, attrs...)
+}
+
+func (h *ReminderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Get", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for reminder preferences")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "user_id", userID)
+
+	prefs, err := h.service.GetReminderPreferences(r.Context(), principal, userID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "reminder preferences lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "reminder preferences fetched")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, toReminderPreferencesDTO(prefs, displayLocation(principal)))
+}
+
+func (h *ReminderHandler) Set(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Set", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for reminder preferences")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req reminderPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Set", "principal_id", principal.UserID, "user_id", userID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode reminder preferences", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Set", "principal_id", principal.UserID, "user_id", userID)
+
+	prefs, err := h.service.SetReminderPreferences(r.Context(), application.SetReminderPreferencesParams{
+		Principal:     principal,
+		UserID:        userID,
+		MinutesBefore: req.MinutesBefore,
+		Channels:      req.Channels,
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "reminder preferences update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "reminder preferences updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, toReminderPreferencesDTO(prefs, displayLocation(principal)))
+}
+
+type reminderPreferencesRequest struct {
+	MinutesBefore []int    `json:"minutes_before"`
+	Channels      []string `json:"channels"`
+}
+
+type reminderPreferencesDTO struct {
+	UserID        string   `json:"user_id"`
+	MinutesBefore []int    `json:"minutes_before"`
+	Channels      []string `json:"channels"`
+	UpdatedAt     string   `json:"updated_at,omitempty"`
+}
+
+func toReminderPreferencesDTO(prefs application.ReminderPreferences, loc *time.Location) reminderPreferencesDTO {
+	dto := reminderPreferencesDTO{
+		UserID:        prefs.UserID,
+		MinutesBefore: append([]int{}, prefs.MinutesBefore...),
+		Channels:      append([]string{}, prefs.Channels...),
+	}
+	if !prefs.UpdatedAt.IsZero() {
+		dto.UpdatedAt = formatInLocation(prefs.UpdatedAt, loc)
+	}
+	return dto
+}
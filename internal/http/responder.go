@@ -12,11 +12,25 @@ import (
 )
 
 var (
-	errBadRequestBody      = errors.New("無効なリクエスト形式です。")
-	errInvalidScheduleID   = errors.New("無効なスケジュール ID です。")
-	errInvalidUserID       = errors.New("無効なユーザー ID です。")
-	errInvalidRoomID       = errors.New("無効な会議室 ID です。")
-	errMissingSessionToken = errors.New("認証トークンを指定してください")
+	errBadRequestBody        = errors.New("無効なリクエスト形式です。")
+	errInvalidScheduleID     = errors.New("無効なスケジュール ID です。")
+	errInvalidUserID         = errors.New("無効なユーザー ID です。")
+	errInvalidRoomID         = errors.New("無効な会議室 ID です。")
+	errMissingSessionToken   = errors.New("認証トークンを指定してください")
+	errInvalidLimit          = errors.New("limit には整数を指定してください。")
+	errInvalidOutOfOfficeID  = errors.New("無効な不在期間 ID です。")
+	errInvalidYear           = errors.New("year には整数を指定してください。")
+	errInvalidHolidayDate    = errors.New("日付は YYYY-MM-DD 形式で指定してください。")
+	errInvalidLastEventID    = errors.New("Last-Event-ID には 0 以上の整数を指定してください。")
+	errServiceShuttingDown   = errors.New("サーバーを停止しています。再接続してください。")
+	errInvalidRevision       = errors.New("リビジョン番号には 1 以上の整数を指定してください。")
+	errInvalidCompareTo      = errors.New("compare_to には 1 以上の整数を指定してください。")
+	errIfMatchRequired       = errors.New("If-Match ヘッダーに取得時の ETag を指定してください。")
+	errInvalidIdempotencyKey = errors.New("Idempotency-Key には 1〜255 文字の値を 1 つだけ指定してください。")
+	errIdempotencyKeyReused  = errors.New("この Idempotency-Key は別の内容のリクエストで使用されています。")
+	errIdempotencyKeyInUse   = errors.New("同じ Idempotency-Key のリクエストを処理中です。しばらくしてから再試行してください。")
+	errRequestBodyTooLarge   = errors.New("リクエストの本文が大きすぎます。")
+	errInvalidUserImport     = errors.New("CSV のヘッダーに email, department, manager_email, location の列を含めてください。")
 )
 
 type responder struct {
@@ -68,44 +82,61 @@ func (r responder) handleServiceError(ctx context.Context, w http.ResponseWriter
 	}
 
 	logDetails := []any{"error", err, "error_code", errorCode(err)}
+	status, payload := serviceErrorResponse(err)
 
+	switch status {
+	case http.StatusForbidden:
+		logger.WarnContext(ctx, "unauthorized access", logDetails...)
+	case http.StatusNotFound:
+		logger.WarnContext(ctx, "resource not found", logDetails...)
+	case http.StatusConflict:
+		logger.InfoContext(ctx, "resource conflict", logDetails...)
+	case http.StatusPreconditionFailed:
+		logger.InfoContext(ctx, "precondition failed", logDetails...)
+	case http.StatusUnprocessableEntity:
+		logger.InfoContext(ctx, "validation failed", logDetails...)
+	default:
+		logger.ErrorContext(ctx, "internal server error", logDetails...)
+	}
+	r.writeJSON(ctx, w, status, payload)
+}
+
+// serviceErrorResponse maps an application error to its HTTP status and body.
+func serviceErrorResponse(err error) (int, errorResponse) {
 	switch {
 	case errors.Is(err, application.ErrUnauthorized):
-		logger.WarnContext(ctx, "unauthorized access", logDetails...)
-		r.writeJSON(ctx, w, http.StatusForbidden, errorResponse{
+		return http.StatusForbidden, errorResponse{
 			ErrorCode: "AUTH_FORBIDDEN",
 			Message:   "この操作を実行する権限がありません。",
-		})
+		}
 	case errors.Is(err, application.ErrNotFound):
-		logger.WarnContext(ctx, "resource not found", logDetails...)
-		r.writeJSON(ctx, w, http.StatusNotFound, errorResponse{
+		return http.StatusNotFound, errorResponse{
 			ErrorCode: "RESOURCE_NOT_FOUND",
 			Message:   "指定されたリソースが見つかりません。",
-		})
+		}
 	case errors.Is(err, application.ErrAlreadyExists):
-		logger.InfoContext(ctx, "resource conflict", logDetails...)
-		r.writeJSON(ctx, w, http.StatusConflict, errorResponse{
+		return http.StatusConflict, errorResponse{
 			ErrorCode: "RESOURCE_CONFLICT",
 			Message:   "指定されたリソースは既に存在します。",
-		})
-	default:
-		var vErr *application.ValidationError
-		if errors.As(err, &vErr) {
-			logger.InfoContext(ctx, "validation failed", logDetails...)
-			details := localizeValidationErrors(vErr)
-			r.writeJSON(ctx, w, http.StatusUnprocessableEntity, errorResponse{
-				ErrorCode: "VALIDATION_FAILED",
-				Message:   "入力内容に誤りがあります。",
-				Errors:    details,
-			})
-			return
 		}
+	case errors.Is(err, application.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed, errorResponse{
+			ErrorCode: "PRECONDITION_FAILED",
+			Message:   localizedStatusMessage(http.StatusPreconditionFailed),
+		}
+	}
 
-		logger.ErrorContext(ctx, "internal server error", logDetails...)
-		r.writeJSON(ctx, w, http.StatusInternalServerError, errorResponse{
-			ErrorCode: "INTERNAL_SERVER_ERROR",
-			Message:   "サーバー内部でエラーが発生しました。",
-		})
+	var vErr *application.ValidationError
+	if errors.As(err, &vErr) {
+		return http.StatusUnprocessableEntity, errorResponse{
+			ErrorCode: "VALIDATION_FAILED",
+			Message:   "入力内容に誤りがあります。",
+			Errors:    localizeValidationErrors(vErr),
+		}
+	}
+	return http.StatusInternalServerError, errorResponse{
+		ErrorCode: "INTERNAL_SERVER_ERROR",
+		Message:   "サーバー内部でエラーが発生しました。",
 	}
 }
 
@@ -128,8 +159,12 @@ func localizedStatusMessage(status int) string {
 		return "指定されたリソースが見つかりません。"
 	case http.StatusConflict:
 		return "要求はリソースの現在の状態と競合しています。"
+	case http.StatusPreconditionFailed:
+		return "ほかの操作で更新されています。最新の内容を取得してからやり直してください。"
 	case http.StatusUnprocessableEntity:
 		return "入力内容に誤りがあります。"
+	case http.StatusPreconditionRequired:
+		return "条件付きリクエストが必要です。"
 	default:
 		return "サーバー内部でエラーが発生しました。"
 	}
@@ -165,14 +200,14 @@ func translateValidationMessage(message string) string {
 		return "タイトルは必須です。"
 	case "start is required":
 		return "開始日時は必須です。"
-	case "start must be in Asia/Tokyo (JST)":
-		return "開始日時は日本標準時で指定してください。"
 	case "end is required":
 		return "終了日時は必須です。"
-	case "end must be in Asia/Tokyo (JST)":
-		return "終了日時は日本標準時で指定してください。"
+	case "time zone is invalid":
+		return "タイムゾーンは IANA 形式（例: Asia/Tokyo）で指定してください。"
 	case "start must be before end":
 		return "終了日時は開始日時より後である必要があります。"
+	case "range must not exceed 366 days":
+		return "期間は 366 日以内で指定してください。"
 	case "must be a valid URL":
 		return "有効な URL を指定してください。"
 	case "at least one participant is required":
@@ -181,10 +216,109 @@ func translateValidationMessage(message string) string {
 		return "作成者は変更できません。"
 	case "room does not exist":
 		return "指定された会議室は存在しません。"
+	case "limit must be between 1 and 500":
+		return "limit は 1 から 500 の範囲で指定してください。"
+	case "cursor is invalid":
+		return "カーソルの形式が不正です。"
+	case "sync token is invalid":
+		return "同期トークンの形式が不正です。"
+	case "sync token does not match participants":
+		return "同期トークンと参加者の指定が一致しません。最初から同期し直してください。"
+	case "sync token is unknown":
+		return "同期トークンが見つかりません。最初から同期し直してください。"
+	case "weekday is invalid":
+		return "曜日の指定が不正です。"
+	case "working hours must start before they end within a day":
+		return "勤務時間は同じ日のうちに開始時刻が終了時刻より前になるよう指定してください。"
+	case "working hours must not overlap":
+		return "勤務時間帯が重複しています。"
+	case "holiday policy is invalid":
+		return "祝日の扱いは skip または next_business_day で指定してください。"
+	case "year must be between 1980 and 2099":
+		return "year は 1980 から 2099 の範囲で指定してください。"
+	case "date is required":
+		return "日付は必須です。"
+	case "holiday name is required":
+		return "休日名は必須です。"
+	case "at most 5 reminders are allowed":
+		return "リマインダーは 5 件まで設定できます。"
+	case "reminder must be between 0 and 10080 minutes before start":
+		return "リマインダーは開始の 0 分前から 10080 分前までの範囲で指定してください。"
+	case "reminder channel is invalid":
+		return "通知チャネルは email または webhook で指定してください。"
+	case "url is required":
+		return "URL は必須です。"
+	case "url must be an absolute http or https URL":
+		return "URL は http または https の絶対 URL で指定してください。"
+	case "event type is invalid":
+		return "イベント種別が不正です。"
+	case "app password name is required":
+		return "アプリパスワードの名前は必須です。"
+	case "app password name must be at most 100 characters":
+		return "アプリパスワードの名前は 100 文字以内で指定してください。"
+	case "revision does not exist":
+		return "指定されたリビジョンは存在しません。"
+	case "conflict policy is invalid":
+		return "競合時の扱いは remote_wins / local_wins / latest で指定してください。"
+	case "mode must be all_or_nothing or best_effort":
+		return "mode は all_or_nothing または best_effort で指定してください。"
+	case "operations must include between 1 and 500 entries":
+		return "operations には 1〜500 件の操作を指定してください。"
+	case "op must be create, update or delete":
+		return "op は create / update / delete で指定してください。"
+	case "schedule_id is required for update and delete":
+		return "update と delete には schedule_id が必要です。"
+	case "view must be day, week or month":
+		return "ビューは day / week / month で指定してください。"
+	case "group name is required":
+		return "グループ名は必須です。"
+	case "group name must be at most 100 characters":
+		return "グループ名は 100 文字以内で指定してください。"
+	case "at least one member is required":
+		return "メンバーを 1 名以上指定してください。"
+	case "group does not exist":
+		return "指定されたグループは存在しません。"
+	case "calendar name is required":
+		return "カレンダー名は必須です。"
+	case "calendar name must be at most 100 characters":
+		return "カレンダー名は 100 文字以内で指定してください。"
+	case "calendar does not exist":
+		return "指定されたカレンダーは存在しません。"
+	case "user group name is required":
+		return "ユーザーグループ名は必須です。"
+	case "user group name must be at most 100 characters":
+		return "ユーザーグループ名は 100 文字以内で指定してください。"
+	case "user group does not exist":
+		return "指定されたユーザーグループは存在しません。"
+	case "participant group mode must be expand or live":
+		return "グループの招待方法は expand または live で指定してください。"
+	case "department name is required":
+		return "部署名は必須です。"
+	case "department name must be at most 100 characters":
+		return "部署名は 100 文字以内で指定してください。"
+	case "department does not exist":
+		return "指定された部署は存在しません。"
+	case "parent department does not exist":
+		return "指定された上位部署は存在しません。"
+	case "a department cannot be nested under itself":
+		return "部署を自身またはその配下の部署の下に置くことはできません。"
+	case "manager does not exist":
+		return "指定された上長は存在しません。"
+	case "a user cannot report to themselves or their own reports":
+		return "自分自身または自分の部下を上長に指定することはできません。"
+	case "location must be at most 100 characters":
+		return "勤務地は 100 文字以内で指定してください。"
+	case "email appears more than once":
+		return "同じメールアドレスが複数行に含まれています。"
+	case "rows must include between 1 and 5000 entries":
+		return "1〜5000 件のユーザーを指定してください。"
 	default:
 		if strings.HasPrefix(message, "unknown user ids:") {
 			return "存在しないユーザー ID が含まれています: " + strings.TrimSpace(strings.TrimPrefix(message, "unknown user ids:"))
 		}
+		if strings.HasPrefix(message, "unknown user group ids:") {
+			return "存在しないユーザーグループ ID が含まれています: " + strings.TrimSpace(strings.TrimPrefix(message, "unknown user group ids:"))
+		}
 		return message
 	}
 }
@@ -206,6 +340,8 @@ func errorCode(err error) string {
 		return "RESOURCE_NOT_FOUND"
 	case errors.Is(err, application.ErrAlreadyExists):
 		return "RESOURCE_CONFLICT"
+	case errors.Is(err, application.ErrPreconditionFailed):
+		return "PRECONDITION_FAILED"
 	case errors.Is(err, application.ErrInvalidCredentials):
 		return "AUTH_INVALID_CREDENTIALS"
 	case errors.Is(err, application.ErrSessionExpired):
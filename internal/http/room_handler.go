@@ -14,8 +14,10 @@ import (
 type roomService interface {
 	CreateRoom(ctx context.Context, params application.CreateRoomParams) (application.Room, error)
 	UpdateRoom(ctx context.Context, params application.UpdateRoomParams) (application.Room, error)
+	GetRoom(ctx context.Context, principal application.Principal, roomID string) (application.Room, error)
 	DeleteRoom(ctx context.Context, principal application.Principal, roomID string) error
-	ListRooms(ctx context.Context, principal application.Principal) ([]application.Room, error)
+	RestoreRoom(ctx context.Context, principal application.Principal, roomID string) (application.Room, error)
+	ListRoomsPage(ctx context.Context, params application.ListRoomsParams) (application.RoomPage, error)
 }
 
 type RoomHandler struct {
@@ -64,6 +66,7 @@ func (h *RoomHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.With("room_id", room.ID).InfoContext(r.Context(), "room created")
+	setVersionETag(w, room.Version)
 	h.responder.writeJSON(r.Context(), w, http.StatusCreated, roomResponse{Room: toRoomDTO(room)})
 }
 
@@ -81,6 +84,11 @@ func (h *RoomHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	principal, _ := PrincipalFromContext(r.Context())
+	ifMatch, ok := requireIfMatch(w, r, h.responder)
+	if !ok {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "room_id", roomID, "error_kind", "precondition_required").ErrorContext(r.Context(), "missing If-Match for room update")
+		return
+	}
 
 	var req roomRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -95,6 +103,7 @@ func (h *RoomHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Principal: principal,
 		RoomID:    roomID,
 		Input:     req.toInput(),
+		IfMatch:   ifMatch,
 	})
 	if err != nil {
 		logger.ErrorContext(r.Context(), "room update failed", "error", err, "error_kind", application.ErrorKind(err))
@@ -103,6 +112,37 @@ func (h *RoomHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.InfoContext(r.Context(), "room updated")
+	setVersionETag(w, room.Version)
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, roomResponse{Room: toRoomDTO(room)})
+}
+
+// Get serves GET /rooms/{id} with the room's ETag, which a later PUT
+// must send back in If-Match.
+func (h *RoomHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	roomID, ok := RoomIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(roomID) == "" {
+		h.log(r.Context(), "Get", "error_kind", "bad_request").ErrorContext(r.Context(), "missing room id for get")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidRoomID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "room_id", roomID)
+
+	room, err := h.service.GetRoom(r.Context(), principal, roomID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "room lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "room retrieved")
+	setVersionETag(w, room.Version)
 	h.responder.writeJSON(r.Context(), w, http.StatusOK, roomResponse{Room: toRoomDTO(room)})
 }
 
@@ -131,6 +171,34 @@ func (h *RoomHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
 }
 
+func (h *RoomHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	roomID, ok := RoomIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(roomID) == "" {
+		h.log(r.Context(), "Restore", "error_kind", "bad_request").ErrorContext(r.Context(), "missing room id for restore")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidRoomID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Restore", "principal_id", principal.UserID, "room_id", roomID)
+
+	room, err := h.service.RestoreRoom(r.Context(), principal, roomID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "room restore failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "room restored")
+	setVersionETag(w, room.Version)
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, roomResponse{Room: toRoomDTO(room)})
+}
+
 func (h *RoomHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.service == nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -143,16 +211,27 @@ func (h *RoomHandler) List(w http.ResponseWriter, r *http.Request) {
 		h.responder.writeError(r.Context(), w, http.StatusUnauthorized, errMissingSessionToken)
 		return
 	}
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		h.log(r.Context(), "List", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+
 	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
-	rooms, err := h.service.ListRooms(r.Context(), principal)
+	page, err := h.service.ListRoomsPage(r.Context(), application.ListRoomsParams{
+		Principal: principal,
+		Limit:     limit,
+		Cursor:    strings.TrimSpace(r.URL.Query().Get("cursor")),
+	})
 	if err != nil {
 		logger.ErrorContext(r.Context(), "room list failed", "error", err, "error_kind", application.ErrorKind(err))
 		h.responder.handleServiceError(r.Context(), w, err)
 		return
 	}
 
-	logger.With("result_count", len(rooms)).InfoContext(r.Context(), "rooms listed")
-	h.responder.writeJSON(r.Context(), w, http.StatusOK, listRoomsResponse{Rooms: toRoomDTOs(rooms)})
+	logger.With("result_count", len(page.Rooms)).InfoContext(r.Context(), "rooms listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listRoomsResponse{Rooms: toRoomDTOs(page.Rooms), NextCursor: page.NextCursor})
 }
 
 type roomRequest struct {
@@ -181,7 +260,8 @@ type roomResponse struct {
 }
 
 type listRoomsResponse struct {
-	Rooms []roomDTO `json:"rooms"`
+	Rooms      []roomDTO `json:"rooms"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
 
 type roomDTO struct {
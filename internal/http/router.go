@@ -6,11 +6,26 @@ import (
 )
 
 type RouterConfig struct {
-	Auth       *AuthHandler
-	Users      *UserHandler
-	Rooms      *RoomHandler
-	Schedules  *ScheduleHandler
-	Middleware []func(http.Handler) http.Handler
+	Auth         *AuthHandler
+	Users        *UserHandler
+	Rooms        *RoomHandler
+	Schedules    *ScheduleHandler
+	Availability *AvailabilityHandler
+	Holidays     *HolidayHandler
+	Reminders    *ReminderHandler
+	Webhooks     *WebhookHandler
+	Events       *EventStreamHandler
+	AppPasswords *AppPasswordHandler
+	CalDAV       *CalDAVHandler
+	CalDAVSyncs  *CalDAVSyncHandler
+	Trash        *TrashHandler
+	Views        *CalendarViewHandler
+	Groups       *ColleagueGroupHandler
+	Links        *ViewLinkHandler
+	Calendars    *CalendarHandler
+	UserGroups   *UserGroupHandler
+	Departments  *DepartmentHandler
+	Middleware   []func(http.Handler) http.Handler
 }
 
 func NewRouter(cfg RouterConfig) http.Handler {
@@ -56,21 +71,52 @@ func NewRouter(cfg RouterConfig) http.Handler {
 				methodNotAllowed(w, http.MethodGet, http.MethodPost)
 			}
 		})
+		mux.HandleFunc("/schedules:batch", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				methodNotAllowed(w, http.MethodPost)
+				return
+			}
+			cfg.Schedules.Batch(w, r)
+		})
+		mux.HandleFunc("/occurrences", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Schedules.Occurrences(w, r)
+		})
+		mux.HandleFunc("/schedules/changes", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Schedules.Changes(w, r)
+		})
 		mux.HandleFunc("/schedules/", func(w http.ResponseWriter, r *http.Request) {
-			id := strings.TrimPrefix(r.URL.Path, "/schedules/")
+			id, rest, nested := strings.Cut(strings.TrimPrefix(r.URL.Path, "/schedules/"), "/")
 			if id == "" {
 				http.NotFound(w, r)
 				return
 			}
 			ctx := ContextWithScheduleID(r.Context(), id)
 			r = r.WithContext(ctx)
+			if nested {
+				if rest == "revisions" || strings.HasPrefix(rest, "revisions/") {
+					routeScheduleRevisions(w, r, cfg.Schedules, strings.TrimPrefix(strings.TrimPrefix(rest, "revisions"), "/"))
+					return
+				}
+				routeRestore(w, r, rest, cfg.Schedules.Restore)
+				return
+			}
 			switch r.Method {
+			case http.MethodGet:
+				cfg.Schedules.Get(w, r)
 			case http.MethodPut:
 				cfg.Schedules.Update(w, r)
 			case http.MethodDelete:
 				cfg.Schedules.Delete(w, r)
 			default:
-				methodNotAllowed(w, http.MethodPut, http.MethodDelete)
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
 			}
 		})
 	}
@@ -86,21 +132,46 @@ func NewRouter(cfg RouterConfig) http.Handler {
 				methodNotAllowed(w, http.MethodGet, http.MethodPost)
 			}
 		})
+		mux.HandleFunc("/users:import", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				methodNotAllowed(w, http.MethodPost)
+				return
+			}
+			cfg.Users.Import(w, r)
+		})
 		mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
-			id := strings.TrimPrefix(r.URL.Path, "/users/")
+			id, rest, nested := strings.Cut(strings.TrimPrefix(r.URL.Path, "/users/"), "/")
 			if id == "" {
 				http.NotFound(w, r)
 				return
 			}
 			ctx := ContextWithUserID(r.Context(), id)
 			r = r.WithContext(ctx)
+			if nested && rest == "reminders" {
+				routeUserReminders(w, r, cfg.Reminders)
+				return
+			}
+			if nested && (rest == "app-passwords" || strings.HasPrefix(rest, "app-passwords/")) {
+				routeUserAppPasswords(w, r, cfg.AppPasswords, strings.TrimPrefix(rest, "app-passwords"))
+				return
+			}
+			if nested && (rest == "calendar-syncs" || strings.HasPrefix(rest, "calendar-syncs/")) {
+				routeUserCalendarSyncs(w, r, cfg.CalDAVSyncs, strings.TrimPrefix(rest, "calendar-syncs"))
+				return
+			}
+			if nested {
+				routeUserAvailability(w, r, cfg.Availability, rest)
+				return
+			}
 			switch r.Method {
+			case http.MethodGet:
+				cfg.Users.Get(w, r)
 			case http.MethodPut:
 				cfg.Users.Update(w, r)
 			case http.MethodDelete:
 				cfg.Users.Delete(w, r)
 			default:
-				methodNotAllowed(w, http.MethodPut, http.MethodDelete)
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
 			}
 		})
 	}
@@ -117,24 +188,255 @@ func NewRouter(cfg RouterConfig) http.Handler {
 			}
 		})
 		mux.HandleFunc("/rooms/", func(w http.ResponseWriter, r *http.Request) {
-			id := strings.TrimPrefix(r.URL.Path, "/rooms/")
+			id, rest, nested := strings.Cut(strings.TrimPrefix(r.URL.Path, "/rooms/"), "/")
 			if id == "" {
 				http.NotFound(w, r)
 				return
 			}
 			ctx := ContextWithRoomID(r.Context(), id)
 			r = r.WithContext(ctx)
+			if nested {
+				routeRestore(w, r, rest, cfg.Rooms.Restore)
+				return
+			}
 			switch r.Method {
+			case http.MethodGet:
+				cfg.Rooms.Get(w, r)
 			case http.MethodPut:
 				cfg.Rooms.Update(w, r)
 			case http.MethodDelete:
 				cfg.Rooms.Delete(w, r)
 			default:
-				methodNotAllowed(w, http.MethodPut, http.MethodDelete)
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+			}
+		})
+	}
+
+	if cfg.Holidays != nil {
+		mux.HandleFunc("/holidays", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Holidays.List(w, r)
+			case http.MethodPost:
+				cfg.Holidays.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/holidays/", func(w http.ResponseWriter, r *http.Request) {
+			date := strings.TrimPrefix(r.URL.Path, "/holidays/")
+			if date == "" {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodDelete {
+				methodNotAllowed(w, http.MethodDelete)
+				return
+			}
+			cfg.Holidays.Delete(w, r, date)
+		})
+	}
+
+	if cfg.Webhooks != nil {
+		mux.HandleFunc("/webhooks", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Webhooks.List(w, r)
+			case http.MethodPost:
+				cfg.Webhooks.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/webhooks/", func(w http.ResponseWriter, r *http.Request) {
+			routeWebhook(w, r, cfg.Webhooks, strings.TrimPrefix(r.URL.Path, "/webhooks/"))
+		})
+	}
+
+	if cfg.Events != nil {
+		mux.HandleFunc("/events/stream", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Events.Stream(w, r)
+		})
+	}
+
+	if cfg.Trash != nil {
+		mux.HandleFunc("/trash", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Trash.List(w, r)
+		})
+	}
+
+	if cfg.Views != nil {
+		mux.HandleFunc("/views/", func(w http.ResponseWriter, r *http.Request) {
+			period := strings.TrimPrefix(r.URL.Path, "/views/")
+			if period != "day" && period != "week" && period != "month" {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Views.Get(w, r, period)
+		})
+	}
+
+	if cfg.Groups != nil {
+		mux.HandleFunc("/colleague-groups", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Groups.List(w, r)
+			case http.MethodPost:
+				cfg.Groups.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/colleague-groups/", func(w http.ResponseWriter, r *http.Request) {
+			groupID := strings.TrimPrefix(r.URL.Path, "/colleague-groups/")
+			if groupID == "" || strings.Contains(groupID, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Groups.Get(w, r, groupID)
+			case http.MethodPut:
+				cfg.Groups.Update(w, r, groupID)
+			case http.MethodDelete:
+				cfg.Groups.Delete(w, r, groupID)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+			}
+		})
+	}
+
+	if cfg.Links != nil {
+		mux.HandleFunc("/links", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				methodNotAllowed(w, http.MethodPost)
+				return
+			}
+			cfg.Links.Create(w, r)
+		})
+		mux.HandleFunc("/links/", func(w http.ResponseWriter, r *http.Request) {
+			code := strings.TrimPrefix(r.URL.Path, "/links/")
+			if code == "" || strings.Contains(code, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, http.MethodGet)
+				return
+			}
+			cfg.Links.Get(w, r, code)
+		})
+	}
+
+	if cfg.Calendars != nil {
+		mux.HandleFunc("/calendars", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Calendars.List(w, r)
+			case http.MethodPost:
+				cfg.Calendars.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/calendars/", func(w http.ResponseWriter, r *http.Request) {
+			calendarID := strings.TrimPrefix(r.URL.Path, "/calendars/")
+			if calendarID == "" || strings.Contains(calendarID, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Calendars.Get(w, r, calendarID)
+			case http.MethodPut:
+				cfg.Calendars.Update(w, r, calendarID)
+			case http.MethodDelete:
+				cfg.Calendars.Delete(w, r, calendarID)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+			}
+		})
+	}
+
+	if cfg.UserGroups != nil {
+		mux.HandleFunc("/user-groups", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.UserGroups.List(w, r)
+			case http.MethodPost:
+				cfg.UserGroups.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/user-groups/", func(w http.ResponseWriter, r *http.Request) {
+			groupID := strings.TrimPrefix(r.URL.Path, "/user-groups/")
+			if groupID == "" || strings.Contains(groupID, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				cfg.UserGroups.Get(w, r, groupID)
+			case http.MethodPut:
+				cfg.UserGroups.Update(w, r, groupID)
+			case http.MethodDelete:
+				cfg.UserGroups.Delete(w, r, groupID)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+			}
+		})
+	}
+
+	if cfg.Departments != nil {
+		mux.HandleFunc("/departments", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Departments.List(w, r)
+			case http.MethodPost:
+				cfg.Departments.Create(w, r)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPost)
+			}
+		})
+		mux.HandleFunc("/departments/", func(w http.ResponseWriter, r *http.Request) {
+			departmentID := strings.TrimPrefix(r.URL.Path, "/departments/")
+			if departmentID == "" || strings.Contains(departmentID, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				cfg.Departments.Get(w, r, departmentID)
+			case http.MethodPut:
+				cfg.Departments.Update(w, r, departmentID)
+			case http.MethodDelete:
+				cfg.Departments.Delete(w, r, departmentID)
+			default:
+				methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
 			}
 		})
 	}
 
+	if cfg.CalDAV != nil {
+		mux.HandleFunc(CalDAVWellKnownPath, cfg.CalDAV.WellKnown)
+		mux.HandleFunc(CalDAVPrefix, func(w http.ResponseWriter, r *http.Request) {
+			routeCalDAV(w, r, cfg.CalDAV, strings.TrimPrefix(r.URL.Path, CalDAVPrefix))
+		})
+	}
+
 	var handler http.Handler = mux
 	if len(cfg.Middleware) > 0 {
 		for i := len(cfg.Middleware) - 1; i >= 0; i-- {
@@ -147,6 +449,271 @@ func NewRouter(cfg RouterConfig) http.Handler {
 	return handler
 }
 
+// routeRestore dispatches the restore action nested under a trashable resource.
+func routeRestore(w http.ResponseWriter, r *http.Request, rest string, restore http.HandlerFunc) {
+	if rest != "restore" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	restore(w, r)
+}
+
+// routeScheduleRevisions dispatches the revision resources nested under
+// /schedules/{id}/revisions: the list, {n} and {n}/revert.
+func routeScheduleRevisions(w http.ResponseWriter, r *http.Request, h *ScheduleHandler, rest string) {
+	revision, action, hasAction := strings.Cut(rest, "/")
+	switch {
+	case revision == "":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		h.Revisions(w, r)
+	case !hasAction:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		h.Revision(w, r, revision)
+	case action == "revert":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		h.Revert(w, r, revision)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// routeUserAvailability dispatches the availability resources nested under
+// /users/{id}: working-hours and out-of-office[/{entryID}].
+func routeUserAvailability(w http.ResponseWriter, r *http.Request, h *AvailabilityHandler, rest string) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	resource, entryID, hasEntry := strings.Cut(rest, "/")
+	switch {
+	case resource == "working-hours" && !hasEntry:
+		switch r.Method {
+		case http.MethodGet:
+			h.GetWorkingHours(w, r)
+		case http.MethodPut:
+			h.SetWorkingHours(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPut)
+		}
+	case resource == "out-of-office" && !hasEntry:
+		switch r.Method {
+		case http.MethodGet:
+			h.ListOutOfOffice(w, r)
+		case http.MethodPost:
+			h.CreateOutOfOffice(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+	case resource == "out-of-office" && entryID != "" && !strings.Contains(entryID, "/"):
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, http.MethodDelete)
+			return
+		}
+		h.DeleteOutOfOffice(w, r, entryID)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// routeUserReminders dispatches /users/{id}/reminders.
+func routeUserReminders(w http.ResponseWriter, r *http.Request, h *ReminderHandler) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.Get(w, r)
+	case http.MethodPut:
+		h.Set(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPut)
+	}
+}
+
+// routeUserAppPasswords dispatches /users/{id}/app-passwords and
+// /users/{id}/app-passwords/{passwordID}; rest is the path after "app-passwords".
+func routeUserAppPasswords(w http.ResponseWriter, r *http.Request, h *AppPasswordHandler, rest string) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+		return
+	}
+	passwordID := strings.TrimPrefix(rest, "/")
+	if passwordID == "" || strings.Contains(passwordID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		methodNotAllowed(w, http.MethodDelete)
+		return
+	}
+	h.Delete(w, r, passwordID)
+}
+
+// routeUserCalendarSyncs dispatches /users/{id}/calendar-syncs,
+// /users/{id}/calendar-syncs/{syncID} and /users/{id}/calendar-syncs/{syncID}/runs;
+// rest is the path after "calendar-syncs".
+func routeUserCalendarSyncs(w http.ResponseWriter, r *http.Request, h *CalDAVSyncHandler, rest string) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+		return
+	}
+	syncID, sub, hasSub := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+	switch {
+	case syncID == "":
+		http.NotFound(w, r)
+	case !hasSub:
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, http.MethodDelete)
+			return
+		}
+		h.Delete(w, r, syncID)
+	case sub == "runs":
+		switch r.Method {
+		case http.MethodGet:
+			h.ListRuns(w, r, syncID)
+		case http.MethodPost:
+			h.SyncNow(w, r, syncID)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// routeCalDAV dispatches the CalDAV tree: the root, principals/{user},
+// calendars/{user}, calendars/{user}/{calendar} and
+// calendars/{user}/{calendar}/{resource}.ics.
+func routeCalDAV(w http.ResponseWriter, r *http.Request, h *CalDAVHandler, path string) {
+	if r.Method == http.MethodOptions {
+		h.Options(w, r)
+		return
+	}
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for _, part := range parts[1:] {
+		if part == "" {
+			http.NotFound(w, r)
+			return
+		}
+	}
+	switch {
+	case path == "":
+		if r.Method != methodPropfind {
+			methodNotAllowed(w, http.MethodOptions, methodPropfind)
+			return
+		}
+		h.PropfindRoot(w, r)
+	case parts[0] == "principals" && len(parts) == 2:
+		if r.Method != methodPropfind {
+			methodNotAllowed(w, http.MethodOptions, methodPropfind)
+			return
+		}
+		h.PropfindPrincipal(w, r, parts[1])
+	case parts[0] == "calendars" && len(parts) == 2:
+		if r.Method != methodPropfind {
+			methodNotAllowed(w, http.MethodOptions, methodPropfind)
+			return
+		}
+		h.PropfindHome(w, r, parts[1])
+	case parts[0] == "calendars" && len(parts) == 3:
+		switch r.Method {
+		case methodPropfind:
+			h.PropfindCalendar(w, r, parts[1], parts[2])
+		case methodReport:
+			h.Report(w, r, parts[1], parts[2])
+		default:
+			methodNotAllowed(w, http.MethodOptions, methodPropfind, methodReport)
+		}
+	case parts[0] == "calendars" && len(parts) == 4:
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			h.Get(w, r, parts[1], parts[2], parts[3])
+		case http.MethodPut:
+			h.Put(w, r, parts[1], parts[2], parts[3])
+		case http.MethodDelete:
+			h.Delete(w, r, parts[1], parts[2], parts[3])
+		case methodPropfind:
+			h.PropfindResource(w, r, parts[1], parts[2], parts[3])
+		default:
+			methodNotAllowed(w, http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, methodPropfind)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// routeWebhook dispatches /webhooks/{id}, /webhooks/{id}/deliveries and
+// /webhooks/{id}/deliveries/{deliveryID}/redeliver.
+func routeWebhook(w http.ResponseWriter, r *http.Request, h *WebhookHandler, path string) {
+	parts := strings.Split(path, "/")
+	if parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+	webhookID := parts[0]
+	switch {
+	case len(parts) == 1:
+		switch r.Method {
+		case http.MethodPut:
+			h.Update(w, r, webhookID)
+		case http.MethodDelete:
+			h.Delete(w, r, webhookID)
+		default:
+			methodNotAllowed(w, http.MethodPut, http.MethodDelete)
+		}
+	case len(parts) == 2 && parts[1] == "deliveries":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		h.ListDeliveries(w, r, webhookID)
+	case len(parts) == 4 && parts[1] == "deliveries" && parts[2] != "" && parts[3] == "redeliver":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		h.Redeliver(w, r, webhookID, parts[2])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
 func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
 	if len(allowed) > 0 {
 		w.Header().Set("Allow", strings.Join(allowed, ", "))
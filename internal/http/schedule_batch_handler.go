@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+// Batch serves POST /schedules:batch, which applies a list of schedule creates,
+// updates and deletes either all-or-nothing or best-effort. Each operation
+// goes through the same service call as its single-schedule endpoint.
+func (h *ScheduleHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req scheduleBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Batch", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode schedule batch", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+	// Updates need If-Match exactly as PUT /schedules/{id} does
+	for _, op := range req.Operations {
+		if strings.TrimSpace(op.Op) == string(application.ScheduleBatchUpdate) && strings.TrimSpace(op.IfMatch) == "" {
+			h.log(r.Context(), "Batch", "principal_id", principal.UserID, "error_kind", "precondition_required").ErrorContext(r.Context(), "missing if_match for batch update", "schedule_id", op.ScheduleID)
+			h.responder.writeError(r.Context(), w, http.StatusPreconditionRequired, errIfMatchRequired)
+			return
+		}
+	}
+
+	logger := h.log(r.Context(), "Batch", "principal_id", principal.UserID, "operation_count", len(req.Operations))
+
+	batch, err := h.service.ApplyScheduleBatch(r.Context(), req.toParams(principal))
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule batch failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	status := http.StatusOK
+	loc := displayLocation(principal)
+	response := scheduleBatchResponse{Mode: string(batch.Mode), Committed: batch.Committed, Results: make([]scheduleBatchResultDTO, 0, len(batch.Results))}
+	for i, result := range batch.Results {
+		dto := toScheduleBatchResultDTO(i, result, loc)
+		// A rolled back batch answers with the status of the operation that failed
+		if !batch.Committed && result.Status == application.ScheduleBatchFailed {
+			status = dto.HTTPStatus
+		}
+		response.Results = append(response.Results, dto)
+	}
+
+	logger.With("committed", batch.Committed, "status", status).InfoContext(r.Context(), "schedule batch applied")
+	h.responder.writeJSON(r.Context(), w, status, response)
+}
+
+type scheduleBatchRequest struct {
+	Mode       string                          `json:"mode"`
+	Operations []scheduleBatchOperationRequest `json:"operations"`
+}
+
+type scheduleBatchOperationRequest struct {
+	Op         string          `json:"op"`
+	ScheduleID string          `json:"schedule_id"`
+	IfMatch    string          `json:"if_match"`
+	Schedule   scheduleRequest `json:"schedule"`
+}
+
+func (r scheduleBatchRequest) toParams(principal application.Principal) application.ScheduleBatchParams {
+	params := application.ScheduleBatchParams{
+		Principal:  principal,
+		Mode:       application.ScheduleBatchMode(strings.TrimSpace(r.Mode)),
+		Operations: make([]application.ScheduleBatchOperation, 0, len(r.Operations)),
+	}
+	for _, op := range r.Operations {
+		params.Operations = append(params.Operations, application.ScheduleBatchOperation{
+			Op:         application.ScheduleBatchOp(strings.TrimSpace(op.Op)),
+			ScheduleID: strings.TrimSpace(op.ScheduleID),
+			IfMatch:    strings.TrimSpace(op.IfMatch),
+			Input:      op.Schedule.toInput(),
+		})
+	}
+	return params
+}
+
+type scheduleBatchResponse struct {
+	Mode      string                   `json:"mode"`
+	Committed bool                     `json:"committed"`
+	Results   []scheduleBatchResultDTO `json:"results"`
+}
+
+// scheduleBatchResultDTO reports one operation. HTTPStatus is what the single
+// call would have answered; operations undone or never run by a failed
+// all-or-nothing batch report 424 Failed Dependency.
+type scheduleBatchResultDTO struct {
+	Index      int                  `json:"index"`
+	Op         string               `json:"op"`
+	Status     string               `json:"status"`
+	HTTPStatus int                  `json:"http_status"`
+	ScheduleID string               `json:"schedule_id,omitempty"`
+	ETag       string               `json:"etag,omitempty"`
+	Schedule   *scheduleDTO         `json:"schedule,omitempty"`
+	Warnings   []conflictWarningDTO `json:"warnings,omitempty"`
+	Error      *errorResponse       `json:"error,omitempty"`
+}
+
+func toScheduleBatchResultDTO(index int, result application.ScheduleBatchResult, loc *time.Location) scheduleBatchResultDTO {
+	dto := scheduleBatchResultDTO{
+		Index:      index,
+		Op:         string(result.Op),
+		Status:     string(result.Status),
+		ScheduleID: result.ScheduleID,
+	}
+	switch result.Status {
+	case application.ScheduleBatchApplied:
+		dto.HTTPStatus = http.StatusOK
+		switch result.Op {
+		case application.ScheduleBatchCreate:
+			dto.HTTPStatus = http.StatusCreated
+		case application.ScheduleBatchDelete:
+			dto.HTTPStatus = http.StatusNoContent
+		}
+		if result.Schedule != nil {
+			schedule := toScheduleDTO(*result.Schedule, loc)
+			dto.Schedule = &schedule
+			if result.Schedule.Version > 0 {
+				dto.ETag = application.VersionETag(result.Schedule.Version)
+			}
+		}
+		dto.Warnings = toWarningDTOs(result.Warnings)
+	case application.ScheduleBatchFailed:
+		status, payload := serviceErrorResponse(result.Err)
+		dto.HTTPStatus = status
+		dto.Error = &payload
+	default:
+		dto.HTTPStatus = http.StatusFailedDependency
+	}
+	return dto
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,8 +16,16 @@ import (
 type scheduleService interface {
 	CreateSchedule(ctx context.Context, params application.CreateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
 	UpdateSchedule(ctx context.Context, params application.UpdateScheduleParams) (application.Schedule, []application.ConflictWarning, error)
+	GetSchedule(ctx context.Context, principal application.Principal, scheduleID string) (application.Schedule, error)
 	DeleteSchedule(ctx context.Context, principal application.Principal, scheduleID string) error
-	ListSchedules(ctx context.Context, params application.ListSchedulesParams) ([]application.Schedule, []application.ConflictWarning, error)
+	RestoreSchedule(ctx context.Context, principal application.Principal, scheduleID string) (application.Schedule, []application.ConflictWarning, error)
+	ListScheduleRevisions(ctx context.Context, principal application.Principal, scheduleID string) ([]application.ScheduleRevision, error)
+	GetScheduleRevision(ctx context.Context, principal application.Principal, scheduleID string, revision, compareTo int) (application.ScheduleRevision, error)
+	RevertSchedule(ctx context.Context, principal application.Principal, scheduleID string, revision int) (application.Schedule, []application.ConflictWarning, error)
+	ListSchedulesPage(ctx context.Context, params application.ListSchedulesParams) (application.SchedulePage, error)
+	ListScheduleInstances(ctx context.Context, params application.ListScheduleInstancesParams) ([]application.ScheduleInstance, error)
+	ListScheduleChanges(ctx context.Context, params application.ListScheduleChangesParams) (application.ScheduleChanges, error)
+	ApplyScheduleBatch(ctx context.Context, params application.ScheduleBatchParams) (application.ScheduleBatch, error)
 }
 
 type ScheduleHandler struct {
@@ -65,7 +74,7 @@ func (h *ScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.With("schedule_id", schedule.ID, "warning_count", len(warnings)).InfoContext(r.Context(), "schedule created")
-	h.renderSchedule(r.Context(), w, schedule, warnings, http.StatusCreated)
+	h.renderSchedule(r.Context(), w, schedule, warnings, displayLocation(principal), http.StatusCreated)
 }
 
 func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -81,6 +90,12 @@ func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, ok := requireIfMatch(w, r, h.responder)
+	if !ok {
+		h.log(r.Context(), "Update", "schedule_id", scheduleID, "error_kind", "precondition_required").ErrorContext(r.Context(), "missing If-Match for schedule update")
+		return
+	}
+
 	var req scheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.log(r.Context(), "Update", "schedule_id", scheduleID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode schedule update", "error", err)
@@ -96,6 +111,7 @@ func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Principal:  principal,
 		ScheduleID: scheduleID,
 		Input:      req.toInput(),
+		IfMatch:    ifMatch,
 	})
 	if err != nil {
 		logger.ErrorContext(r.Context(), "schedule update failed", "error", err, "error_kind", application.ErrorKind(err))
@@ -104,7 +120,36 @@ func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.With("schedule_id", schedule.ID, "warning_count", len(warnings)).InfoContext(r.Context(), "schedule updated")
-	h.renderSchedule(r.Context(), w, schedule, warnings, http.StatusOK)
+	h.renderSchedule(r.Context(), w, schedule, warnings, displayLocation(principal), http.StatusOK)
+}
+
+// Get serves GET /schedules/{id} with the schedule's ETag, which a later
+// PUT must send back in If-Match.
+func (h *ScheduleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	scheduleID, ok := ScheduleIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(scheduleID) == "" {
+		h.log(r.Context(), "Get", "error_kind", "bad_request").ErrorContext(r.Context(), "missing schedule id for get")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidScheduleID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "schedule_id", scheduleID)
+
+	schedule, err := h.service.GetSchedule(r.Context(), principal, scheduleID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "schedule retrieved")
+	h.renderSchedule(r.Context(), w, schedule, nil, displayLocation(principal), http.StatusOK)
 }
 
 func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -132,6 +177,33 @@ func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
 }
 
+func (h *ScheduleHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	scheduleID, ok := ScheduleIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(scheduleID) == "" {
+		h.log(r.Context(), "Restore", "error_kind", "bad_request").ErrorContext(r.Context(), "missing schedule id for restore")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidScheduleID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Restore", "principal_id", principal.UserID, "schedule_id", scheduleID)
+
+	schedule, warnings, err := h.service.RestoreSchedule(r.Context(), principal, scheduleID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule restore failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("warning_count", len(warnings)).InfoContext(r.Context(), "schedule restored")
+	h.renderSchedule(r.Context(), w, schedule, warnings, displayLocation(principal), http.StatusOK)
+}
+
 func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.service == nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -141,8 +213,16 @@ func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
 	principal, _ := PrincipalFromContext(r.Context())
 	params := buildListParams(r.URL.Query(), principal)
 
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		h.log(r.Context(), "List", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+	params.Limit = limit
+
 	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
-	schedules, warnings, err := h.service.ListSchedules(r.Context(), params)
+	page, err := h.service.ListSchedulesPage(r.Context(), params)
 	if err != nil {
 		logger.ErrorContext(r.Context(), "schedule list failed", "error", err, "error_kind", application.ErrorKind(err))
 		h.responder.handleServiceError(r.Context(), w, err)
@@ -150,55 +230,179 @@ func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := listSchedulesResponse{
-		Schedules: toScheduleDTOs(schedules),
-		Warnings:  toWarningDTOs(warnings),
+		Schedules:   toScheduleDTOs(page.Schedules, displayLocation(principal)),
+		OutOfOffice: toOutOfOfficeDTOs(page.OutOfOffice, displayLocation(principal)),
+		Warnings:    toWarningDTOs(page.Warnings),
+		NextCursor:  page.NextCursor,
+	}
+
+	logger.With("result_count", len(page.Schedules), "warning_count", len(page.Warnings)).InfoContext(r.Context(), "schedules listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, response)
+}
+
+// Occurrences lists the concrete instances of the visible schedules between
+// the `start` and `end` query parameters, one row per one-off schedule or
+// occurrence, sorted by start. The participant, group, calendar and
+// organisation filters follow List.
+func (h *ScheduleHandler) Occurrences(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	values := r.URL.Query()
+	listParams := buildListParams(values, principal)
+	params := application.ListScheduleInstancesParams{
+		Principal:      principal,
+		ParticipantIDs: listParams.ParticipantIDs,
+		Start:          parseTime(values.Get("start")),
+		End:            parseTime(values.Get("end")),
+		GroupID:        listParams.GroupID,
+		CalendarID:     listParams.CalendarID,
+		UserGroupID:    listParams.UserGroupID,
+		DepartmentID:   listParams.DepartmentID,
+		ReportsTo:      listParams.ReportsTo,
+	}
+
+	logger := h.log(r.Context(), "Occurrences", "principal_id", principal.UserID)
+	instances, err := h.service.ListScheduleInstances(r.Context(), params)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "occurrence list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	response := listOccurrencesResponse{Occurrences: make([]scheduleInstanceDTO, 0, len(instances))}
+	for _, instance := range instances {
+		response.Occurrences = append(response.Occurrences, toScheduleInstanceDTO(instance, loc))
+	}
+
+	logger.With("result_count", len(instances)).InfoContext(r.Context(), "occurrences listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, response)
+}
+
+// Changes serves delta sync: the schedules created or updated since the
+// `since` sync token, tombstones for those deleted or no longer involving the
+// synced participants, and the token to pass on the next request.
+func (h *ScheduleHandler) Changes(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	values := r.URL.Query()
+
+	limit, err := parseLimit(values)
+	if err != nil {
+		h.log(r.Context(), "Changes", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+
+	logger := h.log(r.Context(), "Changes", "principal_id", principal.UserID)
+	changes, err := h.service.ListScheduleChanges(r.Context(), application.ListScheduleChangesParams{
+		Principal:      principal,
+		ParticipantIDs: parseCSV(values.Get("participants")),
+		SyncToken:      values.Get("since"),
+		Limit:          limit,
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule changes failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
 	}
 
-	logger.With("result_count", len(schedules), "warning_count", len(warnings)).InfoContext(r.Context(), "schedules listed")
+	loc := displayLocation(principal)
+	response := scheduleChangesResponse{
+		Schedules: make([]scheduleDTO, 0, len(changes.Schedules)),
+		Deleted:   make([]scheduleTombstoneDTO, 0, len(changes.Tombstones)),
+		SyncToken: changes.SyncToken,
+		HasMore:   changes.HasMore,
+	}
+	for _, schedule := range changes.Schedules {
+		response.Schedules = append(response.Schedules, toScheduleDTO(schedule, loc))
+	}
+	for _, tombstone := range changes.Tombstones {
+		response.Deleted = append(response.Deleted, scheduleTombstoneDTO{
+			ID:        tombstone.ScheduleID,
+			DeletedAt: formatInLocation(tombstone.DeletedAt, loc),
+			Reason:    string(tombstone.Reason),
+		})
+	}
+
+	logger.With("result_count", len(response.Schedules), "tombstone_count", len(response.Deleted), "has_more", changes.HasMore).InfoContext(r.Context(), "schedule changes listed")
 	h.responder.writeJSON(r.Context(), w, http.StatusOK, response)
 }
 
-func (h *ScheduleHandler) renderSchedule(ctx context.Context, w http.ResponseWriter, schedule application.Schedule, warnings []application.ConflictWarning, status int) {
+func (h *ScheduleHandler) renderSchedule(ctx context.Context, w http.ResponseWriter, schedule application.Schedule, warnings []application.ConflictWarning, loc *time.Location, status int) {
 	payload := scheduleResponse{
-		Schedule: toScheduleDTO(schedule),
+		Schedule: toScheduleDTO(schedule, loc),
 		Warnings: toWarningDTOs(warnings),
 	}
+	setVersionETag(w, schedule.Version)
 	h.responder.writeJSON(ctx, w, status, payload)
 }
 
 type scheduleRequest struct {
-	CreatorID        string            `json:"creator_id"`
-	Title            string            `json:"title"`
-	Description      string            `json:"description"`
-	Start            string            `json:"start"`
-	End              string            `json:"end"`
-	RoomID           *string           `json:"room_id"`
-	WebConferenceURL string            `json:"web_conference_url"`
-	ParticipantIDs   []string          `json:"participant_ids"`
-	Recurrence       *recurrenceRequest `json:"recurrence,omitempty"`
+	CreatorID            string             `json:"creator_id"`
+	Title                string             `json:"title"`
+	Description          string             `json:"description"`
+	Start                string             `json:"start"`
+	End                  string             `json:"end"`
+	RoomID               *string            `json:"room_id"`
+	WebConferenceURL     string             `json:"web_conference_url"`
+	ParticipantIDs       []string           `json:"participant_ids"`
+	Recurrence           *recurrenceRequest `json:"recurrence,omitempty"`
+	TimeZone             string             `json:"time_zone"`
+	AllDay               bool               `json:"all_day"`
+	Busy                 bool               `json:"busy"`
+	ReminderMinutes      []int              `json:"reminder_minutes"`
+	CalendarID           *string            `json:"calendar_id"`
+	ParticipantGroupIDs  []string           `json:"participant_group_ids"`
+	ParticipantGroupMode string             `json:"participant_group_mode"`
 }
 
 type recurrenceRequest struct {
-	Frequency string   `json:"frequency"`
-	Weekdays  []string `json:"weekdays"`
-	Until     *string  `json:"until,omitempty"`
+	Frequency     string   `json:"frequency"`
+	Weekdays      []string `json:"weekdays"`
+	Until         *string  `json:"until,omitempty"`
+	HolidayPolicy string   `json:"holiday_policy,omitempty"`
 }
 
 func (r scheduleRequest) toInput() application.ScheduleInput {
 	input := application.ScheduleInput{
-		CreatorID:        strings.TrimSpace(r.CreatorID),
-		Title:            strings.TrimSpace(r.Title),
-		Description:      r.Description,
-		Start:            parseTime(r.Start),
-		End:              parseTime(r.End),
-		RoomID:           r.RoomID,
-		WebConferenceURL: strings.TrimSpace(r.WebConferenceURL),
-		ParticipantIDs:   append([]string(nil), r.ParticipantIDs...),
+		CreatorID:            strings.TrimSpace(r.CreatorID),
+		Title:                strings.TrimSpace(r.Title),
+		Description:          r.Description,
+		Start:                parseTime(r.Start),
+		End:                  parseTime(r.End),
+		RoomID:               r.RoomID,
+		WebConferenceURL:     strings.TrimSpace(r.WebConferenceURL),
+		ParticipantIDs:       append([]string(nil), r.ParticipantIDs...),
+		TimeZone:             strings.TrimSpace(r.TimeZone),
+		AllDay:               r.AllDay,
+		Busy:                 r.Busy,
+		ReminderMinutes:      append([]int(nil), r.ReminderMinutes...),
+		ParticipantGroupIDs:  append([]string(nil), r.ParticipantGroupIDs...),
+		ParticipantGroupMode: application.ParticipantGroupMode(strings.TrimSpace(r.ParticipantGroupMode)),
+	}
+	if r.CalendarID != nil {
+		if calendarID := strings.TrimSpace(*r.CalendarID); calendarID != "" {
+			input.CalendarID = &calendarID
+		}
+	}
+	if r.AllDay {
+		input.Start = parseDate(r.Start)
+		input.End = parseDate(r.End)
 	}
 	if r.Recurrence != nil {
 		input.Recurrence = &application.RecurrenceInput{
-			Frequency: r.Recurrence.Frequency,
-			Weekdays:  r.Recurrence.Weekdays,
+			Frequency:     r.Recurrence.Frequency,
+			Weekdays:      r.Recurrence.Weekdays,
+			HolidayPolicy: application.HolidayPolicy(strings.TrimSpace(r.Recurrence.HolidayPolicy)),
 		}
 		if r.Recurrence.Until != nil {
 			if t := parseTime(*r.Recurrence.Until); !t.IsZero() {
@@ -223,64 +427,181 @@ func parseTime(value string) time.Time {
 	return time.Time{}
 }
 
+// parseDate reads the calendar date of an all-day bound. Plain YYYY-MM-DD values
+// are preferred; full timestamps contribute the date they were written in.
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if ts, err := time.Parse(dateLayout, value); err == nil {
+		return ts
+	}
+	return parseTime(value)
+}
+
+// displayLocation returns the zone used to render times for the principal.
+// Callers without a preferred zone receive UTC.
+func displayLocation(principal application.Principal) *time.Location {
+	name := strings.TrimSpace(principal.TimeZone)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+const dateLayout = "2006-01-02"
+
+// formatBounds renders a schedule or occurrence range. All-day ranges are
+// rendered as inclusive calendar dates; timed ranges as instants in loc.
+func formatBounds(start, end time.Time, allDay bool, loc *time.Location) (string, string) {
+	if allDay {
+		return start.UTC().Format(dateLayout), end.UTC().AddDate(0, 0, -1).Format(dateLayout)
+	}
+	return formatInLocation(start, loc), formatInLocation(end, loc)
+}
+
+func formatInLocation(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(time.RFC3339Nano)
+}
+
 type scheduleResponse struct {
 	Schedule scheduleDTO          `json:"schedule"`
 	Warnings []conflictWarningDTO `json:"warnings,omitempty"`
 }
 
 type listSchedulesResponse struct {
-	Schedules []scheduleDTO        `json:"schedules"`
-	Warnings  []conflictWarningDTO `json:"warnings,omitempty"`
+	Schedules   []scheduleDTO        `json:"schedules"`
+	OutOfOffice []outOfOfficeDTO     `json:"out_of_office,omitempty"`
+	Warnings    []conflictWarningDTO `json:"warnings,omitempty"`
+	NextCursor  string               `json:"next_cursor,omitempty"`
+}
+
+type scheduleChangesResponse struct {
+	Schedules []scheduleDTO          `json:"schedules"`
+	Deleted   []scheduleTombstoneDTO `json:"deleted"`
+	SyncToken string                 `json:"sync_token"`
+	HasMore   bool                   `json:"has_more"`
+}
+
+type scheduleTombstoneDTO struct {
+	ID        string `json:"id"`
+	DeletedAt string `json:"deleted_at"`
+	Reason    string `json:"reason"`
 }
 
 type scheduleDTO struct {
-	ID               string          `json:"id"`
-	CreatorID        string          `json:"creator_id"`
-	Title            string          `json:"title"`
-	Description      string          `json:"description"`
-	Start            string          `json:"start"`
-	End              string          `json:"end"`
-	RoomID           *string         `json:"room_id,omitempty"`
-	WebConferenceURL string          `json:"web_conference_url,omitempty"`
-	ParticipantIDs   []string        `json:"participant_ids"`
-	CreatedAt        string          `json:"created_at"`
-	UpdatedAt        string          `json:"updated_at"`
-	Occurrences      []occurrenceDTO `json:"occurrences,omitempty"`
-}
-
-func toScheduleDTO(schedule application.Schedule) scheduleDTO {
+	ID                  string          `json:"id"`
+	CreatorID           string          `json:"creator_id"`
+	Title               string          `json:"title"`
+	Description         string          `json:"description"`
+	Start               string          `json:"start"`
+	End                 string          `json:"end"`
+	TimeZone            string          `json:"time_zone,omitempty"`
+	AllDay              bool            `json:"all_day"`
+	Busy                bool            `json:"busy,omitempty"`
+	RoomID              *string         `json:"room_id,omitempty"`
+	WebConferenceURL    string          `json:"web_conference_url,omitempty"`
+	ParticipantIDs      []string        `json:"participant_ids"`
+	ReminderMinutes     []int           `json:"reminder_minutes,omitempty"`
+	CalendarID          *string         `json:"calendar_id,omitempty"`
+	ParticipantGroupIDs []string        `json:"participant_group_ids,omitempty"`
+	CreatedAt           string          `json:"created_at"`
+	UpdatedAt           string          `json:"updated_at"`
+	Occurrences         []occurrenceDTO `json:"occurrences,omitempty"`
+	BusyOnly            bool            `json:"busy_only,omitempty"`
+}
+
+// toScheduleDTO renders a schedule with its start, end and occurrence times in loc.
+// All-day schedules render dates instead.
+func toScheduleDTO(schedule application.Schedule, loc *time.Location) scheduleDTO {
+	start, end := formatBounds(schedule.Start, schedule.End, schedule.AllDay, loc)
 	return scheduleDTO{
-		ID:               schedule.ID,
-		CreatorID:        schedule.CreatorID,
-		Title:            schedule.Title,
-		Description:      schedule.Description,
-		Start:            schedule.Start.UTC().Format(time.RFC3339Nano),
-		End:              schedule.End.UTC().Format(time.RFC3339Nano),
-		RoomID:           schedule.RoomID,
-		WebConferenceURL: schedule.WebConferenceURL,
-		ParticipantIDs:   append([]string(nil), schedule.ParticipantIDs...),
-		CreatedAt:        schedule.CreatedAt.UTC().Format(time.RFC3339Nano),
-		UpdatedAt:        schedule.UpdatedAt.UTC().Format(time.RFC3339Nano),
-		Occurrences:      toOccurrenceDTOs(schedule.Occurrences),
+		ID:                  schedule.ID,
+		CreatorID:           schedule.CreatorID,
+		Title:               schedule.Title,
+		Description:         schedule.Description,
+		Start:               start,
+		End:                 end,
+		TimeZone:            schedule.TimeZone,
+		AllDay:              schedule.AllDay,
+		Busy:                schedule.Busy,
+		RoomID:              schedule.RoomID,
+		WebConferenceURL:    schedule.WebConferenceURL,
+		ParticipantIDs:      append([]string(nil), schedule.ParticipantIDs...),
+		ReminderMinutes:     append([]int(nil), schedule.ReminderMinutes...),
+		CalendarID:          schedule.CalendarID,
+		ParticipantGroupIDs: append([]string(nil), schedule.ParticipantGroupIDs...),
+		CreatedAt:           schedule.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:           schedule.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		Occurrences:         toOccurrenceDTOs(schedule.Occurrences, schedule.AllDay, loc),
+		BusyOnly:            schedule.BusyOnly,
 	}
 }
 
-func toScheduleDTOs(schedules []application.Schedule) []scheduleDTO {
+func toScheduleDTOs(schedules []application.Schedule, loc *time.Location) []scheduleDTO {
 	if len(schedules) == 0 {
 		return nil
 	}
 	out := make([]scheduleDTO, 0, len(schedules))
 	for _, schedule := range schedules {
-		out = append(out, toScheduleDTO(schedule))
+		out = append(out, toScheduleDTO(schedule, loc))
 	}
 	return out
 }
 
+type listOccurrencesResponse struct {
+	Occurrences []scheduleInstanceDTO `json:"occurrences"`
+}
+
+// scheduleInstanceDTO is one row of a flattened calendar: a one-off schedule
+// or a single occurrence, carrying the series details needed to render it.
+type scheduleInstanceDTO struct {
+	ID               string   `json:"id"`
+	ScheduleID       string   `json:"schedule_id"`
+	RuleID           string   `json:"rule_id,omitempty"`
+	CreatorID        string   `json:"creator_id"`
+	Title            string   `json:"title"`
+	Start            string   `json:"start"`
+	End              string   `json:"end"`
+	TimeZone         string   `json:"time_zone,omitempty"`
+	AllDay           bool     `json:"all_day"`
+	Busy             bool     `json:"busy,omitempty"`
+	RoomID           *string  `json:"room_id,omitempty"`
+	WebConferenceURL string   `json:"web_conference_url,omitempty"`
+	ParticipantIDs   []string `json:"participant_ids"`
+}
+
+func toScheduleInstanceDTO(instance application.ScheduleInstance, loc *time.Location) scheduleInstanceDTO {
+	schedule := instance.Schedule
+	start, end := formatBounds(instance.Start, instance.End, schedule.AllDay, loc)
+	return scheduleInstanceDTO{
+		ID:               instance.ID,
+		ScheduleID:       schedule.ID,
+		RuleID:           instance.RuleID,
+		CreatorID:        schedule.CreatorID,
+		Title:            schedule.Title,
+		Start:            start,
+		End:              end,
+		TimeZone:         schedule.TimeZone,
+		AllDay:           schedule.AllDay,
+		Busy:             schedule.Busy,
+		RoomID:           schedule.RoomID,
+		WebConferenceURL: schedule.WebConferenceURL,
+		ParticipantIDs:   append([]string(nil), schedule.ParticipantIDs...),
+	}
+}
+
 type conflictWarningDTO struct {
 	ScheduleID    string  `json:"schedule_id"`
 	Type          string  `json:"type"`
 	ParticipantID string  `json:"participant_id,omitempty"`
 	RoomID        *string `json:"room_id,omitempty"`
+	OutOfOfficeID string  `json:"out_of_office_id,omitempty"`
 }
 
 type occurrenceDTO struct {
@@ -290,18 +611,19 @@ type occurrenceDTO struct {
 	End        string `json:"end"`
 }
 
-func toOccurrenceDTOs(occurrences []application.ScheduleOccurrence) []occurrenceDTO {
+func toOccurrenceDTOs(occurrences []application.ScheduleOccurrence, allDay bool, loc *time.Location) []occurrenceDTO {
 	if len(occurrences) == 0 {
 		return nil
 	}
 
 	out := make([]occurrenceDTO, 0, len(occurrences))
 	for _, occurrence := range occurrences {
+		start, end := formatBounds(occurrence.Start, occurrence.End, allDay, loc)
 		dto := occurrenceDTO{
 			ScheduleID: occurrence.ScheduleID,
 			RuleID:     occurrence.RuleID,
-			Start:      occurrence.Start.UTC().Format(time.RFC3339Nano),
-			End:        occurrence.End.UTC().Format(time.RFC3339Nano),
+			Start:      start,
+			End:        end,
 		}
 		out = append(out, dto)
 	}
@@ -320,6 +642,7 @@ func toWarningDTOs(warnings []application.ConflictWarning) []conflictWarningDTO
 			Type:          warning.Type,
 			ParticipantID: warning.ParticipantID,
 			RoomID:        warning.RoomID,
+			OutOfOfficeID: warning.OutOfOfficeID,
 		}
 		out = append(out, dto)
 	}
@@ -349,18 +672,44 @@ func buildListParams(values url.Values, principal application.Principal) applica
 		}
 	}
 
+	if roomID := strings.TrimSpace(values.Get("room_id")); roomID != "" {
+		params.RoomID = &roomID
+	}
+
+	params.CreatorID = strings.TrimSpace(values.Get("creator_id"))
+	params.GroupID = strings.TrimSpace(values.Get("group"))
+	params.CalendarID = strings.TrimSpace(values.Get("calendar_id"))
+	params.UserGroupID = strings.TrimSpace(values.Get("user_group"))
+	params.DepartmentID = strings.TrimSpace(values.Get("department"))
+	// reports_to=me lists the schedules of the caller's direct reports.
+	if params.ReportsTo = strings.TrimSpace(values.Get("reports_to")); params.ReportsTo == "me" {
+		params.ReportsTo = principal.UserID
+	}
+	params.TitlePrefix = strings.TrimSpace(values.Get("title_prefix"))
+
+	if since := strings.TrimSpace(values.Get("updated_since")); since != "" {
+		if ts := parseTime(since); !ts.IsZero() {
+			params.UpdatedSince = &ts
+		}
+	}
+
+	params.Cursor = strings.TrimSpace(values.Get("cursor"))
+
+	// Calendar dates are interpreted in the caller's zone so period presets
+	// line up with their local midnight.
+	loc := displayLocation(principal)
 	if day := strings.TrimSpace(values.Get("day")); day != "" {
-		if ts, err := time.Parse("2006-01-02", day); err == nil {
+		if ts, err := time.ParseInLocation(dateLayout, day, loc); err == nil {
 			params.Period = application.ListPeriodDay
 			params.PeriodReference = ts
 		}
 	} else if week := strings.TrimSpace(values.Get("week")); week != "" {
-		if ts, err := time.Parse("2006-01-02", week); err == nil {
+		if ts, err := time.ParseInLocation(dateLayout, week, loc); err == nil {
 			params.Period = application.ListPeriodWeek
 			params.PeriodReference = ts
 		}
 	} else if month := strings.TrimSpace(values.Get("month")); month != "" {
-		if ts, err := time.Parse("2006-01", month); err == nil {
+		if ts, err := time.ParseInLocation("2006-01", month, loc); err == nil {
 			params.Period = application.ListPeriodMonth
 			params.PeriodReference = ts
 		}
@@ -377,6 +726,16 @@ func buildListParams(values url.Values, principal application.Principal) applica
 	return params
 }
 
+// parseLimit reads the optional page size query parameter. Range checks are left
+// to the service so that out-of-range values surface as validation errors.
+func parseLimit(values url.Values) (int, error) {
+	raw := strings.TrimSpace(values.Get("limit"))
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
 func parseCSV(value string) []string {
 	parts := strings.Split(value, ",")
 	result := make([]string, 0, len(parts))
@@ -0,0 +1,247 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+// Revisions serves GET /schedules/{id}/revisions, the version history of a schedule.
+func (h *ScheduleHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	scheduleID, ok := ScheduleIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(scheduleID) == "" {
+		h.log(r.Context(), "Revisions", "error_kind", "bad_request").ErrorContext(r.Context(), "missing schedule id for revisions")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidScheduleID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Revisions", "principal_id", principal.UserID, "schedule_id", scheduleID)
+
+	revisions, err := h.service.ListScheduleRevisions(r.Context(), principal, scheduleID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule revision list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	dtos := make([]scheduleRevisionDTO, 0, len(revisions))
+	for _, revision := range revisions {
+		dtos = append(dtos, toScheduleRevisionDTO(revision, loc))
+	}
+
+	logger.With("result_count", len(dtos)).InfoContext(r.Context(), "schedule revisions listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listScheduleRevisionsResponse{Revisions: dtos})
+}
+
+// Revision serves GET /schedules/{id}/revisions/{n}. The changes are relative
+// to the revision named by compare_to, or to the previous revision by default.
+func (h *ScheduleHandler) Revision(w http.ResponseWriter, r *http.Request, rawRevision string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	scheduleID, ok := ScheduleIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(scheduleID) == "" {
+		h.log(r.Context(), "Revision", "error_kind", "bad_request").ErrorContext(r.Context(), "missing schedule id for revision")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidScheduleID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	revision, ok := parseRevisionNumber(rawRevision)
+	if !ok {
+		h.log(r.Context(), "Revision", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid revision number", "revision", rawRevision)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidRevision)
+		return
+	}
+	var compareTo int
+	if raw := strings.TrimSpace(r.URL.Query().Get("compare_to")); raw != "" {
+		if compareTo, ok = parseRevisionNumber(raw); !ok {
+			h.log(r.Context(), "Revision", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid compare_to parameter", "compare_to", raw)
+			h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidCompareTo)
+			return
+		}
+	}
+
+	logger := h.log(r.Context(), "Revision", "principal_id", principal.UserID, "schedule_id", scheduleID, "revision", revision)
+
+	result, err := h.service.GetScheduleRevision(r.Context(), principal, scheduleID, revision, compareTo)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule revision lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("change_count", len(result.ChangedFields)).InfoContext(r.Context(), "schedule revision retrieved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, scheduleRevisionResponse{Revision: toScheduleRevisionDTO(result, displayLocation(principal))})
+}
+
+// Revert serves POST /schedules/{id}/revisions/{n}/revert, which writes the
+// state of revision n back to the schedule as a regular update.
+func (h *ScheduleHandler) Revert(w http.ResponseWriter, r *http.Request, rawRevision string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	scheduleID, ok := ScheduleIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(scheduleID) == "" {
+		h.log(r.Context(), "Revert", "error_kind", "bad_request").ErrorContext(r.Context(), "missing schedule id for revert")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidScheduleID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	revision, ok := parseRevisionNumber(rawRevision)
+	if !ok {
+		h.log(r.Context(), "Revert", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid revision number", "revision", rawRevision)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidRevision)
+		return
+	}
+
+	logger := h.log(r.Context(), "Revert", "principal_id", principal.UserID, "schedule_id", scheduleID, "revision", revision)
+
+	schedule, warnings, err := h.service.RevertSchedule(r.Context(), principal, scheduleID, revision)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "schedule revert failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("warning_count", len(warnings)).InfoContext(r.Context(), "schedule reverted")
+	h.renderSchedule(r.Context(), w, schedule, warnings, displayLocation(principal), http.StatusOK)
+}
+
+func parseRevisionNumber(raw string) (int, bool) {
+	revision, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || revision <= 0 {
+		return 0, false
+	}
+	return revision, true
+}
+
+type listScheduleRevisionsResponse struct {
+	Revisions []scheduleRevisionDTO `json:"revisions"`
+}
+
+type scheduleRevisionResponse struct {
+	Revision scheduleRevisionDTO `json:"revision"`
+}
+
+type scheduleRevisionDTO struct {
+	Revision   int                   `json:"revision"`
+	ChangedBy  string                `json:"changed_by"`
+	ChangedAt  string                `json:"changed_at"`
+	ComparedTo int                   `json:"compared_to,omitempty"`
+	Schedule   scheduleSnapshotDTO   `json:"schedule"`
+	Changes    []scheduleFieldChange `json:"changes"`
+}
+
+type scheduleSnapshotDTO struct {
+	Title            string              `json:"title"`
+	Description      string              `json:"description"`
+	Start            string              `json:"start"`
+	End              string              `json:"end"`
+	TimeZone         string              `json:"time_zone,omitempty"`
+	AllDay           bool                `json:"all_day"`
+	Busy             bool                `json:"busy"`
+	RoomID           *string             `json:"room_id"`
+	WebConferenceURL string              `json:"web_conference_url"`
+	ParticipantIDs   []string            `json:"participant_ids"`
+	ReminderMinutes  []int               `json:"reminder_minutes"`
+	Recurrence       *recurrenceSnapshot `json:"recurrence"`
+}
+
+type recurrenceSnapshot struct {
+	Frequency     string   `json:"frequency"`
+	Weekdays      []string `json:"weekdays,omitempty"`
+	Until         *string  `json:"until,omitempty"`
+	HolidayPolicy string   `json:"holiday_policy,omitempty"`
+}
+
+// scheduleFieldChange is one field that differs from the compared revision,
+// with both values rendered as in the schedule snapshot.
+type scheduleFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+func toScheduleRevisionDTO(revision application.ScheduleRevision, loc *time.Location) scheduleRevisionDTO {
+	snapshot := toScheduleSnapshotDTO(revision.Snapshot, loc)
+	dto := scheduleRevisionDTO{
+		Revision:   revision.Revision,
+		ChangedBy:  revision.ChangedBy,
+		ChangedAt:  formatInLocation(revision.ChangedAt, loc),
+		ComparedTo: revision.BaseRevision,
+		Schedule:   snapshot,
+		Changes:    []scheduleFieldChange{},
+	}
+	if revision.Base == nil {
+		return dto
+	}
+
+	from, to := toScheduleSnapshotDTO(*revision.Base, loc).fields(), snapshot.fields()
+	for _, field := range revision.ChangedFields {
+		dto.Changes = append(dto.Changes, scheduleFieldChange{Field: field, From: from[field], To: to[field]})
+	}
+	return dto
+}
+
+func toScheduleSnapshotDTO(snapshot application.ScheduleSnapshot, loc *time.Location) scheduleSnapshotDTO {
+	start, end := formatBounds(snapshot.Start, snapshot.End, snapshot.AllDay, loc)
+	dto := scheduleSnapshotDTO{
+		Title:            snapshot.Title,
+		Description:      snapshot.Description,
+		Start:            start,
+		End:              end,
+		TimeZone:         snapshot.TimeZone,
+		AllDay:           snapshot.AllDay,
+		Busy:             snapshot.Busy,
+		RoomID:           snapshot.RoomID,
+		WebConferenceURL: snapshot.WebConferenceURL,
+		ParticipantIDs:   append([]string{}, snapshot.ParticipantIDs...),
+		ReminderMinutes:  append([]int{}, snapshot.ReminderMinutes...),
+	}
+	if r := snapshot.Recurrence; r != nil {
+		dto.Recurrence = &recurrenceSnapshot{
+			Frequency:     r.Frequency,
+			Weekdays:      r.Weekdays,
+			HolidayPolicy: string(r.HolidayPolicy),
+		}
+		if r.Until != nil {
+			until := formatInLocation(*r.Until, loc)
+			dto.Recurrence.Until = &until
+		}
+	}
+	return dto
+}
+
+// fields indexes the snapshot by the field names used in revision changes.
+func (s scheduleSnapshotDTO) fields() map[string]any {
+	return map[string]any{
+		application.RevisionFieldTitle:            s.Title,
+		application.RevisionFieldDescription:      s.Description,
+		application.RevisionFieldStart:            s.Start,
+		application.RevisionFieldEnd:              s.End,
+		application.RevisionFieldTimeZone:         s.TimeZone,
+		application.RevisionFieldAllDay:           s.AllDay,
+		application.RevisionFieldBusy:             s.Busy,
+		application.RevisionFieldRoomID:           s.RoomID,
+		application.RevisionFieldWebConferenceURL: s.WebConferenceURL,
+		application.RevisionFieldParticipantIDs:   s.ParticipantIDs,
+		application.RevisionFieldReminderMinutes:  s.ReminderMinutes,
+		application.RevisionFieldRecurrence:       s.Recurrence,
+	}
+}
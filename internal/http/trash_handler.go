@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type trashService interface {
+	ListTrash(ctx context.Context, principal application.Principal) ([]application.TrashItem, error)
+}
+
+// TrashHandler serves GET /trash, the schedules and rooms the caller deleted.
+type TrashHandler struct {
+	service   trashService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewTrashHandler(service trashService, logger *slog.Logger) *TrashHandler {
+	base := defaultLogger(logger)
+	return &TrashHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *TrashHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "TrashHandler", operation, attrs...)
+}
+
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	items, err := h.service.ListTrash(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "trash list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	dtos := make([]trashItemDTO, 0, len(items))
+	for _, item := range items {
+		dtos = append(dtos, toTrashItemDTO(item, loc))
+	}
+
+	logger.With("result_count", len(dtos)).InfoContext(r.Context(), "trash listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listTrashResponse{Items: dtos})
+}
+
+type listTrashResponse struct {
+	Items []trashItemDTO `json:"items"`
+}
+
+type trashItemDTO struct {
+	Kind      string       `json:"kind"`
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	DeletedAt string       `json:"deleted_at"`
+	PurgeAt   string       `json:"purge_at"`
+	Schedule  *scheduleDTO `json:"schedule,omitempty"`
+	Room      *roomDTO     `json:"room,omitempty"`
+}
+
+func toTrashItemDTO(item application.TrashItem, loc *time.Location) trashItemDTO {
+	dto := trashItemDTO{
+		Kind:      string(item.Kind),
+		ID:        item.ID,
+		Name:      item.Name,
+		DeletedAt: formatInLocation(item.DeletedAt, loc),
+		PurgeAt:   formatInLocation(item.PurgeAt, loc),
+	}
+	if item.Schedule != nil {
+		schedule := toScheduleDTO(*item.Schedule, loc)
+		dto.Schedule = &schedule
+	}
+	if item.Room != nil {
+		room := toRoomDTO(*item.Room)
+		dto.Room = &room
+	}
+	return dto
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type userGroupService interface {
+	ListUserGroups(ctx context.Context, principal application.Principal) ([]application.UserGroup, error)
+	GetUserGroup(ctx context.Context, principal application.Principal, groupID string) (application.UserGroup, error)
+	CreateUserGroup(ctx context.Context, params application.CreateUserGroupParams) (application.UserGroup, error)
+	UpdateUserGroup(ctx context.Context, params application.UpdateUserGroupParams) (application.UserGroup, error)
+	DeleteUserGroup(ctx context.Context, principal application.Principal, groupID string) error
+}
+
+// UserGroupHandler serves the user groups that can be invited to schedules.
+type UserGroupHandler struct {
+	service   userGroupService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewUserGroupHandler(service userGroupService, logger *slog.Logger) *UserGroupHandler {
+	base := defaultLogger(logger)
+	return &UserGroupHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *UserGroupHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "UserGroupHandler", operation, attrs...)
+}
+
+// List returns every user group with its members.
+func (h *UserGroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	groups, err := h.service.ListUserGroups(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user group list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]userGroupDTO, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, toUserGroupDTO(group, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "user groups listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listUserGroupsResponse{Groups: items})
+}
+
+func (h *UserGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req userGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode user group", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	group, err := h.service.CreateUserGroup(r.Context(), application.CreateUserGroupParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user group creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("group_id", group.ID).InfoContext(r.Context(), "user group created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, userGroupResponse{Group: toUserGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *UserGroupHandler) Get(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "group_id", groupID)
+
+	group, err := h.service.GetUserGroup(r.Context(), principal, groupID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user group lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "user group retrieved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, userGroupResponse{Group: toUserGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *UserGroupHandler) Update(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req userGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "group_id", groupID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode user group", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Update", "principal_id", principal.UserID, "group_id", groupID)
+
+	group, err := h.service.UpdateUserGroup(r.Context(), application.UpdateUserGroupParams{
+		Principal: principal,
+		GroupID:   groupID,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user group update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "user group updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, userGroupResponse{Group: toUserGroupDTO(group, displayLocation(principal))})
+}
+
+func (h *UserGroupHandler) Delete(w http.ResponseWriter, r *http.Request, groupID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "group_id", groupID)
+
+	if err := h.service.DeleteUserGroup(r.Context(), principal, groupID); err != nil {
+		logger.ErrorContext(r.Context(), "user group delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "user group deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+type userGroupRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	OwnerID     string   `json:"owner_id"`
+	MemberIDs   []string `json:"member_ids"`
+}
+
+func (req userGroupRequest) toInput() application.UserGroupInput {
+	return application.UserGroupInput{
+		Name:        req.Name,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		MemberIDs:   req.MemberIDs,
+	}
+}
+
+type listUserGroupsResponse struct {
+	Groups []userGroupDTO `json:"user_groups"`
+}
+
+type userGroupResponse struct {
+	Group userGroupDTO `json:"user_group"`
+}
+
+type userGroupDTO struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	OwnerID     string   `json:"owner_id,omitempty"`
+	MemberIDs   []string `json:"member_ids"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+func toUserGroupDTO(group application.UserGroup, loc *time.Location) userGroupDTO {
+	dto := userGroupDTO{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		OwnerID:     group.OwnerID,
+		MemberIDs:   group.MemberIDs,
+		CreatedAt:   formatInLocation(group.CreatedAt, loc),
+		UpdatedAt:   formatInLocation(group.UpdatedAt, loc),
+	}
+	if dto.MemberIDs == nil {
+		dto.MemberIDs = []string{}
+	}
+	return dto
+}
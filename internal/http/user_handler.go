@@ -2,7 +2,10 @@ package http
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -14,10 +17,19 @@ import (
 type userService interface {
 	CreateUser(ctx context.Context, params application.CreateUserParams) (application.User, error)
 	UpdateUser(ctx context.Context, params application.UpdateUserParams) (application.User, error)
+	GetUser(ctx context.Context, principal application.Principal, userID string) (application.User, error)
 	DeleteUser(ctx context.Context, principal application.Principal, userID string) error
-	ListUsers(ctx context.Context, principal application.Principal) ([]application.User, error)
+	ListUsersPage(ctx context.Context, params application.ListUsersParams) (application.UserPage, error)
+	ImportUsers(ctx context.Context, params application.ImportUsersParams) (application.UserImportResult, error)
 }
 
+// maxUserImportBody bounds the CSV accepted by POST /users:import.
+const maxUserImportBody = 4 << 20
+
+// requiredUserImportColumns must appear in the CSV header of POST
+// /users:import; display_name and time_zone may be left out.
+var requiredUserImportColumns = []string{"email", "department", "manager_email", "location"}
+
 type UserHandler struct {
 	service   userService
 	responder responder
@@ -64,6 +76,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.With("user_id", user.ID).InfoContext(r.Context(), "user created")
+	setVersionETag(w, user.Version)
 	h.responder.writeJSON(r.Context(), w, http.StatusCreated, userResponse{User: toUserDTO(user)})
 }
 
@@ -81,6 +94,11 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	principal, _ := PrincipalFromContext(r.Context())
+	ifMatch, ok := requireIfMatch(w, r, h.responder)
+	if !ok {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "user_id", userID, "error_kind", "precondition_required").ErrorContext(r.Context(), "missing If-Match for user update")
+		return
+	}
 
 	var req userRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -95,6 +113,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Principal: principal,
 		UserID:    userID,
 		Input:     req.toInput(),
+		IfMatch:   ifMatch,
 	})
 	if err != nil {
 		logger.ErrorContext(r.Context(), "user update failed", "error", err, "error_kind", application.ErrorKind(err))
@@ -103,6 +122,37 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.InfoContext(r.Context(), "user updated")
+	setVersionETag(w, user.Version)
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, userResponse{User: toUserDTO(user)})
+}
+
+// Get serves GET /users/{id} with the user's ETag, which a later PUT
+// must send back in If-Match.
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok || strings.TrimSpace(userID) == "" {
+		h.log(r.Context(), "Get", "error_kind", "bad_request").ErrorContext(r.Context(), "missing user id for get")
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "user_id", userID)
+
+	user, err := h.service.GetUser(r.Context(), principal, userID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "user retrieved")
+	setVersionETag(w, user.Version)
 	h.responder.writeJSON(r.Context(), w, http.StatusOK, userResponse{User: toUserDTO(user)})
 }
 
@@ -138,29 +188,129 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	principal, _ := PrincipalFromContext(r.Context())
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		h.log(r.Context(), "List", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+
 	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
-	users, err := h.service.ListUsers(r.Context(), principal)
+	page, err := h.service.ListUsersPage(r.Context(), application.ListUsersParams{
+		Principal: principal,
+		Limit:     limit,
+		Cursor:    strings.TrimSpace(r.URL.Query().Get("cursor")),
+	})
 	if err != nil {
 		logger.ErrorContext(r.Context(), "user list failed", "error", err, "error_kind", application.ErrorKind(err))
 		h.responder.handleServiceError(r.Context(), w, err)
 		return
 	}
 
-	logger.With("result_count", len(users)).InfoContext(r.Context(), "users listed")
-	h.responder.writeJSON(r.Context(), w, http.StatusOK, listUsersResponse{Users: toUserDTOs(users)})
+	logger.With("result_count", len(page.Users)).InfoContext(r.Context(), "users listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listUsersResponse{Users: toUserDTOs(page.Users), NextCursor: page.NextCursor})
+}
+
+// Import serves POST /users:import, creating and updating users from a CSV
+// whose header names the columns. Every row is checked before any is written.
+func (h *UserHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	rows, err := readUserImport(http.MaxBytesReader(w, r.Body, maxUserImportBody))
+	if err != nil {
+		h.log(r.Context(), "Import", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to read user import", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidUserImport)
+		return
+	}
+
+	logger := h.log(r.Context(), "Import", "principal_id", principal.UserID, "row_count", len(rows))
+
+	result, err := h.service.ImportUsers(r.Context(), application.ImportUsersParams{
+		Principal: principal,
+		Rows:      rows,
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "user import failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("created_count", len(result.Created), "updated_count", len(result.Updated)).InfoContext(r.Context(), "users imported")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, userImportResponse{
+		Created: emptyUserDTOs(toUserDTOs(result.Created)),
+		Updated: emptyUserDTOs(toUserDTOs(result.Updated)),
+	})
+}
+
+// readUserImport parses the CSV of a user import. The header must name the
+// email, department, manager_email and location columns.
+func readUserImport(body io.Reader) ([]application.UserImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range requiredUserImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("missing column " + name)
+		}
+	}
+
+	var rows []application.UserImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		rows = append(rows, application.UserImportRow{
+			Email:        value("email"),
+			DisplayName:  value("display_name"),
+			TimeZone:     value("time_zone"),
+			Department:   value("department"),
+			ManagerEmail: value("manager_email"),
+			Location:     value("location"),
+		})
+	}
 }
 
 type userRequest struct {
-	Email       string `json:"email"`
-	DisplayName string `json:"display_name"`
-	IsAdmin     bool   `json:"is_admin"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	IsAdmin      bool   `json:"is_admin"`
+	TimeZone     string `json:"time_zone"`
+	DepartmentID string `json:"department_id"`
+	ManagerID    string `json:"manager_id"`
+	Location     string `json:"location"`
 }
 
 func (r userRequest) toInput() application.UserInput {
 	return application.UserInput{
-		Email:       strings.TrimSpace(r.Email),
-		DisplayName: strings.TrimSpace(r.DisplayName),
-		IsAdmin:     r.IsAdmin,
+		Email:        strings.TrimSpace(r.Email),
+		DisplayName:  strings.TrimSpace(r.DisplayName),
+		IsAdmin:      r.IsAdmin,
+		TimeZone:     strings.TrimSpace(r.TimeZone),
+		DepartmentID: strings.TrimSpace(r.DepartmentID),
+		ManagerID:    strings.TrimSpace(r.ManagerID),
+		Location:     strings.TrimSpace(r.Location),
 	}
 }
 
@@ -169,26 +319,47 @@ type userResponse struct {
 }
 
 type listUsersResponse struct {
-	Users []userDTO `json:"users"`
+	Users      []userDTO `json:"users"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type userImportResponse struct {
+	Created []userDTO `json:"created"`
+	Updated []userDTO `json:"updated"`
+}
+
+func emptyUserDTOs(users []userDTO) []userDTO {
+	if users == nil {
+		return []userDTO{}
+	}
+	return users
 }
 
 type userDTO struct {
-	ID          string `json:"id"`
-	Email       string `json:"email"`
-	DisplayName string `json:"display_name"`
-	IsAdmin     bool   `json:"is_admin"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	IsAdmin      bool   `json:"is_admin"`
+	TimeZone     string `json:"time_zone,omitempty"`
+	DepartmentID string `json:"department_id,omitempty"`
+	ManagerID    string `json:"manager_id,omitempty"`
+	Location     string `json:"location,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
 }
 
 func toUserDTO(user application.User) userDTO {
 	return userDTO{
-		ID:          user.ID,
-		Email:       user.Email,
-		DisplayName: user.DisplayName,
-		IsAdmin:     user.IsAdmin,
-		CreatedAt:   user.CreatedAt.UTC().Format(time.RFC3339Nano),
-		UpdatedAt:   user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		ID:           user.ID,
+		Email:        user.Email,
+		DisplayName:  user.DisplayName,
+		IsAdmin:      user.IsAdmin,
+		TimeZone:     user.TimeZone,
+		DepartmentID: user.DepartmentID,
+		ManagerID:    user.ManagerID,
+		Location:     user.Location,
+		CreatedAt:    user.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:    user.UpdatedAt.UTC().Format(time.RFC3339Nano),
 	}
 }
 
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type viewLinkService interface {
+	CreateViewLink(ctx context.Context, params application.CreateViewLinkParams) (application.ViewLink, error)
+	GetViewLink(ctx context.Context, principal application.Principal, code string) (application.ViewLink, error)
+}
+
+// ViewLinkHandler serves short links to calendar views.
+type ViewLinkHandler struct {
+	service   viewLinkService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewViewLinkHandler(service viewLinkService, logger *slog.Logger) *ViewLinkHandler {
+	base := defaultLogger(logger)
+	return &ViewLinkHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *ViewLinkHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "ViewLinkHandler", operation, attrs...)
+}
+
+// Create stores a link to the view described in the body. The date is read
+// as YYYY-MM-DD in the caller's zone and defaults to today.
+func (h *ViewLinkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req viewLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode view link", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	params := application.CreateViewLinkParams{
+		Principal:      principal,
+		View:           application.ListPeriod(strings.TrimSpace(req.View)),
+		ParticipantIDs: req.Participants,
+		GroupID:        strings.TrimSpace(req.GroupID),
+	}
+	if raw := strings.TrimSpace(req.Date); raw != "" {
+		date, err := time.ParseInLocation(dateLayout, raw, displayLocation(principal))
+		if err != nil {
+			h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid view link date", "date", raw)
+			h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidHolidayDate)
+			return
+		}
+		params.Date = date
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	link, err := h.service.CreateViewLink(r.Context(), params)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "view link creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("code", link.Code).InfoContext(r.Context(), "view link created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, viewLinkResponse{Link: toViewLinkDTO(link, displayLocation(principal))})
+}
+
+// Get resolves a link for any authenticated caller.
+func (h *ViewLinkHandler) Get(w http.ResponseWriter, r *http.Request, code string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Get", "principal_id", principal.UserID, "code", code)
+
+	link, err := h.service.GetViewLink(r.Context(), principal, code)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "view link lookup failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "view link resolved")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, viewLinkResponse{Link: toViewLinkDTO(link, displayLocation(principal))})
+}
+
+type viewLinkRequest struct {
+	View         string   `json:"view"`
+	Date         string   `json:"date"`
+	Participants []string `json:"participants"`
+	GroupID      string   `json:"group_id"`
+}
+
+type viewLinkResponse struct {
+	Link viewLinkDTO `json:"link"`
+}
+
+// viewLinkDTO carries Path, the view URL the link stands for, so clients can
+// navigate without rebuilding the query themselves.
+type viewLinkDTO struct {
+	Code           string   `json:"code"`
+	CreatorID      string   `json:"creator_id"`
+	View           string   `json:"view"`
+	Date           string   `json:"date"`
+	ParticipantIDs []string `json:"participant_ids"`
+	Path           string   `json:"path"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+func toViewLinkDTO(link application.ViewLink, loc *time.Location) viewLinkDTO {
+	date := link.Date.Format(dateLayout)
+	participants := make([]string, 0, len(link.ParticipantIDs))
+	for _, id := range link.ParticipantIDs {
+		participants = append(participants, url.QueryEscape(id))
+	}
+	path := "/views/" + string(link.View) + "?date=" + date
+	if len(participants) > 0 {
+		path += "&participants=" + strings.Join(participants, ",")
+	}
+	dto := viewLinkDTO{
+		Code:           link.Code,
+		CreatorID:      link.CreatorID,
+		View:           string(link.View),
+		Date:           date,
+		ParticipantIDs: link.ParticipantIDs,
+		Path:           path,
+		CreatedAt:      formatInLocation(link.CreatedAt, loc),
+	}
+	if dto.ParticipantIDs == nil {
+		dto.ParticipantIDs = []string{}
+	}
+	return dto
+}
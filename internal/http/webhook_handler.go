@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/application"
+)
+
+type webhookService interface {
+	ListWebhooks(ctx context.Context, principal application.Principal) ([]application.WebhookSubscription, error)
+	CreateWebhook(ctx context.Context, params application.CreateWebhookParams) (application.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, params application.UpdateWebhookParams) (application.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, principal application.Principal, webhookID string) error
+	ListWebhookDeliveries(ctx context.Context, principal application.Principal, webhookID string, limit int) ([]application.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, principal application.Principal, webhookID, deliveryID string) (application.WebhookDelivery, error)
+}
+
+// WebhookHandler serves admin-managed webhook subscriptions and their delivery logs.
+type WebhookHandler struct {
+	service   webhookService
+	responder responder
+	logger    *slog.Logger
+}
+
+func NewWebhookHandler(service webhookService, logger *slog.Logger) *WebhookHandler {
+	base := defaultLogger(logger)
+	return &WebhookHandler{service: service, responder: newResponder(base), logger: base}
+}
+
+func (h *WebhookHandler) log(ctx context.Context, operation string, attrs ...any) *slog.Logger {
+	if h == nil {
+		return slog.Default()
+	}
+	return handlerLogger(ctx, h.logger, "WebhookHandler", operation, attrs...)
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "List", "principal_id", principal.UserID)
+
+	subscriptions, err := h.service.ListWebhooks(r.Context(), principal)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "webhook list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]webhookDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		items = append(items, toWebhookDTO(subscription, loc, false))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "webhooks listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listWebhooksResponse{Webhooks: items})
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Create", "principal_id", principal.UserID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode webhook", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Create", "principal_id", principal.UserID)
+
+	subscription, err := h.service.CreateWebhook(r.Context(), application.CreateWebhookParams{
+		Principal: principal,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "webhook creation failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("webhook_id", subscription.ID).InfoContext(r.Context(), "webhook created")
+	h.responder.writeJSON(r.Context(), w, http.StatusCreated, webhookResponse{Webhook: toWebhookDTO(subscription, displayLocation(principal), true)})
+}
+
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request, webhookID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log(r.Context(), "Update", "principal_id", principal.UserID, "webhook_id", webhookID, "error_kind", "bad_request").ErrorContext(r.Context(), "failed to decode webhook", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errBadRequestBody)
+		return
+	}
+
+	logger := h.log(r.Context(), "Update", "principal_id", principal.UserID, "webhook_id", webhookID)
+
+	subscription, err := h.service.UpdateWebhook(r.Context(), application.UpdateWebhookParams{
+		Principal: principal,
+		WebhookID: webhookID,
+		Input:     req.toInput(),
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "webhook update failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "webhook updated")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, webhookResponse{Webhook: toWebhookDTO(subscription, displayLocation(principal), false)})
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request, webhookID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Delete", "principal_id", principal.UserID, "webhook_id", webhookID)
+
+	if err := h.service.DeleteWebhook(r.Context(), principal, webhookID); err != nil {
+		logger.ErrorContext(r.Context(), "webhook delete failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.InfoContext(r.Context(), "webhook deleted")
+	h.responder.writeJSON(r.Context(), w, http.StatusNoContent, nil)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request, webhookID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		h.log(r.Context(), "ListDeliveries", "principal_id", principal.UserID, "webhook_id", webhookID, "error_kind", "bad_request").ErrorContext(r.Context(), "invalid limit parameter", "error", err)
+		h.responder.writeError(r.Context(), w, http.StatusBadRequest, errInvalidLimit)
+		return
+	}
+
+	logger := h.log(r.Context(), "ListDeliveries", "principal_id", principal.UserID, "webhook_id", webhookID)
+
+	deliveries, err := h.service.ListWebhookDeliveries(r.Context(), principal, webhookID, limit)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "webhook delivery list failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	loc := displayLocation(principal)
+	items := make([]webhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, toWebhookDeliveryDTO(delivery, loc))
+	}
+
+	logger.With("result_count", len(items)).InfoContext(r.Context(), "webhook deliveries listed")
+	h.responder.writeJSON(r.Context(), w, http.StatusOK, listWebhookDeliveriesResponse{Deliveries: items})
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request, webhookID, deliveryID string) {
+	if h == nil || h.service == nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	logger := h.log(r.Context(), "Redeliver", "principal_id", principal.UserID, "webhook_id", webhookID, "delivery_id", deliveryID)
+
+	delivery, err := h.service.RedeliverWebhook(r.Context(), principal, webhookID, deliveryID)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "webhook redelivery failed", "error", err, "error_kind", application.ErrorKind(err))
+		h.responder.handleServiceError(r.Context(), w, err)
+		return
+	}
+
+	logger.With("redelivery_id", delivery.ID).InfoContext(r.Context(), "webhook redelivery queued")
+	h.responder.writeJSON(r.Context(), w, http.StatusAccepted, webhookDeliveryResponse{Delivery: toWebhookDeliveryDTO(delivery, displayLocation(principal))})
+}
+
+type webhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+func (r webhookRequest) toInput() application.WebhookInput {
+	return application.WebhookInput{
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Active:     r.Active,
+	}
+}
+
+type listWebhooksResponse struct {
+	Webhooks []webhookDTO `json:"webhooks"`
+}
+
+type webhookResponse struct {
+	Webhook webhookDTO `json:"webhook"`
+}
+
+// webhookDTO omits the signing secret except in the creation response.
+type webhookDTO struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	Secret     string   `json:"secret,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+func toWebhookDTO(subscription application.WebhookSubscription, loc *time.Location, withSecret bool) webhookDTO {
+	dto := webhookDTO{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: append([]string{}, subscription.EventTypes...),
+		Active:     subscription.Active,
+		CreatedAt:  formatInLocation(subscription.CreatedAt, loc),
+		UpdatedAt:  formatInLocation(subscription.UpdatedAt, loc),
+	}
+	if withSecret {
+		dto.Secret = subscription.Secret
+	}
+	return dto
+}
+
+type listWebhookDeliveriesResponse struct {
+	Deliveries []webhookDeliveryDTO `json:"deliveries"`
+}
+
+type webhookDeliveryResponse struct {
+	Delivery webhookDeliveryDTO `json:"delivery"`
+}
+
+type webhookDeliveryDTO struct {
+	ID             string `json:"id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+func toWebhookDeliveryDTO(delivery application.WebhookDelivery, loc *time.Location) webhookDeliveryDTO {
+	dto := webhookDeliveryDTO{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      formatInLocation(delivery.CreatedAt, loc),
+		UpdatedAt:      formatInLocation(delivery.UpdatedAt, loc),
+	}
+	if delivery.Status == application.WebhookDeliveryPending {
+		dto.NextAttemptAt = formatInLocation(delivery.NextAttemptAt, loc)
+	}
+	return dto
+}
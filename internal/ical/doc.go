@@ -0,0 +1,8 @@
+// Package ical reads and writes the subset of iCalendar (RFC 5545) exchanged
+// with CalDAV clients: VCALENDAR objects holding VEVENT components with
+// summary, description, location, URL, organizer, attendees and a weekly RRULE.
+//
+// Timed events are written with a TZID and a VTIMEZONE generated from the Go
+// time zone database. Unknown properties and components, including VALARM, are
+// ignored when reading.
+package ical
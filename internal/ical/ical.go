@@ -0,0 +1,760 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrNoEvent is returned by Decode when the object holds no VEVENT.
+var ErrNoEvent = errors.New("ical: no VEVENT component")
+
+const (
+	dateLayout     = "20060102"
+	localLayout    = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+	maxLineOctets  = 75
+	timeZoneParam  = "TZID"
+	mailtoPrefix   = "mailto:"
+	defaultProduct = "-//Enterprise Scheduler//CalDAV//JA"
+)
+
+// Event is one VEVENT. All-day events hold dates at UTC midnight and End is
+// exclusive, as in DTEND;VALUE=DATE. Timed events are written in TimeZone, or
+// in UTC when it is empty.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	TimeZone     string
+	Organizer    *Attendee
+	Attendees    []Attendee
+	Recurrence   *Recurrence
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Attendee is a calendar user addressed by e-mail.
+type Attendee struct {
+	Email string
+	Name  string
+}
+
+// Recurrence is the subset of RRULE the scheduler understands. Interval and
+// Count are reported so callers can reject rules they cannot store.
+type Recurrence struct {
+	Frequency string
+	ByDay     []time.Weekday
+	Until     *time.Time
+	Interval  int
+	Count     int
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Encode writes a VCALENDAR object holding events, with a VTIMEZONE for every
+// zone the timed events use.
+func Encode(w io.Writer, events []Event) error {
+	var out contentWriter
+	out.line("BEGIN", nil, "VCALENDAR")
+	out.line("VERSION", nil, "2.0")
+	out.line("PRODID", nil, defaultProduct)
+	out.line("CALSCALE", nil, "GREGORIAN")
+
+	for _, zone := range eventZones(events) {
+		if err := writeTimeZone(&out, zone.name, zone.year); err != nil {
+			return err
+		}
+	}
+	for _, event := range events {
+		writeEvent(&out, event)
+	}
+
+	out.line("END", nil, "VCALENDAR")
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+type zoneUse struct {
+	name string
+	year int
+}
+
+func eventZones(events []Event) []zoneUse {
+	years := map[string]int{}
+	for _, event := range events {
+		if event.AllDay || event.TimeZone == "" || event.TimeZone == "UTC" {
+			continue
+		}
+		year := event.Start.Year()
+		if current, ok := years[event.TimeZone]; !ok || year < current {
+			years[event.TimeZone] = year
+		}
+	}
+	zones := make([]zoneUse, 0, len(years))
+	for name, year := range years {
+		zones = append(zones, zoneUse{name: name, year: year})
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].name < zones[j].name })
+	return zones
+}
+
+func writeEvent(out *contentWriter, event Event) {
+	stamp := event.LastModified
+	if stamp.IsZero() {
+		stamp = event.Created
+	}
+
+	out.line("BEGIN", nil, "VEVENT")
+	out.line("UID", nil, event.UID)
+	out.line("DTSTAMP", nil, stamp.UTC().Format(utcLayout))
+	if !event.Created.IsZero() {
+		out.line("CREATED", nil, event.Created.UTC().Format(utcLayout))
+	}
+	if !event.LastModified.IsZero() {
+		out.line("LAST-MODIFIED", nil, event.LastModified.UTC().Format(utcLayout))
+	}
+	writeTime(out, "DTSTART", event.Start, event.AllDay, event.TimeZone)
+	writeTime(out, "DTEND", event.End, event.AllDay, event.TimeZone)
+	out.line("SUMMARY", nil, escapeText(event.Summary))
+	if event.Description != "" {
+		out.line("DESCRIPTION", nil, escapeText(event.Description))
+	}
+	if event.Location != "" {
+		out.line("LOCATION", nil, escapeText(event.Location))
+	}
+	if event.URL != "" {
+		out.line("URL", nil, event.URL)
+	}
+	if event.Recurrence != nil {
+		out.line("RRULE", nil, event.Recurrence.format(event.AllDay))
+	}
+	if event.Organizer != nil {
+		out.line("ORGANIZER", attendeeParams(*event.Organizer), mailtoPrefix+event.Organizer.Email)
+	}
+	for _, attendee := range event.Attendees {
+		out.line("ATTENDEE", attendeeParams(attendee), mailtoPrefix+attendee.Email)
+	}
+	out.line("END", nil, "VEVENT")
+}
+
+func writeTime(out *contentWriter, name string, t time.Time, allDay bool, zone string) {
+	switch {
+	case allDay:
+		out.line(name, []param{{"VALUE", "DATE"}}, t.UTC().Format(dateLayout))
+	case zone == "" || zone == "UTC":
+		out.line(name, nil, t.UTC().Format(utcLayout))
+	default:
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			out.line(name, nil, t.UTC().Format(utcLayout))
+			return
+		}
+		out.line(name, []param{{timeZoneParam, zone}}, t.In(loc).Format(localLayout))
+	}
+}
+
+func attendeeParams(attendee Attendee) []param {
+	if attendee.Name == "" {
+		return nil
+	}
+	return []param{{"CN", attendee.Name}}
+}
+
+func (r Recurrence) format(allDay bool) string {
+	parts := []string{"FREQ=" + strings.ToUpper(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, weekdayCodes[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if allDay {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(dateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(utcLayout))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// writeTimeZone emits a VTIMEZONE for name. Zones without daylight saving get
+// a single STANDARD rule; others get yearly rules derived from the
+// transitions in year.
+func writeTimeZone(out *contentWriter, name string, year int) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+
+	out.line("BEGIN", nil, "VTIMEZONE")
+	out.line("TZID", nil, name)
+
+	transitions := zoneTransitions(loc, year)
+	if len(transitions) == 0 {
+		abbr, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		out.line("BEGIN", nil, "STANDARD")
+		out.line("DTSTART", nil, "19700101T000000")
+		out.line("TZOFFSETFROM", nil, formatOffset(offset))
+		out.line("TZOFFSETTO", nil, formatOffset(offset))
+		out.line("TZNAME", nil, abbr)
+		out.line("END", nil, "STANDARD")
+	}
+	for _, transition := range transitions {
+		kind := "STANDARD"
+		if transition.to > transition.from {
+			kind = "DAYLIGHT"
+		}
+		local := transition.at.Add(time.Duration(transition.from) * time.Second).UTC()
+		out.line("BEGIN", nil, kind)
+		out.line("DTSTART", nil, local.Format(localLayout))
+		out.line("RRULE", nil, fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%s", int(local.Month()), weekdayOrdinal(local)))
+		out.line("TZOFFSETFROM", nil, formatOffset(transition.from))
+		out.line("TZOFFSETTO", nil, formatOffset(transition.to))
+		out.line("TZNAME", nil, transition.name)
+		out.line("END", nil, kind)
+	}
+
+	out.line("END", nil, "VTIMEZONE")
+	return nil
+}
+
+type zoneTransition struct {
+	at       time.Time
+	from, to int
+	name     string
+}
+
+func zoneTransitions(loc *time.Location, year int) []zoneTransition {
+	var transitions []zoneTransition
+	day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, offset := day.In(loc).Zone()
+	for next := day.AddDate(0, 0, 1); next.Year() == year; day, next = next, next.AddDate(0, 0, 1) {
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+		// Narrow the change down to the second within the day.
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, midOffset := mid.In(loc).Zone(); midOffset == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		name, _ := hi.In(loc).Zone()
+		transitions = append(transitions, zoneTransition{at: hi, from: offset, to: nextOffset, name: name})
+		offset = nextOffset
+	}
+	return transitions
+}
+
+// weekdayOrdinal renders the BYDAY value matching t's weekday within its
+// month, using -1 for the last occurrence.
+func weekdayOrdinal(t time.Time) string {
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if t.Day()+7 > daysInMonth {
+		return "-1" + weekdayCodes[t.Weekday()]
+	}
+	return strconv.Itoa((t.Day()-1)/7+1) + weekdayCodes[t.Weekday()]
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	if seconds%60 != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds/60%60)
+}
+
+func parseOffset(value string) (int, bool) {
+	if len(value) != 5 && len(value) != 7 {
+		return 0, false
+	}
+	sign := 1
+	switch value[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return 0, false
+	}
+	total := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+i*2 >= len(value) {
+			break
+		}
+		n, err := strconv.Atoi(value[1+i*2 : 3+i*2])
+		if err != nil {
+			return 0, false
+		}
+		total += n * unit
+	}
+	return sign * total, true
+}
+
+type param struct {
+	name  string
+	value string
+}
+
+// contentWriter accumulates folded content lines.
+type contentWriter struct {
+	strings.Builder
+}
+
+func (w *contentWriter) line(name string, params []param, value string) {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, p := range params {
+		b.WriteByte(';')
+		b.WriteString(p.name)
+		b.WriteByte('=')
+		b.WriteString(quoteParam(p.value))
+	}
+	b.WriteByte(':')
+	b.WriteString(value)
+	w.WriteString(fold(b.String()))
+}
+
+// fold splits a content line into 75-octet lines without breaking UTF-8
+// sequences.
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func quoteParam(value string) string {
+	value = strings.ReplaceAll(value, `"`, "")
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func unescapeText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// property is one parsed content line.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Decode reads the VEVENT components of an iCalendar object. Times without a
+// zone (floating times) are read in floating, or UTC when it is nil. A TZID
+// the Go time zone database does not know falls back to the fixed offset
+// declared by the object's VTIMEZONE.
+func Decode(r io.Reader, floating *time.Location) ([]Event, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if floating == nil {
+		floating = time.UTC
+	}
+
+	lines := unfold(string(raw))
+	var (
+		stack      []string
+		eventProps [][]property
+		zoneID     string
+		zones      = map[string]int{}
+	)
+	for _, line := range lines {
+		prop, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			if component == "VEVENT" && len(stack) == 2 {
+				eventProps = append(eventProps, nil)
+			}
+			continue
+		case "END":
+			if len(stack) > 0 {
+				if stack[len(stack)-1] == "VTIMEZONE" {
+					zoneID = ""
+				}
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		switch top := stack[len(stack)-1]; {
+		case top == "VEVENT" && len(stack) == 2:
+			eventProps[len(eventProps)-1] = append(eventProps[len(eventProps)-1], prop)
+		case top == "VTIMEZONE" && prop.name == "TZID":
+			zoneID = prop.value
+		case (top == "STANDARD" || top == "DAYLIGHT") && prop.name == "TZOFFSETTO" && zoneID != "":
+			if offset, ok := parseOffset(prop.value); ok {
+				if _, seen := zones[zoneID]; !seen || top == "STANDARD" {
+					zones[zoneID] = offset
+				}
+			}
+		}
+	}
+
+	if len(eventProps) == 0 {
+		return nil, ErrNoEvent
+	}
+	events := make([]Event, 0, len(eventProps))
+	for _, props := range eventProps {
+		event, err := buildEvent(props, zones, floating)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func unfold(raw string) []string {
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(raw, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseLine splits "NAME;P=V;Q=\"W:X\":value" honouring quoted parameter values.
+func parseLine(line string) (property, bool) {
+	inQuote := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuote = !inQuote
+		case ':':
+			if !inQuote {
+				colon = i
+			}
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon <= 0 {
+		return property{}, false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	segments := splitUnquoted(head, ';')
+	prop := property{name: strings.ToUpper(segments[0]), value: value}
+	for _, segment := range segments[1:] {
+		name, val, ok := strings.Cut(segment, "=")
+		if !ok {
+			continue
+		}
+		if prop.params == nil {
+			prop.params = map[string]string{}
+		}
+		prop.params[strings.ToUpper(name)] = strings.Trim(val, `"`)
+	}
+	return prop, true
+}
+
+func splitUnquoted(value string, sep byte) []string {
+	var parts []string
+	inQuote := false
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '"':
+			inQuote = !inQuote
+		case value[i] == sep && !inQuote:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+func buildEvent(props []property, zones map[string]int, floating *time.Location) (Event, error) {
+	var (
+		event            Event
+		hasStart, hasEnd bool
+		duration         time.Duration
+		rrule            string
+	)
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Summary = unescapeText(prop.value)
+		case "DESCRIPTION":
+			event.Description = unescapeText(prop.value)
+		case "LOCATION":
+			event.Location = unescapeText(prop.value)
+		case "URL":
+			event.URL = prop.value
+		case "DTSTART":
+			start, allDay, zone, err := parseTime(prop, zones, floating)
+			if err != nil {
+				return Event{}, err
+			}
+			event.Start, event.AllDay, event.TimeZone, hasStart = start, allDay, zone, true
+		case "DTEND":
+			end, _, _, err := parseTime(prop, zones, floating)
+			if err != nil {
+				return Event{}, err
+			}
+			event.End, hasEnd = end, true
+		case "DURATION":
+			d, err := parseDuration(prop.value)
+			if err != nil {
+				return Event{}, err
+			}
+			duration = d
+		case "RRULE":
+			rrule = prop.value
+		case "ORGANIZER":
+			organizer := parseAttendee(prop)
+			event.Organizer = &organizer
+		case "ATTENDEE":
+			event.Attendees = append(event.Attendees, parseAttendee(prop))
+		case "CREATED":
+			event.Created, _ = time.Parse(utcLayout, prop.value)
+		case "LAST-MODIFIED":
+			event.LastModified, _ = time.Parse(utcLayout, prop.value)
+		}
+	}
+
+	if !hasStart {
+		return Event{}, errors.New("ical: VEVENT without DTSTART")
+	}
+	if !hasEnd {
+		switch {
+		case duration > 0:
+			event.End = event.Start.Add(duration)
+		case event.AllDay:
+			event.End = event.Start.AddDate(0, 0, 1)
+		default:
+			event.End = event.Start
+		}
+	}
+	if rrule != "" {
+		recurrence, err := parseRecurrence(rrule, floating)
+		if err != nil {
+			return Event{}, err
+		}
+		event.Recurrence = &recurrence
+	}
+	return event, nil
+}
+
+func parseTime(prop property, zones map[string]int, floating *time.Location) (time.Time, bool, string, error) {
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, prop.value)
+		if err != nil {
+			return time.Time{}, false, "", fmt.Errorf("ical: invalid %s %q", prop.name, prop.value)
+		}
+		return t, true, "", nil
+	}
+	if strings.HasSuffix(prop.value, "Z") {
+		t, err := time.Parse(utcLayout, prop.value)
+		if err != nil {
+			return time.Time{}, false, "", fmt.Errorf("ical: invalid %s %q", prop.name, prop.value)
+		}
+		return t, false, "", nil
+	}
+
+	loc := floating
+	zone := ""
+	if tzid := prop.params[timeZoneParam]; tzid != "" {
+		if known, err := time.LoadLocation(tzid); err == nil {
+			loc, zone = known, tzid
+		} else if offset, ok := zones[tzid]; ok {
+			loc = time.FixedZone(tzid, offset)
+		}
+	}
+	t, err := time.ParseInLocation(localLayout, prop.value, loc)
+	if err != nil {
+		return time.Time{}, false, "", fmt.Errorf("ical: invalid %s %q", prop.name, prop.value)
+	}
+	return t, false, zone, nil
+}
+
+// parseDuration reads the dur-value form ([+-]PnW or [+-]PnDTnHnMnS).
+func parseDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("ical: invalid DURATION %q", value)
+	rest := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "-")
+	negative := strings.HasPrefix(value, "-")
+	if !strings.HasPrefix(rest, "P") {
+		return 0, invalid
+	}
+	rest = rest[1:]
+
+	var total time.Duration
+	inTime := false
+	number := ""
+	for _, c := range rest {
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, invalid
+		}
+		number = ""
+		switch {
+		case c == 'W' && !inTime:
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			total += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, invalid
+		}
+	}
+	if number != "" {
+		return 0, invalid
+	}
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+func parseAttendee(prop property) Attendee {
+	email := prop.value
+	if len(email) >= len(mailtoPrefix) && strings.EqualFold(email[:len(mailtoPrefix)], mailtoPrefix) {
+		email = email[len(mailtoPrefix):]
+	}
+	return Attendee{Email: strings.ToLower(strings.TrimSpace(email)), Name: prop.params["CN"]}
+}
+
+func parseRecurrence(value string, floating *time.Location) (Recurrence, error) {
+	var recurrence Recurrence
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			recurrence.Frequency = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("ical: invalid RRULE INTERVAL %q", val)
+			}
+			recurrence.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("ical: invalid RRULE COUNT %q", val)
+			}
+			recurrence.Count = n
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				code = strings.ToUpper(strings.TrimLeft(code, "+-0123456789"))
+				day, ok := weekdayFromCode(code)
+				if !ok {
+					return Recurrence{}, fmt.Errorf("ical: invalid RRULE BYDAY %q", val)
+				}
+				recurrence.ByDay = append(recurrence.ByDay, day)
+			}
+		case "UNTIL":
+			until, _, _, err := parseTime(property{name: "UNTIL", value: val}, nil, floating)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			recurrence.Until = &until
+		}
+	}
+	if recurrence.Frequency == "" {
+		return Recurrence{}, fmt.Errorf("ical: RRULE without FREQ")
+	}
+	return recurrence, nil
+}
+
+func weekdayFromCode(code string) (time.Weekday, bool) {
+	for i, candidate := range weekdayCodes {
+		if candidate == code {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,171 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	until := time.Date(2024, 6, 30, 14, 59, 59, 0, time.UTC)
+	event := Event{
+		UID:          "sched-1",
+		Summary:      "定例, 週次; 確認",
+		Description:  "line one\nline two",
+		Location:     "会議室A",
+		Start:        time.Date(2024, 4, 1, 10, 0, 0, 0, tokyo),
+		End:          time.Date(2024, 4, 1, 11, 0, 0, 0, tokyo),
+		TimeZone:     "Asia/Tokyo",
+		Organizer:    &Attendee{Email: "alice@example.com", Name: "Alice"},
+		Attendees:    []Attendee{{Email: "bob@example.com", Name: "Bob: Sales"}},
+		Recurrence:   &Recurrence{Frequency: "WEEKLY", ByDay: []time.Weekday{time.Monday, time.Thursday}, Until: &until},
+		LastModified: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, []Event{event}); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	body := buf.String()
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Tokyo\r\n",
+		"TZOFFSETTO:+0900\r\n",
+		"DTSTART;TZID=Asia/Tokyo:20240401T100000\r\n",
+		`SUMMARY:定例\, 週次\; 確認`,
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20240630T145959Z\r\n",
+		`ATTENDEE;CN="Bob: Sales":mailto:bob@example.com`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected encoded calendar to contain %q:\n%s", want, body)
+		}
+	}
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line exceeds 75 octets: %q", line)
+		}
+	}
+
+	events, err := Decode(strings.NewReader(body), nil)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one event, got %d", len(events))
+	}
+	got := events[0]
+	if got.UID != event.UID || got.Summary != event.Summary || got.Description != event.Description || got.Location != event.Location {
+		t.Fatalf("unexpected text fields: %#v", got)
+	}
+	if !got.Start.Equal(event.Start) || !got.End.Equal(event.End) || got.TimeZone != "Asia/Tokyo" || got.AllDay {
+		t.Fatalf("unexpected times: %v - %v (%s)", got.Start, got.End, got.TimeZone)
+	}
+	if got.Organizer == nil || got.Organizer.Email != "alice@example.com" || len(got.Attendees) != 1 || got.Attendees[0].Name != "Bob: Sales" {
+		t.Fatalf("unexpected attendees: %#v %#v", got.Organizer, got.Attendees)
+	}
+	if got.Recurrence == nil || got.Recurrence.Frequency != "WEEKLY" || len(got.Recurrence.ByDay) != 2 || !got.Recurrence.Until.Equal(until) {
+		t.Fatalf("unexpected recurrence: %#v", got.Recurrence)
+	}
+}
+
+func TestEncodeDaylightSavingTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	event := Event{
+		UID:      "dst",
+		Summary:  "standup",
+		Start:    time.Date(2024, 7, 1, 9, 0, 0, 0, newYork),
+		End:      time.Date(2024, 7, 1, 9, 15, 0, 0, newYork),
+		TimeZone: "America/New_York",
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, []Event{event}); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	body := buf.String()
+	for _, want := range []string{
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240310T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20241103T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected encoded calendar to contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestDecodeClientVariants(t *testing.T) {
+	const body = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Custom Tokyo\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:19700101T000000\r\n" +
+		"TZOFFSETFROM:+0900\r\n" +
+		"TZOFFSETTO:+0900\r\n" +
+		"END:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:client-1\r\n" +
+		"SUMMARY:Long summary that a client\r\n" +
+		"  folded\r\n" +
+		"DTSTART;TZID=Custom Tokyo:20240401T100000\r\n" +
+		"DURATION:PT1H30M\r\n" +
+		"ATTENDEE;CN=Bob;PARTSTAT=ACCEPTED:MAILTO:Bob@Example.com\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"SUMMARY:ignored\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:client-2\r\n" +
+		"DTSTART;VALUE=DATE:20240402\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := Decode(strings.NewReader(body), nil)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected two events, got %d", len(events))
+	}
+
+	timed := events[0]
+	if timed.Summary != "Long summary that a client folded" {
+		t.Fatalf("unexpected unfolded summary %q", timed.Summary)
+	}
+	wantStart := time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)
+	if !timed.Start.Equal(wantStart) || !timed.End.Equal(wantStart.Add(90*time.Minute)) {
+		t.Fatalf("unexpected times: %v - %v", timed.Start, timed.End)
+	}
+	if len(timed.Attendees) != 1 || timed.Attendees[0].Email != "bob@example.com" {
+		t.Fatalf("unexpected attendees: %#v", timed.Attendees)
+	}
+
+	allDay := events[1]
+	if !allDay.AllDay || !allDay.End.Equal(time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected a one-day all-day event, got %#v", allDay)
+	}
+}
+
+func TestDecodeRejectsInvalidObjects(t *testing.T) {
+	cases := map[string]string{
+		"no event":  "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+		"no start":  "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"bad start": "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"bad rrule": "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nDTSTART:20240401T000000Z\r\nRRULE:BYDAY=XX\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(body), nil); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
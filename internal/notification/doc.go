@@ -0,0 +1,9 @@
+// Package notification delivers schedule reminders through pluggable channels
+// and posts signed event webhooks.
+//
+// A Channel sends one Message to one recipient. SMTPChannel renders the reminder
+// as a plain-text UTF-8 mail; WebhookChannel posts it as JSON to a fixed URL.
+// SignedPoster posts event payloads with an HMAC-SHA256 signature header so
+// that receivers can verify their origin.
+// Neither retries: delivery state and retries are owned by the caller.
+package notification
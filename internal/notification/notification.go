@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// ChannelEmail names the SMTP channel.
+	ChannelEmail = "email"
+	// ChannelWebhook names the outgoing webhook channel.
+	ChannelWebhook = "webhook"
+)
+
+// Recipient identifies who a reminder is addressed to. Location is used to
+// render times and defaults to UTC.
+type Recipient struct {
+	UserID      string
+	Email       string
+	DisplayName string
+	Location    *time.Location
+}
+
+// Message is a reminder for a single occurrence of a schedule. Start is the
+// occurrence start rather than the start of the series.
+type Message struct {
+	ScheduleID    string
+	Title         string
+	Start         time.Time
+	End           time.Time
+	AllDay        bool
+	MinutesBefore int
+	Recipient     Recipient
+}
+
+// Channel delivers reminder messages.
+type Channel interface {
+	// Name identifies the channel in delivery state and user preferences.
+	Name() string
+	// Send delivers msg once. A nil error means the message was handed off.
+	Send(ctx context.Context, msg Message) error
+}
+
+// location returns the zone used to render msg for its recipient.
+func (m Message) location() *time.Location {
+	if m.Recipient.Location != nil {
+		return m.Recipient.Location
+	}
+	return time.UTC
+}
+
+// subject renders the reminder headline shared by every channel.
+func (m Message) subject() string {
+	return fmt.Sprintf("[リマインダー] %s (%s)", m.Title, m.when())
+}
+
+// when renders the occurrence start in the recipient's zone. All-day schedules
+// hold floating dates and are shown by date only.
+func (m Message) when() string {
+	if m.AllDay {
+		return m.Start.UTC().Format("2006-01-02")
+	}
+	return m.Start.In(m.location()).Format("2006-01-02 15:04 MST")
+}
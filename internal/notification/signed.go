@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers set on signed webhook requests.
+const (
+	HeaderEvent     = "X-Scheduler-Event"
+	HeaderDelivery  = "X-Scheduler-Delivery"
+	HeaderTimestamp = "X-Scheduler-Timestamp"
+	HeaderSignature = "X-Scheduler-Signature"
+)
+
+// SignedRequest is one webhook delivery attempt.
+type SignedRequest struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID string
+	Timestamp  time.Time
+	Body       []byte
+}
+
+// SignedPoster posts event payloads signed with the subscription secret.
+type SignedPoster struct {
+	client *http.Client
+}
+
+// NewSignedPoster constructs a poster. A nil client uses one with a ten second
+// timeout.
+func NewSignedPoster(client *http.Client) *SignedPoster {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &SignedPoster{client: client}
+}
+
+// Sign returns the signature header value for body sent at timestamp:
+// "sha256=" followed by the hex HMAC-SHA256 of "<unix seconds>.<body>".
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Post sends req and returns the response status code. Non-2xx responses are
+// reported as errors alongside their status code.
+func (p *SignedPoster) Post(ctx context.Context, req SignedRequest) (int, error) {
+	if p == nil {
+		return 0, errors.New("signed poster is nil")
+	}
+
+	timestamp := req.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	res, err := p.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
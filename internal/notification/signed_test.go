@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignedPoster_Post(t *testing.T) {
+	body := []byte(`{"type":"schedule.created"}`)
+	sentAt := time.Date(2024, time.May, 10, 1, 0, 0, 0, time.UTC)
+
+	t.Run("signs the body with the subscription secret", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(server.Close)
+
+		status, err := NewSignedPoster(nil).Post(context.Background(), SignedRequest{
+			URL:        server.URL,
+			Secret:     "s3cret",
+			EventType:  "schedule.created",
+			DeliveryID: "delivery-1",
+			Timestamp:  sentAt,
+			Body:       body,
+		})
+		if err != nil || status != http.StatusNoContent {
+			t.Fatalf("unexpected result: %d %v", status, err)
+		}
+		if received.Header.Get(HeaderEvent) != "schedule.created" || received.Header.Get(HeaderDelivery) != "delivery-1" {
+			t.Fatalf("unexpected headers: %v", received.Header)
+		}
+		if received.Header.Get(HeaderTimestamp) != "1715302800" {
+			t.Fatalf("unexpected timestamp header: %q", received.Header.Get(HeaderTimestamp))
+		}
+		if got, want := received.Header.Get(HeaderSignature), Sign("s3cret", sentAt, receivedBody); got != want {
+			t.Fatalf("signature mismatch: got %q want %q", got, want)
+		}
+		if Sign("other", sentAt, body) == Sign("s3cret", sentAt, body) {
+			t.Fatal("expected signatures to depend on the secret")
+		}
+	})
+
+	t.Run("returns the status of failed deliveries", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		status, err := NewSignedPoster(nil).Post(context.Background(), SignedRequest{URL: server.URL, Body: body})
+		if err == nil || status != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503 failure, got %d %v", status, err)
+		}
+	})
+}
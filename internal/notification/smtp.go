@@ -0,0 +1,154 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const defaultSMTPTimeout = 30 * time.Second
+
+// SMTPConfig configures the mail server used by SMTPChannel. Username and
+// Password enable PLAIN authentication, which net/smtp only permits over TLS
+// or to localhost.
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// SMTPChannel sends reminders as plain-text mails. STARTTLS is used whenever
+// the server offers it.
+type SMTPChannel struct {
+	cfg SMTPConfig
+	now func() time.Time
+}
+
+// NewSMTPChannel constructs a mail channel for the given server.
+func NewSMTPChannel(cfg SMTPConfig) *SMTPChannel {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSMTPTimeout
+	}
+	return &SMTPChannel{cfg: cfg, now: time.Now}
+}
+
+// Name implements Channel.
+func (c *SMTPChannel) Name() string { return ChannelEmail }
+
+// Send implements Channel.
+func (c *SMTPChannel) Send(ctx context.Context, msg Message) error {
+	if c == nil {
+		return errors.New("smtp channel is nil")
+	}
+	to := strings.TrimSpace(msg.Recipient.Email)
+	if to == "" {
+		return fmt.Errorf("recipient %s has no email address", msg.Recipient.UserID)
+	}
+
+	host, _, err := net.SplitHostPort(c.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid smtp address %q: %w", c.cfg.Addr, err)
+	}
+
+	dialer := net.Dialer{Timeout: c.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	deadline := time.Now().Add(c.cfg.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if c.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := client.Mail(c.cfg.From); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp RCPT TO: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(c.render(msg, to)); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("write smtp message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// render builds the RFC 5322 message. Headers are MIME-encoded and the body is
+// base64 so Japanese text survives servers without 8BITMIME.
+func (c *SMTPChannel) render(msg Message, to string) []byte {
+	recipient := mail.Address{Name: msg.Recipient.DisplayName, Address: to}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", recipient.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.subject()))
+	fmt.Fprintf(&buf, "Date: %s\r\n", c.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(mailBody(msg)))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
+
+func mailBody(msg Message) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", msg.Title)
+	if msg.AllDay {
+		fmt.Fprintf(&b, "日付: %s（終日）\n", msg.when())
+	} else {
+		loc := msg.location()
+		fmt.Fprintf(&b, "開始: %s\n", msg.Start.In(loc).Format("2006-01-02 15:04 MST"))
+		fmt.Fprintf(&b, "終了: %s\n", msg.End.In(loc).Format("2006-01-02 15:04 MST"))
+	}
+	if msg.MinutesBefore > 0 {
+		fmt.Fprintf(&b, "\n開始 %d 分前のリマインダーです。\n", msg.MinutesBefore)
+	} else {
+		b.WriteString("\n開始時刻になりました。\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts a single session and records the envelope and data.
+type fakeSMTPServer struct {
+	listener net.Listener
+	done     chan struct{}
+
+	from string
+	to   []string
+	data string
+}
+
+func startFakeSMTPServer(t *testing.T, rejectData bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		defer close(server.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 fake.local ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimSpace(line)
+			upper := strings.ToUpper(command)
+			switch {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 fake.local")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				server.from = strings.Trim(command[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				server.to = append(server.to, strings.Trim(command[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				server.data = data.String()
+				if rejectData {
+					reply("554 rejected")
+				} else {
+					reply("250 OK queued")
+				}
+			case upper == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake smtp server did not finish")
+	}
+}
+
+func TestSMTPChannel_Send(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	msg := Message{
+		ScheduleID:    "schedule-1",
+		Title:         "週次定例",
+		Start:         time.Date(2024, time.May, 10, 10, 0, 0, 0, jst),
+		End:           time.Date(2024, time.May, 10, 11, 0, 0, 0, jst),
+		MinutesBefore: 10,
+		Recipient:     Recipient{UserID: "user-1", Email: "alice@example.com", DisplayName: "Alice", Location: jst},
+	}
+
+	t.Run("delivers a mail to the recipient", func(t *testing.T) {
+		server := startFakeSMTPServer(t, false)
+		channel := NewSMTPChannel(SMTPConfig{Addr: server.listener.Addr().String(), From: "scheduler@example.com"})
+
+		if err := channel.Send(context.Background(), msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		server.wait(t)
+
+		if server.from != "scheduler@example.com" {
+			t.Fatalf("unexpected envelope sender %q", server.from)
+		}
+		if len(server.to) != 1 || server.to[0] != "alice@example.com" {
+			t.Fatalf("unexpected envelope recipients %v", server.to)
+		}
+
+		parsed, err := mail.ReadMessage(strings.NewReader(server.data))
+		if err != nil {
+			t.Fatalf("failed to parse mail: %v", err)
+		}
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		if err != nil {
+			t.Fatalf("failed to decode subject: %v", err)
+		}
+		if subject != "[リマインダー] 週次定例 (2024-05-10 10:00 JST)" {
+			t.Fatalf("unexpected subject %q", subject)
+		}
+		raw := new(strings.Builder)
+		if _, err := bufio.NewReader(parsed.Body).WriteTo(raw); err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(raw.String(), "\r\n", ""))
+		if err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if !strings.Contains(string(body), "開始 10 分前") {
+			t.Fatalf("unexpected body %q", body)
+		}
+	})
+
+	t.Run("reports a rejected message", func(t *testing.T) {
+		server := startFakeSMTPServer(t, true)
+		channel := NewSMTPChannel(SMTPConfig{Addr: server.listener.Addr().String(), From: "scheduler@example.com"})
+
+		if err := channel.Send(context.Background(), msg); err == nil {
+			t.Fatal("expected error for rejected message")
+		}
+		server.wait(t)
+	})
+
+	t.Run("requires a recipient address", func(t *testing.T) {
+		channel := NewSMTPChannel(SMTPConfig{Addr: "127.0.0.1:1", From: "scheduler@example.com"})
+		noAddress := msg
+		noAddress.Recipient.Email = ""
+
+		if err := channel.Send(context.Background(), noAddress); err == nil {
+			t.Fatal("expected error for recipient without email")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookChannel posts reminders as JSON to a fixed URL. Any 2xx response
+// counts as delivered.
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookChannel constructs a webhook channel. A nil client uses one with a
+// ten second timeout.
+func NewWebhookChannel(url string, client *http.Client) *WebhookChannel {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &WebhookChannel{url: url, client: client}
+}
+
+// Name implements Channel.
+func (c *WebhookChannel) Name() string { return ChannelWebhook }
+
+// Send implements Channel.
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	if c == nil {
+		return errors.New("webhook channel is nil")
+	}
+	body, err := json.Marshal(toWebhookPayload(msg))
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Type          string           `json:"type"`
+	ScheduleID    string           `json:"schedule_id"`
+	Title         string           `json:"title"`
+	Start         string           `json:"start"`
+	End           string           `json:"end"`
+	AllDay        bool             `json:"all_day"`
+	MinutesBefore int              `json:"minutes_before"`
+	Text          string           `json:"text"`
+	Recipient     webhookRecipient `json:"recipient"`
+}
+
+type webhookRecipient struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email,omitempty"`
+}
+
+func toWebhookPayload(msg Message) webhookPayload {
+	loc := msg.location()
+	return webhookPayload{
+		Type:          "schedule.reminder",
+		ScheduleID:    msg.ScheduleID,
+		Title:         msg.Title,
+		Start:         msg.Start.In(loc).Format(time.RFC3339),
+		End:           msg.End.In(loc).Format(time.RFC3339),
+		AllDay:        msg.AllDay,
+		MinutesBefore: msg.MinutesBefore,
+		Text:          msg.subject(),
+		Recipient: webhookRecipient{
+			UserID: msg.Recipient.UserID,
+			Email:  msg.Recipient.Email,
+		},
+	}
+}
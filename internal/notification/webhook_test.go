@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookChannel_Send(t *testing.T) {
+	msg := Message{
+		ScheduleID:    "schedule-1",
+		Title:         "週次定例",
+		Start:         time.Date(2024, time.May, 10, 1, 0, 0, 0, time.UTC),
+		End:           time.Date(2024, time.May, 10, 2, 0, 0, 0, time.UTC),
+		MinutesBefore: 10,
+		Recipient:     Recipient{UserID: "user-1", Email: "alice@example.com", Location: time.FixedZone("JST", 9*60*60)},
+	}
+
+	t.Run("posts the reminder as json", func(t *testing.T) {
+		var received webhookPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("failed to decode payload: %v", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		t.Cleanup(server.Close)
+
+		if err := NewWebhookChannel(server.URL, nil).Send(context.Background(), msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if received.Type != "schedule.reminder" || received.ScheduleID != "schedule-1" || received.Recipient.UserID != "user-1" {
+			t.Fatalf("unexpected payload: %#v", received)
+		}
+		if received.Start != "2024-05-10T10:00:00+09:00" || received.MinutesBefore != 10 {
+			t.Fatalf("unexpected timing in payload: %#v", received)
+		}
+	})
+
+	t.Run("treats non-2xx responses as failures", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(server.Close)
+
+		if err := NewWebhookChannel(server.URL, nil).Send(context.Background(), msg); err == nil {
+			t.Fatal("expected error for 502 response")
+		}
+	})
+}
@@ -11,4 +11,6 @@ var (
 	ErrForeignKeyViolation = errors.New("persistence: foreign key violation")
 	// ErrConstraintViolation is returned when a database-level check constraint is violated.
 	ErrConstraintViolation = errors.New("persistence: constraint violation")
+	// ErrVersionConflict is returned when an update names a version the record no longer has.
+	ErrVersionConflict = errors.New("persistence: version conflict")
 )
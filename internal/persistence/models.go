@@ -9,8 +9,16 @@ type User struct {
 	DisplayName  string
 	PasswordHash string
 	IsAdmin      bool
+	TimeZone     string
+	// DepartmentID and ManagerID are empty for users without one.
+	DepartmentID string
+	ManagerID    string
+	Location     string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	// Version increases on every update. UpdateUser only applies when it
+	// matches the stored version; zero skips the check.
+	Version int
 }
 
 // Room represents a meeting room catalog entry.
@@ -22,6 +30,12 @@ type Room struct {
 	Facilities *string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	// Version increases on every update. UpdateRoom only applies when it
+	// matches the stored version; zero skips the check.
+	Version int
+	// DeletedAt and DeletedBy are only set on rooms read from the trash.
+	DeletedAt *time.Time
+	DeletedBy string
 }
 
 // Schedule represents a calendar entry stored in persistence.
@@ -30,25 +44,72 @@ type Schedule struct {
 	Title            string
 	Start            time.Time
 	End              time.Time
+	TimeZone         string
+	AllDay           bool
+	Busy             bool
 	CreatorID        string
 	Memo             *string
 	Participants     []string
+	ReminderMinutes  []int
 	RoomID           *string
 	WebConferenceURL *string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// CalendarID names the shared calendar the schedule belongs to, if any.
+	CalendarID *string
+	// ParticipantGroups lists user groups invited as a live reference; their
+	// current members take part alongside Participants.
+	ParticipantGroups []string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// Version increases on every update. UpdateSchedule only applies when it
+	// matches the stored version; zero skips the check.
+	Version int
+	// DeletedAt and DeletedBy are only set on schedules read from the trash.
+	DeletedAt *time.Time
+	DeletedBy string
+}
+
+// ScheduleChange is one entry of the schedule change log. Sequence increases
+// with every create, update and delete; Deleted marks a hard-deleted schedule.
+type ScheduleChange struct {
+	Sequence   int64
+	ScheduleID string
+	Deleted    bool
+	ChangedAt  time.Time
 }
 
 // RecurrenceRule represents a weekly recurrence configuration for a schedule.
+// HolidayPolicy is empty, "skip" or "next_business_day".
 type RecurrenceRule struct {
-	ID         string
-	ScheduleID string
-	Frequency  int
-	Weekdays   []time.Weekday
-	StartsOn   time.Time
-	EndsOn     *time.Time
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID            string
+	ScheduleID    string
+	Frequency     int
+	Weekdays      []time.Weekday
+	StartsOn      time.Time
+	EndsOn        *time.Time
+	HolidayPolicy string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// WorkingHours represents one weekday working window for a user, in minutes
+// from local midnight of the user's time zone.
+type WorkingHours struct {
+	UserID      string
+	Weekday     time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+// OutOfOffice represents a period during which a user is unavailable.
+type OutOfOffice struct {
+	ID          string
+	UserID      string
+	Start       time.Time
+	End         time.Time
+	Note        *string
+	AutoDecline bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // Session represents an authentication session persisted for a user.
@@ -62,3 +123,239 @@ type Session struct {
 	UpdatedAt   time.Time
 	RevokedAt   *time.Time
 }
+
+// CompanyHoliday represents a company-specific closure day. Date is the calendar
+// date at UTC midnight.
+type CompanyHoliday struct {
+	Date      time.Time
+	Name      string
+	CreatedAt time.Time
+}
+
+// ReminderPreferences stores a user's default reminder offsets and the channels
+// they are reminded through.
+type ReminderPreferences struct {
+	UserID        string
+	MinutesBefore []int
+	Channels      []string
+	UpdatedAt     time.Time
+}
+
+// ReminderDelivery records the delivery state of one reminder. Status is
+// "sending", "sent" or "failed".
+type ReminderDelivery struct {
+	ID              string
+	ScheduleID      string
+	OccurrenceStart time.Time
+	UserID          string
+	MinutesBefore   int
+	Channel         string
+	Status          string
+	Attempts        int
+	LastError       string
+	DueAt           time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// WebhookSubscription stores an outgoing webhook target. An empty EventTypes
+// list subscribes to every event.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// OutboxEvent stores a published event until it is fanned out to webhook
+// subscriptions. DispatchedAt is nil while the event is pending. Sequence is
+// assigned on insert and increases with every appended event.
+type OutboxEvent struct {
+	ID           string
+	Type         string
+	ResourceID   string
+	ActorID      string
+	Payload      []byte
+	OccurredAt   time.Time
+	DispatchedAt *time.Time
+	Sequence     int64
+}
+
+// WebhookDelivery records the attempts to deliver one event to one
+// subscription. Status is "pending", "succeeded" or "failed".
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// AppPassword is a per-device credential for basic auth clients such as
+// CalDAV. Only a hash of the generated password is stored.
+type AppPassword struct {
+	ID         string
+	UserID     string
+	Name       string
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// CalDAVObject records the resource name and iCalendar UID a CalDAV client
+// chose for a schedule so the event keeps its URL across syncs.
+type CalDAVObject struct {
+	ScheduleID   string
+	ResourceName string
+	UID          string
+}
+
+// CalDAVSyncAccount connects a user's calendar to an external CalDAV collection.
+type CalDAVSyncAccount struct {
+	ID             string
+	UserID         string
+	CollectionURL  string
+	Username       string
+	Password       string
+	ConflictPolicy string
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// CalDAVSyncItem maps a remote calendar object to the schedule it was last
+// synced with, recording both sides' ETags at that point.
+type CalDAVSyncItem struct {
+	AccountID  string
+	RemoteHref string
+	RemoteUID  string
+	RemoteETag string
+	ScheduleID string
+	LocalETag  string
+}
+
+// CalDAVSyncRun records the outcome of one sync of an account.
+type CalDAVSyncRun struct {
+	ID            string
+	AccountID     string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	Status        string
+	PulledCreated int
+	PulledUpdated int
+	PulledDeleted int
+	PushedCreated int
+	PushedUpdated int
+	PushedDeleted int
+	Conflicts     int
+	Skipped       int
+	Failures      int
+	Error         string
+}
+
+// ScheduleRevision is one stored version of a schedule. Revision numbers start
+// at 1 and increase by one per schedule; Snapshot is an opaque JSON document.
+type ScheduleRevision struct {
+	ScheduleID string
+	Revision   int
+	ChangedBy  string
+	ChangedAt  time.Time
+	Snapshot   []byte
+}
+
+// IdempotencyRecord is the response remembered for a request sent with an
+// Idempotency-Key. StatusCode stays 0 while the first request is in flight.
+type IdempotencyRecord struct {
+	PrincipalID string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Occurrence is a materialized occurrence of a recurring schedule.
+type Occurrence struct {
+	ScheduleID string
+	RuleID     string
+	Start      time.Time
+	End        time.Time
+}
+
+// OccurrenceRefresh names a schedule whose materialized occurrences must be
+// regenerated, with the generation it had when it was listed.
+type OccurrenceRefresh struct {
+	ScheduleID string
+	Generation int64
+}
+
+// ColleagueGroup is a named, ordered set of users its owner selects together
+// in calendar views. SharedWith lists the other users who may use it.
+type ColleagueGroup struct {
+	ID         string
+	OwnerID    string
+	Name       string
+	MemberIDs  []string
+	SharedWith []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ViewLink is a short code standing for a calendar view, the date it shows
+// and the participants selected in it.
+type ViewLink struct {
+	Code           string
+	CreatorID      string
+	View           string
+	Date           time.Time
+	ParticipantIDs []string
+	CreatedAt      time.Time
+}
+
+// Calendar is a shared calendar not tied to a person. OwnerID may change and
+// delete it; EditorIDs may also add and change its schedules, and ViewerIDs
+// may only see them.
+type Calendar struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	EditorIDs   []string
+	ViewerIDs   []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// UserGroup is a department or distribution list that can be invited to
+// schedules as a unit. OwnerID is empty for groups only administrators manage.
+type UserGroup struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	MemberIDs   []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Department is a unit of the organization. ParentID is empty for top-level
+// departments.
+type Department struct {
+	ID        string
+	Name      string
+	ParentID  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -3,6 +3,29 @@ package persistence
 import "context"
 import "time"
 
+// UserFilter pages through users ordered by email then ID.
+// AfterEmail/AfterID form an exclusive keyset bound; a zero Limit means unbounded.
+type UserFilter struct {
+	AfterEmail string
+	AfterID    string
+	Limit      int
+}
+
+// RoomFilter pages through rooms ordered by case-insensitive name then ID.
+// AfterName/AfterID form an exclusive keyset bound; a zero Limit means unbounded.
+type RoomFilter struct {
+	AfterName string
+	AfterID   string
+	Limit     int
+}
+
+// Transactor groups repository calls into a single transaction. Calls made
+// with the context passed to fn commit together, or roll back together when
+// fn returns an error. Nested calls join the outer transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // UserRepository exposes CRUD operations for users.
 type UserRepository interface {
 	CreateUser(ctx context.Context, user User) error
@@ -10,6 +33,7 @@ type UserRepository interface {
 	GetUser(ctx context.Context, id string) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	ListUsers(ctx context.Context) ([]User, error)
+	ListUsersPage(ctx context.Context, filter UserFilter) ([]User, error)
 	DeleteUser(ctx context.Context, id string) error
 }
 
@@ -19,14 +43,50 @@ type RoomRepository interface {
 	UpdateRoom(ctx context.Context, room Room) error
 	GetRoom(ctx context.Context, id string) (Room, error)
 	ListRooms(ctx context.Context) ([]Room, error)
+	ListRoomsPage(ctx context.Context, filter RoomFilter) ([]Room, error)
 	DeleteRoom(ctx context.Context, id string) error
+	// TrashRoom soft-deletes a room; reads other than the trash methods no
+	// longer see it. RestoreRoom brings it back.
+	TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error
+	RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error
+	GetTrashedRoom(ctx context.Context, id string) (Room, error)
+	// ListTrashedRooms lists rooms trashed by deletedBy, or all trashed rooms
+	// when it is empty, most recently deleted first.
+	ListTrashedRooms(ctx context.Context, deletedBy string) ([]Room, error)
+	// PurgeRooms permanently deletes rooms trashed before deletedBefore and
+	// returns how many were removed.
+	PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error)
 }
 
-// ScheduleFilter narrows schedule queries.
+// ScheduleFilter narrows schedule queries. Results are ordered by start time then ID;
+// AfterStart/AfterID form an exclusive keyset bound and a zero Limit means unbounded.
+// AllDayStartsAfter/AllDayEndsBefore, when set, replace the time bounds for all-day rows.
 type ScheduleFilter struct {
+	IDs               []string
+	ParticipantIDs    []string
+	StartsAfter       *time.Time
+	EndsBefore        *time.Time
+	AllDayStartsAfter *time.Time
+	AllDayEndsBefore  *time.Time
+	RoomID            *string
+	CalendarID        string
+	CreatorID         string
+	TitlePrefix       string
+	UpdatedSince      *time.Time
+	AfterStart        *time.Time
+	AfterID           string
+	Limit             int
+}
+
+// ScheduleConflictFilter selects the schedules that may conflict with a
+// candidate: those with one of ParticipantIDs or RoomID overlapping
+// [Start, End), and recurring ones whose series has not ended by Start.
+type ScheduleConflictFilter struct {
 	ParticipantIDs []string
-	StartsAfter    *time.Time
-	EndsBefore     *time.Time
+	RoomID         *string
+	Start          time.Time
+	End            time.Time
+	ExcludeID      string
 }
 
 // ScheduleRepository stores schedule entries and their participants.
@@ -35,7 +95,30 @@ type ScheduleRepository interface {
 	UpdateSchedule(ctx context.Context, schedule Schedule) error
 	GetSchedule(ctx context.Context, id string) (Schedule, error)
 	ListSchedules(ctx context.Context, filter ScheduleFilter) ([]Schedule, error)
+	ListConflictCandidates(ctx context.Context, filter ScheduleConflictFilter) ([]Schedule, error)
 	DeleteSchedule(ctx context.Context, id string) error
+	ListScheduleChanges(ctx context.Context, filter ScheduleChangeFilter) ([]ScheduleChange, error)
+	// TrashSchedule soft-deletes a schedule; reads other than the trash
+	// methods no longer see it. RestoreSchedule brings it back.
+	TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error
+	RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error
+	GetTrashedSchedule(ctx context.Context, id string) (Schedule, error)
+	// ListTrashedSchedules lists schedules trashed by deletedBy, or all
+	// trashed schedules when it is empty, most recently deleted first.
+	ListTrashedSchedules(ctx context.Context, deletedBy string) ([]Schedule, error)
+	// PurgeSchedules permanently deletes schedules trashed before
+	// deletedBefore and returns how many were removed.
+	PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// ScheduleChangeFilter selects change log entries after AfterSequence that
+// involved any of UserIDs (all entries when empty), in sequence order. A zero
+// Limit means unbounded. An AfterSequence past the end of the log yields
+// ErrNotFound.
+type ScheduleChangeFilter struct {
+	UserIDs       []string
+	AfterSequence int64
+	Limit         int
 }
 
 // RecurrenceRepository stores recurrence rules attached to schedules.
@@ -46,6 +129,182 @@ type RecurrenceRepository interface {
 	DeleteRecurrencesForSchedule(ctx context.Context, scheduleID string) error
 }
 
+// OutOfOfficeFilter narrows out-of-office queries to users and an overlapping time range.
+type OutOfOfficeFilter struct {
+	UserIDs     []string
+	StartsAfter *time.Time
+	EndsBefore  *time.Time
+}
+
+// AvailabilityRepository stores per-user working hours and out-of-office periods.
+type AvailabilityRepository interface {
+	ListWorkingHours(ctx context.Context, userIDs []string) ([]WorkingHours, error)
+	ReplaceWorkingHours(ctx context.Context, userID string, hours []WorkingHours) error
+	CreateOutOfOffice(ctx context.Context, entry OutOfOffice) error
+	GetOutOfOffice(ctx context.Context, id string) (OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, id string) error
+	ListOutOfOffice(ctx context.Context, filter OutOfOfficeFilter) ([]OutOfOffice, error)
+}
+
+// HolidayRepository stores company closure days.
+type HolidayRepository interface {
+	ListCompanyHolidays(ctx context.Context) ([]CompanyHoliday, error)
+	CreateCompanyHoliday(ctx context.Context, holiday CompanyHoliday) error
+	DeleteCompanyHoliday(ctx context.Context, date time.Time) error
+}
+
+// ReminderRepository stores reminder preferences and delivery state.
+type ReminderRepository interface {
+	GetReminderPreferences(ctx context.Context, userID string) (ReminderPreferences, error)
+	SaveReminderPreferences(ctx context.Context, prefs ReminderPreferences) error
+	ListReminderPreferences(ctx context.Context, userIDs []string) ([]ReminderPreferences, error)
+	ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error)
+	ClaimReminderDelivery(ctx context.Context, delivery ReminderDelivery, maxAttempts int, lease time.Duration) (ReminderDelivery, bool, error)
+	FinishReminderDelivery(ctx context.Context, delivery ReminderDelivery) error
+}
+
+// WebhookRepository stores webhook subscriptions, the event outbox and
+// webhook delivery logs.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, subscription WebhookSubscription) error
+	GetWebhook(ctx context.Context, id string) (WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, subscription WebhookSubscription) error
+	DeleteWebhook(ctx context.Context, id string) error
+	ListWebhooks(ctx context.Context) ([]WebhookSubscription, error)
+	AppendOutboxEvent(ctx context.Context, event OutboxEvent) error
+	ListPendingOutboxEvents(ctx context.Context, limit int) ([]OutboxEvent, error)
+	ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]OutboxEvent, error)
+	LatestOutboxSequence(ctx context.Context) (int64, error)
+	EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []WebhookDelivery, dispatchedAt time.Time) error
+	CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, id string) (WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]WebhookDelivery, error)
+}
+
+// AppPasswordRepository stores app passwords used for basic auth.
+type AppPasswordRepository interface {
+	CreateAppPassword(ctx context.Context, password AppPassword) error
+	GetAppPasswordByHash(ctx context.Context, tokenHash string) (AppPassword, error)
+	ListAppPasswords(ctx context.Context, userID string) ([]AppPassword, error)
+	DeleteAppPassword(ctx context.Context, userID, id string) error
+	TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// CalDAVObjectRepository maps schedules to the CalDAV resources clients created.
+type CalDAVObjectRepository interface {
+	SaveCalDAVObject(ctx context.Context, object CalDAVObject) error
+	GetCalDAVObjectByName(ctx context.Context, resourceName string) (CalDAVObject, error)
+	ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]CalDAVObject, error)
+}
+
+// CalDAVSyncRepository stores external CalDAV sync accounts, their object
+// mappings and run history.
+type CalDAVSyncRepository interface {
+	CreateCalDAVSyncAccount(ctx context.Context, account CalDAVSyncAccount) error
+	GetCalDAVSyncAccount(ctx context.Context, id string) (CalDAVSyncAccount, error)
+	ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]CalDAVSyncAccount, error)
+	DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error
+	ListCalDAVSyncItems(ctx context.Context, accountID string) ([]CalDAVSyncItem, error)
+	SaveCalDAVSyncItem(ctx context.Context, item CalDAVSyncItem) error
+	DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error
+	CreateCalDAVSyncRun(ctx context.Context, run CalDAVSyncRun) error
+	ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]CalDAVSyncRun, error)
+}
+
+// ScheduleRevisionRepository stores the version history of schedules.
+// AppendScheduleRevision assigns the next revision number and returns it;
+// ListScheduleRevisions returns revisions in ascending order.
+type ScheduleRevisionRepository interface {
+	AppendScheduleRevision(ctx context.Context, revision ScheduleRevision) (int, error)
+	ListScheduleRevisions(ctx context.Context, scheduleID string) ([]ScheduleRevision, error)
+	GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (ScheduleRevision, error)
+}
+
+// IdempotencyRepository stores the responses replayed for retried requests.
+// ReserveIdempotencyKey inserts record unless an unexpired record already holds
+// its key, in which case it returns that record and false.
+type IdempotencyRepository interface {
+	ReserveIdempotencyKey(ctx context.Context, record IdempotencyRecord) (IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, record IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error
+}
+
+// OccurrenceRepository stores materialized occurrences of recurring schedules
+// together with the horizon each schedule has been materialized to.
+// MarkOccurrencesStale bumps a schedule's generation; ReplaceOccurrences
+// returns ErrVersionConflict when the generation moved since the refresh was
+// listed. ListOccurrences returns only schedules that are fresh and
+// materialized to at least End, keyed by schedule ID.
+type OccurrenceRepository interface {
+	MarkOccurrencesStale(ctx context.Context, scheduleID string) error
+	MarkHolidayOccurrencesStale(ctx context.Context) error
+	ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]OccurrenceRefresh, error)
+	ReplaceOccurrences(ctx context.Context, refresh OccurrenceRefresh, until time.Time, occurrences []Occurrence) error
+	ListOccurrences(ctx context.Context, filter OccurrenceFilter) (map[string][]Occurrence, error)
+	ResetOccurrences(ctx context.Context) (int, error)
+}
+
+// OccurrenceFilter selects the materialized occurrences of ScheduleIDs that
+// overlap [Start, End). A nil Start or End leaves that side open.
+type OccurrenceFilter struct {
+	ScheduleIDs []string
+	Start       *time.Time
+	End         *time.Time
+}
+
+// ColleagueGroupRepository stores colleague groups with their members and
+// shares. ListColleagueGroups returns the groups a user owns or that are
+// shared with them, ordered by name.
+type ColleagueGroupRepository interface {
+	CreateColleagueGroup(ctx context.Context, group ColleagueGroup) error
+	GetColleagueGroup(ctx context.Context, id string) (ColleagueGroup, error)
+	UpdateColleagueGroup(ctx context.Context, group ColleagueGroup) error
+	DeleteColleagueGroup(ctx context.Context, id string) error
+	ListColleagueGroups(ctx context.Context, userID string) ([]ColleagueGroup, error)
+}
+
+// ViewLinkRepository stores shareable view links by code.
+type ViewLinkRepository interface {
+	CreateViewLink(ctx context.Context, link ViewLink) error
+	GetViewLink(ctx context.Context, code string) (ViewLink, error)
+}
+
+// CalendarRepository stores shared calendars with their editors and viewers.
+// ListCalendars returns the calendars a user owns, edits or views, ordered by
+// name.
+type CalendarRepository interface {
+	CreateCalendar(ctx context.Context, calendar Calendar) error
+	GetCalendar(ctx context.Context, id string) (Calendar, error)
+	UpdateCalendar(ctx context.Context, calendar Calendar) error
+	DeleteCalendar(ctx context.Context, id string) error
+	ListCalendars(ctx context.Context, userID string) ([]Calendar, error)
+}
+
+// UserGroupRepository stores user groups with their members. ListUserGroups
+// returns every group ordered by name.
+type UserGroupRepository interface {
+	CreateUserGroup(ctx context.Context, group UserGroup) error
+	GetUserGroup(ctx context.Context, id string) (UserGroup, error)
+	UpdateUserGroup(ctx context.Context, group UserGroup) error
+	DeleteUserGroup(ctx context.Context, id string) error
+	ListUserGroups(ctx context.Context) ([]UserGroup, error)
+}
+
+// DepartmentRepository stores departments and answers reporting-line queries.
+// ListDepartments returns every department ordered by name;
+// ListDepartmentMembers and ListDirectReports return user IDs in order.
+type DepartmentRepository interface {
+	CreateDepartment(ctx context.Context, department Department) error
+	GetDepartment(ctx context.Context, id string) (Department, error)
+	UpdateDepartment(ctx context.Context, department Department) error
+	DeleteDepartment(ctx context.Context, id string) error
+	ListDepartments(ctx context.Context) ([]Department, error)
+	ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error)
+	ListDirectReports(ctx context.Context, managerID string) ([]string, error)
+}
+
 // SessionRepository stores authentication session state.
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session Session) (Session, error)
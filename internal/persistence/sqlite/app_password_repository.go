@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// AppPasswordRepository implements persistence.AppPasswordRepository using SQLite
+type AppPasswordRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewAppPasswordRepository creates a new SQLite app password repository
+func NewAppPasswordRepository(pool *ConnectionPool) *AppPasswordRepository {
+	return &AppPasswordRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateAppPassword inserts a new app password
+func (r *AppPasswordRepository) CreateAppPassword(ctx context.Context, password persistence.AppPassword) error {
+	if password.ID == "" || password.UserID == "" || password.TokenHash == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO app_passwords (id, user_id, name, token_hash, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		password.ID,
+		password.UserID,
+		password.Name,
+		password.TokenHash,
+		password.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		errStr := err.Error()
+		if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetAppPasswordByHash retrieves the app password with the given token hash
+func (r *AppPasswordRepository) GetAppPasswordByHash(ctx context.Context, tokenHash string) (persistence.AppPassword, error) {
+	if tokenHash == "" {
+		return persistence.AppPassword{}, persistence.ErrNotFound
+	}
+
+	row := r.helper.QueryRow(ctx,
+		"SELECT id, user_id, name, token_hash, created_at, last_used_at FROM app_passwords WHERE token_hash = ?",
+		tokenHash,
+	)
+	password, err := scanAppPassword(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.AppPassword{}, persistence.ErrNotFound
+		}
+		return persistence.AppPassword{}, r.mapper.MapError(err)
+	}
+	return password, nil
+}
+
+// ListAppPasswords lists a user's app passwords, oldest first
+func (r *AppPasswordRepository) ListAppPasswords(ctx context.Context, userID string) ([]persistence.AppPassword, error) {
+	rows, err := r.helper.Query(ctx, `
+		SELECT id, user_id, name, token_hash, created_at, last_used_at
+		FROM app_passwords
+		WHERE user_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, userID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var passwords []persistence.AppPassword
+	for rows.Next() {
+		password, err := scanAppPassword(rows)
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		passwords = append(passwords, password)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return passwords, nil
+}
+
+// DeleteAppPassword removes one of a user's app passwords
+func (r *AppPasswordRepository) DeleteAppPassword(ctx context.Context, userID, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM app_passwords WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// TouchAppPassword records when an app password was last used
+func (r *AppPasswordRepository) TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := r.helper.Exec(ctx,
+		"UPDATE app_passwords SET last_used_at = ? WHERE id = ?",
+		usedAt.UTC().Format(time.RFC3339), id,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+func scanAppPassword(row rowScanner) (persistence.AppPassword, error) {
+	var password persistence.AppPassword
+	var createdAtStr string
+	var lastUsedAt sql.NullString
+	if err := row.Scan(&password.ID, &password.UserID, &password.Name, &password.TokenHash, &createdAtStr, &lastUsedAt); err != nil {
+		return persistence.AppPassword{}, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.AppPassword{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	password.CreatedAt = createdAt
+
+	if lastUsedAt.Valid {
+		usedAt, err := time.Parse(time.RFC3339, lastUsedAt.String)
+		if err != nil {
+			return persistence.AppPassword{}, fmt.Errorf("failed to parse last_used_at: %w", err)
+		}
+		password.LastUsedAt = &usedAt
+	}
+	return password, nil
+}
@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// AvailabilityRepository implements persistence.AvailabilityRepository using SQLite
+type AvailabilityRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewAvailabilityRepository creates a new SQLite availability repository
+func NewAvailabilityRepository(pool *ConnectionPool) *AvailabilityRepository {
+	return &AvailabilityRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// ListWorkingHours returns the working windows of the given users ordered by user, weekday and start
+func (r *AvailabilityRepository) ListWorkingHours(ctx context.Context, userIDs []string) ([]persistence.WorkingHours, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inPlaceholders(userIDs)
+	query := fmt.Sprintf(`
+		SELECT user_id, weekday, start_minute, end_minute
+		FROM working_hours
+		WHERE user_id IN (%s)
+		ORDER BY user_id ASC, weekday ASC, start_minute ASC
+	`, placeholders)
+
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var hours []persistence.WorkingHours
+	for rows.Next() {
+		var window persistence.WorkingHours
+		var weekday int
+		if err := rows.Scan(&window.UserID, &weekday, &window.StartMinute, &window.EndMinute); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		window.Weekday = time.Weekday(weekday)
+		hours = append(hours, window)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return hours, nil
+}
+
+// ReplaceWorkingHours atomically swaps the user's working windows
+func (r *AvailabilityRepository) ReplaceWorkingHours(ctx context.Context, userID string, hours []persistence.WorkingHours) error {
+	if strings.TrimSpace(userID) == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM working_hours WHERE user_id = ?", userID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		for _, window := range hours {
+			_, err := r.helper.ExecTx(tx,
+				"INSERT INTO working_hours (user_id, weekday, start_minute, end_minute) VALUES (?, ?, ?, ?)",
+				userID, int(window.Weekday), window.StartMinute, window.EndMinute,
+			)
+			if err != nil {
+				return r.mapper.MapError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateOutOfOffice inserts a new out-of-office period
+func (r *AvailabilityRepository) CreateOutOfOffice(ctx context.Context, entry persistence.OutOfOffice) error {
+	if entry.ID == "" || entry.UserID == "" {
+		return persistence.ErrConstraintViolation
+	}
+	if !entry.Start.Before(entry.End) {
+		return persistence.ErrConstraintViolation
+	}
+
+	var note sql.NullString
+	if entry.Note != nil {
+		note.String = *entry.Note
+		note.Valid = true
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO out_of_office (id, user_id, start_time, end_time, note, auto_decline, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		entry.ID,
+		entry.UserID,
+		entry.Start.UTC().Format(time.RFC3339),
+		entry.End.UTC().Format(time.RFC3339),
+		note,
+		entry.AutoDecline,
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		entry.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetOutOfOffice retrieves an out-of-office period by ID
+func (r *AvailabilityRepository) GetOutOfOffice(ctx context.Context, id string) (persistence.OutOfOffice, error) {
+	if id == "" {
+		return persistence.OutOfOffice{}, persistence.ErrNotFound
+	}
+
+	entries, err := r.queryOutOfOffice(ctx, `
+		SELECT id, user_id, start_time, end_time, note, auto_decline, created_at, updated_at
+		FROM out_of_office
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		return persistence.OutOfOffice{}, err
+	}
+	if len(entries) == 0 {
+		return persistence.OutOfOffice{}, persistence.ErrNotFound
+	}
+	return entries[0], nil
+}
+
+// DeleteOutOfOffice removes an out-of-office period by ID
+func (r *AvailabilityRepository) DeleteOutOfOffice(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM out_of_office WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListOutOfOffice lists out-of-office periods overlapping the filter range ordered by start time
+func (r *AvailabilityRepository) ListOutOfOffice(ctx context.Context, filter persistence.OutOfOfficeFilter) ([]persistence.OutOfOffice, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.UserIDs) > 0 {
+		placeholders, userArgs := inPlaceholders(filter.UserIDs)
+		conditions = append(conditions, fmt.Sprintf("user_id IN (%s)", placeholders))
+		args = append(args, userArgs...)
+	}
+	if filter.StartsAfter != nil {
+		conditions = append(conditions, "end_time > ?")
+		args = append(args, filter.StartsAfter.UTC().Format(time.RFC3339))
+	}
+	if filter.EndsBefore != nil {
+		conditions = append(conditions, "start_time < ?")
+		args = append(args, filter.EndsBefore.UTC().Format(time.RFC3339))
+	}
+
+	query := `
+		SELECT id, user_id, start_time, end_time, note, auto_decline, created_at, updated_at
+		FROM out_of_office
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY start_time ASC, id ASC"
+
+	return r.queryOutOfOffice(ctx, query, args...)
+}
+
+func (r *AvailabilityRepository) queryOutOfOffice(ctx context.Context, query string, args ...interface{}) ([]persistence.OutOfOffice, error) {
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var entries []persistence.OutOfOffice
+	for rows.Next() {
+		var entry persistence.OutOfOffice
+		var startStr, endStr, createdAtStr, updatedAtStr string
+		var note sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.UserID, &startStr, &endStr, &note, &entry.AutoDecline, &createdAtStr, &updatedAtStr); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		if note.Valid {
+			entry.Note = &note.String
+		}
+		if entry.Start, err = time.Parse(time.RFC3339, startStr); err != nil {
+			return nil, fmt.Errorf("failed to parse start_time: %w", err)
+		}
+		if entry.End, err = time.Parse(time.RFC3339, endStr); err != nil {
+			return nil, fmt.Errorf("failed to parse end_time: %w", err)
+		}
+		if entry.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		if entry.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse updated_at: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return entries, nil
+}
+
+// inPlaceholders renders "?, ?, ?" for an IN clause together with its arguments
+func inPlaceholders(values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// CalDAVObjectRepository implements persistence.CalDAVObjectRepository using SQLite
+type CalDAVObjectRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewCalDAVObjectRepository creates a new SQLite CalDAV object repository
+func NewCalDAVObjectRepository(pool *ConnectionPool) *CalDAVObjectRepository {
+	return &CalDAVObjectRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// SaveCalDAVObject records or replaces the resource name and UID of a schedule
+func (r *CalDAVObjectRepository) SaveCalDAVObject(ctx context.Context, object persistence.CalDAVObject) error {
+	if object.ScheduleID == "" || object.ResourceName == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO caldav_objects (schedule_id, resource_name, uid)
+		VALUES (?, ?, ?)
+		ON CONFLICT(schedule_id) DO UPDATE SET resource_name = excluded.resource_name, uid = excluded.uid
+	`, object.ScheduleID, object.ResourceName, object.UID)
+	if err != nil {
+		errStr := err.Error()
+		if containsAny(errStr, []string{"UNIQUE constraint failed"}) {
+			return persistence.ErrDuplicate
+		}
+		if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetCalDAVObjectByName retrieves the mapping for a resource name
+func (r *CalDAVObjectRepository) GetCalDAVObjectByName(ctx context.Context, resourceName string) (persistence.CalDAVObject, error) {
+	if resourceName == "" {
+		return persistence.CalDAVObject{}, persistence.ErrNotFound
+	}
+
+	var object persistence.CalDAVObject
+	err := r.helper.QueryRow(ctx,
+		"SELECT schedule_id, resource_name, uid FROM caldav_objects WHERE resource_name = ?",
+		resourceName,
+	).Scan(&object.ScheduleID, &object.ResourceName, &object.UID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.CalDAVObject{}, persistence.ErrNotFound
+		}
+		return persistence.CalDAVObject{}, r.mapper.MapError(err)
+	}
+	return object, nil
+}
+
+// ListCalDAVObjects returns the mappings recorded for the given schedules
+func (r *CalDAVObjectRepository) ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]persistence.CalDAVObject, error) {
+	if len(scheduleIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(scheduleIDs))
+	args := make([]any, len(scheduleIDs))
+	for i, id := range scheduleIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := r.helper.Query(ctx,
+		"SELECT schedule_id, resource_name, uid FROM caldav_objects WHERE schedule_id IN ("+strings.Join(placeholders, ", ")+")",
+		args...,
+	)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var objects []persistence.CalDAVObject
+	for rows.Next() {
+		var object persistence.CalDAVObject
+		if err := rows.Scan(&object.ScheduleID, &object.ResourceName, &object.UID); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		objects = append(objects, object)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return objects, nil
+}
@@ -0,0 +1,353 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// maxCalDAVSyncRunsPerAccount bounds the run history kept for each account.
+const maxCalDAVSyncRunsPerAccount = 100
+
+const calDAVSyncAccountColumns = `
+			id, user_id, collection_url, username, password, conflict_policy, active, created_at, updated_at`
+
+const calDAVSyncRunColumns = `
+			id, account_id, started_at, finished_at, status,
+			pulled_created, pulled_updated, pulled_deleted,
+			pushed_created, pushed_updated, pushed_deleted,
+			conflicts, skipped, failures, error`
+
+// CalDAVSyncRepository implements persistence.CalDAVSyncRepository using SQLite
+type CalDAVSyncRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewCalDAVSyncRepository creates a new SQLite CalDAV sync repository
+func NewCalDAVSyncRepository(pool *ConnectionPool) *CalDAVSyncRepository {
+	return &CalDAVSyncRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateCalDAVSyncAccount inserts a new sync account
+func (r *CalDAVSyncRepository) CreateCalDAVSyncAccount(ctx context.Context, account persistence.CalDAVSyncAccount) error {
+	if account.ID == "" || account.UserID == "" || account.CollectionURL == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO caldav_sync_accounts (id, user_id, collection_url, username, password, conflict_policy, active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		account.ID,
+		account.UserID,
+		account.CollectionURL,
+		account.Username,
+		account.Password,
+		account.ConflictPolicy,
+		account.Active,
+		account.CreatedAt.UTC().Format(time.RFC3339),
+		account.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		errStr := err.Error()
+		if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetCalDAVSyncAccount retrieves a sync account by ID
+func (r *CalDAVSyncRepository) GetCalDAVSyncAccount(ctx context.Context, id string) (persistence.CalDAVSyncAccount, error) {
+	row := r.helper.QueryRow(ctx, `
+		SELECT`+calDAVSyncAccountColumns+`
+		FROM caldav_sync_accounts
+		WHERE id = ?
+	`, id)
+	account, err := scanCalDAVSyncAccount(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.CalDAVSyncAccount{}, persistence.ErrNotFound
+		}
+		return persistence.CalDAVSyncAccount{}, r.mapper.MapError(err)
+	}
+	return account, nil
+}
+
+// ListCalDAVSyncAccounts lists a user's sync accounts, oldest first. An empty
+// userID lists every account.
+func (r *CalDAVSyncRepository) ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]persistence.CalDAVSyncAccount, error) {
+	query := `
+		SELECT` + calDAVSyncAccountColumns + `
+		FROM caldav_sync_accounts`
+	var args []interface{}
+	if userID != "" {
+		query += " WHERE user_id = ?"
+		args = append(args, userID)
+	}
+	query += " ORDER BY created_at ASC, id ASC"
+
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var accounts []persistence.CalDAVSyncAccount
+	for rows.Next() {
+		account, err := scanCalDAVSyncAccount(rows)
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return accounts, nil
+}
+
+// DeleteCalDAVSyncAccount removes one of a user's sync accounts along with its
+// mappings and run history
+func (r *CalDAVSyncRepository) DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM caldav_sync_accounts WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListCalDAVSyncItems lists the object mappings of a sync account
+func (r *CalDAVSyncRepository) ListCalDAVSyncItems(ctx context.Context, accountID string) ([]persistence.CalDAVSyncItem, error) {
+	rows, err := r.helper.Query(ctx, `
+		SELECT account_id, remote_href, remote_uid, remote_etag, schedule_id, local_etag
+		FROM caldav_sync_items
+		WHERE account_id = ?
+		ORDER BY remote_href ASC
+	`, accountID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var items []persistence.CalDAVSyncItem
+	for rows.Next() {
+		var item persistence.CalDAVSyncItem
+		if err := rows.Scan(&item.AccountID, &item.RemoteHref, &item.RemoteUID, &item.RemoteETag, &item.ScheduleID, &item.LocalETag); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return items, nil
+}
+
+// SaveCalDAVSyncItem inserts or replaces the mapping for item.RemoteHref
+func (r *CalDAVSyncRepository) SaveCalDAVSyncItem(ctx context.Context, item persistence.CalDAVSyncItem) error {
+	if item.AccountID == "" || item.RemoteHref == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO caldav_sync_items (account_id, remote_href, remote_uid, remote_etag, schedule_id, local_etag)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_id, remote_href) DO UPDATE SET
+			remote_uid = excluded.remote_uid,
+			remote_etag = excluded.remote_etag,
+			schedule_id = excluded.schedule_id,
+			local_etag = excluded.local_etag
+	`, item.AccountID, item.RemoteHref, item.RemoteUID, item.RemoteETag, item.ScheduleID, item.LocalETag)
+	if err != nil {
+		if containsAny(err.Error(), []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// DeleteCalDAVSyncItem removes the mapping for a remote object. Missing
+// mappings are ignored.
+func (r *CalDAVSyncRepository) DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error {
+	_, err := r.helper.Exec(ctx, "DELETE FROM caldav_sync_items WHERE account_id = ? AND remote_href = ?", accountID, remoteHref)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// CreateCalDAVSyncRun records a sync run and prunes the account's history to
+// the most recent runs
+func (r *CalDAVSyncRepository) CreateCalDAVSyncRun(ctx context.Context, run persistence.CalDAVSyncRun) error {
+	if run.ID == "" || run.AccountID == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	var errorText sql.NullString
+	if run.Error != "" {
+		errorText = sql.NullString{String: run.Error, Valid: true}
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO caldav_sync_runs (`+calDAVSyncRunColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		run.ID,
+		run.AccountID,
+		run.StartedAt.UTC().Format(time.RFC3339),
+		run.FinishedAt.UTC().Format(time.RFC3339),
+		run.Status,
+		run.PulledCreated,
+		run.PulledUpdated,
+		run.PulledDeleted,
+		run.PushedCreated,
+		run.PushedUpdated,
+		run.PushedDeleted,
+		run.Conflicts,
+		run.Skipped,
+		run.Failures,
+		errorText,
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+
+	_, err = r.helper.Exec(ctx, `
+		DELETE FROM caldav_sync_runs
+		WHERE account_id = ? AND id NOT IN (
+			SELECT id FROM caldav_sync_runs
+			WHERE account_id = ?
+			ORDER BY started_at DESC, id DESC
+			LIMIT ?
+		)
+	`, run.AccountID, run.AccountID, maxCalDAVSyncRunsPerAccount)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// ListCalDAVSyncRuns returns an account's most recent runs first
+func (r *CalDAVSyncRepository) ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]persistence.CalDAVSyncRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := r.helper.Query(ctx, `
+		SELECT`+calDAVSyncRunColumns+`
+		FROM caldav_sync_runs
+		WHERE account_id = ?
+		ORDER BY started_at DESC, id DESC
+		LIMIT ?
+	`, accountID, limit)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var runs []persistence.CalDAVSyncRun
+	for rows.Next() {
+		run, err := scanCalDAVSyncRun(rows)
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return runs, nil
+}
+
+func scanCalDAVSyncAccount(row rowScanner) (persistence.CalDAVSyncAccount, error) {
+	var account persistence.CalDAVSyncAccount
+	var createdAtStr, updatedAtStr string
+	if err := row.Scan(
+		&account.ID,
+		&account.UserID,
+		&account.CollectionURL,
+		&account.Username,
+		&account.Password,
+		&account.ConflictPolicy,
+		&account.Active,
+		&createdAtStr,
+		&updatedAtStr,
+	); err != nil {
+		return persistence.CalDAVSyncAccount{}, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.CalDAVSyncAccount{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return persistence.CalDAVSyncAccount{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	account.CreatedAt = createdAt
+	account.UpdatedAt = updatedAt
+	return account, nil
+}
+
+func scanCalDAVSyncRun(row rowScanner) (persistence.CalDAVSyncRun, error) {
+	var run persistence.CalDAVSyncRun
+	var startedAtStr, finishedAtStr string
+	var errorText sql.NullString
+	if err := row.Scan(
+		&run.ID,
+		&run.AccountID,
+		&startedAtStr,
+		&finishedAtStr,
+		&run.Status,
+		&run.PulledCreated,
+		&run.PulledUpdated,
+		&run.PulledDeleted,
+		&run.PushedCreated,
+		&run.PushedUpdated,
+		&run.PushedDeleted,
+		&run.Conflicts,
+		&run.Skipped,
+		&run.Failures,
+		&errorText,
+	); err != nil {
+		return persistence.CalDAVSyncRun{}, err
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, startedAtStr)
+	if err != nil {
+		return persistence.CalDAVSyncRun{}, fmt.Errorf("failed to parse started_at: %w", err)
+	}
+	finishedAt, err := time.Parse(time.RFC3339, finishedAtStr)
+	if err != nil {
+		return persistence.CalDAVSyncRun{}, fmt.Errorf("failed to parse finished_at: %w", err)
+	}
+	run.StartedAt = startedAt
+	run.FinishedAt = finishedAt
+	run.Error = errorText.String
+	return run, nil
+}
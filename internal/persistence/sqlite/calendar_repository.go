@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// Calendar member roles stored in calendar_members
+const (
+	calendarRoleEditor = "editor"
+	calendarRoleViewer = "viewer"
+)
+
+// CalendarRepository implements persistence.CalendarRepository using SQLite
+type CalendarRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewCalendarRepository creates a new SQLite calendar repository
+func NewCalendarRepository(pool *ConnectionPool) *CalendarRepository {
+	return &CalendarRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateCalendar inserts a calendar with its editors and viewers
+func (r *CalendarRepository) CreateCalendar(ctx context.Context, calendar persistence.Calendar) error {
+	if calendar.ID == "" || calendar.OwnerID == "" || calendar.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := r.helper.ExecTx(tx, `
+			INSERT INTO calendars (id, name, description, owner_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`,
+			calendar.ID,
+			calendar.Name,
+			calendar.Description,
+			calendar.OwnerID,
+			calendar.CreatedAt.UTC().Format(time.RFC3339),
+			calendar.UpdatedAt.UTC().Format(time.RFC3339),
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		return r.insertMembersTx(tx, calendar)
+	})
+}
+
+// GetCalendar retrieves a calendar by ID
+func (r *CalendarRepository) GetCalendar(ctx context.Context, id string) (persistence.Calendar, error) {
+	var calendar persistence.Calendar
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		row := r.helper.QueryRowTx(tx,
+			"SELECT id, name, description, owner_id, created_at, updated_at FROM calendars WHERE id = ?",
+			id,
+		)
+		var err error
+		calendar, err = scanCalendar(row)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
+			return r.mapper.MapError(err)
+		}
+		return r.loadMembersTx(tx, &calendar)
+	})
+	if err != nil {
+		return persistence.Calendar{}, err
+	}
+	return calendar, nil
+}
+
+// UpdateCalendar changes a calendar's details and owner and replaces its editors and viewers
+func (r *CalendarRepository) UpdateCalendar(ctx context.Context, calendar persistence.Calendar) error {
+	if calendar.ID == "" || calendar.OwnerID == "" || calendar.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := r.helper.ExecTx(tx,
+			"UPDATE calendars SET name = ?, description = ?, owner_id = ?, updated_at = ? WHERE id = ?",
+			calendar.Name, calendar.Description, calendar.OwnerID, calendar.UpdatedAt.UTC().Format(time.RFC3339), calendar.ID,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return persistence.ErrNotFound
+		}
+
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM calendar_members WHERE calendar_id = ?", calendar.ID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return r.insertMembersTx(tx, calendar)
+	})
+}
+
+// DeleteCalendar removes a calendar; members cascade and its schedules are detached
+func (r *CalendarRepository) DeleteCalendar(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM calendars WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListCalendars lists the calendars a user owns, edits or views, by name
+func (r *CalendarRepository) ListCalendars(ctx context.Context, userID string) ([]persistence.Calendar, error) {
+	var calendars []persistence.Calendar
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := r.helper.QueryTx(tx, `
+			SELECT id, name, description, owner_id, created_at, updated_at
+			FROM calendars
+			WHERE owner_id = ?
+			   OR id IN (SELECT calendar_id FROM calendar_members WHERE user_id = ?)
+			ORDER BY name ASC, id ASC
+		`, userID, userID)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		for rows.Next() {
+			calendar, err := scanCalendar(rows)
+			if err != nil {
+				rows.Close()
+				return r.mapper.MapError(err)
+			}
+			calendars = append(calendars, calendar)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return r.mapper.MapError(err)
+		}
+		rows.Close()
+
+		for i := range calendars {
+			if err := r.loadMembersTx(tx, &calendars[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+func (r *CalendarRepository) insertMembersTx(tx *sql.Tx, calendar persistence.Calendar) error {
+	if err := r.insertRoleTx(tx, calendar.ID, calendarRoleEditor, calendar.EditorIDs); err != nil {
+		return err
+	}
+	return r.insertRoleTx(tx, calendar.ID, calendarRoleViewer, calendar.ViewerIDs)
+}
+
+func (r *CalendarRepository) insertRoleTx(tx *sql.Tx, calendarID, role string, userIDs []string) error {
+	for _, userID := range userIDs {
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO calendar_members (calendar_id, user_id, role) VALUES (?, ?, ?)",
+			calendarID, userID, role,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+	}
+	return nil
+}
+
+func (r *CalendarRepository) loadMembersTx(tx *sql.Tx, calendar *persistence.Calendar) error {
+	rows, err := r.helper.QueryTx(tx,
+		"SELECT user_id, role FROM calendar_members WHERE calendar_id = ? ORDER BY user_id ASC",
+		calendar.ID,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	calendar.EditorIDs, calendar.ViewerIDs = nil, nil
+	for rows.Next() {
+		var userID, role string
+		if err := rows.Scan(&userID, &role); err != nil {
+			return r.mapper.MapError(err)
+		}
+		if role == calendarRoleEditor {
+			calendar.EditorIDs = append(calendar.EditorIDs, userID)
+		} else {
+			calendar.ViewerIDs = append(calendar.ViewerIDs, userID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+func (r *CalendarRepository) mapWriteError(err error) error {
+	errStr := err.Error()
+	if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+		return persistence.ErrDuplicate
+	}
+	if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+		return persistence.ErrForeignKeyViolation
+	}
+	return r.mapper.MapError(err)
+}
+
+func scanCalendar(row rowScanner) (persistence.Calendar, error) {
+	var calendar persistence.Calendar
+	var createdAtStr, updatedAtStr string
+	if err := row.Scan(&calendar.ID, &calendar.Name, &calendar.Description, &calendar.OwnerID, &createdAtStr, &updatedAtStr); err != nil {
+		return persistence.Calendar{}, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.Calendar{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return persistence.Calendar{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	calendar.CreatedAt, calendar.UpdatedAt = createdAt, updatedAt
+	return calendar, nil
+}
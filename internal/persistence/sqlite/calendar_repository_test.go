@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestCalendarRepository(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// Only the keys the migration references are needed here.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE users (id TEXT PRIMARY KEY);
+		CREATE TABLE schedules (id TEXT PRIMARY KEY, start_time TEXT NOT NULL);
+		INSERT INTO users (id) VALUES ('alice'), ('bob'), ('carol'), ('dave');
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	schema, err := embeddedMigrations.ReadFile("migrations/020_calendars.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	calendars := NewCalendarRepository(pool)
+
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	release := persistence.Calendar{
+		ID: "cal-1", Name: "Release Train", Description: "Cut and ship dates", OwnerID: "alice",
+		EditorIDs: []string{"carol", "bob"}, ViewerIDs: []string{"dave"},
+		CreatedAt: now, UpdatedAt: now,
+	}
+	if err := calendars.CreateCalendar(ctx, release); err != nil {
+		t.Fatalf("CreateCalendar returned error: %v", err)
+	}
+	if err := calendars.CreateCalendar(ctx, persistence.Calendar{ID: "cal-2", Name: "Office Events", OwnerID: "bob", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateCalendar returned error: %v", err)
+	}
+	if err := calendars.CreateCalendar(ctx, release); !errors.Is(err, persistence.ErrDuplicate) {
+		t.Fatalf("expected a duplicate ID to be rejected, got %v", err)
+	}
+	if err := calendars.CreateCalendar(ctx, persistence.Calendar{ID: "cal-3", Name: "Ghosts", OwnerID: "alice", ViewerIDs: []string{"nobody"}, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrForeignKeyViolation) {
+		t.Fatalf("expected unknown members to be rejected, got %v", err)
+	}
+
+	got, err := calendars.GetCalendar(ctx, "cal-1")
+	if err != nil {
+		t.Fatalf("GetCalendar returned error: %v", err)
+	}
+	if got.Description != "Cut and ship dates" || len(got.EditorIDs) != 2 || got.EditorIDs[0] != "bob" || len(got.ViewerIDs) != 1 || !got.CreatedAt.Equal(now) {
+		t.Fatalf("expected editors sorted and the viewer kept, got %+v", got)
+	}
+
+	if listed, err := calendars.ListCalendars(ctx, "bob"); err != nil || len(listed) != 2 || listed[0].ID != "cal-2" {
+		t.Fatalf("expected bob to see the calendar he owns and the one he edits by name, got %+v (err %v)", listed, err)
+	}
+
+	release.OwnerID, release.EditorIDs, release.ViewerIDs = "carol", nil, []string{"alice"}
+	release.UpdatedAt = now.Add(time.Hour)
+	if err := calendars.UpdateCalendar(ctx, release); err != nil {
+		t.Fatalf("UpdateCalendar returned error: %v", err)
+	}
+	if listed, err := calendars.ListCalendars(ctx, "dave"); err != nil || len(listed) != 0 {
+		t.Fatalf("expected dave to lose access, got %+v (err %v)", listed, err)
+	}
+	if got, err = calendars.GetCalendar(ctx, "cal-1"); err != nil || got.OwnerID != "carol" || len(got.EditorIDs) != 0 || len(got.ViewerIDs) != 1 {
+		t.Fatalf("expected the update to be stored, got %+v (err %v)", got, err)
+	}
+
+	if _, err := pool.DB().ExecContext(ctx, "INSERT INTO schedules (id, start_time, calendar_id) VALUES ('s1', '2024-06-03T09:00:00Z', 'cal-1')"); err != nil {
+		t.Fatalf("Failed to insert schedule: %v", err)
+	}
+	if err := calendars.DeleteCalendar(ctx, "cal-1"); err != nil {
+		t.Fatalf("DeleteCalendar returned error: %v", err)
+	}
+	if _, err := calendars.GetCalendar(ctx, "cal-1"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected the calendar to be gone, got %v", err)
+	}
+	var calendarID *string
+	if err := pool.DB().QueryRowContext(ctx, "SELECT calendar_id FROM schedules WHERE id = 's1'").Scan(&calendarID); err != nil || calendarID != nil {
+		t.Fatalf("expected the schedule to be detached, got %v (err %v)", calendarID, err)
+	}
+	if err := calendars.DeleteCalendar(ctx, "cal-1"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected deleting twice to report not found, got %v", err)
+	}
+}
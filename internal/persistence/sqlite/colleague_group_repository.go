@@ -0,0 +1,249 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// ColleagueGroupRepository implements persistence.ColleagueGroupRepository using SQLite
+type ColleagueGroupRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewColleagueGroupRepository creates a new SQLite colleague group repository
+func NewColleagueGroupRepository(pool *ConnectionPool) *ColleagueGroupRepository {
+	return &ColleagueGroupRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateColleagueGroup inserts a group with its members and shares
+func (r *ColleagueGroupRepository) CreateColleagueGroup(ctx context.Context, group persistence.ColleagueGroup) error {
+	if group.ID == "" || group.OwnerID == "" || group.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := r.helper.ExecTx(tx, `
+			INSERT INTO colleague_groups (id, owner_id, name, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?)
+		`,
+			group.ID,
+			group.OwnerID,
+			group.Name,
+			group.CreatedAt.UTC().Format(time.RFC3339),
+			group.UpdatedAt.UTC().Format(time.RFC3339),
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		return r.replaceMembersTx(tx, group)
+	})
+}
+
+// GetColleagueGroup retrieves a group by ID
+func (r *ColleagueGroupRepository) GetColleagueGroup(ctx context.Context, id string) (persistence.ColleagueGroup, error) {
+	var group persistence.ColleagueGroup
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		row := r.helper.QueryRowTx(tx,
+			"SELECT id, owner_id, name, created_at, updated_at FROM colleague_groups WHERE id = ?",
+			id,
+		)
+		var err error
+		group, err = scanColleagueGroup(row)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
+			return r.mapper.MapError(err)
+		}
+		return r.loadMembersTx(tx, &group)
+	})
+	if err != nil {
+		return persistence.ColleagueGroup{}, err
+	}
+	return group, nil
+}
+
+// UpdateColleagueGroup renames a group and replaces its members and shares
+func (r *ColleagueGroupRepository) UpdateColleagueGroup(ctx context.Context, group persistence.ColleagueGroup) error {
+	if group.ID == "" || group.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := r.helper.ExecTx(tx,
+			"UPDATE colleague_groups SET name = ?, updated_at = ? WHERE id = ?",
+			group.Name, group.UpdatedAt.UTC().Format(time.RFC3339), group.ID,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return persistence.ErrNotFound
+		}
+
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM colleague_group_members WHERE group_id = ?", group.ID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM colleague_group_shares WHERE group_id = ?", group.ID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return r.replaceMembersTx(tx, group)
+	})
+}
+
+// DeleteColleagueGroup removes a group; members and shares cascade
+func (r *ColleagueGroupRepository) DeleteColleagueGroup(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM colleague_groups WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListColleagueGroups lists the groups a user owns or that are shared with them, by name
+func (r *ColleagueGroupRepository) ListColleagueGroups(ctx context.Context, userID string) ([]persistence.ColleagueGroup, error) {
+	var groups []persistence.ColleagueGroup
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := r.helper.QueryTx(tx, `
+			SELECT id, owner_id, name, created_at, updated_at
+			FROM colleague_groups
+			WHERE owner_id = ?
+			   OR id IN (SELECT group_id FROM colleague_group_shares WHERE user_id = ?)
+			ORDER BY name ASC, id ASC
+		`, userID, userID)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		for rows.Next() {
+			group, err := scanColleagueGroup(rows)
+			if err != nil {
+				rows.Close()
+				return r.mapper.MapError(err)
+			}
+			groups = append(groups, group)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return r.mapper.MapError(err)
+		}
+		rows.Close()
+
+		for i := range groups {
+			if err := r.loadMembersTx(tx, &groups[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *ColleagueGroupRepository) replaceMembersTx(tx *sql.Tx, group persistence.ColleagueGroup) error {
+	for position, userID := range group.MemberIDs {
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO colleague_group_members (group_id, user_id, position) VALUES (?, ?, ?)",
+			group.ID, userID, position,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+	}
+	for _, userID := range group.SharedWith {
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO colleague_group_shares (group_id, user_id) VALUES (?, ?)",
+			group.ID, userID,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+	}
+	return nil
+}
+
+func (r *ColleagueGroupRepository) loadMembersTx(tx *sql.Tx, group *persistence.ColleagueGroup) error {
+	members, err := r.queryUserIDsTx(tx, "SELECT user_id FROM colleague_group_members WHERE group_id = ? ORDER BY position ASC", group.ID)
+	if err != nil {
+		return err
+	}
+	shares, err := r.queryUserIDsTx(tx, "SELECT user_id FROM colleague_group_shares WHERE group_id = ? ORDER BY user_id ASC", group.ID)
+	if err != nil {
+		return err
+	}
+	group.MemberIDs, group.SharedWith = members, shares
+	return nil
+}
+
+func (r *ColleagueGroupRepository) queryUserIDsTx(tx *sql.Tx, query, groupID string) ([]string, error) {
+	rows, err := r.helper.QueryTx(tx, query, groupID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return userIDs, nil
+}
+
+func (r *ColleagueGroupRepository) mapWriteError(err error) error {
+	errStr := err.Error()
+	if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+		return persistence.ErrDuplicate
+	}
+	if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+		return persistence.ErrForeignKeyViolation
+	}
+	return r.mapper.MapError(err)
+}
+
+func scanColleagueGroup(row rowScanner) (persistence.ColleagueGroup, error) {
+	var group persistence.ColleagueGroup
+	var createdAtStr, updatedAtStr string
+	if err := row.Scan(&group.ID, &group.OwnerID, &group.Name, &createdAtStr, &updatedAtStr); err != nil {
+		return persistence.ColleagueGroup{}, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.ColleagueGroup{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return persistence.ColleagueGroup{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	group.CreatedAt, group.UpdatedAt = createdAt, updatedAt
+	return group, nil
+}
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestColleagueGroupAndViewLinkRepositories(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// Only the user keys the groups reference are needed here.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE users (id TEXT PRIMARY KEY);
+		INSERT INTO users (id) VALUES ('alice'), ('bob'), ('carol'), ('dave');
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	schema, err := embeddedMigrations.ReadFile("migrations/019_colleague_groups.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	groups := NewColleagueGroupRepository(pool)
+
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	team := persistence.ColleagueGroup{
+		ID: "group-1", OwnerID: "alice", Name: "Platform team",
+		MemberIDs: []string{"carol", "bob"}, SharedWith: []string{"dave"},
+		CreatedAt: now, UpdatedAt: now,
+	}
+	if err := groups.CreateColleagueGroup(ctx, team); err != nil {
+		t.Fatalf("CreateColleagueGroup returned error: %v", err)
+	}
+	if err := groups.CreateColleagueGroup(ctx, persistence.ColleagueGroup{ID: "group-2", OwnerID: "bob", Name: "Leads", MemberIDs: []string{"alice"}, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateColleagueGroup returned error: %v", err)
+	}
+
+	got, err := groups.GetColleagueGroup(ctx, "group-1")
+	if err != nil {
+		t.Fatalf("GetColleagueGroup returned error: %v", err)
+	}
+	if len(got.MemberIDs) != 2 || got.MemberIDs[0] != "carol" || len(got.SharedWith) != 1 || !got.CreatedAt.Equal(now) {
+		t.Fatalf("expected members in selection order and the share, got %+v", got)
+	}
+
+	duplicate := team
+	duplicate.ID = "group-3"
+	if err := groups.CreateColleagueGroup(ctx, duplicate); !errors.Is(err, persistence.ErrDuplicate) {
+		t.Fatalf("expected a duplicate name to be rejected, got %v", err)
+	}
+	if err := groups.CreateColleagueGroup(ctx, persistence.ColleagueGroup{ID: "group-4", OwnerID: "alice", Name: "Ghosts", MemberIDs: []string{"nobody"}, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrForeignKeyViolation) {
+		t.Fatalf("expected unknown members to be rejected, got %v", err)
+	}
+
+	if listed, err := groups.ListColleagueGroups(ctx, "dave"); err != nil || len(listed) != 1 || listed[0].ID != "group-1" {
+		t.Fatalf("expected dave to see the shared group, got %+v (err %v)", listed, err)
+	}
+	if listed, err := groups.ListColleagueGroups(ctx, "alice"); err != nil || len(listed) != 1 {
+		t.Fatalf("expected alice to see only her own group, got %+v (err %v)", listed, err)
+	}
+
+	team.Name, team.MemberIDs, team.SharedWith = "Platform", []string{"bob"}, nil
+	team.UpdatedAt = now.Add(time.Hour)
+	if err := groups.UpdateColleagueGroup(ctx, team); err != nil {
+		t.Fatalf("UpdateColleagueGroup returned error: %v", err)
+	}
+	if listed, err := groups.ListColleagueGroups(ctx, "dave"); err != nil || len(listed) != 0 {
+		t.Fatalf("expected the share to be revoked, got %+v (err %v)", listed, err)
+	}
+	if got, err = groups.GetColleagueGroup(ctx, "group-1"); err != nil || got.Name != "Platform" || len(got.MemberIDs) != 1 {
+		t.Fatalf("expected the update to be stored, got %+v (err %v)", got, err)
+	}
+
+	if err := groups.DeleteColleagueGroup(ctx, "group-1"); err != nil {
+		t.Fatalf("DeleteColleagueGroup returned error: %v", err)
+	}
+	if _, err := groups.GetColleagueGroup(ctx, "group-1"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected the group to be gone, got %v", err)
+	}
+	if err := groups.DeleteColleagueGroup(ctx, "group-1"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected deleting twice to report not found, got %v", err)
+	}
+
+	t.Run("view links", func(t *testing.T) {
+		links := NewViewLinkRepository(pool)
+		link := persistence.ViewLink{
+			Code: "a1b2c3", CreatorID: "alice", View: "week",
+			Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), ParticipantIDs: []string{"bob", "carol"},
+			CreatedAt: now,
+		}
+		if err := links.CreateViewLink(ctx, link); err != nil {
+			t.Fatalf("CreateViewLink returned error: %v", err)
+		}
+		if err := links.CreateViewLink(ctx, link); !errors.Is(err, persistence.ErrDuplicate) {
+			t.Fatalf("expected a taken code to be rejected, got %v", err)
+		}
+		got, err := links.GetViewLink(ctx, "a1b2c3")
+		if err != nil {
+			t.Fatalf("GetViewLink returned error: %v", err)
+		}
+		if got.View != "week" || !got.Date.Equal(link.Date) || len(got.ParticipantIDs) != 2 || got.ParticipantIDs[1] != "carol" {
+			t.Fatalf("unexpected view link: %+v", got)
+		}
+		if _, err := links.GetViewLink(ctx, "zzzzzz"); !errors.Is(err, persistence.ErrNotFound) {
+			t.Fatalf("expected an unknown code to report not found, got %v", err)
+		}
+	})
+}
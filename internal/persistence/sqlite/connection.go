@@ -52,10 +52,35 @@ func (cp *ConnectionPool) Ping(ctx context.Context) error {
 // TransactionFunc represents a function that executes within a transaction
 type TransactionFunc func(tx *sql.Tx) error
 
+type txContextKey struct{}
+
+// txFromContext returns the transaction started by RunInTransaction, if any
+func txFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx
+}
+
+// RunInTransaction executes fn within a database transaction carried by the
+// context it receives. Repository calls made with that context join the
+// transaction, so their writes commit or roll back together. A call made
+// while a transaction is already running joins the outer one.
+func (cp *ConnectionPool) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	return cp.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
 // WithTransaction executes a function within a database transaction
 // If the function returns an error, the transaction is rolled back
 // Otherwise, the transaction is committed
+// Inside RunInTransaction the function runs in the surrounding transaction
 func (cp *ConnectionPool) WithTransaction(ctx context.Context, fn TransactionFunc) error {
+	if tx := txFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
 	tx, err := cp.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -86,7 +111,11 @@ func (cp *ConnectionPool) WithTransaction(ctx context.Context, fn TransactionFun
 }
 
 // WithReadOnlyTransaction executes a function within a read-only transaction
+// Inside RunInTransaction the function runs in the surrounding transaction
 func (cp *ConnectionPool) WithReadOnlyTransaction(ctx context.Context, fn TransactionFunc) error {
+	if tx := txFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
 	tx, err := cp.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return fmt.Errorf("failed to begin read-only transaction: %w", err)
@@ -126,19 +155,34 @@ func NewQueryHelper(pool *ConnectionPool) *QueryHelper {
 	return &QueryHelper{pool: pool}
 }
 
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// conn returns the transaction carried by ctx, or the pool outside of one
+func (qh *QueryHelper) conn(ctx context.Context) queryer {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+	return qh.pool.db
+}
+
 // QueryRow executes a query that returns a single row
 func (qh *QueryHelper) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	return qh.pool.db.QueryRowContext(ctx, query, args...)
+	return qh.conn(ctx).QueryRowContext(ctx, query, args...)
 }
 
 // Query executes a query that returns multiple rows
 func (qh *QueryHelper) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	return qh.pool.db.QueryContext(ctx, query, args...)
+	return qh.conn(ctx).QueryContext(ctx, query, args...)
 }
 
 // Exec executes a query that doesn't return rows
 func (qh *QueryHelper) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	return qh.pool.db.ExecContext(ctx, query, args...)
+	return qh.conn(ctx).ExecContext(ctx, query, args...)
 }
 
 // QueryRowTx executes a query that returns a single row within a transaction
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestConnectionPool_RunInTransaction(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	if _, err := pool.DB().ExecContext(ctx, `CREATE TABLE items (id TEXT PRIMARY KEY)`); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	helper := NewQueryHelper(pool)
+	insert := func(ctx context.Context, id string) error {
+		_, err := helper.Exec(ctx, `INSERT INTO items (id) VALUES (?)`, id)
+		return err
+	}
+	count := func() int {
+		var n int
+		if err := helper.QueryRow(ctx, `SELECT COUNT(*) FROM items`).Scan(&n); err != nil {
+			t.Fatalf("Failed to count items: %v", err)
+		}
+		return n
+	}
+
+	t.Run("rolls back every write when fn fails", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := pool.RunInTransaction(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "a"); err != nil {
+				return err
+			}
+			if err := pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+				_, err := helper.ExecTx(tx, `INSERT INTO items (id) VALUES (?)`, "b")
+				return err
+			}); err != nil {
+				return err
+			}
+			var n int
+			if err := helper.QueryRow(ctx, `SELECT COUNT(*) FROM items`).Scan(&n); err != nil || n != 2 {
+				t.Fatalf("Expected both uncommitted rows to be visible inside the transaction, got %d (%v)", n, err)
+			}
+			return boom
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("Expected the error from fn, got %v", err)
+		}
+		if n := count(); n != 0 {
+			t.Fatalf("Expected no rows after rollback, got %d", n)
+		}
+	})
+
+	t.Run("commits nested calls once with the outer transaction", func(t *testing.T) {
+		err := pool.RunInTransaction(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "a"); err != nil {
+				return err
+			}
+			return pool.RunInTransaction(ctx, func(ctx context.Context) error {
+				return insert(ctx, "b")
+			})
+		})
+		if err != nil {
+			t.Fatalf("RunInTransaction returned error: %v", err)
+		}
+		if n := count(); n != 2 {
+			t.Fatalf("Expected 2 committed rows, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// DepartmentRepository implements persistence.DepartmentRepository using SQLite
+type DepartmentRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewDepartmentRepository creates a new SQLite department repository
+func NewDepartmentRepository(pool *ConnectionPool) *DepartmentRepository {
+	return &DepartmentRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateDepartment inserts a department
+func (r *DepartmentRepository) CreateDepartment(ctx context.Context, department persistence.Department) error {
+	if department.ID == "" || department.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO departments (id, name, parent_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		department.ID,
+		department.Name,
+		sql.NullString{String: department.ParentID, Valid: department.ParentID != ""},
+		department.CreatedAt.UTC().Format(time.RFC3339),
+		department.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return r.mapWriteError(err)
+	}
+	return nil
+}
+
+// GetDepartment retrieves a department by ID
+func (r *DepartmentRepository) GetDepartment(ctx context.Context, id string) (persistence.Department, error) {
+	row := r.helper.QueryRow(ctx,
+		"SELECT id, name, parent_id, created_at, updated_at FROM departments WHERE id = ?",
+		id,
+	)
+	department, err := scanDepartment(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.Department{}, persistence.ErrNotFound
+		}
+		return persistence.Department{}, r.mapper.MapError(err)
+	}
+	return department, nil
+}
+
+// UpdateDepartment changes a department's name and parent
+func (r *DepartmentRepository) UpdateDepartment(ctx context.Context, department persistence.Department) error {
+	if department.ID == "" || department.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	result, err := r.helper.Exec(ctx,
+		"UPDATE departments SET name = ?, parent_id = ?, updated_at = ? WHERE id = ?",
+		department.Name,
+		sql.NullString{String: department.ParentID, Valid: department.ParentID != ""},
+		department.UpdatedAt.UTC().Format(time.RFC3339),
+		department.ID,
+	)
+	if err != nil {
+		return r.mapWriteError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteDepartment removes a department; its users and subdepartments are
+// left without one
+func (r *DepartmentRepository) DeleteDepartment(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM departments WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListDepartments lists every department by name
+func (r *DepartmentRepository) ListDepartments(ctx context.Context) ([]persistence.Department, error) {
+	rows, err := r.helper.Query(ctx,
+		"SELECT id, name, parent_id, created_at, updated_at FROM departments ORDER BY name ASC, id ASC",
+	)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var departments []persistence.Department
+	for rows.Next() {
+		department, err := scanDepartment(rows)
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		departments = append(departments, department)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return departments, nil
+}
+
+// ListDepartmentMembers lists the IDs of users in any of the departments
+func (r *DepartmentRepository) ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error) {
+	if len(departmentIDs) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(departmentIDs))
+	args := make([]interface{}, len(departmentIDs))
+	for i, id := range departmentIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	return r.queryUserIDs(ctx,
+		fmt.Sprintf("SELECT id FROM users WHERE department_id IN (%s) ORDER BY id ASC", strings.Join(placeholders, ",")),
+		args...,
+	)
+}
+
+// ListDirectReports lists the IDs of users whose manager is managerID
+func (r *DepartmentRepository) ListDirectReports(ctx context.Context, managerID string) ([]string, error) {
+	return r.queryUserIDs(ctx, "SELECT id FROM users WHERE manager_id = ? ORDER BY id ASC", managerID)
+}
+
+func (r *DepartmentRepository) queryUserIDs(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return ids, nil
+}
+
+func (r *DepartmentRepository) mapWriteError(err error) error {
+	errStr := err.Error()
+	if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+		return persistence.ErrDuplicate
+	}
+	if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+		return persistence.ErrForeignKeyViolation
+	}
+	return r.mapper.MapError(err)
+}
+
+func scanDepartment(row rowScanner) (persistence.Department, error) {
+	var department persistence.Department
+	var parentID sql.NullString
+	var createdAtStr, updatedAtStr string
+	if err := row.Scan(&department.ID, &department.Name, &parentID, &createdAtStr, &updatedAtStr); err != nil {
+		return persistence.Department{}, err
+	}
+	department.ParentID = parentID.String
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.Department{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return persistence.Department{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	department.CreatedAt, department.UpdatedAt = createdAt, updatedAt
+	return department, nil
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestDepartmentRepository(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// The users table as earlier migrations leave it, and the tables
+	// DeleteUser checks.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE users (
+			id TEXT PRIMARY KEY,
+			email TEXT NOT NULL UNIQUE,
+			display_name TEXT NOT NULL,
+			password_hash TEXT NOT NULL,
+			is_admin BOOLEAN NOT NULL DEFAULT 0,
+			time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			version INTEGER NOT NULL DEFAULT 1
+		);
+		CREATE TABLE schedules (id TEXT PRIMARY KEY, creator_id TEXT);
+		CREATE TABLE schedule_participants (schedule_id TEXT, user_id TEXT);
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	schema, err := embeddedMigrations.ReadFile("migrations/022_org_structure.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	departments := NewDepartmentRepository(pool)
+	users := NewUserRepository(pool)
+
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	for _, department := range []persistence.Department{
+		{ID: "dep-sales", Name: "Sales", CreatedAt: now, UpdatedAt: now},
+		{ID: "dep-east", Name: "Sales East", ParentID: "dep-sales", CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := departments.CreateDepartment(ctx, department); err != nil {
+			t.Fatalf("CreateDepartment returned error: %v", err)
+		}
+	}
+	if err := departments.CreateDepartment(ctx, persistence.Department{ID: "dep-x", Name: "Sales", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrDuplicate) {
+		t.Fatalf("expected a duplicate name to be rejected, got %v", err)
+	}
+	if err := departments.CreateDepartment(ctx, persistence.Department{ID: "dep-x", Name: "Orphans", ParentID: "dep-none", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrForeignKeyViolation) {
+		t.Fatalf("expected an unknown parent to be rejected, got %v", err)
+	}
+
+	for _, user := range []persistence.User{
+		{ID: "alice", Email: "alice@example.com", DisplayName: "Alice", PasswordHash: "x", DepartmentID: "dep-sales", Location: "Tokyo"},
+		{ID: "bob", Email: "bob@example.com", DisplayName: "Bob", PasswordHash: "x", DepartmentID: "dep-east", ManagerID: "alice", Location: "Osaka"},
+		{ID: "carol", Email: "carol@example.com", DisplayName: "Carol", PasswordHash: "x", ManagerID: "alice"},
+	} {
+		if err := users.CreateUser(ctx, user); err != nil {
+			t.Fatalf("CreateUser returned error: %v", err)
+		}
+	}
+
+	bob, err := users.GetUser(ctx, "bob")
+	if err != nil || bob.DepartmentID != "dep-east" || bob.ManagerID != "alice" || bob.Location != "Osaka" {
+		t.Fatalf("expected the org fields to round-trip, got %+v (err %v)", bob, err)
+	}
+	if carol, err := users.GetUserByEmail(ctx, "carol@example.com"); err != nil || carol.DepartmentID != "" || carol.ManagerID != "alice" {
+		t.Fatalf("expected no department for carol, got %+v (err %v)", carol, err)
+	}
+
+	if members, err := departments.ListDepartmentMembers(ctx, []string{"dep-sales", "dep-east"}); err != nil || len(members) != 2 || members[0] != "alice" {
+		t.Fatalf("expected the members of both departments, got %v (err %v)", members, err)
+	}
+	if reports, err := departments.ListDirectReports(ctx, "alice"); err != nil || len(reports) != 2 || reports[1] != "carol" {
+		t.Fatalf("expected alice's direct reports, got %v (err %v)", reports, err)
+	}
+
+	east, err := departments.GetDepartment(ctx, "dep-east")
+	if err != nil || east.ParentID != "dep-sales" || !east.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected department %+v (err %v)", east, err)
+	}
+	east.Name, east.ParentID = "East", ""
+	if err := departments.UpdateDepartment(ctx, east); err != nil {
+		t.Fatalf("UpdateDepartment returned error: %v", err)
+	}
+	if listed, err := departments.ListDepartments(ctx); err != nil || len(listed) != 2 || listed[0].Name != "East" || listed[0].ParentID != "" {
+		t.Fatalf("expected departments by name, got %+v (err %v)", listed, err)
+	}
+
+	if err := departments.DeleteDepartment(ctx, "dep-east"); err != nil {
+		t.Fatalf("DeleteDepartment returned error: %v", err)
+	}
+	if err := users.DeleteUser(ctx, "alice"); err != nil {
+		t.Fatalf("DeleteUser returned error: %v", err)
+	}
+	if bob, err = users.GetUser(ctx, "bob"); err != nil || bob.DepartmentID != "" || bob.ManagerID != "" {
+		t.Fatalf("expected the department and manager to be cleared, got %+v (err %v)", bob, err)
+	}
+	if err := departments.DeleteDepartment(ctx, "dep-east"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected deleting twice to report not found, got %v", err)
+	}
+}
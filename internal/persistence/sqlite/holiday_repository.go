@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+const holidayDateLayout = "2006-01-02"
+
+// HolidayRepository implements persistence.HolidayRepository using SQLite
+type HolidayRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewHolidayRepository creates a new SQLite holiday repository
+func NewHolidayRepository(pool *ConnectionPool) *HolidayRepository {
+	return &HolidayRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// ListCompanyHolidays returns all company closure days ordered by date
+func (r *HolidayRepository) ListCompanyHolidays(ctx context.Context) ([]persistence.CompanyHoliday, error) {
+	rows, err := r.helper.Query(ctx, "SELECT date, name, created_at FROM company_holidays ORDER BY date ASC")
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var holidays []persistence.CompanyHoliday
+	for rows.Next() {
+		var holiday persistence.CompanyHoliday
+		var dateStr, createdAtStr string
+		if err := rows.Scan(&dateStr, &holiday.Name, &createdAtStr); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		if holiday.Date, err = time.Parse(holidayDateLayout, dateStr); err != nil {
+			return nil, fmt.Errorf("failed to parse date: %w", err)
+		}
+		if holiday.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		holidays = append(holidays, holiday)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return holidays, nil
+}
+
+// CreateCompanyHoliday inserts a closure day; a second closure on the same date is a duplicate
+func (r *HolidayRepository) CreateCompanyHoliday(ctx context.Context, holiday persistence.CompanyHoliday) error {
+	if holiday.Date.IsZero() || strings.TrimSpace(holiday.Name) == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	createdAt := holiday.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	_, err := r.helper.Exec(ctx,
+		"INSERT INTO company_holidays (date, name, created_at) VALUES (?, ?, ?)",
+		holiday.Date.Format(holidayDateLayout),
+		holiday.Name,
+		createdAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// DeleteCompanyHoliday removes the closure on the calendar date of date
+func (r *HolidayRepository) DeleteCompanyHoliday(ctx context.Context, date time.Time) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM company_holidays WHERE date = ?", date.Format(holidayDateLayout))
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// IdempotencyRepository implements persistence.IdempotencyRepository using SQLite
+type IdempotencyRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewIdempotencyRepository creates a new SQLite idempotency key repository
+func NewIdempotencyRepository(pool *ConnectionPool) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// ReserveIdempotencyKey claims a key for the first request that uses it.
+// Expired records are purged first so their keys can be reused.
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) (persistence.IdempotencyRecord, bool, error) {
+	if record.PrincipalID == "" || record.Key == "" || record.Fingerprint == "" {
+		return persistence.IdempotencyRecord{}, false, persistence.ErrConstraintViolation
+	}
+
+	var (
+		stored   persistence.IdempotencyRecord
+		reserved bool
+	)
+	err := r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM idempotency_keys WHERE expires_at <= ?", formatIdempotencyTime(record.CreatedAt)); err != nil {
+			return r.mapper.MapError(err)
+		}
+
+		row := r.helper.QueryRowTx(tx, `
+			SELECT principal_id, idempotency_key, fingerprint, status_code, headers, body, created_at, expires_at
+			FROM idempotency_keys
+			WHERE principal_id = ? AND idempotency_key = ?
+		`, record.PrincipalID, record.Key)
+		existing, err := scanIdempotencyRecord(row)
+		if err == nil {
+			stored = existing
+			return nil
+		}
+		if err != sql.ErrNoRows {
+			return r.mapper.MapError(err)
+		}
+
+		_, err = r.helper.ExecTx(tx, `
+			INSERT INTO idempotency_keys (principal_id, idempotency_key, fingerprint, status_code, headers, body, created_at, expires_at)
+			VALUES (?, ?, ?, 0, '{}', NULL, ?, ?)
+		`,
+			record.PrincipalID,
+			record.Key,
+			record.Fingerprint,
+			formatIdempotencyTime(record.CreatedAt),
+			formatIdempotencyTime(record.ExpiresAt),
+		)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		stored = record
+		stored.StatusCode = 0
+		stored.Headers = nil
+		stored.Body = nil
+		reserved = true
+		return nil
+	})
+	if err != nil {
+		return persistence.IdempotencyRecord{}, false, err
+	}
+	return stored, reserved, nil
+}
+
+// CompleteIdempotencyKey stores the response of a reserved key
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) error {
+	if record.StatusCode <= 0 {
+		return persistence.ErrConstraintViolation
+	}
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers: %w", err)
+	}
+	if record.Headers == nil {
+		headers = []byte("{}")
+	}
+
+	result, err := r.helper.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = ?, headers = ?, body = ?
+		WHERE principal_id = ? AND idempotency_key = ?
+	`, record.StatusCode, string(headers), record.Body, record.PrincipalID, record.Key)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops a key so that the request can be retried
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error {
+	if _, err := r.helper.Exec(ctx, "DELETE FROM idempotency_keys WHERE principal_id = ? AND idempotency_key = ?", principalID, key); err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// formatIdempotencyTime uses a fixed-width UTC layout so that expires_at
+// compares correctly as text.
+func formatIdempotencyTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func scanIdempotencyRecord(row rowScanner) (persistence.IdempotencyRecord, error) {
+	var record persistence.IdempotencyRecord
+	var headers, createdAt, expiresAt string
+	var body []byte
+	if err := row.Scan(&record.PrincipalID, &record.Key, &record.Fingerprint, &record.StatusCode, &headers, &body, &createdAt, &expiresAt); err != nil {
+		return persistence.IdempotencyRecord{}, err
+	}
+	if err := json.Unmarshal([]byte(headers), &record.Headers); err != nil {
+		return persistence.IdempotencyRecord{}, fmt.Errorf("failed to parse headers: %w", err)
+	}
+	record.Body = body
+	var err error
+	if record.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return persistence.IdempotencyRecord{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if record.ExpiresAt, err = time.Parse(time.RFC3339, expiresAt); err != nil {
+		return persistence.IdempotencyRecord{}, fmt.Errorf("failed to parse expires_at: %w", err)
+	}
+	return record, nil
+}
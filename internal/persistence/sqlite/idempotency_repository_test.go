@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	schema, err := embeddedMigrations.ReadFile("migrations/016_idempotency_keys.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	repo := NewIdempotencyRepository(pool)
+
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	record := persistence.IdempotencyRecord{
+		PrincipalID: "user-1",
+		Key:         "key-1",
+		Fingerprint: "abc",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	if _, reserved, err := repo.ReserveIdempotencyKey(ctx, record); err != nil || !reserved {
+		t.Fatalf("expected the first reservation to succeed, got %v (reserved %v)", err, reserved)
+	}
+	stored, reserved, err := repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil || reserved || stored.StatusCode != 0 {
+		t.Fatalf("expected the pending record, got %+v (reserved %v, err %v)", stored, reserved, err)
+	}
+
+	record.StatusCode = 201
+	record.Headers = map[string]string{"ETag": `"1"`}
+	record.Body = []byte(`{"id":"sched-1"}`)
+	if err := repo.CompleteIdempotencyKey(ctx, record); err != nil {
+		t.Fatalf("CompleteIdempotencyKey returned error: %v", err)
+	}
+	stored, reserved, err = repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil || reserved {
+		t.Fatalf("expected the stored response, got reserved %v, err %v", reserved, err)
+	}
+	if stored.StatusCode != 201 || stored.Headers["ETag"] != `"1"` || string(stored.Body) != `{"id":"sched-1"}` || !stored.ExpiresAt.Equal(record.ExpiresAt) {
+		t.Fatalf("unexpected stored response %+v", stored)
+	}
+
+	other := record
+	other.PrincipalID = "user-2"
+	if _, reserved, err := repo.ReserveIdempotencyKey(ctx, other); err != nil || !reserved {
+		t.Fatalf("expected keys to be scoped to the principal, got %v (reserved %v)", err, reserved)
+	}
+
+	later := record
+	later.Fingerprint = "def"
+	later.CreatedAt = now.Add(2 * time.Hour)
+	later.ExpiresAt = later.CreatedAt.Add(time.Hour)
+	if stored, reserved, err := repo.ReserveIdempotencyKey(ctx, later); err != nil || !reserved || stored.Fingerprint != "def" {
+		t.Fatalf("expected an expired key to be reusable, got %+v (reserved %v, err %v)", stored, reserved, err)
+	}
+
+	if err := repo.ReleaseIdempotencyKey(ctx, "user-1", "key-1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey returned error: %v", err)
+	}
+	if err := repo.CompleteIdempotencyKey(ctx, record); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after release, got %v", err)
+	}
+}
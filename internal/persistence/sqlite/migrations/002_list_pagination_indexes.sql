@@ -0,0 +1,8 @@
+-- Migration: 002_list_pagination_indexes.sql
+-- Description: Add indexes backing keyset pagination and attribute filters on list endpoints
+
+CREATE INDEX IF NOT EXISTS idx_users_email_id ON users(email, id);
+CREATE INDEX IF NOT EXISTS idx_rooms_name_nocase_id ON rooms(name COLLATE NOCASE, id);
+CREATE INDEX IF NOT EXISTS idx_schedules_room_id ON schedules(room_id);
+CREATE INDEX IF NOT EXISTS idx_schedules_creator_id ON schedules(creator_id);
+CREATE INDEX IF NOT EXISTS idx_schedules_updated_at ON schedules(updated_at);
@@ -0,0 +1,5 @@
+-- Migration: 003_time_zones.sql
+-- Description: Record the preferred IANA time zone per user and the originating zone per schedule
+
+ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo';
+ALTER TABLE schedules ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo';
@@ -0,0 +1,5 @@
+-- Migration: 004_all_day_events.sql
+-- Description: Flag date-only schedules and whether they block participants
+
+ALTER TABLE schedules ADD COLUMN all_day INTEGER NOT NULL DEFAULT 0 CHECK (all_day IN (0, 1));
+ALTER TABLE schedules ADD COLUMN busy INTEGER NOT NULL DEFAULT 0 CHECK (busy IN (0, 1));
@@ -0,0 +1,25 @@
+-- Migration: 005_availability.sql
+-- Description: Store per-user working hours and out-of-office periods
+
+CREATE TABLE IF NOT EXISTS working_hours (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_minute INTEGER NOT NULL CHECK (start_minute >= 0),
+    end_minute INTEGER NOT NULL CHECK (end_minute <= 1440),
+    CHECK (start_minute < end_minute),
+    PRIMARY KEY (user_id, weekday, start_minute)
+);
+
+CREATE TABLE IF NOT EXISTS out_of_office (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    note TEXT,
+    auto_decline INTEGER NOT NULL DEFAULT 0 CHECK (auto_decline IN (0, 1)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    CHECK (start_time < end_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_out_of_office_user_range ON out_of_office(user_id, start_time, end_time);
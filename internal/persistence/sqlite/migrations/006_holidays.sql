@@ -0,0 +1,10 @@
+-- Migration: 006_holidays.sql
+-- Description: Store company closure days and per-rule holiday handling for recurrences
+
+CREATE TABLE IF NOT EXISTS company_holidays (
+    date TEXT PRIMARY KEY,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    created_at TEXT NOT NULL
+);
+
+ALTER TABLE recurrences ADD COLUMN holiday_policy TEXT NOT NULL DEFAULT '' CHECK (holiday_policy IN ('', 'skip', 'next_business_day'));
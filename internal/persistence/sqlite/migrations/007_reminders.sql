@@ -0,0 +1,33 @@
+-- Migration: 007_reminders.sql
+-- Description: Store schedule reminders, per-user reminder preferences and reminder delivery state
+
+CREATE TABLE IF NOT EXISTS schedule_reminders (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    minutes_before INTEGER NOT NULL CHECK (minutes_before BETWEEN 0 AND 10080),
+    PRIMARY KEY (schedule_id, minutes_before)
+);
+
+CREATE TABLE IF NOT EXISTS reminder_preferences (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    minutes_before TEXT NOT NULL DEFAULT '',
+    channels TEXT NOT NULL DEFAULT '',
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+    id TEXT PRIMARY KEY,
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    occurrence_start TEXT NOT NULL,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    minutes_before INTEGER NOT NULL,
+    channel TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('sending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    due_at TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE (schedule_id, occurrence_start, user_id, minutes_before, channel)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_deliveries_status ON reminder_deliveries(status, due_at);
@@ -0,0 +1,8 @@
+-- Migration: 009_outbox_sequence.sql
+-- Description: Number outbox events in commit order so change streams can resume after the last seen event
+
+ALTER TABLE outbox_events ADD COLUMN sequence INTEGER;
+
+UPDATE outbox_events SET sequence = rowid WHERE sequence IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_sequence ON outbox_events(sequence);
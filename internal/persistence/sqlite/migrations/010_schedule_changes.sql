@@ -0,0 +1,31 @@
+-- Migration: 010_schedule_changes.sql
+-- Description: Record a monotonic change log of schedule writes and deletions for delta sync
+
+CREATE TABLE IF NOT EXISTS schedule_changes (
+    sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('upsert', 'delete')),
+    changed_at TEXT NOT NULL
+);
+
+-- Users whose view of the schedule changed: creator, participants, and
+-- participants removed by the change. Deleted schedules keep their rows so
+-- clients receive tombstones.
+CREATE TABLE IF NOT EXISTS schedule_change_users (
+    sequence INTEGER NOT NULL REFERENCES schedule_changes(sequence) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (sequence, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_change_users_user ON schedule_change_users(user_id, sequence);
+
+-- Seed the log with the current schedules so the first sync returns them all.
+-- Participants need no seed rows: the change query also matches users who
+-- currently participate in the schedule.
+INSERT INTO schedule_changes (schedule_id, kind, changed_at)
+SELECT id, 'upsert', updated_at FROM schedules ORDER BY updated_at, id;
+
+INSERT OR IGNORE INTO schedule_change_users (sequence, user_id)
+SELECT c.sequence, s.creator_id
+FROM schedule_changes c
+JOIN schedules s ON s.id = c.schedule_id;
@@ -0,0 +1,19 @@
+-- Migration: 011_caldav.sql
+-- Description: Store app passwords for CalDAV basic auth and the resource names CalDAV clients chose for schedules
+
+CREATE TABLE IF NOT EXISTS app_passwords (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL,
+    last_used_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_passwords_user ON app_passwords(user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS caldav_objects (
+    schedule_id TEXT PRIMARY KEY REFERENCES schedules(id) ON DELETE CASCADE,
+    resource_name TEXT NOT NULL UNIQUE,
+    uid TEXT NOT NULL
+);
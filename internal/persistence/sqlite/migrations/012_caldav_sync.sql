@@ -0,0 +1,49 @@
+-- Migration: 012_caldav_sync.sql
+-- Description: Connect user calendars to external CalDAV collections, tracking remote objects and sync runs
+
+CREATE TABLE IF NOT EXISTS caldav_sync_accounts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    collection_url TEXT NOT NULL,
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    conflict_policy TEXT NOT NULL,
+    active INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_caldav_sync_accounts_user ON caldav_sync_accounts(user_id, created_at);
+
+-- schedule_id has no foreign key so a mapping outlives a locally deleted
+-- schedule until the deletion is pushed to the remote collection. An empty
+-- schedule_id marks a remote object that could not be imported.
+CREATE TABLE IF NOT EXISTS caldav_sync_items (
+    account_id TEXT NOT NULL REFERENCES caldav_sync_accounts(id) ON DELETE CASCADE,
+    remote_href TEXT NOT NULL,
+    remote_uid TEXT NOT NULL,
+    remote_etag TEXT NOT NULL,
+    schedule_id TEXT NOT NULL,
+    local_etag TEXT NOT NULL,
+    PRIMARY KEY (account_id, remote_href)
+);
+
+CREATE TABLE IF NOT EXISTS caldav_sync_runs (
+    id TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL REFERENCES caldav_sync_accounts(id) ON DELETE CASCADE,
+    started_at TEXT NOT NULL,
+    finished_at TEXT NOT NULL,
+    status TEXT NOT NULL,
+    pulled_created INTEGER NOT NULL DEFAULT 0,
+    pulled_updated INTEGER NOT NULL DEFAULT 0,
+    pulled_deleted INTEGER NOT NULL DEFAULT 0,
+    pushed_created INTEGER NOT NULL DEFAULT 0,
+    pushed_updated INTEGER NOT NULL DEFAULT 0,
+    pushed_deleted INTEGER NOT NULL DEFAULT 0,
+    conflicts INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_caldav_sync_runs_account ON caldav_sync_runs(account_id, started_at);
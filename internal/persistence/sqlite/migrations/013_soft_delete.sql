@@ -0,0 +1,10 @@
+-- Migration: 013_soft_delete.sql
+-- Description: Soft delete schedules and rooms into a trash that is purged after a retention period
+
+ALTER TABLE schedules ADD COLUMN deleted_at TEXT;
+ALTER TABLE schedules ADD COLUMN deleted_by TEXT;
+ALTER TABLE rooms ADD COLUMN deleted_at TEXT;
+ALTER TABLE rooms ADD COLUMN deleted_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_schedules_trash ON schedules(deleted_by, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_rooms_trash ON rooms(deleted_by, deleted_at) WHERE deleted_at IS NOT NULL;
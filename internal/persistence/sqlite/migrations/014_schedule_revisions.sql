@@ -0,0 +1,11 @@
+-- Migration: 014_schedule_revisions.sql
+-- Description: Version history of schedules for revision diffs and reverts
+
+CREATE TABLE IF NOT EXISTS schedule_revisions (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    changed_by TEXT NOT NULL,
+    changed_at TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    PRIMARY KEY (schedule_id, revision)
+);
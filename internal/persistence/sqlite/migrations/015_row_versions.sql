@@ -0,0 +1,6 @@
+-- Migration: 015_row_versions.sql
+-- Description: Version numbers for optimistic concurrency on schedules, rooms and users
+
+ALTER TABLE schedules ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE rooms ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+-- Migration: 016_idempotency_keys.sql
+-- Description: Stored responses replayed for retried requests carrying an Idempotency-Key
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    principal_id TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    headers TEXT NOT NULL DEFAULT '{}',
+    body BLOB,
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL,
+    PRIMARY KEY (principal_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,5 @@
+-- Migration: 017_conflict_lookup_indexes.sql
+-- Description: Indexes for the participant, room and recurrence lookups behind conflict detection
+
+CREATE INDEX IF NOT EXISTS idx_schedules_room_window ON schedules(room_id, start_time, end_time);
+CREATE INDEX IF NOT EXISTS idx_recurrences_schedule_ends ON recurrences(schedule_id, ends_on);
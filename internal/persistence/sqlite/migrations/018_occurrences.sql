@@ -0,0 +1,30 @@
+-- Migration: 018_occurrences.sql
+-- Description: Materialized occurrences of recurring schedules and the horizon each schedule is indexed to
+
+CREATE TABLE IF NOT EXISTS occurrences (
+    schedule_id TEXT NOT NULL,
+    rule_id TEXT NOT NULL,
+    start_time TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    PRIMARY KEY (schedule_id, rule_id, start_time),
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_occurrences_window ON occurrences(start_time, end_time);
+CREATE INDEX IF NOT EXISTS idx_occurrences_schedule_window ON occurrences(schedule_id, end_time, start_time);
+
+-- generation grows on every change to a schedule or its rules so a refresh
+-- computed from older rules is not stored over a newer one.
+CREATE TABLE IF NOT EXISTS occurrence_horizons (
+    schedule_id TEXT PRIMARY KEY,
+    materialized_until TEXT,
+    stale INTEGER NOT NULL DEFAULT 1,
+    generation INTEGER NOT NULL DEFAULT 1,
+    refreshed_at TEXT,
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_occurrence_horizons_refresh ON occurrence_horizons(stale, materialized_until);
+
+INSERT OR IGNORE INTO occurrence_horizons (schedule_id)
+SELECT DISTINCT schedule_id FROM recurrences;
@@ -0,0 +1,36 @@
+-- Migration: 019_colleague_groups.sql
+-- Description: Named colleague groups users select in calendar views, and short links sharing a view
+
+CREATE TABLE IF NOT EXISTS colleague_groups (
+    id TEXT PRIMARY KEY,
+    owner_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE (owner_id, name)
+);
+
+-- position keeps members in the order they were selected.
+CREATE TABLE IF NOT EXISTS colleague_group_members (
+    group_id TEXT NOT NULL REFERENCES colleague_groups(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS colleague_group_shares (
+    group_id TEXT NOT NULL REFERENCES colleague_groups(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_colleague_group_shares_user ON colleague_group_shares(user_id);
+
+CREATE TABLE IF NOT EXISTS view_links (
+    code TEXT PRIMARY KEY,
+    creator_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    view TEXT NOT NULL CHECK (view IN ('day', 'week', 'month')),
+    date TEXT NOT NULL,
+    participant_ids TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
@@ -0,0 +1,27 @@
+-- Migration: 020_calendars.sql
+-- Description: Shared calendars not tied to a person, with owner/editor/viewer access, that schedules can belong to
+
+CREATE TABLE IF NOT EXISTS calendars (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendars_owner ON calendars(owner_id);
+
+-- The owner is kept on calendars; members hold the editor and viewer grants.
+CREATE TABLE IF NOT EXISTS calendar_members (
+    calendar_id TEXT NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('editor', 'viewer')),
+    PRIMARY KEY (calendar_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_members_user ON calendar_members(user_id);
+
+ALTER TABLE schedules ADD COLUMN calendar_id TEXT REFERENCES calendars(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_schedules_calendar ON schedules(calendar_id, start_time);
@@ -0,0 +1,31 @@
+-- Migration: 021_user_groups.sql
+-- Description: Admin-managed user groups and distribution lists that can be invited to schedules as a unit
+
+CREATE TABLE IF NOT EXISTS user_groups (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_groups_owner ON user_groups(owner_id);
+
+CREATE TABLE IF NOT EXISTS user_group_members (
+    group_id TEXT NOT NULL REFERENCES user_groups(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_members_user ON user_group_members(user_id);
+
+-- Groups invited as a live reference; their current members take part in the
+-- schedule. Groups expanded at save time are stored as plain participants.
+CREATE TABLE IF NOT EXISTS schedule_participant_groups (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    group_id TEXT NOT NULL REFERENCES user_groups(id) ON DELETE CASCADE,
+    PRIMARY KEY (schedule_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_participant_groups_group ON schedule_participant_groups(group_id);
@@ -0,0 +1,7 @@
+-- Migration: 023_reminder_claim_lease.sql
+-- Description: Record when a reminder delivery was claimed so abandoned claims can be taken over
+
+ALTER TABLE reminder_deliveries ADD COLUMN claimed_at TEXT;
+
+-- Rows already sending were claimed when they were last updated.
+UPDATE reminder_deliveries SET claimed_at = updated_at WHERE status = 'sending';
@@ -0,0 +1,291 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// OccurrenceRepository implements persistence.OccurrenceRepository using SQLite
+type OccurrenceRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewOccurrenceRepository creates a new SQLite occurrence index repository
+func NewOccurrenceRepository(pool *ConnectionPool) *OccurrenceRepository {
+	return &OccurrenceRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// MarkOccurrencesStale queues a schedule for regeneration and bumps its
+// generation so refreshes computed from older rules are discarded. Schedules
+// that never had a recurrence are not tracked
+func (r *OccurrenceRepository) MarkOccurrencesStale(ctx context.Context, scheduleID string) error {
+	if scheduleID == "" {
+		return persistence.ErrConstraintViolation
+	}
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := r.helper.ExecTx(tx, `
+			UPDATE occurrence_horizons SET stale = 1, generation = generation + 1 WHERE schedule_id = ?
+		`, scheduleID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		if _, err := r.helper.ExecTx(tx, `
+			INSERT OR IGNORE INTO occurrence_horizons (schedule_id)
+			SELECT ? WHERE EXISTS (SELECT 1 FROM recurrences WHERE schedule_id = ?)
+		`, scheduleID, scheduleID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return nil
+	})
+}
+
+// MarkHolidayOccurrencesStale queues every schedule whose rules follow a
+// holiday policy, since a changed calendar moves their occurrences
+func (r *OccurrenceRepository) MarkHolidayOccurrencesStale(ctx context.Context) error {
+	_, err := r.helper.Exec(ctx, `
+		UPDATE occurrence_horizons
+		SET stale = 1, generation = generation + 1
+		WHERE schedule_id IN (SELECT schedule_id FROM recurrences WHERE holiday_policy <> '')
+	`)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// ListOccurrenceRefreshes lists schedules that are stale or materialized to
+// less than horizon, oldest refresh first
+func (r *OccurrenceRepository) ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]persistence.OccurrenceRefresh, error) {
+	query := `
+		SELECT schedule_id, generation
+		FROM occurrence_horizons
+		WHERE stale = 1 OR materialized_until IS NULL OR materialized_until < ?
+		ORDER BY refreshed_at ASC, schedule_id ASC
+	`
+	args := []interface{}{formatOccurrenceTime(horizon)}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var refreshes []persistence.OccurrenceRefresh
+	for rows.Next() {
+		var refresh persistence.OccurrenceRefresh
+		if err := rows.Scan(&refresh.ScheduleID, &refresh.Generation); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		refreshes = append(refreshes, refresh)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return refreshes, nil
+}
+
+// ReplaceOccurrences swaps a schedule's materialized occurrences for the
+// regenerated ones, provided its generation has not moved since the refresh
+// was listed
+func (r *OccurrenceRepository) ReplaceOccurrences(ctx context.Context, refresh persistence.OccurrenceRefresh, until time.Time, occurrences []persistence.Occurrence) error {
+	if refresh.ScheduleID == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := r.helper.ExecTx(tx, `
+			UPDATE occurrence_horizons
+			SET stale = 0, materialized_until = ?, refreshed_at = ?
+			WHERE schedule_id = ? AND generation = ?
+		`, formatOccurrenceTime(until), formatOccurrenceTime(time.Now()), refresh.ScheduleID, refresh.Generation)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if affected == 0 {
+			return persistence.ErrVersionConflict
+		}
+
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM occurrences WHERE schedule_id = ?", refresh.ScheduleID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		for _, occurrence := range occurrences {
+			_, err := r.helper.ExecTx(tx, `
+				INSERT OR IGNORE INTO occurrences (schedule_id, rule_id, start_time, end_time)
+				VALUES (?, ?, ?, ?)
+			`, refresh.ScheduleID, occurrence.RuleID, formatOccurrenceTime(occurrence.Start), formatOccurrenceTime(occurrence.End))
+			if err != nil {
+				return r.mapper.MapError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListOccurrences returns the materialized occurrences of the selected
+// schedules that overlap the filter window, in start order. Schedules that are
+// stale, or materialized to less than the window end, are left out so callers
+// generate them instead; fresh schedules without an occurrence in the window
+// map to an empty slice.
+func (r *OccurrenceRepository) ListOccurrences(ctx context.Context, filter persistence.OccurrenceFilter) (map[string][]persistence.Occurrence, error) {
+	occurrences := make(map[string][]persistence.Occurrence)
+	if len(filter.ScheduleIDs) == 0 {
+		return occurrences, nil
+	}
+
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		placeholders := make([]string, len(filter.ScheduleIDs))
+		args := make([]interface{}, 0, len(filter.ScheduleIDs)+1)
+		for i, id := range filter.ScheduleIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		query := fmt.Sprintf(`
+			SELECT schedule_id
+			FROM occurrence_horizons
+			WHERE schedule_id IN (%s) AND stale = 0 AND materialized_until IS NOT NULL
+		`, strings.Join(placeholders, ","))
+		if filter.End != nil {
+			query += " AND materialized_until >= ?"
+			args = append(args, formatOccurrenceTime(*filter.End))
+		}
+
+		fresh, err := r.scanScheduleIDs(tx, query, args...)
+		if err != nil {
+			return err
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		placeholders = make([]string, len(fresh))
+		args = make([]interface{}, 0, len(fresh)+2)
+		for i, id := range fresh {
+			occurrences[id] = []persistence.Occurrence{}
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		query = fmt.Sprintf(`
+			SELECT schedule_id, rule_id, start_time, end_time
+			FROM occurrences
+			WHERE schedule_id IN (%s)
+		`, strings.Join(placeholders, ","))
+		if filter.End != nil {
+			query += " AND start_time < ?"
+			args = append(args, formatOccurrenceTime(*filter.End))
+		}
+		if filter.Start != nil {
+			query += " AND end_time > ?"
+			args = append(args, formatOccurrenceTime(*filter.Start))
+		}
+		query += " ORDER BY schedule_id ASC, start_time ASC, rule_id ASC"
+
+		rows, err := r.helper.QueryTx(tx, query, args...)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var occurrence persistence.Occurrence
+			var startTime, endTime string
+			if err := rows.Scan(&occurrence.ScheduleID, &occurrence.RuleID, &startTime, &endTime); err != nil {
+				return r.mapper.MapError(err)
+			}
+			if occurrence.Start, err = time.Parse(time.RFC3339, startTime); err != nil {
+				return fmt.Errorf("failed to parse occurrence start: %w", err)
+			}
+			if occurrence.End, err = time.Parse(time.RFC3339, endTime); err != nil {
+				return fmt.Errorf("failed to parse occurrence end: %w", err)
+			}
+			occurrences[occurrence.ScheduleID] = append(occurrences[occurrence.ScheduleID], occurrence)
+		}
+		if err := rows.Err(); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+func (r *OccurrenceRepository) scanScheduleIDs(tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.helper.QueryTx(tx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return ids, nil
+}
+
+// ResetOccurrences drops every materialized occurrence and queues each
+// recurring schedule for regeneration, returning how many were queued
+func (r *OccurrenceRepository) ResetOccurrences(ctx context.Context) (int, error) {
+	var queued int64
+	err := r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM occurrences"); err != nil {
+			return r.mapper.MapError(err)
+		}
+		// Generations keep growing so refreshes already in flight are discarded
+		if _, err := r.helper.ExecTx(tx, `
+			UPDATE occurrence_horizons
+			SET stale = 1, materialized_until = NULL, generation = generation + 1
+		`); err != nil {
+			return r.mapper.MapError(err)
+		}
+		if _, err := r.helper.ExecTx(tx, `
+			DELETE FROM occurrence_horizons
+			WHERE schedule_id NOT IN (SELECT schedule_id FROM recurrences)
+		`); err != nil {
+			return r.mapper.MapError(err)
+		}
+		if _, err := r.helper.ExecTx(tx, `
+			INSERT OR IGNORE INTO occurrence_horizons (schedule_id)
+			SELECT DISTINCT schedule_id FROM recurrences
+		`); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return r.helper.QueryRowTx(tx, "SELECT COUNT(*) FROM occurrence_horizons").Scan(&queued)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(queued), nil
+}
+
+// formatOccurrenceTime uses the fixed-width UTC layout of schedule times so
+// occurrences compare correctly as text
+func formatOccurrenceTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
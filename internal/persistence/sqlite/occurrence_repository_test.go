@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestOccurrenceRepository(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// Only the columns the occurrence index reads are needed here.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE schedules (id TEXT PRIMARY KEY);
+		CREATE TABLE recurrences (id TEXT PRIMARY KEY, schedule_id TEXT NOT NULL, holiday_policy TEXT NOT NULL DEFAULT '');
+		INSERT INTO schedules (id) VALUES ('standup'), ('review'), ('lunch');
+		INSERT INTO recurrences (id, schedule_id) VALUES ('rule-1', 'standup');
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	schema, err := embeddedMigrations.ReadFile("migrations/018_occurrences.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	repo := NewOccurrenceRepository(pool)
+
+	horizon := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	refreshes, err := repo.ListOccurrenceRefreshes(ctx, horizon, 10)
+	if err != nil || len(refreshes) != 1 || refreshes[0].ScheduleID != "standup" {
+		t.Fatalf("expected the existing series to be queued by the migration, got %+v (err %v)", refreshes, err)
+	}
+
+	monday := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	var occurrences []persistence.Occurrence
+	for week := 0; week < 4; week++ {
+		start := monday.AddDate(0, 0, 7*week)
+		occurrences = append(occurrences, persistence.Occurrence{ScheduleID: "standup", RuleID: "rule-1", Start: start, End: start.Add(30 * time.Minute)})
+	}
+	if err := repo.ReplaceOccurrences(ctx, refreshes[0], horizon, occurrences); err != nil {
+		t.Fatalf("ReplaceOccurrences returned error: %v", err)
+	}
+	if refreshes, err = repo.ListOccurrenceRefreshes(ctx, horizon, 10); err != nil || len(refreshes) != 0 {
+		t.Fatalf("expected nothing queued after the refresh, got %+v (err %v)", refreshes, err)
+	}
+
+	from := time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 18, 0, 0, 0, 0, time.UTC)
+	listed, err := repo.ListOccurrences(ctx, persistence.OccurrenceFilter{ScheduleIDs: []string{"standup", "review"}, Start: &from, End: &to})
+	if err != nil {
+		t.Fatalf("ListOccurrences returned error: %v", err)
+	}
+	if _, ok := listed["review"]; ok {
+		t.Fatalf("expected untracked schedules to be left out, got %+v", listed)
+	}
+	if got := listed["standup"]; len(got) != 2 || !got[0].Start.Equal(monday.AddDate(0, 0, 7)) {
+		t.Fatalf("expected the two occurrences inside the window, got %+v", got)
+	}
+
+	beyond := horizon.AddDate(0, 0, 1)
+	if listed, err = repo.ListOccurrences(ctx, persistence.OccurrenceFilter{ScheduleIDs: []string{"standup"}, End: &beyond}); err != nil || len(listed) != 0 {
+		t.Fatalf("expected a window past the horizon to be left to generation, got %+v (err %v)", listed, err)
+	}
+
+	t.Run("stale schedules are not listed and old refreshes are rejected", func(t *testing.T) {
+		stale := persistence.OccurrenceRefresh{ScheduleID: "standup", Generation: 1}
+		if err := repo.MarkOccurrencesStale(ctx, "standup"); err != nil {
+			t.Fatalf("MarkOccurrencesStale returned error: %v", err)
+		}
+		if listed, err := repo.ListOccurrences(ctx, persistence.OccurrenceFilter{ScheduleIDs: []string{"standup"}}); err != nil || len(listed) != 0 {
+			t.Fatalf("expected a stale schedule to be left out, got %+v (err %v)", listed, err)
+		}
+		if err := repo.ReplaceOccurrences(ctx, stale, horizon, nil); !errors.Is(err, persistence.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict for an outdated refresh, got %v", err)
+		}
+
+		if err := repo.MarkOccurrencesStale(ctx, "lunch"); err != nil {
+			t.Fatalf("MarkOccurrencesStale returned error: %v", err)
+		}
+		refreshes, err := repo.ListOccurrenceRefreshes(ctx, horizon, 10)
+		if err != nil || len(refreshes) != 1 || refreshes[0].Generation != 2 {
+			t.Fatalf("expected only the recurring schedule to be queued at generation 2, got %+v (err %v)", refreshes, err)
+		}
+	})
+
+	t.Run("reset queues every recurring schedule", func(t *testing.T) {
+		if _, err := pool.DB().ExecContext(ctx, "INSERT INTO recurrences (id, schedule_id, holiday_policy) VALUES ('rule-2', 'review', 'skip')"); err != nil {
+			t.Fatalf("Failed to insert recurrence: %v", err)
+		}
+		queued, err := repo.ResetOccurrences(ctx)
+		if err != nil || queued != 2 {
+			t.Fatalf("expected two schedules queued, got %d (err %v)", queued, err)
+		}
+		refreshes, err := repo.ListOccurrenceRefreshes(ctx, horizon, 10)
+		if err != nil || len(refreshes) != 2 {
+			t.Fatalf("expected two refreshes, got %+v (err %v)", refreshes, err)
+		}
+		for _, refresh := range refreshes {
+			if err := repo.ReplaceOccurrences(ctx, refresh, horizon, nil); err != nil {
+				t.Fatalf("ReplaceOccurrences returned error: %v", err)
+			}
+		}
+
+		if err := repo.MarkHolidayOccurrencesStale(ctx); err != nil {
+			t.Fatalf("MarkHolidayOccurrencesStale returned error: %v", err)
+		}
+		refreshes, err = repo.ListOccurrenceRefreshes(ctx, horizon, 10)
+		if err != nil || len(refreshes) != 1 || refreshes[0].ScheduleID != "review" {
+			t.Fatalf("expected only the holiday-aware series to be queued, got %+v (err %v)", refreshes, err)
+		}
+	})
+}
@@ -83,8 +83,8 @@ func (r *RecurrenceRepository) UpsertRecurrence(ctx context.Context, rule persis
 		// Upsert the recurrence rule
 		query := `
 			INSERT OR REPLACE INTO recurrences 
-			(id, schedule_id, frequency, interval_value, weekdays, starts_on, ends_on, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			(id, schedule_id, frequency, interval_value, weekdays, starts_on, ends_on, holiday_policy, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		
 		_, err = r.helper.ExecTx(tx, query,
@@ -95,6 +95,7 @@ func (r *RecurrenceRepository) UpsertRecurrence(ctx context.Context, rule persis
 			weekdayMask,
 			rule.StartsOn.Format(time.RFC3339),
 			endsOn,
+			rule.HolidayPolicy,
 			rule.CreatedAt.Format(time.RFC3339),
 			rule.UpdatedAt.Format(time.RFC3339),
 		)
@@ -114,7 +115,7 @@ func (r *RecurrenceRepository) ListRecurrencesForSchedule(ctx context.Context, s
 	}
 	
 	query := `
-		SELECT id, schedule_id, frequency, interval_value, weekdays, starts_on, ends_on, created_at, updated_at
+		SELECT id, schedule_id, frequency, interval_value, weekdays, starts_on, ends_on, holiday_policy, created_at, updated_at
 		FROM recurrences
 		WHERE schedule_id = ?
 		ORDER BY created_at ASC, id ASC
@@ -143,6 +144,7 @@ func (r *RecurrenceRepository) ListRecurrencesForSchedule(ctx context.Context, s
 			&weekdayMask,
 			&startsOnStr,
 			&endsOn,
+			&rule.HolidayPolicy,
 			&createdAtStr,
 			&updatedAtStr,
 		)
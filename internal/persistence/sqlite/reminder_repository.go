@@ -0,0 +1,304 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// ReminderRepository implements persistence.ReminderRepository using SQLite
+type ReminderRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewReminderRepository creates a new SQLite reminder repository
+func NewReminderRepository(pool *ConnectionPool) *ReminderRepository {
+	return &ReminderRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// GetReminderPreferences returns a user's stored preferences or ErrNotFound
+func (r *ReminderRepository) GetReminderPreferences(ctx context.Context, userID string) (persistence.ReminderPreferences, error) {
+	if userID == "" {
+		return persistence.ReminderPreferences{}, persistence.ErrNotFound
+	}
+
+	var minutes, channels, updatedAtStr string
+	err := r.helper.QueryRow(ctx,
+		"SELECT minutes_before, channels, updated_at FROM reminder_preferences WHERE user_id = ?",
+		userID,
+	).Scan(&minutes, &channels, &updatedAtStr)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.ReminderPreferences{}, persistence.ErrNotFound
+		}
+		return persistence.ReminderPreferences{}, r.mapper.MapError(err)
+	}
+	return decodeReminderPreferences(userID, minutes, channels, updatedAtStr)
+}
+
+// SaveReminderPreferences inserts or replaces a user's preferences
+func (r *ReminderRepository) SaveReminderPreferences(ctx context.Context, prefs persistence.ReminderPreferences) error {
+	if prefs.UserID == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	updatedAt := prefs.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO reminder_preferences (user_id, minutes_before, channels, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			minutes_before = excluded.minutes_before,
+			channels = excluded.channels,
+			updated_at = excluded.updated_at
+	`,
+		prefs.UserID,
+		encodeReminderMinutes(prefs.MinutesBefore),
+		strings.Join(prefs.Channels, ","),
+		updatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrNotFound
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// ListReminderPreferences returns the stored preferences of the given users
+func (r *ReminderRepository) ListReminderPreferences(ctx context.Context, userIDs []string) ([]persistence.ReminderPreferences, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inPlaceholders(userIDs)
+	rows, err := r.helper.Query(ctx,
+		"SELECT user_id, minutes_before, channels, updated_at FROM reminder_preferences WHERE user_id IN ("+placeholders+") ORDER BY user_id ASC",
+		args...,
+	)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var out []persistence.ReminderPreferences
+	for rows.Next() {
+		var userID, minutes, channels, updatedAtStr string
+		if err := rows.Scan(&userID, &minutes, &channels, &updatedAtStr); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		prefs, err := decodeReminderPreferences(userID, minutes, channels, updatedAtStr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, prefs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return out, nil
+}
+
+// ListRecurringScheduleIDs returns schedules with a recurrence that has not ended before activeFrom
+func (r *ReminderRepository) ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error) {
+	rows, err := r.helper.Query(ctx, `
+		SELECT DISTINCT schedule_id
+		FROM recurrences
+		WHERE (ends_on IS NULL OR datetime(ends_on) >= datetime(?))
+			AND schedule_id IN (SELECT id FROM schedules WHERE deleted_at IS NULL)
+		ORDER BY schedule_id ASC
+	`, activeFrom.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return ids, nil
+}
+
+// ClaimReminderDelivery marks a reminder as sending when it is new or when a
+// previous attempt failed fewer than maxAttempts times. The lookup and write
+// share a transaction so concurrent dispatchers never claim the same reminder.
+// A claim is a lease: a row still sending lease after it was claimed, left by
+// a dispatcher that stopped before FinishReminderDelivery, is claimed again
+// like a failed one.
+func (r *ReminderRepository) ClaimReminderDelivery(ctx context.Context, delivery persistence.ReminderDelivery, maxAttempts int, lease time.Duration) (persistence.ReminderDelivery, bool, error) {
+	if delivery.ID == "" || delivery.ScheduleID == "" || delivery.UserID == "" || delivery.Channel == "" {
+		return persistence.ReminderDelivery{}, false, persistence.ErrConstraintViolation
+	}
+
+	now := delivery.UpdatedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	occurrenceStart := delivery.OccurrenceStart.UTC().Format(time.RFC3339)
+
+	claimed := false
+	err := r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var existingID, status, createdAtStr string
+		var attempts int
+		var lastError, claimedAtStr sql.NullString
+		err := r.helper.QueryRowTx(tx, `
+			SELECT id, status, attempts, last_error, created_at, claimed_at
+			FROM reminder_deliveries
+			WHERE schedule_id = ? AND occurrence_start = ? AND user_id = ? AND minutes_before = ? AND channel = ?
+		`, delivery.ScheduleID, occurrenceStart, delivery.UserID, delivery.MinutesBefore, delivery.Channel,
+		).Scan(&existingID, &status, &attempts, &lastError, &createdAtStr, &claimedAtStr)
+
+		switch {
+		case err == sql.ErrNoRows:
+			delivery.Status = "sending"
+			delivery.Attempts = 1
+			delivery.CreatedAt = now
+			delivery.UpdatedAt = now
+			_, err = r.helper.ExecTx(tx, `
+				INSERT INTO reminder_deliveries
+				(id, schedule_id, occurrence_start, user_id, minutes_before, channel, status, attempts, due_at, created_at, updated_at, claimed_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`,
+				delivery.ID,
+				delivery.ScheduleID,
+				occurrenceStart,
+				delivery.UserID,
+				delivery.MinutesBefore,
+				delivery.Channel,
+				delivery.Status,
+				delivery.Attempts,
+				delivery.DueAt.UTC().Format(time.RFC3339),
+				now.UTC().Format(time.RFC3339),
+				now.UTC().Format(time.RFC3339),
+				now.UTC().Format(time.RFC3339),
+			)
+			if err != nil {
+				return r.mapper.MapError(err)
+			}
+			claimed = true
+			return nil
+		case err != nil:
+			return r.mapper.MapError(err)
+		}
+
+		delivery.ID = existingID
+		delivery.Status = status
+		delivery.Attempts = attempts
+		delivery.LastError = lastError.String
+		if delivery.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+			return fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		abandoned := false
+		if status == "sending" && claimedAtStr.Valid {
+			claimedAt, err := time.Parse(time.RFC3339, claimedAtStr.String)
+			if err != nil {
+				return fmt.Errorf("failed to parse claimed_at: %w", err)
+			}
+			abandoned = !claimedAt.Add(lease).After(now)
+		}
+		if (status != "failed" && !abandoned) || attempts >= maxAttempts {
+			return nil
+		}
+
+		delivery.Status = "sending"
+		delivery.Attempts = attempts + 1
+		delivery.UpdatedAt = now
+		_, err = r.helper.ExecTx(tx,
+			"UPDATE reminder_deliveries SET status = ?, attempts = ?, updated_at = ?, claimed_at = ? WHERE id = ?",
+			delivery.Status, delivery.Attempts, now.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339), existingID,
+		)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return persistence.ReminderDelivery{}, false, err
+	}
+	return delivery, claimed, nil
+}
+
+// FinishReminderDelivery records the outcome of a claimed delivery
+func (r *ReminderRepository) FinishReminderDelivery(ctx context.Context, delivery persistence.ReminderDelivery) error {
+	updatedAt := delivery.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	var lastError sql.NullString
+	if delivery.LastError != "" {
+		lastError = sql.NullString{String: delivery.LastError, Valid: true}
+	}
+
+	result, err := r.helper.Exec(ctx,
+		"UPDATE reminder_deliveries SET status = ?, last_error = ?, updated_at = ? WHERE id = ?",
+		delivery.Status, lastError, updatedAt.UTC().Format(time.RFC3339), delivery.ID,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// encodeReminderMinutes stores reminder offsets as a comma separated list
+func encodeReminderMinutes(minutes []int) string {
+	parts := make([]string, len(minutes))
+	for i, before := range minutes {
+		parts[i] = strconv.Itoa(before)
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeReminderPreferences(userID, minutes, channels, updatedAtStr string) (persistence.ReminderPreferences, error) {
+	prefs := persistence.ReminderPreferences{UserID: userID, Channels: []string{}}
+	if minutes != "" {
+		for _, part := range strings.Split(minutes, ",") {
+			before, err := strconv.Atoi(part)
+			if err != nil {
+				return persistence.ReminderPreferences{}, fmt.Errorf("failed to parse minutes_before: %w", err)
+			}
+			prefs.MinutesBefore = append(prefs.MinutesBefore, before)
+		}
+	}
+	if channels != "" {
+		prefs.Channels = strings.Split(channels, ",")
+	}
+	var err error
+	if prefs.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+		return persistence.ReminderPreferences{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	return prefs, nil
+}
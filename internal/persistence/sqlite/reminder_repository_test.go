@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestReminderRepository_ClaimReminderDelivery(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// Only the tables the reminder migration references are needed here.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE users (id TEXT PRIMARY KEY);
+		CREATE TABLE schedules (id TEXT PRIMARY KEY);
+		INSERT INTO users (id) VALUES ('user-1');
+		INSERT INTO schedules (id) VALUES ('standup');
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	for _, name := range []string{"migrations/007_reminders.sql", "migrations/023_reminder_claim_lease.sql"} {
+		schema, err := embeddedMigrations.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read migration %s: %v", name, err)
+		}
+		if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+			t.Fatalf("Failed to apply migration %s: %v", name, err)
+		}
+	}
+	repo := NewReminderRepository(pool)
+
+	occurrence := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	due := occurrence.Add(-10 * time.Minute)
+	lease := 5 * time.Minute
+	claim := func(id string, at time.Time) (persistence.ReminderDelivery, bool) {
+		t.Helper()
+		delivery, claimed, err := repo.ClaimReminderDelivery(ctx, persistence.ReminderDelivery{
+			ID:              id,
+			ScheduleID:      "standup",
+			OccurrenceStart: occurrence,
+			UserID:          "user-1",
+			MinutesBefore:   10,
+			Channel:         "email",
+			DueAt:           due,
+			UpdatedAt:       at,
+		}, 3, lease)
+		if err != nil {
+			t.Fatalf("ClaimReminderDelivery returned error: %v", err)
+		}
+		return delivery, claimed
+	}
+	fail := func(delivery persistence.ReminderDelivery) {
+		t.Helper()
+		delivery.Status = "failed"
+		delivery.LastError = "smtp unavailable"
+		if err := repo.FinishReminderDelivery(ctx, delivery); err != nil {
+			t.Fatalf("FinishReminderDelivery returned error: %v", err)
+		}
+	}
+
+	first, claimed := claim("delivery-1", due)
+	if !claimed || first.Status != "sending" || first.Attempts != 1 {
+		t.Fatalf("expected the first claim to succeed, got %+v (claimed %v)", first, claimed)
+	}
+	if second, claimed := claim("delivery-2", due.Add(time.Minute)); claimed || second.ID != "delivery-1" || second.Status != "sending" {
+		t.Fatalf("expected a claim inside the lease to be refused, got %+v (claimed %v)", second, claimed)
+	}
+
+	// The first dispatcher never finished: once the lease runs out the reminder is taken over
+	takeover, claimed := claim("delivery-3", due.Add(lease))
+	if !claimed || takeover.ID != "delivery-1" || takeover.Attempts != 2 {
+		t.Fatalf("expected an abandoned claim to be taken over, got %+v (claimed %v)", takeover, claimed)
+	}
+	if again, claimed := claim("delivery-4", due.Add(lease+time.Minute)); claimed || again.Attempts != 2 {
+		t.Fatalf("expected the takeover to renew the lease, got %+v (claimed %v)", again, claimed)
+	}
+
+	fail(takeover)
+	retry, claimed := claim("delivery-5", due.Add(lease+time.Minute))
+	if !claimed || retry.ID != "delivery-1" || retry.Attempts != 3 {
+		t.Fatalf("expected the failed reminder to be retried, got %+v (claimed %v)", retry, claimed)
+	}
+
+	fail(retry)
+	if exhausted, claimed := claim("delivery-6", due.Add(time.Hour)); claimed || exhausted.Attempts != 3 || exhausted.LastError != "smtp unavailable" {
+		t.Fatalf("expected no retry after maxAttempts, got %+v (claimed %v)", exhausted, claimed)
+	}
+}
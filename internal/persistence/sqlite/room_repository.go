@@ -11,17 +11,19 @@ import (
 
 // RoomRepository implements persistence.RoomRepository using SQLite
 type RoomRepository struct {
-	pool   *ConnectionPool
-	helper *QueryHelper
-	mapper *ErrorMapper
+	pool      *ConnectionPool
+	helper    *QueryHelper
+	mapper    *ErrorMapper
+	schedules *ScheduleRepository
 }
 
 // NewRoomRepository creates a new SQLite room repository
 func NewRoomRepository(pool *ConnectionPool) *RoomRepository {
 	return &RoomRepository{
-		pool:   pool,
-		helper: NewQueryHelper(pool),
-		mapper: NewErrorMapper(),
+		pool:      pool,
+		helper:    NewQueryHelper(pool),
+		mapper:    NewErrorMapper(),
+		schedules: NewScheduleRepository(pool),
 	}
 }
 
@@ -74,8 +76,8 @@ func (r *RoomRepository) UpdateRoom(ctx context.Context, room persistence.Room)
 	
 	query := `
 		UPDATE rooms 
-		SET name = ?, capacity = ?, location = ?, updated_at = ?
-		WHERE id = ?
+		SET name = ?, capacity = ?, location = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)
 	`
 	
 	result, err := r.helper.Exec(ctx, query,
@@ -84,6 +86,8 @@ func (r *RoomRepository) UpdateRoom(ctx context.Context, room persistence.Room)
 		room.Location,
 		room.UpdatedAt.Format(time.RFC3339),
 		room.ID,
+		room.Version,
+		room.Version,
 	)
 	
 	if err != nil {
@@ -96,7 +100,19 @@ func (r *RoomRepository) UpdateRoom(ctx context.Context, room persistence.Room)
 	}
 	
 	if rowsAffected == 0 {
-		return persistence.ErrNotFound
+		if room.Version == 0 {
+			return persistence.ErrNotFound
+		}
+		// Tell a missing room apart from one updated by another writer
+		var exists int
+		err := r.helper.QueryRow(ctx, "SELECT COUNT(*) FROM rooms WHERE id = ? AND deleted_at IS NULL", room.ID).Scan(&exists)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		if exists == 0 {
+			return persistence.ErrNotFound
+		}
+		return persistence.ErrVersionConflict
 	}
 	
 	return nil
@@ -109,9 +125,9 @@ func (r *RoomRepository) GetRoom(ctx context.Context, id string) (persistence.Ro
 	}
 	
 	query := `
-		SELECT id, name, capacity, location, created_at, updated_at
+		SELECT id, name, capacity, location, created_at, updated_at, version
 		FROM rooms
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 	
 	var room persistence.Room
@@ -125,6 +141,7 @@ func (r *RoomRepository) GetRoom(ctx context.Context, id string) (persistence.Ro
 		&location,
 		&createdAtStr,
 		&updatedAtStr,
+		&room.Version,
 	)
 	
 	if err != nil {
@@ -153,12 +170,43 @@ func (r *RoomRepository) GetRoom(ctx context.Context, id string) (persistence.Ro
 // ListRooms returns all rooms ordered by name then ID
 func (r *RoomRepository) ListRooms(ctx context.Context) ([]persistence.Room, error) {
 	query := `
-		SELECT id, name, capacity, location, created_at, updated_at
+		SELECT id, name, capacity, location, created_at, updated_at, version
 		FROM rooms
+		WHERE deleted_at IS NULL
 		ORDER BY name ASC, id ASC
 	`
 	
-	rows, err := r.helper.Query(ctx, query)
+	return r.queryRooms(ctx, query)
+}
+
+// ListRoomsPage returns rooms ordered by case-insensitive name then ID, starting after the filter's keyset bound
+func (r *RoomRepository) ListRoomsPage(ctx context.Context, filter persistence.RoomFilter) ([]persistence.Room, error) {
+	query := `
+		SELECT id, name, capacity, location, created_at, updated_at, version
+		FROM rooms
+		WHERE deleted_at IS NULL
+	`
+	
+	var args []interface{}
+	
+	if filter.AfterID != "" {
+		query += " AND (name COLLATE NOCASE > ? OR (name COLLATE NOCASE = ? AND id > ?))"
+		args = append(args, filter.AfterName, filter.AfterName, filter.AfterID)
+	}
+	
+	query += " ORDER BY name COLLATE NOCASE ASC, id ASC"
+	
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	
+	return r.queryRooms(ctx, query, args...)
+}
+
+// queryRooms executes a room listing query and scans the result rows
+func (r *RoomRepository) queryRooms(ctx context.Context, query string, args ...interface{}) ([]persistence.Room, error) {
+	rows, err := r.helper.Query(ctx, query, args...)
 	if err != nil {
 		return nil, r.mapper.MapError(err)
 	}
@@ -178,6 +226,7 @@ func (r *RoomRepository) ListRooms(ctx context.Context) ([]persistence.Room, err
 			&location,
 			&createdAtStr,
 			&updatedAtStr,
+			&room.Version,
 		)
 		
 		if err != nil {
@@ -214,29 +263,233 @@ func (r *RoomRepository) DeleteRoom(ctx context.Context, id string) error {
 	}
 	
 	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// Update schedules that reference this room to have no room
-		_, err := r.helper.ExecTx(tx, "UPDATE schedules SET room_id = NULL WHERE room_id = ?", id)
-		if err != nil {
+		return r.deleteRoomRows(tx, id, time.Now().UTC())
+	})
+}
+
+// deleteRoomRows detaches a room from its schedules and deletes it within tx.
+// Live schedules that lose the room are recorded as changed at deletedAt.
+func (r *RoomRepository) deleteRoomRows(tx *sql.Tx, id string, deletedAt time.Time) error {
+	if err := r.detachSchedules(tx, id, deletedAt); err != nil {
+		return err
+	}
+	
+	// Delete the room
+	result, err := r.helper.ExecTx(tx, "DELETE FROM rooms WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	
+	return nil
+}
+
+// detachSchedules removes a room from the schedules booked in it within tx and
+// records the live ones in the change log so synced clients see the room go
+func (r *RoomRepository) detachSchedules(tx *sql.Tx, roomID string, detachedAt time.Time) error {
+	rows, err := r.helper.QueryTx(tx, "SELECT id, creator_id FROM schedules WHERE room_id = ? AND deleted_at IS NULL", roomID)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	audiences := make(map[string][]string)
+	var scheduleIDs []string
+	for rows.Next() {
+		var scheduleID, creatorID string
+		if err := rows.Scan(&scheduleID, &creatorID); err != nil {
+			rows.Close()
 			return r.mapper.MapError(err)
 		}
-		
-		// Delete the room
-		result, err := r.helper.ExecTx(tx, "DELETE FROM rooms WHERE id = ?", id)
+		scheduleIDs = append(scheduleIDs, scheduleID)
+		audiences[scheduleID] = []string{creatorID}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return r.mapper.MapError(err)
+	}
+	rows.Close()
+	
+	// Trashed schedules lose the room too but stay out of the change log
+	_, err = r.helper.ExecTx(tx, "UPDATE schedules SET room_id = NULL, updated_at = ?, version = version + 1 WHERE room_id = ?",
+		detachedAt.UTC().Format(time.RFC3339), roomID)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	for _, scheduleID := range scheduleIDs {
+		participants, err := r.schedules.loadParticipantsTx(tx, scheduleID)
 		if err != nil {
-			return r.mapper.MapError(err)
+			return err
+		}
+		audience := append(audiences[scheduleID], participants...)
+		if err := r.schedules.recordChange(tx, scheduleID, scheduleChangeUpsert, detachedAt, audience); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// TrashRoom soft-deletes a room; schedules keep their booking so a restore is lossless
+func (r *RoomRepository) TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	if id == "" {
+		return persistence.ErrNotFound
+	}
+	
+	result, err := r.helper.Exec(ctx, "UPDATE rooms SET deleted_at = ?, deleted_by = ? WHERE id = ? AND deleted_at IS NULL",
+		deletedAt.UTC().Format(time.RFC3339), deletedBy, id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	return r.requireRowAffected(result)
+}
+
+// RestoreRoom brings a trashed room back
+func (r *RoomRepository) RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error {
+	if id == "" {
+		return persistence.ErrNotFound
+	}
+	
+	result, err := r.helper.Exec(ctx, "UPDATE rooms SET deleted_at = NULL, deleted_by = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL",
+		restoredAt.UTC().Format(time.RFC3339), id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	return r.requireRowAffected(result)
+}
+
+// GetTrashedRoom retrieves a trashed room by ID
+func (r *RoomRepository) GetTrashedRoom(ctx context.Context, id string) (persistence.Room, error) {
+	if id == "" {
+		return persistence.Room{}, persistence.ErrNotFound
+	}
+	
+	rooms, err := r.queryTrashedRooms(ctx, "id = ?", id)
+	if err != nil {
+		return persistence.Room{}, err
+	}
+	if len(rooms) == 0 {
+		return persistence.Room{}, persistence.ErrNotFound
+	}
+	return rooms[0], nil
+}
+
+// ListTrashedRooms lists trashed rooms, most recently deleted first
+func (r *RoomRepository) ListTrashedRooms(ctx context.Context, deletedBy string) ([]persistence.Room, error) {
+	if deletedBy == "" {
+		return r.queryTrashedRooms(ctx, "1 = 1")
+	}
+	return r.queryTrashedRooms(ctx, "deleted_by = ?", deletedBy)
+}
+
+// PurgeRooms permanently deletes rooms trashed before deletedBefore, detaching
+// them from their schedules and recording those in the change log
+func (r *RoomRepository) PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error) {
+	rooms, err := r.queryTrashedRooms(ctx, "deleted_at < ?", deletedBefore.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	
+	purgedAt := time.Now().UTC()
+	err = r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, room := range rooms {
+			if err := r.deleteRoomRows(tx, room.ID, purgedAt); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rooms), nil
+}
+
+// queryTrashedRooms loads trashed rooms matching condition
+func (r *RoomRepository) queryTrashedRooms(ctx context.Context, condition string, args ...interface{}) ([]persistence.Room, error) {
+	query := `
+		SELECT id, name, capacity, location, created_at, updated_at, version, deleted_at, deleted_by
+		FROM rooms
+		WHERE deleted_at IS NOT NULL AND ` + condition + `
+		ORDER BY deleted_at DESC, id ASC
+	`
+	
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var rooms []persistence.Room
+	
+	for rows.Next() {
+		var room persistence.Room
+		var createdAtStr, updatedAtStr, deletedAtStr string
+		var location, deletedBy sql.NullString
+		
+		err := rows.Scan(
+			&room.ID,
+			&room.Name,
+			&room.Capacity,
+			&location,
+			&createdAtStr,
+			&updatedAtStr,
+			&room.Version,
+			&deletedAtStr,
+			&deletedBy,
+		)
 		
-		rowsAffected, err := result.RowsAffected()
 		if err != nil {
-			return fmt.Errorf("failed to get rows affected: %w", err)
+			return nil, r.mapper.MapError(err)
 		}
 		
-		if rowsAffected == 0 {
-			return persistence.ErrNotFound
+		room.Location = location.String
+		room.DeletedBy = deletedBy.String
+		
+		// Parse timestamps
+		if room.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		if room.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse updated_at: %w", err)
 		}
+		deletedAt, err := time.Parse(time.RFC3339, deletedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deleted_at: %w", err)
+		}
+		room.DeletedAt = &deletedAt
 		
-		return nil
-	})
+		rooms = append(rooms, room)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	
+	return rooms, nil
+}
+
+// requireRowAffected reports ErrNotFound when an update matched no room
+func (r *RoomRepository) requireRowAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	
+	return nil
 }
 
 // mapRoomError maps SQLite errors to appropriate persistence errors for room operations
@@ -2,8 +2,10 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/example/enterprise-scheduler/internal/persistence"
 	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
@@ -101,6 +103,48 @@ func TestRoomRepository_UpdateRoom(t *testing.T) {
 	}
 }
 
+func TestRoomRepository_UpdateRoom_ChecksVersion(t *testing.T) {
+	repo, cleanup := setupRoomRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := repo.CreateRoom(ctx, persistence.Room{ID: "room1", Name: "Annex", Capacity: 4}); err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+
+	room, err := repo.GetRoom(ctx, "room1")
+	if err != nil {
+		t.Fatalf("GetRoom failed: %v", err)
+	}
+	if room.Version != 1 {
+		t.Fatalf("Expected version 1 for a new room, got %d", room.Version)
+	}
+
+	room.Capacity = 6
+	if err := repo.UpdateRoom(ctx, room); err != nil {
+		t.Fatalf("UpdateRoom failed: %v", err)
+	}
+
+	// The second writer still holds version 1
+	room.Capacity = 8
+	if err := repo.UpdateRoom(ctx, room); err != persistence.ErrVersionConflict {
+		t.Fatalf("Expected ErrVersionConflict for a stale version, got %v", err)
+	}
+
+	room.ID = "missing"
+	if err := repo.UpdateRoom(ctx, room); err != persistence.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for a missing room, got %v", err)
+	}
+
+	retrieved, err := repo.GetRoom(ctx, "room1")
+	if err != nil {
+		t.Fatalf("GetRoom failed: %v", err)
+	}
+	if retrieved.Capacity != 6 || retrieved.Version != 2 {
+		t.Errorf("Expected capacity 6 at version 2, got %d at version %d", retrieved.Capacity, retrieved.Version)
+	}
+}
+
 func TestRoomRepository_ListRooms(t *testing.T) {
 	repo, cleanup := setupRoomRepositoryTest(t)
 	defer cleanup()
@@ -179,6 +223,126 @@ func TestRoomRepository_DeleteRoom(t *testing.T) {
 	}
 }
 
+func TestRoomRepository_TrashAndRestore(t *testing.T) {
+	repo, cleanup := setupRoomRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	room := persistence.Room{
+		ID:       "room1",
+		Name:     "Conference Room A",
+		Capacity: 10,
+		Location: "Building 1, Floor 2",
+	}
+
+	err := repo.CreateRoom(ctx, room)
+	if err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+
+	deletedAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	if err := repo.TrashRoom(ctx, "room1", "admin", deletedAt); err != nil {
+		t.Fatalf("TrashRoom failed: %v", err)
+	}
+
+	// Trashed rooms are hidden from reads and listings
+	if _, err := repo.GetRoom(ctx, "room1"); err != persistence.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for trashed room, got %v", err)
+	}
+	listed, err := repo.ListRooms(ctx)
+	if err != nil || len(listed) != 0 {
+		t.Fatalf("Expected no listed rooms, got %v (%v)", listed, err)
+	}
+
+	trashed, err := repo.ListTrashedRooms(ctx, "admin")
+	if err != nil {
+		t.Fatalf("ListTrashedRooms failed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].DeletedBy != "admin" || trashed[0].DeletedAt == nil || !trashed[0].DeletedAt.Equal(deletedAt) {
+		t.Fatalf("Unexpected trashed rooms: %+v", trashed)
+	}
+
+	if err := repo.RestoreRoom(ctx, "room1", deletedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("RestoreRoom failed: %v", err)
+	}
+	if _, err := repo.GetRoom(ctx, "room1"); err != nil {
+		t.Fatalf("GetRoom after restore failed: %v", err)
+	}
+	if err := repo.RestoreRoom(ctx, "room1", deletedAt); err != persistence.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound restoring a live room, got %v", err)
+	}
+
+	// Purging only removes rooms trashed before the cutoff
+	if err := repo.TrashRoom(ctx, "room1", "admin", deletedAt); err != nil {
+		t.Fatalf("TrashRoom failed: %v", err)
+	}
+	if purged, err := repo.PurgeRooms(ctx, deletedAt); err != nil || purged != 0 {
+		t.Fatalf("Expected nothing purged at the deletion time, got %d (%v)", purged, err)
+	}
+	if purged, err := repo.PurgeRooms(ctx, deletedAt.Add(time.Second)); err != nil || purged != 1 {
+		t.Fatalf("Expected one purged room, got %d (%v)", purged, err)
+	}
+	if _, err := repo.GetTrashedRoom(ctx, "room1"); err != persistence.ErrNotFound {
+		t.Fatalf("Expected purged room to be gone, got %v", err)
+	}
+}
+
+func TestRoomRepository_PurgeRooms_RecordsDetachedSchedules(t *testing.T) {
+	repo, cleanup := setupRoomRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := repo.CreateRoom(ctx, persistence.Room{ID: "room1", Name: "Conference Room A", Capacity: 10}); err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+	if _, err := repo.pool.DB().ExecContext(ctx, `
+		INSERT INTO schedules (id, creator_id, room_id, updated_at, deleted_at) VALUES
+			('booked', 'alice', 'room1', '2024-03-01T09:00:00Z', NULL),
+			('binned', 'alice', 'room1', '2024-03-01T09:00:00Z', '2024-03-02T09:00:00Z');
+		INSERT INTO schedule_participants (schedule_id, user_id) VALUES ('booked', 'bob');
+	`); err != nil {
+		t.Fatalf("Failed to insert schedules: %v", err)
+	}
+
+	deletedAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	if err := repo.TrashRoom(ctx, "room1", "admin", deletedAt); err != nil {
+		t.Fatalf("TrashRoom failed: %v", err)
+	}
+	if purged, err := repo.PurgeRooms(ctx, deletedAt.Add(time.Second)); err != nil || purged != 1 {
+		t.Fatalf("Expected one purged room, got %d (%v)", purged, err)
+	}
+
+	for _, id := range []string{"booked", "binned"} {
+		var roomID sql.NullString
+		var updatedAt string
+		var version int
+		if err := repo.pool.DB().QueryRowContext(ctx, "SELECT room_id, updated_at, version FROM schedules WHERE id = ?", id).Scan(&roomID, &updatedAt, &version); err != nil {
+			t.Fatalf("Failed to read schedule %s: %v", id, err)
+		}
+		if roomID.Valid || version != 2 || updatedAt == "2024-03-01T09:00:00Z" {
+			t.Fatalf("Expected %s to lose the room with a new version and update time, got room %v, version %d, updated %s", id, roomID, version, updatedAt)
+		}
+	}
+
+	// Only the live schedule is synced; its creator and participant both see it
+	var scheduleID, kind string
+	var users int
+	err := repo.pool.DB().QueryRowContext(ctx, `
+		SELECT c.schedule_id, c.kind, (SELECT COUNT(*) FROM schedule_change_users u WHERE u.sequence = c.sequence)
+		FROM schedule_changes c
+	`).Scan(&scheduleID, &kind, &users)
+	if err != nil {
+		t.Fatalf("Expected one change log entry, got %v", err)
+	}
+	if scheduleID != "booked" || kind != scheduleChangeUpsert || users != 2 {
+		t.Fatalf("Unexpected change log entry: %s %s for %d users", scheduleID, kind, users)
+	}
+	var changes int
+	if err := repo.pool.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM schedule_changes").Scan(&changes); err != nil || changes != 1 {
+		t.Fatalf("Expected exactly one change log entry, got %d (%v)", changes, err)
+	}
+}
+
 func setupRoomRepositoryTest(t *testing.T) (*RoomRepository, func()) {
 	// Create temporary database file
 	tempDir := t.TempDir()
@@ -200,14 +364,52 @@ func setupRoomRepositoryTest(t *testing.T) (*RoomRepository, func()) {
 			capacity INTEGER NOT NULL CHECK (capacity > 0),
 			location TEXT,
 			created_at TEXT NOT NULL,
-			updated_at TEXT NOT NULL
+			updated_at TEXT NOT NULL,
+			version INTEGER NOT NULL DEFAULT 1,
+			deleted_at TEXT,
+			deleted_by TEXT
 		);
 		
 		CREATE TABLE IF NOT EXISTS schedules (
 			id TEXT PRIMARY KEY,
+			creator_id TEXT NOT NULL DEFAULT '',
 			room_id TEXT,
+			updated_at TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL DEFAULT 1,
+			deleted_at TEXT,
 			FOREIGN KEY (room_id) REFERENCES rooms(id)
 		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_participants (
+			schedule_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			PRIMARY KEY (schedule_id, user_id)
+		);
+		
+		CREATE TABLE IF NOT EXISTS user_group_members (
+			group_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			PRIMARY KEY (group_id, user_id)
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_participant_groups (
+			schedule_id TEXT NOT NULL,
+			group_id TEXT NOT NULL,
+			PRIMARY KEY (schedule_id, group_id)
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_changes (
+			sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+			schedule_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			changed_at TEXT NOT NULL
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_change_users (
+			sequence INTEGER NOT NULL,
+			user_id TEXT NOT NULL,
+			PRIMARY KEY (sequence, user_id)
+		);
 	`)
 	if err != nil {
 		t.Fatalf("Failed to create test schema: %v", err)
@@ -10,6 +10,12 @@ import (
 	"github.com/example/enterprise-scheduler/internal/persistence"
 )
 
+// Kinds stored in schedule_changes.kind
+const (
+	scheduleChangeUpsert = "upsert"
+	scheduleChangeDelete = "delete"
+)
+
 // ScheduleRepository implements persistence.ScheduleRepository using SQLite
 type ScheduleRepository struct {
 	pool   *ConnectionPool
@@ -45,8 +51,8 @@ func (r *ScheduleRepository) CreateSchedule(ctx context.Context, schedule persis
 	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
 		// Insert the schedule
 		query := `
-			INSERT INTO schedules (id, title, start_time, end_time, creator_id, room_id, memo, web_conference_url, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO schedules (id, title, start_time, end_time, time_zone, all_day, busy, creator_id, room_id, calendar_id, memo, web_conference_url, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		
 		var roomID sql.NullString
@@ -55,6 +61,12 @@ func (r *ScheduleRepository) CreateSchedule(ctx context.Context, schedule persis
 			roomID.Valid = true
 		}
 		
+		var calendarID sql.NullString
+		if schedule.CalendarID != nil {
+			calendarID.String = *schedule.CalendarID
+			calendarID.Valid = true
+		}
+		
 		var memo sql.NullString
 		if schedule.Memo != nil {
 			memo.String = *schedule.Memo
@@ -70,10 +82,14 @@ func (r *ScheduleRepository) CreateSchedule(ctx context.Context, schedule persis
 		_, err := r.helper.ExecTx(tx, query,
 			schedule.ID,
 			schedule.Title,
-			schedule.Start.Format(time.RFC3339),
-			schedule.End.Format(time.RFC3339),
+			schedule.Start.UTC().Format(time.RFC3339),
+			schedule.End.UTC().Format(time.RFC3339),
+			timeZoneOrDefault(schedule.TimeZone),
+			schedule.AllDay,
+			schedule.Busy,
 			schedule.CreatorID,
 			roomID,
+			calendarID,
 			memo,
 			webConferenceURL,
 			schedule.CreatedAt.Format(time.RFC3339),
@@ -84,12 +100,26 @@ func (r *ScheduleRepository) CreateSchedule(ctx context.Context, schedule persis
 			return r.mapScheduleError(err)
 		}
 		
-		// Insert participants
+		// Insert participants and the groups invited as a live reference
 		if err := r.insertParticipants(tx, schedule.ID, schedule.Participants); err != nil {
 			return err
 		}
+		if err := r.insertParticipantGroups(tx, schedule.ID, schedule.ParticipantGroups); err != nil {
+			return err
+		}
 		
-		return nil
+		// Insert reminders
+		if err := r.insertReminders(tx, schedule.ID, schedule.ReminderMinutes); err != nil {
+			return err
+		}
+		
+		// Record the change for delta sync
+		participants, err := r.loadParticipantsTx(tx, schedule.ID)
+		if err != nil {
+			return err
+		}
+		audience := append([]string{schedule.CreatorID}, participants...)
+		return r.recordChange(tx, schedule.ID, scheduleChangeUpsert, schedule.CreatedAt, audience)
 	})
 }
 
@@ -108,15 +138,19 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 	schedule.UpdatedAt = time.Now().UTC()
 	
 	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// Get the current creator_id (it should not be changed)
+		// Get the current creator_id (it should not be changed) and version
 		var currentCreatorID string
-		err := r.helper.QueryRowTx(tx, "SELECT creator_id FROM schedules WHERE id = ?", schedule.ID).Scan(&currentCreatorID)
+		var currentVersion int
+		err := r.helper.QueryRowTx(tx, "SELECT creator_id, version FROM schedules WHERE id = ? AND deleted_at IS NULL", schedule.ID).Scan(&currentCreatorID, &currentVersion)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return persistence.ErrNotFound
 			}
 			return r.mapper.MapError(err)
 		}
+		if schedule.Version != 0 && schedule.Version != currentVersion {
+			return persistence.ErrVersionConflict
+		}
 		
 		// Use the current creator_id instead of the one from the input
 		schedule.CreatorID = currentCreatorID
@@ -124,8 +158,8 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 		// Update the schedule
 		query := `
 			UPDATE schedules 
-			SET title = ?, start_time = ?, end_time = ?, room_id = ?, memo = ?, web_conference_url = ?, updated_at = ?
-			WHERE id = ?
+			SET title = ?, start_time = ?, end_time = ?, time_zone = ?, all_day = ?, busy = ?, room_id = ?, calendar_id = ?, memo = ?, web_conference_url = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND deleted_at IS NULL AND version = ?
 		`
 		
 		var roomID sql.NullString
@@ -134,6 +168,12 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 			roomID.Valid = true
 		}
 		
+		var calendarID sql.NullString
+		if schedule.CalendarID != nil {
+			calendarID.String = *schedule.CalendarID
+			calendarID.Valid = true
+		}
+		
 		var memo sql.NullString
 		if schedule.Memo != nil {
 			memo.String = *schedule.Memo
@@ -148,13 +188,18 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 		
 		result, err := r.helper.ExecTx(tx, query,
 			schedule.Title,
-			schedule.Start.Format(time.RFC3339),
-			schedule.End.Format(time.RFC3339),
+			schedule.Start.UTC().Format(time.RFC3339),
+			schedule.End.UTC().Format(time.RFC3339),
+			timeZoneOrDefault(schedule.TimeZone),
+			schedule.AllDay,
+			schedule.Busy,
 			roomID,
+			calendarID,
 			memo,
 			webConferenceURL,
 			schedule.UpdatedAt.Format(time.RFC3339),
 			schedule.ID,
+			currentVersion,
 		)
 		
 		if err != nil {
@@ -166,8 +211,15 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 			return fmt.Errorf("failed to get rows affected: %w", err)
 		}
 		
+		// Another writer updated the schedule since it was read above
 		if rowsAffected == 0 {
-			return persistence.ErrNotFound
+			return persistence.ErrVersionConflict
+		}
+		
+		// Keep the previous participants so removed users see the change
+		previousParticipants, err := r.loadParticipantsTx(tx, schedule.ID)
+		if err != nil {
+			return err
 		}
 		
 		// Update participants
@@ -182,7 +234,32 @@ func (r *ScheduleRepository) UpdateSchedule(ctx context.Context, schedule persis
 			return err
 		}
 		
-		return nil
+		// Replace the live group references the same way
+		_, err = r.helper.ExecTx(tx, "DELETE FROM schedule_participant_groups WHERE schedule_id = ?", schedule.ID)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		if err := r.insertParticipantGroups(tx, schedule.ID, schedule.ParticipantGroups); err != nil {
+			return err
+		}
+		
+		// Replace reminders the same way
+		_, err = r.helper.ExecTx(tx, "DELETE FROM schedule_reminders WHERE schedule_id = ?", schedule.ID)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		if err := r.insertReminders(tx, schedule.ID, schedule.ReminderMinutes); err != nil {
+			return err
+		}
+		
+		// Record the change for delta sync
+		participants, err := r.loadParticipantsTx(tx, schedule.ID)
+		if err != nil {
+			return err
+		}
+		audience := append([]string{schedule.CreatorID}, participants...)
+		audience = append(audience, previousParticipants...)
+		return r.recordChange(tx, schedule.ID, scheduleChangeUpsert, schedule.UpdatedAt, audience)
 	})
 }
 
@@ -193,26 +270,31 @@ func (r *ScheduleRepository) GetSchedule(ctx context.Context, id string) (persis
 	}
 	
 	query := `
-		SELECT id, title, start_time, end_time, creator_id, room_id, memo, web_conference_url, created_at, updated_at
+		SELECT id, title, start_time, end_time, time_zone, all_day, busy, creator_id, room_id, calendar_id, memo, web_conference_url, created_at, updated_at, version
 		FROM schedules
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 	
 	var schedule persistence.Schedule
 	var createdAtStr, updatedAtStr, startTimeStr, endTimeStr string
-	var roomID, memo, webConferenceURL sql.NullString
+	var roomID, calendarID, memo, webConferenceURL sql.NullString
 	
 	err := r.helper.QueryRow(ctx, query, id).Scan(
 		&schedule.ID,
 		&schedule.Title,
 		&startTimeStr,
 		&endTimeStr,
+		&schedule.TimeZone,
+		&schedule.AllDay,
+		&schedule.Busy,
 		&schedule.CreatorID,
 		&roomID,
+		&calendarID,
 		&memo,
 		&webConferenceURL,
 		&createdAtStr,
 		&updatedAtStr,
+		&schedule.Version,
 	)
 	
 	if err != nil {
@@ -226,6 +308,9 @@ func (r *ScheduleRepository) GetSchedule(ctx context.Context, id string) (persis
 	if roomID.Valid {
 		schedule.RoomID = &roomID.String
 	}
+	if calendarID.Valid {
+		schedule.CalendarID = &calendarID.String
+	}
 	if memo.Valid {
 		schedule.Memo = &memo.String
 	}
@@ -253,6 +338,14 @@ func (r *ScheduleRepository) GetSchedule(ctx context.Context, id string) (persis
 		return persistence.Schedule{}, err
 	}
 	schedule.Participants = participants
+	if schedule.ParticipantGroups, err = r.loadParticipantGroups(ctx, id); err != nil {
+		return persistence.Schedule{}, err
+	}
+	
+	// Load reminders
+	if schedule.ReminderMinutes, err = r.loadReminders(ctx, id); err != nil {
+		return persistence.Schedule{}, err
+	}
 	
 	return schedule, nil
 }
@@ -260,7 +353,65 @@ func (r *ScheduleRepository) GetSchedule(ctx context.Context, id string) (persis
 // ListSchedules lists schedules filtered by the provided filter
 func (r *ScheduleRepository) ListSchedules(ctx context.Context, filter persistence.ScheduleFilter) ([]persistence.Schedule, error) {
 	query, args := r.buildListQuery(filter)
+	return r.querySchedules(ctx, query, args...)
+}
+
+// ListConflictCandidates lists the schedules sharing a participant or the room
+// with a candidate that overlap its window, either directly or through a
+// materialized occurrence. Recurring schedules whose occurrences are not
+// materialized up to the window end are included while their series has not
+// ended by the window start. Every lookup goes through indexes, so the cost
+// follows the calendars involved rather than the size of the table.
+func (r *ScheduleRepository) ListConflictCandidates(ctx context.Context, filter persistence.ScheduleConflictFilter) ([]persistence.Schedule, error) {
+	var sources []string
+	var args []interface{}
+	
+	if len(filter.ParticipantIDs) > 0 {
+		placeholders := make([]string, len(filter.ParticipantIDs))
+		for i, participantID := range filter.ParticipantIDs {
+			placeholders[i] = "?"
+			args = append(args, participantID)
+		}
+		in := strings.Join(placeholders, ",")
+		sources = append(sources,
+			fmt.Sprintf("SELECT schedule_id FROM schedule_participants WHERE user_id IN (%s)", in),
+			fmt.Sprintf("SELECT pg.schedule_id FROM schedule_participant_groups pg JOIN user_group_members gm ON gm.group_id = pg.group_id WHERE gm.user_id IN (%s)", in),
+		)
+		for _, participantID := range filter.ParticipantIDs {
+			args = append(args, participantID)
+		}
+	}
+	if filter.RoomID != nil {
+		sources = append(sources, "SELECT id FROM schedules WHERE room_id = ?")
+		args = append(args, *filter.RoomID)
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
 	
+	start := filter.Start.UTC().Format(time.RFC3339)
+	end := filter.End.UTC().Format(time.RFC3339)
+	query := fmt.Sprintf(`
+		SELECT s.id, s.title, s.start_time, s.end_time, s.time_zone, s.all_day, s.busy, s.creator_id, s.room_id, s.calendar_id, s.memo, s.web_conference_url, s.created_at, s.updated_at, s.version
+		FROM schedules s
+		WHERE s.deleted_at IS NULL
+			AND s.id <> ?
+			AND s.id IN (%s)
+			AND s.start_time < ?
+			AND (s.end_time > ? OR EXISTS (
+				SELECT 1 FROM occurrences o
+				WHERE o.schedule_id = s.id AND o.end_time > ? AND o.start_time < ?
+			) OR %s)
+		ORDER BY s.start_time ASC, s.id ASC
+	`, strings.Join(sources, " UNION "), unmaterializedSeriesCondition(true))
+	args = append([]interface{}{filter.ExcludeID}, args...)
+	args = append(args, end, start, start, end, start, end)
+	
+	return r.querySchedules(ctx, query, args...)
+}
+
+// querySchedules runs a query selecting schedule columns and batch-loads the participants and reminders of the result
+func (r *ScheduleRepository) querySchedules(ctx context.Context, query string, args ...interface{}) ([]persistence.Schedule, error) {
 	rows, err := r.helper.Query(ctx, query, args...)
 	if err != nil {
 		return nil, r.mapper.MapError(err)
@@ -272,19 +423,24 @@ func (r *ScheduleRepository) ListSchedules(ctx context.Context, filter persisten
 	for rows.Next() {
 		var schedule persistence.Schedule
 		var createdAtStr, updatedAtStr, startTimeStr, endTimeStr string
-		var roomID, memo, webConferenceURL sql.NullString
+		var roomID, calendarID, memo, webConferenceURL sql.NullString
 		
 		err := rows.Scan(
 			&schedule.ID,
 			&schedule.Title,
 			&startTimeStr,
 			&endTimeStr,
+			&schedule.TimeZone,
+			&schedule.AllDay,
+			&schedule.Busy,
 			&schedule.CreatorID,
 			&roomID,
+			&calendarID,
 			&memo,
 			&webConferenceURL,
 			&createdAtStr,
 			&updatedAtStr,
+			&schedule.Version,
 		)
 		
 		if err != nil {
@@ -295,6 +451,9 @@ func (r *ScheduleRepository) ListSchedules(ctx context.Context, filter persisten
 		if roomID.Valid {
 			schedule.RoomID = &roomID.String
 		}
+		if calendarID.Valid {
+			schedule.CalendarID = &calendarID.String
+		}
 		if memo.Valid {
 			schedule.Memo = &memo.String
 		}
@@ -316,19 +475,17 @@ func (r *ScheduleRepository) ListSchedules(ctx context.Context, filter persisten
 			return nil, fmt.Errorf("failed to parse updated_at: %w", err)
 		}
 		
-		// Load participants for each schedule
-		participants, err := r.loadParticipants(ctx, schedule.ID)
-		if err != nil {
-			return nil, err
-		}
-		schedule.Participants = participants
-		
 		schedules = append(schedules, schedule)
 	}
 	
 	if err := rows.Err(); err != nil {
 		return nil, r.mapper.MapError(err)
 	}
+	rows.Close()
+	
+	if err := r.loadScheduleDetails(ctx, schedules); err != nil {
+		return nil, err
+	}
 	
 	return schedules, nil
 }
@@ -340,35 +497,380 @@ func (r *ScheduleRepository) DeleteSchedule(ctx context.Context, id string) erro
 	}
 	
 	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// Delete participants first
-		_, err := r.helper.ExecTx(tx, "DELETE FROM schedule_participants WHERE schedule_id = ?", id)
+		// Capture the audience before the rows are gone so the deletion can be synced
+		var creatorID string
+		err := r.helper.QueryRowTx(tx, "SELECT creator_id FROM schedules WHERE id = ?", id).Scan(&creatorID)
 		if err != nil {
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
 			return r.mapper.MapError(err)
 		}
+		participants, err := r.loadParticipantsTx(tx, id)
+		if err != nil {
+			return err
+		}
+		
+		if err := r.deleteScheduleRows(tx, id); err != nil {
+			return err
+		}
 		
-		// Delete recurrences for this schedule
-		_, err = r.helper.ExecTx(tx, "DELETE FROM recurrences WHERE schedule_id = ?", id)
+		// Keep a tombstone in the change log
+		audience := append([]string{creatorID}, participants...)
+		return r.recordChange(tx, id, scheduleChangeDelete, time.Now().UTC(), audience)
+	})
+}
+
+// TrashSchedule soft-deletes a schedule and records the deletion in the change log
+func (r *ScheduleRepository) TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	if id == "" {
+		return persistence.ErrNotFound
+	}
+	
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var creatorID string
+		err := r.helper.QueryRowTx(tx, "SELECT creator_id FROM schedules WHERE id = ? AND deleted_at IS NULL", id).Scan(&creatorID)
 		if err != nil {
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
 			return r.mapper.MapError(err)
 		}
+		participants, err := r.loadParticipantsTx(tx, id)
+		if err != nil {
+			return err
+		}
 		
-		// Delete the schedule
-		result, err := r.helper.ExecTx(tx, "DELETE FROM schedules WHERE id = ?", id)
+		// Participants, reminders and recurrences stay so the schedule can be restored
+		_, err = r.helper.ExecTx(tx, "UPDATE schedules SET deleted_at = ?, deleted_by = ? WHERE id = ?",
+			deletedAt.UTC().Format(time.RFC3339), deletedBy, id)
 		if err != nil {
 			return r.mapper.MapError(err)
 		}
 		
-		rowsAffected, err := result.RowsAffected()
+		// Synced clients see the schedule disappear
+		audience := append([]string{creatorID}, participants...)
+		return r.recordChange(tx, id, scheduleChangeDelete, deletedAt, audience)
+	})
+}
+
+// RestoreSchedule brings a trashed schedule back and records it as changed
+func (r *ScheduleRepository) RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error {
+	if id == "" {
+		return persistence.ErrNotFound
+	}
+	
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var creatorID string
+		err := r.helper.QueryRowTx(tx, "SELECT creator_id FROM schedules WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&creatorID)
 		if err != nil {
-			return fmt.Errorf("failed to get rows affected: %w", err)
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
+			return r.mapper.MapError(err)
+		}
+		participants, err := r.loadParticipantsTx(tx, id)
+		if err != nil {
+			return err
 		}
 		
-		if rowsAffected == 0 {
-			return persistence.ErrNotFound
+		_, err = r.helper.ExecTx(tx, "UPDATE schedules SET deleted_at = NULL, deleted_by = NULL, updated_at = ?, version = version + 1 WHERE id = ?",
+			restoredAt.UTC().Format(time.RFC3339), id)
+		if err != nil {
+			return r.mapper.MapError(err)
 		}
 		
+		audience := append([]string{creatorID}, participants...)
+		return r.recordChange(tx, id, scheduleChangeUpsert, restoredAt, audience)
+	})
+}
+
+// GetTrashedSchedule retrieves a trashed schedule by ID
+func (r *ScheduleRepository) GetTrashedSchedule(ctx context.Context, id string) (persistence.Schedule, error) {
+	if id == "" {
+		return persistence.Schedule{}, persistence.ErrNotFound
+	}
+	
+	schedules, err := r.queryTrashedSchedules(ctx, "id = ?", id)
+	if err != nil {
+		return persistence.Schedule{}, err
+	}
+	if len(schedules) == 0 {
+		return persistence.Schedule{}, persistence.ErrNotFound
+	}
+	return schedules[0], nil
+}
+
+// ListTrashedSchedules lists trashed schedules, most recently deleted first
+func (r *ScheduleRepository) ListTrashedSchedules(ctx context.Context, deletedBy string) ([]persistence.Schedule, error) {
+	if deletedBy == "" {
+		return r.queryTrashedSchedules(ctx, "1 = 1")
+	}
+	return r.queryTrashedSchedules(ctx, "deleted_by = ?", deletedBy)
+}
+
+// PurgeSchedules permanently deletes schedules trashed before deletedBefore
+func (r *ScheduleRepository) PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error) {
+	rows, err := r.helper.Query(ctx, "SELECT id FROM schedules WHERE deleted_at IS NOT NULL AND deleted_at < ?",
+		deletedBefore.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, r.mapper.MapError(err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, r.mapper.MapError(err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, r.mapper.MapError(err)
+	}
+	
+	// The change log already holds the tombstone written when the schedule was trashed
+	err = r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, id := range ids {
+			if err := r.deleteScheduleRows(tx, id); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// queryTrashedSchedules loads trashed schedules matching condition with their participants and reminders
+func (r *ScheduleRepository) queryTrashedSchedules(ctx context.Context, condition string, args ...interface{}) ([]persistence.Schedule, error) {
+	query := `
+		SELECT id, title, start_time, end_time, time_zone, all_day, busy, creator_id, room_id, calendar_id, memo, web_conference_url, created_at, updated_at, version, deleted_at, deleted_by
+		FROM schedules
+		WHERE deleted_at IS NOT NULL AND ` + condition + `
+		ORDER BY deleted_at DESC, id ASC
+	`
+	
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var schedules []persistence.Schedule
+	
+	for rows.Next() {
+		var schedule persistence.Schedule
+		var createdAtStr, updatedAtStr, startTimeStr, endTimeStr, deletedAtStr string
+		var roomID, calendarID, memo, webConferenceURL, deletedBy sql.NullString
+		
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.Title,
+			&startTimeStr,
+			&endTimeStr,
+			&schedule.TimeZone,
+			&schedule.AllDay,
+			&schedule.Busy,
+			&schedule.CreatorID,
+			&roomID,
+			&calendarID,
+			&memo,
+			&webConferenceURL,
+			&createdAtStr,
+			&updatedAtStr,
+			&schedule.Version,
+			&deletedAtStr,
+			&deletedBy,
+		)
+		
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		
+		// Handle nullable fields
+		if roomID.Valid {
+			schedule.RoomID = &roomID.String
+		}
+		if calendarID.Valid {
+			schedule.CalendarID = &calendarID.String
+		}
+		if memo.Valid {
+			schedule.Memo = &memo.String
+		}
+		if webConferenceURL.Valid {
+			schedule.WebConferenceURL = &webConferenceURL.String
+		}
+		schedule.DeletedBy = deletedBy.String
+		
+		// Parse timestamps
+		if schedule.Start, err = time.Parse(time.RFC3339, startTimeStr); err != nil {
+			return nil, fmt.Errorf("failed to parse start_time: %w", err)
+		}
+		if schedule.End, err = time.Parse(time.RFC3339, endTimeStr); err != nil {
+			return nil, fmt.Errorf("failed to parse end_time: %w", err)
+		}
+		if schedule.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		if schedule.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse updated_at: %w", err)
+		}
+		deletedAt, err := time.Parse(time.RFC3339, deletedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deleted_at: %w", err)
+		}
+		schedule.DeletedAt = &deletedAt
+		
+		schedules = append(schedules, schedule)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	rows.Close()
+	
+	// Participants and reminders are loaded once the result set is closed
+	if err := r.loadScheduleDetails(ctx, schedules); err != nil {
+		return nil, err
+	}
+	
+	return schedules, nil
+}
+
+// ListScheduleChanges returns change log entries after filter.AfterSequence in sequence order.
+// A sequence past the end of the log was not issued by this database and
+// returns persistence.ErrNotFound.
+func (r *ScheduleRepository) ListScheduleChanges(ctx context.Context, filter persistence.ScheduleChangeFilter) ([]persistence.ScheduleChange, error) {
+	if filter.AfterSequence > 0 {
+		var last int64
+		if err := r.helper.QueryRow(ctx, "SELECT COALESCE(MAX(sequence), 0) FROM schedule_changes").Scan(&last); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		if filter.AfterSequence > last {
+			return nil, persistence.ErrNotFound
+		}
+	}
+	
+	query := "SELECT c.sequence, c.schedule_id, c.kind, c.changed_at FROM schedule_changes c WHERE c.sequence > ?"
+	args := []interface{}{filter.AfterSequence}
+	
+	// Match users recorded with the change or currently participating in the schedule
+	if len(filter.UserIDs) > 0 {
+		placeholders := make([]string, len(filter.UserIDs))
+		for i := range filter.UserIDs {
+			placeholders[i] = "?"
+		}
+		in := "(" + strings.Join(placeholders, ", ") + ")"
+		query += " AND (EXISTS (SELECT 1 FROM schedule_change_users u WHERE u.sequence = c.sequence AND u.user_id IN " + in + ")" +
+			" OR EXISTS (SELECT 1 FROM schedule_participants p WHERE p.schedule_id = c.schedule_id AND p.user_id IN " + in + ")" +
+			" OR EXISTS (SELECT 1 FROM schedule_participant_groups pg JOIN user_group_members gm ON gm.group_id = pg.group_id" +
+			" WHERE pg.schedule_id = c.schedule_id AND gm.user_id IN " + in + "))"
+		for i := 0; i < 3; i++ {
+			for _, userID := range filter.UserIDs {
+				args = append(args, userID)
+			}
+		}
+	}
+	
+	query += " ORDER BY c.sequence ASC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var changes []persistence.ScheduleChange
+	
+	for rows.Next() {
+		var change persistence.ScheduleChange
+		var kind, changedAtStr string
+		if err := rows.Scan(&change.Sequence, &change.ScheduleID, &kind, &changedAtStr); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		change.Deleted = kind == scheduleChangeDelete
+		change.ChangedAt, err = time.Parse(time.RFC3339, changedAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse changed_at: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	
+	return changes, nil
+}
+
+// deleteScheduleRows removes a schedule and its dependent rows within a transaction
+func (r *ScheduleRepository) deleteScheduleRows(tx *sql.Tx, id string) error {
+	// Delete participants first
+	_, err := r.helper.ExecTx(tx, "DELETE FROM schedule_participants WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	_, err = r.helper.ExecTx(tx, "DELETE FROM schedule_participant_groups WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	// Delete reminders and their delivery state
+	_, err = r.helper.ExecTx(tx, "DELETE FROM schedule_reminders WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	_, err = r.helper.ExecTx(tx, "DELETE FROM reminder_deliveries WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	// Delete recurrences for this schedule
+	_, err = r.helper.ExecTx(tx, "DELETE FROM recurrences WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	// Delete the materialized occurrences
+	_, err = r.helper.ExecTx(tx, "DELETE FROM occurrences WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	_, err = r.helper.ExecTx(tx, "DELETE FROM occurrence_horizons WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	// Delete the revision history
+	_, err = r.helper.ExecTx(tx, "DELETE FROM schedule_revisions WHERE schedule_id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	// Delete the schedule
+	result, err := r.helper.ExecTx(tx, "DELETE FROM schedules WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	
+	return nil
 }
 
 // validateSchedule validates schedule business rules
@@ -445,16 +947,275 @@ func (r *ScheduleRepository) loadParticipants(ctx context.Context, scheduleID st
 	return participants, nil
 }
 
+// loadParticipantsTx loads participants for a schedule within a transaction,
+// including the current members of its live participant groups
+func (r *ScheduleRepository) loadParticipantsTx(tx *sql.Tx, scheduleID string) ([]string, error) {
+	rows, err := r.helper.QueryTx(tx, `
+		SELECT user_id FROM schedule_participants WHERE schedule_id = ?
+		UNION
+		SELECT gm.user_id FROM schedule_participant_groups pg
+		JOIN user_group_members gm ON gm.group_id = pg.group_id
+		WHERE pg.schedule_id = ?
+	`, scheduleID, scheduleID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var participants []string
+	
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		participants = append(participants, userID)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	
+	return participants, nil
+}
+
+// recordChange appends a change log entry and the users it concerns within a transaction
+func (r *ScheduleRepository) recordChange(tx *sql.Tx, scheduleID, kind string, changedAt time.Time, userIDs []string) error {
+	result, err := r.helper.ExecTx(tx,
+		"INSERT INTO schedule_changes (schedule_id, kind, changed_at) VALUES (?, ?, ?)",
+		scheduleID, kind, changedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	sequence, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get change sequence: %w", err)
+	}
+	
+	for _, userID := range userIDs {
+		userID = strings.TrimSpace(userID)
+		if userID == "" {
+			continue
+		}
+		_, err := r.helper.ExecTx(tx,
+			"INSERT OR IGNORE INTO schedule_change_users (sequence, user_id) VALUES (?, ?)",
+			sequence, userID)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+	}
+	
+	return nil
+}
+
+// insertReminders inserts reminder offsets for a schedule within a transaction
+func (r *ScheduleRepository) insertReminders(tx *sql.Tx, scheduleID string, minutes []int) error {
+	seen := make(map[int]struct{}, len(minutes))
+	for _, before := range minutes {
+		if _, ok := seen[before]; ok {
+			continue
+		}
+		seen[before] = struct{}{}
+		
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO schedule_reminders (schedule_id, minutes_before) VALUES (?, ?)",
+			scheduleID, before)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+	}
+	
+	return nil
+}
+
+// loadReminders loads reminder offsets for a schedule in ascending order
+func (r *ScheduleRepository) loadReminders(ctx context.Context, scheduleID string) ([]int, error) {
+	rows, err := r.helper.Query(ctx,
+		"SELECT minutes_before FROM schedule_reminders WHERE schedule_id = ? ORDER BY minutes_before ASC",
+		scheduleID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var minutes []int
+	
+	for rows.Next() {
+		var before int
+		if err := rows.Scan(&before); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		minutes = append(minutes, before)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	
+	return minutes, nil
+}
+
+// insertParticipantGroups stores the groups invited to a schedule as a live reference
+func (r *ScheduleRepository) insertParticipantGroups(tx *sql.Tx, scheduleID string, groupIDs []string) error {
+	seen := make(map[string]struct{}, len(groupIDs))
+	for _, groupID := range groupIDs {
+		if _, ok := seen[groupID]; ok || groupID == "" {
+			continue
+		}
+		seen[groupID] = struct{}{}
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO schedule_participant_groups (schedule_id, group_id) VALUES (?, ?)",
+			scheduleID, groupID)
+		if err != nil {
+			return r.mapScheduleError(err)
+		}
+	}
+	
+	return nil
+}
+
+// loadParticipantGroups loads the live participant groups of a schedule in ID order
+func (r *ScheduleRepository) loadParticipantGroups(ctx context.Context, scheduleID string) ([]string, error) {
+	rows, err := r.helper.Query(ctx,
+		"SELECT group_id FROM schedule_participant_groups WHERE schedule_id = ? ORDER BY group_id ASC",
+		scheduleID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	var groupIDs []string
+	
+	for rows.Next() {
+		var groupID string
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	
+	return groupIDs, nil
+}
+
+// scheduleDetailBatchSize bounds the schedule IDs bound into one IN list,
+// keeping listing queries well under SQLite's host parameter limit
+const scheduleDetailBatchSize = 500
+
+// loadScheduleDetails loads the participants, participant groups and reminders
+// of listed schedules with one query per table and batch instead of per schedule
+func (r *ScheduleRepository) loadScheduleDetails(ctx context.Context, schedules []persistence.Schedule) error {
+	index := make(map[string]int, len(schedules))
+	for i := range schedules {
+		index[schedules[i].ID] = i
+	}
+	
+	for start := 0; start < len(schedules); start += scheduleDetailBatchSize {
+		end := min(start+scheduleDetailBatchSize, len(schedules))
+		ids := make([]interface{}, 0, end-start)
+		for _, schedule := range schedules[start:end] {
+			ids = append(ids, schedule.ID)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+		
+		err := r.queryScheduleDetails(ctx,
+			"SELECT schedule_id, user_id FROM schedule_participants WHERE schedule_id IN ("+placeholders+") ORDER BY schedule_id, user_id ASC",
+			ids, func(rows *sql.Rows) error {
+				var scheduleID, userID string
+				if err := rows.Scan(&scheduleID, &userID); err != nil {
+					return err
+				}
+				if i, ok := index[scheduleID]; ok {
+					schedules[i].Participants = append(schedules[i].Participants, userID)
+				}
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+		
+		err = r.queryScheduleDetails(ctx,
+			"SELECT schedule_id, group_id FROM schedule_participant_groups WHERE schedule_id IN ("+placeholders+") ORDER BY schedule_id, group_id ASC",
+			ids, func(rows *sql.Rows) error {
+				var scheduleID, groupID string
+				if err := rows.Scan(&scheduleID, &groupID); err != nil {
+					return err
+				}
+				if i, ok := index[scheduleID]; ok {
+					schedules[i].ParticipantGroups = append(schedules[i].ParticipantGroups, groupID)
+				}
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+		
+		err = r.queryScheduleDetails(ctx,
+			"SELECT schedule_id, minutes_before FROM schedule_reminders WHERE schedule_id IN ("+placeholders+") ORDER BY schedule_id, minutes_before ASC",
+			ids, func(rows *sql.Rows) error {
+				var scheduleID string
+				var before int
+				if err := rows.Scan(&scheduleID, &before); err != nil {
+					return err
+				}
+				if i, ok := index[scheduleID]; ok {
+					schedules[i].ReminderMinutes = append(schedules[i].ReminderMinutes, before)
+				}
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// queryScheduleDetails runs a detail query and hands each row to scan
+func (r *ScheduleRepository) queryScheduleDetails(ctx context.Context, query string, args []interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	defer rows.Close()
+	
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return r.mapper.MapError(err)
+		}
+	}
+	
+	if err := rows.Err(); err != nil {
+		return r.mapper.MapError(err)
+	}
+	
+	return nil
+}
+
 // buildListQuery builds the SQL query for listing schedules with filters
 func (r *ScheduleRepository) buildListQuery(filter persistence.ScheduleFilter) (string, []interface{}) {
 	baseQuery := `
-		SELECT DISTINCT s.id, s.title, s.start_time, s.end_time, s.creator_id, s.room_id, s.memo, s.web_conference_url, s.created_at, s.updated_at
+		SELECT DISTINCT s.id, s.title, s.start_time, s.end_time, s.time_zone, s.all_day, s.busy, s.creator_id, s.room_id, s.calendar_id, s.memo, s.web_conference_url, s.created_at, s.updated_at, s.version
 		FROM schedules s
 	`
 	
-	var conditions []string
+	// Trashed schedules are only read through the trash methods
+	conditions := []string{"s.deleted_at IS NULL"}
 	var args []interface{}
 	
+	if len(filter.IDs) > 0 {
+		placeholders := make([]string, len(filter.IDs))
+		for i, id := range filter.IDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		conditions = append(conditions, fmt.Sprintf("s.id IN (%s)", strings.Join(placeholders, ",")))
+	}
+	
 	// Add participant filter if specified
 	if len(filter.ParticipantIDs) > 0 {
 		baseQuery += " LEFT JOIN schedule_participants sp ON s.id = sp.schedule_id"
@@ -466,39 +1227,149 @@ func (r *ScheduleRepository) buildListQuery(filter persistence.ScheduleFilter) (
 			args = append(args, participantID)
 		}
 		
-		// Include schedules where the user is a participant OR the creator
-		participantCondition := fmt.Sprintf("(sp.user_id IN (%s) OR s.creator_id IN (%s))", 
-			strings.Join(placeholders, ","), strings.Join(placeholders, ","))
+		// Include schedules where the user is a participant, directly or
+		// through a live group, OR the creator
+		in := strings.Join(placeholders, ",")
+		participantCondition := fmt.Sprintf("(sp.user_id IN (%s) OR s.creator_id IN (%s) OR s.id IN ("+
+			"SELECT pg.schedule_id FROM schedule_participant_groups pg JOIN user_group_members gm ON gm.group_id = pg.group_id WHERE gm.user_id IN (%s)))",
+			in, in, in)
 		conditions = append(conditions, participantCondition)
 		
-		// Add the participant IDs again for the creator condition
-		for _, participantID := range filter.ParticipantIDs {
-			args = append(args, participantID)
+		// Add the participant IDs again for the creator and group conditions
+		for i := 0; i < 2; i++ {
+			for _, participantID := range filter.ParticipantIDs {
+				args = append(args, participantID)
+			}
 		}
 	}
 	
-	// Add time range filters
+	// Add time range filters; all-day rows may be compared against floating bounds
+	var windowConditions, occurrenceConditions []string
+	var windowArgs, occurrenceArgs []interface{}
+	if condition, boundArgs := timeBoundCondition("s.end_time > ?", filter.StartsAfter, filter.AllDayStartsAfter); condition != "" {
+		windowConditions = append(windowConditions, condition)
+		windowArgs = append(windowArgs, boundArgs...)
+		condition, boundArgs = timeBoundCondition("o.end_time > ?", filter.StartsAfter, filter.AllDayStartsAfter)
+		occurrenceConditions = append(occurrenceConditions, condition)
+		occurrenceArgs = append(occurrenceArgs, boundArgs...)
+	}
+	
+	if condition, boundArgs := timeBoundCondition("s.start_time < ?", filter.EndsBefore, filter.AllDayEndsBefore); condition != "" {
+		windowConditions = append(windowConditions, condition)
+		windowArgs = append(windowArgs, boundArgs...)
+		condition, boundArgs = timeBoundCondition("o.start_time < ?", filter.EndsBefore, filter.AllDayEndsBefore)
+		occurrenceConditions = append(occurrenceConditions, condition)
+		occurrenceArgs = append(occurrenceArgs, boundArgs...)
+	}
+	
 	if filter.StartsAfter != nil {
-		conditions = append(conditions, "s.end_time > ?")
-		args = append(args, filter.StartsAfter.UTC().Format(time.RFC3339))
+		// Recurring schedules whose first occurrence precedes the window are
+		// found through their materialized occurrences, or kept while their
+		// occurrences are not materialized over the window
+		seriesStart := *filter.StartsAfter
+		if filter.AllDayStartsAfter != nil && filter.AllDayStartsAfter.Before(seriesStart) {
+			seriesStart = *filter.AllDayStartsAfter
+		}
+		seriesArgs := []interface{}{seriesStart.UTC().Format(time.RFC3339)}
+		if filter.EndsBefore != nil {
+			seriesEnd := *filter.EndsBefore
+			if filter.AllDayEndsBefore != nil && filter.AllDayEndsBefore.After(seriesEnd) {
+				seriesEnd = *filter.AllDayEndsBefore
+			}
+			seriesArgs = append(seriesArgs, seriesEnd.UTC().Format(time.RFC3339))
+		}
+		conditions = append(conditions, fmt.Sprintf("((%s) OR EXISTS (SELECT 1 FROM occurrences o WHERE o.schedule_id = s.id AND %s) OR %s)",
+			strings.Join(windowConditions, " AND "), strings.Join(occurrenceConditions, " AND "),
+			unmaterializedSeriesCondition(filter.EndsBefore != nil)))
+		args = append(args, windowArgs...)
+		args = append(args, occurrenceArgs...)
+		args = append(args, seriesArgs...)
+	} else {
+		conditions = append(conditions, windowConditions...)
+		args = append(args, windowArgs...)
+	}
+	
+	// Add attribute filters
+	if filter.RoomID != nil {
+		conditions = append(conditions, "s.room_id = ?")
+		args = append(args, *filter.RoomID)
+	}
+	if filter.CalendarID != "" {
+		conditions = append(conditions, "s.calendar_id = ?")
+		args = append(args, filter.CalendarID)
 	}
 	
-	if filter.EndsBefore != nil {
-		conditions = append(conditions, "s.start_time < ?")
-		args = append(args, filter.EndsBefore.UTC().Format(time.RFC3339))
+	if filter.CreatorID != "" {
+		conditions = append(conditions, "s.creator_id = ?")
+		args = append(args, filter.CreatorID)
 	}
 	
-	// Add WHERE clause if there are conditions
-	if len(conditions) > 0 {
-		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	if filter.TitlePrefix != "" {
+		conditions = append(conditions, `s.title LIKE ? ESCAPE '\'`)
+		args = append(args, escapeLikePattern(filter.TitlePrefix)+"%")
 	}
 	
+	if filter.UpdatedSince != nil {
+		conditions = append(conditions, "s.updated_at >= ?")
+		args = append(args, filter.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	
+	// Add keyset pagination bound
+	if filter.AfterStart != nil {
+		afterStart := filter.AfterStart.UTC().Format(time.RFC3339)
+		conditions = append(conditions, "(s.start_time > ? OR (s.start_time = ? AND s.id > ?))")
+		args = append(args, afterStart, afterStart, filter.AfterID)
+	}
+	
+	baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	
 	// Add ordering
 	baseQuery += " ORDER BY s.start_time ASC, s.id ASC"
 	
+	if filter.Limit > 0 {
+		baseQuery += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	
 	return baseQuery, args
 }
 
+// unmaterializedSeriesCondition matches recurring schedules whose series has
+// not ended by the window start and whose occurrences may be missing from the
+// occurrences table: never materialized, stale, or materialized short of the
+// window end. It takes the window start and, when bounded, the window end as
+// arguments; an unbounded window is never covered by a horizon.
+func unmaterializedSeriesCondition(bounded bool) string {
+	horizon := ""
+	if bounded {
+		horizon = " AND (h.schedule_id IS NULL OR h.stale = 1 OR h.materialized_until IS NULL OR h.materialized_until < ?)"
+	}
+	return `EXISTS (
+				SELECT 1 FROM recurrences rc
+				LEFT JOIN occurrence_horizons h ON h.schedule_id = rc.schedule_id
+				WHERE rc.schedule_id = s.id AND (rc.ends_on IS NULL OR rc.ends_on >= ?)` + horizon + `
+			)`
+}
+
+// timeBoundCondition renders a time range comparison. When allDayBound is set,
+// all-day rows are compared against it instead of bound.
+func timeBoundCondition(comparison string, bound, allDayBound *time.Time) (string, []interface{}) {
+	if bound == nil {
+		return "", nil
+	}
+	if allDayBound == nil {
+		return comparison, []interface{}{bound.UTC().Format(time.RFC3339)}
+	}
+	condition := fmt.Sprintf("((s.all_day = 0 AND %s) OR (s.all_day = 1 AND %s))", comparison, comparison)
+	return condition, []interface{}{bound.UTC().Format(time.RFC3339), allDayBound.UTC().Format(time.RFC3339)}
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input matches literally
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
+
 // mapScheduleError maps SQLite errors to appropriate persistence errors for schedule operations
 func (r *ScheduleRepository) mapScheduleError(err error) error {
 	if err == nil {
@@ -2,7 +2,10 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -132,6 +135,51 @@ func TestScheduleRepository_UpdateSchedule(t *testing.T) {
 	}
 }
 
+func TestScheduleRepository_UpdateSchedule_ChecksVersion(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	if err := repo.CreateSchedule(ctx, persistence.Schedule{ID: "schedule1", Title: "Test Meeting", Start: start, End: start.Add(time.Hour), CreatorID: "user1"}); err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	schedule, err := repo.GetSchedule(ctx, "schedule1")
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if schedule.Version != 1 {
+		t.Fatalf("Expected version 1 for a new schedule, got %d", schedule.Version)
+	}
+
+	schedule.Title = "First Writer"
+	if err := repo.UpdateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("UpdateSchedule failed: %v", err)
+	}
+
+	// The second writer still holds version 1
+	schedule.Title = "Second Writer"
+	if err := repo.UpdateSchedule(ctx, schedule); err != persistence.ErrVersionConflict {
+		t.Fatalf("Expected ErrVersionConflict for a stale version, got %v", err)
+	}
+
+	schedule.ID = "missing"
+	if err := repo.UpdateSchedule(ctx, schedule); err != persistence.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for a missing schedule, got %v", err)
+	}
+
+	retrieved, err := repo.GetSchedule(ctx, "schedule1")
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if retrieved.Title != "First Writer" || retrieved.Version != 2 {
+		t.Errorf("Expected 'First Writer' at version 2, got '%s' at version %d", retrieved.Title, retrieved.Version)
+	}
+}
+
 func TestScheduleRepository_ListSchedules_WithFilter(t *testing.T) {
 	repo, cleanup := setupScheduleRepositoryTest(t)
 	defer cleanup()
@@ -226,6 +274,407 @@ func TestScheduleRepository_DeleteSchedule(t *testing.T) {
 	}
 }
 
+func TestScheduleRepository_ListSchedules_AttributeFilters(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "alice@example.com")
+	createTestUser(t, repo.pool, "user2", "bob@example.com")
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := repo.pool.DB().ExecContext(ctx, `
+		INSERT INTO rooms (id, name, capacity, created_at, updated_at) VALUES ('room1', 'Room 1', 4, ?, ?)
+	`, now, now); err != nil {
+		t.Fatalf("Failed to create test room: %v", err)
+	}
+
+	room := "room1"
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	for _, schedule := range []persistence.Schedule{
+		{ID: "budget", Title: "50% budget review", Start: start, End: start.Add(time.Hour), CreatorID: "user1", RoomID: &room},
+		{ID: "quarter", Title: "500 quarterly review", Start: start, End: start.Add(time.Hour), CreatorID: "user2"},
+		{ID: "retro", Title: "Retro", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), CreatorID: "user2", RoomID: &room},
+	} {
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", schedule.ID, err)
+		}
+	}
+	// Backdate one schedule so UpdatedSince has something to leave out
+	if _, err := repo.pool.DB().ExecContext(ctx, "UPDATE schedules SET updated_at = '2024-01-01T00:00:00Z' WHERE id = 'quarter'"); err != nil {
+		t.Fatalf("Failed to backdate schedule: %v", err)
+	}
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter persistence.ScheduleFilter
+		want   []string
+	}{
+		{name: "room", filter: persistence.ScheduleFilter{RoomID: &room}, want: []string{"budget", "retro"}},
+		{name: "creator", filter: persistence.ScheduleFilter{CreatorID: "user2"}, want: []string{"quarter", "retro"}},
+		{name: "title prefix matches wildcards literally", filter: persistence.ScheduleFilter{TitlePrefix: "50%"}, want: []string{"budget"}},
+		{name: "updated since", filter: persistence.ScheduleFilter{UpdatedSince: &since}, want: []string{"budget", "retro"}},
+		{name: "combined", filter: persistence.ScheduleFilter{RoomID: &room, CreatorID: "user2"}, want: []string{"retro"}},
+		{name: "ids", filter: persistence.ScheduleFilter{IDs: []string{"retro", "budget", "missing"}, CreatorID: "user1"}, want: []string{"budget"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedules, err := repo.ListSchedules(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListSchedules failed: %v", err)
+			}
+			if got := scheduleIDs(schedules); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScheduleRepository_ListSchedules_KeysetPagination(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+
+	// Three schedules share a start time so pages have to break ties on id
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	for _, schedule := range []persistence.Schedule{
+		{ID: "c", Title: "C", Start: start, End: start.Add(time.Hour), CreatorID: "user1"},
+		{ID: "a", Title: "A", Start: start, End: start.Add(time.Hour), CreatorID: "user1"},
+		{ID: "b", Title: "B", Start: start, End: start.Add(time.Hour), CreatorID: "user1"},
+		{ID: "0-later", Title: "Later", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), CreatorID: "user1"},
+	} {
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", schedule.ID, err)
+		}
+	}
+
+	var pages [][]string
+	filter := persistence.ScheduleFilter{Limit: 2}
+	for {
+		schedules, err := repo.ListSchedules(ctx, filter)
+		if err != nil {
+			t.Fatalf("ListSchedules failed: %v", err)
+		}
+		if len(schedules) == 0 {
+			break
+		}
+		pages = append(pages, scheduleIDs(schedules))
+		if len(pages) > 3 {
+			t.Fatalf("Expected paging to stop, got pages %v", pages)
+		}
+		last := schedules[len(schedules)-1]
+		filter.AfterStart = &last.Start
+		filter.AfterID = last.ID
+	}
+
+	if len(pages) != 2 || !slices.Equal(pages[0], []string{"a", "b"}) || !slices.Equal(pages[1], []string{"c", "0-later"}) {
+		t.Fatalf("Expected pages [[a b] [c 0-later]], got %v", pages)
+	}
+}
+
+func TestScheduleRepository_ListScheduleChanges(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+	createTestUser(t, repo.pool, "user2", "participant@example.com")
+
+	start := time.Now().UTC().Add(time.Hour)
+	for _, id := range []string{"a", "b", "c"} {
+		schedule := persistence.Schedule{ID: id, Title: id, Start: start, End: start.Add(time.Hour), CreatorID: "user1", Participants: []string{"user2"}}
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", id, err)
+		}
+	}
+	// Give every change the same timestamp so pages can only break on sequence
+	if _, err := repo.pool.DB().ExecContext(ctx, "UPDATE schedule_changes SET changed_at = '2024-05-01T09:00:00Z'"); err != nil {
+		t.Fatalf("Failed to align change timestamps: %v", err)
+	}
+
+	t.Run("pages split changes sharing a timestamp", func(t *testing.T) {
+		first, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{UserIDs: []string{"user2"}, Limit: 2})
+		if err != nil || len(first) != 2 {
+			t.Fatalf("Expected a first page of 2, got %+v (err %v)", first, err)
+		}
+		second, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{UserIDs: []string{"user2"}, AfterSequence: first[1].Sequence, Limit: 2})
+		if err != nil || len(second) != 1 {
+			t.Fatalf("Expected a second page of 1, got %+v (err %v)", second, err)
+		}
+		if got := []string{first[0].ScheduleID, first[1].ScheduleID, second[0].ScheduleID}; !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("Expected every change once in order, got %v", got)
+		}
+	})
+
+	t.Run("deletes leave tombstones for former participants", func(t *testing.T) {
+		all, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{})
+		if err != nil || len(all) == 0 {
+			t.Fatalf("Expected changes, got %+v (err %v)", all, err)
+		}
+		token := all[len(all)-1].Sequence
+		if err := repo.DeleteSchedule(ctx, "b"); err != nil {
+			t.Fatalf("DeleteSchedule failed: %v", err)
+		}
+
+		changes, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{UserIDs: []string{"user2"}, AfterSequence: token})
+		if err != nil {
+			t.Fatalf("ListScheduleChanges failed: %v", err)
+		}
+		if len(changes) != 1 || changes[0].ScheduleID != "b" || !changes[0].Deleted {
+			t.Fatalf("Expected a tombstone for b, got %+v", changes)
+		}
+	})
+
+	t.Run("tokens past the end of the log are unknown", func(t *testing.T) {
+		all, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{})
+		if err != nil || len(all) == 0 {
+			t.Fatalf("Expected changes, got %+v (err %v)", all, err)
+		}
+		last := all[len(all)-1].Sequence
+		if changes, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{AfterSequence: last}); err != nil || len(changes) != 0 {
+			t.Fatalf("Expected an empty delta at the end of the log, got %+v (err %v)", changes, err)
+		}
+		if _, err := repo.ListScheduleChanges(ctx, persistence.ScheduleChangeFilter{AfterSequence: last + 1}); !errors.Is(err, persistence.ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound for an unknown token, got %v", err)
+		}
+	})
+}
+
+func TestScheduleRepository_Trash(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+	createTestUser(t, repo.pool, "user2", "participant@example.com")
+
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	for _, id := range []string{"old", "recent", "kept"} {
+		schedule := persistence.Schedule{ID: id, Title: id, Start: start, End: start.Add(time.Hour), CreatorID: "user1", Participants: []string{"user2"}}
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", id, err)
+		}
+	}
+
+	listed := func() []string {
+		t.Helper()
+		schedules, err := repo.ListSchedules(ctx, persistence.ScheduleFilter{ParticipantIDs: []string{"user2"}})
+		if err != nil {
+			t.Fatalf("ListSchedules failed: %v", err)
+		}
+		return scheduleIDs(schedules)
+	}
+	conflicting := func() []string {
+		t.Helper()
+		schedules, err := repo.ListConflictCandidates(ctx, persistence.ScheduleConflictFilter{
+			ParticipantIDs: []string{"user2"},
+			Start:          start,
+			End:            start.Add(time.Hour),
+			ExcludeID:      "new",
+		})
+		if err != nil {
+			t.Fatalf("ListConflictCandidates failed: %v", err)
+		}
+		return scheduleIDs(schedules)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := repo.TrashSchedule(ctx, "old", "user1", now.AddDate(0, 0, -40)); err != nil {
+		t.Fatalf("TrashSchedule failed: %v", err)
+	}
+	if err := repo.TrashSchedule(ctx, "recent", "user1", now.AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("TrashSchedule failed: %v", err)
+	}
+	if err := repo.TrashSchedule(ctx, "recent", "user1", now); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound trashing a trashed schedule, got %v", err)
+	}
+
+	if got := listed(); !slices.Equal(got, []string{"kept"}) {
+		t.Errorf("Expected trashed schedules to drop out of listings, got %v", got)
+	}
+	if got := conflicting(); !slices.Equal(got, []string{"kept"}) {
+		t.Errorf("Expected trashed schedules to drop out of conflict candidates, got %v", got)
+	}
+	if _, err := repo.GetSchedule(ctx, "old"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound reading a trashed schedule, got %v", err)
+	}
+	trashed, err := repo.ListTrashedSchedules(ctx, "user1")
+	if err != nil || !slices.Equal(scheduleIDs(trashed), []string{"recent", "old"}) {
+		t.Fatalf("Expected the trash most recent first, got %v (err %v)", scheduleIDs(trashed), err)
+	}
+
+	if err := repo.RestoreSchedule(ctx, "recent", now); err != nil {
+		t.Fatalf("RestoreSchedule failed: %v", err)
+	}
+	if got := listed(); !slices.Equal(got, []string{"kept", "recent"}) {
+		t.Errorf("Expected the restored schedule to be listed again, got %v", got)
+	}
+	if got := conflicting(); !slices.Equal(got, []string{"kept", "recent"}) {
+		t.Errorf("Expected the restored schedule to conflict again, got %v", got)
+	}
+	restored, err := repo.GetSchedule(ctx, "recent")
+	if err != nil || !slices.Equal(restored.Participants, []string{"user2"}) || restored.Version != 2 {
+		t.Fatalf("Expected the restored schedule with its participants and a new version, got %+v (err %v)", restored, err)
+	}
+
+	// Trash "recent" again inside the retention period: only "old" is past the cutoff
+	if err := repo.TrashSchedule(ctx, "recent", "user1", now.AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("TrashSchedule failed: %v", err)
+	}
+	purged, err := repo.PurgeSchedules(ctx, now.AddDate(0, 0, -30))
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected one schedule purged, got %d (err %v)", purged, err)
+	}
+	if _, err := repo.GetTrashedSchedule(ctx, "old"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Errorf("Expected the purged schedule to be gone, got %v", err)
+	}
+	if _, err := repo.GetTrashedSchedule(ctx, "recent"); err != nil {
+		t.Errorf("Expected the schedule inside the retention period to stay in the trash, got %v", err)
+	}
+}
+
+func TestScheduleRepository_ListConflictCandidates_LoadsDetailsInBatches(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+	createTestUser(t, repo.pool, "user2", "participant@example.com")
+	createTestUser(t, repo.pool, "user3", "other@example.com")
+
+	// One more candidate than a batch holds, so details span two IN lists
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	count := scheduleDetailBatchSize + 1
+	for i := 0; i < count; i++ {
+		schedule := persistence.Schedule{
+			ID:           fmt.Sprintf("schedule-%04d", i),
+			Title:        "candidate",
+			Start:        start,
+			End:          start.Add(time.Hour),
+			CreatorID:    "user1",
+			Participants: []string{"user2"},
+		}
+		if i%2 == 1 {
+			schedule.Participants = append(schedule.Participants, "user3")
+			schedule.ReminderMinutes = []int{30, 10}
+		}
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", schedule.ID, err)
+		}
+	}
+
+	schedules, err := repo.ListConflictCandidates(ctx, persistence.ScheduleConflictFilter{
+		ParticipantIDs: []string{"user2"},
+		Start:          start,
+		End:            start.Add(time.Hour),
+		ExcludeID:      "new",
+	})
+	if err != nil {
+		t.Fatalf("ListConflictCandidates failed: %v", err)
+	}
+	if len(schedules) != count {
+		t.Fatalf("Expected %d candidates, got %d", count, len(schedules))
+	}
+
+	for i, schedule := range schedules {
+		wantParticipants, wantReminders := []string{"user2"}, []int(nil)
+		if i%2 == 1 {
+			wantParticipants, wantReminders = []string{"user2", "user3"}, []int{10, 30}
+		}
+		if schedule.ID != fmt.Sprintf("schedule-%04d", i) {
+			t.Fatalf("Expected candidates in ID order, got %s at %d", schedule.ID, i)
+		}
+		if !slices.Equal(schedule.Participants, wantParticipants) || !slices.Equal(schedule.ReminderMinutes, wantReminders) {
+			t.Fatalf("Unexpected details for %s: participants %v, reminders %v", schedule.ID, schedule.Participants, schedule.ReminderMinutes)
+		}
+	}
+}
+
+func scheduleIDs(schedules []persistence.Schedule) []string {
+	ids := make([]string, len(schedules))
+	for i, schedule := range schedules {
+		ids[i] = schedule.ID
+	}
+	return ids
+}
+
+func TestScheduleRepository_ListSchedules_RecurringSeriesBeforeWindow(t *testing.T) {
+	repo, cleanup := setupScheduleRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, repo.pool, "user1", "creator@example.com")
+
+	monday := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	for _, schedule := range []persistence.Schedule{
+		{ID: "standup", Title: "Standup", Start: monday, End: monday.Add(30 * time.Minute), CreatorID: "user1"},
+		{ID: "kickoff", Title: "Kickoff", Start: monday, End: monday.Add(time.Hour), CreatorID: "user1"},
+	} {
+		if err := repo.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule failed for %s: %v", schedule.ID, err)
+		}
+	}
+	now := monday.Format(time.RFC3339)
+	if _, err := repo.pool.DB().ExecContext(ctx, `
+		INSERT INTO recurrences (id, schedule_id, frequency, interval_value, weekdays, starts_on, created_at, updated_at)
+		VALUES ('rule-1', 'standup', 'weekly', 1, 0, ?, ?, ?)
+	`, now, now, now); err != nil {
+		t.Fatalf("Failed to insert recurrence: %v", err)
+	}
+
+	listIDs := func(from, to time.Time) []string {
+		t.Helper()
+		schedules, err := repo.ListSchedules(ctx, persistence.ScheduleFilter{StartsAfter: &from, EndsBefore: &to})
+		if err != nil {
+			t.Fatalf("ListSchedules failed: %v", err)
+		}
+		ids := make([]string, len(schedules))
+		for i, schedule := range schedules {
+			ids[i] = schedule.ID
+		}
+		return ids
+	}
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if ids := listIDs(july, july.AddDate(0, 0, 7)); len(ids) != 1 || ids[0] != "standup" {
+		t.Fatalf("Expected the unmaterialized series to be listed, got %v", ids)
+	}
+
+	// Materialize June only: the series has no occurrence in July, but its
+	// rule still has to be expanded for windows past the horizon
+	occurrences := NewOccurrenceRepository(repo.pool)
+	if err := occurrences.MarkOccurrencesStale(ctx, "standup"); err != nil {
+		t.Fatalf("MarkOccurrencesStale failed: %v", err)
+	}
+	horizon := july.AddDate(0, 1, 0)
+	refreshes, err := occurrences.ListOccurrenceRefreshes(ctx, horizon, 10)
+	if err != nil || len(refreshes) != 1 {
+		t.Fatalf("Expected one refresh, got %+v (err %v)", refreshes, err)
+	}
+	var june []persistence.Occurrence
+	for week := 0; week < 4; week++ {
+		start := monday.AddDate(0, 0, 7*week)
+		june = append(june, persistence.Occurrence{ScheduleID: "standup", RuleID: "rule-1", Start: start, End: start.Add(30 * time.Minute)})
+	}
+	if err := occurrences.ReplaceOccurrences(ctx, refreshes[0], horizon, june); err != nil {
+		t.Fatalf("ReplaceOccurrences failed: %v", err)
+	}
+	if ids := listIDs(july, july.AddDate(0, 0, 7)); len(ids) != 0 {
+		t.Fatalf("Expected a materialized series without occurrences in the window to be left out, got %v", ids)
+	}
+	if ids := listIDs(horizon, horizon.AddDate(0, 0, 7)); len(ids) != 1 || ids[0] != "standup" {
+		t.Fatalf("Expected the series to be listed past its horizon, got %v", ids)
+	}
+
+	if err := occurrences.MarkOccurrencesStale(ctx, "standup"); err != nil {
+		t.Fatalf("MarkOccurrencesStale failed: %v", err)
+	}
+	if ids := listIDs(july, july.AddDate(0, 0, 7)); len(ids) != 1 || ids[0] != "standup" {
+		t.Fatalf("Expected a stale series to be listed, got %v", ids)
+	}
+}
+
 func setupScheduleRepositoryTest(t *testing.T) (*ScheduleRepository, func()) {
 	// Create temporary database file
 	tempDir := t.TempDir()
@@ -247,6 +696,8 @@ func setupScheduleRepositoryTest(t *testing.T) (*ScheduleRepository, func()) {
 			display_name TEXT NOT NULL,
 			password_hash TEXT NOT NULL,
 			is_admin INTEGER NOT NULL DEFAULT 0,
+			time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo',
+			version INTEGER NOT NULL DEFAULT 1,
 			created_at TEXT NOT NULL,
 			updated_at TEXT NOT NULL
 		);
@@ -256,8 +707,11 @@ func setupScheduleRepositoryTest(t *testing.T) (*ScheduleRepository, func()) {
 			name TEXT NOT NULL,
 			capacity INTEGER NOT NULL CHECK (capacity > 0),
 			location TEXT,
+			version INTEGER NOT NULL DEFAULT 1,
 			created_at TEXT NOT NULL,
-			updated_at TEXT NOT NULL
+			updated_at TEXT NOT NULL,
+			deleted_at TEXT,
+			deleted_by TEXT
 		);
 		
 		CREATE TABLE IF NOT EXISTS schedules (
@@ -269,8 +723,15 @@ func setupScheduleRepositoryTest(t *testing.T) (*ScheduleRepository, func()) {
 			room_id TEXT,
 			memo TEXT,
 			web_conference_url TEXT,
+			time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo',
+			all_day INTEGER NOT NULL DEFAULT 0,
+			busy INTEGER NOT NULL DEFAULT 0,
+			version INTEGER NOT NULL DEFAULT 1,
+			calendar_id TEXT,
 			created_at TEXT NOT NULL,
 			updated_at TEXT NOT NULL,
+			deleted_at TEXT,
+			deleted_by TEXT,
 			FOREIGN KEY (creator_id) REFERENCES users(id),
 			FOREIGN KEY (room_id) REFERENCES rooms(id)
 		);
@@ -295,6 +756,97 @@ func setupScheduleRepositoryTest(t *testing.T) (*ScheduleRepository, func()) {
 			updated_at TEXT NOT NULL,
 			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
 		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_reminders (
+			schedule_id TEXT NOT NULL,
+			minutes_before INTEGER NOT NULL,
+			PRIMARY KEY (schedule_id, minutes_before),
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS reminder_deliveries (
+			id TEXT PRIMARY KEY,
+			schedule_id TEXT NOT NULL,
+			occurrence_start TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			minutes_before INTEGER NOT NULL,
+			channel TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT,
+			due_at TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			UNIQUE (schedule_id, occurrence_start, user_id, minutes_before, channel),
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_changes (
+			sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+			schedule_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			changed_at TEXT NOT NULL
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_change_users (
+			sequence INTEGER NOT NULL,
+			user_id TEXT NOT NULL,
+			PRIMARY KEY (sequence, user_id),
+			FOREIGN KEY (sequence) REFERENCES schedule_changes(sequence) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_revisions (
+			schedule_id TEXT NOT NULL,
+			revision INTEGER NOT NULL,
+			changed_by TEXT NOT NULL,
+			changed_at TEXT NOT NULL,
+			snapshot TEXT NOT NULL,
+			PRIMARY KEY (schedule_id, revision),
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS occurrences (
+			schedule_id TEXT NOT NULL,
+			rule_id TEXT NOT NULL,
+			start_time TEXT NOT NULL,
+			end_time TEXT NOT NULL,
+			PRIMARY KEY (schedule_id, rule_id, start_time),
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS occurrence_horizons (
+			schedule_id TEXT PRIMARY KEY,
+			materialized_until TEXT,
+			stale INTEGER NOT NULL DEFAULT 1,
+			generation INTEGER NOT NULL DEFAULT 1,
+			refreshed_at TEXT,
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS user_groups (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT NOT NULL DEFAULT '',
+			owner_id TEXT,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		);
+		
+		CREATE TABLE IF NOT EXISTS user_group_members (
+			group_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			PRIMARY KEY (group_id, user_id),
+			FOREIGN KEY (group_id) REFERENCES user_groups(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		
+		CREATE TABLE IF NOT EXISTS schedule_participant_groups (
+			schedule_id TEXT NOT NULL,
+			group_id TEXT NOT NULL,
+			PRIMARY KEY (schedule_id, group_id),
+			FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+			FOREIGN KEY (group_id) REFERENCES user_groups(id) ON DELETE CASCADE
+		);
 	`)
 	if err != nil {
 		t.Fatalf("Failed to create test schema: %v", err)
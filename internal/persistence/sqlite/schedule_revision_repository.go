@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// ScheduleRevisionRepository implements persistence.ScheduleRevisionRepository using SQLite
+type ScheduleRevisionRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewScheduleRevisionRepository creates a new SQLite schedule revision repository
+func NewScheduleRevisionRepository(pool *ConnectionPool) *ScheduleRevisionRepository {
+	return &ScheduleRevisionRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// AppendScheduleRevision stores a revision under the next number for its schedule
+func (r *ScheduleRevisionRepository) AppendScheduleRevision(ctx context.Context, revision persistence.ScheduleRevision) (int, error) {
+	if revision.ScheduleID == "" || len(revision.Snapshot) == 0 {
+		return 0, persistence.ErrConstraintViolation
+	}
+
+	var number int
+	err := r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := r.helper.QueryRowTx(tx, "SELECT COALESCE(MAX(revision), 0) + 1 FROM schedule_revisions WHERE schedule_id = ?", revision.ScheduleID).Scan(&number)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		_, err = r.helper.ExecTx(tx, `
+			INSERT INTO schedule_revisions (schedule_id, revision, changed_by, changed_at, snapshot)
+			VALUES (?, ?, ?, ?, ?)
+		`,
+			revision.ScheduleID,
+			number,
+			revision.ChangedBy,
+			revision.ChangedAt.UTC().Format(time.RFC3339Nano),
+			string(revision.Snapshot),
+		)
+		if err != nil {
+			errStr := err.Error()
+			if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+				return persistence.ErrNotFound
+			}
+			return r.mapper.MapError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return number, nil
+}
+
+// ListScheduleRevisions returns a schedule's revisions in ascending order
+func (r *ScheduleRevisionRepository) ListScheduleRevisions(ctx context.Context, scheduleID string) ([]persistence.ScheduleRevision, error) {
+	rows, err := r.helper.Query(ctx, `
+		SELECT schedule_id, revision, changed_by, changed_at, snapshot
+		FROM schedule_revisions
+		WHERE schedule_id = ?
+		ORDER BY revision ASC
+	`, scheduleID)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var revisions []persistence.ScheduleRevision
+	for rows.Next() {
+		revision, err := scanScheduleRevision(rows)
+		if err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	return revisions, nil
+}
+
+// GetScheduleRevision retrieves one revision of a schedule
+func (r *ScheduleRevisionRepository) GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (persistence.ScheduleRevision, error) {
+	row := r.helper.QueryRow(ctx, `
+		SELECT schedule_id, revision, changed_by, changed_at, snapshot
+		FROM schedule_revisions
+		WHERE schedule_id = ? AND revision = ?
+	`, scheduleID, revision)
+	stored, err := scanScheduleRevision(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.ScheduleRevision{}, persistence.ErrNotFound
+		}
+		return persistence.ScheduleRevision{}, r.mapper.MapError(err)
+	}
+	return stored, nil
+}
+
+func scanScheduleRevision(row rowScanner) (persistence.ScheduleRevision, error) {
+	var revision persistence.ScheduleRevision
+	var changedAt, snapshot string
+	if err := row.Scan(&revision.ScheduleID, &revision.Revision, &revision.ChangedBy, &changedAt, &snapshot); err != nil {
+		return persistence.ScheduleRevision{}, err
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, changedAt)
+	if err != nil {
+		return persistence.ScheduleRevision{}, fmt.Errorf("failed to parse changed_at: %w", err)
+	}
+	revision.ChangedAt = parsed
+	revision.Snapshot = []byte(snapshot)
+	return revision, nil
+}
@@ -34,6 +34,21 @@ type Storage struct {
 	scheduleRepo   *ScheduleRepository
 	recurrenceRepo *RecurrenceRepository
 	sessionRepo    *SessionRepository
+	availabilityRepo *AvailabilityRepository
+	holidayRepo    *HolidayRepository
+	reminderRepo   *ReminderRepository
+	webhookRepo    *WebhookRepository
+	appPasswordRepo *AppPasswordRepository
+	caldavRepo     *CalDAVObjectRepository
+	caldavSyncRepo *CalDAVSyncRepository
+	revisionRepo   *ScheduleRevisionRepository
+	idempotencyRepo *IdempotencyRepository
+	occurrenceRepo *OccurrenceRepository
+	colleagueGroupRepo *ColleagueGroupRepository
+	viewLinkRepo   *ViewLinkRepository
+	calendarRepo   *CalendarRepository
+	userGroupRepo  *UserGroupRepository
+	departmentRepo *DepartmentRepository
 	
 	// Legacy fields for backward compatibility during migration
 	mu sync.RWMutex
@@ -71,6 +86,21 @@ func Open(dsn string) (*Storage, error) {
 	scheduleRepo := NewScheduleRepository(pool)
 	recurrenceRepo := NewRecurrenceRepository(pool)
 	sessionRepo := NewSessionRepository(pool)
+	availabilityRepo := NewAvailabilityRepository(pool)
+	holidayRepo := NewHolidayRepository(pool)
+	reminderRepo := NewReminderRepository(pool)
+	webhookRepo := NewWebhookRepository(pool)
+	appPasswordRepo := NewAppPasswordRepository(pool)
+	caldavRepo := NewCalDAVObjectRepository(pool)
+	caldavSyncRepo := NewCalDAVSyncRepository(pool)
+	revisionRepo := NewScheduleRevisionRepository(pool)
+	idempotencyRepo := NewIdempotencyRepository(pool)
+	occurrenceRepo := NewOccurrenceRepository(pool)
+	colleagueGroupRepo := NewColleagueGroupRepository(pool)
+	viewLinkRepo := NewViewLinkRepository(pool)
+	calendarRepo := NewCalendarRepository(pool)
+	userGroupRepo := NewUserGroupRepository(pool)
+	departmentRepo := NewDepartmentRepository(pool)
 
 	return &Storage{
 		pool:           pool,
@@ -79,6 +109,21 @@ func Open(dsn string) (*Storage, error) {
 		scheduleRepo:   scheduleRepo,
 		recurrenceRepo: recurrenceRepo,
 		sessionRepo:    sessionRepo,
+		availabilityRepo: availabilityRepo,
+		holidayRepo:    holidayRepo,
+		reminderRepo:   reminderRepo,
+		webhookRepo:    webhookRepo,
+		appPasswordRepo: appPasswordRepo,
+		caldavRepo:     caldavRepo,
+		caldavSyncRepo: caldavSyncRepo,
+		revisionRepo:   revisionRepo,
+		idempotencyRepo: idempotencyRepo,
+		occurrenceRepo: occurrenceRepo,
+		colleagueGroupRepo: colleagueGroupRepo,
+		viewLinkRepo:   viewLinkRepo,
+		calendarRepo:   calendarRepo,
+		userGroupRepo:  userGroupRepo,
+		departmentRepo: departmentRepo,
 		path:           path,
 		// Initialize legacy maps for backward compatibility
 		users:                make(map[string]persistence.User),
@@ -93,6 +138,12 @@ func Open(dsn string) (*Storage, error) {
 	}, nil
 }
 
+// WithinTransaction runs fn in one database transaction. Repository calls made
+// with the context passed to fn commit or roll back together.
+func (s *Storage) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.pool.RunInTransaction(ctx, fn)
+}
+
 // Close releases any held resources.
 func (s *Storage) Close() error {
 	if s.pool != nil {
@@ -242,6 +293,11 @@ func (s *Storage) ListUsers(ctx context.Context) ([]persistence.User, error) {
 	return s.userRepo.ListUsers(ctx)
 }
 
+// ListUsersPage returns users ordered by email then ID after the filter's keyset bound.
+func (s *Storage) ListUsersPage(ctx context.Context, filter persistence.UserFilter) ([]persistence.User, error) {
+	return s.userRepo.ListUsersPage(ctx, filter)
+}
+
 // DeleteUser removes a user by ID.
 func (s *Storage) DeleteUser(ctx context.Context, id string) error {
 	return s.userRepo.DeleteUser(ctx, id)
@@ -267,11 +323,41 @@ func (s *Storage) ListRooms(ctx context.Context) ([]persistence.Room, error) {
 	return s.roomRepo.ListRooms(ctx)
 }
 
+// ListRoomsPage returns rooms ordered by name then ID after the filter's keyset bound.
+func (s *Storage) ListRoomsPage(ctx context.Context, filter persistence.RoomFilter) ([]persistence.Room, error) {
+	return s.roomRepo.ListRoomsPage(ctx, filter)
+}
+
 // DeleteRoom deletes a room by ID.
 func (s *Storage) DeleteRoom(ctx context.Context, id string) error {
 	return s.roomRepo.DeleteRoom(ctx, id)
 }
 
+// TrashRoom soft-deletes a room.
+func (s *Storage) TrashRoom(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	return s.roomRepo.TrashRoom(ctx, id, deletedBy, deletedAt)
+}
+
+// RestoreRoom brings a trashed room back.
+func (s *Storage) RestoreRoom(ctx context.Context, id string, restoredAt time.Time) error {
+	return s.roomRepo.RestoreRoom(ctx, id, restoredAt)
+}
+
+// GetTrashedRoom retrieves a trashed room by ID.
+func (s *Storage) GetTrashedRoom(ctx context.Context, id string) (persistence.Room, error) {
+	return s.roomRepo.GetTrashedRoom(ctx, id)
+}
+
+// ListTrashedRooms lists trashed rooms deleted by the given user, or all when empty.
+func (s *Storage) ListTrashedRooms(ctx context.Context, deletedBy string) ([]persistence.Room, error) {
+	return s.roomRepo.ListTrashedRooms(ctx, deletedBy)
+}
+
+// PurgeRooms permanently deletes rooms trashed before the bound.
+func (s *Storage) PurgeRooms(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return s.roomRepo.PurgeRooms(ctx, deletedBefore)
+}
+
 // CreateSchedule stores a schedule with participants.
 func (s *Storage) CreateSchedule(ctx context.Context, schedule persistence.Schedule) error {
 	return s.scheduleRepo.CreateSchedule(ctx, schedule)
@@ -297,6 +383,41 @@ func (s *Storage) DeleteSchedule(ctx context.Context, id string) error {
 	return s.scheduleRepo.DeleteSchedule(ctx, id)
 }
 
+// TrashSchedule soft-deletes a schedule.
+func (s *Storage) TrashSchedule(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	return s.scheduleRepo.TrashSchedule(ctx, id, deletedBy, deletedAt)
+}
+
+// RestoreSchedule brings a trashed schedule back.
+func (s *Storage) RestoreSchedule(ctx context.Context, id string, restoredAt time.Time) error {
+	return s.scheduleRepo.RestoreSchedule(ctx, id, restoredAt)
+}
+
+// GetTrashedSchedule retrieves a trashed schedule by ID.
+func (s *Storage) GetTrashedSchedule(ctx context.Context, id string) (persistence.Schedule, error) {
+	return s.scheduleRepo.GetTrashedSchedule(ctx, id)
+}
+
+// ListTrashedSchedules lists trashed schedules deleted by the given user, or all when empty.
+func (s *Storage) ListTrashedSchedules(ctx context.Context, deletedBy string) ([]persistence.Schedule, error) {
+	return s.scheduleRepo.ListTrashedSchedules(ctx, deletedBy)
+}
+
+// PurgeSchedules permanently deletes schedules trashed before the bound.
+func (s *Storage) PurgeSchedules(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return s.scheduleRepo.PurgeSchedules(ctx, deletedBefore)
+}
+
+// ListConflictCandidates lists the schedules that may conflict with a candidate.
+func (s *Storage) ListConflictCandidates(ctx context.Context, filter persistence.ScheduleConflictFilter) ([]persistence.Schedule, error) {
+	return s.scheduleRepo.ListConflictCandidates(ctx, filter)
+}
+
+// ListScheduleChanges lists schedule change log entries in sequence order.
+func (s *Storage) ListScheduleChanges(ctx context.Context, filter persistence.ScheduleChangeFilter) ([]persistence.ScheduleChange, error) {
+	return s.scheduleRepo.ListScheduleChanges(ctx, filter)
+}
+
 // UpsertRecurrence creates or updates a recurrence rule.
 func (s *Storage) UpsertRecurrence(ctx context.Context, rule persistence.RecurrenceRule) error {
 	return s.recurrenceRepo.UpsertRecurrence(ctx, rule)
@@ -342,6 +463,421 @@ func (s *Storage) DeleteExpiredSessions(ctx context.Context, reference time.Time
 	return s.sessionRepo.DeleteExpiredSessions(ctx, reference)
 }
 
+// ListWorkingHours returns working windows for the given users.
+func (s *Storage) ListWorkingHours(ctx context.Context, userIDs []string) ([]persistence.WorkingHours, error) {
+	return s.availabilityRepo.ListWorkingHours(ctx, userIDs)
+}
+
+// ReplaceWorkingHours replaces a user's working windows.
+func (s *Storage) ReplaceWorkingHours(ctx context.Context, userID string, hours []persistence.WorkingHours) error {
+	return s.availabilityRepo.ReplaceWorkingHours(ctx, userID, hours)
+}
+
+// CreateOutOfOffice stores a new out-of-office period.
+func (s *Storage) CreateOutOfOffice(ctx context.Context, entry persistence.OutOfOffice) error {
+	return s.availabilityRepo.CreateOutOfOffice(ctx, entry)
+}
+
+// GetOutOfOffice retrieves an out-of-office period by ID.
+func (s *Storage) GetOutOfOffice(ctx context.Context, id string) (persistence.OutOfOffice, error) {
+	return s.availabilityRepo.GetOutOfOffice(ctx, id)
+}
+
+// DeleteOutOfOffice removes an out-of-office period by ID.
+func (s *Storage) DeleteOutOfOffice(ctx context.Context, id string) error {
+	return s.availabilityRepo.DeleteOutOfOffice(ctx, id)
+}
+
+// ListOutOfOffice lists out-of-office periods overlapping the filter range.
+func (s *Storage) ListOutOfOffice(ctx context.Context, filter persistence.OutOfOfficeFilter) ([]persistence.OutOfOffice, error) {
+	return s.availabilityRepo.ListOutOfOffice(ctx, filter)
+}
+
+// ListCompanyHolidays returns all company closure days.
+func (s *Storage) ListCompanyHolidays(ctx context.Context) ([]persistence.CompanyHoliday, error) {
+	return s.holidayRepo.ListCompanyHolidays(ctx)
+}
+
+// CreateCompanyHoliday stores a company closure day.
+func (s *Storage) CreateCompanyHoliday(ctx context.Context, holiday persistence.CompanyHoliday) error {
+	return s.holidayRepo.CreateCompanyHoliday(ctx, holiday)
+}
+
+// DeleteCompanyHoliday removes a company closure day.
+func (s *Storage) DeleteCompanyHoliday(ctx context.Context, date time.Time) error {
+	return s.holidayRepo.DeleteCompanyHoliday(ctx, date)
+}
+
+// GetReminderPreferences returns a user's reminder preferences.
+func (s *Storage) GetReminderPreferences(ctx context.Context, userID string) (persistence.ReminderPreferences, error) {
+	return s.reminderRepo.GetReminderPreferences(ctx, userID)
+}
+
+// SaveReminderPreferences stores a user's reminder preferences.
+func (s *Storage) SaveReminderPreferences(ctx context.Context, prefs persistence.ReminderPreferences) error {
+	return s.reminderRepo.SaveReminderPreferences(ctx, prefs)
+}
+
+// ListReminderPreferences returns the reminder preferences of the given users.
+func (s *Storage) ListReminderPreferences(ctx context.Context, userIDs []string) ([]persistence.ReminderPreferences, error) {
+	return s.reminderRepo.ListReminderPreferences(ctx, userIDs)
+}
+
+// ListRecurringScheduleIDs returns schedules with an active recurrence.
+func (s *Storage) ListRecurringScheduleIDs(ctx context.Context, activeFrom time.Time) ([]string, error) {
+	return s.reminderRepo.ListRecurringScheduleIDs(ctx, activeFrom)
+}
+
+// ClaimReminderDelivery claims a reminder for delivery.
+func (s *Storage) ClaimReminderDelivery(ctx context.Context, delivery persistence.ReminderDelivery, maxAttempts int, lease time.Duration) (persistence.ReminderDelivery, bool, error) {
+	return s.reminderRepo.ClaimReminderDelivery(ctx, delivery, maxAttempts, lease)
+}
+
+// FinishReminderDelivery records the outcome of a reminder delivery.
+func (s *Storage) FinishReminderDelivery(ctx context.Context, delivery persistence.ReminderDelivery) error {
+	return s.reminderRepo.FinishReminderDelivery(ctx, delivery)
+}
+
+// CreateWebhook stores a webhook subscription.
+func (s *Storage) CreateWebhook(ctx context.Context, subscription persistence.WebhookSubscription) error {
+	return s.webhookRepo.CreateWebhook(ctx, subscription)
+}
+
+// GetWebhook retrieves a webhook subscription.
+func (s *Storage) GetWebhook(ctx context.Context, id string) (persistence.WebhookSubscription, error) {
+	return s.webhookRepo.GetWebhook(ctx, id)
+}
+
+// UpdateWebhook updates a webhook subscription.
+func (s *Storage) UpdateWebhook(ctx context.Context, subscription persistence.WebhookSubscription) error {
+	return s.webhookRepo.UpdateWebhook(ctx, subscription)
+}
+
+// DeleteWebhook removes a webhook subscription.
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) error {
+	return s.webhookRepo.DeleteWebhook(ctx, id)
+}
+
+// ListWebhooks returns all webhook subscriptions.
+func (s *Storage) ListWebhooks(ctx context.Context) ([]persistence.WebhookSubscription, error) {
+	return s.webhookRepo.ListWebhooks(ctx)
+}
+
+// AppendOutboxEvent stores a published event in the outbox.
+func (s *Storage) AppendOutboxEvent(ctx context.Context, event persistence.OutboxEvent) error {
+	return s.webhookRepo.AppendOutboxEvent(ctx, event)
+}
+
+// ListPendingOutboxEvents returns outbox events awaiting fan-out.
+func (s *Storage) ListPendingOutboxEvents(ctx context.Context, limit int) ([]persistence.OutboxEvent, error) {
+	return s.webhookRepo.ListPendingOutboxEvents(ctx, limit)
+}
+
+// ListOutboxEventsAfter returns outbox events newer than the given sequence.
+func (s *Storage) ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]persistence.OutboxEvent, error) {
+	return s.webhookRepo.ListOutboxEventsAfter(ctx, after, limit)
+}
+
+// LatestOutboxSequence returns the sequence of the newest outbox event.
+func (s *Storage) LatestOutboxSequence(ctx context.Context) (int64, error) {
+	return s.webhookRepo.LatestOutboxSequence(ctx)
+}
+
+// EnqueueWebhookDeliveries stores an event's deliveries and marks it dispatched.
+func (s *Storage) EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []persistence.WebhookDelivery, dispatchedAt time.Time) error {
+	return s.webhookRepo.EnqueueWebhookDeliveries(ctx, eventID, deliveries, dispatchedAt)
+}
+
+// CreateWebhookDelivery stores a single webhook delivery.
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, delivery persistence.WebhookDelivery) error {
+	return s.webhookRepo.CreateWebhookDelivery(ctx, delivery)
+}
+
+// GetWebhookDelivery retrieves a webhook delivery.
+func (s *Storage) GetWebhookDelivery(ctx context.Context, id string) (persistence.WebhookDelivery, error) {
+	return s.webhookRepo.GetWebhookDelivery(ctx, id)
+}
+
+// UpdateWebhookDelivery records a webhook delivery attempt.
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, delivery persistence.WebhookDelivery) error {
+	return s.webhookRepo.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// ListDueWebhookDeliveries returns webhook deliveries due for an attempt.
+func (s *Storage) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]persistence.WebhookDelivery, error) {
+	return s.webhookRepo.ListDueWebhookDeliveries(ctx, now, limit)
+}
+
+// ListWebhookDeliveries returns a subscription's delivery log.
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]persistence.WebhookDelivery, error) {
+	return s.webhookRepo.ListWebhookDeliveries(ctx, subscriptionID, limit)
+}
+
+// CreateAppPassword stores a hashed app password.
+func (s *Storage) CreateAppPassword(ctx context.Context, password persistence.AppPassword) error {
+	return s.appPasswordRepo.CreateAppPassword(ctx, password)
+}
+
+// GetAppPasswordByHash retrieves an app password by its token hash.
+func (s *Storage) GetAppPasswordByHash(ctx context.Context, tokenHash string) (persistence.AppPassword, error) {
+	return s.appPasswordRepo.GetAppPasswordByHash(ctx, tokenHash)
+}
+
+// ListAppPasswords lists a user's app passwords.
+func (s *Storage) ListAppPasswords(ctx context.Context, userID string) ([]persistence.AppPassword, error) {
+	return s.appPasswordRepo.ListAppPasswords(ctx, userID)
+}
+
+// DeleteAppPassword removes one of a user's app passwords.
+func (s *Storage) DeleteAppPassword(ctx context.Context, userID, id string) error {
+	return s.appPasswordRepo.DeleteAppPassword(ctx, userID, id)
+}
+
+// TouchAppPassword records when an app password was last used.
+func (s *Storage) TouchAppPassword(ctx context.Context, id string, usedAt time.Time) error {
+	return s.appPasswordRepo.TouchAppPassword(ctx, id, usedAt)
+}
+
+// SaveCalDAVObject records the CalDAV resource name of a schedule.
+func (s *Storage) SaveCalDAVObject(ctx context.Context, object persistence.CalDAVObject) error {
+	return s.caldavRepo.SaveCalDAVObject(ctx, object)
+}
+
+// GetCalDAVObjectByName retrieves a CalDAV resource mapping by name.
+func (s *Storage) GetCalDAVObjectByName(ctx context.Context, resourceName string) (persistence.CalDAVObject, error) {
+	return s.caldavRepo.GetCalDAVObjectByName(ctx, resourceName)
+}
+
+// ListCalDAVObjects lists the CalDAV resource mappings of schedules.
+func (s *Storage) ListCalDAVObjects(ctx context.Context, scheduleIDs []string) ([]persistence.CalDAVObject, error) {
+	return s.caldavRepo.ListCalDAVObjects(ctx, scheduleIDs)
+}
+
+// CreateCalDAVSyncAccount stores an external CalDAV sync account.
+func (s *Storage) CreateCalDAVSyncAccount(ctx context.Context, account persistence.CalDAVSyncAccount) error {
+	return s.caldavSyncRepo.CreateCalDAVSyncAccount(ctx, account)
+}
+
+// GetCalDAVSyncAccount retrieves a sync account by ID.
+func (s *Storage) GetCalDAVSyncAccount(ctx context.Context, id string) (persistence.CalDAVSyncAccount, error) {
+	return s.caldavSyncRepo.GetCalDAVSyncAccount(ctx, id)
+}
+
+// ListCalDAVSyncAccounts lists a user's sync accounts, or all when userID is empty.
+func (s *Storage) ListCalDAVSyncAccounts(ctx context.Context, userID string) ([]persistence.CalDAVSyncAccount, error) {
+	return s.caldavSyncRepo.ListCalDAVSyncAccounts(ctx, userID)
+}
+
+// DeleteCalDAVSyncAccount removes one of a user's sync accounts.
+func (s *Storage) DeleteCalDAVSyncAccount(ctx context.Context, userID, id string) error {
+	return s.caldavSyncRepo.DeleteCalDAVSyncAccount(ctx, userID, id)
+}
+
+// ListCalDAVSyncItems lists the object mappings of a sync account.
+func (s *Storage) ListCalDAVSyncItems(ctx context.Context, accountID string) ([]persistence.CalDAVSyncItem, error) {
+	return s.caldavSyncRepo.ListCalDAVSyncItems(ctx, accountID)
+}
+
+// SaveCalDAVSyncItem inserts or replaces an object mapping.
+func (s *Storage) SaveCalDAVSyncItem(ctx context.Context, item persistence.CalDAVSyncItem) error {
+	return s.caldavSyncRepo.SaveCalDAVSyncItem(ctx, item)
+}
+
+// DeleteCalDAVSyncItem removes an object mapping.
+func (s *Storage) DeleteCalDAVSyncItem(ctx context.Context, accountID, remoteHref string) error {
+	return s.caldavSyncRepo.DeleteCalDAVSyncItem(ctx, accountID, remoteHref)
+}
+
+// CreateCalDAVSyncRun records a sync run.
+func (s *Storage) CreateCalDAVSyncRun(ctx context.Context, run persistence.CalDAVSyncRun) error {
+	return s.caldavSyncRepo.CreateCalDAVSyncRun(ctx, run)
+}
+
+// ListCalDAVSyncRuns returns an account's most recent runs.
+func (s *Storage) ListCalDAVSyncRuns(ctx context.Context, accountID string, limit int) ([]persistence.CalDAVSyncRun, error) {
+	return s.caldavSyncRepo.ListCalDAVSyncRuns(ctx, accountID, limit)
+}
+
+// AppendScheduleRevision stores the next revision of a schedule.
+func (s *Storage) AppendScheduleRevision(ctx context.Context, revision persistence.ScheduleRevision) (int, error) {
+	return s.revisionRepo.AppendScheduleRevision(ctx, revision)
+}
+
+// ListScheduleRevisions lists a schedule's revisions in ascending order.
+func (s *Storage) ListScheduleRevisions(ctx context.Context, scheduleID string) ([]persistence.ScheduleRevision, error) {
+	return s.revisionRepo.ListScheduleRevisions(ctx, scheduleID)
+}
+
+// GetScheduleRevision retrieves one revision of a schedule.
+func (s *Storage) GetScheduleRevision(ctx context.Context, scheduleID string, revision int) (persistence.ScheduleRevision, error) {
+	return s.revisionRepo.GetScheduleRevision(ctx, scheduleID, revision)
+}
+
+// ReserveIdempotencyKey claims an idempotency key for a first request.
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) (persistence.IdempotencyRecord, bool, error) {
+	return s.idempotencyRepo.ReserveIdempotencyKey(ctx, record)
+}
+
+// CompleteIdempotencyKey stores the response of a reserved idempotency key.
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) error {
+	return s.idempotencyRepo.CompleteIdempotencyKey(ctx, record)
+}
+
+// ReleaseIdempotencyKey drops an idempotency key so its request can be retried.
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error {
+	return s.idempotencyRepo.ReleaseIdempotencyKey(ctx, principalID, key)
+}
+
+// MarkOccurrencesStale queues a schedule's occurrences for regeneration.
+func (s *Storage) MarkOccurrencesStale(ctx context.Context, scheduleID string) error {
+	return s.occurrenceRepo.MarkOccurrencesStale(ctx, scheduleID)
+}
+
+// MarkHolidayOccurrencesStale queues the occurrences of schedules with a holiday policy for regeneration.
+func (s *Storage) MarkHolidayOccurrencesStale(ctx context.Context) error {
+	return s.occurrenceRepo.MarkHolidayOccurrencesStale(ctx)
+}
+
+// ListOccurrenceRefreshes lists schedules whose occurrences need regenerating.
+func (s *Storage) ListOccurrenceRefreshes(ctx context.Context, horizon time.Time, limit int) ([]persistence.OccurrenceRefresh, error) {
+	return s.occurrenceRepo.ListOccurrenceRefreshes(ctx, horizon, limit)
+}
+
+// ReplaceOccurrences stores the regenerated occurrences of a schedule.
+func (s *Storage) ReplaceOccurrences(ctx context.Context, refresh persistence.OccurrenceRefresh, until time.Time, occurrences []persistence.Occurrence) error {
+	return s.occurrenceRepo.ReplaceOccurrences(ctx, refresh, until, occurrences)
+}
+
+// ListOccurrences retrieves materialized occurrences of schedules.
+func (s *Storage) ListOccurrences(ctx context.Context, filter persistence.OccurrenceFilter) (map[string][]persistence.Occurrence, error) {
+	return s.occurrenceRepo.ListOccurrences(ctx, filter)
+}
+
+// ResetOccurrences drops the occurrence index and queues every recurring schedule.
+func (s *Storage) ResetOccurrences(ctx context.Context) (int, error) {
+	return s.occurrenceRepo.ResetOccurrences(ctx)
+}
+
+// CreateColleagueGroup stores a new colleague group.
+func (s *Storage) CreateColleagueGroup(ctx context.Context, group persistence.ColleagueGroup) error {
+	return s.colleagueGroupRepo.CreateColleagueGroup(ctx, group)
+}
+
+// GetColleagueGroup retrieves a colleague group by ID.
+func (s *Storage) GetColleagueGroup(ctx context.Context, id string) (persistence.ColleagueGroup, error) {
+	return s.colleagueGroupRepo.GetColleagueGroup(ctx, id)
+}
+
+// UpdateColleagueGroup replaces a colleague group's name, members and shares.
+func (s *Storage) UpdateColleagueGroup(ctx context.Context, group persistence.ColleagueGroup) error {
+	return s.colleagueGroupRepo.UpdateColleagueGroup(ctx, group)
+}
+
+// DeleteColleagueGroup removes a colleague group.
+func (s *Storage) DeleteColleagueGroup(ctx context.Context, id string) error {
+	return s.colleagueGroupRepo.DeleteColleagueGroup(ctx, id)
+}
+
+// ListColleagueGroups lists the colleague groups a user owns or can use.
+func (s *Storage) ListColleagueGroups(ctx context.Context, userID string) ([]persistence.ColleagueGroup, error) {
+	return s.colleagueGroupRepo.ListColleagueGroups(ctx, userID)
+}
+
+// CreateViewLink stores a new shareable view link.
+func (s *Storage) CreateViewLink(ctx context.Context, link persistence.ViewLink) error {
+	return s.viewLinkRepo.CreateViewLink(ctx, link)
+}
+
+// GetViewLink retrieves a shareable view link by code.
+func (s *Storage) GetViewLink(ctx context.Context, code string) (persistence.ViewLink, error) {
+	return s.viewLinkRepo.GetViewLink(ctx, code)
+}
+
+// CreateCalendar stores a new shared calendar.
+func (s *Storage) CreateCalendar(ctx context.Context, calendar persistence.Calendar) error {
+	return s.calendarRepo.CreateCalendar(ctx, calendar)
+}
+
+// GetCalendar retrieves a shared calendar by ID.
+func (s *Storage) GetCalendar(ctx context.Context, id string) (persistence.Calendar, error) {
+	return s.calendarRepo.GetCalendar(ctx, id)
+}
+
+// UpdateCalendar replaces a shared calendar's details, editors and viewers.
+func (s *Storage) UpdateCalendar(ctx context.Context, calendar persistence.Calendar) error {
+	return s.calendarRepo.UpdateCalendar(ctx, calendar)
+}
+
+// DeleteCalendar removes a shared calendar.
+func (s *Storage) DeleteCalendar(ctx context.Context, id string) error {
+	return s.calendarRepo.DeleteCalendar(ctx, id)
+}
+
+// ListCalendars lists the shared calendars a user owns, edits or views.
+func (s *Storage) ListCalendars(ctx context.Context, userID string) ([]persistence.Calendar, error) {
+	return s.calendarRepo.ListCalendars(ctx, userID)
+}
+
+// CreateUserGroup stores a new user group.
+func (s *Storage) CreateUserGroup(ctx context.Context, group persistence.UserGroup) error {
+	return s.userGroupRepo.CreateUserGroup(ctx, group)
+}
+
+// GetUserGroup retrieves a user group by ID.
+func (s *Storage) GetUserGroup(ctx context.Context, id string) (persistence.UserGroup, error) {
+	return s.userGroupRepo.GetUserGroup(ctx, id)
+}
+
+// UpdateUserGroup replaces a user group's details and members.
+func (s *Storage) UpdateUserGroup(ctx context.Context, group persistence.UserGroup) error {
+	return s.userGroupRepo.UpdateUserGroup(ctx, group)
+}
+
+// DeleteUserGroup removes a user group.
+func (s *Storage) DeleteUserGroup(ctx context.Context, id string) error {
+	return s.userGroupRepo.DeleteUserGroup(ctx, id)
+}
+
+// ListUserGroups lists every user group.
+func (s *Storage) ListUserGroups(ctx context.Context) ([]persistence.UserGroup, error) {
+	return s.userGroupRepo.ListUserGroups(ctx)
+}
+
+// CreateDepartment stores a new department.
+func (s *Storage) CreateDepartment(ctx context.Context, department persistence.Department) error {
+	return s.departmentRepo.CreateDepartment(ctx, department)
+}
+
+// GetDepartment retrieves a department by ID.
+func (s *Storage) GetDepartment(ctx context.Context, id string) (persistence.Department, error) {
+	return s.departmentRepo.GetDepartment(ctx, id)
+}
+
+// UpdateDepartment renames or moves a department.
+func (s *Storage) UpdateDepartment(ctx context.Context, department persistence.Department) error {
+	return s.departmentRepo.UpdateDepartment(ctx, department)
+}
+
+// DeleteDepartment removes a department.
+func (s *Storage) DeleteDepartment(ctx context.Context, id string) error {
+	return s.departmentRepo.DeleteDepartment(ctx, id)
+}
+
+// ListDepartments lists every department.
+func (s *Storage) ListDepartments(ctx context.Context) ([]persistence.Department, error) {
+	return s.departmentRepo.ListDepartments(ctx)
+}
+
+// ListDepartmentMembers lists the users in any of the departments.
+func (s *Storage) ListDepartmentMembers(ctx context.Context, departmentIDs []string) ([]string, error) {
+	return s.departmentRepo.ListDepartmentMembers(ctx, departmentIDs)
+}
+
+// ListDirectReports lists the users reporting to a manager.
+func (s *Storage) ListDirectReports(ctx context.Context, managerID string) ([]string, error) {
+	return s.departmentRepo.ListDirectReports(ctx, managerID)
+}
+
 func (s *Storage) validateScheduleLocked(schedule persistence.Schedule) (persistence.Schedule, error) {
 	if schedule.End.Before(schedule.Start) || schedule.End.Equal(schedule.Start) {
 		return persistence.Schedule{}, persistence.ErrConstraintViolation
@@ -423,6 +959,16 @@ func normalizeDSN(dsn string) (string, error) {
 	return abs, nil
 }
 
+// defaultTimeZone mirrors the column default applied by the time zone migration.
+const defaultTimeZone = "Asia/Tokyo"
+
+func timeZoneOrDefault(name string) string {
+	if trimmed := strings.TrimSpace(name); trimmed != "" {
+		return trimmed
+	}
+	return defaultTimeZone
+}
+
 func uniqueStrings(values []string) []string {
 	seen := make(map[string]struct{}, len(values))
 	result := make([]string, 0, len(values))
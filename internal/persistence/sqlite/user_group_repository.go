@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// UserGroupRepository implements persistence.UserGroupRepository using SQLite
+type UserGroupRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewUserGroupRepository creates a new SQLite user group repository
+func NewUserGroupRepository(pool *ConnectionPool) *UserGroupRepository {
+	return &UserGroupRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateUserGroup inserts a user group with its members
+func (r *UserGroupRepository) CreateUserGroup(ctx context.Context, group persistence.UserGroup) error {
+	if group.ID == "" || group.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := r.helper.ExecTx(tx, `
+			INSERT INTO user_groups (id, name, description, owner_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`,
+			group.ID,
+			group.Name,
+			group.Description,
+			sql.NullString{String: group.OwnerID, Valid: group.OwnerID != ""},
+			group.CreatedAt.UTC().Format(time.RFC3339),
+			group.UpdatedAt.UTC().Format(time.RFC3339),
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		return r.insertMembersTx(tx, group)
+	})
+}
+
+// GetUserGroup retrieves a user group by ID
+func (r *UserGroupRepository) GetUserGroup(ctx context.Context, id string) (persistence.UserGroup, error) {
+	var group persistence.UserGroup
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		row := r.helper.QueryRowTx(tx,
+			"SELECT id, name, description, owner_id, created_at, updated_at FROM user_groups WHERE id = ?",
+			id,
+		)
+		var err error
+		group, err = scanUserGroup(row)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return persistence.ErrNotFound
+			}
+			return r.mapper.MapError(err)
+		}
+		return r.loadMembersTx(tx, &group)
+	})
+	if err != nil {
+		return persistence.UserGroup{}, err
+	}
+	return group, nil
+}
+
+// UpdateUserGroup changes a user group's details and owner and replaces its members
+func (r *UserGroupRepository) UpdateUserGroup(ctx context.Context, group persistence.UserGroup) error {
+	if group.ID == "" || group.Name == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := r.helper.ExecTx(tx,
+			"UPDATE user_groups SET name = ?, description = ?, owner_id = ?, updated_at = ? WHERE id = ?",
+			group.Name, group.Description, sql.NullString{String: group.OwnerID, Valid: group.OwnerID != ""}, group.UpdatedAt.UTC().Format(time.RFC3339), group.ID,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return persistence.ErrNotFound
+		}
+
+		if _, err := r.helper.ExecTx(tx, "DELETE FROM user_group_members WHERE group_id = ?", group.ID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		return r.insertMembersTx(tx, group)
+	})
+}
+
+// DeleteUserGroup removes a user group; its members and live invitations cascade
+func (r *UserGroupRepository) DeleteUserGroup(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM user_groups WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
+
+// ListUserGroups lists every user group by name
+func (r *UserGroupRepository) ListUserGroups(ctx context.Context) ([]persistence.UserGroup, error) {
+	var groups []persistence.UserGroup
+	err := r.pool.WithReadOnlyTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := r.helper.QueryTx(tx,
+			"SELECT id, name, description, owner_id, created_at, updated_at FROM user_groups ORDER BY name ASC, id ASC",
+		)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		for rows.Next() {
+			group, err := scanUserGroup(rows)
+			if err != nil {
+				rows.Close()
+				return r.mapper.MapError(err)
+			}
+			groups = append(groups, group)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return r.mapper.MapError(err)
+		}
+		rows.Close()
+
+		for i := range groups {
+			if err := r.loadMembersTx(tx, &groups[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *UserGroupRepository) insertMembersTx(tx *sql.Tx, group persistence.UserGroup) error {
+	for _, userID := range group.MemberIDs {
+		_, err := r.helper.ExecTx(tx,
+			"INSERT INTO user_group_members (group_id, user_id) VALUES (?, ?)",
+			group.ID, userID,
+		)
+		if err != nil {
+			return r.mapWriteError(err)
+		}
+	}
+	return nil
+}
+
+func (r *UserGroupRepository) loadMembersTx(tx *sql.Tx, group *persistence.UserGroup) error {
+	rows, err := r.helper.QueryTx(tx,
+		"SELECT user_id FROM user_group_members WHERE group_id = ? ORDER BY user_id ASC",
+		group.ID,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	group.MemberIDs = nil
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return r.mapper.MapError(err)
+		}
+		group.MemberIDs = append(group.MemberIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+func (r *UserGroupRepository) mapWriteError(err error) error {
+	errStr := err.Error()
+	if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+		return persistence.ErrDuplicate
+	}
+	if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+		return persistence.ErrForeignKeyViolation
+	}
+	return r.mapper.MapError(err)
+}
+
+func scanUserGroup(row rowScanner) (persistence.UserGroup, error) {
+	var group persistence.UserGroup
+	var ownerID sql.NullString
+	var createdAtStr, updatedAtStr string
+	if err := row.Scan(&group.ID, &group.Name, &group.Description, &ownerID, &createdAtStr, &updatedAtStr); err != nil {
+		return persistence.UserGroup{}, err
+	}
+	group.OwnerID = ownerID.String
+
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.UserGroup{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		return persistence.UserGroup{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	group.CreatedAt, group.UpdatedAt = createdAt, updatedAt
+	return group, nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+	"github.com/example/enterprise-scheduler/internal/persistence/sqlite/migration"
+)
+
+func TestUserGroupRepository(t *testing.T) {
+	pool, err := NewConnectionPool(migration.TempFileTestSQLiteConfig(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create connection pool: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Close() })
+
+	ctx := context.Background()
+	// Only the keys the migration references are needed here.
+	if _, err := pool.DB().ExecContext(ctx, `
+		CREATE TABLE users (id TEXT PRIMARY KEY);
+		CREATE TABLE schedules (id TEXT PRIMARY KEY);
+		INSERT INTO users (id) VALUES ('alice'), ('bob'), ('carol'), ('dave');
+		INSERT INTO schedules (id) VALUES ('s1');
+	`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	schema, err := embeddedMigrations.ReadFile("migrations/021_user_groups.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if _, err := pool.DB().ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	groups := NewUserGroupRepository(pool)
+
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	sales := persistence.UserGroup{
+		ID: "grp-1", Name: "Sales", Description: "Sales department", OwnerID: "alice",
+		MemberIDs: []string{"carol", "bob"}, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := groups.CreateUserGroup(ctx, sales); err != nil {
+		t.Fatalf("CreateUserGroup returned error: %v", err)
+	}
+	if err := groups.CreateUserGroup(ctx, persistence.UserGroup{ID: "grp-2", Name: "All Hands", MemberIDs: []string{"dave"}, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateUserGroup without an owner returned error: %v", err)
+	}
+	if err := groups.CreateUserGroup(ctx, persistence.UserGroup{ID: "grp-3", Name: "Sales", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrDuplicate) {
+		t.Fatalf("expected a duplicate name to be rejected, got %v", err)
+	}
+	if err := groups.CreateUserGroup(ctx, persistence.UserGroup{ID: "grp-3", Name: "Ghosts", MemberIDs: []string{"nobody"}, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, persistence.ErrForeignKeyViolation) {
+		t.Fatalf("expected unknown members to be rejected, got %v", err)
+	}
+
+	got, err := groups.GetUserGroup(ctx, "grp-1")
+	if err != nil {
+		t.Fatalf("GetUserGroup returned error: %v", err)
+	}
+	if got.OwnerID != "alice" || len(got.MemberIDs) != 2 || got.MemberIDs[0] != "bob" || !got.CreatedAt.Equal(now) {
+		t.Fatalf("expected members sorted and the owner kept, got %+v", got)
+	}
+
+	if listed, err := groups.ListUserGroups(ctx); err != nil || len(listed) != 2 || listed[0].ID != "grp-2" || listed[0].OwnerID != "" {
+		t.Fatalf("expected every group by name, got %+v (err %v)", listed, err)
+	}
+
+	sales.OwnerID, sales.MemberIDs = "", []string{"dave"}
+	sales.UpdatedAt = now.Add(time.Hour)
+	if err := groups.UpdateUserGroup(ctx, sales); err != nil {
+		t.Fatalf("UpdateUserGroup returned error: %v", err)
+	}
+	if got, err = groups.GetUserGroup(ctx, "grp-1"); err != nil || got.OwnerID != "" || len(got.MemberIDs) != 1 || got.MemberIDs[0] != "dave" {
+		t.Fatalf("expected the update to be stored, got %+v (err %v)", got, err)
+	}
+
+	if _, err := pool.DB().ExecContext(ctx, "INSERT INTO schedule_participant_groups (schedule_id, group_id) VALUES ('s1', 'grp-1')"); err != nil {
+		t.Fatalf("Failed to invite group: %v", err)
+	}
+	if err := groups.DeleteUserGroup(ctx, "grp-1"); err != nil {
+		t.Fatalf("DeleteUserGroup returned error: %v", err)
+	}
+	var invitations int
+	if err := pool.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM schedule_participant_groups").Scan(&invitations); err != nil || invitations != 0 {
+		t.Fatalf("expected the invitation to be removed with the group, got %d (err %v)", invitations, err)
+	}
+	if err := groups.DeleteUserGroup(ctx, "grp-1"); !errors.Is(err, persistence.ErrNotFound) {
+		t.Fatalf("expected deleting twice to report not found, got %v", err)
+	}
+}
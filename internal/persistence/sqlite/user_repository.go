@@ -44,8 +44,8 @@ func (r *UserRepository) CreateUser(ctx context.Context, user persistence.User)
 	user.UpdatedAt = now
 	
 	query := `
-		INSERT INTO users (id, email, display_name, password_hash, is_admin, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO users (id, email, display_name, password_hash, is_admin, time_zone, department_id, manager_id, location, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	
 	_, err := r.helper.Exec(ctx, query,
@@ -54,6 +54,10 @@ func (r *UserRepository) CreateUser(ctx context.Context, user persistence.User)
 		user.DisplayName,
 		user.PasswordHash,
 		user.IsAdmin,
+		timeZoneOrDefault(user.TimeZone),
+		nullableID(user.DepartmentID),
+		nullableID(user.ManagerID),
+		user.Location,
 		user.CreatedAt.Format(time.RFC3339),
 		user.UpdatedAt.Format(time.RFC3339),
 	)
@@ -82,8 +86,8 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user persistence.User)
 	
 	query := `
 		UPDATE users 
-		SET email = ?, display_name = ?, password_hash = ?, is_admin = ?, updated_at = ?
-		WHERE id = ?
+		SET email = ?, display_name = ?, password_hash = ?, is_admin = ?, time_zone = ?, department_id = ?, manager_id = ?, location = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?)
 	`
 	
 	result, err := r.helper.Exec(ctx, query,
@@ -91,8 +95,14 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user persistence.User)
 		user.DisplayName,
 		user.PasswordHash,
 		user.IsAdmin,
+		timeZoneOrDefault(user.TimeZone),
+		nullableID(user.DepartmentID),
+		nullableID(user.ManagerID),
+		user.Location,
 		user.UpdatedAt.Format(time.RFC3339),
 		user.ID,
+		user.Version,
+		user.Version,
 	)
 	
 	if err != nil {
@@ -105,7 +115,19 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user persistence.User)
 	}
 	
 	if rowsAffected == 0 {
-		return persistence.ErrNotFound
+		if user.Version == 0 {
+			return persistence.ErrNotFound
+		}
+		// Tell a missing user apart from one updated by another writer
+		var exists int
+		err := r.helper.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE id = ?", user.ID).Scan(&exists)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		if exists == 0 {
+			return persistence.ErrNotFound
+		}
+		return persistence.ErrVersionConflict
 	}
 	
 	return nil
@@ -118,12 +140,13 @@ func (r *UserRepository) GetUser(ctx context.Context, id string) (persistence.Us
 	}
 	
 	query := `
-		SELECT id, email, display_name, password_hash, is_admin, created_at, updated_at
+		SELECT id, email, display_name, password_hash, is_admin, time_zone, department_id, manager_id, location, created_at, updated_at, version
 		FROM users
 		WHERE id = ?
 	`
 	
 	var user persistence.User
+	var departmentID, managerID sql.NullString
 	var createdAtStr, updatedAtStr string
 	
 	err := r.helper.QueryRow(ctx, query, id).Scan(
@@ -132,8 +155,13 @@ func (r *UserRepository) GetUser(ctx context.Context, id string) (persistence.Us
 		&user.DisplayName,
 		&user.PasswordHash,
 		&user.IsAdmin,
+		&user.TimeZone,
+		&departmentID,
+		&managerID,
+		&user.Location,
 		&createdAtStr,
 		&updatedAtStr,
+		&user.Version,
 	)
 	
 	if err != nil {
@@ -143,6 +171,8 @@ func (r *UserRepository) GetUser(ctx context.Context, id string) (persistence.Us
 		return persistence.User{}, r.mapper.MapError(err)
 	}
 	
+	user.DepartmentID, user.ManagerID = departmentID.String, managerID.String
+	
 	// Parse timestamps
 	if user.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
 		return persistence.User{}, fmt.Errorf("failed to parse created_at: %w", err)
@@ -163,12 +193,13 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (pers
 	normalizedEmail := normalizeEmail(email)
 	
 	query := `
-		SELECT id, email, display_name, password_hash, is_admin, created_at, updated_at
+		SELECT id, email, display_name, password_hash, is_admin, time_zone, department_id, manager_id, location, created_at, updated_at, version
 		FROM users
 		WHERE email = ?
 	`
 	
 	var user persistence.User
+	var departmentID, managerID sql.NullString
 	var createdAtStr, updatedAtStr string
 	
 	err := r.helper.QueryRow(ctx, query, normalizedEmail).Scan(
@@ -177,8 +208,13 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (pers
 		&user.DisplayName,
 		&user.PasswordHash,
 		&user.IsAdmin,
+		&user.TimeZone,
+		&departmentID,
+		&managerID,
+		&user.Location,
 		&createdAtStr,
 		&updatedAtStr,
+		&user.Version,
 	)
 	
 	if err != nil {
@@ -188,6 +224,8 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (pers
 		return persistence.User{}, r.mapper.MapError(err)
 	}
 	
+	user.DepartmentID, user.ManagerID = departmentID.String, managerID.String
+	
 	// Parse timestamps
 	if user.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
 		return persistence.User{}, fmt.Errorf("failed to parse created_at: %w", err)
@@ -202,12 +240,41 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (pers
 // ListUsers returns all users ordered by creation timestamp then ID
 func (r *UserRepository) ListUsers(ctx context.Context) ([]persistence.User, error) {
 	query := `
-		SELECT id, email, display_name, password_hash, is_admin, created_at, updated_at
+		SELECT id, email, display_name, password_hash, is_admin, time_zone, department_id, manager_id, location, created_at, updated_at, version
 		FROM users
 		ORDER BY created_at ASC, id ASC
 	`
 	
-	rows, err := r.helper.Query(ctx, query)
+	return r.queryUsers(ctx, query)
+}
+
+// ListUsersPage returns users ordered by email then ID, starting after the filter's keyset bound
+func (r *UserRepository) ListUsersPage(ctx context.Context, filter persistence.UserFilter) ([]persistence.User, error) {
+	query := `
+		SELECT id, email, display_name, password_hash, is_admin, time_zone, department_id, manager_id, location, created_at, updated_at, version
+		FROM users
+	`
+	
+	var args []interface{}
+	
+	if filter.AfterID != "" {
+		query += " WHERE (email > ? OR (email = ? AND id > ?))"
+		args = append(args, filter.AfterEmail, filter.AfterEmail, filter.AfterID)
+	}
+	
+	query += " ORDER BY email ASC, id ASC"
+	
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	
+	return r.queryUsers(ctx, query, args...)
+}
+
+// queryUsers executes a user listing query and scans the result rows
+func (r *UserRepository) queryUsers(ctx context.Context, query string, args ...interface{}) ([]persistence.User, error) {
+	rows, err := r.helper.Query(ctx, query, args...)
 	if err != nil {
 		return nil, r.mapper.MapError(err)
 	}
@@ -217,6 +284,7 @@ func (r *UserRepository) ListUsers(ctx context.Context) ([]persistence.User, err
 	
 	for rows.Next() {
 		var user persistence.User
+		var departmentID, managerID sql.NullString
 		var createdAtStr, updatedAtStr string
 		
 		err := rows.Scan(
@@ -225,14 +293,21 @@ func (r *UserRepository) ListUsers(ctx context.Context) ([]persistence.User, err
 			&user.DisplayName,
 			&user.PasswordHash,
 			&user.IsAdmin,
+			&user.TimeZone,
+			&departmentID,
+			&managerID,
+			&user.Location,
 			&createdAtStr,
 			&updatedAtStr,
+			&user.Version,
 		)
 		
 		if err != nil {
 			return nil, r.mapper.MapError(err)
 		}
 		
+		user.DepartmentID, user.ManagerID = departmentID.String, managerID.String
+		
 		// Parse timestamps
 		if user.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
 			return nil, fmt.Errorf("failed to parse created_at: %w", err)
@@ -327,6 +402,11 @@ func (r *UserRepository) mapUserError(err error) error {
 	return r.mapper.MapError(err)
 }
 
+// nullableID stores an empty reference as NULL so foreign keys allow it
+func nullableID(id string) sql.NullString {
+	return sql.NullString{String: id, Valid: id != ""}
+}
+
 // normalizeEmail normalizes email addresses for consistent storage and lookup
 func normalizeEmail(email string) string {
 	return strings.ToLower(strings.TrimSpace(email))
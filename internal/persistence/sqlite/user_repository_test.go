@@ -239,6 +239,11 @@ func setupUserRepositoryTest(t *testing.T) (*UserRepository, func()) {
 				display_name TEXT NOT NULL,
 				password_hash TEXT NOT NULL,
 				is_admin INTEGER NOT NULL DEFAULT 0,
+				time_zone TEXT NOT NULL DEFAULT 'Asia/Tokyo',
+				version INTEGER NOT NULL DEFAULT 1,
+				department_id TEXT,
+				manager_id TEXT,
+				location TEXT NOT NULL DEFAULT '',
 				created_at TEXT NOT NULL,
 				updated_at TEXT NOT NULL
 			);
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// ViewLinkRepository implements persistence.ViewLinkRepository using SQLite
+type ViewLinkRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewViewLinkRepository creates a new SQLite view link repository
+func NewViewLinkRepository(pool *ConnectionPool) *ViewLinkRepository {
+	return &ViewLinkRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+// CreateViewLink inserts a new view link; a taken code yields ErrDuplicate
+func (r *ViewLinkRepository) CreateViewLink(ctx context.Context, link persistence.ViewLink) error {
+	if link.Code == "" || link.CreatorID == "" || link.View == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO view_links (code, creator_id, view, date, participant_ids, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		link.Code,
+		link.CreatorID,
+		link.View,
+		link.Date.Format(holidayDateLayout),
+		strings.Join(link.ParticipantIDs, ","),
+		link.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		errStr := err.Error()
+		if containsAny(errStr, []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		if containsAny(errStr, []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrForeignKeyViolation
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetViewLink retrieves the view link with the given code
+func (r *ViewLinkRepository) GetViewLink(ctx context.Context, code string) (persistence.ViewLink, error) {
+	if code == "" {
+		return persistence.ViewLink{}, persistence.ErrNotFound
+	}
+
+	row := r.helper.QueryRow(ctx,
+		"SELECT code, creator_id, view, date, participant_ids, created_at FROM view_links WHERE code = ?",
+		code,
+	)
+	var link persistence.ViewLink
+	var dateStr, participantIDs, createdAtStr string
+	if err := row.Scan(&link.Code, &link.CreatorID, &link.View, &dateStr, &participantIDs, &createdAtStr); err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.ViewLink{}, persistence.ErrNotFound
+		}
+		return persistence.ViewLink{}, r.mapper.MapError(err)
+	}
+
+	date, err := time.Parse(holidayDateLayout, dateStr)
+	if err != nil {
+		return persistence.ViewLink{}, fmt.Errorf("failed to parse date: %w", err)
+	}
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return persistence.ViewLink{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	link.Date, link.CreatedAt = date, createdAt
+	if participantIDs != "" {
+		link.ParticipantIDs = strings.Split(participantIDs, ",")
+	}
+	return link, nil
+}
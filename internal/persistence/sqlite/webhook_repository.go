@@ -0,0 +1,475 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/example/enterprise-scheduler/internal/persistence"
+)
+
+// WebhookRepository implements persistence.WebhookRepository using SQLite
+type WebhookRepository struct {
+	pool   *ConnectionPool
+	helper *QueryHelper
+	mapper *ErrorMapper
+}
+
+// NewWebhookRepository creates a new SQLite webhook repository
+func NewWebhookRepository(pool *ConnectionPool) *WebhookRepository {
+	return &WebhookRepository{
+		pool:   pool,
+		helper: NewQueryHelper(pool),
+		mapper: NewErrorMapper(),
+	}
+}
+
+const webhookDeliveryColumns = `
+	d.id, d.subscription_id, d.event_id, d.event_type, d.status, d.attempts, d.next_attempt_at,
+	d.response_status, d.last_error, d.created_at, d.updated_at`
+
+// CreateWebhook inserts a new webhook subscription
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, subscription persistence.WebhookSubscription) error {
+	if subscription.ID == "" || subscription.URL == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO webhook_subscriptions (id, url, secret, event_types, active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		subscription.ID,
+		subscription.URL,
+		subscription.Secret,
+		strings.Join(subscription.EventTypes, ","),
+		subscription.Active,
+		subscription.CreatedAt.UTC().Format(time.RFC3339),
+		subscription.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetWebhook retrieves a webhook subscription by ID
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id string) (persistence.WebhookSubscription, error) {
+	if id == "" {
+		return persistence.WebhookSubscription{}, persistence.ErrNotFound
+	}
+
+	row := r.helper.QueryRow(ctx,
+		"SELECT id, url, secret, event_types, active, created_at, updated_at FROM webhook_subscriptions WHERE id = ?",
+		id,
+	)
+	subscription, err := scanWebhookSubscription(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.WebhookSubscription{}, persistence.ErrNotFound
+		}
+		return persistence.WebhookSubscription{}, err
+	}
+	return subscription, nil
+}
+
+// UpdateWebhook updates a webhook subscription's URL, event filter and state
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, subscription persistence.WebhookSubscription) error {
+	result, err := r.helper.Exec(ctx, `
+		UPDATE webhook_subscriptions
+		SET url = ?, event_types = ?, active = ?, updated_at = ?
+		WHERE id = ?
+	`,
+		subscription.URL,
+		strings.Join(subscription.EventTypes, ","),
+		subscription.Active,
+		subscription.UpdatedAt.UTC().Format(time.RFC3339),
+		subscription.ID,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return requireAffected(result)
+}
+
+// DeleteWebhook removes a webhook subscription and, by cascade, its deliveries
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := r.helper.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = ?", id)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return requireAffected(result)
+}
+
+// ListWebhooks returns every webhook subscription ordered by creation
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]persistence.WebhookSubscription, error) {
+	rows, err := r.helper.Query(ctx,
+		"SELECT id, url, secret, event_types, active, created_at, updated_at FROM webhook_subscriptions ORDER BY created_at ASC, id ASC",
+	)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var subscriptions []persistence.WebhookSubscription
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return subscriptions, nil
+}
+
+// AppendOutboxEvent stores a published event for later fan-out
+func (r *WebhookRepository) AppendOutboxEvent(ctx context.Context, event persistence.OutboxEvent) error {
+	if event.ID == "" || event.Type == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	var actorID sql.NullString
+	if event.ActorID != "" {
+		actorID = sql.NullString{String: event.ActorID, Valid: true}
+	}
+
+	// The sequence is assigned inside the INSERT so that concurrent writers,
+	// which SQLite serialises, always produce increasing values.
+	_, err := r.helper.Exec(ctx, `
+		INSERT INTO outbox_events (id, type, resource_id, actor_id, payload, occurred_at, sequence)
+		SELECT ?, ?, ?, ?, ?, ?, COALESCE(MAX(sequence), 0) + 1 FROM outbox_events
+	`,
+		event.ID,
+		event.Type,
+		event.ResourceID,
+		actorID,
+		string(event.Payload),
+		event.OccurredAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"UNIQUE constraint failed", "PRIMARY KEY"}) {
+			return persistence.ErrDuplicate
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// ListPendingOutboxEvents returns events that have not been fanned out, oldest first
+func (r *WebhookRepository) ListPendingOutboxEvents(ctx context.Context, limit int) ([]persistence.OutboxEvent, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.listOutboxEvents(ctx, `
+		SELECT id, type, resource_id, actor_id, payload, occurred_at, sequence
+		FROM outbox_events
+		WHERE dispatched_at IS NULL
+		ORDER BY occurred_at ASC, id ASC
+		LIMIT ?
+	`, limit)
+}
+
+// ListOutboxEventsAfter returns events with a sequence greater than after, in sequence order
+func (r *WebhookRepository) ListOutboxEventsAfter(ctx context.Context, after int64, limit int) ([]persistence.OutboxEvent, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.listOutboxEvents(ctx, `
+		SELECT id, type, resource_id, actor_id, payload, occurred_at, sequence
+		FROM outbox_events
+		WHERE sequence > ?
+		ORDER BY sequence ASC
+		LIMIT ?
+	`, after, limit)
+}
+
+// LatestOutboxSequence returns the sequence of the newest event, or 0 when the outbox is empty
+func (r *WebhookRepository) LatestOutboxSequence(ctx context.Context) (int64, error) {
+	var latest int64
+	if err := r.helper.QueryRow(ctx, "SELECT COALESCE(MAX(sequence), 0) FROM outbox_events").Scan(&latest); err != nil {
+		return 0, r.mapper.MapError(err)
+	}
+	return latest, nil
+}
+
+func (r *WebhookRepository) listOutboxEvents(ctx context.Context, query string, args ...any) ([]persistence.OutboxEvent, error) {
+	rows, err := r.helper.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var events []persistence.OutboxEvent
+	for rows.Next() {
+		var event persistence.OutboxEvent
+		var actorID sql.NullString
+		var sequence sql.NullInt64
+		var payload, occurredAtStr string
+		if err := rows.Scan(&event.ID, &event.Type, &event.ResourceID, &actorID, &payload, &occurredAtStr, &sequence); err != nil {
+			return nil, r.mapper.MapError(err)
+		}
+		event.ActorID = actorID.String
+		event.Payload = []byte(payload)
+		event.Sequence = sequence.Int64
+		if event.OccurredAt, err = time.Parse(time.RFC3339, occurredAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse occurred_at: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return events, nil
+}
+
+// EnqueueWebhookDeliveries inserts the deliveries of an event and marks the
+// event dispatched in one transaction, so an event is fanned out exactly once.
+func (r *WebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, eventID string, deliveries []persistence.WebhookDelivery, dispatchedAt time.Time) error {
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, delivery := range deliveries {
+			if err := r.insertDelivery(tx, delivery); err != nil {
+				return err
+			}
+		}
+
+		result, err := r.helper.ExecTx(tx,
+			"UPDATE outbox_events SET dispatched_at = ? WHERE id = ? AND dispatched_at IS NULL",
+			dispatchedAt.UTC().Format(time.RFC3339), eventID,
+		)
+		if err != nil {
+			return r.mapper.MapError(err)
+		}
+		return requireAffected(result)
+	})
+}
+
+// CreateWebhookDelivery inserts a single delivery, e.g. a manual redelivery
+func (r *WebhookRepository) CreateWebhookDelivery(ctx context.Context, delivery persistence.WebhookDelivery) error {
+	return r.pool.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return r.insertDelivery(tx, delivery)
+	})
+}
+
+func (r *WebhookRepository) insertDelivery(tx *sql.Tx, delivery persistence.WebhookDelivery) error {
+	if delivery.ID == "" || delivery.SubscriptionID == "" || delivery.EventID == "" {
+		return persistence.ErrConstraintViolation
+	}
+
+	_, err := r.helper.ExecTx(tx, `
+		INSERT INTO webhook_deliveries
+		(id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt.UTC().Format(time.RFC3339),
+		delivery.CreatedAt.UTC().Format(time.RFC3339),
+		delivery.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		if containsAny(err.Error(), []string{"FOREIGN KEY constraint failed"}) {
+			return persistence.ErrNotFound
+		}
+		return r.mapper.MapError(err)
+	}
+	return nil
+}
+
+// GetWebhookDelivery retrieves a delivery by ID
+func (r *WebhookRepository) GetWebhookDelivery(ctx context.Context, id string) (persistence.WebhookDelivery, error) {
+	if id == "" {
+		return persistence.WebhookDelivery{}, persistence.ErrNotFound
+	}
+
+	row := r.helper.QueryRow(ctx, "SELECT"+webhookDeliveryColumns+" FROM webhook_deliveries d WHERE d.id = ?", id)
+	delivery, err := scanWebhookDelivery(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return persistence.WebhookDelivery{}, persistence.ErrNotFound
+		}
+		return persistence.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt
+func (r *WebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery persistence.WebhookDelivery) error {
+	var responseStatus sql.NullInt64
+	if delivery.ResponseStatus != 0 {
+		responseStatus = sql.NullInt64{Int64: int64(delivery.ResponseStatus), Valid: true}
+	}
+	var lastError sql.NullString
+	if delivery.LastError != "" {
+		lastError = sql.NullString{String: delivery.LastError, Valid: true}
+	}
+
+	result, err := r.helper.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, response_status = ?, last_error = ?, updated_at = ?
+		WHERE id = ?
+	`,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt.UTC().Format(time.RFC3339),
+		responseStatus,
+		lastError,
+		delivery.UpdatedAt.UTC().Format(time.RFC3339),
+		delivery.ID,
+	)
+	if err != nil {
+		return r.mapper.MapError(err)
+	}
+	return requireAffected(result)
+}
+
+// ListDueWebhookDeliveries returns pending deliveries of active subscriptions
+// that are due at now, with the event payload attached
+func (r *WebhookRepository) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]persistence.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.helper.Query(ctx, `
+		SELECT`+webhookDeliveryColumns+`, e.payload
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.status = 'pending' AND s.active = 1 AND d.next_attempt_at <= ?
+		ORDER BY d.next_attempt_at ASC, d.id ASC
+		LIMIT ?
+	`, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var deliveries []persistence.WebhookDelivery
+	for rows.Next() {
+		var payload string
+		delivery, err := scanWebhookDelivery(rows, &payload)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Payload = []byte(payload)
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return deliveries, nil
+}
+
+// ListWebhookDeliveries returns a subscription's most recent deliveries first
+func (r *WebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int) ([]persistence.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.helper.Query(ctx, `
+		SELECT`+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.subscription_id = ?
+		ORDER BY d.created_at DESC, d.id DESC
+		LIMIT ?
+	`, subscriptionID, limit)
+	if err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+	defer rows.Close()
+
+	var deliveries []persistence.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapper.MapError(err)
+	}
+
+	return deliveries, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhookSubscription(row rowScanner) (persistence.WebhookSubscription, error) {
+	var subscription persistence.WebhookSubscription
+	var eventTypes, createdAtStr, updatedAtStr string
+	if err := row.Scan(&subscription.ID, &subscription.URL, &subscription.Secret, &eventTypes, &subscription.Active, &createdAtStr, &updatedAtStr); err != nil {
+		return persistence.WebhookSubscription{}, err
+	}
+	if eventTypes != "" {
+		subscription.EventTypes = strings.Split(eventTypes, ",")
+	}
+
+	var err error
+	if subscription.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+		return persistence.WebhookSubscription{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if subscription.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+		return persistence.WebhookSubscription{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	return subscription, nil
+}
+
+// scanWebhookDelivery scans webhookDeliveryColumns followed by any extra destinations.
+func scanWebhookDelivery(row rowScanner, extra ...any) (persistence.WebhookDelivery, error) {
+	var delivery persistence.WebhookDelivery
+	var nextAttemptAtStr, createdAtStr, updatedAtStr string
+	var responseStatus sql.NullInt64
+	var lastError sql.NullString
+	dest := append([]any{
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &delivery.Status,
+		&delivery.Attempts, &nextAttemptAtStr, &responseStatus, &lastError, &createdAtStr, &updatedAtStr,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return persistence.WebhookDelivery{}, err
+	}
+	delivery.ResponseStatus = int(responseStatus.Int64)
+	delivery.LastError = lastError.String
+
+	var err error
+	if delivery.NextAttemptAt, err = time.Parse(time.RFC3339, nextAttemptAtStr); err != nil {
+		return persistence.WebhookDelivery{}, fmt.Errorf("failed to parse next_attempt_at: %w", err)
+	}
+	if delivery.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+		return persistence.WebhookDelivery{}, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if delivery.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+		return persistence.WebhookDelivery{}, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	return delivery, nil
+}
+
+// requireAffected maps an update or delete that matched no row to ErrNotFound.
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return persistence.ErrNotFound
+	}
+	return nil
+}
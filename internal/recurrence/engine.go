@@ -20,20 +20,51 @@ const (
 	FrequencyWeekly
 )
 
+// HolidayPolicy controls what happens to occurrences that fall on a holiday.
+type HolidayPolicy int
+
+const (
+	// HolidayPolicyNone generates occurrences on holidays like any other day.
+	HolidayPolicyNone HolidayPolicy = iota
+	// HolidayPolicySkip drops occurrences that fall on a holiday.
+	HolidayPolicySkip
+	// HolidayPolicyNextBusinessDay moves occurrences that fall on a holiday to
+	// the next weekday that is not a holiday, keeping their local start time.
+	HolidayPolicyNextBusinessDay
+)
+
+// maxHolidayShiftDays bounds how far an occurrence may move forward under
+// HolidayPolicyNextBusinessDay; occurrences that cannot be placed are dropped.
+const maxHolidayShiftDays = 31
+
+// HolidayCalendar reports non-working days. IsHoliday receives a time in the
+// engine's location and judges its calendar date as read there.
+type HolidayCalendar interface {
+	IsHoliday(day time.Time) bool
+}
+
 // Rule describes a recurrence configuration for a schedule.
 type Rule struct {
-	ID         string
-	ScheduleID string
-	Frequency  Frequency
-	Weekdays   []time.Weekday
-	StartsOn   time.Time
-	EndsOn     *time.Time
+	ID            string
+	ScheduleID    string
+	Frequency     Frequency
+	Weekdays      []time.Weekday
+	StartsOn      time.Time
+	EndsOn        *time.Time
+	HolidayPolicy HolidayPolicy
 }
 
 // GenerateOptions defines optional range bounds for occurrence generation.
 type GenerateOptions struct {
 	RangeStart *time.Time
 	RangeEnd   *time.Time
+	// AllDay treats the base schedule as date-only: occurrences start at local
+	// midnight, span the same number of calendar days as the base schedule, and
+	// are kept when any of their days overlaps [RangeStart, RangeEnd).
+	AllDay bool
+	// Holidays is consulted for rules with a HolidayPolicy. Without a calendar
+	// the policy has no effect.
+	Holidays HolidayCalendar
 }
 
 // Occurrence represents a generated instance of a recurrence rule.
@@ -50,7 +81,9 @@ type Engine struct {
 }
 
 // NewEngine constructs an Engine that normalizes results to the provided location.
-// If loc is nil, Asia/Tokyo (JST) is used.
+// If loc is nil, Asia/Tokyo (JST) is used. Occurrences keep the base schedule's
+// wall-clock time in loc, so rules expanded in zones with daylight saving time
+// shift their UTC instant across transitions rather than their local time.
 func NewEngine(loc *time.Location) *Engine {
 	if loc == nil {
 		loc = jst
@@ -70,10 +103,16 @@ var ErrInvalidDuration = errors.New("recurrence: schedule duration must be posit
 // GenerateOccurrences produces scheduled occurrences within the configured window.
 //
 // The engine enforces the following semantics:
-//   - All timestamps are normalized to the engine's timezone (default JST).
+//   - All timestamps are normalized to the engine's timezone (default JST) and
+//     candidates advance by calendar days so local start times survive DST changes.
 //   - The generation window is bounded by the rule's EndsOn and the optional range end.
 //   - Weekday selections are respected for weekly rules; daily rules may optionally
 //     filter by weekdays when provided.
+//   - All-day generation (GenerateOptions.AllDay) works in whole local days and keeps
+//     multi-day occurrences that started before the range but still overlap it.
+//   - Rules with a HolidayPolicy skip or shift occurrences on days reported by
+//     GenerateOptions.Holidays. Shifted occurrences that land on an existing
+//     occurrence are merged into it.
 func (e *Engine) GenerateOccurrences(rule Rule, baseStart, baseEnd time.Time, opts GenerateOptions) ([]Occurrence, error) {
 	loc := e.location
 	if loc == nil {
@@ -86,6 +125,11 @@ func (e *Engine) GenerateOccurrences(rule Rule, baseStart, baseEnd time.Time, op
 		return nil, ErrInvalidDuration
 	}
 	duration := baseEnd.Sub(baseStart)
+	spanDays := 0
+	if opts.AllDay {
+		baseStart = startOfLocalDay(baseStart, loc)
+		spanDays = calendarDaysBetween(baseStart, baseEnd, loc)
+	}
 
 	ruleStart := rule.StartsOn.In(loc)
 	var ruleEnd time.Time
@@ -123,6 +167,10 @@ func (e *Engine) GenerateOccurrences(rule Rule, baseStart, baseEnd time.Time, op
 	lowerBound := ruleStart
 	if !rangeStart.IsZero() && rangeStart.After(lowerBound) {
 		lowerBound = rangeStart
+		if opts.AllDay {
+			// Multi-day occurrences starting before the range may still overlap it.
+			lowerBound = startOfLocalDay(rangeStart, loc).AddDate(0, 0, 1-spanDays)
+		}
 	}
 	if lowerBound.After(upperBound) {
 		return nil, nil
@@ -133,21 +181,56 @@ func (e *Engine) GenerateOccurrences(rule Rule, baseStart, baseEnd time.Time, op
 		weekdaySet[day] = struct{}{}
 	}
 
-	current := firstCandidate(ruleStart, lowerBound, baseStart, loc)
+	policy := rule.HolidayPolicy
+	if opts.Holidays == nil {
+		policy = HolidayPolicyNone
+	}
+	evalFrom := lowerBound
+	if policy == HolidayPolicyNextBusinessDay {
+		// Occurrences on holidays just before the window may shift into it.
+		evalFrom = lowerBound.AddDate(0, 0, -maxHolidayShiftDays)
+	}
+
+	current := firstCandidate(ruleStart, evalFrom, baseStart, loc)
 	occurrences := make([]Occurrence, 0)
 
 	for !current.After(upperBound) {
+		if opts.AllDay && !rangeEnd.IsZero() && !current.Before(rangeEnd) {
+			break
+		}
+
 		include, err := shouldInclude(rule.Frequency, weekdaySet, current.Weekday())
 		if err != nil {
 			return nil, err
 		}
 
+		start := current
+		if include && policy != HolidayPolicyNone && opts.Holidays.IsHoliday(current) {
+			if policy == HolidayPolicySkip {
+				include = false
+			} else {
+				start, include = nextBusinessDay(current, opts.Holidays)
+			}
+		}
+		if include && evalFrom.Before(lowerBound) {
+			// Candidates evaluated early for shifting must still land in the window.
+			include = !start.Before(lowerBound) && !start.After(upperBound) &&
+				!(opts.AllDay && !rangeEnd.IsZero() && !start.Before(rangeEnd))
+		}
+		if include && len(occurrences) > 0 && occurrences[len(occurrences)-1].Start.Equal(start) {
+			include = false
+		}
+
 		if include {
+			end := start.Add(duration)
+			if opts.AllDay {
+				end = start.AddDate(0, 0, spanDays)
+			}
 			occurrences = append(occurrences, Occurrence{
 				ScheduleID: rule.ScheduleID,
 				RuleID:     rule.ID,
-				Start:      current,
-				End:        current.Add(duration),
+				Start:      start,
+				End:        end,
 			})
 		}
 
@@ -157,6 +240,23 @@ func (e *Engine) GenerateOccurrences(rule Rule, baseStart, baseEnd time.Time, op
 	return occurrences, nil
 }
 
+// nextBusinessDay returns the first weekday after day that is not a holiday,
+// keeping day's local time. It reports false when none is found within
+// maxHolidayShiftDays.
+func nextBusinessDay(day time.Time, holidays HolidayCalendar) (time.Time, bool) {
+	for i := 1; i <= maxHolidayShiftDays; i++ {
+		candidate := day.AddDate(0, 0, i)
+		switch candidate.Weekday() {
+		case time.Saturday, time.Sunday:
+			continue
+		}
+		if !holidays.IsHoliday(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
 func firstCandidate(ruleStart, lowerBound, template time.Time, loc *time.Location) time.Time {
 	target := lowerBound
 	if target.Before(ruleStart) {
@@ -165,12 +265,29 @@ func firstCandidate(ruleStart, lowerBound, template time.Time, loc *time.Locatio
 
 	candidate := combineDateTime(target, template, loc)
 	for candidate.Before(target) || candidate.Before(ruleStart) {
-		candidate = candidate.Add(24 * time.Hour)
+		candidate = candidate.AddDate(0, 0, 1)
 	}
 
 	return candidate
 }
 
+func startOfLocalDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// calendarDaysBetween counts the local days touched by [start, end), at least one.
+func calendarDaysBetween(start, end time.Time, loc *time.Location) int {
+	days := 0
+	for day := startOfLocalDay(start, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+	if days == 0 {
+		days = 1
+	}
+	return days
+}
+
 func combineDateTime(dateSource, template time.Time, loc *time.Location) time.Time {
 	y, m, d := dateSource.In(loc).Date()
 	return time.Date(y, m, d, template.In(loc).Hour(), template.In(loc).Minute(), template.In(loc).Second(), template.In(loc).Nanosecond(), loc)
@@ -199,7 +316,7 @@ func shouldInclude(freq Frequency, weekdaySet map[time.Weekday]struct{}, day tim
 
 func nextWeeklyCandidate(current time.Time, weekdays map[time.Weekday]struct{}) time.Time {
 	if len(weekdays) == 0 {
-		return current.Add(24 * time.Hour)
+		return current.AddDate(0, 0, 1)
 	}
 
 	sortedWeekdays := make([]time.Weekday, 0, len(weekdays))
@@ -225,5 +342,5 @@ func nextWeeklyCandidate(current time.Time, weekdays map[time.Weekday]struct{})
 		daysToAdd = (7 - int(currentWeekday)) + int(firstDayOfWeek)
 	}
 
-	return current.Add(time.Duration(daysToAdd) * 24 * time.Hour)
+	return current.AddDate(0, 0, daysToAdd)
 }
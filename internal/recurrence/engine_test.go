@@ -141,6 +141,123 @@ func TestEngine_GenerateOccurrences(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps wall-clock time across daylight saving transitions", func(t *testing.T) {
+		t.Parallel()
+
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+
+		engine := NewEngine(newYork)
+		start := time.Date(2024, time.March, 4, 9, 0, 0, 0, newYork)
+		end := start.Add(30 * time.Minute)
+		until := time.Date(2024, time.March, 18, 23, 59, 0, 0, newYork)
+		rule := Rule{
+			ID:         "rule-dst",
+			ScheduleID: "schedule-dst",
+			Frequency:  FrequencyWeekly,
+			Weekdays:   []time.Weekday{time.Monday},
+			StartsOn:   start,
+			EndsOn:     &until,
+		}
+
+		occurrences, err := engine.GenerateOccurrences(rule, start, end, GenerateOptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(occurrences) != 3 {
+			t.Fatalf("expected three weekly occurrences, got %d", len(occurrences))
+		}
+
+		for _, occurrence := range occurrences {
+			if occurrence.Start.Hour() != 9 || occurrence.Start.Minute() != 0 {
+				t.Fatalf("expected 09:00 local start, got %v", occurrence.Start)
+			}
+		}
+		if got := occurrences[0].Start.UTC().Hour(); got != 14 {
+			t.Fatalf("expected 14:00 UTC before DST, got %d", got)
+		}
+		if got := occurrences[2].Start.UTC().Hour(); got != 13 {
+			t.Fatalf("expected 13:00 UTC after DST, got %d", got)
+		}
+	})
+
+	t.Run("expands multi-day all-day schedules by calendar day", func(t *testing.T) {
+		t.Parallel()
+
+		engine := NewEngine(time.UTC)
+		start := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 2)
+		rule := Rule{
+			ID:         "rule-all-day",
+			ScheduleID: "schedule-all-day",
+			Frequency:  FrequencyWeekly,
+			Weekdays:   []time.Weekday{time.Monday},
+			StartsOn:   start,
+		}
+		rangeStart := time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)
+		rangeEnd := time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)
+
+		occurrences, err := engine.GenerateOccurrences(rule, start, end, GenerateOptions{
+			RangeStart: &rangeStart,
+			RangeEnd:   &rangeEnd,
+			AllDay:     true,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(occurrences) != 1 {
+			t.Fatalf("expected the overlapping Monday occurrence only, got %#v", occurrences)
+		}
+		wantStart := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+		if !occurrences[0].Start.Equal(wantStart) || !occurrences[0].End.Equal(wantStart.AddDate(0, 0, 2)) {
+			t.Fatalf("expected occurrence spanning March 11-12, got %v - %v", occurrences[0].Start, occurrences[0].End)
+		}
+	})
+
+	t.Run("skips or shifts occurrences on holidays", func(t *testing.T) {
+		t.Parallel()
+
+		engine := NewEngine(nil)
+		holidays := holidaySet{"2024-04-29": true, "2024-05-06": true}
+		rangeStart := time.Date(2024, time.April, 22, 0, 0, 0, 0, jst)
+		rangeEnd := time.Date(2024, time.May, 14, 0, 0, 0, 0, jst)
+		rule := Rule{
+			ID:         "rule-holiday",
+			ScheduleID: "schedule-holiday",
+			Frequency:  FrequencyWeekly,
+			Weekdays:   []time.Weekday{time.Monday},
+			StartsOn:   baseStart,
+		}
+
+		starts := func(policy HolidayPolicy, from time.Time) []string {
+			t.Helper()
+			rule := rule
+			rule.HolidayPolicy = policy
+			occurrences, err := engine.GenerateOccurrences(rule, baseStart, baseEnd, GenerateOptions{
+				RangeStart: &from,
+				RangeEnd:   &rangeEnd,
+				Holidays:   holidays,
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			out := make([]string, 0, len(occurrences))
+			for _, occurrence := range occurrences {
+				out = append(out, occurrence.Start.Format("2006-01-02T15:04"))
+			}
+			return out
+		}
+
+		assertStarts(t, starts(HolidayPolicyNone, rangeStart), "2024-04-22T09:00", "2024-04-29T09:00", "2024-05-06T09:00", "2024-05-13T09:00")
+		assertStarts(t, starts(HolidayPolicySkip, rangeStart), "2024-04-22T09:00", "2024-05-13T09:00")
+		assertStarts(t, starts(HolidayPolicyNextBusinessDay, rangeStart), "2024-04-22T09:00", "2024-04-30T09:00", "2024-05-07T09:00", "2024-05-13T09:00")
+
+		// A holiday just before the range may shift into it.
+		assertStarts(t, starts(HolidayPolicyNextBusinessDay, time.Date(2024, time.May, 7, 0, 0, 0, 0, jst)), "2024-05-07T09:00", "2024-05-13T09:00")
+	})
+
 	t.Run("links generated occurrences back to their source schedule", func(t *testing.T) {
 		t.Parallel()
 
@@ -172,3 +289,21 @@ func TestEngine_GenerateOccurrences(t *testing.T) {
 		}
 	})
 }
+
+type holidaySet map[string]bool
+
+func (h holidaySet) IsHoliday(day time.Time) bool {
+	return h[day.Format("2006-01-02")]
+}
+
+func assertStarts(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected starts %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected starts %v, got %v", want, got)
+		}
+	}
+}
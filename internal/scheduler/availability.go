@@ -0,0 +1,123 @@
+package scheduler
+
+import "time"
+
+// WorkingWindow is a span of working time on a weekday, expressed as offsets
+// from local midnight in the participant's time zone.
+type WorkingWindow struct {
+	Weekday time.Weekday
+	Start   time.Duration
+	End     time.Duration
+}
+
+// Absence is an out-of-office period. Participants with AutoDecline absences
+// decline new invitations that overlap them.
+type Absence struct {
+	ID          string
+	Start       time.Time
+	End         time.Time
+	AutoDecline bool
+}
+
+// Availability describes when a participant can be booked. An empty
+// WorkingHours slice means the participant has no working-hours restriction.
+type Availability struct {
+	Location     *time.Location
+	WorkingHours []WorkingWindow
+	Absences     []Absence
+}
+
+// DetectAvailabilityConflicts reports candidate participants who are out of
+// office or outside their working hours. A participant who auto-declines is
+// reported once with ConflictTypeAutoDeclined and receives no other availability conflicts.
+func DetectAvailabilityConflicts(candidate Schedule, availability map[string]Availability) []Conflict {
+	conflicts := make([]Conflict, 0)
+	if candidate.Transparent {
+		return conflicts
+	}
+
+	for _, participant := range candidate.Participants {
+		profile, ok := availability[participant]
+		if !ok {
+			continue
+		}
+
+		var absences []Conflict
+		declined := false
+		for _, absence := range profile.Absences {
+			if !absence.Start.Before(candidate.End) || !candidate.Start.Before(absence.End) {
+				continue
+			}
+			if absence.AutoDecline {
+				conflicts = append(conflicts, Conflict{
+					Type:        ConflictTypeAutoDeclined,
+					Participant: participant,
+					AbsenceID:   absence.ID,
+				})
+				declined = true
+				break
+			}
+			absences = append(absences, Conflict{
+				Type:        ConflictTypeOutOfOffice,
+				Participant: participant,
+				AbsenceID:   absence.ID,
+			})
+		}
+		if declined {
+			continue
+		}
+		conflicts = append(conflicts, absences...)
+
+		if len(profile.WorkingHours) > 0 && !withinWorkingHours(candidate.Start, candidate.End, profile) {
+			conflicts = append(conflicts, Conflict{
+				Type:        ConflictTypeOutsideWorkingHours,
+				Participant: participant,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// withinWorkingHours reports whether every local day touched by [start, end)
+// is covered by a single working window for that weekday.
+func withinWorkingHours(start, end time.Time, profile Availability) bool {
+	loc := profile.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for day.Before(end) {
+		next := day.AddDate(0, 0, 1)
+		segmentStart := start
+		if segmentStart.Before(day) {
+			segmentStart = day
+		}
+		segmentEnd := end
+		if segmentEnd.After(next) {
+			segmentEnd = next
+		}
+		if !segmentCovered(day, segmentStart, segmentEnd, profile.WorkingHours) {
+			return false
+		}
+		day = next
+	}
+	return true
+}
+
+func segmentCovered(day, start, end time.Time, windows []WorkingWindow) bool {
+	for _, window := range windows {
+		if window.Weekday != day.Weekday() {
+			continue
+		}
+		// Build the bounds from wall-clock minutes so DST days keep local times.
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, int(window.Start/time.Minute), 0, 0, day.Location())
+		windowEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, int(window.End/time.Minute), 0, 0, day.Location())
+		if !start.Before(windowStart) && !end.After(windowEnd) {
+			return true
+		}
+	}
+	return false
+}
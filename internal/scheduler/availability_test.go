@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectAvailabilityConflicts(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	weekdays := make([]WorkingWindow, 0, 5)
+	for day := time.Monday; day <= time.Friday; day++ {
+		weekdays = append(weekdays, WorkingWindow{Weekday: day, Start: 9 * time.Hour, End: 18 * time.Hour})
+	}
+
+	t.Run("flags meetings outside working hours", func(t *testing.T) {
+		availability := map[string]Availability{
+			"alice": {Location: jst, WorkingHours: weekdays},
+		}
+		inside := Schedule{
+			Participants: []string{"alice"},
+			Start:        mustParseTime(t, "2024-03-01T09:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-01T18:00:00+09:00"),
+		}
+		if conflicts := DetectAvailabilityConflicts(inside, availability); len(conflicts) != 0 {
+			t.Fatalf("expected no conflicts inside working hours, got %#v", conflicts)
+		}
+
+		late := Schedule{
+			Participants: []string{"alice"},
+			Start:        mustParseTime(t, "2024-03-01T17:30:00+09:00"),
+			End:          mustParseTime(t, "2024-03-01T18:30:00+09:00"),
+		}
+		conflicts := DetectAvailabilityConflicts(late, availability)
+		if len(conflicts) != 1 || conflicts[0].Type != ConflictTypeOutsideWorkingHours || conflicts[0].Participant != "alice" {
+			t.Fatalf("expected outside working hours conflict, got %#v", conflicts)
+		}
+
+		weekend := Schedule{
+			Participants: []string{"alice"},
+			Start:        mustParseTime(t, "2024-03-02T10:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-02T11:00:00+09:00"),
+		}
+		if conflicts := DetectAvailabilityConflicts(weekend, availability); len(conflicts) != 1 {
+			t.Fatalf("expected weekend meeting to be flagged, got %#v", conflicts)
+		}
+	})
+
+	t.Run("evaluates working hours in the participant's zone", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		availability := map[string]Availability{
+			"bob": {Location: newYork, WorkingHours: weekdays},
+		}
+		// 23:00 JST on Friday is 09:00 in New York.
+		candidate := Schedule{
+			Participants: []string{"bob"},
+			Start:        mustParseTime(t, "2024-03-01T23:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-02T00:00:00+09:00"),
+		}
+		if conflicts := DetectAvailabilityConflicts(candidate, availability); len(conflicts) != 0 {
+			t.Fatalf("expected meeting inside New York working hours, got %#v", conflicts)
+		}
+	})
+
+	t.Run("reports out-of-office and auto-decline", func(t *testing.T) {
+		availability := map[string]Availability{
+			"alice": {Absences: []Absence{{
+				ID:    "ooo-1",
+				Start: mustParseTime(t, "2024-03-01T00:00:00+09:00"),
+				End:   mustParseTime(t, "2024-03-02T00:00:00+09:00"),
+			}}},
+			"bob": {WorkingHours: weekdays[:1], Absences: []Absence{{
+				ID:          "ooo-2",
+				Start:       mustParseTime(t, "2024-03-01T00:00:00+09:00"),
+				End:         mustParseTime(t, "2024-03-08T00:00:00+09:00"),
+				AutoDecline: true,
+			}}},
+		}
+		candidate := Schedule{
+			Participants: []string{"alice", "bob", "carol"},
+			Start:        mustParseTime(t, "2024-03-01T10:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-01T11:00:00+09:00"),
+		}
+
+		conflicts := DetectAvailabilityConflicts(candidate, availability)
+		if len(conflicts) != 2 {
+			t.Fatalf("expected two conflicts, got %#v", conflicts)
+		}
+		if conflicts[0].Type != ConflictTypeOutOfOffice || conflicts[0].AbsenceID != "ooo-1" {
+			t.Fatalf("expected out-of-office conflict for alice, got %#v", conflicts[0])
+		}
+		if conflicts[1].Type != ConflictTypeAutoDeclined || conflicts[1].Participant != "bob" {
+			t.Fatalf("expected auto-decline for bob, got %#v", conflicts[1])
+		}
+	})
+
+	t.Run("ignores transparent schedules", func(t *testing.T) {
+		availability := map[string]Availability{
+			"alice": {Location: jst, WorkingHours: weekdays},
+		}
+		candidate := Schedule{
+			Participants: []string{"alice"},
+			Start:        mustParseTime(t, "2024-03-02T00:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-03T00:00:00+09:00"),
+			Transparent:  true,
+		}
+		if conflicts := DetectAvailabilityConflicts(candidate, availability); len(conflicts) != 0 {
+			t.Fatalf("expected no conflicts for transparent schedule, got %#v", conflicts)
+		}
+	})
+}
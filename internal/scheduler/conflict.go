@@ -1,6 +1,9 @@
 package scheduler
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // Schedule represents a scheduled event in the enterprise scheduler domain.
 type Schedule struct {
@@ -9,6 +12,9 @@ type Schedule struct {
 	RoomID       *string
 	Start        time.Time
 	End          time.Time
+	// Transparent schedules (such as all-day events not marked busy) do not
+	// block their participants; room bookings are still enforced.
+	Transparent bool
 }
 
 // ConflictType describes the type of conflict detected between schedules.
@@ -19,6 +25,12 @@ const (
 	ConflictTypeParticipant ConflictType = "participant"
 	// ConflictTypeRoom indicates a room is double-booked.
 	ConflictTypeRoom ConflictType = "room"
+	// ConflictTypeOutsideWorkingHours indicates the schedule falls outside a participant's working hours.
+	ConflictTypeOutsideWorkingHours ConflictType = "outside_working_hours"
+	// ConflictTypeOutOfOffice indicates the schedule overlaps a participant's out-of-office period.
+	ConflictTypeOutOfOffice ConflictType = "out_of_office"
+	// ConflictTypeAutoDeclined indicates a participant declined automatically because they are out of office.
+	ConflictTypeAutoDeclined ConflictType = "auto_declined"
 )
 
 // Conflict details an overlapping schedule relation that callers can present to users.
@@ -27,6 +39,8 @@ type Conflict struct {
 	Type           ConflictType
 	Participant    string
 	RoomID         *string
+	// AbsenceID identifies the out-of-office period behind availability conflicts.
+	AbsenceID string
 }
 
 // DetectConflicts identifies conflicts for the candidate schedule against existing ones.
@@ -53,11 +67,99 @@ func DetectConflicts(existing []Schedule, candidate Schedule) []Conflict {
 	return conflicts
 }
 
+// DetectConflictsAmong reports the conflicts between every pair of overlapping
+// schedules. The result equals calling DetectConflicts(schedules[i+1:],
+// schedules[i]) for each i in turn, but schedules are grouped by participant and
+// room and each group is swept in start order, so only schedules that share a
+// resource and overlap are compared: O(n log n + k) for k conflicting pairs
+// rather than O(n²).
+func DetectConflictsAmong(schedules []Schedule) []Conflict {
+	conflicts := make([]Conflict, 0)
+	if len(schedules) < 2 {
+		return conflicts
+	}
+
+	// Transparent schedules never block participants, so they only join their
+	// room's group.
+	groups := make(map[string][]int)
+	for i, sched := range schedules {
+		if !sched.Transparent {
+			for _, participant := range sched.Participants {
+				key := "participant:" + participant
+				if members := groups[key]; len(members) == 0 || members[len(members)-1] != i {
+					groups[key] = append(members, i)
+				}
+			}
+		}
+		if sched.RoomID != nil {
+			key := "room:" + *sched.RoomID
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	type pair struct{ candidate, existing int }
+	seen := make(map[pair]struct{})
+	var pairs []pair
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(a, b int) bool {
+			return schedules[members[a]].Start.Before(schedules[members[b]].Start)
+		})
+		active := make([]int, 0)
+		for _, current := range members {
+			// Schedules that ended by this start cannot overlap it or any later one
+			kept := active[:0]
+			for _, other := range active {
+				if schedules[other].End.After(schedules[current].Start) {
+					kept = append(kept, other)
+				}
+			}
+			active = kept
+
+			for _, other := range active {
+				if !overlaps(schedules[other], schedules[current]) {
+					continue
+				}
+				p := pair{candidate: min(other, current), existing: max(other, current)}
+				if _, ok := seen[p]; !ok {
+					seen[p] = struct{}{}
+					pairs = append(pairs, p)
+				}
+			}
+			active = append(active, current)
+		}
+	}
+
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a].candidate != pairs[b].candidate {
+			return pairs[a].candidate < pairs[b].candidate
+		}
+		return pairs[a].existing < pairs[b].existing
+	})
+
+	for _, p := range pairs {
+		candidate, existing := schedules[p.candidate], schedules[p.existing]
+		if candidate.ID != "" && existing.ID == candidate.ID {
+			continue
+		}
+		conflicts = append(conflicts, detectParticipantConflicts(existing, candidate)...)
+		if roomConflict := detectRoomConflict(existing, candidate); roomConflict != nil {
+			conflicts = append(conflicts, *roomConflict)
+		}
+	}
+	return conflicts
+}
+
 func overlaps(a, b Schedule) bool {
 	return a.Start.Before(b.End) && b.Start.Before(a.End)
 }
 
 func detectParticipantConflicts(existing Schedule, candidate Schedule) []Conflict {
+	if existing.Transparent || candidate.Transparent {
+		return nil
+	}
 	if len(existing.Participants) == 0 || len(candidate.Participants) == 0 {
 		return nil
 	}
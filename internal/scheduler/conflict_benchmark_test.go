@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// benchmarkSchedules lays out n one-hour schedules over working days for 1,000
+// people and 200 rooms, roughly what a large tenant keeps on its calendar.
+func benchmarkSchedules(n int) []Schedule {
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	schedules := make([]Schedule, n)
+	for i := range schedules {
+		day := i / 400
+		slot := i % 8
+		start := base.AddDate(0, 0, day).Add(time.Duration(slot) * time.Hour)
+		room := fmt.Sprintf("room-%d", i%200)
+		schedules[i] = Schedule{
+			ID:           fmt.Sprintf("schedule-%d", i),
+			Participants: []string{fmt.Sprintf("user-%d", i%1000), fmt.Sprintf("user-%d", (i*7)%1000)},
+			RoomID:       &room,
+			Start:        start,
+			End:          start.Add(time.Hour),
+		}
+	}
+	return schedules
+}
+
+func BenchmarkDetectConflictsAmong(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		schedules := benchmarkSchedules(n)
+		b.Run(fmt.Sprintf("schedules=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				DetectConflictsAmong(schedules)
+			}
+		})
+	}
+}
+
+// BenchmarkDetectConflictsPairwise is the quadratic baseline that
+// DetectConflictsAmong replaces; 100k schedules are left out as it takes minutes.
+func BenchmarkDetectConflictsPairwise(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		schedules := benchmarkSchedules(n)
+		b.Run(fmt.Sprintf("schedules=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := range schedules {
+					DetectConflicts(schedules[j+1:], schedules[j])
+				}
+			}
+		})
+	}
+}
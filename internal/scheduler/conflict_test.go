@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"math/rand"
 	"reflect"
 	"testing"
 	"time"
@@ -93,6 +95,33 @@ func TestDetectConflicts(t *testing.T) {
 		}
 	})
 
+	t.Run("transparent schedules do not block participants but still hold rooms", func(t *testing.T) {
+		roomID := "room-offsite"
+		existing := []Schedule{
+			{
+				ID:           "existing-all-day",
+				Participants: []string{"alice"},
+				RoomID:       &roomID,
+				Start:        mustParseTime(t, "2024-03-01T00:00:00+09:00"),
+				End:          mustParseTime(t, "2024-03-02T00:00:00+09:00"),
+				Transparent:  true,
+			},
+		}
+
+		candidate := Schedule{
+			ID:           "candidate-meeting",
+			Participants: []string{"alice"},
+			RoomID:       &roomID,
+			Start:        mustParseTime(t, "2024-03-01T10:00:00+09:00"),
+			End:          mustParseTime(t, "2024-03-01T11:00:00+09:00"),
+		}
+
+		conflicts := DetectConflicts(existing, candidate)
+		if len(conflicts) != 1 || conflicts[0].Type != ConflictTypeRoom {
+			t.Fatalf("expected only a room conflict, got %#v", conflicts)
+		}
+	})
+
 	t.Run("ignores candidate schedule when IDs match", func(t *testing.T) {
 		scheduleID := "shared-id"
 		existing := []Schedule{
@@ -158,6 +187,55 @@ func TestDetectConflicts(t *testing.T) {
 	})
 }
 
+func TestDetectConflictsAmong(t *testing.T) {
+	t.Run("matches pairwise detection", func(t *testing.T) {
+		rooms := []string{"room-1", "room-2"}
+		people := []string{"alice", "bob", "carol", "dave"}
+		base := mustParseTime(t, "2024-03-04T09:00:00+09:00")
+		rng := rand.New(rand.NewSource(7))
+
+		schedules := make([]Schedule, 200)
+		for i := range schedules {
+			start := base.Add(time.Duration(rng.Intn(48)) * 30 * time.Minute)
+			sched := Schedule{
+				ID:           fmt.Sprintf("schedule-%d", i),
+				Participants: []string{people[rng.Intn(len(people))], people[rng.Intn(len(people))]},
+				Start:        start,
+				End:          start.Add(time.Duration(rng.Intn(4)) * 30 * time.Minute),
+				Transparent:  rng.Intn(10) == 0,
+			}
+			if rng.Intn(2) == 0 {
+				sched.RoomID = &rooms[rng.Intn(len(rooms))]
+			}
+			schedules[i] = sched
+		}
+
+		expect := make([]Conflict, 0)
+		for i := range schedules {
+			expect = append(expect, DetectConflicts(schedules[i+1:], schedules[i])...)
+		}
+
+		got := DetectConflictsAmong(schedules)
+		if len(expect) == 0 {
+			t.Fatal("expected the generated schedules to overlap")
+		}
+		if !reflect.DeepEqual(got, expect) {
+			t.Fatalf("expected %d pairwise conflicts, got %d", len(expect), len(got))
+		}
+	})
+
+	t.Run("schedules that only touch do not conflict", func(t *testing.T) {
+		schedules := []Schedule{
+			{ID: "later", Participants: []string{"alice"}, Start: mustParseTime(t, "2024-03-01T10:00:00+09:00"), End: mustParseTime(t, "2024-03-01T11:00:00+09:00")},
+			{ID: "earlier", Participants: []string{"alice"}, Start: mustParseTime(t, "2024-03-01T09:00:00+09:00"), End: mustParseTime(t, "2024-03-01T10:00:00+09:00")},
+		}
+
+		if got := DetectConflictsAmong(schedules); len(got) != 0 {
+			t.Fatalf("expected no conflicts, got %#v", got)
+		}
+	})
+}
+
 func mustParseTime(t *testing.T, value string) time.Time {
 	t.Helper()
 
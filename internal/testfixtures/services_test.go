@@ -32,6 +32,10 @@ func (c *capturingUserRepo) ListUsers(ctx context.Context) ([]application.User,
 	return nil, nil
 }
 
+func (c *capturingUserRepo) ListUsersPage(ctx context.Context, filter application.UserRepositoryFilter) ([]application.User, error) {
+	return nil, nil
+}
+
 func TestServiceFactoryNewUserService(t *testing.T) {
 	factory := NewServiceFactory()
 	repo := &capturingUserRepo{}